@@ -2,6 +2,7 @@ package activity
 
 import (
 	"context"
+	"time"
 
 	"go.temporal.io/sdk/internal"
 	"go.temporal.io/sdk/internal/common/metrics"
@@ -17,6 +18,26 @@ type (
 
 	// RegisterOptions consists of options for registering an activity.
 	RegisterOptions = internal.RegisterActivityOptions
+
+	// WorkerStopReason is why a worker is stopping.
+	//
+	// NOTE: Experimental
+	WorkerStopReason = internal.WorkerStopReason
+
+	// WorkerStopInfo describes why and until when a worker is stopping.
+	//
+	// NOTE: Experimental
+	WorkerStopInfo = internal.WorkerStopInfo
+)
+
+const (
+	// WorkerStopReasonShutdown indicates the worker was stopped with Stop, or by the interrupt channel of Run.
+	WorkerStopReasonShutdown = internal.WorkerStopReasonShutdown
+	// WorkerStopReasonFatalError indicates the worker is stopping because of a fatal error, such as a poll error
+	// that cannot be retried.
+	WorkerStopReasonFatalError = internal.WorkerStopReasonFatalError
+	// WorkerStopReasonDeploymentDrain indicates the worker is stopping because its deployment version is drained.
+	WorkerStopReasonDeploymentDrain = internal.WorkerStopReasonDeploymentDrain
 )
 
 // ErrResultPending is returned from activity's implementation to indicate the activity is not completed when the
@@ -46,6 +67,15 @@ func GetMetricsHandler(ctx context.Context) metrics.Handler {
 	return internal.GetActivityMetricsHandler(ctx)
 }
 
+// Now returns the current time for the activity. It is the wall clock time, except for activities executed by the test
+// environments, where it is the time of the environment's clock, including the time skipped by the workflow test
+// environment. Use it instead of time.Now to test activities depending on time deterministically.
+//
+// NOTE: Experimental
+func Now(ctx context.Context) time.Time {
+	return internal.GetActivityNow(ctx)
+}
+
 // RecordHeartbeat sends a heartbeat for the currently executing activity.
 // If the activity is either canceled or the workflow/activity doesn't exist, then we would cancel
 // the context with error [context.Canceled]. The [context.Cause] will be set based on the reason
@@ -70,6 +100,30 @@ func RecordHeartbeat(ctx context.Context, details ...interface{}) {
 	internal.RecordActivityHeartbeat(ctx, details...)
 }
 
+// RecordHeartbeatImmediate sends a heartbeat for the currently executing activity like [RecordHeartbeat], but does not
+// wait for the throttling interval derived from the heartbeat timeout to elapse. Use it for progress updates that must
+// reach the server right away, such as a checkpoint before a long blocking call. Calling it frequently sends a request
+// to the server on every call.
+//
+// Regardless of which function was used, the last heartbeat details buffered by throttling are sent before the
+// activity result is reported, so the last progress update is not lost when the activity returns or panics.
+//
+// NOTE: Experimental
+func RecordHeartbeatImmediate(ctx context.Context, details ...interface{}) {
+	internal.RecordActivityHeartbeatImmediate(ctx, details...)
+}
+
+// RecordProgress records a short summary of the progress of the current activity. If the activity was scheduled with
+// [go.temporal.io/sdk/workflow.ActivityOptions.Progress], the summary is sent to the workflow, which upserts it into its
+// search attribute and memo. Summaries are sent at most once per interval of the progress options, the latest one
+// replacing the others recorded during the interval. It does nothing for activities scheduled without progress options
+// and for local activities.
+//
+// NOTE: Experimental
+func RecordProgress(ctx context.Context, summary string) {
+	internal.RecordActivityProgress(ctx, summary)
+}
+
 // HasHeartbeatDetails checks if there are heartbeat details from the last attempt.
 func HasHeartbeatDetails(ctx context.Context) bool {
 	return internal.HasHeartbeatDetails(ctx)
@@ -96,14 +150,24 @@ func GetWorkerStopChannel(ctx context.Context) <-chan struct{} {
 	return internal.GetWorkerStopChannel(ctx)
 }
 
+// GetWorkerStopInfo returns why and until when the worker is stopping, and false if it is not stopping. Use it once
+// the channel returned by GetWorkerStopChannel is closed, to choose between checkpointing and aborting quickly.
+//
+// NOTE: Experimental
+func GetWorkerStopInfo(ctx context.Context) (WorkerStopInfo, bool) {
+	return internal.GetWorkerStopInfo(ctx)
+}
+
 // IsActivity checks if the context is an activity context from a normal or local activity.
 func IsActivity(ctx context.Context) bool {
 	return internal.IsActivity(ctx)
 }
 
 // GetClient returns a client that can be used to interact with the Temporal
-// service from an activity. Return type internal.Client is the same underlying
-// type as client.Client.
+// service from an activity. It is the client of the worker running the
+// activity, so it uses the namespace and the interceptors of the worker, and
+// must not be closed by the activity. Return type internal.Client is the same
+// underlying type as client.Client.
 func GetClient(ctx context.Context) internal.Client {
 	return internal.GetClient(ctx)
 }
@@ -191,6 +191,42 @@ const (
 	WorkflowUpdateStageCompleted = internal.WorkflowUpdateStageCompleted
 )
 
+// ExecutionTraceNodeKind is the kind of operation of an ExecutionTraceNode.
+//
+// NOTE: Experimental
+type ExecutionTraceNodeKind = internal.ExecutionTraceNodeKind
+
+const (
+	// ExecutionTraceNodeKindWorkflow is a workflow execution, the root of a trace or a child workflow.
+	ExecutionTraceNodeKindWorkflow = internal.ExecutionTraceNodeKindWorkflow
+	// ExecutionTraceNodeKindActivity is an activity.
+	ExecutionTraceNodeKindActivity = internal.ExecutionTraceNodeKindActivity
+	// ExecutionTraceNodeKindNexusOperation is a Nexus operation.
+	ExecutionTraceNodeKindNexusOperation = internal.ExecutionTraceNodeKindNexusOperation
+)
+
+// ExecutionTraceNodeStatus is the status of the operation of an ExecutionTraceNode.
+//
+// NOTE: Experimental
+type ExecutionTraceNodeStatus = internal.ExecutionTraceNodeStatus
+
+const (
+	// ExecutionTraceNodeStatusRunning is an operation that has not completed yet.
+	ExecutionTraceNodeStatusRunning = internal.ExecutionTraceNodeStatusRunning
+	// ExecutionTraceNodeStatusCompleted is an operation that completed successfully.
+	ExecutionTraceNodeStatusCompleted = internal.ExecutionTraceNodeStatusCompleted
+	// ExecutionTraceNodeStatusFailed is an operation that failed, or that could not be started.
+	ExecutionTraceNodeStatusFailed = internal.ExecutionTraceNodeStatusFailed
+	// ExecutionTraceNodeStatusCanceled is an operation that was canceled.
+	ExecutionTraceNodeStatusCanceled = internal.ExecutionTraceNodeStatusCanceled
+	// ExecutionTraceNodeStatusTimedOut is an operation that timed out.
+	ExecutionTraceNodeStatusTimedOut = internal.ExecutionTraceNodeStatusTimedOut
+	// ExecutionTraceNodeStatusTerminated is a workflow that was terminated.
+	ExecutionTraceNodeStatusTerminated = internal.ExecutionTraceNodeStatusTerminated
+	// ExecutionTraceNodeStatusContinuedAsNew is a workflow run that continued as new.
+	ExecutionTraceNodeStatusContinuedAsNew = internal.ExecutionTraceNodeStatusContinuedAsNew
+)
+
 const (
 	// DefaultHostPort is the host:port which is used if not passed with options.
 	DefaultHostPort = internal.LocalHostPort
@@ -202,10 +238,31 @@ const (
 	// stack of the workflow. The result will be a string encoded in the converter.EncodedValue.
 	QueryTypeStackTrace string = internal.QueryTypeStackTrace
 
+	// QueryTypeStackTraceJSON is the build in query type for Client.QueryWorkflow() call. Use this query type to get the
+	// call stack of the workflow in a structured form, for tooling that renders workflow state. The result will be a
+	// WorkflowStackTrace encoded in the converter.EncodedValue.
+	//
+	// NOTE: Experimental
+	QueryTypeStackTraceJSON string = internal.QueryTypeStackTraceJSON
+
 	// QueryTypeOpenSessions is the build in query type for Client.QueryWorkflow() call. Use this query type to get all open
 	// sessions in the workflow. The result will be a list of SessionInfo encoded in the converter.EncodedValue.
 	QueryTypeOpenSessions string = internal.QueryTypeOpenSessions
 
+	// QueryTypeHandlers is the build in query type for Client.QueryWorkflow() call. Use this query type to get the
+	// signal, query, and update handlers registered on the workflow with their descriptions. The result will be a
+	// WorkflowHandlers encoded in the converter.EncodedValue.
+	//
+	// NOTE: Experimental
+	QueryTypeHandlers string = internal.QueryTypeHandlers
+
+	// QueryTypeStateSnapshot is the build in query type for Client.QueryWorkflow() call. Use this query type to get
+	// the state snapshot registered by the workflow with workflow.RegisterStateSnapshot. The result will be the value
+	// returned by the snapshot function encoded in the converter.EncodedValue.
+	//
+	// NOTE: Experimental
+	QueryTypeStateSnapshot string = internal.QueryTypeStateSnapshot
+
 	// UnversionedBuildID is a stand-in for a Build Id for unversioned Workers.
 	// WARNING: Worker versioning is currently experimental
 	UnversionedBuildID string = internal.UnversionedBuildID
@@ -218,6 +275,12 @@ type (
 	// ConnectionOptions are optional parameters that can be specified in ClientOptions
 	ConnectionOptions = internal.ConnectionOptions
 
+	// CallTimeoutOptions are the default timeouts of the calls to the Temporal service by class of API, see
+	// ConnectionOptions.CallTimeouts.
+	//
+	// NOTE: Experimental
+	CallTimeoutOptions = internal.CallTimeoutOptions
+
 	// Credentials are optional credentials that can be specified in ClientOptions.
 	Credentials = internal.Credentials
 
@@ -245,9 +308,128 @@ type (
 	// QueryWorkflowWithOptionsResponse defines the response to QueryWorkflowWithOptions.
 	QueryWorkflowWithOptionsResponse = internal.QueryWorkflowWithOptionsResponse
 
+	// WorkflowHandlers is the result of the QueryTypeHandlers query, listing the handlers registered on a workflow.
+	//
+	// NOTE: Experimental
+	WorkflowHandlers = internal.WorkflowHandlers
+
+	// WorkflowHandlerInfo describes a signal, query, or update handler of a workflow.
+	//
+	// NOTE: Experimental
+	WorkflowHandlerInfo = internal.WorkflowHandlerInfo
+
+	// WorkflowStackTrace is the result of the QueryTypeStackTraceJSON query, listing the call stacks of the
+	// coroutines of a workflow.
+	//
+	// NOTE: Experimental
+	WorkflowStackTrace = internal.WorkflowStackTrace
+
+	// CoroutineStackTrace is the call stack of a workflow coroutine.
+	//
+	// NOTE: Experimental
+	CoroutineStackTrace = internal.CoroutineStackTrace
+
+	// StackFrame is a frame of the call stack of a workflow coroutine.
+	//
+	// NOTE: Experimental
+	StackFrame = internal.StackFrame
+
 	// WorkflowExecutionDescription defines the response to DescribeWorkflow.
 	WorkflowExecutionDescription = internal.WorkflowExecutionDescription
 
+	// WorkflowRunCompletion is the completion of a run awaited with AwaitAnyWorkflow or AwaitAllWorkflows.
+	//
+	// NOTE: Experimental
+	WorkflowRunCompletion = internal.WorkflowRunCompletion
+
+	// HistoryDiffOptions are options for DiffWorkflowHistories.
+	//
+	// NOTE: Experimental
+	HistoryDiffOptions = internal.HistoryDiffOptions
+
+	// HistoryEventDiff is a divergence between two workflow histories, for the events with the same ID.
+	//
+	// NOTE: Experimental
+	HistoryEventDiff = internal.HistoryEventDiff
+
+	// ExecutionTraceOptions are options for GetWorkflowExecutionTrace.
+	//
+	// NOTE: Experimental
+	ExecutionTraceOptions = internal.ExecutionTraceOptions
+
+	// ExecutionTraceNode is an operation in the call tree of a workflow execution: the workflow itself, one of its
+	// activities, child workflows or Nexus operations.
+	//
+	// NOTE: Experimental
+	ExecutionTraceNode = internal.ExecutionTraceNode
+
+	// ArchivalConfig is the archival configuration of a namespace.
+	//
+	// NOTE: Experimental
+	ArchivalConfig = internal.ArchivalConfig
+
+	// ArchivedWorkflowListOptions are the parameters for configuring listing archived workflows.
+	//
+	// NOTE: Experimental
+	ArchivedWorkflowListOptions = internal.ArchivedWorkflowListOptions
+
+	// ArchivedWorkflowListIterator is an iterator for archived workflows.
+	//
+	// NOTE: Experimental
+	ArchivedWorkflowListIterator = internal.ArchivedWorkflowListIterator
+
+	// NamespaceFailoverOptions configures how a client retries calls failing because its namespace is failing over
+	// to another cluster, see ClientOptions.NamespaceFailover.
+	//
+	// NOTE: Experimental
+	NamespaceFailoverOptions = internal.NamespaceFailoverOptions
+
+	// NamespaceFailoverInfo describes a call retried because its namespace is failing over, see
+	// NamespaceFailoverOptions.OnFailover.
+	//
+	// NOTE: Experimental
+	NamespaceFailoverInfo = internal.NamespaceFailoverInfo
+
+	// MultiOptions are options for DialMulti and NewLazyMulti.
+	//
+	// NOTE: Experimental
+	MultiOptions = internal.MultiClientOptions
+
+	// Multi hands out clients of any number of namespaces that share a single connection, each with its own
+	// interceptors, data converter and metrics tags, for processes serving many namespaces.
+	//
+	// NOTE: Experimental
+	Multi = internal.MultiClient
+
+	// TaskTokenCodecOptions are options for NewTaskTokenCodec.
+	//
+	// NOTE: Experimental
+	TaskTokenCodecOptions = internal.TaskTokenCodecOptions
+
+	// TaskTokenCodec wraps activity task tokens before they are handed to third parties for asynchronous
+	// completion, and unwraps and verifies the tokens they hand back.
+	//
+	// NOTE: Experimental
+	TaskTokenCodec = internal.TaskTokenCodec
+
+	// PendingActivityInfo describes an activity of a workflow execution that has not completed yet, see
+	// WorkflowExecutionDescription.PendingActivities.
+	//
+	// NOTE: Experimental
+	PendingActivityInfo = internal.PendingActivityInfo
+
+	// PendingChildWorkflowInfo describes a child workflow of a workflow execution that has not completed yet, see
+	// WorkflowExecutionDescription.PendingChildren.
+	//
+	// NOTE: Experimental
+	PendingChildWorkflowInfo = internal.PendingChildWorkflowInfo
+
+	// PendingNexusOperationInfo describes a Nexus operation of a workflow execution that has not completed yet, see
+	// WorkflowExecutionDescription.PendingNexusOperations.
+	//
+	// NOTE: Experimental
+	PendingNexusOperationInfo = internal.PendingNexusOperationInfo
+
 	// WorkflowExecutionMetadata defines common workflow information across multiple calls.
 	WorkflowExecutionMetadata = internal.WorkflowExecutionMetadata
 
@@ -335,6 +517,26 @@ type (
 	// ScheduleUnpauseOptions configure the parameters for unpausing a schedule.
 	ScheduleUnpauseOptions = internal.ScheduleUnpauseOptions
 
+	// SchedulePauseManyOptions configure the parameters for pausing all schedules matching a query.
+	//
+	// NOTE: Experimental
+	SchedulePauseManyOptions = internal.SchedulePauseManyOptions
+
+	// ScheduleUnpauseManyOptions configure the parameters for unpausing all schedules matching a query.
+	//
+	// NOTE: Experimental
+	ScheduleUnpauseManyOptions = internal.ScheduleUnpauseManyOptions
+
+	// ScheduleBulkProgress reports the progress of a ScheduleClient.PauseMany or ScheduleClient.UnpauseMany call.
+	//
+	// NOTE: Experimental
+	ScheduleBulkProgress = internal.ScheduleBulkProgress
+
+	// ScheduleBulkResult is the result of a ScheduleClient.PauseMany or ScheduleClient.UnpauseMany call.
+	//
+	// NOTE: Experimental
+	ScheduleBulkResult = internal.ScheduleBulkResult
+
 	// ScheduleBackfillOptions configure the parameters for backfilling a schedule.
 	ScheduleBackfillOptions = internal.ScheduleBackfillOptions
 
@@ -492,6 +694,46 @@ type (
 	// NOTE: Experimental
 	WorkerDeploymentClient = internal.WorkerDeploymentClient
 
+	// NexusEndpointSpec describes a Nexus endpoint.
+	//
+	// NOTE: Experimental
+	NexusEndpointSpec = internal.NexusEndpointSpec
+
+	// NexusEndpoint is a Nexus endpoint registered on the server.
+	//
+	// NOTE: Experimental
+	NexusEndpoint = internal.NexusEndpoint
+
+	// NexusEndpointCreateOptions provides options for [NexusEndpointClient.Create].
+	//
+	// NOTE: Experimental
+	NexusEndpointCreateOptions = internal.NexusEndpointCreateOptions
+
+	// NexusEndpointUpdateOptions provides options for [NexusEndpointClient.Update].
+	//
+	// NOTE: Experimental
+	NexusEndpointUpdateOptions = internal.NexusEndpointUpdateOptions
+
+	// NexusEndpointDeleteOptions provides options for [NexusEndpointClient.Delete].
+	//
+	// NOTE: Experimental
+	NexusEndpointDeleteOptions = internal.NexusEndpointDeleteOptions
+
+	// NexusEndpointListOptions provides options for [NexusEndpointClient.List].
+	//
+	// NOTE: Experimental
+	NexusEndpointListOptions = internal.NexusEndpointListOptions
+
+	// NexusEndpointListIterator is an iterator for Nexus endpoints.
+	//
+	// NOTE: Experimental
+	NexusEndpointListIterator = internal.NexusEndpointListIterator
+
+	// NexusEndpointClient is the client that manages Nexus endpoints.
+	//
+	// NOTE: Experimental
+	NexusEndpointClient = internal.NexusEndpointClient
+
 	// Deployment identifies a set of workers. This identifier combines
 	// the deployment series name with their Build ID.
 	//
@@ -578,6 +820,22 @@ type (
 	// Deprecated: Use [WorkerDeploymentClient]
 	DeploymentClient = internal.DeploymentClient
 
+	// ResetWorkflowOptions is a request for [client.Client.ResetWorkflow].
+	//
+	// NOTE: Experimental
+	ResetWorkflowOptions = internal.ResetWorkflowOptions
+
+	// ResetReapplyOptions selects the events after the reset point of [client.Client.ResetWorkflow] that are
+	// reapplied to the new run.
+	//
+	// NOTE: Experimental
+	ResetReapplyOptions = internal.ResetReapplyOptions
+
+	// ResetWorkflowResult is the result of [client.Client.ResetWorkflow].
+	//
+	// NOTE: Experimental
+	ResetWorkflowResult = internal.ResetWorkflowResult
+
 	// UpdateWorkflowExecutionOptionsRequest is a request for [client.Client.UpdateWorkflowExecutionOptions].
 	//
 	// NOTE: Experimental
@@ -821,6 +1079,13 @@ type (
 	// Note, this is not related to any general concept of timing out or cancelling a running update, this is only related to the client call itself.
 	WorkflowUpdateServiceTimeoutOrCanceledError = internal.WorkflowUpdateServiceTimeoutOrCanceledError
 
+	// WorkflowUpdateRejectedError is the error returned by WorkflowUpdateHandle.Get for updates rejected by a Go
+	// workflow, either by the validator of the update or because the workflow has no handler for it. Its Code method
+	// returns the type of the application error returned by the validator.
+	//
+	// NOTE: Experimental
+	WorkflowUpdateRejectedError = internal.WorkflowUpdateRejectedError
+
 	// Client is the client for starting and getting information about a workflow executions as well as
 	// completing activities asynchronously.
 	Client interface {
@@ -889,6 +1154,7 @@ type (
 		//  - workflowID, signalName, signalArg are same as SignalWorkflow's parameters
 		//  - options, workflow, workflowArgs are same as StartWorkflow's parameters
 		//  - the workflowID parameter is used instead of options.ID. If the latter is present, it must match the workflowID.
+		// The returned WorkflowRun.GetStarted tells whether the workflow was started or was already running.
 		//
 		// NOTE: options.WorkflowIDReusePolicy is default to AllowDuplicate in this API.
 		// The errors it can return:
@@ -921,6 +1187,8 @@ type (
 		// workflowID is required, other parameters are optional.
 		//  - workflow ID of the workflow.
 		//  - runID can be default(empty string). if empty string then it will pick the running execution of that workflow ID.
+		//  - details are encoded with the DataConverter and can be read with temporal.TerminatedError.Details from
+		//    the error returned by WorkflowRun.Get.
 		// The errors it can return:
 		//  - serviceerror.NotFound
 		//  - serviceerror.InvalidArgument
@@ -935,6 +1203,9 @@ type (
 		//    of HistoryEventIterator, if isLongPoll == true, then iterator will do long poll, tracking new history event, i.e. the iteration
 		//   will not be finished until workflow is finished; if isLongPoll == false, then iterator will only return current history events.
 		//  - whether return all history events or just the last event, which contains the workflow execution end result
+		// History is fetched one page at a time while iterating, and the iterator releases events once returned, so
+		// very large histories can be processed with bounded memory by not retaining the events. The page size can be
+		// capped with converter.PayloadCodecGRPCClientInterceptorOptions.MaxHistoryPageSize.
 		// Example:-
 		//  To iterate all events,
 		//     iter := GetWorkflowHistory(ctx, workflowID, runID, isLongPoll, filterType)
@@ -1150,6 +1421,14 @@ type (
 		// RequestId is used to deduplicate requests. It will be autogenerated if not set.
 		ResetWorkflowExecution(ctx context.Context, request *workflowservice.ResetWorkflowExecutionRequest) (*workflowservice.ResetWorkflowExecutionResponse, error)
 
+		// ResetWorkflow resets an existing workflow execution to a workflow task, terminating the current run and
+		// starting a new one. The signals and updates after the reset point are reapplied to the new run unless
+		// excluded by the options. With DryRun, it only returns the events that would be reapplied, after checking
+		// the reset point, so that resets can be reviewed before being applied.
+		//
+		// NOTE: Experimental
+		ResetWorkflow(ctx context.Context, options ResetWorkflowOptions) (ResetWorkflowResult, error)
+
 		// UpdateWorkerBuildIdCompatibility
 		// Allows you to update the worker-build-id based version sets for a particular task queue. This is used in
 		// conjunction with workers who specify their build id and thus opt into the feature.
@@ -1250,6 +1529,11 @@ type (
 		// NOTE: Experimental
 		WorkerDeploymentClient() WorkerDeploymentClient
 
+		// NexusEndpointClient creates a new Nexus endpoint client with the same gRPC connection as this client.
+		//
+		// NOTE: Experimental
+		NexusEndpointClient() NexusEndpointClient
+
 		// Close client and clean up underlying resources.
 		//
 		// If this client was created via NewClientFromExisting or this client has
@@ -1313,6 +1597,13 @@ type MetricsGauge = metrics.Gauge
 // MetricsTimer records time durations.
 type MetricsTimer = metrics.Timer
 
+// MetricsHistogramHandler is an optional interface a [MetricsHandler] may
+// implement to support histograms of values that are not durations.
+type MetricsHistogramHandler = metrics.HistogramHandler
+
+// MetricsHistogram is a histogram of values that are not durations.
+type MetricsHistogram = metrics.Histogram
+
 // MetricsNopHandler is a noop handler that does nothing with the metrics.
 var MetricsNopHandler = metrics.NopHandler
 
@@ -1414,6 +1705,33 @@ func NewValues(data *commonpb.Payloads) converter.EncodedValues {
 	return internal.NewValues(data)
 }
 
+// AwaitAnyWorkflow waits for the first of the runs to complete and returns its completion. Runs are awaited
+// concurrently, and the waits on the other runs are canceled once one completes. It returns the error of the context
+// if it is done before any run completes.
+//
+// NOTE: Experimental
+func AwaitAnyWorkflow(ctx context.Context, runs ...WorkflowRun) (WorkflowRunCompletion, error) {
+	return internal.AwaitAnyWorkflow(ctx, runs...)
+}
+
+// AwaitAllWorkflows waits for the runs to complete concurrently, and sends the completion of each run to the returned
+// channel as soon as it completes. The channel is closed once all runs have completed, or once the context is done,
+// in which case the runs still running are not sent:
+//
+//	for completion := range client.AwaitAllWorkflows(ctx, runs...) {
+//		if completion.Err != nil {
+//			// Handle the failure of runs[completion.Index].
+//			continue
+//		}
+//		var result Result
+//		err := completion.Run.Get(ctx, &result)
+//	}
+//
+// NOTE: Experimental
+func AwaitAllWorkflows(ctx context.Context, runs ...WorkflowRun) <-chan WorkflowRunCompletion {
+	return internal.AwaitAllWorkflows(ctx, runs...)
+}
+
 // HistoryJSONOptions are options for HistoryFromJSON.
 type HistoryJSONOptions struct {
 	// LastEventID, if set, will only load history up to this ID (inclusive).
@@ -1426,6 +1744,83 @@ func HistoryFromJSON(r io.Reader, options HistoryJSONOptions) (*historypb.Histor
 	return internal.HistoryFromJSON(r, options.LastEventID)
 }
 
+// DiffWorkflowHistories compares two workflow histories event by event, matching events by ID, and returns the
+// divergent events ordered by ID. This helps debugging resets, replays and replication, for example by diffing the
+// history of a workflow with the history of its reset run, or with its history in another cluster.
+//
+// NOTE: Experimental
+func DiffWorkflowHistories(left, right *historypb.History, options HistoryDiffOptions) []HistoryEventDiff {
+	return internal.DiffWorkflowHistories(left, right, options)
+}
+
+// GetWorkflowExecutionTrace walks the history of a workflow execution, and the histories of its child workflows and of
+// the workflows backing its Nexus operations in the namespace of the client, and returns its call tree. Each node
+// carries the timing, status, failure and number of attempts of an operation, so that executions can be visualized
+// without parsing their histories. The run ID is optional. Default is the current run.
+//
+// NOTE: Experimental
+func GetWorkflowExecutionTrace(
+	ctx context.Context,
+	c Client,
+	workflowID string,
+	runID string,
+	options ExecutionTraceOptions,
+) (*ExecutionTraceNode, error) {
+	return internal.GetWorkflowExecutionTrace(ctx, c, workflowID, runID, options)
+}
+
+// GetArchivalConfig returns the archival configuration of the namespace of the client. Like the other archival helpers,
+// it returns an error for clients not created with Dial or NewClient, such as mocks, as their namespace is unknown.
+//
+// NOTE: Experimental
+func GetArchivalConfig(ctx context.Context, c Client) (ArchivalConfig, error) {
+	return internal.GetArchivalConfig(ctx, c)
+}
+
+// ListArchivedWorkflows returns an iterator over the archived visibility records of the namespace of the client
+// matching the query, whichever archiver they are archived with. An error is returned if visibility archival is not
+// enabled for the namespace.
+//
+// NOTE: Experimental
+func ListArchivedWorkflows(ctx context.Context, c Client, options ArchivedWorkflowListOptions) (ArchivedWorkflowListIterator, error) {
+	return internal.ListArchivedWorkflows(ctx, c, options)
+}
+
+// GetArchivedWorkflowHistory returns the history of a workflow run of the namespace of the client. It is read from the
+// history archiver by the server if the run was deleted after its retention period, whichever archiver it is archived
+// with. The run ID is required, as archived histories are identified by run. An error is returned if history archival
+// is not enabled for the namespace.
+//
+// NOTE: Experimental
+func GetArchivedWorkflowHistory(ctx context.Context, c Client, workflowID string, runID string) (*historypb.History, error) {
+	return internal.GetArchivedWorkflowHistory(ctx, c, workflowID, runID)
+}
+
+// DialMulti creates a Multi client, connecting to the server eagerly. The clients of the namespaces are created when
+// first requested with Multi.Namespace and share the connection, which is closed with Multi.Close.
+//
+// NOTE: Experimental
+func DialMulti(ctx context.Context, options MultiOptions) (Multi, error) {
+	return internal.DialMultiClient(ctx, options)
+}
+
+// NewLazyMulti creates a Multi client and does not attempt to connect to the server until the first namespace client
+// is requested.
+//
+// NOTE: Experimental
+func NewLazyMulti(options MultiOptions) (Multi, error) {
+	return internal.NewLazyMultiClient(options)
+}
+
+// NewTaskTokenCodec creates a TaskTokenCodec signing tokens with HMAC-SHA256 and, if an encryption key is given,
+// encrypting them with AES-GCM. Use it to hand activity task tokens to external completion endpoints, which unwrap
+// the tokens before calling Client.CompleteActivity.
+//
+// NOTE: Experimental
+func NewTaskTokenCodec(options TaskTokenCodecOptions) (TaskTokenCodec, error) {
+	return internal.NewTaskTokenCodec(options)
+}
+
 // NewAPIKeyStaticCredentials creates credentials that can be provided to
 // ClientOptions to use a fixed API key.
 //
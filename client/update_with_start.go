@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/internal"
+)
+
+// UpdateWithStartWorkflowBuilder builds the options of an update-with-start request, checking on Build that the start
+// and update options can be combined. Create it with [NewUpdateWithStartWorkflowBuilder] and execute it with
+// [ExecuteUpdateWithStartWorkflow]. Setters return the builder so that calls can be chained.
+//
+// NOTE: Experimental
+type UpdateWithStartWorkflowBuilder = internal.UpdateWithStartWorkflowBuilder
+
+// NOTE to maintainers, the interface definitions below are duplicated in the internal package to provide a better UX.
+
+// TypedWorkflowRun is a [WorkflowRun] whose result is of type T.
+//
+// NOTE: Experimental
+type TypedWorkflowRun[T any] interface {
+	WorkflowRun
+
+	// GetResult blocks until the workflow completes and returns its result decoded into T.
+	GetResult(ctx context.Context) (T, error)
+}
+
+// TypedWorkflowUpdateHandle is a [WorkflowUpdateHandle] whose result is of type T.
+//
+// NOTE: Experimental
+type TypedWorkflowUpdateHandle[T any] interface {
+	WorkflowUpdateHandle
+
+	// GetResult blocks on the outcome of the update and returns its result decoded into T.
+	GetResult(ctx context.Context) (T, error)
+}
+
+// NewUpdateWithStartWorkflowBuilder creates an [UpdateWithStartWorkflowBuilder] starting the workflow with the options
+// and arguments if it is not running. The update is sent to the workflow with the ID of the options, and waits for
+// [WorkflowUpdateStageCompleted] unless set otherwise.
+//
+// NOTE: Experimental
+func NewUpdateWithStartWorkflowBuilder(options StartWorkflowOptions, workflow interface{}, args ...interface{}) *UpdateWithStartWorkflowBuilder {
+	return internal.NewUpdateWithStartWorkflowBuilder(options, workflow, args...)
+}
+
+// ExecuteUpdateWithStartWorkflow issues the update-with-start request built by the builder, and returns the run of the
+// workflow, started or already running, along with the handle of the update. The options are checked before any
+// request is made:
+//
+//	builder := client.NewUpdateWithStartWorkflowBuilder(client.StartWorkflowOptions{
+//		ID:        "cart-" + userID,
+//		TaskQueue: "carts",
+//	}, CartWorkflow).UseExisting().Update("add-item", item)
+//	run, update, err := client.ExecuteUpdateWithStartWorkflow[Order, Cart](ctx, c, builder)
+//	if err != nil {
+//		return err
+//	}
+//	cart, err := update.GetResult(ctx)
+//
+// NOTE: Experimental
+func ExecuteUpdateWithStartWorkflow[W, U any](
+	ctx context.Context,
+	c Client,
+	builder *UpdateWithStartWorkflowBuilder,
+) (TypedWorkflowRun[W], TypedWorkflowUpdateHandle[U], error) {
+	return internal.ExecuteUpdateWithStartWorkflow[W, U](ctx, c, builder)
+}
@@ -0,0 +1,213 @@
+// Package awsxray provides AWS X-Ray utilities.
+package awsxray
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-xray-sdk-go/header"
+	"github.com/aws/aws-xray-sdk-go/xray"
+
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/log"
+)
+
+// TraceHeaderKey is the key of the X-Ray trace header in the map a span is
+// serialized to, the name of the HTTP header X-Ray uses.
+const TraceHeaderKey = "X-Amzn-Trace-Id"
+
+// TracerOptions are options provided to NewInterceptor or NewTracer.
+type TracerOptions struct {
+	// Config is the X-Ray recorder configuration segments are created with. If
+	// not set, the global configuration of the X-Ray SDK is used.
+	Config *xray.Config
+
+	// DisableSignalTracing can be set to disable signal tracing.
+	DisableSignalTracing bool
+
+	// DisableQueryTracing can be set to disable query tracing.
+	DisableQueryTracing bool
+
+	// DisableUpdateTracing can be set to disable update tracing.
+	DisableUpdateTracing bool
+
+	// AllowInvalidParentSpans will swallow errors interpreting parent
+	// spans from headers. Useful when migrating from one tracing library
+	// to another, while workflows/activities may be in progress.
+	AllowInvalidParentSpans bool
+
+	// SpanContextKey is the context key used for internal span tracking (not to
+	// be confused with the context key X-Ray uses internally). If not set, this
+	// defaults to an internal key (recommended).
+	SpanContextKey interface{}
+
+	// HeaderKey is the Temporal header field key used to serialize spans. If
+	// empty, this defaults to the one used by all SDKs (recommended).
+	HeaderKey string
+
+	// SpanNamer is a callback to name segments. If not set, segments are named
+	// "${operation}:${name}" (e.g. "RunWorkflow:MyWorkflow").
+	SpanNamer func(options *interceptor.TracerStartSpanOptions) string
+}
+
+type spanContextKey struct{}
+
+const defaultHeaderKey = "_tracer-data"
+
+type tracer struct {
+	interceptor.BaseTracer
+	options *TracerOptions
+	// ctx carries the recorder configuration segments are created with
+	ctx context.Context
+}
+
+// NewTracer creates a tracer with the given options. Most callers should use
+// NewInterceptor instead.
+//
+// Spans started in the process of their parent span are X-Ray subsegments of
+// the parent, others are segments. Spans are propagated through workflow and
+// activity headers as X-Ray trace headers.
+func NewTracer(options TracerOptions) (interceptor.Tracer, error) {
+	if options.SpanContextKey == nil {
+		options.SpanContextKey = spanContextKey{}
+	}
+	if options.HeaderKey == "" {
+		options.HeaderKey = defaultHeaderKey
+	}
+	ctx := context.Background()
+	if options.Config != nil {
+		var err error
+		if ctx, err = xray.ContextWithConfig(ctx, *options.Config); err != nil {
+			return nil, fmt.Errorf("invalid X-Ray config: %w", err)
+		}
+	}
+	return &tracer{options: &options, ctx: ctx}, nil
+}
+
+// NewInterceptor creates an interceptor for setting on client options that
+// implements AWS X-Ray tracing for workflows.
+func NewInterceptor(options TracerOptions) (interceptor.Interceptor, error) {
+	t, err := NewTracer(options)
+	if err != nil {
+		return nil, err
+	}
+	return interceptor.NewTracingInterceptor(t), nil
+}
+
+func (t *tracer) Options() interceptor.TracerOptions {
+	return interceptor.TracerOptions{
+		SpanContextKey:          t.options.SpanContextKey,
+		HeaderKey:               t.options.HeaderKey,
+		DisableSignalTracing:    t.options.DisableSignalTracing,
+		DisableQueryTracing:     t.options.DisableQueryTracing,
+		DisableUpdateTracing:    t.options.DisableUpdateTracing,
+		AllowInvalidParentSpans: t.options.AllowInvalidParentSpans,
+	}
+}
+
+func (t *tracer) SpanName(options *interceptor.TracerStartSpanOptions) string {
+	if t.options.SpanNamer != nil {
+		return t.options.SpanNamer(options)
+	}
+	return t.BaseTracer.SpanName(options)
+}
+
+func (t *tracer) UnmarshalSpan(m map[string]string) (interceptor.TracerSpanRef, error) {
+	value, ok := m[TraceHeaderKey]
+	if !ok {
+		// Nexus headers have lower case keys
+		value, ok = m[strings.ToLower(TraceHeaderKey)]
+	}
+	if !ok {
+		// If there is no span, return nothing, but don't error out. This is
+		// a legitimate place where a span does not exist in the headers
+		return nil, nil
+	}
+	h := header.FromString(value)
+	if h.TraceID == "" {
+		return nil, fmt.Errorf("invalid X-Ray trace header %q", value)
+	}
+	return &tracerSpanRef{Header: h}, nil
+}
+
+func (t *tracer) MarshalSpan(span interceptor.TracerSpan) (map[string]string, error) {
+	segment := span.(*tracerSpan).segment
+	if segment == nil {
+		return nil, nil
+	}
+	return map[string]string{TraceHeaderKey: segment.DownstreamHeader().String()}, nil
+}
+
+func (t *tracer) SpanFromContext(ctx context.Context) interceptor.TracerSpan {
+	segment := xray.GetSegment(ctx)
+	if segment == nil {
+		return nil
+	}
+	return &tracerSpan{ctx: ctx, segment: segment}
+}
+
+func (t *tracer) ContextWithSpan(ctx context.Context, span interceptor.TracerSpan) context.Context {
+	return context.WithValue(ctx, xray.ContextKey, span.(*tracerSpan).segment)
+}
+
+func (t *tracer) StartSpan(opts *interceptor.TracerStartSpanOptions) (interceptor.TracerSpan, error) {
+	name := t.SpanName(opts)
+	var ctx context.Context
+	var segment *xray.Segment
+	switch optParent := opts.Parent.(type) {
+	case nil:
+		ctx, segment = xray.BeginSegment(t.ctx, name)
+	case *tracerSpan:
+		ctx, segment = xray.BeginSubsegment(optParent.ctx, name)
+	case *tracerSpanRef:
+		// The request is only used by the sampling strategy, when the header
+		// does not carry the sampling decision
+		request := &http.Request{URL: &url.URL{Path: name}}
+		ctx, segment = xray.NewSegmentFromHeader(t.ctx, name, request, optParent.Header)
+	default:
+		return nil, fmt.Errorf("unrecognized parent type %T", optParent)
+	}
+	if segment == nil {
+		return nil, errors.New("failed to begin X-Ray subsegment")
+	}
+
+	if !opts.Time.IsZero() {
+		segment.Lock()
+		segment.StartTime = float64(opts.Time.UnixNano()) / float64(time.Second)
+		segment.Unlock()
+	}
+	for k, v := range opts.Tags {
+		if err := segment.AddAnnotation(k, v); err != nil {
+			return nil, err
+		}
+	}
+	return &tracerSpan{ctx: ctx, segment: segment}, nil
+}
+
+func (t *tracer) GetLogger(logger log.Logger, ref interceptor.TracerSpanRef) log.Logger {
+	span, ok := ref.(*tracerSpan)
+	if !ok || span.segment == nil {
+		return logger
+	}
+	return log.With(logger,
+		"TraceID", span.segment.TraceID,
+		"SegmentID", span.segment.ID,
+	)
+}
+
+type tracerSpanRef struct{ *header.Header }
+
+type tracerSpan struct {
+	// ctx carries the segment, to begin its subsegments
+	ctx     context.Context
+	segment *xray.Segment
+}
+
+func (t *tracerSpan) Finish(opts *interceptor.TracerFinishSpanOptions) {
+	t.segment.Close(opts.Error)
+}
@@ -0,0 +1,140 @@
+package awsxray
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-xray-sdk-go/strategy/sampling"
+	"github.com/aws/aws-xray-sdk-go/xray"
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/internal/interceptortest"
+)
+
+type alwaysSample struct{}
+
+func (alwaysSample) ShouldTrace(*sampling.Request) *sampling.Decision {
+	return &sampling.Decision{Sample: true}
+}
+
+type discardEmitter struct{}
+
+func (discardEmitter) Emit(*xray.Segment) {}
+
+func (discardEmitter) RefreshEmitterWithAddress(*net.UDPAddr) {}
+
+func TestSpanPropagation(t *testing.T) {
+	tracer, err := NewTracer(TracerOptions{
+		Config: &xray.Config{Emitter: discardEmitter{}, SamplingStrategy: alwaysSample{}},
+	})
+	require.NoError(t, err)
+
+	testTracer := &testTracer{Tracer: tracer}
+	interceptortest.RunTestWorkflow(t, testTracer)
+	interceptortest.AssertSpanPropagation(t, testTracer)
+}
+
+func TestMarshalSpan(t *testing.T) {
+	tracer, err := NewTracer(TracerOptions{
+		Config: &xray.Config{Emitter: discardEmitter{}, SamplingStrategy: alwaysSample{}},
+	})
+	require.NoError(t, err)
+
+	span, err := tracer.StartSpan(&interceptor.TracerStartSpanOptions{Operation: "StartWorkflow", Name: "MyWorkflow"})
+	require.NoError(t, err)
+	segment := span.(*tracerSpan).segment
+	m, err := tracer.MarshalSpan(span)
+	require.NoError(t, err)
+	ref, err := tracer.UnmarshalSpan(m)
+	require.NoError(t, err)
+	require.Equal(t, segment.TraceID, ref.(*tracerSpanRef).TraceID)
+	require.Equal(t, segment.ID, ref.(*tracerSpanRef).ParentID)
+
+	child, err := tracer.StartSpan(&interceptor.TracerStartSpanOptions{Operation: "RunWorkflow", Name: "MyWorkflow", Parent: ref})
+	require.NoError(t, err)
+	require.Equal(t, segment.TraceID, child.(*tracerSpan).segment.TraceID)
+	require.Equal(t, segment.ID, child.(*tracerSpan).segment.ParentID)
+	child.Finish(&interceptor.TracerFinishSpanOptions{})
+	span.Finish(&interceptor.TracerFinishSpanOptions{})
+
+	ref, err = tracer.UnmarshalSpan(map[string]string{})
+	require.NoError(t, err)
+	require.Nil(t, ref)
+	_, err = tracer.UnmarshalSpan(map[string]string{TraceHeaderKey: "Sampled=1"})
+	require.Error(t, err)
+}
+
+// testTracer records the spans it starts, to return them as a tree in the
+// order they finished.
+type testTracer struct {
+	interceptor.Tracer
+	lock     sync.Mutex
+	finished []*testSpan
+}
+
+// testSpan records itself as finished in its tracer.
+type testSpan struct {
+	*tracerSpan
+	tracer   *testTracer
+	name     string
+	parentID string
+}
+
+func (s *testSpan) Finish(opts *interceptor.TracerFinishSpanOptions) {
+	s.tracerSpan.Finish(opts)
+	s.tracer.lock.Lock()
+	defer s.tracer.lock.Unlock()
+	s.tracer.finished = append(s.tracer.finished, s)
+}
+
+// unwrapSpan returns the span of the X-Ray tracer for the spans of the test
+// tracer.
+func unwrapSpan(span interceptor.TracerSpanRef) interceptor.TracerSpanRef {
+	if s, ok := span.(*testSpan); ok {
+		return s.tracerSpan
+	}
+	return span
+}
+
+func (t *testTracer) StartSpan(opts *interceptor.TracerStartSpanOptions) (interceptor.TracerSpan, error) {
+	options := *opts
+	options.Parent = unwrapSpan(opts.Parent)
+	span, err := t.Tracer.StartSpan(&options)
+	if err != nil {
+		return nil, err
+	}
+	started := &testSpan{tracerSpan: span.(*tracerSpan), tracer: t, name: t.SpanName(opts)}
+	switch parent := options.Parent.(type) {
+	case *tracerSpan:
+		started.parentID = parent.segment.ID
+	case *tracerSpanRef:
+		started.parentID = parent.ParentID
+	}
+	return started, nil
+}
+
+func (t *testTracer) MarshalSpan(span interceptor.TracerSpan) (map[string]string, error) {
+	return t.Tracer.MarshalSpan(unwrapSpan(span).(interceptor.TracerSpan))
+}
+
+func (t *testTracer) ContextWithSpan(ctx context.Context, span interceptor.TracerSpan) context.Context {
+	return t.Tracer.ContextWithSpan(ctx, unwrapSpan(span).(interceptor.TracerSpan))
+}
+
+func (t *testTracer) FinishedSpans() []*interceptortest.SpanInfo {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.spanChildren("")
+}
+
+func (t *testTracer) spanChildren(parentID string) (ret []*interceptortest.SpanInfo) {
+	for _, s := range t.finished {
+		if s.parentID == parentID {
+			ret = append(ret, interceptortest.Span(s.name, t.spanChildren(s.segment.ID)...))
+		}
+	}
+	return
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 
 	"go.temporal.io/sdk/interceptor"
@@ -28,6 +29,14 @@ type TracerOptions struct {
 	// DisableUpdateTracing can be set to disable update tracing.
 	DisableUpdateTracing bool
 
+	// EnableProfilingEndpoints can be set to give spans for running workflows,
+	// activities, signal/query/update handlers, and Nexus operation handlers the
+	// RPC span type. The Datadog profiler labels profiles with the resource name
+	// of spans of this type, so CPU time can be broken down by workflow and
+	// activity type. Profiler endpoint collection must also be enabled, which is
+	// the default.
+	EnableProfilingEndpoints bool
+
 	// OnFinish sets finish options.
 	// If unset, this will use [tracer.WithError]
 	// in case [interceptor.TracerFinishSpanOptions.Error] is non-nil and not [workflow.IsContinueAsNewError].
@@ -57,10 +66,11 @@ func NewTracer(opts TracerOptions) interceptor.Tracer {
 
 	return &tracerImpl{
 		opts: TracerOptions{
-			DisableSignalTracing: opts.DisableSignalTracing,
-			DisableQueryTracing:  opts.DisableQueryTracing,
-			DisableUpdateTracing: opts.DisableUpdateTracing,
-			OnFinish:             opts.OnFinish,
+			DisableSignalTracing:     opts.DisableSignalTracing,
+			DisableQueryTracing:      opts.DisableQueryTracing,
+			DisableUpdateTracing:     opts.DisableUpdateTracing,
+			EnableProfilingEndpoints: opts.EnableProfilingEndpoints,
+			OnFinish:                 opts.OnFinish,
 		},
 	}
 }
@@ -70,8 +80,26 @@ type contextKey string
 const (
 	activeSpanContextKey contextKey = "dd_trace_span"
 	headerKey                       = string(activeSpanContextKey)
+
+	// Propagated trace tags header and the tag holding the upper 64 bits of
+	// 128-bit trace IDs, as used by the Datadog propagator
+	traceTagsHeader     = "x-datadog-tags"
+	traceIDUpperBitsTag = "_dd.p.tid"
 )
 
+// Operations that run user code in response to a request. Spans for these are
+// the local roots profiling endpoints are attributed to.
+var inboundOperations = map[string]bool{
+	"RunWorkflow":                    true,
+	"RunActivity":                    true,
+	"HandleSignal":                   true,
+	"HandleQuery":                    true,
+	"ValidateUpdate":                 true,
+	"HandleUpdate":                   true,
+	"RunStartNexusOperationHandler":  true,
+	"RunCancelNexusOperationHandler": true,
+}
+
 type tracerImpl struct {
 	interceptor.BaseTracer
 	// DisableSignalTracing can be set to disable signal tracing.
@@ -147,6 +175,9 @@ func (t *tracerImpl) StartSpan(options *interceptor.TracerStartSpanOptions) (int
 		tracer.ResourceName(options.Name),
 		tracer.StartTime(options.Time),
 	}
+	if t.opts.EnableProfilingEndpoints && inboundOperations[options.Operation] {
+		startOpts = append(startOpts, tracer.SpanType(ext.AppTypeRPC))
+	}
 	// Set a deterministic span ID for workflows which are long-running and cross process boundaries
 	if options.IdempotencyKey != "" {
 		startOpts = append(startOpts, tracer.WithSpanID(genSpanID(options.IdempotencyKey)))
@@ -210,6 +241,19 @@ func newSpanContextReader(parent ddtrace.SpanContext) spanContextReader {
 		tracer.DefaultParentIDHeader: strconv.FormatUint(parent.SpanID(), 10),
 	}
 
+	// Keep the sampling decision and the full 128-bit trace ID so the trace is
+	// not split or resampled across process boundaries
+	if p, ok := parent.(interface{ SamplingPriority() (int, bool) }); ok {
+		if priority, ok := p.SamplingPriority(); ok {
+			carrier[tracer.DefaultPriorityHeader] = strconv.Itoa(priority)
+		}
+	}
+	if w3c, ok := parent.(ddtrace.SpanContextW3C); ok {
+		if traceID := w3c.TraceID128(); len(traceID) == 32 && traceID[:16] != "0000000000000000" {
+			carrier[traceTagsHeader] = traceIDUpperBitsTag + "=" + traceID[:16]
+		}
+	}
+
 	// attach baggage items
 	parent.ForeachBaggageItem(func(k, v string) bool {
 		carrier[tracer.DefaultBaggageHeaderPrefix+k] = v
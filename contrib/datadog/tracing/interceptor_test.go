@@ -2,10 +2,12 @@ package tracing
 
 import (
 	"errors"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/mocktracer"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 
@@ -161,3 +163,70 @@ func Test_SpanFromWorkflowContext(t *testing.T) {
 	testSpan := mt.FinishedSpans()[0]
 	require.Equal(t, "testValue", testSpan.Tag("testTag"))
 }
+
+func Test_EnableProfilingEndpoints(t *testing.T) {
+	mt := mocktracer.Start()
+	defer mt.Stop()
+
+	impl := NewTracer(TracerOptions{EnableProfilingEndpoints: true})
+	testTracer := testTracer{
+		Tracer: impl,
+		mt:     mt,
+	}
+	interceptortest.RunTestWorkflow(t, testTracer)
+
+	var inbound, outbound int
+	for _, span := range mt.FinishedSpans() {
+		switch span.OperationName() {
+		case "temporal.RunWorkflow", "temporal.RunActivity", "temporal.HandleUpdate", "temporal.ValidateUpdate":
+			require.Equal(t, ext.AppTypeRPC, span.Tag(ext.SpanType), span.OperationName())
+			inbound++
+		case "temporal.StartActivity", "temporal.StartWorkflow":
+			require.Nil(t, span.Tag(ext.SpanType), span.OperationName())
+			outbound++
+		}
+	}
+	require.NotZero(t, inbound)
+	require.NotZero(t, outbound)
+}
+
+func Test_newSpanContextReader(t *testing.T) {
+	mt := mocktracer.Start()
+	defer mt.Stop()
+
+	span := tracer.StartSpan("parent")
+	span.SetBaggageItem("key", "value")
+	reader := newSpanContextReader(span.Context())
+	require.Equal(t, strconv.FormatUint(span.Context().TraceID(), 10), reader.keyMap[tracer.DefaultTraceIDHeader])
+	require.Equal(t, strconv.FormatUint(span.Context().SpanID(), 10), reader.keyMap[tracer.DefaultParentIDHeader])
+	require.Equal(t, "value", reader.keyMap[tracer.DefaultBaggageHeaderPrefix+"key"])
+
+	// The reader can be extracted to a parent for child spans in the same trace
+	parent, err := tracer.Extract(reader)
+	require.NoError(t, err)
+	require.Equal(t, span.Context().TraceID(), parent.TraceID())
+	require.Equal(t, span.Context().SpanID(), parent.SpanID())
+
+	// The sampling priority and the upper bits of 128-bit trace IDs survive a
+	// round trip through the Datadog propagator
+	propagator := tracer.NewPropagator(&tracer.PropagatorConfig{MaxTagsHeaderLen: 512})
+	spanCtx, err := propagator.Extract(tracer.TextMapCarrier{
+		tracer.DefaultTraceIDHeader:  "1234",
+		tracer.DefaultParentIDHeader: "5678",
+		tracer.DefaultPriorityHeader: "2",
+		traceTagsHeader:              traceIDUpperBitsTag + "=640cfd8d00000000",
+	})
+	require.NoError(t, err)
+	reader = newSpanContextReader(spanCtx)
+	require.Equal(t, "2", reader.keyMap[tracer.DefaultPriorityHeader])
+	require.Equal(t, traceIDUpperBitsTag+"=640cfd8d00000000", reader.keyMap[traceTagsHeader])
+
+	parent, err = propagator.Extract(reader)
+	require.NoError(t, err)
+	carrier := tracer.TextMapCarrier{}
+	require.NoError(t, propagator.Inject(parent, carrier))
+	require.Equal(t, "1234", carrier[tracer.DefaultTraceIDHeader])
+	require.Equal(t, "5678", carrier[tracer.DefaultParentIDHeader])
+	require.Equal(t, "2", carrier[tracer.DefaultPriorityHeader])
+	require.Contains(t, carrier[traceTagsHeader], traceIDUpperBitsTag+"=640cfd8d00000000")
+}
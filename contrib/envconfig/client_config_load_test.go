@@ -1,8 +1,16 @@
 package envconfig_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.temporal.io/sdk/contrib/envconfig"
@@ -62,6 +70,31 @@ func TestLoadClientOptionsAPIKeyTLS(t *testing.T) {
 	require.Nil(t, opts.ConnectionOptions.TLS)
 }
 
+func TestClientProfileServerCACertData(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "my-server-ca"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	prof := envconfig.ClientConfigProfile{TLS: &envconfig.ClientConfigTLS{ServerCACertData: caPEM}}
+	opts, err := prof.ToClientOptions(envconfig.ToClientOptionsRequest{})
+	require.NoError(t, err)
+	require.NotNil(t, opts.ConnectionOptions.TLS.RootCAs)
+
+	// Path and data are mutually exclusive
+	prof.TLS.ServerCACertPath = "my-server-ca-cert-path"
+	_, err = prof.ToClientOptions(envconfig.ToClientOptionsRequest{})
+	require.ErrorContains(t, err, "cannot have server CA cert path with data")
+}
+
 func TestClientProfileApplyEnvVars(t *testing.T) {
 	data := `
 [profile.foo]
@@ -0,0 +1,188 @@
+// Package expvar implements a MetricsHandler publishing metrics with the
+// standard library [expvar] package, for services avoiding metrics
+// dependencies.
+package expvar
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"runtime/metrics"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.temporal.io/sdk/client"
+	sdkmetrics "go.temporal.io/sdk/internal/common/metrics"
+)
+
+// DefaultMapName is the name under which metrics are published if
+// MetricsHandlerOptions.Map is not set.
+const DefaultMapName = "temporal"
+
+// varsLock guards the creation of variables in the maps, which may be shared
+// by several handlers.
+var varsLock sync.Mutex
+
+type metricsHandler struct {
+	vars *expvar.Map
+	tags map[string]string
+}
+
+// MetricsHandlerOptions are options for NewMetricsHandler.
+type MetricsHandlerOptions struct {
+	// Map is the map metrics are published in, keyed by metric name followed by
+	// the sorted tags, for example
+	// `temporal_request{namespace="default",operation="StartWorkflowExecution"}`.
+	// Counters are published as integers, gauges as floats, and timers and
+	// histograms as a JSON object with the count, sum and max of the recorded
+	// values, in seconds for timers.
+	//
+	// Optional: Defaults to the map published under DefaultMapName, which is
+	// created if it does not exist.
+	Map *expvar.Map
+
+	// EnableRuntimeMetrics publishes the Go runtime metrics of the
+	// [runtime/metrics] package that are a single value, such as
+	// "/sched/goroutines:goroutines", under the "runtime" entry of the map.
+	// Histogram runtime metrics are not published.
+	EnableRuntimeMetrics bool
+}
+
+// NewMetricsHandler returns a [client.MetricsHandler] publishing metrics in an
+// expvar map. Metrics are served with the other expvar variables by the
+// handler of [expvar.Handler], which is registered at /debug/vars on
+// [net/http.DefaultServeMux].
+//
+// It panics if Map is not set and a variable that is not a map is already
+// published under DefaultMapName.
+//
+// NOTE: Experimental
+func NewMetricsHandler(options MetricsHandlerOptions) client.MetricsHandler {
+	if options.Map == nil {
+		options.Map = defaultMap()
+	}
+	if options.EnableRuntimeMetrics {
+		options.Map.Set("runtime", expvar.Func(runtimeMetrics))
+	}
+	return metricsHandler{vars: options.Map}
+}
+
+func defaultMap() *expvar.Map {
+	varsLock.Lock()
+	defer varsLock.Unlock()
+	v := expvar.Get(DefaultMapName)
+	if v == nil {
+		return expvar.NewMap(DefaultMapName)
+	}
+	m, ok := v.(*expvar.Map)
+	if !ok {
+		panic(fmt.Sprintf("expvar %q is a %T, not a map", DefaultMapName, v))
+	}
+	return m
+}
+
+func (m metricsHandler) WithTags(tags map[string]string) client.MetricsHandler {
+	newTags := make(map[string]string, len(m.tags)+len(tags))
+	for k, v := range m.tags {
+		newTags[k] = v
+	}
+	for k, v := range tags {
+		newTags[k] = v
+	}
+	return metricsHandler{vars: m.vars, tags: newTags}
+}
+
+func (m metricsHandler) Counter(name string) client.MetricsCounter {
+	v := m.getOrCreate(name, func() expvar.Var { return new(expvar.Int) }).(*expvar.Int)
+	return sdkmetrics.CounterFunc(v.Add)
+}
+
+func (m metricsHandler) Gauge(name string) client.MetricsGauge {
+	v := m.getOrCreate(name, func() expvar.Var { return new(expvar.Float) }).(*expvar.Float)
+	return sdkmetrics.GaugeFunc(v.Set)
+}
+
+func (m metricsHandler) Timer(name string) client.MetricsTimer {
+	d := m.getOrCreate(name, func() expvar.Var { return new(distribution) }).(*distribution)
+	return sdkmetrics.TimerFunc(func(t time.Duration) { d.record(t.Seconds()) })
+}
+
+func (m metricsHandler) Histogram(name string) client.MetricsHistogram {
+	d := m.getOrCreate(name, func() expvar.Var { return new(distribution) }).(*distribution)
+	return sdkmetrics.HistogramFunc(d.record)
+}
+
+// getOrCreate returns the variable of the metric with the tags of the handler,
+// creating it if it does not exist. It panics if a metric of another kind
+// exists with the same name and tags.
+func (m metricsHandler) getOrCreate(name string, create func() expvar.Var) expvar.Var {
+	key := m.key(name)
+	varsLock.Lock()
+	defer varsLock.Unlock()
+	v := m.vars.Get(key)
+	if v == nil {
+		v = create()
+		m.vars.Set(key, v)
+	}
+	return v
+}
+
+func (m metricsHandler) key(name string) string {
+	if len(m.tags) == 0 {
+		return name
+	}
+	tags := make([]string, 0, len(m.tags))
+	for k, v := range m.tags {
+		tags = append(tags, fmt.Sprintf("%s=%q", k, v))
+	}
+	sort.Strings(tags)
+	return name + "{" + strings.Join(tags, ",") + "}"
+}
+
+// distribution is a variable summarizing recorded values.
+type distribution struct {
+	lock  sync.Mutex
+	count int64
+	sum   float64
+	max   float64
+}
+
+func (d *distribution) record(value float64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.count == 0 || value > d.max {
+		d.max = value
+	}
+	d.count++
+	d.sum += value
+}
+
+// String implements expvar.Var.String.
+func (d *distribution) String() string {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	b, _ := json.Marshal(map[string]interface{}{"count": d.count, "sum": d.sum, "max": d.max})
+	return string(b)
+}
+
+func runtimeMetrics() interface{} {
+	var samples []metrics.Sample
+	for _, description := range metrics.All() {
+		if description.Kind == metrics.KindUint64 || description.Kind == metrics.KindFloat64 {
+			samples = append(samples, metrics.Sample{Name: description.Name})
+		}
+	}
+	metrics.Read(samples)
+	values := make(map[string]interface{}, len(samples))
+	for _, sample := range samples {
+		switch sample.Value.Kind() {
+		case metrics.KindUint64:
+			values[sample.Name] = sample.Value.Uint64()
+		case metrics.KindFloat64:
+			values[sample.Name] = sample.Value.Float64()
+		}
+	}
+	return values
+}
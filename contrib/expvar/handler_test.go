@@ -0,0 +1,53 @@
+package expvar_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	contribexpvar "go.temporal.io/sdk/contrib/expvar"
+	"go.temporal.io/sdk/internal/common/metrics"
+)
+
+func TestExpvar(t *testing.T) {
+	vars := new(expvar.Map)
+	handler := contribexpvar.NewMetricsHandler(contribexpvar.MetricsHandlerOptions{Map: vars})
+
+	handler.Counter("counter_foo").Inc(1)
+	handler.Counter("counter_foo").Inc(2)
+	handler.Gauge("gauge_foo").Update(2.5)
+	handler.Timer("timer_foo").Record(3 * time.Second)
+	handler.Timer("timer_foo").Record(time.Second)
+	subHandler := handler.WithTags(map[string]string{"tagkey2": "tagval2"}).
+		WithTags(map[string]string{"tagkey1": "tagval1"})
+	subHandler.Counter("counter_foo").Inc(4)
+	metrics.HistogramFromHandler(subHandler, "histogram_foo").RecordValue(5)
+
+	values := map[string]interface{}{}
+	vars.Do(func(kv expvar.KeyValue) {
+		var value interface{}
+		require.NoError(t, json.Unmarshal([]byte(kv.Value.String()), &value))
+		values[kv.Key] = value
+	})
+	require.Equal(t, map[string]interface{}{
+		"counter_foo": float64(3),
+		"gauge_foo":   2.5,
+		"timer_foo":   map[string]interface{}{"count": float64(2), "sum": float64(4), "max": float64(3)},
+		`counter_foo{tagkey1="tagval1",tagkey2="tagval2"}`:   float64(4),
+		`histogram_foo{tagkey1="tagval1",tagkey2="tagval2"}`: map[string]interface{}{"count": float64(1), "sum": float64(5), "max": float64(5)},
+	}, values)
+}
+
+func TestExpvarDefaultMap(t *testing.T) {
+	handler := contribexpvar.NewMetricsHandler(contribexpvar.MetricsHandlerOptions{EnableRuntimeMetrics: true})
+	handler.Counter("counter_foo").Inc(1)
+	contribexpvar.NewMetricsHandler(contribexpvar.MetricsHandlerOptions{}).Counter("counter_foo").Inc(1)
+
+	vars := expvar.Get(contribexpvar.DefaultMapName).(*expvar.Map)
+	require.Equal(t, "2", vars.Get("counter_foo").String())
+	var runtimeMetrics map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(vars.Get("runtime").String()), &runtimeMetrics))
+	require.Contains(t, runtimeMetrics, "/sched/goroutines:goroutines")
+}
@@ -0,0 +1,106 @@
+// Package jsonschema provides an interceptor.Schema validating values against a JSON Schema document.
+package jsonschema
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	commonpb "go.temporal.io/api/common/v1"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/interceptor"
+)
+
+// The URL the schema document is compiled as, which only appears in references within the document
+const schemaURL = "schema.json"
+
+var (
+	printer         = message.NewPrinter(language.English)
+	pointerReplacer = strings.NewReplacer("~", "~0", "/", "~1")
+)
+
+type schema struct {
+	schema *jsonschema.Schema
+}
+
+// NewSchema creates an interceptor.Schema from a JSON Schema document, for use with
+// interceptor.NewSchemaValidationInterceptor. Values are validated in the JSON form the default data converter gives
+// them, so the schema of a struct describes its JSON encoding. Documents without $schema are treated as draft 2020-12,
+// and references can only point within the document.
+//
+// NOTE: Experimental
+func NewSchema(document []byte) (interceptor.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(document))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(schemaURL, doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+	s, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+	return &schema{schema: s}, nil
+}
+
+func (s *schema) Validate(value interface{}) error {
+	payload, ok := value.(*commonpb.Payload)
+	if !ok {
+		var err error
+		if payload, err = converter.GetDefaultDataConverter().ToPayload(value); err != nil {
+			return err
+		}
+	}
+	var doc interface{}
+	switch encoding := string(payload.GetMetadata()[converter.MetadataEncoding]); encoding {
+	case converter.MetadataEncodingNil:
+	case converter.MetadataEncodingJSON, converter.MetadataEncodingProtoJSON:
+		var err error
+		if doc, err = jsonschema.UnmarshalJSON(bytes.NewReader(payload.GetData())); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("values encoded as %s cannot be validated against a JSON schema", encoding)
+	}
+	err := s.schema.Validate(doc)
+	var validationErr *jsonschema.ValidationError
+	if errors.As(err, &validationErr) {
+		return newValidationError(validationErr)
+	}
+	return err
+}
+
+// newValidationError returns the leaf errors of the validation error, which are the ones describing what does not
+// match, on a single line.
+func newValidationError(err *jsonschema.ValidationError) error {
+	var messages []string
+	var collect func(err *jsonschema.ValidationError)
+	collect = func(err *jsonschema.ValidationError) {
+		if len(err.Causes) == 0 {
+			message := err.ErrorKind.LocalizedString(printer)
+			messages = append(messages, fmt.Sprintf("$%s: %s", instancePath(err.InstanceLocation), message))
+		}
+		for _, cause := range err.Causes {
+			collect(cause)
+		}
+	}
+	collect(err)
+	return errors.New(strings.Join(messages, "; "))
+}
+
+// instancePath returns the location as a JSON pointer.
+func instancePath(location []string) string {
+	var b strings.Builder
+	for _, token := range location {
+		b.WriteString("/")
+		b.WriteString(pointerReplacer.Replace(token))
+	}
+	return b.String()
+}
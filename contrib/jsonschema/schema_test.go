@@ -0,0 +1,45 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/sdk/contrib/jsonschema"
+	"go.temporal.io/sdk/converter"
+)
+
+func TestSchema(t *testing.T) {
+	schema, err := jsonschema.NewSchema([]byte(`{
+		"type": "object",
+		"properties": {
+			"tags": {"type": "array", "items": {"type": "string", "minLength": 1}, "maxItems": 2},
+			"amount": {"anyOf": [{"type": "null"}, {"type": "number", "exclusiveMinimum": 0}]},
+			"id": {"$ref": "#/$defs/id"}
+		},
+		"additionalProperties": {"type": "boolean"},
+		"$defs": {"id": {"type": "string", "pattern": "^order-"}}
+	}`))
+	require.NoError(t, err)
+	require.NoError(t, schema.Validate(map[string]interface{}{"tags": []string{"a"}, "amount": 1.5, "flag": true}))
+	require.NoError(t, schema.Validate(map[string]interface{}{"amount": nil, "id": "order-1"}))
+	require.EqualError(t, schema.Validate(map[string]interface{}{"tags": []string{""}}),
+		"$/tags/0: minLength: got 0, want 1")
+	require.EqualError(t, schema.Validate(map[string]interface{}{"tags": []string{"a", "b", "c"}}),
+		"$/tags: maxItems: got 3, want 2")
+	require.ErrorContains(t, schema.Validate(map[string]interface{}{"amount": 0}), "$/amount: ")
+	require.ErrorContains(t, schema.Validate(map[string]interface{}{"id": "1"}), "$/id: ")
+	require.EqualError(t, schema.Validate(map[string]interface{}{"flag": "yes"}), "$/flag: got string, want boolean")
+	require.EqualError(t, schema.Validate("order"), "$: got string, want object")
+	require.ErrorContains(t, schema.Validate([]byte("order")), "cannot be validated against a JSON schema")
+
+	// Encoded values are validated in their JSON form
+	payload, err := converter.GetDefaultDataConverter().ToPayload(map[string]interface{}{"flag": false})
+	require.NoError(t, err)
+	require.NoError(t, schema.Validate(payload))
+
+	_, err = jsonschema.NewSchema([]byte(`{"type": "order"}`))
+	require.ErrorContains(t, err, "invalid JSON schema: ")
+	_, err = jsonschema.NewSchema([]byte(`{"type": `))
+	require.ErrorContains(t, err, "invalid JSON schema: ")
+}
@@ -0,0 +1,150 @@
+// Package kms provides a converter.KeyProvider for envelope encryption with a
+// key management service (KMS).
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.temporal.io/sdk/converter"
+)
+
+const (
+	defaultRotationInterval = 24 * time.Hour
+	defaultCacheTTL         = time.Hour
+)
+
+// Client is the key management service data keys are generated and decrypted
+// with, using a master key that never leaves the service. It is implemented
+// for example with the GenerateDataKey and Decrypt operations of AWS KMS.
+type Client interface {
+	// GenerateDataKey generates a new AES-256 data key, and returns it in
+	// plaintext and encrypted with the master key.
+	GenerateDataKey(ctx context.Context, masterKeyID string) (plaintext, ciphertext []byte, err error)
+
+	// Decrypt decrypts a data key encrypted with the master key.
+	Decrypt(ctx context.Context, masterKeyID string, ciphertext []byte) ([]byte, error)
+}
+
+// KeyProviderOptions are options for NewKeyProvider.
+type KeyProviderOptions struct {
+	// Client is the KMS client. Required.
+	Client Client
+
+	// MasterKeyID is the ID of the master key in the KMS, for example the ARN of
+	// an AWS KMS key. Required.
+	MasterKeyID string
+
+	// RotationInterval is the interval after which a new data key is generated
+	// for the payloads encrypted from then on.
+	//
+	// Optional: Defaults to 24 hours.
+	RotationInterval time.Duration
+
+	// CacheTTL is how long decrypted data keys are cached, so that decoding
+	// payloads does not call the KMS for each of them.
+	//
+	// Optional: Defaults to 1 hour.
+	CacheTTL time.Duration
+}
+
+// KeyProvider is a converter.KeyProvider for envelope encryption: payloads are
+// encrypted with data keys generated by the KMS, and the ID of a data key,
+// stored along with the payloads, is the data key encrypted with the master
+// key of the KMS. Any process with access to the master key can therefore
+// decrypt the payloads.
+type KeyProvider struct {
+	options KeyProviderOptions
+	now     func() time.Time
+
+	lock         sync.Mutex
+	currentKeyID string
+	rotateAt     time.Time
+	cache        map[string]cachedKey
+}
+
+type cachedKey struct {
+	key       []byte
+	expiresAt time.Time
+}
+
+var _ converter.KeyProvider = (*KeyProvider)(nil)
+
+// NewKeyProvider creates a KeyProvider with the given options.
+func NewKeyProvider(options KeyProviderOptions) (*KeyProvider, error) {
+	if options.Client == nil {
+		return nil, errors.New("client is required")
+	} else if options.MasterKeyID == "" {
+		return nil, errors.New("master key ID is required")
+	}
+	if options.RotationInterval <= 0 {
+		options.RotationInterval = defaultRotationInterval
+	}
+	if options.CacheTTL <= 0 {
+		options.CacheTTL = defaultCacheTTL
+	}
+	return &KeyProvider{options: options, now: time.Now, cache: map[string]cachedKey{}}, nil
+}
+
+// CurrentKeyID implements converter.KeyProvider.CurrentKeyID. It generates a
+// new data key on first use and once the rotation interval has elapsed.
+func (p *KeyProvider) CurrentKeyID(ctx context.Context) (string, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	now := p.now()
+	if p.currentKeyID != "" && now.Before(p.rotateAt) {
+		return p.currentKeyID, nil
+	}
+	plaintext, ciphertext, err := p.options.Client.GenerateDataKey(ctx, p.options.MasterKeyID)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+	p.currentKeyID = base64.RawURLEncoding.EncodeToString(ciphertext)
+	p.rotateAt = now.Add(p.options.RotationInterval)
+	p.cacheKey(p.currentKeyID, plaintext, now)
+	return p.currentKeyID, nil
+}
+
+// GetKey implements converter.KeyProvider.GetKey, decrypting the data key with
+// the KMS unless it is cached.
+func (p *KeyProvider) GetKey(ctx context.Context, keyID string) ([]byte, error) {
+	p.lock.Lock()
+	cached, ok := p.cache[keyID]
+	p.lock.Unlock()
+	if ok && p.now().Before(cached.expiresAt) {
+		return cached.key, nil
+	}
+
+	ciphertext, err := base64.RawURLEncoding.DecodeString(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key ID: %w", err)
+	}
+	// Decrypt without the lock so a slow KMS call does not block the others
+	key, err := p.options.Client.Decrypt(ctx, p.options.MasterKeyID, ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key: %w", err)
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.cacheKey(keyID, key, p.now())
+	return key, nil
+}
+
+// cacheKey caches the key and evicts expired ones. The lock must be held.
+func (p *KeyProvider) cacheKey(keyID string, key []byte, now time.Time) {
+	for id, cached := range p.cache {
+		// The current key remains cached until it is rotated
+		if id != p.currentKeyID && !now.Before(cached.expiresAt) {
+			delete(p.cache, id)
+		}
+	}
+	expiresAt := now.Add(p.options.CacheTTL)
+	if keyID == p.currentKeyID {
+		expiresAt = p.rotateAt.Add(p.options.CacheTTL)
+	}
+	p.cache[keyID] = cachedKey{key: key, expiresAt: expiresAt}
+}
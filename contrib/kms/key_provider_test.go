@@ -0,0 +1,116 @@
+package kms
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	"google.golang.org/protobuf/proto"
+
+	"go.temporal.io/sdk/converter"
+)
+
+// testClient is a KMS "encrypting" data keys by reversing them.
+type testClient struct {
+	generated, decrypted int
+}
+
+func (c *testClient) GenerateDataKey(_ context.Context, masterKeyID string) ([]byte, []byte, error) {
+	if masterKeyID != "master" {
+		return nil, nil, errors.New("unknown master key")
+	}
+	c.generated++
+	plaintext := make([]byte, 32)
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, nil, err
+	}
+	return plaintext, reverse(plaintext), nil
+}
+
+func (c *testClient) Decrypt(_ context.Context, masterKeyID string, ciphertext []byte) ([]byte, error) {
+	if masterKeyID != "master" {
+		return nil, errors.New("unknown master key")
+	}
+	c.decrypted++
+	return reverse(ciphertext), nil
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+func TestKeyProvider(t *testing.T) {
+	client := &testClient{}
+	provider, err := NewKeyProvider(KeyProviderOptions{Client: client, MasterKeyID: "master"})
+	require.NoError(t, err)
+	now := time.Now()
+	provider.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// The current key is generated once and cached until rotated
+	keyID, err := provider.CurrentKeyID(ctx)
+	require.NoError(t, err)
+	sameKeyID, err := provider.CurrentKeyID(ctx)
+	require.NoError(t, err)
+	require.Equal(t, keyID, sameKeyID)
+	key, err := provider.GetKey(ctx, keyID)
+	require.NoError(t, err)
+	require.Len(t, key, 32)
+	require.Equal(t, 1, client.generated)
+	require.Equal(t, 0, client.decrypted)
+
+	now = now.Add(25 * time.Hour)
+	rotatedKeyID, err := provider.CurrentKeyID(ctx)
+	require.NoError(t, err)
+	require.NotEqual(t, keyID, rotatedKeyID)
+	require.Equal(t, 2, client.generated)
+
+	// Keys are decrypted by the KMS once their cache entry expires, or by
+	// another provider
+	now = now.Add(2 * time.Hour)
+	decryptedKey, err := provider.GetKey(ctx, keyID)
+	require.NoError(t, err)
+	require.Equal(t, key, decryptedKey)
+	require.Equal(t, 1, client.decrypted)
+	_, err = provider.GetKey(ctx, keyID)
+	require.NoError(t, err)
+	require.Equal(t, 1, client.decrypted)
+
+	other, err := NewKeyProvider(KeyProviderOptions{Client: client, MasterKeyID: "master"})
+	require.NoError(t, err)
+	decryptedKey, err = other.GetKey(ctx, keyID)
+	require.NoError(t, err)
+	require.Equal(t, key, decryptedKey)
+
+	_, err = NewKeyProvider(KeyProviderOptions{Client: client})
+	require.Error(t, err)
+}
+
+func TestKeyProviderEncryptionCodec(t *testing.T) {
+	provider, err := NewKeyProvider(KeyProviderOptions{Client: &testClient{}, MasterKeyID: "master"})
+	require.NoError(t, err)
+	codec, err := converter.NewEncryptionCodec(converter.EncryptionCodecOptions{KeyProvider: provider})
+	require.NoError(t, err)
+
+	payload, err := converter.GetDefaultDataConverter().ToPayload("some value")
+	require.NoError(t, err)
+	encoded, err := codec.Encode([]*commonpb.Payload{payload})
+	require.NoError(t, err)
+
+	// Another worker sharing the master key decodes the payload
+	otherProvider, err := NewKeyProvider(KeyProviderOptions{Client: &testClient{}, MasterKeyID: "master"})
+	require.NoError(t, err)
+	otherCodec, err := converter.NewEncryptionCodec(converter.EncryptionCodecOptions{KeyProvider: otherProvider})
+	require.NoError(t, err)
+	decoded, err := otherCodec.Decode(encoded)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(payload, decoded[0]))
+}
@@ -7,6 +7,7 @@ toolchain go1.23.6
 require (
 	github.com/stretchr/testify v1.10.0
 	go.opentelemetry.io/otel v1.27.0
+	go.opentelemetry.io/otel/log v0.3.0
 	go.opentelemetry.io/otel/sdk v1.27.0
 	go.opentelemetry.io/otel/trace v1.27.0
 	go.temporal.io/sdk v1.12.0
@@ -19,6 +19,9 @@ type MetricsHandler struct {
 	meter      metric.Meter
 	attributes attribute.Set
 	onError    func(error)
+
+	durationBuckets         []time.Duration
+	durationBucketsByMetric map[string][]time.Duration
 }
 
 // MetricsHandlerOptions are options provided to NewMetricsHandler.
@@ -34,6 +37,18 @@ type MetricsHandlerOptions struct {
 	//
 	// Optional: Defaults to panicking on any error.
 	OnError func(error)
+	// DurationBuckets are the explicit bucket boundaries of the histograms
+	// timer metrics are recorded on, such as the SDK's workflow task, activity
+	// execution, and end-to-end latencies.
+	//
+	// Optional: Defaults to the bucket boundaries of the meter provider.
+	DurationBuckets []time.Duration
+	// DurationBucketsByMetric overrides DurationBuckets for individual timer
+	// metrics, keyed by metric name, for example
+	// "temporal_activity_execution_latency".
+	//
+	// Optional: Defaults to no overrides.
+	DurationBucketsByMetric map[string][]time.Duration
 }
 
 // NewMetricsHandler returns a client.MetricsHandler that is backed by the given Meter
@@ -45,9 +60,11 @@ func NewMetricsHandler(options MetricsHandlerOptions) MetricsHandler {
 		options.OnError = func(err error) { panic(err) }
 	}
 	return MetricsHandler{
-		meter:      options.Meter,
-		attributes: options.InitialAttributes,
-		onError:    options.OnError,
+		meter:                   options.Meter,
+		attributes:              options.InitialAttributes,
+		onError:                 options.OnError,
+		durationBuckets:         options.DurationBuckets,
+		durationBucketsByMetric: options.DurationBucketsByMetric,
 	}
 }
 
@@ -89,9 +106,11 @@ func (m MetricsHandler) WithTags(tags map[string]string) client.MetricsHandler {
 		attributes = append(attributes, attribute.String(k, v))
 	}
 	return MetricsHandler{
-		meter:      m.meter,
-		attributes: attribute.NewSet(attributes...),
-		onError:    m.onError,
+		meter:                   m.meter,
+		attributes:              attribute.NewSet(attributes...),
+		onError:                 m.onError,
+		durationBuckets:         m.durationBuckets,
+		durationBucketsByMetric: m.durationBucketsByMetric,
 	}
 }
 
@@ -118,7 +137,19 @@ func (m MetricsHandler) Gauge(name string) client.MetricsGauge {
 }
 
 func (m MetricsHandler) Timer(name string) client.MetricsTimer {
-	h, err := m.meter.Float64Histogram(name, metric.WithUnit("s"))
+	opts := []metric.Float64HistogramOption{metric.WithUnit("s")}
+	buckets, ok := m.durationBucketsByMetric[name]
+	if !ok {
+		buckets = m.durationBuckets
+	}
+	if len(buckets) > 0 {
+		bounds := make([]float64, len(buckets))
+		for i, bucket := range buckets {
+			bounds[i] = bucket.Seconds()
+		}
+		opts = append(opts, metric.WithExplicitBucketBoundaries(bounds...))
+	}
+	h, err := m.meter.Float64Histogram(name, opts...)
 	if err != nil {
 		m.onError(err)
 		return client.MetricsNopHandler.Timer(name)
@@ -127,3 +158,14 @@ func (m MetricsHandler) Timer(name string) client.MetricsTimer {
 		h.Record(context.Background(), t.Seconds(), metric.WithAttributeSet(m.attributes))
 	})
 }
+
+func (m MetricsHandler) Histogram(name string) client.MetricsHistogram {
+	h, err := m.meter.Float64Histogram(name)
+	if err != nil {
+		m.onError(err)
+		return metrics.HistogramFromHandler(client.MetricsNopHandler, name)
+	}
+	return metrics.HistogramFunc(func(v float64) {
+		h.Record(context.Background(), v, metric.WithAttributeSet(m.attributes))
+	})
+}
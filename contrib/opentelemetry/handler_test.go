@@ -190,3 +190,66 @@ func TestTimerHandler(t *testing.T) {
 	}
 	metricdatatest.AssertEqual(t, want, metrics[0], metricdatatest.IgnoreTimestamp())
 }
+
+func TestTimerHandlerDurationBuckets(t *testing.T) {
+	ctx := context.Background()
+	metricReader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(metricReader))
+	handler := opentelemetry.NewMetricsHandler(
+		opentelemetry.MetricsHandlerOptions{
+			Meter:           meterProvider.Meter("test"),
+			DurationBuckets: []time.Duration{10 * time.Millisecond, time.Second},
+			DurationBucketsByMetric: map[string][]time.Duration{
+				"testLongTimer": {time.Minute, time.Hour},
+			},
+		},
+	)
+	handler.Timer("testTimer").Record(time.Millisecond)
+	handler.WithTags(map[string]string{"tag1": "value1"}).Timer("testLongTimer").Record(time.Hour)
+
+	var rm metricdata.ResourceMetrics
+	metricReader.Collect(ctx, &rm)
+	assert.Len(t, rm.ScopeMetrics, 1)
+	metrics := rm.ScopeMetrics[0].Metrics
+	assert.Len(t, metrics, 2)
+	want := []metricdata.Metrics{
+		{
+			Name: "testTimer",
+			Unit: "s",
+			Data: metricdata.Histogram[float64]{
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.HistogramDataPoint[float64]{
+					{
+						Count:        1,
+						Sum:          0.001,
+						Min:          metricdata.NewExtrema(time.Millisecond.Seconds()),
+						Max:          metricdata.NewExtrema(time.Millisecond.Seconds()),
+						Bounds:       []float64{0.01, 1},
+						BucketCounts: []uint64{1, 0, 0},
+					},
+				},
+			},
+		},
+		{
+			Name: "testLongTimer",
+			Unit: "s",
+			Data: metricdata.Histogram[float64]{
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.HistogramDataPoint[float64]{
+					{
+						Count:        1,
+						Sum:          3600,
+						Min:          metricdata.NewExtrema(time.Hour.Seconds()),
+						Max:          metricdata.NewExtrema(time.Hour.Seconds()),
+						Bounds:       []float64{60, 3600},
+						BucketCounts: []uint64{0, 1, 0},
+						Attributes:   attribute.NewSet(attribute.String("tag1", "value1")),
+					},
+				},
+			},
+		},
+	}
+	for i := range want {
+		metricdatatest.AssertEqual(t, want[i], metrics[i], metricdatatest.IgnoreTimestamp())
+	}
+}
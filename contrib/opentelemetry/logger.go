@@ -0,0 +1,158 @@
+package opentelemetry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.temporal.io/sdk/log"
+)
+
+var _ log.WithLogger = (*Logger)(nil)
+
+// Logger is an implementation of log.Logger emitting OpenTelemetry log
+// records. It can be set as the client logger so that workflow and activity
+// loggers emit log records.
+//
+// Records are correlated with the span whose trace and span IDs are among the
+// logger fields, such as the fields added by the tracing interceptor to
+// workflow and activity loggers. Resource attributes are the ones of the
+// logger provider.
+//
+// NOTE: Experimental
+type Logger struct {
+	logger     otellog.Logger
+	attributes []otellog.KeyValue
+	traceID    trace.TraceID
+	spanID     trace.SpanID
+}
+
+// LoggerOptions are options provided to NewLogger.
+//
+// NOTE: Experimental
+type LoggerOptions struct {
+	// Logger is the OpenTelemetry logger to emit records with. If not set, one
+	// is obtained from the global logger provider using the name
+	// "temporal-sdk-go".
+	Logger otellog.Logger
+}
+
+// NewLogger creates a Logger with the given options.
+//
+// NOTE: Experimental
+func NewLogger(options LoggerOptions) *Logger {
+	if options.Logger == nil {
+		options.Logger = global.GetLoggerProvider().Logger("temporal-sdk-go")
+	}
+	return &Logger{logger: options.Logger}
+}
+
+// Debug implements log.Logger.Debug.
+func (l *Logger) Debug(msg string, keyvals ...interface{}) {
+	l.emit(otellog.SeverityDebug, "DEBUG", msg, keyvals)
+}
+
+// Info implements log.Logger.Info.
+func (l *Logger) Info(msg string, keyvals ...interface{}) {
+	l.emit(otellog.SeverityInfo, "INFO", msg, keyvals)
+}
+
+// Warn implements log.Logger.Warn.
+func (l *Logger) Warn(msg string, keyvals ...interface{}) {
+	l.emit(otellog.SeverityWarn, "WARN", msg, keyvals)
+}
+
+// Error implements log.Logger.Error.
+func (l *Logger) Error(msg string, keyvals ...interface{}) {
+	l.emit(otellog.SeverityError, "ERROR", msg, keyvals)
+}
+
+// With implements log.WithLogger.With. Trace and span IDs among the fields
+// correlate the records with their span instead of being record attributes.
+func (l *Logger) With(keyvals ...interface{}) log.Logger {
+	logger := &Logger{logger: l.logger, traceID: l.traceID, spanID: l.spanID}
+	logger.attributes = append(logger.attributes, l.attributes...)
+	logger.attributes = logger.addFields(logger.attributes, keyvals)
+	return logger
+}
+
+func (l *Logger) emit(severity otellog.Severity, severityText, msg string, keyvals []interface{}) {
+	// Copy so the trace and span IDs of this call do not leak into the logger
+	logger := *l
+	var record otellog.Record
+	record.SetTimestamp(time.Now())
+	record.SetSeverity(severity)
+	record.SetSeverityText(severityText)
+	record.SetBody(otellog.StringValue(msg))
+	record.AddAttributes(l.attributes...)
+	record.AddAttributes(logger.addFields(nil, keyvals)...)
+
+	ctx := context.Background()
+	if logger.traceID.IsValid() && logger.spanID.IsValid() {
+		ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: logger.traceID,
+			SpanID:  logger.spanID,
+		}))
+	}
+	if !l.logger.Enabled(ctx, record) {
+		return
+	}
+	l.logger.Emit(ctx, record)
+}
+
+// addFields appends the key-value pairs to the attributes, except for trace
+// and span IDs which are set on the logger.
+func (l *Logger) addFields(attributes []otellog.KeyValue, keyvals []interface{}) []otellog.KeyValue {
+	for i := 0; i < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+		var value interface{}
+		if i+1 < len(keyvals) {
+			value = keyvals[i+1]
+		}
+		switch v := value.(type) {
+		case trace.TraceID:
+			l.traceID = v
+			continue
+		case trace.SpanID:
+			l.spanID = v
+			continue
+		}
+		attributes = append(attributes, otellog.KeyValue{Key: key, Value: logValue(value)})
+	}
+	return attributes
+}
+
+func logValue(value interface{}) otellog.Value {
+	switch v := value.(type) {
+	case string:
+		return otellog.StringValue(v)
+	case bool:
+		return otellog.BoolValue(v)
+	case int:
+		return otellog.IntValue(v)
+	case int32:
+		return otellog.Int64Value(int64(v))
+	case int64:
+		return otellog.Int64Value(v)
+	case uint32:
+		return otellog.Int64Value(int64(v))
+	case float32:
+		return otellog.Float64Value(float64(v))
+	case float64:
+		return otellog.Float64Value(v)
+	case []byte:
+		return otellog.BytesValue(v)
+	case time.Duration:
+		return otellog.StringValue(v.String())
+	case error:
+		return otellog.StringValue(v.Error())
+	case fmt.Stringer:
+		return otellog.StringValue(v.String())
+	default:
+		return otellog.StringValue(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,110 @@
+package opentelemetry_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.temporal.io/sdk/contrib/opentelemetry"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+)
+
+type emittedRecord struct {
+	spanContext trace.SpanContext
+	record      otellog.Record
+}
+
+type recordingLogger struct {
+	embedded.Logger
+	lock    sync.Mutex
+	records []emittedRecord
+}
+
+func (l *recordingLogger) Emit(ctx context.Context, record otellog.Record) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.records = append(l.records, emittedRecord{spanContext: trace.SpanContextFromContext(ctx), record: record})
+}
+
+func (l *recordingLogger) Enabled(context.Context, otellog.Record) bool {
+	return true
+}
+
+func recordAttributes(record otellog.Record) map[string]string {
+	attributes := map[string]string{}
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attributes[kv.Key] = kv.Value.String()
+		return true
+	})
+	return attributes
+}
+
+func TestLogger(t *testing.T) {
+	var recorder recordingLogger
+	logger := opentelemetry.NewLogger(opentelemetry.LoggerOptions{Logger: &recorder})
+
+	log.With(logger, "p1", "v1").Warn("message", "p2", 2)
+	logger.Error("message2")
+
+	require.Len(t, recorder.records, 2)
+	record := recorder.records[0].record
+	require.Equal(t, "message", record.Body().AsString())
+	require.Equal(t, otellog.SeverityWarn, record.Severity())
+	require.Equal(t, "WARN", record.SeverityText())
+	require.Equal(t, map[string]string{"p1": "v1", "p2": "2"}, recordAttributes(record))
+	require.False(t, recorder.records[0].spanContext.IsValid())
+
+	record = recorder.records[1].record
+	require.Equal(t, "message2", record.Body().AsString())
+	require.Equal(t, otellog.SeverityError, record.Severity())
+	require.Empty(t, recordAttributes(record))
+}
+
+func TestLoggerSpanCorrelation(t *testing.T) {
+	var rec tracetest.SpanRecorder
+	tracer, err := opentelemetry.NewTracer(opentelemetry.TracerOptions{
+		Tracer: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(&rec)).Tracer(""),
+	})
+	require.NoError(t, err)
+
+	var recorder recordingLogger
+	var suite testsuite.WorkflowTestSuite
+	suite.SetLogger(opentelemetry.NewLogger(opentelemetry.LoggerOptions{Logger: &recorder}))
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterActivity(testActivity)
+	env.RegisterWorkflow(testWorkflow)
+	env.SetWorkerOptions(worker.Options{
+		Interceptors: []interceptor.WorkerInterceptor{interceptor.NewTracingInterceptor(tracer)},
+	})
+
+	env.ExecuteWorkflow(testWorkflow)
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	spanIDs := map[trace.SpanID]bool{}
+	for _, span := range rec.Ended() {
+		spanIDs[span.SpanContext().SpanID()] = true
+	}
+	var bodies []string
+	for _, emitted := range recorder.records {
+		body := emitted.record.Body().AsString()
+		if body != "inside a worflow" && body != "inside an activity" {
+			continue
+		}
+		bodies = append(bodies, body)
+		require.True(t, emitted.spanContext.IsValid())
+		require.True(t, spanIDs[emitted.spanContext.SpanID()])
+		require.NotContains(t, recordAttributes(emitted.record), "TraceID")
+	}
+	require.ElementsMatch(t, []string{"inside a worflow", "inside an activity"}, bodies)
+}
@@ -7,8 +7,10 @@ import (
 	"fmt"
 
 	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
 
 	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/temporal"
 )
 
 // TracerOptions are options provided to NewInterceptor or NewTracer.
@@ -22,6 +24,14 @@ type TracerOptions struct {
 	// DisableQueryTracing can be set to disable query tracing.
 	DisableQueryTracing bool
 
+	// DisableUpdateTracing can be set to disable update tracing.
+	DisableUpdateTracing bool
+
+	// AllowInvalidParentSpans will swallow errors interpreting parent
+	// spans from headers. Useful when migrating from one tracing library
+	// to another, while workflows/activities may be in progress.
+	AllowInvalidParentSpans bool
+
 	// SpanContextKey is the context key used for internal span tracking (not to
 	// be confused with the context key OpenTracing uses internally). If not set,
 	// this defaults to an internal key (recommended).
@@ -34,11 +44,33 @@ type TracerOptions struct {
 	// SpanStarter is a callback to create spans. If not set, this creates normal
 	// OpenTracing spans calling Tracer.StartSpan.
 	SpanStarter func(t opentracing.Tracer, operationName string, opts ...opentracing.StartSpanOption) opentracing.Span
+
+	// SpanNamer is a callback to name spans. If not set, spans are named
+	// "${operation}:${name}" (e.g. "RunWorkflow:MyWorkflow").
+	SpanNamer func(options *interceptor.TracerStartSpanOptions) string
+
+	// FinishWorkflowSpansOnStart can be set to finish RunWorkflow spans as soon
+	// as they are started instead of when the workflow completes. Spans of
+	// workflows that run longer than the tracer flush interval may otherwise
+	// never be reported. When set, the outcome of the workflow is recorded on a
+	// separate CompleteWorkflow span that follows from the RunWorkflow span.
+	FinishWorkflowSpansOnStart bool
 }
 
 type spanContextKey struct{}
 
-const defaultHeaderKey = "_tracer-data"
+const (
+	defaultHeaderKey = "_tracer-data"
+
+	runWorkflowOperation      = "RunWorkflow"
+	completeWorkflowOperation = "CompleteWorkflow"
+
+	// Standard OpenTracing tag and log field keys for errors
+	errorKindKey   = "error.kind"
+	errorObjectKey = "error.object"
+	messageKey     = "message"
+	eventKey       = "event"
+)
 
 type tracer struct {
 	interceptor.BaseTracer
@@ -81,11 +113,20 @@ func NewInterceptor(options TracerOptions) (interceptor.Interceptor, error) {
 
 func (t *tracer) Options() interceptor.TracerOptions {
 	return interceptor.TracerOptions{
-		SpanContextKey:       t.options.SpanContextKey,
-		HeaderKey:            t.options.HeaderKey,
-		DisableSignalTracing: t.options.DisableSignalTracing,
-		DisableQueryTracing:  t.options.DisableQueryTracing,
+		SpanContextKey:          t.options.SpanContextKey,
+		HeaderKey:               t.options.HeaderKey,
+		DisableSignalTracing:    t.options.DisableSignalTracing,
+		DisableQueryTracing:     t.options.DisableQueryTracing,
+		DisableUpdateTracing:    t.options.DisableUpdateTracing,
+		AllowInvalidParentSpans: t.options.AllowInvalidParentSpans,
+	}
+}
+
+func (t *tracer) SpanName(options *interceptor.TracerStartSpanOptions) string {
+	if t.options.SpanNamer != nil {
+		return t.options.SpanNamer(options)
 	}
+	return t.BaseTracer.SpanName(options)
 }
 
 func (t *tracer) UnmarshalSpan(m map[string]string) (interceptor.TracerSpanRef, error) {
@@ -156,17 +197,78 @@ func (t *tracer) StartSpan(opts *interceptor.TracerStartSpanOptions) (intercepto
 	}
 
 	// Start
-	return &tracerSpan{Span: t.options.SpanStarter(t.options.Tracer, opts.Operation+":"+opts.Name, startOpts...)}, nil
+	span := &tracerSpan{Span: t.options.SpanStarter(t.options.Tracer, t.SpanName(opts), startOpts...)}
+	if t.options.FinishWorkflowSpansOnStart && opts.Operation == runWorkflowOperation {
+		span.Span.Finish()
+		span.completion = &interceptor.TracerStartSpanOptions{
+			Operation: completeWorkflowOperation,
+			Name:      opts.Name,
+			Tags:      opts.Tags,
+		}
+		span.tracer = t
+	}
+	return span, nil
 }
 
 type tracerSpanRef struct{ opentracing.SpanContext }
 
-type tracerSpan struct{ opentracing.Span }
+type tracerSpan struct {
+	opentracing.Span
+	// Set when the span was finished on start, the options of the span
+	// recording its outcome
+	completion *interceptor.TracerStartSpanOptions
+	tracer     *tracer
+}
 
 func (t *tracerSpan) Finish(opts *interceptor.TracerFinishSpanOptions) {
+	span := t.Span
+	if t.completion != nil {
+		startOpts := []opentracing.StartSpanOption{opentracing.FollowsFrom(t.Context())}
+		if len(t.completion.Tags) > 0 {
+			tags := make(opentracing.Tags, len(t.completion.Tags))
+			for k, v := range t.completion.Tags {
+				tags[k] = v
+			}
+			startOpts = append(startOpts, tags)
+		}
+		span = t.tracer.options.SpanStarter(t.tracer.options.Tracer, t.tracer.SpanName(t.completion), startOpts...)
+	}
 	if opts.Error != nil {
 		// Standard tag that can be bridged to OpenTelemetry
-		t.SetTag("error", "true")
+		span.SetTag("error", "true")
+		span.SetTag(errorKindKey, errorKind(opts.Error))
+		span.LogFields(
+			log.String(eventKey, "error"),
+			log.String(messageKey, opts.Error.Error()),
+			log.Object(errorObjectKey, opts.Error),
+		)
+	}
+	span.Finish()
+}
+
+// errorKind returns the Temporal failure type of the error, e.g. the type of
+// an application error, or the Go type if it is not a Temporal failure.
+func errorKind(err error) string {
+	var applicationErr *temporal.ApplicationError
+	var timeoutErr *temporal.TimeoutError
+	var canceledErr *temporal.CanceledError
+	var panicErr *temporal.PanicError
+	var terminatedErr *temporal.TerminatedError
+	switch {
+	case errors.As(err, &applicationErr):
+		if applicationErr.Type() != "" {
+			return applicationErr.Type()
+		}
+		return "ApplicationError"
+	case errors.As(err, &timeoutErr):
+		return "TimeoutError"
+	case errors.As(err, &canceledErr):
+		return "CanceledError"
+	case errors.As(err, &panicErr):
+		return "PanicError"
+	case errors.As(err, &terminatedErr):
+		return "TerminatedError"
+	default:
+		return fmt.Sprintf("%T", err)
 	}
-	t.Span.Finish()
 }
@@ -1,6 +1,7 @@
 package opentracing_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/opentracing/opentracing-go/mocktracer"
@@ -38,3 +39,60 @@ func spanChildren(spans []*mocktracer.MockSpan, parentID int) (ret []*intercepto
 	}
 	return
 }
+
+func TestSpanNamer(t *testing.T) {
+	mock := mocktracer.New()
+	tracer, err := opentracing.NewTracer(opentracing.TracerOptions{
+		Tracer: mock,
+		SpanNamer: func(options *interceptor.TracerStartSpanOptions) string {
+			return "temporal." + options.Operation
+		},
+	})
+	require.NoError(t, err)
+
+	interceptortest.RunTestWorkflow(t, &testTracer{Tracer: tracer, mock: mock})
+
+	spans := mock.FinishedSpans()
+	require.NotEmpty(t, spans)
+	for _, span := range spans {
+		require.True(t, strings.HasPrefix(span.OperationName, "temporal."), span.OperationName)
+	}
+}
+
+func TestErrorTags(t *testing.T) {
+	mock := mocktracer.New()
+	tracer, err := opentracing.NewTracer(opentracing.TracerOptions{Tracer: mock})
+	require.NoError(t, err)
+
+	interceptortest.RunTestWorkflowWithError(t, &testTracer{Tracer: tracer, mock: mock})
+
+	spans := mock.FinishedSpans()
+	require.Len(t, spans, 1)
+	require.Equal(t, "RunWorkflow:testWorkflowWithError", spans[0].OperationName)
+	require.Equal(t, "true", spans[0].Tag("error"))
+	require.Equal(t, "*errors.errorString", spans[0].Tag("error.kind"))
+	require.NotEmpty(t, spans[0].Tag("temporalWorkflowID"))
+	require.NotEmpty(t, spans[0].Tag("temporalRunID"))
+	require.Len(t, spans[0].Logs(), 1)
+}
+
+func TestFinishWorkflowSpansOnStart(t *testing.T) {
+	mock := mocktracer.New()
+	tracer, err := opentracing.NewTracer(opentracing.TracerOptions{
+		Tracer:                     mock,
+		FinishWorkflowSpansOnStart: true,
+	})
+	require.NoError(t, err)
+
+	interceptortest.RunTestWorkflowWithError(t, &testTracer{Tracer: tracer, mock: mock})
+
+	spans := mock.FinishedSpans()
+	require.Len(t, spans, 2)
+	// The workflow span is reported before the workflow completes and carries no outcome
+	require.Equal(t, "RunWorkflow:testWorkflowWithError", spans[0].OperationName)
+	require.Nil(t, spans[0].Tag("error"))
+	require.Equal(t, "CompleteWorkflow:testWorkflowWithError", spans[1].OperationName)
+	require.Equal(t, spans[0].SpanContext.SpanID, spans[1].ParentID)
+	require.Equal(t, "true", spans[1].Tag("error"))
+	require.Equal(t, spans[0].Tag("temporalWorkflowID"), spans[1].Tag("temporalWorkflowID"))
+}
@@ -6,9 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
-	"os"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/containerd/cgroups/v3/cgroup2"
@@ -27,7 +24,7 @@ type cGroupInfoImpl struct {
 func (p *cGroupInfoImpl) Update() (bool, error) {
 	err := p.updateCGroupStats()
 	// Stop updates if not in a container. No need to return the error and log it.
-	if !errors.Is(err, fs.ErrNotExist) {
+	if errors.Is(err, fs.ErrNotExist) {
 		return false, nil
 	} else if err != nil {
 		return true, err
@@ -49,11 +46,11 @@ func (p *cGroupInfoImpl) GetLastCPUUsage() float64 {
 func (p *cGroupInfoImpl) updateCGroupStats() error {
 	control, err := cgroup2.Load("/")
 	if err != nil {
-		return fmt.Errorf("failed to get cgroup mem stats %v", err)
+		return fmt.Errorf("failed to get cgroup mem stats: %w", err)
 	}
 	metrics, err := control.Stat()
 	if err != nil {
-		return fmt.Errorf("failed to get cgroup mem stats %v", err)
+		return fmt.Errorf("failed to get cgroup mem stats: %w", err)
 	}
 	// Only update if a limit has been set
 	if metrics.Memory.UsageLimit != 0 {
@@ -62,7 +59,7 @@ func (p *cGroupInfoImpl) updateCGroupStats() error {
 
 	err = p.cgroupCpuCalc.updateCpuUsage(metrics)
 	if err != nil {
-		return fmt.Errorf("failed to get cgroup cpu usage %v", err)
+		return fmt.Errorf("failed to get cgroup cpu usage: %w", err)
 	}
 	return nil
 }
@@ -105,33 +102,3 @@ func (p *cgroupCpuCalc) updateCpuUsage(metrics *stats.Metrics) error {
 
 	return nil
 }
-
-// readCpuMax reads the cpu.max file to get the CPU quota and period
-func readCpuMax(path string) (quota int64, period int64, err error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return 0, 0, err
-	}
-	parts := strings.Fields(string(data))
-	if len(parts) != 2 {
-		return 0, 0, errors.New("invalid format in cpu.max")
-	}
-
-	// Parse the quota (first value)
-	if parts[0] == "max" {
-		quota = 0 // Unlimited quota
-	} else {
-		quota, err = strconv.ParseInt(parts[0], 10, 64)
-		if err != nil {
-			return 0, 0, err
-		}
-	}
-
-	// Parse the period (second value)
-	period, err = strconv.ParseInt(parts[1], 10, 64)
-	if err != nil {
-		return 0, 0, err
-	}
-
-	return quota, period, nil
-}
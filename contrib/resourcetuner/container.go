@@ -0,0 +1,291 @@
+package resourcetuner
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/mem"
+)
+
+const defaultCGroupPath = "/sys/fs/cgroup"
+
+// ContainerLimits are the resource limits of the container a worker runs in. Zero values mean the limit is unknown.
+//
+// WARNING: Resource based tuning is currently experimental.
+type ContainerLimits struct {
+	// CpuCores is the number of CPU cores the container may use, possibly fractional.
+	CpuCores float64
+	// MemBytes is the amount of memory the container may use, in bytes.
+	MemBytes uint64
+}
+
+// DownwardAPILimitsFromEnv reads container limits exposed by the Kubernetes downward API as environment variables.
+// The CPU variable must come from a resourceFieldRef of limits.cpu with a divisor of 1m, so its value is in
+// millicores. The memory variable must come from a resourceFieldRef of limits.memory with the default divisor of 1,
+// so its value is in bytes. Unset or empty variables, or empty names, leave the respective limit unset.
+//
+// Note that without a limit set on the container, Kubernetes exposes the allocatable capacity of the node instead.
+//
+// WARNING: Resource based tuning is currently experimental.
+func DownwardAPILimitsFromEnv(cpuMillicoresEnv, memBytesEnv string) (ContainerLimits, error) {
+	var limits ContainerLimits
+	if value := lookupEnv(cpuMillicoresEnv); value != "" {
+		millicores, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return ContainerLimits{}, fmt.Errorf("invalid CPU limit in %v: %w", cpuMillicoresEnv, err)
+		}
+		limits.CpuCores = float64(millicores) / 1000
+	}
+	if value := lookupEnv(memBytesEnv); value != "" {
+		memBytes, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return ContainerLimits{}, fmt.Errorf("invalid memory limit in %v: %w", memBytesEnv, err)
+		}
+		limits.MemBytes = memBytes
+	}
+	return limits, nil
+}
+
+func lookupEnv(name string) string {
+	if name == "" {
+		return ""
+	}
+	return strings.TrimSpace(os.Getenv(name))
+}
+
+// ContainerSystemInfoSupplierOptions configures a SystemInfoSupplier created with NewContainerSystemInfoSupplier.
+//
+// WARNING: Resource based tuning is currently experimental.
+type ContainerSystemInfoSupplierOptions struct {
+	// CGroupPath is the directory of the cgroup v2 of the container. If not set, the default value is
+	// /sys/fs/cgroup, which is the cgroup of the container when it has its own cgroup namespace.
+	CGroupPath string
+	// Limits override the limits read from the cgroup, for example with DownwardAPILimitsFromEnv. Limits left
+	// unset are read from the cgroup, and fall back to the capacity of the host if the cgroup has no limit.
+	Limits ContainerLimits
+}
+
+type containerSystemInfoSupplier struct {
+	cgroupPath string
+	limits     ContainerLimits
+
+	mu           sync.Mutex
+	lastRefresh  time.Time
+	lastMemUsage float64
+	lastCpuUsage float64
+	lastCpuUsec  uint64
+	lastCpuTime  time.Time
+}
+
+// NewContainerSystemInfoSupplier creates a SystemInfoSupplier that reports memory and CPU usage of a container as a
+// fraction of its limits, read from cgroup v2 files, rather than of the capacity of the host. Memory usage is the
+// working set, which excludes inactive file cache like the kubelet does when evicting pods.
+//
+// WARNING: Resource based tuning is currently experimental.
+func NewContainerSystemInfoSupplier(options ContainerSystemInfoSupplierOptions) (SystemInfoSupplier, error) {
+	if options.CGroupPath == "" {
+		options.CGroupPath = defaultCGroupPath
+	}
+	if options.Limits.CpuCores < 0 || math.IsNaN(options.Limits.CpuCores) {
+		return nil, errors.New("CpuCores must be non-negative")
+	}
+	// Fail early if not in a cgroup v2
+	if _, err := os.Stat(filepath.Join(options.CGroupPath, "cgroup.controllers")); err != nil {
+		return nil, fmt.Errorf("cgroup v2 not found at %v: %w", options.CGroupPath, err)
+	}
+	return &containerSystemInfoSupplier{cgroupPath: options.CGroupPath, limits: options.Limits}, nil
+}
+
+func (c *containerSystemInfoSupplier) GetMemoryUsage(*SystemInfoContext) (float64, error) {
+	if err := c.maybeRefresh(); err != nil {
+		return 0, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastMemUsage, nil
+}
+
+func (c *containerSystemInfoSupplier) GetCpuUsage(*SystemInfoContext) (float64, error) {
+	if err := c.maybeRefresh(); err != nil {
+		return 0, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastCpuUsage, nil
+}
+
+func (c *containerSystemInfoSupplier) maybeRefresh() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if time.Since(c.lastRefresh) < 100*time.Millisecond {
+		return nil
+	}
+	memUsage, err := c.readMemUsage()
+	if err != nil {
+		return err
+	}
+	cpuUsec, err := c.readCpuUsec()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if !c.lastCpuTime.IsZero() && cpuUsec >= c.lastCpuUsec {
+		cores, err := c.cpuCores()
+		if err != nil {
+			return err
+		}
+		if elapsed := now.Sub(c.lastCpuTime).Microseconds(); elapsed > 0 {
+			c.lastCpuUsage = float64(cpuUsec-c.lastCpuUsec) / float64(elapsed) / cores
+		}
+	}
+	c.lastMemUsage = memUsage
+	c.lastCpuUsec = cpuUsec
+	c.lastCpuTime = now
+	c.lastRefresh = now
+	return nil
+}
+
+func (c *containerSystemInfoSupplier) readMemUsage() (float64, error) {
+	current, err := c.readUint("memory.current")
+	if err != nil {
+		return 0, err
+	}
+	stat, err := c.readStat("memory.stat")
+	if err != nil {
+		return 0, err
+	}
+	workingSet := current
+	if inactiveFile := stat["inactive_file"]; inactiveFile < workingSet {
+		workingSet -= inactiveFile
+	} else {
+		workingSet = 0
+	}
+
+	limit := c.limits.MemBytes
+	if limit == 0 {
+		if limit, err = c.readLimit("memory.max"); err != nil {
+			return 0, err
+		}
+	}
+	if limit == 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		memStat, err := mem.VirtualMemoryWithContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+		limit = memStat.Total
+	}
+	return float64(workingSet) / float64(limit), nil
+}
+
+func (c *containerSystemInfoSupplier) readCpuUsec() (uint64, error) {
+	stat, err := c.readStat("cpu.stat")
+	if err != nil {
+		return 0, err
+	}
+	usec, ok := stat["usage_usec"]
+	if !ok {
+		return 0, errors.New("usage_usec missing from cpu.stat")
+	}
+	return usec, nil
+}
+
+func (c *containerSystemInfoSupplier) cpuCores() (float64, error) {
+	if c.limits.CpuCores > 0 {
+		return c.limits.CpuCores, nil
+	}
+	quota, period, err := readCpuMax(filepath.Join(c.cgroupPath, "cpu.max"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 0, err
+	}
+	if quota > 0 && period > 0 {
+		return float64(quota) / float64(period), nil
+	}
+	return float64(runtime.NumCPU()), nil
+}
+
+// readLimit reads a cgroup file containing a single limit, returning 0 if the limit is "max" or the file does not
+// exist.
+func (c *containerSystemInfoSupplier) readLimit(name string) (uint64, error) {
+	data, err := os.ReadFile(filepath.Join(c.cgroupPath, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	value := strings.TrimSpace(string(data))
+	if value == "max" {
+		return 0, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func (c *containerSystemInfoSupplier) readUint(name string) (uint64, error) {
+	data, err := os.ReadFile(filepath.Join(c.cgroupPath, name))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// readStat reads a cgroup file of "key value" lines.
+func (c *containerSystemInfoSupplier) readStat(name string) (map[string]uint64, error) {
+	file, err := os.Open(filepath.Join(c.cgroupPath, name))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	stat := map[string]uint64{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if value, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+			stat[fields[0]] = value
+		}
+	}
+	return stat, scanner.Err()
+}
+
+// readCpuMax reads the cpu.max file to get the CPU quota and period
+func readCpuMax(path string) (quota int64, period int64, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	parts := strings.Fields(string(data))
+	if len(parts) != 2 {
+		return 0, 0, errors.New("invalid format in cpu.max")
+	}
+
+	// Parse the quota (first value)
+	if parts[0] == "max" {
+		quota = 0 // Unlimited quota
+	} else {
+		quota, err = strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	// Parse the period (second value)
+	period, err = strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return quota, period, nil
+}
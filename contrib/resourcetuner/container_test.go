@@ -0,0 +1,81 @@
+package resourcetuner
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCGroupFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+}
+
+func TestContainerSystemInfoSupplier(t *testing.T) {
+	dir := t.TempDir()
+	writeCGroupFiles(t, dir, map[string]string{
+		"cgroup.controllers": "cpu memory",
+		"memory.current":     "600\n",
+		"memory.stat":        "anon 400\ninactive_file 100\n",
+		"memory.max":         "1000\n",
+		"cpu.stat":           "usage_usec 1000000\n",
+		"cpu.max":            "50000 100000\n",
+	})
+	supplier, err := NewContainerSystemInfoSupplier(ContainerSystemInfoSupplierOptions{CGroupPath: dir})
+	require.NoError(t, err)
+
+	memUsage, err := supplier.GetMemoryUsage(&SystemInfoContext{})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, memUsage, 0.001)
+	// No CPU usage until there are two samples
+	cpuUsage, err := supplier.GetCpuUsage(&SystemInfoContext{})
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, cpuUsage)
+
+	// Half a core limit, a quarter of a core used over the sampling interval
+	time.Sleep(200 * time.Millisecond)
+	internal := supplier.(*containerSystemInfoSupplier)
+	elapsed := time.Since(internal.lastCpuTime)
+	writeCGroupFiles(t, dir, map[string]string{"cpu.stat": "usage_usec " + strconv.FormatInt(1000000+elapsed.Microseconds()/4, 10)})
+	cpuUsage, err = supplier.GetCpuUsage(&SystemInfoContext{})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, cpuUsage, 0.05)
+}
+
+func TestContainerSystemInfoSupplierLimitOverrides(t *testing.T) {
+	dir := t.TempDir()
+	writeCGroupFiles(t, dir, map[string]string{
+		"cgroup.controllers": "cpu memory",
+		"memory.current":     "500\n",
+		"memory.stat":        "inactive_file 0\n",
+		"memory.max":         "max\n",
+		"cpu.stat":           "usage_usec 0\n",
+	})
+	t.Setenv("TEST_CPU_LIMIT", "1500")
+	t.Setenv("TEST_MEM_LIMIT", "2000")
+	limits, err := DownwardAPILimitsFromEnv("TEST_CPU_LIMIT", "TEST_MEM_LIMIT")
+	require.NoError(t, err)
+	require.Equal(t, ContainerLimits{CpuCores: 1.5, MemBytes: 2000}, limits)
+
+	supplier, err := NewContainerSystemInfoSupplier(ContainerSystemInfoSupplierOptions{CGroupPath: dir, Limits: limits})
+	require.NoError(t, err)
+	memUsage, err := supplier.GetMemoryUsage(&SystemInfoContext{})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.25, memUsage, 0.001)
+	cores, err := supplier.(*containerSystemInfoSupplier).cpuCores()
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, cores)
+
+	t.Setenv("TEST_CPU_LIMIT", "one")
+	_, err = DownwardAPILimitsFromEnv("TEST_CPU_LIMIT", "")
+	require.ErrorContains(t, err, "TEST_CPU_LIMIT")
+
+	_, err = NewContainerSystemInfoSupplier(ContainerSystemInfoSupplierOptions{CGroupPath: t.TempDir()})
+	require.ErrorContains(t, err, "cgroup v2 not found")
+}
@@ -27,6 +27,10 @@ type ResourceBasedTunerOptions struct {
 	// Passed to ResourceBasedSlotSupplierOptions.RampThrottle for workflows.
 	// If not set, the default value is 0ms.
 	WorkflowRampThrottle time.Duration
+	// InfoSupplier is the supplier of system resource usage, passed to ResourceControllerOptions.InfoSupplier.
+	// Use NewContainerSystemInfoSupplier for usage relative to container limits. If not set, the default
+	// implementation is used.
+	InfoSupplier SystemInfoSupplier
 }
 
 // NewResourceBasedTuner creates a WorkerTuner that dynamically adjusts the number of slots based
@@ -37,6 +41,7 @@ func NewResourceBasedTuner(opts ResourceBasedTunerOptions) (worker.WorkerTuner,
 	options := DefaultResourceControllerOptions()
 	options.MemTargetPercent = opts.TargetMem
 	options.CpuTargetPercent = opts.TargetCpu
+	options.InfoSupplier = opts.InfoSupplier
 	controller := NewResourceController(options)
 	wfSS := &ResourceBasedSlotSupplier{controller: controller,
 		options: defaultWorkflowResourceBasedSlotSupplierOptions()}
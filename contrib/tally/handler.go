@@ -2,11 +2,47 @@
 package tally
 
 import (
+	"time"
+
 	"github.com/uber-go/tally/v4"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/internal/common/metrics"
 )
 
-type metricsHandler struct{ scope tally.Scope }
+type metricsHandler struct {
+	scope   tally.Scope
+	options *MetricsHandlerOptions
+}
+
+// MetricsHandlerOptions are options for NewMetricsHandlerWithOptions.
+type MetricsHandlerOptions struct {
+	// DurationBuckets are the bucket boundaries used for timer metrics, such as
+	// the SDK's workflow task, activity execution, and end-to-end latencies. If
+	// set, timers are recorded as Tally duration histograms with these buckets
+	// instead of Tally timers.
+	//
+	// Optional: Defaults to recording timers as Tally timers.
+	DurationBuckets tally.DurationBuckets
+
+	// DurationBucketsByMetric overrides DurationBuckets for individual timer
+	// metrics, keyed by metric name, for example
+	// "temporal_activity_execution_latency". Timers in this map are recorded as
+	// duration histograms even if DurationBuckets is not set.
+	//
+	// Optional: Defaults to no overrides.
+	DurationBucketsByMetric map[string]tally.DurationBuckets
+
+	// ValueBuckets are the bucket boundaries used for value histograms, such as
+	// the payload sizes in bytes recorded by
+	// interceptor.NewPayloadSizeMetricsGRPCClientInterceptor.
+	//
+	// Optional: Defaults to DefaultValueBuckets.
+	ValueBuckets tally.ValueBuckets
+}
+
+// DefaultValueBuckets are the default bucket boundaries of value histograms,
+// from 1KiB to 32MiB, suited to payload sizes in bytes.
+var DefaultValueBuckets = tally.MustMakeExponentialValueBuckets(1024, 2, 16)
 
 // NewMetricsHandler returns a [client.MetricsHandler] that is backed by the given Tally
 // scope.
@@ -22,7 +58,14 @@ type metricsHandler struct{ scope tally.Scope }
 //	scope, _ := tally.NewRootScope(opts, time.Second)
 //	scope = contribtally.NewPrometheusNamingScope(scope)
 func NewMetricsHandler(scope tally.Scope) client.MetricsHandler {
-	return metricsHandler{scope}
+	return metricsHandler{scope: scope}
+}
+
+// NewMetricsHandlerWithOptions returns a [client.MetricsHandler] that is
+// backed by the given Tally scope and configured with the given options. See
+// [NewMetricsHandler] for more details.
+func NewMetricsHandlerWithOptions(scope tally.Scope, options MetricsHandlerOptions) client.MetricsHandler {
+	return metricsHandler{scope: scope, options: &options}
 }
 
 // ScopeFromHandler returns the underlying scope of the handler. Callers may
@@ -57,7 +100,7 @@ func ScopeFromHandler(handler client.MetricsHandler) tally.Scope {
 }
 
 func (m metricsHandler) WithTags(tags map[string]string) client.MetricsHandler {
-	return metricsHandler{m.scope.Tagged(tags)}
+	return metricsHandler{scope: m.scope.Tagged(tags), options: m.options}
 }
 
 func (m metricsHandler) Counter(name string) client.MetricsCounter {
@@ -69,5 +112,26 @@ func (m metricsHandler) Gauge(name string) client.MetricsGauge {
 }
 
 func (m metricsHandler) Timer(name string) client.MetricsTimer {
+	if buckets := m.durationBuckets(name); len(buckets) > 0 {
+		h := m.scope.Histogram(name, buckets)
+		return metrics.TimerFunc(func(d time.Duration) { h.RecordDuration(d) })
+	}
 	return m.scope.Timer(name)
 }
+
+func (m metricsHandler) durationBuckets(name string) tally.DurationBuckets {
+	if m.options == nil {
+		return nil
+	} else if buckets, ok := m.options.DurationBucketsByMetric[name]; ok {
+		return buckets
+	}
+	return m.options.DurationBuckets
+}
+
+func (m metricsHandler) Histogram(name string) client.MetricsHistogram {
+	buckets := DefaultValueBuckets
+	if m.options != nil && len(m.options.ValueBuckets) > 0 {
+		buckets = m.options.ValueBuckets
+	}
+	return m.scope.Histogram(name, buckets)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/uber-go/tally/v4"
+	"go.temporal.io/sdk/client"
 	contribtally "go.temporal.io/sdk/contrib/tally"
 )
 
@@ -54,3 +55,55 @@ func TestTally(t *testing.T) {
 		"timer_foo: map[tagkey1:tagval2 tagkey2:tagval2] - 9s",
 	}, metrics)
 }
+
+func TestTallyDurationBuckets(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	handler := contribtally.NewMetricsHandlerWithOptions(scope, contribtally.MetricsHandlerOptions{
+		DurationBuckets: tally.DurationBuckets{time.Second, time.Minute},
+		DurationBucketsByMetric: map[string]tally.DurationBuckets{
+			"timer_bar": {time.Hour},
+		},
+	})
+	// Confirm scope is the same
+	require.Equal(t, scope, contribtally.ScopeFromHandler(handler))
+
+	handler.Timer("timer_foo").Record(3 * time.Second)
+	handler.WithTags(map[string]string{"tagkey1": "tagval1"}).Timer("timer_bar").Record(2 * time.Hour)
+
+	snap := scope.Snapshot()
+	require.Empty(t, snap.Timers())
+	var histograms []string
+	for _, h := range snap.Histograms() {
+		for upperBound, count := range h.Durations() {
+			if count > 0 {
+				histograms = append(histograms, fmt.Sprintf("%v: %v - %v", h.Name(), h.Tags(), upperBound))
+			}
+		}
+	}
+	sort.Strings(histograms)
+	require.Equal(t, []string{
+		"timer_bar: map[tagkey1:tagval1] - 2562047h47m16.854775807s",
+		"timer_foo: map[] - 1m0s",
+	}, histograms)
+}
+
+func TestValueHistograms(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	handler := contribtally.NewMetricsHandler(scope).(client.MetricsHistogramHandler)
+	handler.Histogram("size_foo").RecordValue(3000)
+	handler = contribtally.NewMetricsHandlerWithOptions(scope, contribtally.MetricsHandlerOptions{
+		ValueBuckets: tally.ValueBuckets{10, 100},
+	}).(client.MetricsHistogramHandler)
+	handler.Histogram("size_bar").RecordValue(50)
+
+	var histograms []string
+	for _, h := range scope.Snapshot().Histograms() {
+		for upperBound, count := range h.Values() {
+			if count > 0 {
+				histograms = append(histograms, fmt.Sprintf("%v - %v", h.Name(), upperBound))
+			}
+		}
+	}
+	sort.Strings(histograms)
+	require.Equal(t, []string{"size_bar - 100", "size_foo - 4096"}, histograms)
+}
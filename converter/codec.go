@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"compress/zlib"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"strings"
 
@@ -38,6 +40,39 @@ type ZlibCodecOptions struct {
 	// benefit. Otherwise, the zlib codec will only use the encoded value if it
 	// is smaller.
 	AlwaysEncode bool
+
+	// Filter decides which payloads compression is attempted on. Payloads
+	// rejected by the filter are left as is, even if AlwaysEncode is set.
+	Filter CompressionFilter
+}
+
+// CompressionFilter selects the payloads a compression codec should attempt to
+// compress, so tiny payloads and already compressed data skip pointless work.
+// The zero value accepts all payloads. Custom compression codecs may use it via
+// ShouldCompress.
+type CompressionFilter struct {
+	// MinSize is the minimum serialized payload size in bytes for compression
+	// to be attempted.
+	MinSize int
+
+	// SkipEncodings are payload encodings (the MetadataEncoding metadata value)
+	// that are never compressed, for example encodings of payloads known to
+	// carry already compressed data.
+	SkipEncodings []string
+}
+
+// ShouldCompress reports whether the given payload passes the filter.
+func (f CompressionFilter) ShouldCompress(payload *commonpb.Payload) bool {
+	if f.MinSize > 0 && proto.Size(payload) < f.MinSize {
+		return false
+	}
+	encoding := string(payload.GetMetadata()[MetadataEncoding])
+	for _, skip := range f.SkipEncodings {
+		if encoding == skip {
+			return false
+		}
+	}
+	return true
 }
 
 type zlibCodec struct{ options ZlibCodecOptions }
@@ -52,6 +87,10 @@ func NewZlibCodec(options ZlibCodecOptions) PayloadCodec { return &zlibCodec{opt
 func (z *zlibCodec) Encode(payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
 	result := make([]*commonpb.Payload, len(payloads))
 	for i, p := range payloads {
+		if !z.options.Filter.ShouldCompress(p) {
+			result[i] = p
+			continue
+		}
 		// Marshal and write
 		b, err := proto.Marshal(p)
 		if err != nil {
@@ -234,10 +273,44 @@ func (e *CodecDataConverter) ToStrings(payloads *commonpb.Payloads) []string {
 const remotePayloadCodecEncodePath = "/encode"
 const remotePayloadCodecDecodePath = "/decode"
 
+// remotePayloadCodecBatchPath is the suffix of the encode and decode paths for
+// requests of several payload sets.
+const remotePayloadCodecBatchPath = "/batch"
+
+// PayloadCodecHTTPHandlerStreamContentType is the content type of streaming
+// requests to the handler of NewPayloadCodecHTTPHandler, and of their
+// responses: newline-delimited JSON.
+const PayloadCodecHTTPHandlerStreamContentType = "application/x-ndjson"
+
 type codecHTTPHandler struct {
 	codecs []PayloadCodec
 }
 
+// payloadSetsJSON is the body of batch requests and responses, each set
+// being the body of a single request or response.
+type payloadSetsJSON struct {
+	PayloadSets []json.RawMessage `json:"payloadSets"`
+}
+
+// streamErrorJSON is the last line of a streaming response failing after the
+// response status was sent.
+type streamErrorJSON struct {
+	Error string `json:"error"`
+}
+
+// errCodecPayloadCount is returned when codecs do not return as many payloads
+// as they were given for a batch request.
+var errCodecPayloadCount = errors.New("codecs returned an unexpected number of payloads")
+
+// errorStatus returns the status of the response to a request failing with
+// err.
+func errorStatus(err error) int {
+	if errors.Is(err, errCodecPayloadCount) {
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
 func (e *codecHTTPHandler) encode(payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
 	var err error
 	for i := len(e.codecs) - 1; i >= 0; i-- {
@@ -266,60 +339,169 @@ func (e *codecHTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	path := r.URL.Path
+	batch := strings.HasSuffix(path, remotePayloadCodecBatchPath)
+	path = strings.TrimSuffix(path, remotePayloadCodecBatchPath)
 
-	if !strings.HasSuffix(path, remotePayloadCodecEncodePath) &&
-		!strings.HasSuffix(path, remotePayloadCodecDecodePath) {
+	var apply func([]*commonpb.Payload) ([]*commonpb.Payload, error)
+	switch {
+	case strings.HasSuffix(path, remotePayloadCodecEncodePath):
+		apply = e.encode
+	case strings.HasSuffix(path, remotePayloadCodecDecodePath):
+		apply = e.decode
+	default:
 		http.NotFound(w, r)
 		return
 	}
 
-	var payloadspb commonpb.Payloads
-	var err error
-
 	if r.Body == nil {
 		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 		return
 	}
 
+	handle := handlePayloads
+	if batch {
+		handle = handlePayloadSets
+	}
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == PayloadCodecHTTPHandlerStreamContentType {
+		serveStream(w, r.Body, handle, apply)
+		return
+	}
+
 	bs, err := io.ReadAll(r.Body)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	response, err := handle(bs, apply)
+	if err != nil {
+		http.Error(w, err.Error(), errorStatus(err))
+		return
+	}
 
-	if err = protojson.Unmarshal(bs, &payloadspb); err != nil {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+}
 
-	payloads := payloadspb.Payloads
-
-	switch {
-	case strings.HasSuffix(path, remotePayloadCodecEncodePath):
-		if payloads, err = e.encode(payloads); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+// serveStream handles each line of the request as a request of its own, and
+// writes the response of each as a line, flushed as soon as it is handled so
+// that large histories are decoded without buffering them, nor timing out
+// the client.
+func serveStream(
+	w http.ResponseWriter,
+	body io.Reader,
+	handle func([]byte, func([]*commonpb.Payload) ([]*commonpb.Payload, error)) (interface{}, error),
+	apply func([]*commonpb.Payload) ([]*commonpb.Payload, error),
+) {
+	w.Header().Set("Content-Type", PayloadCodecHTTPHandlerStreamContentType)
+	// HTTP/1.1 servers close the request body once the response is written
+	// to, unless full duplex is enabled. HTTP/2 servers do not support it, nor
+	// need it.
+	_ = http.NewResponseController(w).EnableFullDuplex()
+	flusher, _ := w.(http.Flusher)
+	decoder := json.NewDecoder(body)
+	encoder := json.NewEncoder(w)
+	for started := false; ; started = true {
+		var line json.RawMessage
+		err := decoder.Decode(&line)
+		if err == io.EOF {
 			return
 		}
-	case strings.HasSuffix(path, remotePayloadCodecDecodePath):
-		if payloads, err = e.decode(payloads); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		var response interface{}
+		if err == nil {
+			response, err = handle(line, apply)
+		}
+		if err != nil {
+			// Once a line was written, the status cannot be changed anymore
+			if started {
+				_ = encoder.Encode(streamErrorJSON{Error: err.Error()})
+			} else {
+				http.Error(w, err.Error(), errorStatus(err))
+			}
 			return
 		}
-	default:
-		http.NotFound(w, r)
-		return
+		if err := encoder.Encode(response); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
 	}
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(commonpb.Payloads{Payloads: payloads})
+func handlePayloads(bs []byte, apply func([]*commonpb.Payload) ([]*commonpb.Payload, error)) (interface{}, error) {
+	var payloadspb commonpb.Payloads
+	if err := protojson.Unmarshal(bs, &payloadspb); err != nil {
+		return nil, err
+	}
+	payloads, err := apply(payloadspb.Payloads)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+		return nil, err
 	}
+	return commonpb.Payloads{Payloads: payloads}, nil
+}
+
+// handlePayloadSets applies the codecs once to the payloads of all the sets,
+// so codecs batching remote calls, such as calls to a key management service,
+// make as few of them as possible.
+func handlePayloadSets(bs []byte, apply func([]*commonpb.Payload) ([]*commonpb.Payload, error)) (interface{}, error) {
+	var request payloadSetsJSON
+	if err := json.Unmarshal(bs, &request); err != nil {
+		return nil, err
+	}
+	var payloads []*commonpb.Payload
+	counts := make([]int, len(request.PayloadSets))
+	for i, set := range request.PayloadSets {
+		var payloadspb commonpb.Payloads
+		if err := protojson.Unmarshal(set, &payloadspb); err != nil {
+			return nil, fmt.Errorf("payload set %d: %w", i, err)
+		}
+		payloads = append(payloads, payloadspb.Payloads...)
+		counts[i] = len(payloadspb.Payloads)
+	}
+	total := len(payloads)
+	payloads, err := apply(payloads)
+	if err != nil {
+		return nil, err
+	}
+	// The payloads are split back into their sets by position
+	if len(payloads) != total {
+		return nil, fmt.Errorf("%w: got %d payloads for %d", errCodecPayloadCount, len(payloads), total)
+	}
+
+	response := payloadSetsJSON{PayloadSets: make([]json.RawMessage, len(counts))}
+	for i, count := range counts {
+		set, err := json.Marshal(commonpb.Payloads{Payloads: payloads[:count]})
+		if err != nil {
+			return nil, err
+		}
+		response.PayloadSets[i] = set
+		payloads = payloads[count:]
+	}
+	return response, nil
 }
 
 // NewPayloadCodecHTTPHandler creates a http.Handler for a PayloadCodec.
 // This can be used to provide a remote data converter.
+//
+// The handler encodes and decodes the payloads of POST requests to paths
+// ending with /encode and /decode, whose body is a JSON Payloads message. For
+// the Temporal UI and CLI to decode large workflows without timing out, it
+// also supports:
+//   - Batch requests, to paths ending with /encode/batch and /decode/batch,
+//     whose body is a JSON object with a "payloadSets" array of Payloads
+//     messages, encoded or decoded in a single call of the codecs. The
+//     response has the same shape. The codecs must return as many payloads
+//     as they are given, the request fails with status 500 otherwise.
+//   - Streaming requests, with the content type
+//     PayloadCodecHTTPHandlerStreamContentType, whose body is a
+//     newline-delimited sequence of request bodies. The response is the
+//     newline-delimited sequence of their responses, each flushed once
+//     handled. If a request fails after the first response was written, the
+//     last line is a JSON object with an "error" message.
 func NewPayloadCodecHTTPHandler(e ...PayloadCodec) http.Handler {
 	return &codecHTTPHandler{codecs: e}
 }
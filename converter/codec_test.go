@@ -2,13 +2,16 @@ package converter
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	commonpb "go.temporal.io/api/common/v1"
@@ -50,6 +53,33 @@ func ExampleCodecDataConverter_compression() {
 	// Compressed payload back to original? true
 }
 
+func TestZlibCodecFilter(t *testing.T) {
+	codec := NewZlibCodec(ZlibCodecOptions{
+		AlwaysEncode: true,
+		Filter: CompressionFilter{
+			MinSize:       100,
+			SkipEncodings: []string{MetadataEncodingBinary},
+		},
+	})
+	defaultConv := GetDefaultDataConverter()
+	small, err := defaultConv.ToPayload("small")
+	require.NoError(t, err)
+	big, err := defaultConv.ToPayload(strings.Repeat("aabbcc", 200))
+	require.NoError(t, err)
+	bigBinary, err := defaultConv.ToPayload([]byte(strings.Repeat("aabbcc", 200)))
+	require.NoError(t, err)
+
+	encoded, err := codec.Encode([]*commonpb.Payload{small, big, bigBinary})
+	require.NoError(t, err)
+	require.Same(t, small, encoded[0])
+	require.Equal(t, "binary/zlib", string(encoded[1].Metadata[MetadataEncoding]))
+	require.Same(t, bigBinary, encoded[2])
+
+	decoded, err := codec.Decode(encoded)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(big, decoded[1]))
+}
+
 type SomeStruct struct{ MyValue string }
 
 func TestEncodingDataConverter(t *testing.T) {
@@ -183,6 +213,139 @@ func TestPayloadCodecHTTPHandler(t *testing.T) {
 	require.Equal(t, string(payloadsJSON), decodedPayloadsJSON)
 }
 
+func TestPayloadCodecHTTPHandler_Batch(t *testing.T) {
+	defaultConv := GetDefaultDataConverter()
+	handler := NewPayloadCodecHTTPHandler(NewZlibCodec(ZlibCodecOptions{AlwaysEncode: true}))
+
+	payloads1, _ := defaultConv.ToPayloads("test1", "test2")
+	payloads2, _ := defaultConv.ToPayloads("test3")
+	payloadsJSON := fmt.Sprintf(`{"payloadSets":[%s,%s]}`, mustMarshalJSON(t, payloads1), mustMarshalJSON(t, payloads2))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/encode/batch", strings.NewReader(payloadsJSON)))
+	require.Equal(t, http.StatusOK, rr.Code)
+	var encoded struct{ PayloadSets []commonpb.Payloads }
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &encoded))
+	require.Len(t, encoded.PayloadSets, 2)
+	require.Len(t, encoded.PayloadSets[0].Payloads, 2)
+	require.Len(t, encoded.PayloadSets[1].Payloads, 1)
+	require.Equal(t, "binary/zlib", string(encoded.PayloadSets[1].Payloads[0].Metadata[MetadataEncoding]))
+
+	encodedJSON := rr.Body.String()
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/decode/batch", strings.NewReader(encodedJSON)))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, payloadsJSON, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/decode/batch", strings.NewReader(`{"payloadSets":[{"payloads":1}]}`)))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// Payloads cannot be split back into their sets if the codec drops some
+	rr = httptest.NewRecorder()
+	NewPayloadCodecHTTPHandler(dropLastCodec{}).ServeHTTP(rr, httptest.NewRequest("POST", "/encode/batch", strings.NewReader(payloadsJSON)))
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+// dropLastCodec is a codec wrongly dropping the last payload.
+type dropLastCodec struct{}
+
+func (dropLastCodec) Encode(payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
+	return payloads[:len(payloads)-1], nil
+}
+
+func (dropLastCodec) Decode(payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
+	return payloads[:len(payloads)-1], nil
+}
+
+func TestPayloadCodecHTTPHandler_Stream(t *testing.T) {
+	defaultConv := GetDefaultDataConverter()
+	codec := NewZlibCodec(ZlibCodecOptions{AlwaysEncode: true})
+	handler := NewPayloadCodecHTTPHandler(codec)
+
+	var lines []string
+	for _, value := range []string{"test1", "test2", "test3"} {
+		payloads, _ := defaultConv.ToPayloads(value)
+		encoded, err := codec.Encode(payloads.Payloads)
+		require.NoError(t, err)
+		lines = append(lines, string(mustMarshalJSON(t, &commonpb.Payloads{Payloads: encoded})))
+	}
+
+	req := httptest.NewRequest("POST", "/decode", strings.NewReader(strings.Join(lines, "\n")))
+	req.Header.Set("Content-Type", PayloadCodecHTTPHandlerStreamContentType)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, PayloadCodecHTTPHandlerStreamContentType, rr.Header().Get("Content-Type"))
+	require.True(t, rr.Flushed)
+	responses := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+	require.Len(t, responses, 3)
+	for i, value := range []string{"test1", "test2", "test3"} {
+		var decoded commonpb.Payloads
+		require.NoError(t, json.Unmarshal([]byte(responses[i]), &decoded))
+		var s string
+		require.NoError(t, defaultConv.FromPayloads(&decoded, &s))
+		require.Equal(t, value, s)
+	}
+
+	// Failures after the first line are reported on the last line
+	req = httptest.NewRequest("POST", "/decode", strings.NewReader(lines[0]+"\n{\"payloads\":1}\n"+lines[1]))
+	req.Header.Set("Content-Type", PayloadCodecHTTPHandlerStreamContentType)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	responses = strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+	require.Len(t, responses, 2)
+	require.Contains(t, responses[1], `"error"`)
+}
+
+func TestPayloadCodecHTTPHandler_StreamHTTP1(t *testing.T) {
+	defaultConv := GetDefaultDataConverter()
+	codec := NewZlibCodec(ZlibCodecOptions{AlwaysEncode: true})
+	server := httptest.NewServer(NewPayloadCodecHTTPHandler(codec))
+	defer server.Close()
+
+	// The request is streamed, so each line is only sent once the response to the previous one was read
+	bodyReader, bodyWriter := io.Pipe()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", server.URL+"/decode", bodyReader)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", PayloadCodecHTTPHandlerStreamContentType)
+	writeLine := func(value string) {
+		payloads, _ := defaultConv.ToPayloads(value)
+		encoded, err := codec.Encode(payloads.Payloads)
+		require.NoError(t, err)
+		_, err = bodyWriter.Write(append(mustMarshalJSON(t, &commonpb.Payloads{Payloads: encoded}), '\n'))
+		require.NoError(t, err)
+	}
+	go writeLine("test1")
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	decoder := json.NewDecoder(resp.Body)
+	for i, value := range []string{"test1", "test2", "test3"} {
+		if i > 0 {
+			writeLine(value)
+		}
+		var decoded commonpb.Payloads
+		require.NoError(t, decoder.Decode(&decoded))
+		var s string
+		require.NoError(t, defaultConv.FromPayloads(&decoded, &s))
+		require.Equal(t, value, s)
+	}
+	require.NoError(t, bodyWriter.Close())
+	require.False(t, decoder.More())
+}
+
+func mustMarshalJSON(t *testing.T, v interface{}) []byte {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
+
 type testCodec struct {
 	encoding   string
 	encodeFrom string
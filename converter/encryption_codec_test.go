@@ -0,0 +1,68 @@
+package converter
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+type testKeyProvider struct {
+	currentKeyID string
+	keys         map[string][]byte
+}
+
+func (p *testKeyProvider) CurrentKeyID(context.Context) (string, error) {
+	return p.currentKeyID, nil
+}
+
+func (p *testKeyProvider) GetKey(_ context.Context, keyID string) ([]byte, error) {
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key")
+	}
+	return key, nil
+}
+
+func TestEncryptionCodec(t *testing.T) {
+	keys := &testKeyProvider{
+		currentKeyID: "key1",
+		keys:         map[string][]byte{"key1": make([]byte, 32), "key2": []byte("0123456789abcdef")},
+	}
+	codec, err := NewEncryptionCodec(EncryptionCodecOptions{KeyProvider: keys})
+	require.NoError(t, err)
+
+	payload, err := GetDefaultDataConverter().ToPayload("some value")
+	require.NoError(t, err)
+	encoded, err := codec.Encode([]*commonpb.Payload{payload})
+	require.NoError(t, err)
+	require.Equal(t, MetadataEncodingEncrypted, string(encoded[0].Metadata[MetadataEncoding]))
+	require.Equal(t, "key1", string(encoded[0].Metadata[MetadataEncryptionKeyID]))
+	require.NotContains(t, string(encoded[0].Data), "some value")
+
+	// Rotated keys still decode older payloads, and other payloads are unchanged
+	keys.currentKeyID = "key2"
+	rotated, err := codec.Encode([]*commonpb.Payload{payload})
+	require.NoError(t, err)
+	require.Equal(t, "key2", string(rotated[0].Metadata[MetadataEncryptionKeyID]))
+	decoded, err := codec.Decode([]*commonpb.Payload{encoded[0], rotated[0], payload})
+	require.NoError(t, err)
+	for _, p := range decoded {
+		require.True(t, proto.Equal(payload, p))
+	}
+
+	// Tampered payloads and unknown keys fail
+	tampered := proto.Clone(encoded[0]).(*commonpb.Payload)
+	tampered.Data[len(tampered.Data)-1] ^= 1
+	_, err = codec.Decode([]*commonpb.Payload{tampered})
+	require.Error(t, err)
+	delete(keys.keys, "key1")
+	_, err = codec.Decode([]*commonpb.Payload{encoded[0]})
+	require.ErrorContains(t, err, "unknown key")
+
+	_, err = NewEncryptionCodec(EncryptionCodecOptions{})
+	require.Error(t, err)
+}
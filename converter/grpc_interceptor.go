@@ -1,19 +1,27 @@
 package converter
 
 import (
+	"context"
 	"fmt"
 
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 
 	commonpb "go.temporal.io/api/common/v1"
 	failurepb "go.temporal.io/api/failure/v1"
 	"go.temporal.io/api/proxy"
+	"go.temporal.io/api/workflowservice/v1"
 )
 
 // PayloadCodecGRPCClientInterceptorOptions holds interceptor options.
-// Currently this is just the list of codecs to use.
 type PayloadCodecGRPCClientInterceptorOptions struct {
 	Codecs []PayloadCodec
+	// MaxHistoryPageSize is optional. If set, workflow history is requested in pages of at most this many events, so
+	// that tools reading very large histories through the interceptor only hold one bounded page and its decoded
+	// payloads in memory at a time. Requests asking for smaller pages are left unchanged.
+	//
+	// NOTE: Experimental
+	MaxHistoryPageSize int32
 }
 
 // NewPayloadCodecGRPCClientInterceptor returns a GRPC Client Interceptor that will mimic the encoding
@@ -24,7 +32,7 @@ type PayloadCodecGRPCClientInterceptorOptions struct {
 // Note: This approach does not support use cases that rely on the ContextAware DataConverter interface as
 // workflow context is not available at the GRPC level.
 func NewPayloadCodecGRPCClientInterceptor(options PayloadCodecGRPCClientInterceptorOptions) (grpc.UnaryClientInterceptor, error) {
-	return proxy.NewPayloadVisitorInterceptor(proxy.PayloadVisitorInterceptorOptions{
+	interceptor, err := proxy.NewPayloadVisitorInterceptor(proxy.PayloadVisitorInterceptorOptions{
 		Outbound: &proxy.VisitPayloadsOptions{
 			Visitor: func(vpc *proxy.VisitPayloadsContext, payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
 				var err error
@@ -52,6 +60,32 @@ func NewPayloadCodecGRPCClientInterceptor(options PayloadCodecGRPCClientIntercep
 			SkipSearchAttributes: true,
 		},
 	})
+	if err != nil || options.MaxHistoryPageSize <= 0 {
+		return interceptor, err
+	}
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return interceptor(ctx, method, limitHistoryPageSize(req, options.MaxHistoryPageSize), reply, cc, invoker, opts...)
+	}, nil
+}
+
+// limitHistoryPageSize returns a copy of a workflow history request with the page size capped, other requests are
+// returned unchanged. The request is copied as callers reuse it to request the next pages.
+func limitHistoryPageSize(req interface{}, maxPageSize int32) interface{} {
+	switch r := req.(type) {
+	case *workflowservice.GetWorkflowExecutionHistoryRequest:
+		if r.GetMaximumPageSize() <= 0 || r.GetMaximumPageSize() > maxPageSize {
+			r = proto.Clone(r).(*workflowservice.GetWorkflowExecutionHistoryRequest)
+			r.MaximumPageSize = maxPageSize
+			return r
+		}
+	case *workflowservice.GetWorkflowExecutionHistoryReverseRequest:
+		if r.GetMaximumPageSize() <= 0 || r.GetMaximumPageSize() > maxPageSize {
+			r = proto.Clone(r).(*workflowservice.GetWorkflowExecutionHistoryReverseRequest)
+			r.MaximumPageSize = maxPageSize
+			return r
+		}
+	}
+	return req
 }
 
 // NewFailureGRPCClientInterceptorOptions holds interceptor options.
@@ -78,6 +78,39 @@ func TestPayloadCodecGRPCClientInterceptor(t *testing.T) {
 	require.Equal("json/plain", payloadEncoding(response.Input))
 }
 
+func TestPayloadCodecGRPCClientInterceptor_MaxHistoryPageSize(t *testing.T) {
+	require := require.New(t)
+
+	server, err := startTestGRPCServer()
+	require.NoError(err)
+
+	interceptor, err := NewPayloadCodecGRPCClientInterceptor(
+		PayloadCodecGRPCClientInterceptorOptions{
+			Codecs:             []PayloadCodec{NewZlibCodec(ZlibCodecOptions{AlwaysEncode: true})},
+			MaxHistoryPageSize: 100,
+		},
+	)
+	require.NoError(err)
+
+	c, err := grpc.NewClient(
+		server.addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(interceptor),
+	)
+	require.NoError(err)
+
+	client := workflowservice.NewWorkflowServiceClient(c)
+
+	for _, pageSize := range []struct{ requested, sent int32 }{{0, 100}, {1000, 100}, {10, 10}} {
+		request := &workflowservice.GetWorkflowExecutionHistoryRequest{MaximumPageSize: pageSize.requested}
+		_, err = client.GetWorkflowExecutionHistory(context.Background(), request)
+		require.NoError(err)
+		require.Equal(pageSize.sent, server.historyRequest.MaximumPageSize)
+		// The request of the caller is not modified
+		require.Equal(pageSize.requested, request.MaximumPageSize)
+	}
+}
+
 func TestFailureGRPCClientInterceptor(t *testing.T) {
 	require := require.New(t)
 
@@ -134,6 +167,7 @@ type testGRPCServer struct {
 	addr                             string
 	startWorkflowExecutionRequest    *workflowservice.StartWorkflowExecutionRequest
 	respondWorkflowTaskFailedRequest *workflowservice.RespondWorkflowTaskFailedRequest
+	historyRequest                   *workflowservice.GetWorkflowExecutionHistoryRequest
 }
 
 func startTestGRPCServer() (*testGRPCServer, error) {
@@ -229,6 +263,14 @@ func (t *testGRPCServer) PollWorkflowTaskQueue(
 	}, nil
 }
 
+func (t *testGRPCServer) GetWorkflowExecutionHistory(
+	ctx context.Context,
+	req *workflowservice.GetWorkflowExecutionHistoryRequest,
+) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+	t.historyRequest = req
+	return &workflowservice.GetWorkflowExecutionHistoryResponse{}, nil
+}
+
 func (t *testGRPCServer) PollActivityTaskQueue(
 	ctx context.Context,
 	req *workflowservice.PollActivityTaskQueueRequest,
@@ -0,0 +1,129 @@
+package converter
+
+import (
+	"fmt"
+
+	commonpb "go.temporal.io/api/common/v1"
+)
+
+// PayloadCodecRoute associates a PayloadCodec with the payloads it should be
+// applied to, based on payload metadata.
+type PayloadCodecRoute struct {
+	// MetadataKey is the payload metadata key to inspect, for example
+	// MetadataEncoding, an encryption key ID key, or a tenant header key.
+	// Required.
+	MetadataKey string
+
+	// MetadataValue is the metadata value the payload must have for this route
+	// to match. If empty, the route matches any payload that has MetadataKey set.
+	MetadataValue string
+
+	// Codec is the codec applied to matching payloads. Required.
+	Codec PayloadCodec
+}
+
+// RoutingCodecOptions are options for NewRoutingCodec.
+type RoutingCodecOptions struct {
+	// EncodeRoutes are evaluated in order against each payload being encoded and
+	// the codec of the first matching route is used.
+	EncodeRoutes []PayloadCodecRoute
+
+	// DefaultEncodeCodec is used to encode payloads that match no EncodeRoutes.
+	// If nil, such payloads are left unchanged.
+	DefaultEncodeCodec PayloadCodec
+
+	// DecodeRoutes are evaluated in order against each payload being decoded and
+	// the codec of the first matching route is used. Payloads that match no route
+	// are left unchanged. Routes for retired encodings should be kept here for as
+	// long as payloads encoded with them may still be read.
+	DecodeRoutes []PayloadCodecRoute
+}
+
+type routingCodec struct{ options RoutingCodecOptions }
+
+// NewRoutingCodec creates a PayloadCodec that selects among child codecs per
+// payload based on payload metadata. This enables gradual migrations between
+// compression or encryption schemes: new payloads are encoded with the current
+// codec while payloads written with older codecs are still decoded by them.
+// For example, to move from zlib to a new codec:
+//
+//	codec, err := converter.NewRoutingCodec(converter.RoutingCodecOptions{
+//		DefaultEncodeCodec: newCodec,
+//		DecodeRoutes: []converter.PayloadCodecRoute{
+//			{MetadataKey: converter.MetadataEncoding, MetadataValue: "binary/new", Codec: newCodec},
+//			{MetadataKey: converter.MetadataEncoding, MetadataValue: "binary/zlib", Codec: converter.NewZlibCodec(converter.ZlibCodecOptions{})},
+//		},
+//	})
+func NewRoutingCodec(options RoutingCodecOptions) (PayloadCodec, error) {
+	for _, routes := range [][]PayloadCodecRoute{options.EncodeRoutes, options.DecodeRoutes} {
+		for i, route := range routes {
+			if route.MetadataKey == "" {
+				return nil, fmt.Errorf("route %d: metadata key is required", i)
+			} else if route.Codec == nil {
+				return nil, fmt.Errorf("route %d: codec is required", i)
+			}
+		}
+	}
+	return &routingCodec{options}, nil
+}
+
+func (r *routingCodec) Encode(payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
+	return applyRoutedCodecs(payloads, r.options.EncodeRoutes, r.options.DefaultEncodeCodec, PayloadCodec.Encode)
+}
+
+func (r *routingCodec) Decode(payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
+	return applyRoutedCodecs(payloads, r.options.DecodeRoutes, nil, PayloadCodec.Decode)
+}
+
+func (route *PayloadCodecRoute) matches(payload *commonpb.Payload) bool {
+	value, ok := payload.GetMetadata()[route.MetadataKey]
+	return ok && (route.MetadataValue == "" || string(value) == route.MetadataValue)
+}
+
+// applyRoutedCodecs groups payloads by the route selected for them so each
+// codec is invoked once per call, then reassembles the results in order.
+func applyRoutedCodecs(
+	payloads []*commonpb.Payload,
+	routes []PayloadCodecRoute,
+	defaultCodec PayloadCodec,
+	apply func(PayloadCodec, []*commonpb.Payload) ([]*commonpb.Payload, error),
+) ([]*commonpb.Payload, error) {
+	// Group index len(routes) is used for the default codec
+	groups := make([][]int, len(routes)+1)
+	for i, p := range payloads {
+		group := len(routes)
+		for j := range routes {
+			if routes[j].matches(p) {
+				group = j
+				break
+			}
+		}
+		groups[group] = append(groups[group], i)
+	}
+
+	result := make([]*commonpb.Payload, len(payloads))
+	copy(result, payloads)
+	for group, indexes := range groups {
+		codec := defaultCodec
+		if group < len(routes) {
+			codec = routes[group].Codec
+		}
+		if codec == nil || len(indexes) == 0 {
+			continue
+		}
+		toApply := make([]*commonpb.Payload, len(indexes))
+		for i, index := range indexes {
+			toApply[i] = payloads[index]
+		}
+		applied, err := apply(codec, toApply)
+		if err != nil {
+			return payloads, err
+		} else if len(applied) != len(toApply) {
+			return payloads, fmt.Errorf("received %d payloads from codec, expected %d", len(applied), len(toApply))
+		}
+		for i, index := range indexes {
+			result[index] = applied[i]
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,73 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRoutingCodec(t *testing.T) {
+	oldCodec := &testCodec{encoding: "binary/old", encodeFrom: MetadataEncodingJSON}
+	newCodec := &testCodec{encoding: "binary/new", encodeFrom: MetadataEncodingJSON}
+	codec, err := NewRoutingCodec(RoutingCodecOptions{
+		DefaultEncodeCodec: newCodec,
+		DecodeRoutes: []PayloadCodecRoute{
+			{MetadataKey: MetadataEncoding, MetadataValue: "binary/new", Codec: newCodec},
+			{MetadataKey: MetadataEncoding, MetadataValue: "binary/old", Codec: oldCodec},
+		},
+	})
+	require.NoError(t, err)
+
+	plain, err := GetDefaultDataConverter().ToPayloads("first", "second")
+	require.NoError(t, err)
+
+	// New payloads are always encoded with the default encode codec
+	encoded, err := codec.Encode(plain.Payloads)
+	require.NoError(t, err)
+	require.Len(t, encoded, 2)
+	for _, p := range encoded {
+		require.Equal(t, "binary/new", string(p.Metadata[MetadataEncoding]))
+	}
+
+	// A mix of old, new, and unencoded payloads are each decoded by their own codec
+	oldEncoded, err := oldCodec.Encode(plain.Payloads[:1])
+	require.NoError(t, err)
+	mixed := []*commonpb.Payload{oldEncoded[0], encoded[1], plain.Payloads[0]}
+	decoded, err := codec.Decode(mixed)
+	require.NoError(t, err)
+	require.Len(t, decoded, 3)
+	require.True(t, proto.Equal(plain.Payloads[0], decoded[0]))
+	require.True(t, proto.Equal(plain.Payloads[1], decoded[1]))
+	require.Same(t, plain.Payloads[0], decoded[2])
+}
+
+func TestRoutingCodecEncodeRoutes(t *testing.T) {
+	tenantCodec := &testCodec{encoding: "binary/tenant", encodeFrom: MetadataEncodingJSON}
+	codec, err := NewRoutingCodec(RoutingCodecOptions{
+		EncodeRoutes: []PayloadCodecRoute{{MetadataKey: "tenant", Codec: tenantCodec}},
+	})
+	require.NoError(t, err)
+
+	plain, err := GetDefaultDataConverter().ToPayloads("first", "second")
+	require.NoError(t, err)
+	tenantPayload := proto.Clone(plain.Payloads[0]).(*commonpb.Payload)
+	tenantPayload.Metadata["tenant"] = []byte("acme")
+
+	encoded, err := codec.Encode([]*commonpb.Payload{tenantPayload, plain.Payloads[1]})
+	require.NoError(t, err)
+	require.Equal(t, "binary/tenant", string(encoded[0].Metadata[MetadataEncoding]))
+	require.Same(t, plain.Payloads[1], encoded[1])
+}
+
+func TestRoutingCodecValidation(t *testing.T) {
+	_, err := NewRoutingCodec(RoutingCodecOptions{
+		DecodeRoutes: []PayloadCodecRoute{{Codec: NewZlibCodec(ZlibCodecOptions{})}},
+	})
+	require.ErrorContains(t, err, "metadata key is required")
+	_, err = NewRoutingCodec(RoutingCodecOptions{
+		DecodeRoutes: []PayloadCodecRoute{{MetadataKey: MetadataEncoding}},
+	})
+	require.ErrorContains(t, err, "codec is required")
+}
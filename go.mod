@@ -15,7 +15,6 @@ require (
 	github.com/robfig/cron v1.2.0
 	github.com/stretchr/testify v1.10.0
 	go.temporal.io/api v1.49.0
-	golang.org/x/sync v0.13.0
 	golang.org/x/sys v0.32.0
 	golang.org/x/time v0.3.0
 	google.golang.org/grpc v1.66.0
@@ -0,0 +1,269 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/workflow"
+)
+
+const defaultDeadlineHeaderKey = "_temporal-deadline"
+
+type deadlineContextKey struct{}
+
+// DeadlinePropagationOptions are options for NewDeadlinePropagationInterceptor.
+type DeadlinePropagationOptions struct {
+	// HeaderKey is the header key the deadline is propagated in. Default is
+	// "_temporal-deadline".
+	HeaderKey string
+
+	// PropagateContextDeadline, if true, propagates the deadline of the context
+	// given to client calls that start workflows when no deadline was set with
+	// ContextWithPropagatedDeadline. Note this is the deadline of the call
+	// itself, so it should only be enabled when callers set a context deadline
+	// for the whole operation and not just for the call to the server.
+	PropagateContextDeadline bool
+}
+
+type deadlineInterceptor struct {
+	InterceptorBase
+	options DeadlinePropagationOptions
+}
+
+// NewDeadlinePropagationInterceptor creates an interceptor that propagates a
+// "must finish by" deadline from clients to workflows and from workflows to
+// their activities, child workflows, and continue-as-new runs. Activities
+// receive the deadline as the deadline of their context, so downstream calls
+// stop once the original caller has given up. The interceptor must be set on
+// both the client starting the workflows and the workers running them.
+//
+// The deadline of a client call is set with ContextWithPropagatedDeadline (or
+// taken from the context if PropagateContextDeadline is set), and can be
+// read and tightened in workflows with PropagatedDeadline and
+// WithPropagatedDeadline. The workflow itself is not stopped at the deadline,
+// it is up to the workflow code to act on it.
+//
+// NOTE: Experimental
+func NewDeadlinePropagationInterceptor(options DeadlinePropagationOptions) Interceptor {
+	if options.HeaderKey == "" {
+		options.HeaderKey = defaultDeadlineHeaderKey
+	}
+	return &deadlineInterceptor{options: options}
+}
+
+// ContextWithPropagatedDeadline returns a context with a deadline to be
+// propagated to workflows started with it by a client using the interceptor
+// from NewDeadlinePropagationInterceptor. Unlike context.WithDeadline, this
+// does not limit the call to the server.
+//
+// NOTE: Experimental
+func ContextWithPropagatedDeadline(ctx context.Context, deadline time.Time) context.Context {
+	return context.WithValue(ctx, deadlineContextKey{}, deadline)
+}
+
+// PropagatedDeadline returns the deadline propagated to the workflow, or set
+// with WithPropagatedDeadline, if any.
+//
+// NOTE: Experimental
+func PropagatedDeadline(ctx workflow.Context) (time.Time, bool) {
+	deadline, ok := ctx.Value(deadlineContextKey{}).(time.Time)
+	return deadline, ok
+}
+
+// WithPropagatedDeadline returns a workflow context with a deadline to be
+// propagated to activities, child workflows, and continue-as-new runs started
+// with it. The deadline is only set if it is earlier than the existing one.
+//
+// NOTE: Experimental
+func WithPropagatedDeadline(ctx workflow.Context, deadline time.Time) workflow.Context {
+	if existing, ok := PropagatedDeadline(ctx); ok && existing.Before(deadline) {
+		return ctx
+	}
+	return workflow.WithValue(ctx, deadlineContextKey{}, deadline)
+}
+
+func (d *deadlineInterceptor) InterceptClient(next ClientOutboundInterceptor) ClientOutboundInterceptor {
+	i := &deadlineClientOutboundInterceptor{root: d}
+	i.Next = next
+	return i
+}
+
+func (d *deadlineInterceptor) InterceptActivity(
+	ctx context.Context,
+	next ActivityInboundInterceptor,
+) ActivityInboundInterceptor {
+	i := &deadlineActivityInboundInterceptor{root: d}
+	i.Next = next
+	return i
+}
+
+func (d *deadlineInterceptor) InterceptWorkflow(
+	ctx workflow.Context,
+	next WorkflowInboundInterceptor,
+) WorkflowInboundInterceptor {
+	i := &deadlineWorkflowInboundInterceptor{root: d}
+	i.Next = next
+	return i
+}
+
+type deadlineClientOutboundInterceptor struct {
+	ClientOutboundInterceptorBase
+	root *deadlineInterceptor
+}
+
+func (d *deadlineClientOutboundInterceptor) ExecuteWorkflow(
+	ctx context.Context,
+	in *ClientExecuteWorkflowInput,
+) (client.WorkflowRun, error) {
+	if err := d.root.writeClientDeadline(ctx); err != nil {
+		return nil, err
+	}
+	return d.Next.ExecuteWorkflow(ctx, in)
+}
+
+func (d *deadlineClientOutboundInterceptor) SignalWithStartWorkflow(
+	ctx context.Context,
+	in *ClientSignalWithStartWorkflowInput,
+) (client.WorkflowRun, error) {
+	if err := d.root.writeClientDeadline(ctx); err != nil {
+		return nil, err
+	}
+	return d.Next.SignalWithStartWorkflow(ctx, in)
+}
+
+type deadlineActivityInboundInterceptor struct {
+	ActivityInboundInterceptorBase
+	root *deadlineInterceptor
+}
+
+func (d *deadlineActivityInboundInterceptor) ExecuteActivity(
+	ctx context.Context,
+	in *ExecuteActivityInput,
+) (interface{}, error) {
+	deadline, ok, err := d.root.readDeadline(Header(ctx))
+	if err != nil {
+		return nil, err
+	} else if ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+	return d.Next.ExecuteActivity(ctx, in)
+}
+
+type deadlineWorkflowInboundInterceptor struct {
+	WorkflowInboundInterceptorBase
+	root *deadlineInterceptor
+}
+
+func (d *deadlineWorkflowInboundInterceptor) Init(outbound WorkflowOutboundInterceptor) error {
+	i := &deadlineWorkflowOutboundInterceptor{root: d.root}
+	i.Next = outbound
+	return d.Next.Init(i)
+}
+
+func (d *deadlineWorkflowInboundInterceptor) ExecuteWorkflow(
+	ctx workflow.Context,
+	in *ExecuteWorkflowInput,
+) (interface{}, error) {
+	deadline, ok, err := d.root.readDeadline(WorkflowHeader(ctx))
+	if err != nil {
+		return nil, err
+	} else if ok {
+		ctx = workflow.WithValue(ctx, deadlineContextKey{}, deadline)
+	}
+	return d.Next.ExecuteWorkflow(ctx, in)
+}
+
+type deadlineWorkflowOutboundInterceptor struct {
+	WorkflowOutboundInterceptorBase
+	root *deadlineInterceptor
+}
+
+func (d *deadlineWorkflowOutboundInterceptor) ExecuteActivity(
+	ctx workflow.Context,
+	activityType string,
+	args ...interface{},
+) workflow.Future {
+	if err := d.root.writeWorkflowDeadline(ctx); err != nil {
+		return workflowFutureFromErr(ctx, err)
+	}
+	return d.Next.ExecuteActivity(ctx, activityType, args...)
+}
+
+func (d *deadlineWorkflowOutboundInterceptor) ExecuteLocalActivity(
+	ctx workflow.Context,
+	activityType string,
+	args ...interface{},
+) workflow.Future {
+	if err := d.root.writeWorkflowDeadline(ctx); err != nil {
+		return workflowFutureFromErr(ctx, err)
+	}
+	return d.Next.ExecuteLocalActivity(ctx, activityType, args...)
+}
+
+func (d *deadlineWorkflowOutboundInterceptor) ExecuteChildWorkflow(
+	ctx workflow.Context,
+	childWorkflowType string,
+	args ...interface{},
+) workflow.ChildWorkflowFuture {
+	if err := d.root.writeWorkflowDeadline(ctx); err != nil {
+		return childWorkflowFuture{workflowFutureFromErr(ctx, err)}
+	}
+	return d.Next.ExecuteChildWorkflow(ctx, childWorkflowType, args...)
+}
+
+func (d *deadlineWorkflowOutboundInterceptor) NewContinueAsNewError(
+	ctx workflow.Context,
+	wfn interface{},
+	args ...interface{},
+) error {
+	if err := d.root.writeWorkflowDeadline(ctx); err != nil {
+		return err
+	}
+	return d.Next.NewContinueAsNewError(ctx, wfn, args...)
+}
+
+func (d *deadlineInterceptor) writeClientDeadline(ctx context.Context) error {
+	deadline, ok := ctx.Value(deadlineContextKey{}).(time.Time)
+	if !ok && d.options.PropagateContextDeadline {
+		deadline, ok = ctx.Deadline()
+	}
+	if !ok {
+		return nil
+	}
+	return d.writeDeadline(deadline, Header(ctx))
+}
+
+func (d *deadlineInterceptor) writeWorkflowDeadline(ctx workflow.Context) error {
+	deadline, ok := PropagatedDeadline(ctx)
+	if !ok {
+		return nil
+	}
+	return d.writeDeadline(deadline, WorkflowHeader(ctx))
+}
+
+func (d *deadlineInterceptor) readDeadline(header map[string]*commonpb.Payload) (time.Time, bool, error) {
+	payload := header[d.options.HeaderKey]
+	if payload == nil {
+		return time.Time{}, false, nil
+	}
+	var deadline time.Time
+	if err := converter.GetDefaultDataConverter().FromPayload(payload, &deadline); err != nil {
+		return time.Time{}, false, err
+	}
+	return deadline, true, nil
+}
+
+func (d *deadlineInterceptor) writeDeadline(deadline time.Time, header map[string]*commonpb.Payload) error {
+	payload, err := converter.GetDefaultDataConverter().ToPayload(deadline)
+	if err != nil {
+		return err
+	}
+	header[d.options.HeaderKey] = payload
+	return nil
+}
@@ -0,0 +1,66 @@
+package interceptor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestDeadlinePropagationInterceptor(t *testing.T) {
+	deadline := time.Now().Add(time.Hour).Truncate(time.Second)
+	deadlineActivity := func(ctx context.Context) (time.Time, error) {
+		actDeadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		return actDeadline, nil
+	}
+	deadlineWorkflow := func(ctx workflow.Context) ([]time.Time, error) {
+		ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: 2 * time.Hour})
+		wfDeadline, ok := interceptor.PropagatedDeadline(ctx)
+		if !ok {
+			return nil, nil
+		}
+		var fromHeader, tightened time.Time
+		if err := workflow.ExecuteActivity(ctx, deadlineActivity).Get(ctx, &fromHeader); err != nil {
+			return nil, err
+		}
+		ctx = interceptor.WithPropagatedDeadline(ctx, wfDeadline.Add(-time.Minute))
+		// A later deadline does not replace an earlier one
+		ctx = interceptor.WithPropagatedDeadline(ctx, wfDeadline.Add(time.Minute))
+		if err := workflow.ExecuteActivity(ctx, deadlineActivity).Get(ctx, &tightened); err != nil {
+			return nil, err
+		}
+		return []time.Time{wfDeadline, fromHeader, tightened}, nil
+	}
+
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterActivity(deadlineActivity)
+	env.RegisterWorkflow(deadlineWorkflow)
+	env.SetWorkerOptions(worker.Options{
+		Interceptors: []interceptor.WorkerInterceptor{
+			interceptor.NewDeadlinePropagationInterceptor(interceptor.DeadlinePropagationOptions{}),
+		},
+	})
+	payload, err := converter.GetDefaultDataConverter().ToPayload(deadline)
+	require.NoError(t, err)
+	env.SetHeader(&commonpb.Header{Fields: map[string]*commonpb.Payload{"_temporal-deadline": payload}})
+
+	env.ExecuteWorkflow(deadlineWorkflow)
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var result []time.Time
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Len(t, result, 3)
+	require.True(t, deadline.Equal(result[0]))
+	require.True(t, deadline.Equal(result[1]))
+	require.True(t, deadline.Add(-time.Minute).Equal(result[2]))
+}
@@ -0,0 +1,184 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	failurepb "go.temporal.io/api/failure/v1"
+	"go.temporal.io/api/serviceerror"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"go.temporal.io/sdk/log"
+)
+
+const defaultGRPCLoggingMaxSummaryLength = 1024
+
+// GRPCLoggingOptions are options for NewGRPCLoggingClientInterceptor.
+type GRPCLoggingOptions struct {
+	// Logger calls are logged to. Required.
+	Logger log.Logger
+
+	// Enabled is called on every request to decide whether it is logged, so
+	// logging can be toggled at runtime, for example with an atomic.Bool's Load
+	// method. If not set, all requests are logged.
+	Enabled func() bool
+
+	// HashPayloads replaces the data of payloads in request summaries with its
+	// SHA-256 hash instead of omitting it, so requests carrying the same
+	// payloads can be correlated without logging their contents.
+	HashPayloads bool
+
+	// MaxSummaryLength is the maximum length in bytes of request summaries,
+	// longer summaries are truncated. If zero, this defaults to 1024. If
+	// negative, request summaries are not logged.
+	MaxSummaryLength int
+}
+
+// NewGRPCLoggingClientInterceptor returns a gRPC client interceptor that logs
+// every call with its method, latency, and status code, along with a summary of
+// the request, to help debug connectivity issues. Payloads are never logged:
+// their data is omitted from request summaries, or hashed if
+// GRPCLoggingOptions.HashPayloads is set, and only their encoding is kept from
+// their metadata. Headers are omitted, and so are the messages and stack traces
+// of failures. Successful calls are logged at info level and failed calls at
+// warn level. Request summaries are only built when the logger formats them, so
+// loggers that drop the line, such as one created with
+// [log.NewStructuredLogger] whose level is above it, do not pay for them. The
+// interceptor must be set on the client connection, for example:
+//
+//	var logCalls atomic.Bool
+//	c, err := client.Dial(client.Options{
+//		ConnectionOptions: client.ConnectionOptions{
+//			DialOptions: []grpc.DialOption{grpc.WithChainUnaryInterceptor(
+//				interceptor.NewGRPCLoggingClientInterceptor(interceptor.GRPCLoggingOptions{
+//					Logger:  logger,
+//					Enabled: logCalls.Load,
+//				}),
+//			)},
+//		},
+//	})
+//
+// NOTE: Experimental
+func NewGRPCLoggingClientInterceptor(options GRPCLoggingOptions) grpc.UnaryClientInterceptor {
+	if options.MaxSummaryLength == 0 {
+		options.MaxSummaryLength = defaultGRPCLoggingMaxSummaryLength
+	}
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if options.Enabled != nil && !options.Enabled() {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		keyvals := []interface{}{
+			"Method", method,
+			"Latency", time.Since(start),
+			"StatusCode", serviceerror.ToStatus(err).Code().String(),
+		}
+		if options.MaxSummaryLength > 0 {
+			keyvals = append(keyvals, "Request", requestSummary{
+				req:          req,
+				hashPayloads: options.HashPayloads,
+				maxLength:    options.MaxSummaryLength,
+			})
+		}
+		if err != nil {
+			options.Logger.Warn("gRPC call failed", append(keyvals, "Error", err)...)
+		} else {
+			options.Logger.Info("gRPC call completed", keyvals...)
+		}
+		return err
+	}
+}
+
+// requestSummary is the sanitized summary of a request, built when it is
+// formatted so that it costs nothing if the log line is dropped.
+type requestSummary struct {
+	req          interface{}
+	hashPayloads bool
+	maxLength    int
+}
+
+func (r requestSummary) String() string {
+	msg, ok := r.req.(proto.Message)
+	if !ok {
+		return ""
+	}
+	msg = proto.Clone(msg)
+	sanitizeMessage(msg.ProtoReflect(), r.hashPayloads)
+	summary := prototext.MarshalOptions{}.Format(msg)
+	if len(summary) > r.maxLength {
+		summary = summary[:r.maxLength] + "..."
+	}
+	return summary
+}
+
+// LogValue lets slog handlers, which do not all format values with String,
+// resolve the summary only once the record is handled.
+func (r requestSummary) LogValue() slog.Value {
+	return slog.StringValue(r.String())
+}
+
+// sanitizeMessage removes the data and all metadata but the encoding from all
+// payloads in the message, replacing the data with its hash if hashPayloads is
+// set. It also removes all headers, and the messages and stack traces of all
+// failures.
+func sanitizeMessage(msg protoreflect.Message, hashPayloads bool) {
+	switch m := msg.Interface().(type) {
+	case *commonpb.Payload:
+		sanitizePayload(m, hashPayloads)
+		return
+	case *commonpb.Header:
+		m.Fields = nil
+		return
+	case *failurepb.Failure:
+		m.Message = ""
+		m.StackTrace = ""
+	}
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					sanitizeMessage(v.Message(), hashPayloads)
+					return true
+				})
+			}
+		case fd.Message() == nil:
+		case fd.IsList():
+			for i := 0; i < v.List().Len(); i++ {
+				sanitizeMessage(v.List().Get(i).Message(), hashPayloads)
+			}
+		default:
+			sanitizeMessage(v.Message(), hashPayloads)
+		}
+		return true
+	})
+}
+
+func sanitizePayload(payload *commonpb.Payload, hashPayloads bool) {
+	var metadata map[string][]byte
+	if encoding, ok := payload.GetMetadata()["encoding"]; ok {
+		metadata = map[string][]byte{"encoding": encoding}
+	}
+	payload.Metadata = metadata
+	if hashPayloads && len(payload.GetData()) > 0 {
+		hash := sha256.Sum256(payload.GetData())
+		payload.Data = []byte("sha256:" + hex.EncodeToString(hash[:]))
+	} else {
+		payload.Data = nil
+	}
+}
@@ -0,0 +1,111 @@
+package interceptor_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	failurepb "go.temporal.io/api/failure/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc"
+
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/interceptor"
+	ilog "go.temporal.io/sdk/internal/log"
+	"go.temporal.io/sdk/log"
+)
+
+func TestGRPCLoggingClientInterceptor(t *testing.T) {
+	input, err := converter.GetDefaultDataConverter().ToPayloads("secret input")
+	require.NoError(t, err)
+	input.Payloads[0].Metadata["other"] = []byte("secret metadata")
+	req := &workflowservice.StartWorkflowExecutionRequest{
+		Namespace:    "ns",
+		WorkflowId:   "my-workflow-id",
+		WorkflowType: &commonpb.WorkflowType{Name: "MyWorkflow"},
+		Input:        input,
+		Memo: &commonpb.Memo{Fields: map[string]*commonpb.Payload{
+			"memo-key": input.Payloads[0],
+		}},
+		Header: &commonpb.Header{Fields: map[string]*commonpb.Payload{
+			"secret-header": input.Payloads[0],
+		}},
+		ContinuedFailure: &failurepb.Failure{
+			Message:    "secret message",
+			StackTrace: "secret stack trace",
+			FailureInfo: &failurepb.Failure_ApplicationFailureInfo{ApplicationFailureInfo: &failurepb.ApplicationFailureInfo{
+				Type: "MyError",
+			}},
+		},
+	}
+	call := func(intercept grpc.UnaryClientInterceptor, callErr error) {
+		err := intercept(context.Background(), "/temporal.api.workflowservice.v1.WorkflowService/StartWorkflowExecution",
+			req, &workflowservice.StartWorkflowExecutionResponse{}, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return callErr
+			})
+		require.Equal(t, callErr, err)
+	}
+
+	var enabled atomic.Bool
+	logger := ilog.NewMemoryLogger()
+	intercept := interceptor.NewGRPCLoggingClientInterceptor(interceptor.GRPCLoggingOptions{
+		Logger:  logger,
+		Enabled: enabled.Load,
+	})
+	call(intercept, nil)
+	require.Empty(t, logger.Lines())
+
+	enabled.Store(true)
+	call(intercept, nil)
+	call(intercept, serviceerror.NewUnavailable("unavailable"))
+	lines := logger.Lines()
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], "INFO  gRPC call completed")
+	require.Contains(t, lines[0], "StartWorkflowExecution")
+	require.Contains(t, lines[0], "StatusCode OK")
+	require.Contains(t, lines[0], "my-workflow-id")
+	require.Contains(t, lines[0], "json/plain")
+	require.Contains(t, lines[0], "memo-key")
+	require.Contains(t, lines[0], "MyError")
+	require.NotContains(t, lines[0], "secret")
+	require.Contains(t, lines[1], "WARN  gRPC call failed")
+	require.Contains(t, lines[1], "StatusCode Unavailable")
+	// The request itself is not modified
+	require.Equal(t, []byte("secret metadata"), input.Payloads[0].Metadata["other"])
+
+	// Hashed payloads
+	logger = ilog.NewMemoryLogger()
+	call(interceptor.NewGRPCLoggingClientInterceptor(interceptor.GRPCLoggingOptions{
+		Logger:       logger,
+		HashPayloads: true,
+	}), nil)
+	hash := sha256.Sum256(input.Payloads[0].Data)
+	require.Len(t, logger.Lines(), 1)
+	require.Contains(t, logger.Lines()[0], hex.EncodeToString(hash[:]))
+	require.NotContains(t, logger.Lines()[0], "secret")
+
+	// No summary
+	logger = ilog.NewMemoryLogger()
+	call(interceptor.NewGRPCLoggingClientInterceptor(interceptor.GRPCLoggingOptions{
+		Logger:           logger,
+		MaxSummaryLength: -1,
+	}), nil)
+	require.Len(t, logger.Lines(), 1)
+	require.NotContains(t, logger.Lines()[0], "my-workflow-id")
+
+	// Summaries are resolved by slog handlers that do not use String
+	var buf bytes.Buffer
+	call(interceptor.NewGRPCLoggingClientInterceptor(interceptor.GRPCLoggingOptions{
+		Logger: log.NewStructuredLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+	}), nil)
+	require.Contains(t, buf.String(), "my-workflow-id")
+	require.NotContains(t, buf.String(), "secret")
+}
@@ -103,6 +103,11 @@ type HandleSignalInput = internal.HandleSignalInput
 // HandleQueryInput is input for WorkflowInboundInterceptor.HandleQuery.
 type HandleQueryInput = internal.HandleQueryInput
 
+// HandleReplayedEventInput is input for WorkflowInboundInterceptor.HandleReplayedEvent.
+//
+// NOTE: Experimental
+type HandleReplayedEventInput = internal.HandleReplayedEventInput
+
 // UpdateInput is input for WorkflowInboundInterceptor.ExecuteUpdate
 // and WorkflowInboundInterceptor.ValidateUpdate.
 type UpdateInput = internal.UpdateInput
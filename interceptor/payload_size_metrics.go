@@ -0,0 +1,231 @@
+package interceptor
+
+import (
+	"context"
+	"sync"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/internal/common/metrics"
+)
+
+// PayloadSizeMetricsOptions are options for
+// NewPayloadSizeMetricsGRPCClientInterceptor.
+type PayloadSizeMetricsOptions struct {
+	// MetricsHandler is the handler sizes are recorded on as histograms. It, or
+	// a handler it wraps, must implement [client.MetricsHistogramHandler] for
+	// sizes to be recorded. Required.
+	MetricsHandler client.MetricsHandler
+}
+
+// maxTrackedActivityTasks bounds the activity tasks whose type is kept to tag
+// their completions, as tasks that time out are never responded to.
+const maxTrackedActivityTasks = 10000
+
+type payloadSizeMetrics struct {
+	handler client.MetricsHandler
+	// Activity tasks by task token so completions can be tagged, populated when
+	// activity tasks are received and removed when they are responded to. Once
+	// maxTrackedActivityTasks are tracked, the oldest ones are evicted.
+	activityTypesLock sync.Mutex
+	activityTypes     map[string]trackedActivityTask
+	// Task tokens by activity ID, see activityTaskID, for completions by ID
+	activityTokensByID map[string]string
+	// Task tokens in the order they were received, used as a ring of
+	// maxTrackedActivityTasks entries
+	activityTokens    []string
+	nextActivityToken int
+}
+
+type trackedActivityTask struct {
+	activityType string
+	id           string
+}
+
+// NewPayloadSizeMetricsGRPCClientInterceptor returns a gRPC client interceptor
+// that records the serialized size in bytes of payloads sent to and received
+// from the server, so payloads approaching server size limits can be found
+// before they are rejected. Sizes are recorded after any payload codecs have
+// been applied. Recorded metrics are:
+//
+//   - temporal_workflow_input_size, tagged by workflow_type, for workflows
+//     started by clients, as children, or via continue-as-new.
+//   - temporal_activity_input_size, tagged by activity_type, for activity
+//     tasks received by workers.
+//   - temporal_activity_output_size, tagged by activity_type, for activity
+//     results sent by workers, including results completed by activity ID.
+//     The type of activities completed from a process that did not receive
+//     their task is unknown.
+//   - temporal_workflow_task_history_size, tagged by workflow_type, for the
+//     history received with each workflow task.
+//
+// All metrics are also tagged by namespace. The interceptor must be set on the
+// client connection shared with workers to observe worker traffic, for example:
+//
+//	c, err := client.Dial(client.Options{
+//		ConnectionOptions: client.ConnectionOptions{
+//			DialOptions: []grpc.DialOption{grpc.WithChainUnaryInterceptor(
+//				interceptor.NewPayloadSizeMetricsGRPCClientInterceptor(interceptor.PayloadSizeMetricsOptions{
+//					MetricsHandler: handler,
+//				}),
+//			)},
+//		},
+//	})
+//
+// NOTE: Experimental
+func NewPayloadSizeMetricsGRPCClientInterceptor(options PayloadSizeMetricsOptions) grpc.UnaryClientInterceptor {
+	m := newPayloadSizeMetrics(options.MetricsHandler)
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		namespace := "_unknown_"
+		if nsReq, _ := req.(interface{ GetNamespace() string }); nsReq != nil {
+			namespace = nsReq.GetNamespace()
+		}
+		m.recordRequest(namespace, req)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			m.recordResponse(namespace, reply)
+		}
+		return err
+	}
+}
+
+func newPayloadSizeMetrics(handler client.MetricsHandler) *payloadSizeMetrics {
+	return &payloadSizeMetrics{
+		handler:            handler,
+		activityTypes:      map[string]trackedActivityTask{},
+		activityTokensByID: map[string]string{},
+	}
+}
+
+func (m *payloadSizeMetrics) recordRequest(namespace string, req interface{}) {
+	switch req := req.(type) {
+	case *workflowservice.StartWorkflowExecutionRequest:
+		m.recordWorkflowInput(namespace, req.GetWorkflowType().GetName(), req.GetInput())
+	case *workflowservice.SignalWithStartWorkflowExecutionRequest:
+		m.recordWorkflowInput(namespace, req.GetWorkflowType().GetName(), req.GetInput())
+	case *workflowservice.ExecuteMultiOperationRequest:
+		for _, op := range req.GetOperations() {
+			if start := op.GetStartWorkflow(); start != nil {
+				m.recordWorkflowInput(namespace, start.GetWorkflowType().GetName(), start.GetInput())
+			}
+		}
+	case *workflowservice.RespondWorkflowTaskCompletedRequest:
+		for _, command := range req.GetCommands() {
+			if attrs := command.GetStartChildWorkflowExecutionCommandAttributes(); attrs != nil {
+				m.recordWorkflowInput(namespace, attrs.GetWorkflowType().GetName(), attrs.GetInput())
+			} else if attrs := command.GetContinueAsNewWorkflowExecutionCommandAttributes(); attrs != nil {
+				m.recordWorkflowInput(namespace, attrs.GetWorkflowType().GetName(), attrs.GetInput())
+			}
+		}
+	case *workflowservice.RespondActivityTaskCompletedRequest:
+		activityType := m.takeActivityType(req.GetTaskToken())
+		m.record(metrics.ActivityOutputSize, namespace, metrics.ActivityTypeNameTagName, activityType, req.GetResult())
+	case *workflowservice.RespondActivityTaskCompletedByIdRequest:
+		activityType := m.takeActivityTypeByID(activityTaskID(namespace, req.GetWorkflowId(), req.GetActivityId()))
+		m.record(metrics.ActivityOutputSize, namespace, metrics.ActivityTypeNameTagName, activityType, req.GetResult())
+	case *workflowservice.RespondActivityTaskFailedRequest:
+		m.takeActivityType(req.GetTaskToken())
+	case *workflowservice.RespondActivityTaskFailedByIdRequest:
+		m.takeActivityTypeByID(activityTaskID(namespace, req.GetWorkflowId(), req.GetActivityId()))
+	case *workflowservice.RespondActivityTaskCanceledRequest:
+		m.takeActivityType(req.GetTaskToken())
+	}
+}
+
+func (m *payloadSizeMetrics) recordResponse(namespace string, reply interface{}) {
+	switch reply := reply.(type) {
+	case *workflowservice.PollWorkflowTaskQueueResponse:
+		if len(reply.GetTaskToken()) > 0 {
+			m.record(metrics.WorkflowTaskHistorySize, namespace,
+				metrics.WorkflowTypeNameTagName, reply.GetWorkflowType().GetName(), reply.GetHistory())
+		}
+	case *workflowservice.PollActivityTaskQueueResponse:
+		m.recordActivityTask(namespace, reply)
+	case *workflowservice.RespondWorkflowTaskCompletedResponse:
+		// Eagerly dispatched activity tasks
+		for _, task := range reply.GetActivityTasks() {
+			m.recordActivityTask(namespace, task)
+		}
+	}
+}
+
+func (m *payloadSizeMetrics) recordWorkflowInput(namespace, workflowType string, input *commonpb.Payloads) {
+	m.record(metrics.WorkflowInputSize, namespace, metrics.WorkflowTypeNameTagName, workflowType, input)
+}
+
+func (m *payloadSizeMetrics) recordActivityTask(namespace string, task *workflowservice.PollActivityTaskQueueResponse) {
+	if len(task.GetTaskToken()) == 0 {
+		return
+	}
+	activityType := task.GetActivityType().GetName()
+	id := activityTaskID(namespace, task.GetWorkflowExecution().GetWorkflowId(), task.GetActivityId())
+	m.trackActivityType(string(task.GetTaskToken()), id, activityType)
+	m.record(metrics.ActivityInputSize, namespace, metrics.ActivityTypeNameTagName, activityType, task.GetInput())
+}
+
+// activityTaskID identifies an activity task for completions by ID. The run ID
+// is left out as those completions may target the latest run without one.
+func activityTaskID(namespace, workflowID, activityID string) string {
+	return namespace + "/" + workflowID + "/" + activityID
+}
+
+func (m *payloadSizeMetrics) trackActivityType(taskToken, id, activityType string) {
+	m.activityTypesLock.Lock()
+	defer m.activityTypesLock.Unlock()
+	if len(m.activityTokens) < maxTrackedActivityTasks {
+		m.activityTokens = append(m.activityTokens, taskToken)
+	} else {
+		// Evict the oldest task, a no-op if it was already responded to
+		m.untrackActivityTask(m.activityTokens[m.nextActivityToken])
+		m.activityTokens[m.nextActivityToken] = taskToken
+		m.nextActivityToken = (m.nextActivityToken + 1) % maxTrackedActivityTasks
+	}
+	m.activityTypes[taskToken] = trackedActivityTask{activityType: activityType, id: id}
+	m.activityTokensByID[id] = taskToken
+}
+
+func (m *payloadSizeMetrics) takeActivityType(taskToken []byte) string {
+	m.activityTypesLock.Lock()
+	defer m.activityTypesLock.Unlock()
+	return m.untrackActivityTask(string(taskToken))
+}
+
+func (m *payloadSizeMetrics) takeActivityTypeByID(id string) string {
+	m.activityTypesLock.Lock()
+	defer m.activityTypesLock.Unlock()
+	taskToken, ok := m.activityTokensByID[id]
+	if !ok {
+		return "_unknown_"
+	}
+	return m.untrackActivityTask(taskToken)
+}
+
+// untrackActivityTask removes the task and returns its type. The lock must be
+// held.
+func (m *payloadSizeMetrics) untrackActivityTask(taskToken string) string {
+	task, ok := m.activityTypes[taskToken]
+	if !ok {
+		return "_unknown_"
+	}
+	delete(m.activityTypes, taskToken)
+	if m.activityTokensByID[task.id] == taskToken {
+		delete(m.activityTokensByID, task.id)
+	}
+	return task.activityType
+}
+
+func (m *payloadSizeMetrics) record(name, namespace, typeTagName, typeName string, msg proto.Message) {
+	handler := m.handler.WithTags(map[string]string{metrics.NamespaceTagName: namespace, typeTagName: typeName})
+	metrics.HistogramFromHandler(handler, name).RecordValue(float64(proto.Size(msg)))
+}
@@ -0,0 +1,25 @@
+package interceptor
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/sdk/internal/common/metrics"
+)
+
+func TestPayloadSizeMetricsActivityTypesBounded(t *testing.T) {
+	m := newPayloadSizeMetrics(metrics.NewCapturingHandler())
+	for i := 0; i < maxTrackedActivityTasks+10; i++ {
+		m.trackActivityType(strconv.Itoa(i), "id"+strconv.Itoa(i), "Activity"+strconv.Itoa(i))
+	}
+	require.Len(t, m.activityTypes, maxTrackedActivityTasks)
+	require.Len(t, m.activityTokensByID, maxTrackedActivityTasks)
+	require.Equal(t, "_unknown_", m.takeActivityType([]byte("9")))
+	require.Equal(t, "_unknown_", m.takeActivityTypeByID("id9"))
+	require.Equal(t, "Activity10", m.takeActivityType([]byte("10")))
+	require.Equal(t, "Activity11", m.takeActivityTypeByID("id11"))
+	require.Len(t, m.activityTypes, maxTrackedActivityTasks-2)
+	require.Len(t, m.activityTokensByID, maxTrackedActivityTasks-2)
+}
@@ -0,0 +1,152 @@
+package interceptor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	commandpb "go.temporal.io/api/command/v1"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/internal/common/metrics"
+)
+
+// newPayloadSizeMetricsCall returns a function calling the interceptor with the
+// given request and reply, as if the server responded with response.
+func newPayloadSizeMetricsCall(t *testing.T, handler client.MetricsHandler) func(req, reply interface{}, response proto.Message) {
+	intercept := interceptor.NewPayloadSizeMetricsGRPCClientInterceptor(
+		interceptor.PayloadSizeMetricsOptions{MetricsHandler: handler})
+	return func(req, reply interface{}, response proto.Message) {
+		err := intercept(context.Background(), "method", req, reply, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				if response != nil {
+					proto.Merge(reply.(proto.Message), response)
+				}
+				return nil
+			})
+		require.NoError(t, err)
+	}
+}
+
+func TestPayloadSizeMetricsGRPCClientInterceptor(t *testing.T) {
+	handler := metrics.NewCapturingHandler()
+	call := newPayloadSizeMetricsCall(t, handler)
+	input, err := converter.GetDefaultDataConverter().ToPayloads("some input")
+	require.NoError(t, err)
+
+	call(&workflowservice.StartWorkflowExecutionRequest{
+		Namespace:    "ns",
+		WorkflowType: &commonpb.WorkflowType{Name: "MyWorkflow"},
+		Input:        input,
+	}, &workflowservice.StartWorkflowExecutionResponse{}, nil)
+	call(&workflowservice.RespondWorkflowTaskCompletedRequest{
+		Namespace: "ns",
+		Commands: []*commandpb.Command{{
+			Attributes: &commandpb.Command_StartChildWorkflowExecutionCommandAttributes{
+				StartChildWorkflowExecutionCommandAttributes: &commandpb.StartChildWorkflowExecutionCommandAttributes{
+					WorkflowType: &commonpb.WorkflowType{Name: "MyChildWorkflow"},
+					Input:        input,
+				},
+			},
+		}},
+	}, &workflowservice.RespondWorkflowTaskCompletedResponse{}, nil)
+	call(&workflowservice.PollActivityTaskQueueRequest{Namespace: "ns"}, &workflowservice.PollActivityTaskQueueResponse{},
+		&workflowservice.PollActivityTaskQueueResponse{
+			TaskToken:    []byte("token"),
+			ActivityType: &commonpb.ActivityType{Name: "MyActivity"},
+			Input:        input,
+		})
+	call(&workflowservice.RespondActivityTaskCompletedRequest{
+		Namespace: "ns",
+		TaskToken: []byte("token"),
+		Result:    input,
+	}, &workflowservice.RespondActivityTaskCompletedResponse{}, nil)
+
+	histograms := map[string][]float64{}
+	for _, histogram := range handler.Histograms() {
+		require.Equal(t, "ns", histogram.Tags[metrics.NamespaceTagName])
+		typeName := histogram.Tags[metrics.WorkflowTypeNameTagName] + histogram.Tags[metrics.ActivityTypeNameTagName]
+		histograms[histogram.Name+"/"+typeName] = histogram.Values()
+	}
+	size := float64(proto.Size(input))
+	require.Equal(t, map[string][]float64{
+		metrics.WorkflowInputSize + "/MyWorkflow":      {size},
+		metrics.WorkflowInputSize + "/MyChildWorkflow": {size},
+		metrics.ActivityInputSize + "/MyActivity":      {size},
+		metrics.ActivityOutputSize + "/MyActivity":     {size},
+	}, histograms)
+}
+
+func TestPayloadSizeMetricsActivityTaskCompletedByID(t *testing.T) {
+	handler := metrics.NewCapturingHandler()
+	call := newPayloadSizeMetricsCall(t, handler)
+	result, err := converter.GetDefaultDataConverter().ToPayloads("some result")
+	require.NoError(t, err)
+
+	call(&workflowservice.PollActivityTaskQueueRequest{Namespace: "ns"}, &workflowservice.PollActivityTaskQueueResponse{},
+		&workflowservice.PollActivityTaskQueueResponse{
+			TaskToken:         []byte("token"),
+			WorkflowExecution: &commonpb.WorkflowExecution{WorkflowId: "wid", RunId: "rid"},
+			ActivityId:        "aid",
+			ActivityType:      &commonpb.ActivityType{Name: "MyActivity"},
+		})
+	call(&workflowservice.RespondActivityTaskCompletedByIdRequest{
+		Namespace:  "ns",
+		WorkflowId: "wid",
+		ActivityId: "aid",
+		Result:     result,
+	}, &workflowservice.RespondActivityTaskCompletedByIdResponse{}, nil)
+	// Not tracked, so the type is unknown
+	call(&workflowservice.RespondActivityTaskCompletedByIdRequest{
+		Namespace:  "ns",
+		WorkflowId: "wid",
+		ActivityId: "other",
+		Result:     result,
+	}, &workflowservice.RespondActivityTaskCompletedByIdResponse{}, nil)
+
+	outputSizes := map[string][]float64{}
+	for _, histogram := range handler.Histograms() {
+		if histogram.Name == metrics.ActivityOutputSize {
+			outputSizes[histogram.Tags[metrics.ActivityTypeNameTagName]] = histogram.Values()
+		}
+	}
+	size := float64(proto.Size(result))
+	require.Equal(t, map[string][]float64{"MyActivity": {size}, "_unknown_": {size}}, outputSizes)
+}
+
+func TestPayloadSizeMetricsActivityTaskFailedByID(t *testing.T) {
+	handler := metrics.NewCapturingHandler()
+	call := newPayloadSizeMetricsCall(t, handler)
+
+	call(&workflowservice.PollActivityTaskQueueRequest{Namespace: "ns"}, &workflowservice.PollActivityTaskQueueResponse{},
+		&workflowservice.PollActivityTaskQueueResponse{
+			TaskToken:         []byte("token"),
+			WorkflowExecution: &commonpb.WorkflowExecution{WorkflowId: "wid", RunId: "rid"},
+			ActivityId:        "aid",
+			ActivityType:      &commonpb.ActivityType{Name: "MyActivity"},
+		})
+	call(&workflowservice.RespondActivityTaskFailedByIdRequest{
+		Namespace:  "ns",
+		WorkflowId: "wid",
+		ActivityId: "aid",
+	}, &workflowservice.RespondActivityTaskFailedByIdResponse{}, nil)
+	// The task is no longer tracked once failed
+	call(&workflowservice.RespondActivityTaskCompletedRequest{
+		Namespace: "ns",
+		TaskToken: []byte("token"),
+	}, &workflowservice.RespondActivityTaskCompletedResponse{}, nil)
+
+	var outputTypes []string
+	for _, histogram := range handler.Histograms() {
+		if histogram.Name == metrics.ActivityOutputSize {
+			outputTypes = append(outputTypes, histogram.Tags[metrics.ActivityTypeNameTagName])
+		}
+	}
+	require.Equal(t, []string{"_unknown_"}, outputTypes)
+}
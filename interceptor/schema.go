@@ -0,0 +1,123 @@
+package interceptor
+
+import (
+	"fmt"
+	"reflect"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"go.temporal.io/sdk/converter"
+)
+
+type protoSchema struct {
+	descriptor protoreflect.MessageDescriptor
+}
+
+// NewProtoSchema creates a Schema accepting protobuf messages of the type described by the descriptor, for example one
+// obtained from a schema registry. Messages must have the same full name as the descriptor, only set fields that the
+// descriptor has with the same name, kind and cardinality, and have no unknown fields, which are kept when messages are
+// decoded from the binary encoding by a service with an older schema.
+//
+// NOTE: Experimental
+func NewProtoSchema(descriptor protoreflect.MessageDescriptor) Schema {
+	return &protoSchema{descriptor: descriptor}
+}
+
+func (s *protoSchema) Validate(value interface{}) error {
+	if payload, ok := value.(*commonpb.Payload); ok {
+		msg, err := s.decode(payload)
+		if err != nil {
+			return err
+		}
+		value = msg
+	}
+	msg, ok := value.(proto.Message)
+	if !ok || value == nil || reflect.ValueOf(value).IsNil() {
+		return fmt.Errorf("expected %s message, got %T", s.descriptor.FullName(), value)
+	}
+	return validateProtoMessage(msg.ProtoReflect(), s.descriptor, string(s.descriptor.FullName()))
+}
+
+// decode returns the message of the type of the schema encoded in the payload.
+func (s *protoSchema) decode(payload *commonpb.Payload) (proto.Message, error) {
+	if messageType, ok := payload.GetMetadata()[converter.MetadataMessageType]; ok &&
+		string(messageType) != string(s.descriptor.FullName()) {
+		return nil, fmt.Errorf("expected %s message, got %s", s.descriptor.FullName(), messageType)
+	}
+	msg := dynamicpb.NewMessage(s.descriptor)
+	switch encoding := string(payload.GetMetadata()[converter.MetadataEncoding]); encoding {
+	case converter.MetadataEncodingProtoJSON:
+		// Unknown fields are rejected when decoding JSON
+		if err := protojson.Unmarshal(payload.GetData(), msg); err != nil {
+			return nil, err
+		}
+	case converter.MetadataEncodingProto:
+		if err := proto.Unmarshal(payload.GetData(), msg); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("expected %s message, got value encoded as %s", s.descriptor.FullName(), encoding)
+	}
+	return msg, nil
+}
+
+func validateProtoMessage(msg protoreflect.Message, descriptor protoreflect.MessageDescriptor, path string) error {
+	if msg.Descriptor().FullName() != descriptor.FullName() {
+		return fmt.Errorf("%s: expected %s message, got %s", path, descriptor.FullName(), msg.Descriptor().FullName())
+	}
+	if len(msg.GetUnknown()) > 0 {
+		return fmt.Errorf("%s: message has unknown fields", path)
+	}
+	var err error
+	msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		fieldPath := path + "." + string(field.Name())
+		expected := descriptor.Fields().ByNumber(field.Number())
+		switch {
+		case expected == nil:
+			err = fmt.Errorf("%s: field number %d is not in the schema", fieldPath, field.Number())
+		case expected.Name() != field.Name():
+			err = fmt.Errorf("%s: field number %d is named %s in the schema", fieldPath, field.Number(), expected.Name())
+		case expected.Kind() != field.Kind() || expected.Cardinality() != field.Cardinality() ||
+			expected.IsMap() != field.IsMap():
+			err = fmt.Errorf("%s: field type does not match the schema", fieldPath)
+		default:
+			err = validateProtoField(field, expected, value, fieldPath)
+		}
+		return err == nil
+	})
+	return err
+}
+
+func validateProtoField(field, expected protoreflect.FieldDescriptor, value protoreflect.Value, path string) error {
+	switch {
+	case field.IsMap():
+		if field.MapKey().Kind() != expected.MapKey().Kind() || field.MapValue().Kind() != expected.MapValue().Kind() {
+			return fmt.Errorf("%s: field type does not match the schema", path)
+		}
+		if field.MapValue().Message() == nil {
+			return nil
+		}
+		var err error
+		value.Map().Range(func(key protoreflect.MapKey, entry protoreflect.Value) bool {
+			err = validateProtoMessage(entry.Message(), expected.MapValue().Message(), fmt.Sprintf("%s[%v]", path, key))
+			return err == nil
+		})
+		return err
+	case field.Message() == nil:
+		return nil
+	case field.IsList():
+		list := value.List()
+		for i := 0; i < list.Len(); i++ {
+			if err := validateProtoMessage(list.Get(i).Message(), expected.Message(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return validateProtoMessage(value.Message(), expected.Message(), path)
+	}
+}
@@ -0,0 +1,191 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+
+	commonpb "go.temporal.io/api/common/v1"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// SchemaValidationErrorType is the type of the non-retryable application errors returned when an activity input or
+// output does not match its schema.
+//
+// NOTE: Experimental
+const SchemaValidationErrorType = "SchemaValidationError"
+
+// Schema validates values sent to or returned by activities. Protobuf schemas are created with NewProtoSchema, and JSON
+// schemas with NewSchema of the go.temporal.io/sdk/contrib/jsonschema module.
+//
+// NOTE: Experimental
+type Schema interface {
+	// Validate returns an error describing why the value does not match the schema, or nil if it does. Values that are
+	// already encoded, like the results of mocked activities, are given as *commonpb.Payload.
+	Validate(value interface{}) error
+}
+
+// ActivitySchemas are the schemas of the inputs and output of an activity.
+//
+// NOTE: Experimental
+type ActivitySchemas struct {
+	// Args are the schemas of the activity arguments, by position, not including the context. Arguments without a
+	// schema, either because the schema is nil or because there are more arguments than schemas, are not validated.
+	Args []Schema
+
+	// Result is the schema of the activity result. Optional, the result is not validated if nil.
+	Result Schema
+}
+
+// SchemaValidationOptions are options for NewSchemaValidationInterceptor.
+//
+// NOTE: Experimental
+type SchemaValidationOptions struct {
+	// Activities are the schemas of activities by activity type. Activities without schemas are not validated.
+	Activities map[string]ActivitySchemas
+}
+
+type schemaValidationInterceptor struct {
+	InterceptorBase
+	options SchemaValidationOptions
+}
+
+// NewSchemaValidationInterceptor creates an interceptor that validates activity inputs and outputs against the
+// registered schemas, so drift between the services producing and consuming them fails fast with a descriptive error
+// instead of surfacing as corrupt data later on.
+//
+// Set on workers running workflows, activity arguments are validated before activities and local activities are
+// scheduled, failing the activity future without scheduling the activity. Set on workers running activities, the
+// arguments are validated before the activity is executed and the result after, failing the activity. In all cases the
+// error is a non-retryable application error of type SchemaValidationErrorType wrapping the validation error.
+//
+// As workflows replay activity scheduling, changing the schemas of activities scheduled by open workflows so that
+// arguments no longer match can cause non-determinism errors.
+//
+// NOTE: Experimental
+func NewSchemaValidationInterceptor(options SchemaValidationOptions) Interceptor {
+	return &schemaValidationInterceptor{options: options}
+}
+
+func (s *schemaValidationInterceptor) InterceptActivity(
+	ctx context.Context,
+	next ActivityInboundInterceptor,
+) ActivityInboundInterceptor {
+	i := &schemaValidationActivityInboundInterceptor{root: s}
+	i.Next = next
+	return i
+}
+
+func (s *schemaValidationInterceptor) InterceptWorkflow(
+	ctx workflow.Context,
+	next WorkflowInboundInterceptor,
+) WorkflowInboundInterceptor {
+	i := &schemaValidationWorkflowInboundInterceptor{root: s}
+	i.Next = next
+	return i
+}
+
+type schemaValidationActivityInboundInterceptor struct {
+	ActivityInboundInterceptorBase
+	root *schemaValidationInterceptor
+}
+
+func (s *schemaValidationActivityInboundInterceptor) ExecuteActivity(
+	ctx context.Context,
+	in *ExecuteActivityInput,
+) (interface{}, error) {
+	activityType := activity.GetInfo(ctx).ActivityType.Name
+	schemas, ok := s.root.options.Activities[activityType]
+	if !ok {
+		return s.Next.ExecuteActivity(ctx, in)
+	}
+	if err := validateActivityArgs(activityType, schemas, in.Args); err != nil {
+		return nil, err
+	}
+	result, err := s.Next.ExecuteActivity(ctx, in)
+	if err == nil && schemas.Result != nil {
+		if validationErr := schemas.Result.Validate(schemaResultValue(result)); validationErr != nil {
+			return nil, newSchemaValidationError(fmt.Sprintf("activity %s result", activityType), validationErr)
+		}
+	}
+	return result, err
+}
+
+type schemaValidationWorkflowInboundInterceptor struct {
+	WorkflowInboundInterceptorBase
+	root *schemaValidationInterceptor
+}
+
+func (s *schemaValidationWorkflowInboundInterceptor) Init(outbound WorkflowOutboundInterceptor) error {
+	i := &schemaValidationWorkflowOutboundInterceptor{root: s.root}
+	i.Next = outbound
+	return s.Next.Init(i)
+}
+
+type schemaValidationWorkflowOutboundInterceptor struct {
+	WorkflowOutboundInterceptorBase
+	root *schemaValidationInterceptor
+}
+
+func (s *schemaValidationWorkflowOutboundInterceptor) ExecuteActivity(
+	ctx workflow.Context,
+	activityType string,
+	args ...interface{},
+) workflow.Future {
+	if schemas, ok := s.root.options.Activities[activityType]; ok {
+		if err := validateActivityArgs(activityType, schemas, args); err != nil {
+			return workflowFutureFromErr(ctx, err)
+		}
+	}
+	return s.Next.ExecuteActivity(ctx, activityType, args...)
+}
+
+func (s *schemaValidationWorkflowOutboundInterceptor) ExecuteLocalActivity(
+	ctx workflow.Context,
+	activityType string,
+	args ...interface{},
+) workflow.Future {
+	if schemas, ok := s.root.options.Activities[activityType]; ok {
+		if err := validateActivityArgs(activityType, schemas, args); err != nil {
+			return workflowFutureFromErr(ctx, err)
+		}
+	}
+	return s.Next.ExecuteLocalActivity(ctx, activityType, args...)
+}
+
+func validateActivityArgs(activityType string, schemas ActivitySchemas, args []interface{}) error {
+	for i, arg := range args {
+		if i >= len(schemas.Args) {
+			break
+		}
+		if schemas.Args[i] == nil {
+			continue
+		}
+		if err := schemas.Args[i].Validate(arg); err != nil {
+			return newSchemaValidationError(fmt.Sprintf("activity %s argument %d", activityType, i), err)
+		}
+	}
+	return nil
+}
+
+// schemaResultValue returns the activity result to validate, which is its payload if the result is already encoded.
+func schemaResultValue(result interface{}) interface{} {
+	payloads, ok := result.(*commonpb.Payloads)
+	if !ok {
+		return result
+	}
+	if len(payloads.GetPayloads()) == 0 {
+		return nil
+	}
+	return payloads.GetPayloads()[0]
+}
+
+func newSchemaValidationError(subject string, err error) error {
+	return temporal.NewNonRetryableApplicationError(
+		fmt.Sprintf("%s does not match schema: %v", subject, err),
+		SchemaValidationErrorType,
+		err,
+	)
+}
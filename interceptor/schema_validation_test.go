@@ -0,0 +1,153 @@
+package interceptor_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+type schemaOrder struct {
+	ID       string `json:"id"`
+	Quantity int    `json:"quantity"`
+}
+
+// testSchema validates values decoded into the type of T with a function.
+type testSchema[T any] func(T) error
+
+func (s testSchema[T]) Validate(value interface{}) error {
+	payload, ok := value.(*commonpb.Payload)
+	if !ok {
+		var err error
+		if payload, err = converter.GetDefaultDataConverter().ToPayload(value); err != nil {
+			return err
+		}
+	}
+	var decoded T
+	if err := converter.GetDefaultDataConverter().FromPayload(payload, &decoded); err != nil {
+		return err
+	}
+	return s(decoded)
+}
+
+func TestSchemaValidationInterceptor(t *testing.T) {
+	orderSchema := testSchema[schemaOrder](func(order schemaOrder) error {
+		if order.Quantity < 1 {
+			return fmt.Errorf("quantity %d is less than 1", order.Quantity)
+		}
+		return nil
+	})
+	resultSchema := testSchema[string](func(status string) error {
+		if status != "accepted" {
+			return fmt.Errorf("unexpected status %q", status)
+		}
+		return nil
+	})
+
+	placeOrder := func(ctx context.Context, order schemaOrder, status string) (string, error) {
+		return status, nil
+	}
+	placeOrderWorkflow := func(ctx workflow.Context, order schemaOrder, status string, local bool) (string, error) {
+		ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+		ctx = workflow.WithLocalActivityOptions(ctx, workflow.LocalActivityOptions{StartToCloseTimeout: time.Minute})
+		var result string
+		var err error
+		if local {
+			err = workflow.ExecuteLocalActivity(ctx, "PlaceOrder", order, status).Get(ctx, &result)
+		} else {
+			err = workflow.ExecuteActivity(ctx, "PlaceOrder", order, status).Get(ctx, &result)
+		}
+		return result, err
+	}
+	execute := func(order schemaOrder, status string, local bool) (string, error) {
+		var suite testsuite.WorkflowTestSuite
+		env := suite.NewTestWorkflowEnvironment()
+		env.RegisterActivityWithOptions(placeOrder, activity.RegisterOptions{Name: "PlaceOrder"})
+		env.RegisterWorkflow(placeOrderWorkflow)
+		env.SetWorkerOptions(worker.Options{
+			Interceptors: []interceptor.WorkerInterceptor{
+				interceptor.NewSchemaValidationInterceptor(interceptor.SchemaValidationOptions{
+					Activities: map[string]interceptor.ActivitySchemas{
+						"PlaceOrder": {Args: []interceptor.Schema{orderSchema}, Result: resultSchema},
+					},
+				}),
+			},
+		})
+		env.ExecuteWorkflow(placeOrderWorkflow, order, status, local)
+		require.True(t, env.IsWorkflowCompleted())
+		if err := env.GetWorkflowError(); err != nil {
+			return "", err
+		}
+		var result string
+		require.NoError(t, env.GetWorkflowResult(&result))
+		return result, nil
+	}
+
+	for _, local := range []bool{false, true} {
+		result, err := execute(schemaOrder{ID: "order-1", Quantity: 2}, "accepted", local)
+		require.NoError(t, err)
+		require.Equal(t, "accepted", result)
+
+		// Invalid arguments fail the activity without running it
+		_, err = execute(schemaOrder{ID: "order-1"}, "accepted", local)
+		var applicationErr *temporal.ApplicationError
+		require.True(t, errors.As(err, &applicationErr))
+		require.Equal(t, interceptor.SchemaValidationErrorType, applicationErr.Type())
+		require.True(t, applicationErr.NonRetryable())
+		require.ErrorContains(t, err, "activity PlaceOrder argument 0 does not match schema: quantity 0 is less than 1")
+
+		// Invalid results fail the activity
+		_, err = execute(schemaOrder{ID: "order-1", Quantity: 2}, "rejected", local)
+		require.True(t, errors.As(err, &applicationErr))
+		require.Equal(t, interceptor.SchemaValidationErrorType, applicationErr.Type())
+		require.ErrorContains(t, err, "activity PlaceOrder result does not match schema: unexpected status \"rejected\"")
+	}
+}
+
+func TestProtoSchema(t *testing.T) {
+	schema := interceptor.NewProtoSchema((&commonpb.WorkflowExecution{}).ProtoReflect().Descriptor())
+	execution := &commonpb.WorkflowExecution{WorkflowId: "workflow", RunId: "run"}
+	require.NoError(t, schema.Validate(execution))
+	require.EqualError(t, schema.Validate(&commonpb.WorkflowType{}),
+		"temporal.api.common.v1.WorkflowExecution: expected temporal.api.common.v1.WorkflowExecution message, got temporal.api.common.v1.WorkflowType")
+	require.EqualError(t, schema.Validate("workflow"), "expected temporal.api.common.v1.WorkflowExecution message, got string")
+
+	// Encoded messages are decoded with the schema
+	for _, dataConverter := range []converter.DataConverter{
+		converter.NewCompositeDataConverter(converter.NewProtoJSONPayloadConverter()),
+		converter.NewCompositeDataConverter(converter.NewProtoPayloadConverter()),
+	} {
+		payload, err := dataConverter.ToPayload(execution)
+		require.NoError(t, err)
+		require.NoError(t, schema.Validate(payload))
+		payload, err = dataConverter.ToPayload(&commonpb.WorkflowType{})
+		require.NoError(t, err)
+		require.EqualError(t, schema.Validate(payload),
+			"expected temporal.api.common.v1.WorkflowExecution message, got temporal.api.common.v1.WorkflowType")
+	}
+
+	// Fields added by the producer are kept as unknown fields by consumers with an older schema
+	b, err := proto.Marshal(&commonpb.Payload{Data: []byte("data")})
+	require.NoError(t, err)
+	b = protowire.AppendVarint(protowire.AppendTag(b, 99, protowire.VarintType), 1)
+	var payload commonpb.Payload
+	require.NoError(t, proto.Unmarshal(b, &payload))
+	schema = interceptor.NewProtoSchema((&commonpb.Payloads{}).ProtoReflect().Descriptor())
+	require.NoError(t, schema.Validate(&commonpb.Payloads{Payloads: []*commonpb.Payload{{}, {}}}))
+	require.EqualError(t, schema.Validate(&commonpb.Payloads{Payloads: []*commonpb.Payload{{}, &payload}}),
+		"temporal.api.common.v1.Payloads.payloads[1]: message has unknown fields")
+}
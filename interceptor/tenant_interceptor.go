@@ -0,0 +1,322 @@
+package interceptor
+
+import (
+	"context"
+
+	commonpb "go.temporal.io/api/common/v1"
+
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	defaultTenantHeaderKey = "_temporal-tenant"
+	defaultTenantTagName   = "tenant"
+)
+
+type tenantContextKey struct{}
+
+// TenantOptions are options for NewTenantInterceptor.
+type TenantOptions struct {
+	// HeaderKey is the header key the tenant is propagated in. Default is
+	// "_temporal-tenant".
+	HeaderKey string
+
+	// TagName is the key of the tenant in logs and the name of the tenant tag
+	// of metrics. Default is "tenant".
+	TagName string
+
+	// SearchAttribute, if its name is set, is the keyword search attribute the
+	// tenant is stored in, so workflows can be listed by tenant. It is set when
+	// workflows are started by clients, and upserted by workflows started
+	// without it, like child workflows. The search attribute must be
+	// registered on the namespace.
+	SearchAttribute temporal.SearchAttributeKeyKeyword
+}
+
+type tenantInterceptor struct {
+	InterceptorBase
+	options TenantOptions
+}
+
+// NewTenantInterceptor creates an interceptor that propagates a tenant, or any
+// other partition key, from clients to workflows and from workflows to their
+// activities, child workflows, and continue-as-new runs, and tags the logs and
+// metrics of the workflows and activities with it. The interceptor must be set
+// on both the client starting the workflows and the workers running them.
+//
+// The tenant of a client call is set with ContextWithTenant, and read in
+// workflows with WorkflowTenant and in activities with TenantFromContext.
+// Workflows started without a tenant are not tagged.
+//
+// NOTE: Experimental
+func NewTenantInterceptor(options TenantOptions) Interceptor {
+	if options.HeaderKey == "" {
+		options.HeaderKey = defaultTenantHeaderKey
+	}
+	if options.TagName == "" {
+		options.TagName = defaultTenantTagName
+	}
+	return &tenantInterceptor{options: options}
+}
+
+// ContextWithTenant returns a context with a tenant to be propagated to
+// workflows started with it by a client using the interceptor from
+// NewTenantInterceptor.
+//
+// NOTE: Experimental
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant set with ContextWithTenant, or the
+// tenant of the workflow that scheduled the activity in activities.
+//
+// NOTE: Experimental
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantContextKey{}).(string)
+	return tenant, ok
+}
+
+// WorkflowTenant returns the tenant propagated to the workflow, if any.
+//
+// NOTE: Experimental
+func WorkflowTenant(ctx workflow.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantContextKey{}).(string)
+	return tenant, ok
+}
+
+func (t *tenantInterceptor) InterceptClient(next ClientOutboundInterceptor) ClientOutboundInterceptor {
+	i := &tenantClientOutboundInterceptor{root: t}
+	i.Next = next
+	return i
+}
+
+func (t *tenantInterceptor) InterceptActivity(
+	ctx context.Context,
+	next ActivityInboundInterceptor,
+) ActivityInboundInterceptor {
+	i := &tenantActivityInboundInterceptor{root: t}
+	i.Next = next
+	return i
+}
+
+func (t *tenantInterceptor) InterceptWorkflow(
+	ctx workflow.Context,
+	next WorkflowInboundInterceptor,
+) WorkflowInboundInterceptor {
+	i := &tenantWorkflowInboundInterceptor{root: t}
+	i.Next = next
+	return i
+}
+
+type tenantClientOutboundInterceptor struct {
+	ClientOutboundInterceptorBase
+	root *tenantInterceptor
+}
+
+func (t *tenantClientOutboundInterceptor) ExecuteWorkflow(
+	ctx context.Context,
+	in *ClientExecuteWorkflowInput,
+) (client.WorkflowRun, error) {
+	if err := t.root.writeClientTenant(ctx, in.Options); err != nil {
+		return nil, err
+	}
+	return t.Next.ExecuteWorkflow(ctx, in)
+}
+
+func (t *tenantClientOutboundInterceptor) SignalWithStartWorkflow(
+	ctx context.Context,
+	in *ClientSignalWithStartWorkflowInput,
+) (client.WorkflowRun, error) {
+	if err := t.root.writeClientTenant(ctx, in.Options); err != nil {
+		return nil, err
+	}
+	return t.Next.SignalWithStartWorkflow(ctx, in)
+}
+
+type tenantActivityInboundInterceptor struct {
+	ActivityInboundInterceptorBase
+	root *tenantInterceptor
+}
+
+func (t *tenantActivityInboundInterceptor) Init(outbound ActivityOutboundInterceptor) error {
+	i := &tenantActivityOutboundInterceptor{root: t.root}
+	i.Next = outbound
+	return t.Next.Init(i)
+}
+
+func (t *tenantActivityInboundInterceptor) ExecuteActivity(
+	ctx context.Context,
+	in *ExecuteActivityInput,
+) (interface{}, error) {
+	tenant, ok, err := t.root.readTenant(Header(ctx))
+	if err != nil {
+		return nil, err
+	} else if ok {
+		ctx = ContextWithTenant(ctx, tenant)
+	}
+	return t.Next.ExecuteActivity(ctx, in)
+}
+
+type tenantActivityOutboundInterceptor struct {
+	ActivityOutboundInterceptorBase
+	root *tenantInterceptor
+}
+
+func (t *tenantActivityOutboundInterceptor) GetLogger(ctx context.Context) log.Logger {
+	logger := t.Next.GetLogger(ctx)
+	if tenant, ok := TenantFromContext(ctx); ok {
+		logger = log.With(logger, t.root.options.TagName, tenant)
+	}
+	return logger
+}
+
+func (t *tenantActivityOutboundInterceptor) GetMetricsHandler(ctx context.Context) client.MetricsHandler {
+	handler := t.Next.GetMetricsHandler(ctx)
+	if tenant, ok := TenantFromContext(ctx); ok {
+		handler = handler.WithTags(map[string]string{t.root.options.TagName: tenant})
+	}
+	return handler
+}
+
+type tenantWorkflowInboundInterceptor struct {
+	WorkflowInboundInterceptorBase
+	root *tenantInterceptor
+}
+
+func (t *tenantWorkflowInboundInterceptor) Init(outbound WorkflowOutboundInterceptor) error {
+	i := &tenantWorkflowOutboundInterceptor{root: t.root}
+	i.Next = outbound
+	return t.Next.Init(i)
+}
+
+func (t *tenantWorkflowInboundInterceptor) ExecuteWorkflow(
+	ctx workflow.Context,
+	in *ExecuteWorkflowInput,
+) (interface{}, error) {
+	tenant, ok, err := t.root.readTenant(WorkflowHeader(ctx))
+	if err != nil {
+		return nil, err
+	} else if ok {
+		ctx = workflow.WithValue(ctx, tenantContextKey{}, tenant)
+		if key := t.root.options.SearchAttribute; key.GetName() != "" {
+			if current, _ := workflow.GetTypedSearchAttributes(ctx).GetKeyword(key); current != tenant {
+				if err := workflow.UpsertTypedSearchAttributes(ctx, key.ValueSet(tenant)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return t.Next.ExecuteWorkflow(ctx, in)
+}
+
+type tenantWorkflowOutboundInterceptor struct {
+	WorkflowOutboundInterceptorBase
+	root *tenantInterceptor
+}
+
+func (t *tenantWorkflowOutboundInterceptor) GetLogger(ctx workflow.Context) log.Logger {
+	logger := t.Next.GetLogger(ctx)
+	if tenant, ok := WorkflowTenant(ctx); ok {
+		logger = log.With(logger, t.root.options.TagName, tenant)
+	}
+	return logger
+}
+
+func (t *tenantWorkflowOutboundInterceptor) GetMetricsHandler(ctx workflow.Context) client.MetricsHandler {
+	handler := t.Next.GetMetricsHandler(ctx)
+	if tenant, ok := WorkflowTenant(ctx); ok {
+		handler = handler.WithTags(map[string]string{t.root.options.TagName: tenant})
+	}
+	return handler
+}
+
+func (t *tenantWorkflowOutboundInterceptor) ExecuteActivity(
+	ctx workflow.Context,
+	activityType string,
+	args ...interface{},
+) workflow.Future {
+	if err := t.root.writeWorkflowTenant(ctx); err != nil {
+		return workflowFutureFromErr(ctx, err)
+	}
+	return t.Next.ExecuteActivity(ctx, activityType, args...)
+}
+
+func (t *tenantWorkflowOutboundInterceptor) ExecuteLocalActivity(
+	ctx workflow.Context,
+	activityType string,
+	args ...interface{},
+) workflow.Future {
+	if err := t.root.writeWorkflowTenant(ctx); err != nil {
+		return workflowFutureFromErr(ctx, err)
+	}
+	return t.Next.ExecuteLocalActivity(ctx, activityType, args...)
+}
+
+func (t *tenantWorkflowOutboundInterceptor) ExecuteChildWorkflow(
+	ctx workflow.Context,
+	childWorkflowType string,
+	args ...interface{},
+) workflow.ChildWorkflowFuture {
+	if err := t.root.writeWorkflowTenant(ctx); err != nil {
+		return childWorkflowFuture{workflowFutureFromErr(ctx, err)}
+	}
+	return t.Next.ExecuteChildWorkflow(ctx, childWorkflowType, args...)
+}
+
+func (t *tenantWorkflowOutboundInterceptor) NewContinueAsNewError(
+	ctx workflow.Context,
+	wfn interface{},
+	args ...interface{},
+) error {
+	if err := t.root.writeWorkflowTenant(ctx); err != nil {
+		return err
+	}
+	return t.Next.NewContinueAsNewError(ctx, wfn, args...)
+}
+
+func (t *tenantInterceptor) writeClientTenant(ctx context.Context, options *client.StartWorkflowOptions) error {
+	tenant, ok := TenantFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if key := t.options.SearchAttribute; key.GetName() != "" && options != nil &&
+		!options.TypedSearchAttributes.ContainsKey(key) {
+		options.TypedSearchAttributes = temporal.NewSearchAttributes(
+			options.TypedSearchAttributes.Copy(), key.ValueSet(tenant))
+	}
+	return t.writeTenant(tenant, Header(ctx))
+}
+
+func (t *tenantInterceptor) writeWorkflowTenant(ctx workflow.Context) error {
+	tenant, ok := WorkflowTenant(ctx)
+	if !ok {
+		return nil
+	}
+	return t.writeTenant(tenant, WorkflowHeader(ctx))
+}
+
+func (t *tenantInterceptor) readTenant(header map[string]*commonpb.Payload) (string, bool, error) {
+	payload := header[t.options.HeaderKey]
+	if payload == nil {
+		return "", false, nil
+	}
+	var tenant string
+	if err := converter.GetDefaultDataConverter().FromPayload(payload, &tenant); err != nil {
+		return "", false, err
+	}
+	return tenant, true, nil
+}
+
+func (t *tenantInterceptor) writeTenant(tenant string, header map[string]*commonpb.Payload) error {
+	payload, err := converter.GetDefaultDataConverter().ToPayload(tenant)
+	if err != nil {
+		return err
+	}
+	header[t.options.HeaderKey] = payload
+	return nil
+}
@@ -0,0 +1,75 @@
+package interceptor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestTenantInterceptor(t *testing.T) {
+	tenantKey := temporal.NewSearchAttributeKeyKeyword("Tenant")
+	tenantActivity := func(ctx context.Context) (string, error) {
+		tenant, _ := interceptor.TenantFromContext(ctx)
+		return tenant, nil
+	}
+	tenantChildWorkflow := func(ctx workflow.Context) (string, error) {
+		tenant, _ := interceptor.WorkflowTenant(ctx)
+		saTenant, _ := workflow.GetTypedSearchAttributes(ctx).GetKeyword(tenantKey)
+		return tenant + "/" + saTenant, nil
+	}
+	tenantWorkflow := func(ctx workflow.Context) ([]string, error) {
+		tenant, _ := interceptor.WorkflowTenant(ctx)
+		saTenant, _ := workflow.GetTypedSearchAttributes(ctx).GetKeyword(tenantKey)
+		ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+		var activityTenant, childTenant string
+		if err := workflow.ExecuteActivity(ctx, tenantActivity).Get(ctx, &activityTenant); err != nil {
+			return nil, err
+		}
+		if err := workflow.ExecuteChildWorkflow(ctx, tenantChildWorkflow).Get(ctx, &childTenant); err != nil {
+			return nil, err
+		}
+		return []string{tenant + "/" + saTenant, activityTenant, childTenant}, nil
+	}
+	execute := func(header *commonpb.Header) []string {
+		var suite testsuite.WorkflowTestSuite
+		env := suite.NewTestWorkflowEnvironment()
+		env.RegisterActivity(tenantActivity)
+		env.RegisterWorkflow(tenantWorkflow)
+		env.RegisterWorkflow(tenantChildWorkflow)
+		env.SetWorkerOptions(worker.Options{
+			Interceptors: []interceptor.WorkerInterceptor{
+				interceptor.NewTenantInterceptor(interceptor.TenantOptions{SearchAttribute: tenantKey}),
+			},
+		})
+		if header != nil {
+			env.SetHeader(header)
+		}
+		env.ExecuteWorkflow(tenantWorkflow)
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+		var result []string
+		require.NoError(t, env.GetWorkflowResult(&result))
+		return result
+	}
+
+	// The tenant and its search attribute are propagated to activities and
+	// child workflows
+	payload, err := converter.GetDefaultDataConverter().ToPayload("acme")
+	require.NoError(t, err)
+	result := execute(&commonpb.Header{Fields: map[string]*commonpb.Payload{"_temporal-tenant": payload}})
+	require.Equal(t, []string{"acme/acme", "acme", "acme/acme"}, result)
+
+	// Workflows without a tenant are left alone
+	result = execute(nil)
+	require.Equal(t, []string{"/", "", "/"}, result)
+}
@@ -3,6 +3,7 @@ package internal
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	commonpb "go.temporal.io/api/common/v1"
@@ -10,6 +11,7 @@ import (
 
 	"go.temporal.io/sdk/converter"
 	"go.temporal.io/sdk/internal/common/metrics"
+	ilog "go.temporal.io/sdk/internal/log"
 	"go.temporal.io/sdk/log"
 )
 
@@ -38,6 +40,11 @@ type (
 		Deadline          time.Time     // Time of activity timeout
 		Attempt           int32         // Attempt starts from 1, and increased by 1 for every retry if retry policy is specified.
 		IsLocalActivity   bool          // true if it is a local activity
+		// CurrentAttemptScheduledTime is the time the current attempt was scheduled, which is ScheduledTime for the
+		// first attempt. For retries, it is the time computed by the server, or by the worker for local activities,
+		// from the retry policy or from the ApplicationErrorOptions.NextRetryDelay of the error that failed the
+		// previous attempt.
+		CurrentAttemptScheduledTime time.Time
 		// Priority settings that control relative ordering of task processing when activity tasks are backed up in a queue.
 		// If no priority is set, the default value is the zero value.
 		//
@@ -63,6 +70,19 @@ type (
 		// When registering a struct with activities, skip functions that are not valid activities. If false,
 		// registration panics.
 		SkipInvalidStructFunctions bool
+
+		// PanicPolicy configures how the worker deals with the activity panicking.
+		// Defaults to RetryActivity. Local activities are not affected.
+		//
+		// NOTE: Experimental
+		PanicPolicy ActivityPanicPolicy
+
+		// ExecutorPool is the name of the executor pool of the worker running the activity, which limits its
+		// concurrent executions along with the other activities of the pool. It must be one of
+		// WorkerOptions.ActivityExecutorPools. Activities without a pool are only limited by the slots of the worker.
+		//
+		// NOTE: Experimental
+		ExecutorPool string
 	}
 
 	// ActivityOptions stores all activity-specific parameters that will be stored inside of a context.
@@ -156,6 +176,38 @@ type (
 		//
 		// WARNING: Task queue priority is currently experimental.
 		Priority Priority
+
+		// IdempotencyKey - Optional key identifying the result of this activity. When the worker executing the
+		// activity has an ActivityResultCache set in its options, a successful result is stored under this key, and
+		// later executions with the same key, such as the ones scheduled again after a workflow reset, return the
+		// stored result without running the activity. Keys are shared by all workflows using the same cache, so
+		// they should be unique to the work done, for example by including the workflow ID.
+		//
+		// NOTE: Experimental
+		IdempotencyKey string
+
+		// RetryOverrides - Backoff parameters replacing the ones of RetryPolicy when an attempt fails with an error of
+		// a given type. They are applied by the worker executing the activity, which sets the delay of the next retry
+		// on the failure reported to the server. Failures with a NextRetryDelay set are not changed.
+		//
+		// NOTE: Experimental
+		RetryOverrides []RetryOverride
+
+		// DataConverter - Data converter encoding the arguments and decoding the result of the activity instead of the
+		// data converter of the workflow, for example to encrypt them with a payload codec only some workers have.
+		// The workers executing the activity must use a compatible data converter, typically by running it on its own
+		// task queue. Failures and heartbeat details are still converted with the data converter of the workflow.
+		//
+		// Optional: defaults to the data converter of the workflow context.
+		//
+		// NOTE: Experimental
+		DataConverter converter.DataConverter
+
+		// Progress - If set, the progress recorded by the activity with activity.RecordProgress is upserted by the
+		// workflow into the search attribute and memo of the options, so operators can follow it in visibility.
+		//
+		// NOTE: Experimental
+		Progress *ActivityProgressOptions
 	}
 
 	// LocalActivityOptions stores local activity specific parameters that will be stored inside of a context.
@@ -177,6 +229,40 @@ type (
 		// Optional: default is to retry according to the default retry policy up to ScheduleToCloseTimeout
 		// with 1sec initial delay between retries and 2x backoff.
 		RetryPolicy *RetryPolicy
+
+		// LocalRetryThreshold - Retries with a backoff up to this duration are performed by the worker within the
+		// current workflow task, heartbeating the workflow task if needed. Retries with a larger backoff complete the
+		// workflow task and are scheduled with a server timer, which adds the attempt and timer to history but stops
+		// heartbeating the workflow task while waiting. Lowering it trades retry latency and history size for less
+		// workflow task heartbeat load.
+		//
+		// Optional: defaults to the workflow task timeout.
+		//
+		// NOTE: Experimental
+		LocalRetryThreshold time.Duration
+
+		// RetryJitter - Randomizes the delay before retries computed from RetryPolicy, so that local activities
+		// failing at the same time do not retry at the same time. Delays set with NextRetryDelay on an
+		// ApplicationError are not randomized.
+		//
+		// Optional: defaults to no jitter.
+		//
+		// NOTE: Experimental
+		RetryJitter RetryJitter
+
+		// RetryOverrides - Backoff parameters replacing the ones of RetryPolicy when an attempt fails with an error of
+		// a given type.
+		//
+		// NOTE: Experimental
+		RetryOverrides []RetryOverride
+
+		// DataConverter - Data converter encoding the result of the local activity in the workflow history and
+		// decoding it instead of the data converter of the workflow.
+		//
+		// Optional: defaults to the data converter of the workflow context.
+		//
+		// NOTE: Experimental
+		DataConverter converter.DataConverter
 	}
 )
 
@@ -230,6 +316,20 @@ func GetActivityMetricsHandler(ctx context.Context) metrics.Handler {
 	return getActivityOutboundInterceptor(ctx).GetMetricsHandler(ctx)
 }
 
+// GetActivityNow returns the current time for the activity. It is the wall clock time, except for activities executed
+// by the test environments, where it is the time of the environment's clock, so activities depending on time can be
+// tested deterministically and see the time skipped by the workflow test environment.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/activity.Now]
+func GetActivityNow(ctx context.Context) time.Time {
+	if now, ok := ctx.Value(activityClockContextKey).(func() time.Time); ok {
+		return now()
+	}
+	return time.Now()
+}
+
 // GetWorkerStopChannel returns a read-only channel. The closure of this channel indicates the activity worker is stopping.
 // When the worker is stopping, it will close this channel and wait until the worker stop timeout finishes. After the timeout
 // hits, the worker will cancel the activity context and then exit. The timeout can be defined by worker option: WorkerStopTimeout.
@@ -240,6 +340,80 @@ func GetWorkerStopChannel(ctx context.Context) <-chan struct{} {
 	return getActivityOutboundInterceptor(ctx).GetWorkerStopChannel(ctx)
 }
 
+// WorkerStopReason is why a worker is stopping.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/activity.WorkerStopReason], [go.temporal.io/sdk/worker.WorkerStopReason]
+type WorkerStopReason int
+
+const (
+	// WorkerStopReasonShutdown indicates the worker was stopped with Stop, or by the interrupt channel of Run.
+	//
+	// Exposed as: [go.temporal.io/sdk/activity.WorkerStopReasonShutdown], [go.temporal.io/sdk/worker.WorkerStopReasonShutdown]
+	WorkerStopReasonShutdown WorkerStopReason = iota
+	// WorkerStopReasonFatalError indicates the worker is stopping because of a fatal error, such as a poll error
+	// that cannot be retried.
+	//
+	// Exposed as: [go.temporal.io/sdk/activity.WorkerStopReasonFatalError], [go.temporal.io/sdk/worker.WorkerStopReasonFatalError]
+	WorkerStopReasonFatalError
+	// WorkerStopReasonDeploymentDrain indicates the worker is stopping because its deployment version is drained.
+	//
+	// Exposed as: [go.temporal.io/sdk/activity.WorkerStopReasonDeploymentDrain], [go.temporal.io/sdk/worker.WorkerStopReasonDeploymentDrain]
+	WorkerStopReasonDeploymentDrain
+)
+
+// WorkerStopInfo describes why and until when a worker is stopping.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/activity.WorkerStopInfo]
+type WorkerStopInfo struct {
+	// Reason is why the worker is stopping.
+	Reason WorkerStopReason
+	// Err is the fatal error the worker is stopping for, if Reason is WorkerStopReasonFatalError.
+	Err error
+	// Deadline is when the worker stop timeout elapses and the contexts of the activities still running are
+	// canceled. Activities that cannot complete before it should checkpoint their progress with a heartbeat.
+	Deadline time.Time
+}
+
+// GetWorkerStopInfo returns why and until when the worker is stopping, and false if it is not stopping. Use it once
+// the channel returned by GetWorkerStopChannel is closed, to choose between checkpointing and aborting quickly.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/activity.GetWorkerStopInfo]
+func GetWorkerStopInfo(ctx context.Context) (WorkerStopInfo, bool) {
+	return getActivityOutboundInterceptor(ctx).GetWorkerStopInfo(ctx)
+}
+
+// workerStopState is the stop information of a worker, shared with its activities.
+type workerStopState struct {
+	lock    sync.Mutex
+	info    WorkerStopInfo
+	stopped bool
+}
+
+// stop records the stop information, unless the worker is already stopping.
+func (s *workerStopState) stop(info WorkerStopInfo) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.stopped {
+		s.info = info
+		s.stopped = true
+	}
+}
+
+func (s *workerStopState) get() (WorkerStopInfo, bool) {
+	if s == nil {
+		return WorkerStopInfo{}, false
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.info, s.stopped
+}
+
 // RecordActivityHeartbeat sends a heartbeat for the currently executing activity.
 // If the activity is either canceled or workflow/activity doesn't exist, then we would cancel
 // the context with error context.Canceled.
@@ -254,8 +428,21 @@ func RecordActivityHeartbeat(ctx context.Context, details ...interface{}) {
 	getActivityOutboundInterceptor(ctx).RecordHeartbeat(ctx, details...)
 }
 
+// RecordActivityHeartbeatImmediate sends a heartbeat for the currently executing activity right away, bypassing the
+// heartbeat throttling. Details buffered by earlier throttled heartbeats are replaced by these ones.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/activity.RecordHeartbeatImmediate]
+func RecordActivityHeartbeatImmediate(ctx context.Context, details ...interface{}) {
+	getActivityOutboundInterceptor(ctx).RecordHeartbeatImmediate(ctx, details...)
+}
+
 // GetClient returns a client that can be used to interact with the Temporal
-// service from an activity.
+// service from an activity. It is the client of the worker running the
+// activity, so it uses the namespace and the interceptors of the worker, and
+// activities can start or signal workflows without creating their own client.
+// It is shared with the worker and must not be closed by the activity.
 //
 // Exposed as: [go.temporal.io/sdk/activity.GetClient]
 func GetClient(ctx context.Context) Client {
@@ -282,11 +469,16 @@ func WithActivityTask(
 	metricsHandler metrics.Handler,
 	dataConverter converter.DataConverter,
 	workerStopChannel <-chan struct{},
+	workerStopState *workerStopState,
 	contextPropagators []ContextPropagator,
 	interceptors []WorkerInterceptor,
 	client *WorkflowClient,
 ) (context.Context, error) {
 	scheduled := task.GetScheduledTime().AsTime()
+	attemptScheduled := scheduled
+	if task.GetCurrentAttemptScheduledTime().IsValid() {
+		attemptScheduled = task.GetCurrentAttemptScheduledTime().AsTime()
+	}
 	started := task.GetStartedTime().AsTime()
 	scheduleToCloseTimeout := task.GetScheduleToCloseTimeout().AsDuration()
 	startToCloseTimeout := task.GetStartToCloseTimeout().AsDuration()
@@ -315,6 +507,7 @@ func WithActivityTask(
 		deadline:         deadline,
 		heartbeatTimeout: heartbeatTimeout,
 		scheduledTime:    scheduled,
+		attemptTime:      attemptScheduled,
 		startedTime:      started,
 		taskQueue:        taskQueue,
 		dataConverter:    dataConverter,
@@ -326,11 +519,20 @@ func WithActivityTask(
 		},
 		workflowNamespace:  task.WorkflowNamespace,
 		workerStopChannel:  workerStopChannel,
+		workerStopState:    workerStopState,
 		contextPropagators: contextPropagators,
 		client:             client,
 	})
 }
 
+// newStackTraceActivityLogger wraps an activity logger to attach stack traces and the task queue to Error logs.
+func newStackTraceActivityLogger(logger log.Logger, taskQueue string) log.Logger {
+	// Skip 1 log level to remove the StackTraceLogger from the stack.
+	return log.Skip(ilog.NewStackTraceLogger(logger, func() []interface{} {
+		return []interface{}{tagTaskQueue, taskQueue}
+	}), 1)
+}
+
 // WithLocalActivityTask adds local activity specific information into context.
 func WithLocalActivityTask(
 	ctx context.Context,
@@ -382,6 +584,7 @@ func WithLocalActivityTask(
 		isLocalActivity:   true,
 		deadline:          deadline,
 		scheduledTime:     task.scheduledTime,
+		attemptTime:       task.attemptTime,
 		startedTime:       startedTime,
 		dataConverter:     dataConverter,
 		attempt:           task.attempt,
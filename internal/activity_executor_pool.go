@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/internal/common/metrics"
+)
+
+// activityExecutorPools limits the concurrent executions of the activities registered in executor pools, see
+// WorkerOptions.ActivityExecutorPools.
+type activityExecutorPools struct {
+	// slots of each pool, holding a value per running activity
+	slots map[string]chan struct{}
+}
+
+// newActivityExecutorPools returns the pools of the sizes, nil if there are none.
+func newActivityExecutorPools(sizes map[string]int) (*activityExecutorPools, error) {
+	if len(sizes) == 0 {
+		return nil, nil
+	}
+	p := &activityExecutorPools{slots: make(map[string]chan struct{}, len(sizes))}
+	for name, size := range sizes {
+		if name == "" {
+			return nil, errors.New("activity executor pool name cannot be empty")
+		}
+		if size <= 0 {
+			return nil, fmt.Errorf("size of activity executor pool %q must be positive", name)
+		}
+		p.slots[name] = make(chan struct{}, size)
+	}
+	return p, nil
+}
+
+func (p *activityExecutorPools) has(pool string) bool {
+	if p == nil {
+		return false
+	}
+	_, ok := p.slots[pool]
+	return ok
+}
+
+// acquire waits for a slot of the pool until the context is done, returning the function releasing it, and calls
+// heartbeat every heartbeatInterval while waiting if it is positive. Activities that are not in a configured pool are
+// not limited.
+func (p *activityExecutorPools) acquire(
+	ctx context.Context,
+	pool string,
+	metricsHandler metrics.Handler,
+	heartbeatInterval time.Duration,
+	heartbeat func(),
+) (func(), error) {
+	slots, ok := p.slots[pool]
+	if !ok {
+		return func() {}, nil
+	}
+	gauge := metricsHandler.WithTags(map[string]string{metrics.ExecutorPoolTagName: pool}).
+		Gauge(metrics.ActivityExecutorPoolSlotsUsed)
+	var heartbeatC <-chan time.Time
+	if heartbeatInterval > 0 {
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+		heartbeatC = ticker.C
+	}
+WaitSlot:
+	for {
+		select {
+		case slots <- struct{}{}:
+			break WaitSlot
+		case <-heartbeatC:
+			heartbeat()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	gauge.Update(float64(len(slots)))
+	return func() {
+		<-slots
+		gauge.Update(float64(len(slots)))
+	}, nil
+}
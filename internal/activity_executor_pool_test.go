@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/sdk/internal/common/metrics"
+)
+
+func TestActivityExecutorPoolsAcquire(t *testing.T) {
+	pools, err := newActivityExecutorPools(map[string]int{"cpu-heavy": 1})
+	require.NoError(t, err)
+	release, err := pools.acquire(context.Background(), "cpu-heavy", metrics.NopHandler, 0, nil)
+	require.NoError(t, err)
+
+	// Tasks waiting for a slot heartbeat until one is released
+	var heartbeats atomic.Int32
+	acquired := make(chan error, 1)
+	go func() {
+		releaseNext, err := pools.acquire(context.Background(), "cpu-heavy", metrics.NopHandler, time.Millisecond,
+			func() { heartbeats.Add(1) })
+		if err == nil {
+			releaseNext()
+		}
+		acquired <- err
+	}()
+	require.Eventually(t, func() bool { return heartbeats.Load() >= 3 }, time.Second, time.Millisecond)
+	release()
+	require.NoError(t, <-acquired)
+
+	// The wait ends with the deadline of the task
+	release, err = pools.acquire(context.Background(), "cpu-heavy", metrics.NopHandler, 0, nil)
+	require.NoError(t, err)
+	defer release()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = pools.acquire(ctx, "cpu-heavy", metrics.NopHandler, 0, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Activities without a pool are not limited
+	releaseOther, err := pools.acquire(ctx, "io-heavy", metrics.NopHandler, 0, nil)
+	require.NoError(t, err)
+	releaseOther()
+}
@@ -0,0 +1,221 @@
+package internal
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/workflowservice/v1"
+
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/log"
+)
+
+const (
+	// activityProgressHeader is the header carrying ActivityProgressOptions.Interval to the activity worker, its
+	// presence enabling RecordActivityProgress.
+	activityProgressHeader = "__temporal_activity_progress"
+	// activityProgressSignalName is the name of the signals carrying the progress of activities to their workflow.
+	activityProgressSignalName = "__temporal_activity_progress"
+
+	defaultActivityProgressInterval = 10 * time.Second
+	activityProgressSignalTimeout   = 10 * time.Second
+)
+
+type (
+	// ActivityProgressOptions configure the publication of the progress recorded by an activity with
+	// activity.RecordProgress, see ActivityOptions.Progress. The progress is sent to the workflow that scheduled the
+	// activity, which upserts it into its search attribute and memo, so the progress of the workflow can be seen in
+	// visibility. Every update adds a signal and a workflow task to the history of the workflow, so the progress
+	// should be coarse and the interval long enough. Progress received by the workflow after the activity completed
+	// is ignored. The progress is encoded with the default data converter, not the one of the workflow or of
+	// ActivityOptions.DataConverter, so summaries should not contain sensitive data.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/workflow.ActivityProgressOptions]
+	ActivityProgressOptions struct {
+		// SearchAttribute, if set, is the name of the keyword search attribute the progress is upserted into. The
+		// search attribute must be registered on the namespace.
+		SearchAttribute string
+
+		// MemoKey, if set, is the key of the memo the progress is upserted into.
+		MemoKey string
+
+		// Interval is the minimum interval between two progress updates sent by the activity. Progress recorded
+		// more often replaces the one waiting to be sent. Default is 10s.
+		Interval time.Duration
+	}
+
+	// activityProgress is the value of the signals sent by activityProgressReporter.
+	activityProgress struct {
+		ActivityID string
+		Summary    string
+	}
+
+	// activityProgressState is the state of the workflow receiving the progress of its activities.
+	activityProgressState struct {
+		// Whether the coroutine receiving the progress was started
+		started bool
+		// Progress options of the running activities scheduled with ActivityOptions.Progress, by activity ID
+		options map[string]*ActivityProgressOptions
+	}
+
+	// activityProgressReporter throttles the progress recorded by an activity and sends it to its workflow.
+	activityProgressReporter struct {
+		service    workflowservice.WorkflowServiceClient
+		namespace  string
+		identity   string
+		execution  WorkflowExecution
+		activityID string
+		logger     log.Logger
+		interval   time.Duration
+
+		lock     sync.Mutex
+		summary  string
+		lastSent time.Time
+		timer    *time.Timer
+	}
+)
+
+// RecordActivityProgress records a short summary of the progress of the current activity. If the activity was
+// scheduled with ActivityOptions.Progress, the summary is sent to the workflow to be upserted into its search
+// attribute and memo, at most once per ActivityProgressOptions.Interval, only the latest summary being sent if
+// several are recorded during the interval. Otherwise, and in local activities, it does nothing.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/activity.RecordProgress]
+func RecordActivityProgress(ctx context.Context, summary string) {
+	if reporter := getActivityEnv(ctx).progressReporter; reporter != nil {
+		reporter.record(summary)
+	}
+}
+
+// withActivityProgress returns a copy of the header enabling progress reporting for the activity.
+func withActivityProgress(header *commonpb.Header, options *ActivityProgressOptions) (*commonpb.Header, error) {
+	interval := options.Interval
+	if interval <= 0 {
+		interval = defaultActivityProgressInterval
+	}
+	return withHeaderValue(header, activityProgressHeader, interval)
+}
+
+// newActivityProgressReporter returns the reporter of the progress of the activity, nil if the activity was not
+// scheduled with progress reporting.
+func newActivityProgressReporter(header *commonpb.Header, env *activityEnvironment) *activityProgressReporter {
+	var interval time.Duration
+	if env.client == nil || !getHeaderValue(header, activityProgressHeader, &interval) {
+		return nil
+	}
+	return &activityProgressReporter{
+		service:    env.client.workflowService,
+		namespace:  env.workflowNamespace,
+		identity:   env.client.identity,
+		execution:  env.workflowExecution,
+		activityID: env.activityID,
+		logger:     env.logger,
+		interval:   interval,
+	}
+}
+
+// record sends the summary right away if the interval elapsed since the last one, or schedules it to be sent when
+// it elapses.
+func (r *activityProgressReporter) record(summary string) {
+	r.lock.Lock()
+	r.summary = summary
+	if r.timer != nil {
+		// The summary will be sent when the scheduled update fires
+		r.lock.Unlock()
+		return
+	}
+	if wait := r.interval - time.Since(r.lastSent); wait > 0 {
+		r.timer = time.AfterFunc(wait, r.flush)
+		r.lock.Unlock()
+		return
+	}
+	r.lastSent = time.Now()
+	r.lock.Unlock()
+	r.send(summary)
+}
+
+func (r *activityProgressReporter) flush() {
+	r.lock.Lock()
+	summary := r.summary
+	r.timer = nil
+	r.lastSent = time.Now()
+	r.lock.Unlock()
+	r.send(summary)
+}
+
+func (r *activityProgressReporter) send(summary string) {
+	// The data converter of the activity worker may not be the one of the workflow, see ActivityOptions.DataConverter
+	input, err := encodeArg(converter.GetDefaultDataConverter(), activityProgress{ActivityID: r.activityID, Summary: summary})
+	if err == nil {
+		// The activity context is not used as the progress may be sent after the activity completed
+		ctx, cancel := context.WithTimeout(context.Background(), activityProgressSignalTimeout)
+		defer cancel()
+		grpcCtx, grpcCancel := newGRPCContext(ctx, defaultGrpcRetryParameters(ctx))
+		defer grpcCancel()
+		_, err = r.service.SignalWorkflowExecution(grpcCtx, &workflowservice.SignalWorkflowExecutionRequest{
+			Namespace: r.namespace,
+			WorkflowExecution: &commonpb.WorkflowExecution{
+				WorkflowId: r.execution.ID,
+				RunId:      r.execution.RunID,
+			},
+			SignalName: activityProgressSignalName,
+			Input:      input,
+			Identity:   r.identity,
+		})
+	}
+	if err != nil {
+		r.logger.Warn("Failed to send activity progress.",
+			tagWorkflowID, r.execution.ID,
+			tagRunID, r.execution.RunID,
+			tagActivityID, r.activityID,
+			tagError, err)
+	}
+}
+
+// registerActivityProgress makes the workflow upsert the progress of the activity according to the options until
+// it is unregistered, starting the coroutine receiving the progress of activities on first use.
+func registerActivityProgress(ctx Context, activityID string, options *ActivityProgressOptions) {
+	state := getWorkflowEnvOptions(ctx).activityProgress
+	if !state.started {
+		state.started = true
+		ch := getWorkflowEnvOptions(ctx).getSignalChannel(
+			WithDataConverter(ctx, converter.GetDefaultDataConverter()), activityProgressSignalName)
+		ctx, _ := NewDisconnectedContext(ctx)
+		Go(ctx, func(ctx Context) {
+			for {
+				var progress activityProgress
+				ch.Receive(ctx, &progress)
+				upsertActivityProgress(ctx, state.options[progress.ActivityID], progress.Summary)
+			}
+		})
+	}
+	state.options[activityID] = options
+}
+
+// unregisterActivityProgress makes the workflow ignore the progress of the activity, once it completed.
+func unregisterActivityProgress(ctx Context, activityID string) {
+	delete(getWorkflowEnvOptions(ctx).activityProgress.options, activityID)
+}
+
+func upsertActivityProgress(ctx Context, options *ActivityProgressOptions, summary string) {
+	if options == nil {
+		return
+	}
+	if options.SearchAttribute != "" {
+		key := NewSearchAttributeKeyKeyword(options.SearchAttribute)
+		if err := UpsertTypedSearchAttributes(ctx, key.ValueSet(summary)); err != nil {
+			GetLogger(ctx).Warn("Failed to upsert activity progress search attribute.", tagError, err)
+		}
+	}
+	if options.MemoKey != "" {
+		if err := UpsertMemo(ctx, map[string]interface{}{options.MemoKey: summary}); err != nil {
+			GetLogger(ctx).Warn("Failed to upsert activity progress memo.", tagError, err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"context"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+
+	"go.temporal.io/sdk/internal/common/cache"
+)
+
+// activityIdempotencyKeyHeader is the header carrying ActivityOptions.IdempotencyKey to the activity worker.
+const activityIdempotencyKeyHeader = "__temporal_activity_idempotency_key"
+
+// ActivityResultCache stores the results of activities scheduled with an idempotency key, see
+// ActivityOptions.IdempotencyKey. Implementations must be safe for concurrent use.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/worker.ActivityResultCache]
+type ActivityResultCache interface {
+	// Get returns the result stored for the key, and whether one was found.
+	Get(ctx context.Context, key string) (result *commonpb.Payloads, found bool, err error)
+	// Put stores the result of a successful activity execution for the key.
+	Put(ctx context.Context, key string, result *commonpb.Payloads) error
+}
+
+type memoryActivityResultCache struct {
+	cache cache.Cache
+}
+
+// NewMemoryActivityResultCache creates an ActivityResultCache keeping up to maxSize results in memory, evicting the
+// least recently used ones first. Results older than ttl are not returned, a ttl of zero keeps them until evicted.
+// Results are lost when the worker process exits and are not shared between workers.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/worker.NewMemoryActivityResultCache]
+func NewMemoryActivityResultCache(maxSize int, ttl time.Duration) ActivityResultCache {
+	return &memoryActivityResultCache{cache: cache.New(maxSize, &cache.Options{TTL: ttl})}
+}
+
+func (m *memoryActivityResultCache) Get(_ context.Context, key string) (*commonpb.Payloads, bool, error) {
+	result, ok := m.cache.Get(key).(*commonpb.Payloads)
+	return result, ok, nil
+}
+
+func (m *memoryActivityResultCache) Put(_ context.Context, key string, result *commonpb.Payloads) error {
+	m.cache.Put(key, result)
+	return nil
+}
+
+// withActivityIdempotencyKey returns a copy of the header with the idempotency key set.
+func withActivityIdempotencyKey(header *commonpb.Header, key string) (*commonpb.Header, error) {
+	return withHeaderValue(header, activityIdempotencyKeyHeader, key)
+}
+
+// getActivityIdempotencyKey returns the idempotency key set in the header of an activity task, if any.
+func getActivityIdempotencyKey(header *commonpb.Header) string {
+	var key string
+	if !getHeaderValue(header, activityIdempotencyKeyHeader, &key) {
+		return ""
+	}
+	return key
+}
@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityResultCache(t *testing.T) {
+	var executions int
+	activityFn := func(ctx context.Context, input string) (string, error) {
+		executions++
+		return input + "-result", nil
+	}
+	wf := func(ctx Context) ([]string, error) {
+		var results []string
+		for _, key := range []string{"key-1", "key-1", "", "key-2"} {
+			ctx := WithActivityOptions(ctx, ActivityOptions{
+				StartToCloseTimeout: time.Minute,
+				IdempotencyKey:      key,
+			})
+			var result string
+			if err := ExecuteActivity(ctx, activityFn, key).Get(ctx, &result); err != nil {
+				return nil, err
+			}
+			results = append(results, result)
+		}
+		return results, nil
+	}
+
+	var suite WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.SetWorkerOptions(WorkerOptions{ActivityResultCache: NewMemoryActivityResultCache(10, 0)})
+	env.RegisterWorkflow(wf)
+	env.RegisterActivity(activityFn)
+	env.ExecuteWorkflow(wf)
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var results []string
+	require.NoError(t, env.GetWorkflowResult(&results))
+	require.Equal(t, []string{"key-1-result", "key-1-result", "-result", "key-2-result"}, results)
+	require.Equal(t, 3, executions)
+}
+
+func TestActivityIdempotencyKeyHeader(t *testing.T) {
+	require.Empty(t, getActivityIdempotencyKey(nil))
+	header, err := withActivityIdempotencyKey(nil, "key")
+	require.NoError(t, err)
+	require.Equal(t, "key", getActivityIdempotencyKey(header))
+}
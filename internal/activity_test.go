@@ -202,6 +202,33 @@ func (s *activityTestSuite) TestActivityHeartbeat_SuppressContinousInvokes() {
 	invoker4.Close(ctx, false)
 }
 
+func (s *activityTestSuite) TestActivityHeartbeat_Immediate() {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	invoker := newServiceInvoker([]byte("task-token"), "identity", s.service, metrics.NopHandler, cancel,
+		20*time.Second, make(chan struct{}), s.namespace)
+	ctx, _ = newActivityContext(ctx, nil, &activityEnvironment{
+		serviceInvoker: invoker,
+		logger:         getLogger()})
+
+	var reported []string
+	s.service.EXPECT().RecordActivityTaskHeartbeat(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *workflowservice.RecordActivityTaskHeartbeatRequest, opts ...grpc.CallOption) (*workflowservice.RecordActivityTaskHeartbeatResponse, error) {
+			var progress string
+			require.NoError(s.T(), newEncodedValues(request.Details, nil).Get(&progress))
+			reported = append(reported, progress)
+			return &workflowservice.RecordActivityTaskHeartbeatResponse{}, nil
+		}).Times(3)
+
+	RecordActivityHeartbeat(ctx, "first")
+	// Buffered by throttling, then superseded by the immediate heartbeat.
+	RecordActivityHeartbeat(ctx, "buffered")
+	RecordActivityHeartbeatImmediate(ctx, "immediate")
+	// Buffered by throttling and flushed on close.
+	RecordActivityHeartbeat(ctx, "last")
+	invoker.Close(ctx, true)
+	require.Equal(s.T(), []string{"first", "immediate", "last"}, reported)
+}
+
 func (s *activityTestSuite) TestActivityHeartbeat_WorkerStop() {
 	ctx, cancel := context.WithCancelCause(context.Background())
 	workerStopChannel := make(chan struct{})
@@ -235,6 +262,26 @@ func (s *activityTestSuite) TestGetWorkerStopChannel() {
 	s.NotNil(channel)
 }
 
+func (s *activityTestSuite) TestGetWorkerStopInfo() {
+	stopState := &workerStopState{}
+	ctx, _ := newActivityContext(context.Background(), nil, &activityEnvironment{workerStopState: stopState})
+	_, stopping := GetWorkerStopInfo(ctx)
+	s.False(stopping)
+
+	deadline := time.Now().Add(time.Minute)
+	stopState.stop(WorkerStopInfo{Reason: WorkerStopReasonDeploymentDrain, Deadline: deadline})
+	// Only the first stop is recorded
+	stopState.stop(WorkerStopInfo{Reason: WorkerStopReasonShutdown})
+	info, stopping := GetWorkerStopInfo(ctx)
+	s.True(stopping)
+	s.Equal(WorkerStopInfo{Reason: WorkerStopReasonDeploymentDrain, Deadline: deadline}, info)
+
+	// Activities run without a worker, such as in tests, are not stopping
+	ctx, _ = newActivityContext(context.Background(), nil, &activityEnvironment{})
+	_, stopping = GetWorkerStopInfo(ctx)
+	s.False(stopping)
+}
+
 func (s *activityTestSuite) TestIsActivity() {
 	ctx := context.Background()
 	s.False(IsActivity(ctx))
@@ -0,0 +1,199 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+type (
+	// ArchivalConfig is the archival configuration of a namespace. The URIs identify the archivers histories and
+	// visibility records are archived with, for example "s3://bucket", "gs://bucket/path" or "file:///path".
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ArchivalConfig]
+	ArchivalConfig struct {
+		// HistoryEnabled is whether the histories of closed workflows are archived.
+		HistoryEnabled bool
+		// HistoryURI is the URI histories are archived to. Empty if it is the default of the cluster.
+		HistoryURI string
+		// VisibilityEnabled is whether the visibility records of closed workflows are archived.
+		VisibilityEnabled bool
+		// VisibilityURI is the URI visibility records are archived to. Empty if it is the default of the cluster.
+		VisibilityURI string
+	}
+
+	// ArchivedWorkflowListOptions are the parameters for configuring listing archived workflows.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ArchivedWorkflowListOptions]
+	ArchivedWorkflowListOptions struct {
+		// PageSize - How many results to fetch from the Server at a time.
+		//
+		// Optional: defaulted to 1000
+		PageSize int
+
+		// Query - Filter results using a SQL-like query. The fields and operators that can be queried depend on the
+		// visibility archiver of the namespace, see its documentation.
+		Query string
+	}
+
+	// ArchivedWorkflowListIterator is an iterator for archived workflows.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ArchivedWorkflowListIterator]
+	ArchivedWorkflowListIterator interface {
+		// HasNext - Return whether this iterator has next value.
+		HasNext() bool
+
+		// Next - Returns the next archived workflow and error
+		Next() (*workflowpb.WorkflowExecutionInfo, error)
+	}
+
+	// archivedWorkflowListIteratorImpl is the implementation of ArchivedWorkflowListIterator
+	archivedWorkflowListIteratorImpl struct {
+		// nextExecutionIndex - Local cached executions and corresponding consuming index
+		nextExecutionIndex int
+
+		// err - From getting the latest page of executions
+		err error
+
+		// response - From getting the latest page of executions
+		response *workflowservice.ListArchivedWorkflowExecutionsResponse
+
+		// paginate - Function which use a next token to get next page of executions
+		paginate func(nexttoken []byte) (*workflowservice.ListArchivedWorkflowExecutionsResponse, error)
+	}
+)
+
+// Number of archived workflows listed at a time if not set
+const defaultArchivedWorkflowListPageSize = 1000
+
+// GetArchivalConfig returns the archival configuration of the namespace of the client. Like the other archival helpers,
+// it returns an error for clients not created with Dial or NewClient, such as mocks, as their namespace is unknown.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.GetArchivalConfig]
+func GetArchivalConfig(ctx context.Context, c Client) (ArchivalConfig, error) {
+	namespace, err := clientNamespace(c)
+	if err != nil {
+		return ArchivalConfig{}, err
+	}
+	response, err := c.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: namespace,
+	})
+	if err != nil {
+		return ArchivalConfig{}, err
+	}
+	config := response.GetConfig()
+	return ArchivalConfig{
+		HistoryEnabled:    config.GetHistoryArchivalState() == enumspb.ARCHIVAL_STATE_ENABLED,
+		HistoryURI:        config.GetHistoryArchivalUri(),
+		VisibilityEnabled: config.GetVisibilityArchivalState() == enumspb.ARCHIVAL_STATE_ENABLED,
+		VisibilityURI:     config.GetVisibilityArchivalUri(),
+	}, nil
+}
+
+// ListArchivedWorkflows returns an iterator over the archived visibility records of the namespace of the client
+// matching the query, whichever archiver they are archived with. An error is returned if visibility archival is not
+// enabled for the namespace.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.ListArchivedWorkflows]
+func ListArchivedWorkflows(ctx context.Context, c Client, options ArchivedWorkflowListOptions) (ArchivedWorkflowListIterator, error) {
+	namespace, err := clientNamespace(c)
+	if err != nil {
+		return nil, err
+	}
+	config, err := GetArchivalConfig(ctx, c)
+	if err != nil {
+		return nil, err
+	} else if !config.VisibilityEnabled {
+		return nil, fmt.Errorf("visibility archival is not enabled for namespace %s", namespace)
+	}
+	pageSize := options.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultArchivedWorkflowListPageSize
+	}
+	paginate := func(nextToken []byte) (*workflowservice.ListArchivedWorkflowExecutionsResponse, error) {
+		return c.ListArchivedWorkflow(ctx, &workflowservice.ListArchivedWorkflowExecutionsRequest{
+			Namespace:     namespace,
+			PageSize:      int32(pageSize),
+			NextPageToken: nextToken,
+			Query:         options.Query,
+		})
+	}
+	return &archivedWorkflowListIteratorImpl{paginate: paginate}, nil
+}
+
+// GetArchivedWorkflowHistory returns the history of a workflow run of the namespace of the client. It is read from the
+// history archiver by the server if the run was deleted after its retention period, whichever archiver it is archived
+// with. The run ID is required, as archived histories are identified by run. An error is returned if history archival
+// is not enabled for the namespace.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.GetArchivedWorkflowHistory]
+func GetArchivedWorkflowHistory(ctx context.Context, c Client, workflowID string, runID string) (*historypb.History, error) {
+	if runID == "" {
+		return nil, errors.New("run ID is required")
+	}
+	config, err := GetArchivalConfig(ctx, c)
+	if err != nil {
+		return nil, err
+	} else if !config.HistoryEnabled {
+		namespace, _ := clientNamespace(c)
+		return nil, fmt.Errorf("history archival is not enabled for namespace %s", namespace)
+	}
+	history := &historypb.History{}
+	iter := c.GetWorkflowHistory(ctx, workflowID, runID, false, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		history.Events = append(history.Events, event)
+	}
+	return history, nil
+}
+
+// clientNamespace returns the namespace of the client. It is only known for the clients created by the SDK, others,
+// like mocks, would silently target the wrong namespace if it was defaulted.
+func clientNamespace(c Client) (string, error) {
+	if wc, ok := c.(*WorkflowClient); ok {
+		return wc.namespace, nil
+	}
+	return "", fmt.Errorf("unknown namespace for client of type %T, it must be created with client.Dial or client.NewClient", c)
+}
+
+func (iter *archivedWorkflowListIteratorImpl) HasNext() bool {
+	if iter.err == nil &&
+		(iter.response == nil ||
+			(iter.nextExecutionIndex >= len(iter.response.Executions) && len(iter.response.NextPageToken) > 0)) {
+		iter.response, iter.err = iter.paginate(iter.response.GetNextPageToken())
+		iter.nextExecutionIndex = 0
+	}
+
+	return iter.nextExecutionIndex < len(iter.response.GetExecutions()) || iter.err != nil
+}
+
+func (iter *archivedWorkflowListIteratorImpl) Next() (*workflowpb.WorkflowExecutionInfo, error) {
+	if !iter.HasNext() {
+		panic("ArchivedWorkflowListIterator Next() called without checking HasNext()")
+	} else if iter.err != nil {
+		return nil, iter.err
+	}
+	execution := iter.response.Executions[iter.nextExecutionIndex]
+	iter.nextExecutionIndex++
+	return execution, nil
+}
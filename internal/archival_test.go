@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	namespacepb "go.temporal.io/api/namespace/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/api/workflowservicemock/v1"
+)
+
+func TestArchivedWorkflows(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	service := workflowservicemock.NewMockWorkflowServiceClient(mockCtrl)
+	service.EXPECT().GetSystemInfo(gomock.Any(), gomock.Any(), gomock.Any()).Return(&workflowservice.GetSystemInfoResponse{}, nil).AnyTimes()
+	client := NewServiceClient(service, nil, ClientOptions{Namespace: "ns"})
+
+	archivalConfig := &namespacepb.NamespaceConfig{
+		HistoryArchivalState:    enumspb.ARCHIVAL_STATE_ENABLED,
+		HistoryArchivalUri:      "s3://bucket",
+		VisibilityArchivalState: enumspb.ARCHIVAL_STATE_ENABLED,
+		VisibilityArchivalUri:   "file:///tmp/visibility",
+	}
+	service.EXPECT().DescribeNamespace(gomock.Any(), &workflowservice.DescribeNamespaceRequest{Namespace: "ns"}).
+		Return(&workflowservice.DescribeNamespaceResponse{Config: archivalConfig}, nil).Times(3)
+
+	config, err := GetArchivalConfig(ctx, client)
+	require.NoError(t, err)
+	require.Equal(t, ArchivalConfig{
+		HistoryEnabled:    true,
+		HistoryURI:        "s3://bucket",
+		VisibilityEnabled: true,
+		VisibilityURI:     "file:///tmp/visibility",
+	}, config)
+
+	// Archived workflows are listed page by page
+	service.EXPECT().ListArchivedWorkflowExecutions(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, request *workflowservice.ListArchivedWorkflowExecutionsRequest, _ ...interface{}) (*workflowservice.ListArchivedWorkflowExecutionsResponse, error) {
+			require.Equal(t, "ns", request.Namespace)
+			require.Equal(t, "WorkflowType = 'wf'", request.Query)
+			require.Equal(t, int32(1000), request.PageSize)
+			if len(request.NextPageToken) == 0 {
+				return &workflowservice.ListArchivedWorkflowExecutionsResponse{
+					Executions:    []*workflowpb.WorkflowExecutionInfo{{}, {}},
+					NextPageToken: []byte("token"),
+				}, nil
+			}
+			return &workflowservice.ListArchivedWorkflowExecutionsResponse{
+				Executions: []*workflowpb.WorkflowExecutionInfo{{}},
+			}, nil
+		}).Times(2)
+	iter, err := ListArchivedWorkflows(ctx, client, ArchivedWorkflowListOptions{Query: "WorkflowType = 'wf'"})
+	require.NoError(t, err)
+	var count int
+	for iter.HasNext() {
+		_, err := iter.Next()
+		require.NoError(t, err)
+		count++
+	}
+	require.Equal(t, 3, count)
+
+	service.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, request *workflowservice.GetWorkflowExecutionHistoryRequest, _ ...interface{}) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+			require.Equal(t, "run", request.Execution.RunId)
+			require.False(t, request.SkipArchival)
+			return &workflowservice.GetWorkflowExecutionHistoryResponse{
+				History: &historypb.History{Events: []*historypb.HistoryEvent{{EventId: 1}, {EventId: 2}}},
+			}, nil
+		})
+	history, err := GetArchivedWorkflowHistory(ctx, client, "workflow", "run")
+	require.NoError(t, err)
+	require.Len(t, history.Events, 2)
+
+	_, err = GetArchivedWorkflowHistory(ctx, client, "workflow", "")
+	require.ErrorContains(t, err, "run ID is required")
+
+	// Archival must be enabled
+	service.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).
+		Return(&workflowservice.DescribeNamespaceResponse{Config: &namespacepb.NamespaceConfig{}}, nil).Times(2)
+	_, err = ListArchivedWorkflows(ctx, client, ArchivedWorkflowListOptions{})
+	require.ErrorContains(t, err, "visibility archival is not enabled for namespace ns")
+	_, err = GetArchivedWorkflowHistory(ctx, client, "workflow", "run")
+	require.ErrorContains(t, err, "history archival is not enabled for namespace ns")
+
+	// The namespace of other clients is unknown
+	_, err = GetArchivalConfig(ctx, struct{ Client }{})
+	require.ErrorContains(t, err, "unknown namespace")
+	_, err = ListArchivedWorkflows(ctx, struct{ Client }{}, ArchivedWorkflowListOptions{})
+	require.ErrorContains(t, err, "unknown namespace")
+}
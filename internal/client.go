@@ -32,6 +32,15 @@ const (
 	// Exposed as: [go.temporal.io/sdk/client.QueryTypeStackTrace]
 	QueryTypeStackTrace string = "__stack_trace"
 
+	// QueryTypeStackTraceJSON is the build in query type for Client.QueryWorkflow() call. Use this query type to get the
+	// call stack of the workflow in a structured form, for tooling that renders workflow state. The result will be a
+	// WorkflowStackTrace encoded in the EncodedValue.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.QueryTypeStackTraceJSON]
+	QueryTypeStackTraceJSON string = "__stack_trace_json"
+
 	// QueryTypeOpenSessions is the build in query type for Client.QueryWorkflow() call. Use this query type to get all open
 	// sessions in the workflow. The result will be a list of SessionInfo encoded in the EncodedValue.
 	//
@@ -40,6 +49,24 @@ const (
 
 	// QueryTypeWorkflowMetadata is the query name for the workflow metadata.
 	QueryTypeWorkflowMetadata string = "__temporal_workflow_metadata"
+
+	// QueryTypeHandlers is the build in query type for Client.QueryWorkflow() call. Use this query type to get the
+	// signal, query, and update handlers registered on the workflow with their descriptions. The result will be a
+	// WorkflowHandlers encoded in the EncodedValue.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.QueryTypeHandlers]
+	QueryTypeHandlers string = "__handlers"
+
+	// QueryTypeStateSnapshot is the build in query type for Client.QueryWorkflow() call. Use this query type to get
+	// the state snapshot registered by the workflow with workflow.RegisterStateSnapshot. The result will be the value
+	// returned by the snapshot function encoded in the EncodedValue.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.QueryTypeStateSnapshot]
+	QueryTypeStateSnapshot string = "__state_snapshot"
 )
 
 type (
@@ -110,6 +137,7 @@ type (
 		//  - workflowID, signalName, signalArg are same as SignalWorkflow's parameters
 		//  - options, workflow, workflowArgs are same as StartWorkflow's parameters
 		//  - the workflowID parameter is used instead of options.ID. If the latter is present, it must match the workflowID.
+		// The returned WorkflowRun.GetStarted tells whether the workflow was started or was already running.
 		// Note: options.WorkflowIDReusePolicy is default to AllowDuplicate.
 		// The errors it can return:
 		//  - serviceerror.NotFound
@@ -138,6 +166,8 @@ type (
 		// workflowID is required, other parameters are optional.
 		//  - workflow ID of the workflow.
 		//  - runID can be default(empty string). if empty string then it will pick the running execution of that workflow ID.
+		//  - details are encoded with the DataConverter and can be read with TerminatedError.Details from the error
+		//    returned by WorkflowRun.Get.
 		// The errors it can return:
 		//  - serviceerror.NotFound
 		//  - serviceerror.InvalidArgument
@@ -152,6 +182,9 @@ type (
 		//    of HistoryEventIterator, if isLongPoll == true, then iterator will do long poll, tracking new history event, i.e. the iteration
 		//   will not be finished until workflow is finished; if isLongPoll == false, then iterator will only return current history events.
 		//  - whether return all history events or just the last event, which contains the workflow execution end result
+		// History is fetched one page at a time while iterating, and the iterator releases events once returned, so
+		// very large histories can be processed with bounded memory by not retaining the events. The page size can be
+		// capped with converter.PayloadCodecGRPCClientInterceptorOptions.MaxHistoryPageSize.
 		// Example:-
 		//  To iterate all events,
 		//    iter := GetWorkflowHistory(ctx, workflowID, runID, isLongPoll, filterType)
@@ -352,6 +385,14 @@ type (
 		// RequestId is used to deduplicate requests. It will be autogenerated if not set.
 		ResetWorkflowExecution(ctx context.Context, request *workflowservice.ResetWorkflowExecutionRequest) (*workflowservice.ResetWorkflowExecutionResponse, error)
 
+		// ResetWorkflow resets an existing workflow execution to a workflow task, terminating the current run and
+		// starting a new one. The signals and updates after the reset point are reapplied to the new run unless
+		// excluded by the options. With DryRun, it only returns the events that would be reapplied, after checking
+		// the reset point, so that resets can be reviewed before being applied.
+		//
+		// NOTE: Experimental
+		ResetWorkflow(ctx context.Context, options ResetWorkflowOptions) (ResetWorkflowResult, error)
+
 		// UpdateWorkerBuildIdCompatibility allows you to update the worker-build-id based version sets for a particular
 		// task queue. This is used in conjunction with workers who specify their build id and thus opt into the
 		// feature.
@@ -434,6 +475,11 @@ type (
 		// WorkerDeploymentClient creates a new worker deployment client with the same gRPC connection as this client.
 		WorkerDeploymentClient() WorkerDeploymentClient
 
+		// NexusEndpointClient creates a new Nexus endpoint client with the same gRPC connection as this client.
+		//
+		// NOTE: Experimental
+		NexusEndpointClient() NexusEndpointClient
+
 		// Close client and clean up underlying resources.
 		Close()
 	}
@@ -510,6 +556,13 @@ type (
 		// set custom request headers. This can be used to set auth headers for example.
 		HeadersProvider HeadersProvider
 
+		// Optional: gRPC headers added to every request made by this client, for example a tenant ID or a routing
+		// hint. Unlike HeadersProvider, which applies to the connection, they only apply to this client, so clients
+		// created with NewClientFromExisting for other namespaces on the same connection can set their own.
+		//
+		// NOTE: Experimental
+		StaticHeaders map[string]string
+
 		// Optional parameter that is designed to be used *in tests*. It gets invoked last in
 		// the gRPC interceptor chain and can be used to induce artificial failures in test scenarios.
 		TrafficController TrafficController
@@ -526,6 +579,21 @@ type (
 
 		// If set true, error code labels will not be included on request failure metrics.
 		DisableErrorCodeMetricTags bool
+
+		// RetryJitter randomizes the delay before the client retries a failed call to the server.
+		//
+		// Optional: defaults to randomizing the delay by plus or minus 20%.
+		//
+		// NOTE: Experimental
+		RetryJitter RetryJitter
+
+		// NamespaceFailover enables retrying calls that fail because the namespace is failing over to another
+		// cluster, or is unavailable while it is handed over, instead of failing them.
+		//
+		// Optional: defaults to failing such calls.
+		//
+		// NOTE: Experimental
+		NamespaceFailover *NamespaceFailoverOptions
 	}
 
 	// HeadersProvider returns a map of gRPC headers that should be used on every request.
@@ -581,6 +649,12 @@ type (
 		// MaxPayloadSize is a number of bytes that gRPC would allow to travel to and from server. Defaults to 128 MB.
 		MaxPayloadSize int
 
+		// CallTimeouts overrides the default timeouts of the calls made by clients and workers on the connection,
+		// by class of API. Timeouts include the retries of the calls.
+		//
+		// NOTE: Experimental
+		CallTimeouts CallTimeoutOptions
+
 		// Advanced dial options for gRPC connections. These are applied after the internal default dial options are
 		// applied. Therefore any dial options here may override internal ones. Dial options WithBlock, WithTimeout,
 		// WithReturnConnectionError, and FailOnNonTempDialError are ignored since [grpc.NewClient] is used.
@@ -602,6 +676,39 @@ type (
 		excludeInternalFromRetry *atomic.Bool
 	}
 
+	// CallTimeoutOptions are the default timeouts of the calls to the Temporal service by class of API, see
+	// ConnectionOptions.CallTimeouts. Zero fields keep the default of the SDK. They do not apply to the calls
+	// whose timeout is set by another option, like ConnectionOptions.GetSystemInfoTimeout.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.CallTimeoutOptions]
+	CallTimeoutOptions struct {
+		// ShortCall is the timeout of the calls that are neither long polls nor history fetches, like starting,
+		// signaling or describing workflows and completing tasks. If the context of a call has a deadline, the call
+		// times out at half of the remaining time instead, to allow for retries, at least 1s and at most this
+		// timeout.
+		//
+		// default: 10s
+		ShortCall time.Duration
+
+		// LongPoll is the timeout of long polls: the polls of workers for workflow, activity and Nexus tasks, the
+		// polls for the results of updates, and the fetches of workflow histories waiting for new events, like
+		// WorkflowRun.Get. It should be longer than the long poll interval of the server, 60s by default, or the
+		// polls time out before the server responds when there is nothing to return.
+		//
+		// default: 70s for task polls, 60s for update polls and 65s for history fetches
+		LongPoll time.Duration
+
+		// HistoryFetch is the timeout of the fetches of pages of workflow histories that are not long polls, like
+		// the fetches of workers replaying workflows and of Client.GetWorkflowHistory. If the context of a fetch
+		// has a deadline, it times out at half of the remaining time instead, at least 1s and at most this
+		// timeout.
+		//
+		// default: ShortCall
+		HistoryFetch time.Duration
+	}
+
 	// StartWorkflowOptions configuration parameters for starting a workflow execution.
 	// The current timeout resolution implementation is in seconds and uses math.Ceil(d.Seconds()) as the duration. But is
 	// subjected to change in the future.
@@ -647,6 +754,10 @@ type (
 		// NOTE: WorkflowExecutionErrorWhenAlreadyStarted will affect if Client.ExecuteWorkflow returns an error
 		// when a re-run would be disallowed. See its docstring for more information.
 		//
+		// With UseExisting, WorkflowRun.GetStarted tells whether a new workflow was started or the call attached to
+		// the running one, which makes starting a singleton workflow race free. Use Client.SignalWithStartWorkflow to
+		// also deliver a signal atomically to the workflow in both cases.
+		//
 		// Optional: defaults to Fail (but required when used in WithStartWorkflowOperation).
 		WorkflowIDConflictPolicy enumspb.WorkflowIdConflictPolicy
 
@@ -762,12 +873,21 @@ type (
 		// WARNING: Task queue priority is currently experimental.
 		Priority Priority
 
+		// RequestID - Optional ID the server uses to deduplicate start requests. Starting a workflow again with the
+		// same request ID, for instance when retrying a start whose response was lost, returns the run started by the
+		// first request instead of failing as already started. Setting it to an idempotency key generated by an
+		// external system coordinates retries across processes. The request ID used is returned by
+		// WorkflowRun.GetRequestID.
+		//
+		// Optional: defaults to a random UUID.
+		//
+		// NOTE: Experimental
+		RequestID string
+
 		// responseInfo - Optional pointer to store information of StartWorkflowExecution response.
 		// Only settable by the SDK - e.g. [temporalnexus.workflowRunOperation].
 		responseInfo *startWorkflowResponseInfo
 
-		// request ID. Only settable by the SDK - e.g. [temporalnexus.workflowRunOperation].
-		requestID string
 		// workflow completion callback. Only settable by the SDK - e.g. [temporalnexus.workflowRunOperation].
 		callbacks []*commonpb.Callback
 		// links. Only settable by the SDK - e.g. [temporalnexus.workflowRunOperation].
@@ -955,6 +1075,9 @@ func NewClientFromExisting(ctx context.Context, existingClient Client, options C
 }
 
 func newClient(ctx context.Context, options ClientOptions, existing *WorkflowClient) (Client, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
 	if options.Namespace == "" {
 		options.Namespace = DefaultNamespace
 	}
@@ -979,6 +1102,10 @@ func newClient(ctx context.Context, options ClientOptions, existing *WorkflowCli
 			return nil, err
 		}
 	}
+	if options.ConnectionOptions.Authority != "" && options.ConnectionOptions.TLS != nil {
+		options.Logger.Warn("ConnectionOptions.Authority is ignored when TLS is enabled, " +
+			"set ConnectionOptions.TLS.ServerName instead")
+	}
 
 	// Dial or use existing connection
 	var connection *grpc.ClientConn
@@ -993,7 +1120,8 @@ func newClient(ctx context.Context, options ClientOptions, existing *WorkflowCli
 		connection = existing.conn
 	}
 
-	client := NewServiceClient(workflowservice.NewWorkflowServiceClient(connection), connection, options)
+	client := NewServiceClient(
+		workflowservice.NewWorkflowServiceClient(withStaticHeaders(connection, options.StaticHeaders)), connection, options)
 
 	// If using existing connection, always load its capabilities and use them for
 	// the new connection. Otherwise, only load server capabilities eagerly if not
@@ -1083,6 +1211,7 @@ func NewServiceClient(workflowServiceClient workflowservice.WorkflowServiceClien
 			workersByTaskQueue: make(map[string]map[eagerWorker]struct{}),
 		},
 		getSystemInfoTimeout: options.ConnectionOptions.GetSystemInfoTimeout,
+		staticHeaders:        options.StaticHeaders,
 	}
 
 	// Create outbound interceptor by wrapping backwards through chain
@@ -1259,10 +1388,30 @@ func (e *WorkflowUpdateServiceTimeoutOrCanceledError) Error() string {
 
 func (e *WorkflowUpdateServiceTimeoutOrCanceledError) Unwrap() error { return e.cause }
 
+// WorkflowUpdateRejectedError is the error returned by WorkflowUpdateHandle.Get for updates rejected by a Go workflow,
+// either by the validator of the update or because the workflow has no handler for it. The errors of updates that were
+// accepted and failed, and of updates rejected by workflows written with other SDKs, are returned as is.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.WorkflowUpdateRejectedError]
+type WorkflowUpdateRejectedError struct {
+	code  string
+	cause error
+}
+
+// Code returns the rejection code of the update, which is the type of the application error returned by the
+// validator, or empty if the validator returned another kind of error.
+func (e *WorkflowUpdateRejectedError) Code() string { return e.code }
+
+func (e *WorkflowUpdateRejectedError) Error() string { return e.cause.Error() }
+
+// Unwrap returns the reason the update was rejected for, like the application error returned by the validator.
+func (e *WorkflowUpdateRejectedError) Unwrap() error { return e.cause }
+
 // SetRequestIDOnStartWorkflowOptions is an internal only method for setting a requestID on StartWorkflowOptions.
-// RequestID is purposefully not exposed to users for the time being.
 func SetRequestIDOnStartWorkflowOptions(opts *StartWorkflowOptions, requestID string) {
-	opts.requestID = requestID
+	opts.RequestID = requestID
 }
 
 // SetCallbacksOnStartWorkflowOptions is an internal only method for setting callbacks on StartWorkflowOptions.
@@ -0,0 +1,213 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+type (
+	// MultiClientOptions are options for DialMultiClient and NewLazyMultiClient.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.MultiOptions]
+	MultiClientOptions struct {
+		// ClientOptions are the options of the shared connection, and the options of the namespace clients if
+		// NamespaceOptions is not set. The namespace of the options is only used for the client owning the
+		// connection, which is not handed out.
+		ClientOptions ClientOptions
+
+		// NamespaceOptions returns the options of the client of a namespace, so namespaces can have their own
+		// interceptors, data converters, metrics handlers and so on. It is called once, when the client of the
+		// namespace is first requested. The namespace of the returned options is replaced with the namespace, and
+		// the options configuring the connection, like HostPort, ConnectionOptions and Credentials, are ignored.
+		// The metrics of every namespace client are tagged with its namespace.
+		//
+		// Optional: defaults to ClientOptions for every namespace.
+		NamespaceOptions func(namespace string) (ClientOptions, error)
+	}
+
+	// MultiClient hands out clients of any number of namespaces that share a single connection, for processes
+	// serving many namespaces. Namespace clients are created when first requested and kept until removed or the
+	// MultiClient is closed.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.Multi]
+	MultiClient interface {
+		// Namespace returns the client of the namespace, creating it if needed. The client is closed by
+		// RemoveNamespace or Close, and must not be closed directly.
+		Namespace(ctx context.Context, namespace string) (Client, error)
+
+		// Namespaces returns the namespaces that currently have a client, sorted.
+		Namespaces() []string
+
+		// RemoveNamespace closes and forgets the client of the namespace, if any. Workers using the client must be
+		// stopped first. A new client is created if the namespace is requested again.
+		RemoveNamespace(namespace string)
+
+		// Close closes the clients of all namespaces and the shared connection.
+		Close()
+	}
+
+	multiClient struct {
+		options MultiClientOptions
+		// Client owning the shared connection
+		root *WorkflowClient
+
+		// Not held while namespace clients are created, so creating the client of a namespace does not block the
+		// others
+		clientsLock sync.Mutex
+		clients     map[string]Client
+		// Clients being created, so each namespace gets a single client
+		creations map[string]*namespaceClientCreation
+		closed    bool
+	}
+
+	// namespaceClientCreation is the creation of the client of a namespace, which concurrent requests for the
+	// namespace wait for.
+	namespaceClientCreation struct {
+		done   chan struct{}
+		client Client
+		err    error
+	}
+)
+
+var errMultiClientClosed = errors.New("multi client is closed")
+
+// DialMultiClient creates a MultiClient, connecting to the server eagerly.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.DialMulti]
+func DialMultiClient(ctx context.Context, options MultiClientOptions) (MultiClient, error) {
+	root, err := DialClient(ctx, options.ClientOptions)
+	if err != nil {
+		return nil, err
+	}
+	return newMultiClient(options, root.(*WorkflowClient)), nil
+}
+
+// NewLazyMultiClient creates a MultiClient and does not attempt to connect to the server until the first namespace
+// client is requested.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.NewLazyMulti]
+func NewLazyMultiClient(options MultiClientOptions) (MultiClient, error) {
+	root, err := NewLazyClient(options.ClientOptions)
+	if err != nil {
+		return nil, err
+	}
+	return newMultiClient(options, root.(*WorkflowClient)), nil
+}
+
+func newMultiClient(options MultiClientOptions, root *WorkflowClient) *multiClient {
+	return &multiClient{
+		options:   options,
+		root:      root,
+		clients:   map[string]Client{},
+		creations: map[string]*namespaceClientCreation{},
+	}
+}
+
+func (m *multiClient) Namespace(ctx context.Context, namespace string) (Client, error) {
+	if namespace == "" {
+		return nil, errors.New("namespace is required")
+	}
+	m.clientsLock.Lock()
+	if m.closed {
+		m.clientsLock.Unlock()
+		return nil, errMultiClientClosed
+	}
+	if client, ok := m.clients[namespace]; ok {
+		m.clientsLock.Unlock()
+		return client, nil
+	}
+	if creation, ok := m.creations[namespace]; ok {
+		m.clientsLock.Unlock()
+		select {
+		case <-creation.done:
+			return creation.client, creation.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	creation := &namespaceClientCreation{done: make(chan struct{})}
+	m.creations[namespace] = creation
+	m.clientsLock.Unlock()
+	defer close(creation.done)
+
+	creation.client, creation.err = m.newNamespaceClient(ctx, namespace)
+	var closedClient Client
+	m.clientsLock.Lock()
+	delete(m.creations, namespace)
+	if creation.err == nil {
+		if m.closed {
+			closedClient = creation.client
+			creation.client, creation.err = nil, errMultiClientClosed
+		} else {
+			m.clients[namespace] = creation.client
+		}
+	}
+	m.clientsLock.Unlock()
+	if closedClient != nil {
+		closedClient.Close()
+	}
+	return creation.client, creation.err
+}
+
+func (m *multiClient) newNamespaceClient(ctx context.Context, namespace string) (Client, error) {
+	options := m.options.ClientOptions
+	if m.options.NamespaceOptions != nil {
+		var err error
+		if options, err = m.options.NamespaceOptions(namespace); err != nil {
+			return nil, err
+		}
+	}
+	options.Namespace = namespace
+	if options.Logger == nil {
+		options.Logger = m.root.logger
+	}
+	return newClient(ctx, options, m.root)
+}
+
+func (m *multiClient) Namespaces() []string {
+	m.clientsLock.Lock()
+	defer m.clientsLock.Unlock()
+	namespaces := make([]string, 0, len(m.clients))
+	for namespace := range m.clients {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+func (m *multiClient) RemoveNamespace(namespace string) {
+	m.clientsLock.Lock()
+	client, ok := m.clients[namespace]
+	delete(m.clients, namespace)
+	m.clientsLock.Unlock()
+	if ok {
+		client.Close()
+	}
+}
+
+func (m *multiClient) Close() {
+	m.clientsLock.Lock()
+	if m.closed {
+		m.clientsLock.Unlock()
+		return
+	}
+	m.closed = true
+	clients := m.clients
+	m.clients = map[string]Client{}
+	m.clientsLock.Unlock()
+	for _, client := range clients {
+		client.Close()
+	}
+	// The connection is closed with the last client using it
+	m.root.Close()
+}
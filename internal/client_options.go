@@ -0,0 +1,202 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/resolver"
+)
+
+// Validate checks the options for misconfigurations that would otherwise only surface on the first call to the
+// server, such as a blank namespace, a malformed host:port, or conflicting mTLS settings. It is called when a client is
+// created, so calling it directly is only needed to check options ahead of time.
+//
+// NOTE: Experimental
+func (o ClientOptions) Validate() error {
+	var errs []error
+	if o.Namespace != "" && strings.TrimSpace(o.Namespace) == "" {
+		errs = append(errs, errors.New("namespace must not be blank"))
+	}
+	if err := validateHostPort(o.HostPort); err != nil {
+		errs = append(errs, err)
+	}
+
+	conn := o.ConnectionOptions
+	_, mTLS := o.Credentials.(mTLSCredentials)
+	if mTLS && conn.TLS != nil && len(conn.TLS.Certificates) != 0 {
+		errs = append(errs, errors.New("mTLS credentials cannot be combined with certificates set on ConnectionOptions.TLS"))
+	}
+	if conn.KeepAliveTime < 0 {
+		errs = append(errs, fmt.Errorf("ConnectionOptions.KeepAliveTime must not be negative, got %v", conn.KeepAliveTime))
+	}
+	if conn.KeepAliveTimeout < 0 {
+		errs = append(errs, fmt.Errorf("ConnectionOptions.KeepAliveTimeout must not be negative, got %v", conn.KeepAliveTimeout))
+	}
+	if conn.GetSystemInfoTimeout < 0 {
+		errs = append(errs, fmt.Errorf("ConnectionOptions.GetSystemInfoTimeout must not be negative, got %v", conn.GetSystemInfoTimeout))
+	}
+	if conn.MaxPayloadSize < 0 {
+		errs = append(errs, fmt.Errorf("ConnectionOptions.MaxPayloadSize must not be negative, got %d", conn.MaxPayloadSize))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid client options: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// validateHostPort rejects addresses gRPC cannot dial. Resolver targets, such as "dns:///<host>" or
+// "unix:<path>", and addresses without a port, which the DNS resolver defaults, are left to gRPC.
+func validateHostPort(hostPort string) error {
+	if hostPort == "" || strings.Contains(hostPort, "://") {
+		return nil
+	}
+	if scheme, _, ok := strings.Cut(hostPort, ":"); ok && resolver.Get(scheme) != nil {
+		return nil
+	}
+	if strings.ContainsAny(hostPort, " \t\r\n") {
+		return fmt.Errorf("HostPort %q must not contain whitespace", hostPort)
+	}
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		// Addresses without a port (including bare IPv6 addresses) are valid targets.
+		return nil
+	}
+	if host == "" {
+		return fmt.Errorf("HostPort %q is missing a host", hostPort)
+	}
+	if n, err := strconv.ParseUint(port, 10, 16); err != nil || n == 0 {
+		return fmt.Errorf("HostPort %q has an invalid port %q", hostPort, port)
+	}
+	return nil
+}
+
+// EffectiveConfig renders the configuration a client created with these options would use, with defaults resolved.
+// Secrets are never rendered: API keys, client certificates, and headers only show whether they are set. The output is
+// meant for logs and debugging, its format may change.
+//
+// NOTE: Experimental
+func (o ClientOptions) EffectiveConfig() string {
+	conn := o.ConnectionOptions
+	var b strings.Builder
+	field := func(name string, value interface{}) {
+		_, _ = fmt.Fprintf(&b, "%s: %v\n", name, value)
+	}
+
+	namespace := o.Namespace
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	hostPort := o.HostPort
+	if hostPort == "" {
+		hostPort = LocalHostPort
+	}
+	identity := o.Identity
+	if identity == "" {
+		identity = getWorkerIdentity("")
+	}
+	field("Namespace", namespace)
+	field("HostPort", hostPort)
+	field("Identity", identity)
+
+	var mTLSCertificates int
+	switch o.Credentials.(type) {
+	case nil:
+		field("Credentials", "none")
+	case apiKeyCredentials:
+		field("Credentials", "API key (masked)")
+	case mTLSCredentials:
+		mTLSCertificates = 1
+		field("Credentials", "mTLS certificate (masked)")
+	default:
+		field("Credentials", fmt.Sprintf("%T (masked)", o.Credentials))
+	}
+
+	if conn.TLS == nil && mTLSCertificates == 0 {
+		field("TLS", "disabled")
+		field("Authority", valueOrDefault(conn.Authority, "<HostPort>"))
+	} else {
+		rootCAs := "system"
+		serverName := "<HostPort>"
+		var insecureSkipVerify bool
+		certificates := mTLSCertificates
+		if conn.TLS != nil {
+			if conn.TLS.RootCAs != nil {
+				rootCAs = "custom"
+			}
+			serverName = valueOrDefault(conn.TLS.ServerName, serverName)
+			insecureSkipVerify = conn.TLS.InsecureSkipVerify
+			certificates += len(conn.TLS.Certificates)
+			if conn.TLS.GetClientCertificate != nil {
+				certificates++
+			}
+		}
+		field("TLS", "enabled")
+		field("TLS.ServerName", serverName)
+		field("TLS.RootCAs", rootCAs)
+		field("TLS.ClientCertificates", fmt.Sprintf("%d (masked)", certificates))
+		field("TLS.InsecureSkipVerify", insecureSkipVerify)
+	}
+
+	if conn.DisableKeepAliveCheck {
+		field("KeepAlive", "disabled")
+	} else {
+		keepAliveTime, keepAliveTimeout := conn.KeepAliveTime, conn.KeepAliveTimeout
+		if keepAliveTime == 0 {
+			keepAliveTime = defaultKeepAliveTime
+		}
+		if keepAliveTimeout == 0 {
+			keepAliveTimeout = defaultKeepAliveTimeout
+		}
+		field("KeepAliveTime", keepAliveTime)
+		field("KeepAliveTimeout", keepAliveTimeout)
+		field("KeepAlivePermitWithoutStream", !conn.DisableKeepAlivePermitWithoutStream)
+	}
+	maxPayloadSize := conn.MaxPayloadSize
+	if maxPayloadSize == 0 {
+		maxPayloadSize = defaultMaxPayloadSize
+	}
+	getSystemInfoTimeout := conn.GetSystemInfoTimeout
+	if getSystemInfoTimeout == 0 {
+		getSystemInfoTimeout = defaultGetSystemInfoTimeout
+	}
+	field("MaxPayloadSize", maxPayloadSize)
+	field("GetSystemInfoTimeout", getSystemInfoTimeout)
+	field("DialOptions", len(conn.DialOptions))
+
+	field("HeadersProvider", isSet(o.HeadersProvider != nil))
+	field("StaticHeaders", len(o.StaticHeaders))
+	field("TrafficController", isSet(o.TrafficController != nil))
+	field("Logger", typeOrDefault(o.Logger, "default"))
+	field("MetricsHandler", typeOrDefault(o.MetricsHandler, "none"))
+	field("DataConverter", typeOrDefault(o.DataConverter, "default"))
+	field("FailureConverter", typeOrDefault(o.FailureConverter, "default"))
+	field("ContextPropagators", len(o.ContextPropagators))
+	field("Interceptors", len(o.Interceptors))
+	field("DisableErrorCodeMetricTags", o.DisableErrorCodeMetricTags)
+	field("NamespaceFailover", isSet(o.NamespaceFailover != nil))
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func valueOrDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
+
+func isSet(set bool) string {
+	if set {
+		return "set (masked)"
+	}
+	return "unset"
+}
+
+func typeOrDefault(value interface{}, def string) string {
+	if value == nil {
+		return def
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"crypto/tls"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientOptionsValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		options ClientOptions
+		err     string
+	}{
+		{"empty", ClientOptions{}, ""},
+		{"host and port", ClientOptions{HostPort: "localhost:7233"}, ""},
+		{"IPv6", ClientOptions{HostPort: "[::1]:7233"}, ""},
+		{"no port", ClientOptions{HostPort: "my-namespace.tmprl.cloud"}, ""},
+		{"resolver", ClientOptions{HostPort: "myresolver:///ignored"}, ""},
+		{"dns resolver", ClientOptions{HostPort: "dns:///localhost:7233"}, ""},
+		{"passthrough resolver", ClientOptions{HostPort: "passthrough:///localhost:7233"}, ""},
+		{"unix socket", ClientOptions{HostPort: "unix:/tmp/temporal.sock"}, ""},
+		{"absolute unix socket", ClientOptions{HostPort: "unix:///tmp/temporal.sock"}, ""},
+		{"abstract unix socket", ClientOptions{HostPort: "unix-abstract:temporal"}, ""},
+		{
+			"authority with TLS",
+			ClientOptions{ConnectionOptions: ConnectionOptions{TLS: &tls.Config{}, Authority: "my-authority"}},
+			"",
+		},
+		{"blank namespace", ClientOptions{Namespace: "  "}, "namespace must not be blank"},
+		{"empty port", ClientOptions{HostPort: "localhost:"}, "invalid port"},
+		{"invalid port", ClientOptions{HostPort: "localhost:abc"}, "invalid port"},
+		{"missing host", ClientOptions{HostPort: ":7233"}, "missing a host"},
+		{"whitespace", ClientOptions{HostPort: "localhost :7233"}, "whitespace"},
+		{
+			"mTLS with certificates",
+			ClientOptions{
+				Credentials:       NewMTLSCredentials(tls.Certificate{}),
+				ConnectionOptions: ConnectionOptions{TLS: &tls.Config{Certificates: []tls.Certificate{{}}}},
+			},
+			"mTLS credentials cannot be combined",
+		},
+		{
+			"negative keep alive",
+			ClientOptions{ConnectionOptions: ConnectionOptions{KeepAliveTime: -time.Second}},
+			"KeepAliveTime must not be negative",
+		},
+		{
+			"negative payload size",
+			ClientOptions{ConnectionOptions: ConnectionOptions{MaxPayloadSize: -1}},
+			"MaxPayloadSize must not be negative",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.options.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestClientOptionsValidatedOnCreate(t *testing.T) {
+	_, err := NewLazyClient(ClientOptions{Namespace: " "})
+	require.ErrorContains(t, err, "namespace must not be blank")
+}
+
+func TestClientOptionsEffectiveConfig(t *testing.T) {
+	config := ClientOptions{Identity: "my-identity"}.EffectiveConfig()
+	require.Contains(t, config, "Namespace: "+DefaultNamespace+"\n")
+	require.Contains(t, config, "HostPort: "+LocalHostPort+"\n")
+	require.Contains(t, config, "Identity: my-identity\n")
+	require.Contains(t, config, "TLS: disabled\n")
+	require.Contains(t, config, "KeepAliveTime: 30s\n")
+
+	config = ClientOptions{
+		Namespace:         "my-namespace",
+		Credentials:       NewAPIKeyStaticCredentials("my-secret-key"),
+		HeadersProvider:   authHeadersProvider{token: "my-secret-token"},
+		ConnectionOptions: ConnectionOptions{TLS: &tls.Config{ServerName: "my-server"}},
+	}.EffectiveConfig()
+	require.Contains(t, config, "Namespace: my-namespace\n")
+	require.Contains(t, config, "Credentials: API key (masked)\n")
+	require.Contains(t, config, "TLS: enabled\n")
+	require.Contains(t, config, "TLS.ServerName: my-server\n")
+	require.Contains(t, config, "HeadersProvider: set (masked)\n")
+	require.NotContains(t, config, "my-secret")
+}
@@ -30,6 +30,15 @@ type Cache interface {
 	// Size returns the number of entries currently stored in the Cache
 	Size() int
 
+	// UpdateSize sets the size of an entry used for MaxTotalSize, evicting
+	// other entries if the total size is exceeded. It is a no-op if the entry
+	// does not exist.
+	UpdateSize(key string, size int64)
+
+	// TotalSize returns the sum of the sizes of the entries currently stored in
+	// the Cache
+	TotalSize() int64
+
 	// Clear clears the cache.
 	Clear()
 }
@@ -49,10 +58,41 @@ type Options struct {
 	// RemovedFunc is an optional function called when an element
 	// is scheduled for deletion
 	RemovedFunc RemovedFunc
+
+	// MaxIdleTime controls how long an entry may go without being accessed
+	// before it is evicted. Expired entries are evicted when the cache is
+	// accessed.
+	MaxIdleTime time.Duration
+
+	// MaxTotalSize controls the maximum sum of the sizes of the entries set
+	// with UpdateSize. Least recently used entries are evicted when it is
+	// exceeded.
+	MaxTotalSize int64
+
+	// EvictedFunc is an optional function called when an element is
+	// evicted by the cache, in addition to RemovedFunc
+	EvictedFunc EvictedFunc
 }
 
+// EvictionReason is the reason the cache evicted an element.
+type EvictionReason string
+
+const (
+	// EvictionReasonCapacity is used when the maximum number of entries was exceeded
+	EvictionReasonCapacity EvictionReason = "capacity"
+	// EvictionReasonIdle is used when the entry was not accessed for MaxIdleTime
+	EvictionReasonIdle EvictionReason = "idle"
+	// EvictionReasonSize is used when MaxTotalSize was exceeded
+	EvictionReasonSize EvictionReason = "size"
+)
+
 // RemovedFunc is a type for notifying applications when an item is
 // scheduled for removal from the Cache. If f is a function with the
 // appropriate signature and i is the interface{} scheduled for
 // deletion, Cache calls go f(i)
 type RemovedFunc func(interface{})
+
+// EvictedFunc is a type for notifying applications when an item is evicted
+// by the Cache, with the reason for the eviction. Like RemovedFunc, it is
+// called in a new goroutine.
+type EvictedFunc func(value interface{}, reason EvictionReason)
@@ -14,13 +14,17 @@ var (
 
 // lru is a concurrent fixed size cache that evicts elements in lru order
 type lru struct {
-	mut      sync.Mutex
-	byAccess *list.List
-	byKey    map[string]*list.Element
-	maxSize  int
-	ttl      time.Duration
-	pin      bool
-	rmFunc   RemovedFunc
+	mut          sync.Mutex
+	byAccess     *list.List
+	byKey        map[string]*list.Element
+	maxSize      int
+	ttl          time.Duration
+	pin          bool
+	rmFunc       RemovedFunc
+	maxIdleTime  time.Duration
+	maxTotalSize int64
+	totalSize    int64
+	evictedFunc  EvictedFunc
 }
 
 // New creates a new cache with the given options
@@ -30,12 +34,15 @@ func New(maxSize int, opts *Options) Cache {
 	}
 
 	return &lru{
-		byAccess: list.New(),
-		byKey:    make(map[string]*list.Element, opts.InitialCapacity),
-		ttl:      opts.TTL,
-		maxSize:  maxSize,
-		pin:      opts.Pin,
-		rmFunc:   opts.RemovedFunc,
+		byAccess:     list.New(),
+		byKey:        make(map[string]*list.Element, opts.InitialCapacity),
+		ttl:          opts.TTL,
+		maxSize:      maxSize,
+		pin:          opts.Pin,
+		rmFunc:       opts.RemovedFunc,
+		maxIdleTime:  opts.MaxIdleTime,
+		maxTotalSize: opts.MaxTotalSize,
+		evictedFunc:  opts.EvictedFunc,
 	}
 }
 
@@ -66,6 +73,7 @@ func (c *lru) Get(key string) interface{} {
 	c.mut.Lock()
 	defer c.mut.Unlock()
 
+	c.evictIdleNoLock()
 	elt := c.byKey[key]
 	if elt == nil {
 		return nil
@@ -84,9 +92,11 @@ func (c *lru) Get(key string) interface{} {
 		}
 		c.byAccess.Remove(elt)
 		delete(c.byKey, cacheEntry.key)
+		c.totalSize -= cacheEntry.size
 		return nil
 	}
 
+	cacheEntry.lastAccess = time.Now()
 	c.byAccess.MoveToFront(elt)
 	return cacheEntry.value
 }
@@ -127,6 +137,7 @@ func (c *lru) Delete(key string) {
 			go c.rmFunc(entry.value)
 		}
 		delete(c.byKey, key)
+		c.totalSize -= entry.size
 	}
 }
 
@@ -148,6 +159,39 @@ func (c *lru) Size() int {
 	return len(c.byKey)
 }
 
+// UpdateSize sets the size of an entry, evicting least recently used entries if the total size is exceeded
+func (c *lru) UpdateSize(key string, size int64) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	elt := c.byKey[key]
+	if elt == nil {
+		return
+	}
+	entry := elt.Value.(*cacheEntry)
+	c.totalSize += size - entry.size
+	entry.size = size
+	if c.maxTotalSize <= 0 {
+		return
+	}
+	// Evict from least recently used, skipping pinned elements
+	for elt := c.byAccess.Back(); elt != nil && c.totalSize > c.maxTotalSize; {
+		prev := elt.Prev()
+		if entry := elt.Value.(*cacheEntry); entry.refCount == 0 {
+			c.evictNoLock(elt, EvictionReasonSize)
+		}
+		elt = prev
+	}
+}
+
+// TotalSize returns the sum of the sizes of the entries currently in the lru
+func (c *lru) TotalSize() int64 {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return c.totalSize
+}
+
 // Clear clears the cache.
 func (c *lru) Clear() {
 	c.mut.Lock()
@@ -162,6 +206,39 @@ func (c *lru) Clear() {
 			delete(c.byKey, key)
 		}
 	}
+	c.totalSize = 0
+}
+
+// evictIdleNoLock evicts the entries that have not been accessed for maxIdleTime
+func (c *lru) evictIdleNoLock() {
+	if c.maxIdleTime <= 0 {
+		return
+	}
+	cutoff := time.Now().Add(-c.maxIdleTime)
+	// Elements are ordered by access time, so stop at the first one accessed after the cutoff
+	for elt := c.byAccess.Back(); elt != nil; {
+		entry := elt.Value.(*cacheEntry)
+		if entry.lastAccess.After(cutoff) {
+			return
+		}
+		prev := elt.Prev()
+		if entry.refCount == 0 {
+			c.evictNoLock(elt, EvictionReasonIdle)
+		}
+		elt = prev
+	}
+}
+
+func (c *lru) evictNoLock(elt *list.Element, reason EvictionReason) {
+	entry := c.byAccess.Remove(elt).(*cacheEntry)
+	delete(c.byKey, entry.key)
+	c.totalSize -= entry.size
+	if c.rmFunc != nil {
+		go c.rmFunc(entry.value)
+	}
+	if c.evictedFunc != nil {
+		go c.evictedFunc(entry.value, reason)
+	}
 }
 
 // Put puts a new value associated with a given key, returning the existing value (if present)
@@ -170,6 +247,7 @@ func (c *lru) putInternal(key string, value interface{}, allowUpdate bool) (inte
 	c.mut.Lock()
 	defer c.mut.Unlock()
 
+	c.evictIdleNoLock()
 	elt := c.byKey[key]
 	if elt != nil {
 		entry := elt.Value.(*cacheEntry)
@@ -180,6 +258,7 @@ func (c *lru) putInternal(key string, value interface{}, allowUpdate bool) (inte
 		if c.ttl != 0 {
 			entry.expiration = time.Now().Add(c.ttl)
 		}
+		entry.lastAccess = time.Now()
 		c.byAccess.MoveToFront(elt)
 		if c.pin {
 			entry.refCount++
@@ -188,8 +267,9 @@ func (c *lru) putInternal(key string, value interface{}, allowUpdate bool) (inte
 	}
 
 	entry := &cacheEntry{
-		key:   key,
-		value: value,
+		key:        key,
+		value:      value,
+		lastAccess: time.Now(),
 	}
 
 	if c.pin {
@@ -213,11 +293,7 @@ func (c *lru) putInternal(key string, value interface{}, allowUpdate bool) (inte
 			return nil, ErrCacheFull
 		}
 
-		c.byAccess.Remove(c.byAccess.Back())
-		if c.rmFunc != nil {
-			go c.rmFunc(oldest.value)
-		}
-		delete(c.byKey, oldest.key)
+		c.evictNoLock(c.byAccess.Back(), EvictionReasonCapacity)
 	}
 
 	return nil, nil
@@ -226,6 +302,8 @@ func (c *lru) putInternal(key string, value interface{}, allowUpdate bool) (inte
 type cacheEntry struct {
 	key        string
 	expiration time.Time
+	lastAccess time.Time
 	value      interface{}
 	refCount   int
+	size       int64
 }
@@ -172,3 +172,63 @@ func TestLRUMax(t *testing.T) {
 	assert.Equal(t, "Bar", cache.Get("B"))
 	assert.Equal(t, 1, cache.Size())
 }
+
+func TestMaxIdleTime(t *testing.T) {
+	evicted := make(chan EvictionReason, 1)
+	cache := New(5, &Options{
+		MaxIdleTime: time.Millisecond * 50,
+		EvictedFunc: func(_ interface{}, reason EvictionReason) {
+			evicted <- reason
+		},
+	})
+
+	cache.Put("A", "Foo")
+	cache.Put("B", "Bar")
+	time.Sleep(time.Millisecond * 30)
+	// Accessing B keeps it alive
+	assert.Equal(t, "Bar", cache.Get("B"))
+	time.Sleep(time.Millisecond * 30)
+	assert.Nil(t, cache.Get("A"))
+	assert.Equal(t, "Bar", cache.Get("B"))
+	assert.Equal(t, 1, cache.Size())
+
+	select {
+	case reason := <-evicted:
+		assert.Equal(t, EvictionReasonIdle, reason)
+	case <-time.After(time.Millisecond * 300):
+		t.Error("EvictedFunc was not called")
+	}
+}
+
+func TestMaxTotalSize(t *testing.T) {
+	evicted := make(chan interface{}, 2)
+	cache := New(5, &Options{
+		MaxTotalSize: 100,
+		EvictedFunc: func(value interface{}, reason EvictionReason) {
+			assert.Equal(t, EvictionReasonSize, reason)
+			evicted <- value
+		},
+	})
+
+	cache.Put("A", "Foo")
+	cache.Put("B", "Bar")
+	cache.Put("C", "Baz")
+	cache.UpdateSize("A", 40)
+	cache.UpdateSize("B", 40)
+	assert.Equal(t, int64(80), cache.TotalSize())
+	assert.Equal(t, 3, cache.Size())
+
+	// Least recently used entries are evicted until the total size fits
+	cache.Get("A")
+	cache.UpdateSize("C", 40)
+	assert.Equal(t, int64(80), cache.TotalSize())
+	assert.Nil(t, cache.Get("B"))
+	assert.Equal(t, "Foo", cache.Get("A"))
+	assert.Equal(t, "Baz", cache.Get("C"))
+	assert.Equal(t, "Bar", <-evicted)
+
+	cache.Delete("A")
+	assert.Equal(t, int64(40), cache.TotalSize())
+	cache.UpdateSize("unknown", 1000)
+	assert.Equal(t, int64(40), cache.TotalSize())
+}
@@ -9,10 +9,11 @@ import (
 // This file contains test helpers only. They are not private because they are used by other tests.
 
 type capturedInfo struct {
-	sliceLock sync.RWMutex // Only governs slice access, not what's in the slice
-	counters  []*CapturedCounter
-	gauges    []*CapturedGauge
-	timers    []*CapturedTimer
+	sliceLock  sync.RWMutex // Only governs slice access, not what's in the slice
+	counters   []*CapturedCounter
+	gauges     []*CapturedGauge
+	timers     []*CapturedTimer
+	histograms []*CapturedHistogram
 }
 
 // CapturingHandler is a Handler that retains counted values locally.
@@ -22,7 +23,10 @@ type CapturingHandler struct {
 	tags map[string]string
 }
 
-var _ Handler = &CapturingHandler{}
+var (
+	_ Handler          = &CapturingHandler{}
+	_ HistogramHandler = &CapturingHandler{}
+)
 
 // NewCapturingHandler creates a new CapturingHandler.
 func NewCapturingHandler() *CapturingHandler { return &CapturingHandler{capturedInfo: &capturedInfo{}} }
@@ -34,6 +38,7 @@ func (c *CapturingHandler) Clear() {
 	c.counters = nil
 	c.gauges = nil
 	c.timers = nil
+	c.histograms = nil
 }
 
 // WithTags implements Handler.WithTags.
@@ -135,6 +140,35 @@ func (c *CapturingHandler) Timers() []*CapturedTimer {
 	return ret
 }
 
+// Histogram implements HistogramHandler.Histogram.
+func (c *CapturingHandler) Histogram(name string) Histogram {
+	c.sliceLock.Lock()
+	defer c.sliceLock.Unlock()
+	// Try to find one or create otherwise
+	var ret *CapturedHistogram
+	for _, histogram := range c.histograms {
+		if histogram.Name == name && histogram.equalTags(c.tags) {
+			ret = histogram
+			break
+		}
+	}
+	if ret == nil {
+		ret = &CapturedHistogram{CapturedMetricMeta: CapturedMetricMeta{Name: name, Tags: c.tags}}
+		c.histograms = append(c.histograms, ret)
+	}
+	return ret
+}
+
+// Histograms returns shallow copy of the local histograms. New histograms will
+// not get added here, but the values within the histogram may still change.
+func (c *CapturingHandler) Histograms() []*CapturedHistogram {
+	c.sliceLock.RLock()
+	defer c.sliceLock.RUnlock()
+	ret := make([]*CapturedHistogram, len(c.histograms))
+	copy(ret, c.histograms)
+	return ret
+}
+
 // CapturedMetricMeta is common information for captured metrics. These fields
 // should never by mutated.
 type CapturedMetricMeta struct {
@@ -198,3 +232,27 @@ func (c *CapturedTimer) Record(d time.Duration) { atomic.StoreInt64(&c.value, in
 
 // Value atomically returns the current value.
 func (c *CapturedTimer) Value() time.Duration { return time.Duration(atomic.LoadInt64(&c.value)) }
+
+// CapturedHistogram implements Histogram and provides a getter for the recorded
+// values.
+type CapturedHistogram struct {
+	CapturedMetricMeta
+	values     []float64
+	valuesLock sync.RWMutex
+}
+
+// RecordValue implements Histogram.RecordValue.
+func (c *CapturedHistogram) RecordValue(v float64) {
+	c.valuesLock.Lock()
+	defer c.valuesLock.Unlock()
+	c.values = append(c.values, v)
+}
+
+// Values returns a copy of the recorded values.
+func (c *CapturedHistogram) Values() []float64 {
+	c.valuesLock.RLock()
+	defer c.valuesLock.RUnlock()
+	ret := make([]float64, len(c.values))
+	copy(ret, c.values)
+	return ret
+}
@@ -34,15 +34,24 @@ const (
 	LocalActivityErrorCounter             = TemporalMetricsPrefix + "local_activity_error"
 	LocalActivityExecutionLatency         = TemporalMetricsPrefix + "local_activity_execution_latency"
 	LocalActivitySucceedEndToEndLatency   = TemporalMetricsPrefix + "local_activity_succeed_endtoend_latency"
+	LocalActivityWatchdogTimeoutCounter   = TemporalMetricsPrefix + "local_activity_watchdog_timeout"
 
 	CorruptedSignalsCounter = TemporalMetricsPrefix + "corrupted_signals"
+	UnhandledSignalsCounter = TemporalMetricsPrefix + "unhandled_signals"
+
+	WorkflowUpdateRejectedCounter = TemporalMetricsPrefix + "workflow_update_rejected"
 
 	WorkerStartCounter       = TemporalMetricsPrefix + "worker_start"
 	WorkerTaskSlotsAvailable = TemporalMetricsPrefix + "worker_task_slots_available"
 	WorkerTaskSlotsUsed      = TemporalMetricsPrefix + "worker_task_slots_used"
+	WorkerTaskSlotsExhausted = TemporalMetricsPrefix + "worker_task_slots_exhausted"
 	PollerStartCounter       = TemporalMetricsPrefix + "poller_start"
 	NumPoller                = TemporalMetricsPrefix + "num_pollers"
 
+	ActivityExecutorPoolSlotsUsed = TemporalMetricsPrefix + "activity_executor_pool_slots_used"
+
+	ScheduleToStartSLOViolationCounter = TemporalMetricsPrefix + "schedule_to_start_slo_violation"
+
 	TemporalRequest                      = TemporalMetricsPrefix + "request"
 	TemporalRequestFailure               = TemporalRequest + "_failure"
 	TemporalRequestLatency               = TemporalRequest + "_latency"
@@ -56,6 +65,9 @@ const (
 	StickyCacheMiss                = TemporalMetricsPrefix + "sticky_cache_miss"
 	StickyCacheTotalForcedEviction = TemporalMetricsPrefix + "sticky_cache_total_forced_eviction"
 	StickyCacheSize                = TemporalMetricsPrefix + "sticky_cache_size"
+	StickyCacheEviction            = TemporalMetricsPrefix + "sticky_cache_eviction"
+	StickyCacheEntryHistorySize    = TemporalMetricsPrefix + "sticky_cache_entry_history_size"
+	StickyCacheHistorySize         = TemporalMetricsPrefix + "sticky_cache_history_size"
 
 	WorkflowActiveThreadCount = TemporalMetricsPrefix + "workflow_active_thread_count"
 
@@ -64,6 +76,13 @@ const (
 	NexusTaskExecutionFailedCounter = TemporalMetricsPrefix + "nexus_task_execution_failed"
 	NexusTaskExecutionLatency       = TemporalMetricsPrefix + "nexus_task_execution_latency"
 	NexusTaskEndToEndLatency        = TemporalMetricsPrefix + "nexus_task_endtoend_latency"
+
+	WorkflowInputSize       = TemporalMetricsPrefix + "workflow_input_size"
+	ActivityInputSize       = TemporalMetricsPrefix + "activity_input_size"
+	ActivityOutputSize      = TemporalMetricsPrefix + "activity_output_size"
+	WorkflowTaskHistorySize = TemporalMetricsPrefix + "workflow_task_history_size"
+
+	WorkflowGetVersionCounter = TemporalMetricsPrefix + "workflow_get_version"
 )
 
 // Metric tag keys
@@ -76,11 +95,21 @@ const (
 	ActivityTypeNameTagName = "activity_type"
 	NexusServiceTagName     = "nexus_service"
 	NexusOperationTagName   = "nexus_operation"
+	SignalNameTagName       = "signal_name"
+	UpdateNameTagName       = "update_name"
+	RejectionCodeTagName    = "rejection_code"
 	FailureReasonTagName    = "failure_reason"
 	TaskQueueTagName        = "task_queue"
+	BuildIDTagName          = "build_id"
+	WorkerIdentityTagName   = "worker_identity"
 	OperationTagName        = "operation"
 	CauseTagName            = "cause"
+	EvictionReasonTagName   = "eviction_reason"
 	RequestFailureCode      = "status_code"
+	ChangeIDTagName         = "change_id"
+	VersionTagName          = "version"
+	ReplayTagName           = "replay"
+	ExecutorPoolTagName     = "executor_pool"
 )
 
 // Metric tag values
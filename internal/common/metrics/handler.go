@@ -60,6 +60,44 @@ type TimerFunc func(time.Duration)
 // Record implements Timer.Record.
 func (t TimerFunc) Record(d time.Duration) { t(d) }
 
+// HistogramHandler is an optional interface a Handler may implement to support
+// histograms of values that are not durations, such as payload sizes. Use
+// HistogramFromHandler to obtain a histogram from any Handler.
+type HistogramHandler interface {
+	// Histogram obtains a histogram for the given name.
+	Histogram(name string) Histogram
+}
+
+// Histogram records the distribution of values.
+type Histogram interface {
+	// RecordValue records a value in the histogram.
+	RecordValue(float64)
+}
+
+// HistogramFunc implements Histogram with a single function.
+type HistogramFunc func(float64)
+
+// RecordValue implements Histogram.RecordValue.
+func (h HistogramFunc) RecordValue(v float64) { h(v) }
+
+// HistogramFromHandler obtains a histogram for the given name from the handler,
+// unwrapping it until a handler implementing HistogramHandler is found. If no
+// handler in the chain supports histograms, values are not recorded.
+func HistogramFromHandler(handler Handler, name string) Histogram {
+	// Continually unwrap until we find a handler supporting histograms
+	for {
+		if histogramHandler, ok := handler.(HistogramHandler); ok {
+			return histogramHandler.Histogram(name)
+		}
+		// If unwrappable, do so, otherwise return noop
+		unwrappable, _ := handler.(interface{ Unwrap() Handler })
+		if unwrappable == nil {
+			return nopHandler{}
+		}
+		handler = unwrappable.Unwrap()
+	}
+}
+
 // NopHandler is a noop handler that does nothing with the metrics.
 var NopHandler Handler = nopHandler{}
 
@@ -72,6 +110,7 @@ func (nopHandler) Timer(string) Timer                 { return nopHandler{} }
 func (nopHandler) Inc(int64)                          {}
 func (nopHandler) Update(float64)                     {}
 func (nopHandler) Record(time.Duration)               {}
+func (nopHandler) RecordValue(float64)                {}
 
 type replayAwareHandler struct {
 	replay     *bool
@@ -115,6 +154,15 @@ func (r *replayAwareHandler) Timer(name string) Timer {
 	})
 }
 
+func (r *replayAwareHandler) Histogram(name string) Histogram {
+	underlying := HistogramFromHandler(r.underlying, name)
+	return HistogramFunc(func(v float64) {
+		if !*r.replay {
+			underlying.RecordValue(v)
+		}
+	})
+}
+
 func (r *replayAwareHandler) Unwrap() Handler {
 	return r.underlying
 }
@@ -37,3 +37,25 @@ func TestReplayAwareHandler(t *testing.T) {
 	require.Len(t, capture.Timers(), 1)
 	require.Equal(t, 6*time.Second, capture.Timers()[0].Value())
 }
+
+type wrappingHandler struct{ metrics.Handler }
+
+func (w wrappingHandler) Unwrap() metrics.Handler { return w.Handler }
+
+func TestHistogramFromHandler(t *testing.T) {
+	var replaying bool
+	capture := metrics.NewCapturingHandler()
+	handler := wrappingHandler{metrics.NewReplayAwareHandler(&replaying, capture)}
+
+	// Wrapped handlers are unwrapped, honoring replay
+	replaying = true
+	metrics.HistogramFromHandler(handler, "histogram1").RecordValue(1)
+	replaying = false
+	metrics.HistogramFromHandler(handler, "histogram1").RecordValue(2)
+	require.Len(t, capture.Histograms(), 1)
+	require.Equal(t, []float64{2}, capture.Histograms()[0].Values())
+	require.Empty(t, capture.Gauges())
+
+	// Handlers without histogram support record nothing
+	metrics.HistogramFromHandler(metrics.NopHandler, "histogram2").RecordValue(3)
+}
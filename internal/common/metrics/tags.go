@@ -15,6 +15,8 @@ func RootTags(namespace string) map[string]string {
 		WorkflowTypeNameTagName: NoneTagValue,
 		ActivityTypeNameTagName: NoneTagValue,
 		TaskQueueTagName:        NoneTagValue,
+		WorkerIdentityTagName:   NoneTagValue,
+		BuildIDTagName:          NoneTagValue,
 	}
 }
 
@@ -51,6 +53,24 @@ func LocalActivityTags(workflowType, activityType string) map[string]string {
 	}
 }
 
+// SignalTags returns a set of tags for signals.
+func SignalTags(signalName string) map[string]string {
+	return map[string]string{
+		SignalNameTagName: signalName,
+	}
+}
+
+// UpdateRejectionTags returns a set of tags for rejected updates.
+func UpdateRejectionTags(updateName, rejectionCode string) map[string]string {
+	if rejectionCode == "" {
+		rejectionCode = NoneTagValue
+	}
+	return map[string]string{
+		UpdateNameTagName:    updateName,
+		RejectionCodeTagName: rejectionCode,
+	}
+}
+
 // NexusTags returns a set of tags for Nexus Operations.
 func NexusTags(service, operation, taskQueueName string) map[string]string {
 	return map[string]string{
@@ -74,6 +94,22 @@ func TaskQueueTags(taskQueue string) map[string]string {
 	}
 }
 
+// WorkerIdentityTags returns a set of tags identifying a worker.
+func WorkerIdentityTags(namespace, taskQueue, identity string) map[string]string {
+	return map[string]string{
+		NamespaceTagName:      namespace,
+		TaskQueueTagName:      taskQueue,
+		WorkerIdentityTagName: identity,
+	}
+}
+
+// BuildIDTags returns a set of tags for the build ID of a worker.
+func BuildIDTags(buildID string) map[string]string {
+	return map[string]string{
+		BuildIDTagName: buildID,
+	}
+}
+
 // WorkerTags returns a set of tags for workers.
 func WorkerTags(workerType string) map[string]string {
 	return map[string]string{
@@ -144,3 +180,12 @@ func canonicalString(c codes.Code) string {
 		return "CODE(" + strconv.FormatInt(int64(c), 10) + ")"
 	}
 }
+
+// GetVersionTags returns a set of tags for the versions returned by GetVersion.
+func GetVersionTags(changeID, version string, replay bool) map[string]string {
+	return map[string]string{
+		ChangeIDTagName: changeID,
+		VersionTagName:  version,
+		ReplayTagName:   strconv.FormatBool(replay),
+	}
+}
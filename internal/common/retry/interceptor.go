@@ -88,6 +88,15 @@ func NewGrpcRetryConfig(initialInterval time.Duration) *GrpcRetryConfig {
 	}
 }
 
+// backoff returns the exponential backoff after the attempt, capped by the maximum interval.
+func (g *GrpcRetryConfig) backoff(attempt uint) time.Duration {
+	next := float64(g.initialInterval) * math.Pow(g.backoffCoefficient, float64(attempt))
+	if g.maximumInterval != UnlimitedInterval {
+		next = math.Min(next, float64(g.maximumInterval))
+	}
+	return time.Duration(next)
+}
+
 var (
 	// ConfigKey context key for GrpcRetryConfig
 	ConfigKey = contextKey{}
@@ -105,8 +114,9 @@ var (
 
 // NewRetryOptionsInterceptor creates a new gRPC interceptor that populates retry options for each call based on values
 // provided in the context. The atomic bool is checked each call to determine whether internals are included in retry.
-// If not present or false, internals are assumed to be included.
-func NewRetryOptionsInterceptor(excludeInternal *atomic.Bool) grpc.UnaryClientInterceptor {
+// If not present or false, internals are assumed to be included. If jitter is nil, delays are randomized by the jitter
+// fraction of the retry config.
+func NewRetryOptionsInterceptor(excludeInternal *atomic.Bool, jitter Jitter) grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 		if rc, ok := ctx.Value(ConfigKey).(*GrpcRetryConfig); ok {
 			if _, ok := ctx.Deadline(); !ok {
@@ -116,11 +126,21 @@ func NewRetryOptionsInterceptor(excludeInternal *atomic.Bool) grpc.UnaryClientIn
 			}
 			// Populate backoff function, which provides retrier with the delay for each attempt.
 			opts = append(opts, grpc_retry.WithBackoff(func(attempt uint) time.Duration {
-				next := float64(rc.initialInterval) * math.Pow(rc.backoffCoefficient, float64(attempt))
-				if rc.maximumInterval != UnlimitedInterval {
-					next = math.Min(next, float64(rc.maximumInterval))
+				next := rc.backoff(attempt)
+				if jitter == nil {
+					return backoffutils.JitterUp(next, rc.jitter)
+				}
+				previous := rc.initialInterval
+				if attempt > 0 {
+					previous = rc.backoff(attempt - 1)
 				}
-				return backoffutils.JitterUp(time.Duration(next), rc.jitter)
+				return jitter.Apply(JitterInput{
+					Attempt:         int(attempt),
+					InitialInterval: rc.initialInterval,
+					MaximumInterval: rc.maximumInterval,
+					PreviousBackoff: previous,
+					Backoff:         next,
+				})
 			}))
 			// Max attempts is a required parameter in grpc retry interceptor,
 			// if it's set to zero then no retries will be made.
@@ -0,0 +1,66 @@
+package retry
+
+import (
+	"math/rand"
+	"time"
+)
+
+type (
+	// JitterInput is the input of Jitter.Apply.
+	JitterInput struct {
+		// Attempt is the attempt that failed, starting from 1.
+		Attempt int
+		// InitialInterval is the initial interval of the retry policy.
+		InitialInterval time.Duration
+		// MaximumInterval is the maximum interval of the retry policy, zero if unlimited.
+		MaximumInterval time.Duration
+		// PreviousBackoff is the exponential backoff of the previous attempt, which is the initial interval for the
+		// first attempt.
+		PreviousBackoff time.Duration
+		// Backoff is the exponential backoff of the attempt, capped by the maximum interval.
+		Backoff time.Duration
+	}
+
+	// Jitter randomizes the delay before a retry, so that callers failing at the same time do not retry at the same
+	// time. Implementations must be safe for concurrent use.
+	Jitter interface {
+		// Apply returns the delay before retrying the failed attempt.
+		Apply(input JitterInput) time.Duration
+	}
+
+	jitterFunc func(input JitterInput) time.Duration
+)
+
+var (
+	// FullJitter picks a delay between zero and the backoff.
+	FullJitter Jitter = jitterFunc(func(input JitterInput) time.Duration {
+		return randomDuration(0, input.Backoff)
+	})
+
+	// EqualJitter picks a delay between half the backoff and the backoff.
+	EqualJitter Jitter = jitterFunc(func(input JitterInput) time.Duration {
+		return randomDuration(input.Backoff/2, input.Backoff)
+	})
+
+	// DecorrelatedJitter picks a delay between the initial interval and three times the backoff of the previous
+	// attempt, capped by the maximum interval.
+	DecorrelatedJitter Jitter = jitterFunc(func(input JitterInput) time.Duration {
+		delay := randomDuration(input.InitialInterval, 3*input.PreviousBackoff)
+		if input.MaximumInterval != UnlimitedInterval && delay > input.MaximumInterval {
+			delay = input.MaximumInterval
+		}
+		return delay
+	})
+)
+
+func (f jitterFunc) Apply(input JitterInput) time.Duration {
+	return f(input)
+}
+
+// randomDuration returns a random duration in [low, high), or low if the range is empty.
+func randomDuration(low, high time.Duration) time.Duration {
+	if high <= low {
+		return low
+	}
+	return low + time.Duration(rand.Int63n(int64(high-low)))
+}
@@ -0,0 +1,37 @@
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJitter(t *testing.T) {
+	input := JitterInput{
+		Attempt:         3,
+		InitialInterval: time.Second,
+		MaximumInterval: 5 * time.Second,
+		PreviousBackoff: time.Second,
+		Backoff:         4 * time.Second,
+	}
+	for i := 0; i < 100; i++ {
+		delay := FullJitter.Apply(input)
+		require.True(t, delay >= 0 && delay < 4*time.Second, delay)
+
+		delay = EqualJitter.Apply(input)
+		require.True(t, delay >= 2*time.Second && delay < 4*time.Second, delay)
+
+		delay = DecorrelatedJitter.Apply(input)
+		require.True(t, delay >= time.Second && delay < 3*time.Second, delay)
+	}
+
+	// Decorrelated jitter is capped by the maximum interval
+	input.PreviousBackoff = 4 * time.Second
+	for i := 0; i < 100; i++ {
+		require.LessOrEqual(t, DecorrelatedJitter.Apply(input), 5*time.Second)
+	}
+
+	// Empty ranges do not panic
+	require.Equal(t, time.Duration(0), FullJitter.Apply(JitterInput{}))
+}
@@ -106,8 +106,12 @@ type (
 		Cause error
 		// Details is a list of arbitrary values that can be used to provide additional context to the error.
 		Details []interface{}
-		// NextRetryInterval is a request from server to override retry interval calculated by the
-		// server according to the RetryPolicy set by the Workflow.
+		// NextRetryDelay asks the server to wait this long before the next attempt of a failed activity, instead of
+		// the interval it calculates from the RetryPolicy set by the Workflow. It lets an activity that knows when
+		// it can succeed, for instance from the Retry-After header of a rate limited call, drive its own backoff.
+		// The maximum attempts and expiration of the RetryPolicy still apply. The delay picked by the server can be
+		// read from activity.Info.CurrentAttemptScheduledTime of the next attempt and from
+		// client.PendingActivityInfo.NextAttemptScheduleTime.
 		// It is impossible to specify immediate retry as it is indistinguishable from the default value. As a
 		// workaround you could set NextRetryDelay to some small value.
 		//
@@ -140,6 +144,9 @@ type (
 		timeoutType          enumspb.TimeoutType
 		lastHeartbeatDetails converter.EncodedValues
 		cause                error
+		scheduledTime        time.Time
+		startedTime          time.Time
+		closedTime           time.Time
 	}
 
 	// CanceledError returned when operation was canceled.
@@ -155,6 +162,9 @@ type (
 	// Exposed as: [go.temporal.io/sdk/temporal.TerminatedError]
 	TerminatedError struct {
 		temporalError
+		reason   string
+		identity string
+		details  converter.EncodedValues
 	}
 
 	// PanicError contains information about panicked workflow/activity.
@@ -240,6 +250,9 @@ type (
 		activityID       string
 		retryState       enumspb.RetryState
 		cause            error
+		scheduledTime    time.Time
+		startedTime      time.Time
+		closedTime       time.Time
 	}
 
 	// ChildWorkflowExecutionError is returned from workflow when child workflow returned an error.
@@ -288,6 +301,30 @@ type (
 	// NamespaceNotFoundError is set as the cause when failure is due namespace not found.
 	NamespaceNotFoundError struct{}
 
+	// SignalCountLimitExceededError is returned when signaling an external workflow fails because the target
+	// workflow has already received the maximum number of signals allowed by the server.
+	SignalCountLimitExceededError struct{}
+
+	// ExternalWorkflowNotFoundError is returned by the futures of an ExternalWorkflowHandle when the target
+	// execution does not exist, if the handle was created with ExternalWorkflowHandleOptions.DescribeUnknownTarget.
+	// It unwraps to UnknownExternalWorkflowExecutionError.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/temporal.ExternalWorkflowNotFoundError]
+	ExternalWorkflowNotFoundError struct{}
+
+	// ExternalWorkflowCompletedError is returned by the futures of an ExternalWorkflowHandle when the target
+	// execution has already completed, if the handle was created with
+	// ExternalWorkflowHandleOptions.DescribeUnknownTarget. It unwraps to UnknownExternalWorkflowExecutionError.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/temporal.ExternalWorkflowCompletedError]
+	ExternalWorkflowCompletedError struct {
+		status enumspb.WorkflowExecutionStatus
+	}
+
 	// WorkflowExecutionError is returned from workflow.
 	// Unwrap this error to get actual cause.
 	//
@@ -372,6 +409,16 @@ const (
 	ApplicationErrorCategoryBenign
 )
 
+// LocalActivityStillRunningErrorType is the type of the retryable application errors set as the cause of the timeout
+// errors of local activities that had not returned when their deadline passed, when the watchdog is enabled with
+// WorkerOptions.LocalActivityWatchdogGracePeriod. It is set as soon as the deadline passes: whether the attempt is
+// still running after the grace period is only reported by the watchdog's metric and log.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/temporal.LocalActivityStillRunningErrorType]
+const LocalActivityStillRunningErrorType = "LocalActivityStillRunning"
+
 // NewApplicationError create new instance of *ApplicationError with message, type, and optional details.
 func NewApplicationError(msg string, errType string, nonRetryable bool, cause error, details ...interface{}) error {
 	return NewApplicationErrorWithOptions(
@@ -641,6 +688,53 @@ func (e *ApplicationError) Details(d ...interface{}) error {
 	return e.details.Get(d...)
 }
 
+// EncodedDetails returns the detail data of this custom error, to be decoded with Get. It is never nil.
+//
+// NOTE: Experimental
+func (e *ApplicationError) EncodedDetails() converter.EncodedValues {
+	if e.details == nil {
+		return ErrorDetailsValues(nil)
+	}
+	return e.details
+}
+
+// NumDetails returns the number of detail values of this custom error.
+//
+// NOTE: Experimental
+func (e *ApplicationError) NumDetails() int {
+	switch details := e.details.(type) {
+	case ErrorDetailsValues:
+		return len(details)
+	case *EncodedValues:
+		return len(details.values.GetPayloads())
+	}
+	return 0
+}
+
+// DetailsInto decodes the detail value at the index, starting from 0, of the error into T. If the error has no
+// detail value at the index, it returns ErrNoData.
+//
+// NOTE: Experimental
+func DetailsInto[T any](err *ApplicationError, index int) (T, error) {
+	var result T
+	if index < 0 || index >= err.NumDetails() {
+		return result, ErrNoData
+	}
+	switch details := err.details.(type) {
+	case ErrorDetailsValues:
+		value, ok := details[index].(T)
+		if !ok {
+			return result, fmt.Errorf("detail %d is of type %T, not %T", index, details[index], result)
+		}
+		return value, nil
+	case *EncodedValues:
+		if e := details.dataConverter.FromPayload(details.values.GetPayloads()[index], &result); e != nil {
+			return result, e
+		}
+	}
+	return result, nil
+}
+
 // NonRetryable indicated if error is not retryable.
 func (e *ApplicationError) NonRetryable() bool {
 	return e.nonRetryable
@@ -699,6 +793,30 @@ func (e *TimeoutError) LastHeartbeatDetails(d ...interface{}) error {
 	return e.lastHeartbeatDetails.Get(d...)
 }
 
+// ScheduledTime returns the time the operation that timed out was scheduled, taken from the workflow history. It is
+// zero if the time is unknown.
+//
+// NOTE: Experimental
+func (e *TimeoutError) ScheduledTime() time.Time {
+	return e.scheduledTime
+}
+
+// StartedTime returns the time the last attempt of the operation that timed out started, taken from the workflow
+// history. It is zero if the time is unknown or no attempt started.
+//
+// NOTE: Experimental
+func (e *TimeoutError) StartedTime() time.Time {
+	return e.startedTime
+}
+
+// ClosedTime returns the time the operation timed out, taken from the workflow history. It is zero if the time is
+// unknown.
+//
+// NOTE: Experimental
+func (e *TimeoutError) ClosedTime() time.Time {
+	return e.closedTime
+}
+
 // Error from error interface
 func (e *CanceledError) Error() string {
 	return e.message()
@@ -776,6 +894,40 @@ func (e *TerminatedError) message() string {
 	return "terminated"
 }
 
+// Reason returns the reason given when terminating the workflow. It is only set on the error returned by
+// WorkflowRun.Get.
+//
+// NOTE: Experimental
+func (e *TerminatedError) Reason() string {
+	return e.reason
+}
+
+// Identity returns the identity of the client that terminated the workflow. It is only set on the error returned by
+// WorkflowRun.Get.
+//
+// NOTE: Experimental
+func (e *TerminatedError) Identity() string {
+	return e.identity
+}
+
+// HasDetails return if this error has strong typed detail data.
+//
+// NOTE: Experimental
+func (e *TerminatedError) HasDetails() bool {
+	return e.details != nil && e.details.HasValues()
+}
+
+// Details extracts strong typed detail data given when terminating the workflow. If there is no details, it will
+// return ErrNoData. The details are only set on the error returned by WorkflowRun.Get.
+//
+// NOTE: Experimental
+func (e *TerminatedError) Details(d ...interface{}) error {
+	if !e.HasDetails() {
+		return ErrNoData
+	}
+	return e.details.Get(d...)
+}
+
 // newUnknownExternalWorkflowExecutionError creates UnknownExternalWorkflowExecutionError instance
 func newUnknownExternalWorkflowExecutionError() *UnknownExternalWorkflowExecutionError {
 	return &UnknownExternalWorkflowExecutionError{}
@@ -854,6 +1006,42 @@ func (e *ActivityError) RetryState() enumspb.RetryState {
 	return e.retryState
 }
 
+// ScheduledTime returns the time the activity was scheduled, taken from the workflow history. It is zero if the time
+// is unknown, for instance for local activities.
+//
+// NOTE: Experimental
+func (e *ActivityError) ScheduledTime() time.Time {
+	return e.scheduledTime
+}
+
+// StartedTime returns the time the last attempt of the activity started, taken from the workflow history. It is zero
+// if the time is unknown or no attempt started.
+//
+// NOTE: Experimental
+func (e *ActivityError) StartedTime() time.Time {
+	return e.startedTime
+}
+
+// ClosedTime returns the time the activity failed, timed out, or was canceled, taken from the workflow history. It is
+// zero if the time is unknown.
+//
+// NOTE: Experimental
+func (e *ActivityError) ClosedTime() time.Time {
+	return e.closedTime
+}
+
+// setTimes sets the times of the activity, and of the timeout error causing the failure if any.
+func (e *ActivityError) setTimes(scheduledTime, startedTime, closedTime time.Time) {
+	e.scheduledTime = scheduledTime
+	e.startedTime = startedTime
+	e.closedTime = closedTime
+	if timeoutErr, ok := e.cause.(*TimeoutError); ok {
+		timeoutErr.scheduledTime = scheduledTime
+		timeoutErr.startedTime = startedTime
+		timeoutErr.closedTime = closedTime
+	}
+}
+
 // Error from error interface
 func (e *ChildWorkflowExecutionError) Error() string {
 	msg := fmt.Sprintf("%s (type: %s, workflowID: %s, runID: %s, initiatedEventID: %d, startedEventID: %d)",
@@ -938,6 +1126,39 @@ func (*NamespaceNotFoundError) Error() string {
 	return "namespace not found"
 }
 
+// Error from error interface
+func (*SignalCountLimitExceededError) Error() string {
+	return "signal count limit exceeded"
+}
+
+// Error from error interface
+func (*ExternalWorkflowNotFoundError) Error() string {
+	return "external workflow execution not found"
+}
+
+// Unwrap returns an UnknownExternalWorkflowExecutionError, the error of SignalExternalWorkflow and
+// RequestCancelExternalWorkflow in this case.
+func (*ExternalWorkflowNotFoundError) Unwrap() error {
+	return newUnknownExternalWorkflowExecutionError()
+}
+
+// Error from error interface
+func (e *ExternalWorkflowCompletedError) Error() string {
+	return fmt.Sprintf("external workflow execution already completed with status %v", e.status)
+}
+
+// Status returns the status the target execution completed with, like WORKFLOW_EXECUTION_STATUS_COMPLETED or
+// WORKFLOW_EXECUTION_STATUS_FAILED.
+func (e *ExternalWorkflowCompletedError) Status() enumspb.WorkflowExecutionStatus {
+	return e.status
+}
+
+// Unwrap returns an UnknownExternalWorkflowExecutionError, the error of SignalExternalWorkflow and
+// RequestCancelExternalWorkflow in this case.
+func (*ExternalWorkflowCompletedError) Unwrap() error {
+	return newUnknownExternalWorkflowExecutionError()
+}
+
 // Error from error interface
 func (*ChildWorkflowExecutionAlreadyStartedError) Error() string {
 	return "child workflow execution already started"
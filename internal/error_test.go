@@ -14,6 +14,9 @@ import (
 	enumspb "go.temporal.io/api/enums/v1"
 	failurepb "go.temporal.io/api/failure/v1"
 	historypb "go.temporal.io/api/history/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"go.temporal.io/sdk/converter"
 	ilog "go.temporal.io/sdk/internal/log"
 )
@@ -124,6 +127,63 @@ func Test_TimeoutError(t *testing.T) {
 	require.Equal(t, testErrorDetails1, data)
 }
 
+func Test_ActivityError_Times(t *testing.T) {
+	context := &workflowEnvironmentImpl{
+		commandsHelper:   newCommandsHelper(),
+		dataConverter:    converter.GetDefaultDataConverter(),
+		failureConverter: GetDefaultFailureConverter(),
+		workflowInfo:     &WorkflowInfo{},
+	}
+	var actualErr error
+	activityID := "activityID"
+	context.commandsHelper.scheduledEventIDToActivityID[5] = activityID
+	di := context.commandsHelper.newActivityCommandStateMachine(
+		5,
+		&commandpb.ScheduleActivityTaskCommandAttributes{ActivityId: activityID}, nil)
+	di.state = commandStateCommandSent
+	di.setData(&scheduledActivity{
+		callback: func(r *commonpb.Payloads, e error) {
+			actualErr = e
+		},
+	})
+	context.commandsHelper.addCommand(di)
+
+	scheduledTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	startedTime := scheduledTime.Add(time.Second)
+	closedTime := startedTime.Add(time.Minute)
+	scheduledEvent := createTestEventActivityTaskScheduled(5, &historypb.ActivityTaskScheduledEventAttributes{ActivityId: activityID})
+	scheduledEvent.EventTime = timestamppb.New(scheduledTime)
+	startedEvent := createTestEventActivityTaskStarted(6, &historypb.ActivityTaskStartedEventAttributes{ScheduledEventId: 5})
+	startedEvent.EventTime = timestamppb.New(startedTime)
+	timedOutEvent := createTestEventActivityTaskTimedOut(7, &historypb.ActivityTaskTimedOutEventAttributes{
+		Failure: &failurepb.Failure{
+			FailureInfo: &failurepb.Failure_TimeoutFailureInfo{TimeoutFailureInfo: &failurepb.TimeoutFailureInfo{
+				TimeoutType: enumspb.TIMEOUT_TYPE_START_TO_CLOSE,
+			}},
+		},
+		RetryState:       enumspb.RETRY_STATE_TIMEOUT,
+		ScheduledEventId: 5,
+		StartedEventId:   6,
+	})
+	timedOutEvent.EventTime = timestamppb.New(closedTime)
+
+	weh := &workflowExecutionEventHandlerImpl{context, nil}
+	for _, event := range []*historypb.HistoryEvent{scheduledEvent, startedEvent, timedOutEvent} {
+		require.NoError(t, weh.ProcessEvent(event, false, false))
+	}
+
+	var activityErr *ActivityError
+	require.True(t, errors.As(actualErr, &activityErr))
+	require.Equal(t, scheduledTime, activityErr.ScheduledTime())
+	require.Equal(t, startedTime, activityErr.StartedTime())
+	require.Equal(t, closedTime, activityErr.ClosedTime())
+	var timeoutErr *TimeoutError
+	require.True(t, errors.As(actualErr, &timeoutErr))
+	require.Equal(t, scheduledTime, timeoutErr.ScheduledTime())
+	require.Equal(t, startedTime, timeoutErr.StartedTime())
+	require.Equal(t, closedTime, timeoutErr.ClosedTime())
+}
+
 func Test_TimeoutError_WithDetails(t *testing.T) {
 	testTimeoutErrorDetails(t, enumspb.TIMEOUT_TYPE_HEARTBEAT)
 	testTimeoutErrorDetails(t, enumspb.TIMEOUT_TYPE_SCHEDULE_TO_CLOSE)
@@ -253,6 +313,37 @@ func Test_ApplicationError(t *testing.T) {
 	require.Equal(t, testErrorDetails3, b3)
 }
 
+func Test_ApplicationError_DetailsInto(t *testing.T) {
+	localErr := NewApplicationError(applicationErrReasonA, "", false, nil, testErrorDetails1, testErrorDetails2, testErrorDetails3)
+	fc := GetDefaultFailureConverter()
+	encodedErr := fc.FailureToError(fc.ErrorToFailure(localErr))
+	for name, err := range map[string]error{"local": localErr, "encoded": encodedErr} {
+		t.Run(name, func(t *testing.T) {
+			var applicationErr *ApplicationError
+			require.True(t, errors.As(err, &applicationErr))
+			require.Equal(t, 3, applicationErr.NumDetails())
+			require.True(t, applicationErr.EncodedDetails().HasValues())
+
+			d2, err := DetailsInto[int](applicationErr, 1)
+			require.NoError(t, err)
+			require.Equal(t, testErrorDetails2, d2)
+			d3, err := DetailsInto[testStruct](applicationErr, 2)
+			require.NoError(t, err)
+			require.Equal(t, testErrorDetails3, d3)
+			_, err = DetailsInto[string](applicationErr, 3)
+			require.Equal(t, ErrNoData, err)
+			_, err = DetailsInto[string](applicationErr, 1)
+			require.Error(t, err)
+		})
+	}
+
+	noDetailsErr := NewApplicationError(applicationErrReasonA, "", false, nil).(*ApplicationError)
+	require.Equal(t, 0, noDetailsErr.NumDetails())
+	require.False(t, noDetailsErr.EncodedDetails().HasValues())
+	_, err := DetailsInto[string](noDetailsErr, 0)
+	require.Equal(t, ErrNoData, err)
+}
+
 func Test_ApplicationError_Pointer(t *testing.T) {
 	a1 := testStruct2{}
 	err1 := NewApplicationError(applicationErrReasonA, "", false, nil, testErrorDetails4)
@@ -937,6 +1028,8 @@ func Test_convertErrorToFailure_TerminateError(t *testing.T) {
 	err2 := fc.FailureToError(f)
 	var terminateErr *TerminatedError
 	require.True(errors.As(err2, &terminateErr))
+	require.False(terminateErr.HasDetails())
+	require.Equal(ErrNoData, terminateErr.Details())
 }
 
 func Test_convertErrorToFailure_ServerError(t *testing.T) {
@@ -1056,6 +1149,63 @@ func Test_convertErrorToFailure_UnknownError(t *testing.T) {
 	require.Equal("coolError", coolErr.Type())
 }
 
+func Test_convertErrorToFailure_JoinedError(t *testing.T) {
+	require := require.New(t)
+	for _, encodeCommonAttributes := range []bool{false, true} {
+		fc := NewDefaultFailureConverter(DefaultFailureConverterOptions{EncodeCommonAttributes: encodeCommonAttributes})
+
+		err := errors.Join(
+			NewApplicationError("first", "FirstError", false, nil, "detail"),
+			&coolError{},
+		)
+		f := fc.ErrorToFailure(err)
+		require.Equal(joinedErrorType, f.GetApplicationFailureInfo().GetType())
+		require.False(f.GetApplicationFailureInfo().GetNonRetryable())
+		require.Len(f.GetApplicationFailureInfo().GetDetails().GetPayloads(), 2)
+		require.Nil(f.GetCause())
+
+		original := proto.Clone(f)
+		err2 := fc.FailureToError(f)
+		require.Equal(err.Error(), err2.Error())
+		joined, ok := err2.(interface{ Unwrap() []error })
+		require.True(ok)
+		require.Len(joined.Unwrap(), 2)
+
+		var firstErr *ApplicationError
+		require.True(errors.As(err2, &firstErr))
+		require.Equal("FirstError", firstErr.Type())
+		var detail string
+		require.NoError(firstErr.Details(&detail))
+		require.Equal("detail", detail)
+		var coolErr *ApplicationError
+		require.True(errors.As(joined.Unwrap()[1], &coolErr))
+		require.Equal("coolError", coolErr.Type())
+
+		// Converting back gives the same failure.
+		require.True(proto.Equal(original, fc.ErrorToFailure(err2)))
+	}
+
+	fc := GetDefaultFailureConverter()
+	err := errors.Join(
+		errors.New("retry me"),
+		NewApplicationError("give up", "", true, nil),
+	)
+	f := fc.ErrorToFailure(err)
+	require.True(f.GetApplicationFailureInfo().GetNonRetryable())
+	err2 := fc.FailureToError(f)
+	require.Equal("retry me\ngive up", err2.Error())
+	var applicationErr *ApplicationError
+	require.True(errors.As(err2, &applicationErr))
+	require.Equal("retry me", applicationErr.Message())
+
+	// Other errors wrapping several errors keep their own type
+	err = fmt.Errorf("both failed: %w, %w", errors.New("first"), errors.New("second"))
+	f = fc.ErrorToFailure(err)
+	require.Equal("wrapErrors", f.GetApplicationFailureInfo().GetType())
+	require.Empty(f.GetApplicationFailureInfo().GetDetails().GetPayloads())
+	require.Equal("both failed: first, second (type: wrapErrors, retryable: true)", fc.FailureToError(f).Error())
+}
+
 func Test_convertErrorToFailure_SavedFailure(t *testing.T) {
 	require := require.New(t)
 	fc := GetDefaultFailureConverter()
@@ -0,0 +1,356 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	failurepb "go.temporal.io/api/failure/v1"
+	historypb "go.temporal.io/api/history/v1"
+
+	"go.temporal.io/sdk/converter"
+)
+
+// ExecutionTraceNodeKind is the kind of operation of an ExecutionTraceNode.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.ExecutionTraceNodeKind]
+type ExecutionTraceNodeKind int
+
+const (
+	// ExecutionTraceNodeKindWorkflow is a workflow execution, the root of a trace or a child workflow.
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ExecutionTraceNodeKindWorkflow]
+	ExecutionTraceNodeKindWorkflow ExecutionTraceNodeKind = iota
+	// ExecutionTraceNodeKindActivity is an activity.
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ExecutionTraceNodeKindActivity]
+	ExecutionTraceNodeKindActivity
+	// ExecutionTraceNodeKindNexusOperation is a Nexus operation.
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ExecutionTraceNodeKindNexusOperation]
+	ExecutionTraceNodeKindNexusOperation
+)
+
+// ExecutionTraceNodeStatus is the status of the operation of an ExecutionTraceNode.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.ExecutionTraceNodeStatus]
+type ExecutionTraceNodeStatus int
+
+const (
+	// ExecutionTraceNodeStatusRunning is an operation that has not completed yet.
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ExecutionTraceNodeStatusRunning]
+	ExecutionTraceNodeStatusRunning ExecutionTraceNodeStatus = iota
+	// ExecutionTraceNodeStatusCompleted is an operation that completed successfully.
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ExecutionTraceNodeStatusCompleted]
+	ExecutionTraceNodeStatusCompleted
+	// ExecutionTraceNodeStatusFailed is an operation that failed, or that could not be started.
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ExecutionTraceNodeStatusFailed]
+	ExecutionTraceNodeStatusFailed
+	// ExecutionTraceNodeStatusCanceled is an operation that was canceled.
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ExecutionTraceNodeStatusCanceled]
+	ExecutionTraceNodeStatusCanceled
+	// ExecutionTraceNodeStatusTimedOut is an operation that timed out.
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ExecutionTraceNodeStatusTimedOut]
+	ExecutionTraceNodeStatusTimedOut
+	// ExecutionTraceNodeStatusTerminated is a workflow that was terminated.
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ExecutionTraceNodeStatusTerminated]
+	ExecutionTraceNodeStatusTerminated
+	// ExecutionTraceNodeStatusContinuedAsNew is a workflow run that continued as new.
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ExecutionTraceNodeStatusContinuedAsNew]
+	ExecutionTraceNodeStatusContinuedAsNew
+)
+
+type (
+	// ExecutionTraceOptions are options for GetWorkflowExecutionTrace.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ExecutionTraceOptions]
+	ExecutionTraceOptions struct {
+		// Optional: Maximum depth of the child workflows and the workflows backing Nexus operations whose histories
+		// are walked. Workflows deeper than this have no children in the trace. Zero walks all of them, negative
+		// values none of them.
+		MaxDepth int
+	}
+
+	// ExecutionTraceNode is an operation in the call tree of a workflow execution: the workflow itself, one of its
+	// activities, child workflows or Nexus operations.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ExecutionTraceNode]
+	ExecutionTraceNode struct {
+		// Kind is the kind of operation.
+		Kind ExecutionTraceNodeKind
+		// Name is the workflow or activity type, or the service and operation of a Nexus operation separated by a
+		// slash.
+		Name string
+		// ID is the workflow or activity ID, or the endpoint of a Nexus operation.
+		ID string
+		// RunID is the run ID of a workflow. Empty for other nodes, and for child workflows not started yet.
+		RunID string
+		// Status is the status of the operation.
+		Status ExecutionTraceNodeStatus
+		// StartTime is the time the operation was scheduled or started.
+		StartTime time.Time
+		// CloseTime is the time the operation completed. Zero if it is running.
+		CloseTime time.Time
+		// Attempts is the number of attempts of the operation so far, 1 if it was not retried. Zero if it is not known,
+		// for example for activities that have not started yet.
+		Attempts int32
+		// Err is the failure of the operation, if any. Nil for operations that timed out, were canceled or terminated
+		// without a failure, see Status.
+		Err error
+		// Children are the operations started by a workflow, in the order they were, or the workflow backing a Nexus
+		// operation.
+		Children []*ExecutionTraceNode
+	}
+)
+
+// Duration returns the duration of the operation, zero if it is running.
+func (n *ExecutionTraceNode) Duration() time.Duration {
+	if n.CloseTime.IsZero() {
+		return 0
+	}
+	return n.CloseTime.Sub(n.StartTime)
+}
+
+// GetWorkflowExecutionTrace walks the history of a workflow execution, and the histories of its child workflows and of
+// the workflows backing its Nexus operations in the namespace of the client, and returns its call tree. Each node
+// carries the timing, status, failure and number of attempts of an operation, so that executions can be visualized
+// without parsing their histories. The run ID is optional. Default is the current run.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.GetWorkflowExecutionTrace]
+func GetWorkflowExecutionTrace(
+	ctx context.Context,
+	c Client,
+	workflowID string,
+	runID string,
+	options ExecutionTraceOptions,
+) (*ExecutionTraceNode, error) {
+	if runID == "" {
+		description, err := c.DescribeWorkflowExecution(ctx, workflowID, "")
+		if err != nil {
+			return nil, err
+		}
+		runID = description.GetWorkflowExecutionInfo().GetExecution().GetRunId()
+	}
+	t := &executionTracer{client: c, options: options, failureConverter: GetDefaultFailureConverter()}
+	if wc, ok := c.(*WorkflowClient); ok {
+		t.namespace = wc.namespace
+		t.failureConverter = wc.failureConverter
+	}
+	return t.traceWorkflow(ctx, workflowID, runID, 0)
+}
+
+type executionTracer struct {
+	client  Client
+	options ExecutionTraceOptions
+	// namespace of the client, empty if unknown
+	namespace        string
+	failureConverter converter.FailureConverter
+}
+
+// traceWorkflow builds the trace of a workflow run from its history, then walks the histories of the workflows it
+// started.
+func (t *executionTracer) traceWorkflow(ctx context.Context, workflowID, runID string, depth int) (*ExecutionTraceNode, error) {
+	b := executionTraceBuilder{
+		root:             &ExecutionTraceNode{Kind: ExecutionTraceNodeKindWorkflow, ID: workflowID, RunID: runID},
+		nodes:            map[int64]*ExecutionTraceNode{},
+		nexusLinks:       map[*ExecutionTraceNode]*commonpb.Link_WorkflowEvent{},
+		failureConverter: t.failureConverter,
+	}
+	iter := t.client.GetWorkflowHistory(ctx, workflowID, runID, false, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get history of workflow %s: %w", workflowID, err)
+		}
+		b.addEvent(event)
+	}
+	if t.options.MaxDepth < 0 || (t.options.MaxDepth > 0 && depth >= t.options.MaxDepth) {
+		return b.root, nil
+	}
+
+	for _, child := range b.root.Children {
+		switch {
+		case child.Kind == ExecutionTraceNodeKindWorkflow && child.RunID != "":
+			trace, err := t.traceWorkflow(ctx, child.ID, child.RunID, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			child.Attempts = trace.Attempts
+			child.Children = trace.Children
+		case child.Kind == ExecutionTraceNodeKindNexusOperation:
+			link := b.nexusLinks[child]
+			// Workflows in other namespaces cannot be read with the client
+			if link == nil || (t.namespace != "" && link.GetNamespace() != t.namespace) {
+				continue
+			}
+			trace, err := t.traceWorkflow(ctx, link.GetWorkflowId(), link.GetRunId(), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			child.Children = []*ExecutionTraceNode{trace}
+		}
+	}
+	return b.root, nil
+}
+
+// executionTraceBuilder builds the trace of a workflow run event by event.
+type executionTraceBuilder struct {
+	root *ExecutionTraceNode
+	// nodes are the children of the root, by the ID of the event that scheduled or initiated them
+	nodes map[int64]*ExecutionTraceNode
+	// nexusLinks are the workflows backing Nexus operations
+	nexusLinks       map[*ExecutionTraceNode]*commonpb.Link_WorkflowEvent
+	failureConverter converter.FailureConverter
+}
+
+func (b *executionTraceBuilder) addEvent(event *historypb.HistoryEvent) {
+	eventTime := event.GetEventTime().AsTime()
+	switch event.GetEventType() {
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED:
+		attributes := event.GetWorkflowExecutionStartedEventAttributes()
+		b.root.Name = attributes.GetWorkflowType().GetName()
+		b.root.StartTime = eventTime
+		b.root.Attempts = attributes.GetAttempt()
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED:
+		b.close(b.root, ExecutionTraceNodeStatusCompleted, eventTime, nil)
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:
+		b.close(b.root, ExecutionTraceNodeStatusFailed, eventTime, event.GetWorkflowExecutionFailedEventAttributes().GetFailure())
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:
+		b.close(b.root, ExecutionTraceNodeStatusTimedOut, eventTime, nil)
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED:
+		b.close(b.root, ExecutionTraceNodeStatusCanceled, eventTime, nil)
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED:
+		b.close(b.root, ExecutionTraceNodeStatusTerminated, eventTime, nil)
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW:
+		b.close(b.root, ExecutionTraceNodeStatusContinuedAsNew, eventTime, nil)
+
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+		attributes := event.GetActivityTaskScheduledEventAttributes()
+		b.addNode(event.GetEventId(), &ExecutionTraceNode{
+			Kind:      ExecutionTraceNodeKindActivity,
+			Name:      attributes.GetActivityType().GetName(),
+			ID:        attributes.GetActivityId(),
+			StartTime: eventTime,
+		})
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_STARTED:
+		attributes := event.GetActivityTaskStartedEventAttributes()
+		if node := b.nodes[attributes.GetScheduledEventId()]; node != nil {
+			node.Attempts = attributes.GetAttempt()
+		}
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+		b.closeNode(event.GetActivityTaskCompletedEventAttributes().GetScheduledEventId(),
+			ExecutionTraceNodeStatusCompleted, eventTime, nil)
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED:
+		attributes := event.GetActivityTaskFailedEventAttributes()
+		b.closeNode(attributes.GetScheduledEventId(), ExecutionTraceNodeStatusFailed, eventTime, attributes.GetFailure())
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+		attributes := event.GetActivityTaskTimedOutEventAttributes()
+		b.closeNode(attributes.GetScheduledEventId(), ExecutionTraceNodeStatusTimedOut, eventTime, attributes.GetFailure())
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
+		b.closeNode(event.GetActivityTaskCanceledEventAttributes().GetScheduledEventId(),
+			ExecutionTraceNodeStatusCanceled, eventTime, nil)
+
+	case enumspb.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_INITIATED:
+		attributes := event.GetStartChildWorkflowExecutionInitiatedEventAttributes()
+		b.addNode(event.GetEventId(), &ExecutionTraceNode{
+			Kind:      ExecutionTraceNodeKindWorkflow,
+			Name:      attributes.GetWorkflowType().GetName(),
+			ID:        attributes.GetWorkflowId(),
+			StartTime: eventTime,
+		})
+	case enumspb.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_FAILED:
+		attributes := event.GetStartChildWorkflowExecutionFailedEventAttributes()
+		if node := b.nodes[attributes.GetInitiatedEventId()]; node != nil {
+			node.Status = ExecutionTraceNodeStatusFailed
+			node.CloseTime = eventTime
+			node.Err = fmt.Errorf("failed to start child workflow: %v", attributes.GetCause())
+		}
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED:
+		attributes := event.GetChildWorkflowExecutionStartedEventAttributes()
+		if node := b.nodes[attributes.GetInitiatedEventId()]; node != nil {
+			node.RunID = attributes.GetWorkflowExecution().GetRunId()
+		}
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_COMPLETED:
+		b.closeNode(event.GetChildWorkflowExecutionCompletedEventAttributes().GetInitiatedEventId(),
+			ExecutionTraceNodeStatusCompleted, eventTime, nil)
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_FAILED:
+		attributes := event.GetChildWorkflowExecutionFailedEventAttributes()
+		b.closeNode(attributes.GetInitiatedEventId(), ExecutionTraceNodeStatusFailed, eventTime, attributes.GetFailure())
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TIMED_OUT:
+		b.closeNode(event.GetChildWorkflowExecutionTimedOutEventAttributes().GetInitiatedEventId(),
+			ExecutionTraceNodeStatusTimedOut, eventTime, nil)
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_CANCELED:
+		b.closeNode(event.GetChildWorkflowExecutionCanceledEventAttributes().GetInitiatedEventId(),
+			ExecutionTraceNodeStatusCanceled, eventTime, nil)
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TERMINATED:
+		b.closeNode(event.GetChildWorkflowExecutionTerminatedEventAttributes().GetInitiatedEventId(),
+			ExecutionTraceNodeStatusTerminated, eventTime, nil)
+
+	case enumspb.EVENT_TYPE_NEXUS_OPERATION_SCHEDULED:
+		attributes := event.GetNexusOperationScheduledEventAttributes()
+		b.addNode(event.GetEventId(), &ExecutionTraceNode{
+			Kind:      ExecutionTraceNodeKindNexusOperation,
+			Name:      attributes.GetService() + "/" + attributes.GetOperation(),
+			ID:        attributes.GetEndpoint(),
+			StartTime: eventTime,
+		})
+	case enumspb.EVENT_TYPE_NEXUS_OPERATION_STARTED:
+		node := b.nodes[event.GetNexusOperationStartedEventAttributes().GetScheduledEventId()]
+		for _, link := range event.GetLinks() {
+			if node != nil && link.GetWorkflowEvent() != nil {
+				b.nexusLinks[node] = link.GetWorkflowEvent()
+				break
+			}
+		}
+	case enumspb.EVENT_TYPE_NEXUS_OPERATION_COMPLETED:
+		b.closeNode(event.GetNexusOperationCompletedEventAttributes().GetScheduledEventId(),
+			ExecutionTraceNodeStatusCompleted, eventTime, nil)
+	case enumspb.EVENT_TYPE_NEXUS_OPERATION_FAILED:
+		attributes := event.GetNexusOperationFailedEventAttributes()
+		b.closeNode(attributes.GetScheduledEventId(), ExecutionTraceNodeStatusFailed, eventTime, attributes.GetFailure())
+	case enumspb.EVENT_TYPE_NEXUS_OPERATION_TIMED_OUT:
+		attributes := event.GetNexusOperationTimedOutEventAttributes()
+		b.closeNode(attributes.GetScheduledEventId(), ExecutionTraceNodeStatusTimedOut, eventTime, attributes.GetFailure())
+	case enumspb.EVENT_TYPE_NEXUS_OPERATION_CANCELED:
+		attributes := event.GetNexusOperationCanceledEventAttributes()
+		b.closeNode(attributes.GetScheduledEventId(), ExecutionTraceNodeStatusCanceled, eventTime, attributes.GetFailure())
+	}
+}
+
+func (b *executionTraceBuilder) addNode(eventID int64, node *ExecutionTraceNode) {
+	b.nodes[eventID] = node
+	b.root.Children = append(b.root.Children, node)
+}
+
+func (b *executionTraceBuilder) closeNode(eventID int64, status ExecutionTraceNodeStatus, closeTime time.Time, failure *failurepb.Failure) {
+	if node := b.nodes[eventID]; node != nil {
+		b.close(node, status, closeTime, failure)
+	}
+}
+
+func (b *executionTraceBuilder) close(node *ExecutionTraceNode, status ExecutionTraceNodeStatus, closeTime time.Time, failure *failurepb.Failure) {
+	node.Status = status
+	node.CloseTime = closeTime
+	if failure != nil {
+		node.Err = b.failureConverter.FailureToError(failure)
+	}
+}
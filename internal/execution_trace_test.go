@@ -0,0 +1,180 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	failurepb "go.temporal.io/api/failure/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// historyClient is a Client serving the histories of workflow runs by run ID.
+type historyClient struct {
+	Client
+	histories map[string][]*historypb.HistoryEvent
+}
+
+func (c *historyClient) GetWorkflowHistory(
+	_ context.Context,
+	_ string,
+	runID string,
+	_ bool,
+	_ enumspb.HistoryEventFilterType,
+) HistoryEventIterator {
+	return &sliceHistoryEventIterator{events: c.histories[runID]}
+}
+
+type sliceHistoryEventIterator struct {
+	events []*historypb.HistoryEvent
+}
+
+func (s *sliceHistoryEventIterator) HasNext() bool { return len(s.events) > 0 }
+
+func (s *sliceHistoryEventIterator) Next() (*historypb.HistoryEvent, error) {
+	event := s.events[0]
+	s.events = s.events[1:]
+	return event, nil
+}
+
+func TestGetWorkflowExecutionTrace(t *testing.T) {
+	start := time.Unix(1000, 0)
+	at := func(seconds int, event *historypb.HistoryEvent) *historypb.HistoryEvent {
+		event.EventTime = timestamppb.New(start.Add(time.Duration(seconds) * time.Second))
+		return event
+	}
+	client := &historyClient{histories: map[string][]*historypb.HistoryEvent{
+		"parent-run": {
+			at(0, createTestEventWorkflowExecutionStarted(1, &historypb.WorkflowExecutionStartedEventAttributes{
+				WorkflowType: &commonpb.WorkflowType{Name: "ParentWorkflow"},
+				Attempt:      1,
+			})),
+			at(1, createTestEventActivityTaskScheduled(5, &historypb.ActivityTaskScheduledEventAttributes{
+				ActivityId:   "5",
+				ActivityType: &commonpb.ActivityType{Name: "SomeActivity"},
+			})),
+			at(2, createTestEventActivityTaskStarted(6, &historypb.ActivityTaskStartedEventAttributes{
+				ScheduledEventId: 5,
+				Attempt:          3,
+			})),
+			at(4, &historypb.HistoryEvent{
+				EventId:   7,
+				EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED,
+				Attributes: &historypb.HistoryEvent_ActivityTaskFailedEventAttributes{
+					ActivityTaskFailedEventAttributes: &historypb.ActivityTaskFailedEventAttributes{
+						ScheduledEventId: 5,
+						Failure:          &failurepb.Failure{Message: "activity failed"},
+					},
+				},
+			}),
+			at(5, createTestEventStartChildWorkflowExecutionInitiated(8, &historypb.StartChildWorkflowExecutionInitiatedEventAttributes{
+				WorkflowId:   "child",
+				WorkflowType: &commonpb.WorkflowType{Name: "ChildWorkflow"},
+			})),
+			at(6, createTestEventChildWorkflowExecutionStarted(9, &historypb.ChildWorkflowExecutionStartedEventAttributes{
+				InitiatedEventId:  8,
+				WorkflowExecution: &commonpb.WorkflowExecution{WorkflowId: "child", RunId: "child-run"},
+			})),
+			at(10, &historypb.HistoryEvent{
+				EventId:   10,
+				EventType: enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_COMPLETED,
+				Attributes: &historypb.HistoryEvent_ChildWorkflowExecutionCompletedEventAttributes{
+					ChildWorkflowExecutionCompletedEventAttributes: &historypb.ChildWorkflowExecutionCompletedEventAttributes{
+						InitiatedEventId: 8,
+					},
+				},
+			}),
+			at(11, &historypb.HistoryEvent{
+				EventId:   11,
+				EventType: enumspb.EVENT_TYPE_NEXUS_OPERATION_SCHEDULED,
+				Attributes: &historypb.HistoryEvent_NexusOperationScheduledEventAttributes{
+					NexusOperationScheduledEventAttributes: &historypb.NexusOperationScheduledEventAttributes{
+						Endpoint:  "endpoint",
+						Service:   "service",
+						Operation: "operation",
+					},
+				},
+			}),
+			at(12, &historypb.HistoryEvent{
+				EventId:   12,
+				EventType: enumspb.EVENT_TYPE_NEXUS_OPERATION_STARTED,
+				Attributes: &historypb.HistoryEvent_NexusOperationStartedEventAttributes{
+					NexusOperationStartedEventAttributes: &historypb.NexusOperationStartedEventAttributes{
+						ScheduledEventId: 11,
+					},
+				},
+				Links: []*commonpb.Link{{Variant: &commonpb.Link_WorkflowEvent_{
+					WorkflowEvent: &commonpb.Link_WorkflowEvent{
+						Namespace:  "default",
+						WorkflowId: "handler",
+						RunId:      "handler-run",
+					},
+				}}},
+			}),
+			at(20, createTestEventWorkflowExecutionCompleted(13, &historypb.WorkflowExecutionCompletedEventAttributes{})),
+		},
+		"child-run": {
+			at(6, createTestEventWorkflowExecutionStarted(1, &historypb.WorkflowExecutionStartedEventAttributes{
+				WorkflowType: &commonpb.WorkflowType{Name: "ChildWorkflow"},
+				Attempt:      2,
+			})),
+			at(7, createTestEventActivityTaskScheduled(5, &historypb.ActivityTaskScheduledEventAttributes{
+				ActivityId:   "5",
+				ActivityType: &commonpb.ActivityType{Name: "ChildActivity"},
+			})),
+			at(10, createTestEventWorkflowExecutionCompleted(6, &historypb.WorkflowExecutionCompletedEventAttributes{})),
+		},
+		"handler-run": {
+			at(12, createTestEventWorkflowExecutionStarted(1, &historypb.WorkflowExecutionStartedEventAttributes{
+				WorkflowType: &commonpb.WorkflowType{Name: "HandlerWorkflow"},
+				Attempt:      1,
+			})),
+		},
+	}}
+
+	trace, err := GetWorkflowExecutionTrace(context.Background(), client, "parent", "parent-run", ExecutionTraceOptions{})
+	require.NoError(t, err)
+	require.Equal(t, ExecutionTraceNodeKindWorkflow, trace.Kind)
+	require.Equal(t, "ParentWorkflow", trace.Name)
+	require.Equal(t, ExecutionTraceNodeStatusCompleted, trace.Status)
+	require.Equal(t, 20*time.Second, trace.Duration())
+	require.Len(t, trace.Children, 3)
+
+	activity := trace.Children[0]
+	require.Equal(t, ExecutionTraceNodeKindActivity, activity.Kind)
+	require.Equal(t, "SomeActivity", activity.Name)
+	require.Equal(t, ExecutionTraceNodeStatusFailed, activity.Status)
+	require.Equal(t, int32(3), activity.Attempts)
+	require.Equal(t, 3*time.Second, activity.Duration())
+	require.ErrorContains(t, activity.Err, "activity failed")
+
+	child := trace.Children[1]
+	require.Equal(t, ExecutionTraceNodeKindWorkflow, child.Kind)
+	require.Equal(t, "child-run", child.RunID)
+	require.Equal(t, ExecutionTraceNodeStatusCompleted, child.Status)
+	require.Equal(t, int32(2), child.Attempts)
+	require.Len(t, child.Children, 1)
+	require.Equal(t, "ChildActivity", child.Children[0].Name)
+	require.Equal(t, ExecutionTraceNodeStatusRunning, child.Children[0].Status)
+	require.Zero(t, child.Children[0].Duration())
+
+	operation := trace.Children[2]
+	require.Equal(t, ExecutionTraceNodeKindNexusOperation, operation.Kind)
+	require.Equal(t, "service/operation", operation.Name)
+	require.Equal(t, "endpoint", operation.ID)
+	require.Equal(t, ExecutionTraceNodeStatusRunning, operation.Status)
+	require.Len(t, operation.Children, 1)
+	require.Equal(t, "HandlerWorkflow", operation.Children[0].Name)
+	require.Equal(t, "handler-run", operation.Children[0].RunID)
+
+	// The histories of child workflows are not walked beyond the maximum depth
+	trace, err = GetWorkflowExecutionTrace(context.Background(), client, "parent", "parent-run", ExecutionTraceOptions{MaxDepth: -1})
+	require.NoError(t, err)
+	require.Len(t, trace.Children, 3)
+	require.Empty(t, trace.Children[1].Children)
+	require.Empty(t, trace.Children[2].Children)
+}
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"errors"
+	"reflect"
 	"time"
 
 	"github.com/nexus-rpc/sdk-go/nexus"
@@ -17,6 +18,41 @@ import (
 
 var defaultFailureConverter = NewDefaultFailureConverter(DefaultFailureConverterOptions{})
 
+// joinedErrorType is the type of the application failures converted from errors created with errors.Join. Each wrapped
+// error is encoded as a failure in the details.
+const joinedErrorType = "JoinedError"
+
+// joinErrorType is the unexported type of the errors returned by errors.Join.
+var joinErrorType = reflect.TypeOf(errors.Join(errors.New("")))
+
+type (
+	// joinedError is converted from a failure of type joinedErrorType, keeping the message of the original error.
+	joinedError struct {
+		message string
+		errs    []error
+	}
+)
+
+func (e *joinedError) Error() string {
+	return e.message
+}
+
+func (e *joinedError) Unwrap() []error {
+	return e.errs
+}
+
+// getJoinedErrors returns the errors wrapped by an error created with errors.Join. Other errors wrapping several errors,
+// like the ones created with fmt.Errorf and several %w verbs, are converted like any other error.
+func getJoinedErrors(err error) ([]error, bool) {
+	if joined, ok := err.(*joinedError); ok {
+		return joined.errs, true
+	}
+	if reflect.TypeOf(err) == joinErrorType {
+		return err.(interface{ Unwrap() []error }).Unwrap(), true
+	}
+	return nil, false
+}
+
 // GetDefaultFailureConverter returns the default failure converter used by Temporal.
 //
 // Exposed as: [go.temporal.io/sdk/temporal.GetDefaultFailureConverter]
@@ -40,6 +76,9 @@ type DefaultFailureConverterOptions struct {
 }
 
 // DefaultFailureConverter seralizes errors with the option to encode common parameters under Failure.EncodedAttributes
+// Errors created with errors.Join are converted to a failure of type JoinedError with each joined error encoded as a
+// failure in the details, and converted back to an error wrapping all of them. Other errors wrapping several errors,
+// such as the ones created by fmt.Errorf with several %w verbs, are converted like other errors.
 //
 // Exposed as: [go.temporal.io/sdk/temporal.DefaultFailureConverter]
 type DefaultFailureConverter struct {
@@ -175,7 +214,19 @@ func (dfc *DefaultFailureConverter) ErrorToFailure(err error) *failurepb.Failure
 			RetryBehavior: retryBehavior,
 		}
 		failure.FailureInfo = &failurepb.Failure_NexusHandlerFailureInfo{NexusHandlerFailureInfo: failureInfo}
-	default: // All unknown errors are considered to be retryable ApplicationFailureInfo.
+	default:
+		if errs, ok := getJoinedErrors(err); ok {
+			// Like for local activities, the error is not retryable if the first ApplicationError found is not.
+			var applicationErr *ApplicationError
+			failureInfo := &failurepb.ApplicationFailureInfo{
+				Type:         joinedErrorType,
+				NonRetryable: errors.As(err, &applicationErr) && applicationErr.NonRetryable(),
+				Details:      dfc.errorsToPayloads(errs),
+			}
+			failure.FailureInfo = &failurepb.Failure_ApplicationFailureInfo{ApplicationFailureInfo: failureInfo}
+			break
+		}
+		// All unknown errors are considered to be retryable ApplicationFailureInfo.
 		failureInfo := &failurepb.ApplicationFailureInfo{
 			Type:         getErrType(err),
 			NonRetryable: false,
@@ -213,6 +264,12 @@ func (dfc *DefaultFailureConverter) FailureToError(failure *failurepb.Failure) e
 		switch applicationFailureInfo.GetType() {
 		case getErrType(&PanicError{}):
 			err = newPanicError(message, stackTrace)
+		case joinedErrorType:
+			if errs, decodeErr := dfc.payloadsToErrors(applicationFailureInfo.GetDetails()); decodeErr == nil {
+				err = &joinedError{message: message, errs: errs}
+				break
+			}
+			fallthrough
 		default:
 			var nextRetryDelay time.Duration
 			if delay := applicationFailureInfo.GetNextRetryDelay(); delay != nil {
@@ -311,3 +368,32 @@ func (dfc *DefaultFailureConverter) FailureToError(failure *failurepb.Failure) e
 
 	return err
 }
+
+// errorsToPayloads encodes each error as a failure payload.
+func (dfc *DefaultFailureConverter) errorsToPayloads(errs []error) *commonpb.Payloads {
+	payloads := &commonpb.Payloads{}
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		payload, err := dfc.dataConverter.ToPayload(dfc.ErrorToFailure(err))
+		if err != nil {
+			panic(err)
+		}
+		payloads.Payloads = append(payloads.Payloads, payload)
+	}
+	return payloads
+}
+
+// payloadsToErrors decodes the errors encoded by errorsToPayloads.
+func (dfc *DefaultFailureConverter) payloadsToErrors(payloads *commonpb.Payloads) ([]error, error) {
+	errs := make([]error, 0, len(payloads.GetPayloads()))
+	for _, payload := range payloads.GetPayloads() {
+		failure := &failurepb.Failure{}
+		if err := dfc.dataConverter.FromPayload(payload, failure); err != nil {
+			return nil, err
+		}
+		errs = append(errs, dfc.FailureToError(failure))
+	}
+	return errs, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/internal/common/metrics"
 	"go.temporal.io/sdk/internal/common/retry"
 	"google.golang.org/grpc"
@@ -129,18 +130,30 @@ func requiredInterceptors(
 	clientOptions *ClientOptions,
 	excludeInternalFromRetry *atomic.Bool,
 ) []grpc.UnaryClientInterceptor {
-	interceptors := []grpc.UnaryClientInterceptor{
+	var interceptors []grpc.UnaryClientInterceptor
+	if clientOptions.ConnectionOptions.CallTimeouts != (CallTimeoutOptions{}) {
+		// Outermost so the whole call, with its retries, is given the timeout.
+		interceptors = append(interceptors, callTimeoutsInterceptor(clientOptions.ConnectionOptions.CallTimeouts))
+	}
+	interceptors = append(interceptors,
 		errorInterceptor,
 		// Report aggregated metrics for the call, this is done outside of the retry loop.
 		metrics.NewGRPCInterceptor(clientOptions.MetricsHandler, "", clientOptions.DisableErrorCodeMetricTags),
+	)
+	if clientOptions.NamespaceFailover != nil {
+		// Namespace failovers outlast the regular retries, so the calls are retried around them.
+		interceptors = append(interceptors,
+			namespaceFailoverInterceptor(*clientOptions.NamespaceFailover, clientOptions.RetryJitter))
+	}
+	interceptors = append(interceptors,
 		// By default the grpc retry interceptor *is disabled*, preventing accidental use of retries.
 		// We add call options for retry configuration based on the values present in the context.
-		retry.NewRetryOptionsInterceptor(excludeInternalFromRetry),
+		retry.NewRetryOptionsInterceptor(excludeInternalFromRetry, clientOptions.RetryJitter),
 		// Performs retries *IF* retry options are set for the call.
 		grpc_retry.UnaryClientInterceptor(),
 		// Report metrics for every call made to the server.
 		metrics.NewGRPCInterceptor(clientOptions.MetricsHandler, attemptSuffix, clientOptions.DisableErrorCodeMetricTags),
-	}
+	)
 	if clientOptions.HeadersProvider != nil {
 		interceptors = append(interceptors, headersProviderInterceptor(clientOptions.HeadersProvider))
 	}
@@ -159,6 +172,52 @@ func requiredInterceptors(
 	return interceptors
 }
 
+// callTimeoutParentContextKey is the key of the parent context of gRPC contexts whose timeout is a default that
+// callTimeoutsInterceptor may replace. The parent is canceled when the gRPC context is.
+type callTimeoutParentContextKey struct{}
+
+// callTimeoutContext is the context of a call with the values of the context of the call and the deadline of
+// another context.
+type callTimeoutContext struct {
+	context.Context
+	deadlineCtx context.Context
+}
+
+func (c *callTimeoutContext) Deadline() (time.Time, bool) { return c.deadlineCtx.Deadline() }
+func (c *callTimeoutContext) Done() <-chan struct{}       { return c.deadlineCtx.Done() }
+func (c *callTimeoutContext) Err() error                  { return c.deadlineCtx.Err() }
+
+// callTimeoutsInterceptor replaces the default timeout of calls with the one of their class in the options.
+func callTimeoutsInterceptor(options CallTimeoutOptions) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		parent, ok := ctx.Value(callTimeoutParentContextKey{}).(context.Context)
+		if !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		var timeout time.Duration
+		if longPoll, _ := ctx.Value(metrics.LongPollContextKey{}).(bool); longPoll {
+			timeout = options.LongPoll
+		} else if method == workflowservice.WorkflowService_GetWorkflowExecutionHistory_FullMethodName ||
+			method == workflowservice.WorkflowService_GetWorkflowExecutionHistoryReverse_FullMethodName {
+			timeout = options.HistoryFetch
+			if timeout == 0 {
+				timeout = options.ShortCall
+			}
+			if timeout > 0 {
+				timeout = rpcTimeoutWithin(parent, timeout, timeout)
+			}
+		} else if options.ShortCall > 0 {
+			timeout = rpcTimeoutWithin(parent, options.ShortCall, options.ShortCall)
+		}
+		if timeout <= 0 {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		deadlineCtx, cancel := context.WithTimeout(parent, timeout)
+		defer cancel()
+		return invoker(&callTimeoutContext{Context: ctx, deadlineCtx: deadlineCtx}, method, req, reply, cc, opts...)
+	}
+}
+
 func namespaceProviderInterceptor() grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 		if nsReq, ok := req.(interface{ GetNamespace() string }); ok {
@@ -195,6 +254,77 @@ func headersProviderInterceptor(headersProvider HeadersProvider) grpc.UnaryClien
 	}
 }
 
+// staticHeadersConn is a connection adding static headers to every call made through it.
+type staticHeadersConn struct {
+	grpc.ClientConnInterface
+	// Alternating keys and values
+	headers []string
+}
+
+// withStaticHeaders returns the connection adding the headers to every call, or the connection itself if there are
+// none.
+func withStaticHeaders(conn *grpc.ClientConn, headers map[string]string) grpc.ClientConnInterface {
+	if len(headers) == 0 || conn == nil {
+		return conn
+	}
+	c := &staticHeadersConn{ClientConnInterface: conn}
+	for k, v := range headers {
+		c.headers = append(c.headers, k, v)
+	}
+	return c
+}
+
+func (c *staticHeadersConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	return c.ClientConnInterface.Invoke(metadata.AppendToOutgoingContext(ctx, c.headers...), method, args, reply, opts...)
+}
+
+func (c *staticHeadersConn) NewStream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	method string,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	return c.ClientConnInterface.NewStream(metadata.AppendToOutgoingContext(ctx, c.headers...), desc, method, opts...)
+}
+
+// headersProviderConn is a connection adding the headers of a HeadersProvider to every call made through it.
+type headersProviderConn struct {
+	grpc.ClientConnInterface
+	headersProvider HeadersProvider
+}
+
+func (c *headersProviderConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	ctx, err := c.withHeaders(ctx)
+	if err != nil {
+		return err
+	}
+	return c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+}
+
+func (c *headersProviderConn) NewStream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	method string,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	ctx, err := c.withHeaders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.ClientConnInterface.NewStream(ctx, desc, method, opts...)
+}
+
+func (c *headersProviderConn) withHeaders(ctx context.Context) (context.Context, error) {
+	headers, err := c.headersProvider.GetHeaders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		ctx = metadata.AppendToOutgoingContext(ctx, k, v)
+	}
+	return ctx, nil
+}
+
 func errorInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 	err := invoker(ctx, method, req, reply, cc, opts...)
 	err = serviceerror.FromStatus(status.Convert(err))
@@ -24,6 +24,7 @@ import (
 	"go.temporal.io/sdk/internal/common/retry"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -114,6 +115,129 @@ func TestHeadersProvider_IncludedWithHeadersProvider(t *testing.T) {
 	require.Equal(t, 7, len(interceptors))
 }
 
+func TestCallTimeoutsInterceptor(t *testing.T) {
+	interceptor := callTimeoutsInterceptor(CallTimeoutOptions{
+		ShortCall:    3 * time.Second,
+		LongPoll:     90 * time.Second,
+		HistoryFetch: 30 * time.Second,
+	})
+	callTimeout := func(ctx context.Context, method string) time.Duration {
+		var timeout time.Duration
+		require.NoError(t, interceptor(ctx, method, "request", "reply", nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				require.Equal(t, "value", ctx.Value(testContextKey("key")))
+				deadline, ok := ctx.Deadline()
+				require.True(t, ok)
+				timeout = time.Until(deadline)
+				return nil
+			}))
+		return timeout
+	}
+	parent := context.WithValue(context.Background(), testContextKey("key"), "value")
+	const signalMethod = "/temporal.api.workflowservice.v1.WorkflowService/SignalWorkflowExecution"
+
+	for _, tc := range []struct {
+		name     string
+		options  []func(builder *grpcContextBuilder)
+		method   string
+		expected time.Duration
+	}{
+		{"short call", nil, signalMethod, 3 * time.Second},
+		{"long poll", []func(builder *grpcContextBuilder){grpcTimeout(pollTaskServiceTimeOut), grpcLongPoll(true)},
+			signalMethod, 90 * time.Second},
+		{"history fetch", nil, workflowservice.WorkflowService_GetWorkflowExecutionHistory_FullMethodName, 30 * time.Second},
+		{"explicit timeout", []func(builder *grpcContextBuilder){grpcTimeout(time.Minute)}, signalMethod, time.Minute},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := newGRPCContext(parent, tc.options...)
+			defer cancel()
+			require.InDelta(t, tc.expected, callTimeout(ctx, tc.method), float64(100*time.Millisecond))
+		})
+	}
+
+	// The deadline of the parent context is still respected
+	deadlineCtx, cancel := context.WithTimeout(parent, 4*time.Second)
+	defer cancel()
+	ctx, cancel := newGRPCContext(deadlineCtx)
+	defer cancel()
+	require.InDelta(t, 2*time.Second, callTimeout(ctx, signalMethod), float64(100*time.Millisecond))
+
+	// Canceling the context of a long poll still aborts it
+	ctx, cancel = newGRPCContext(context.Background(), grpcTimeout(pollTaskServiceTimeOut), grpcLongPoll(true))
+	time.AfterFunc(10*time.Millisecond, cancel)
+	err := interceptor(ctx, signalMethod, "request", "reply", nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(5 * time.Second):
+				return errors.New("long poll not canceled")
+			}
+		})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestHeadersProvider_Worker(t *testing.T) {
+	srv, err := startTestGRPCServer()
+	require.NoError(t, err)
+	defer srv.Stop()
+
+	client, err := DialClient(context.Background(), ClientOptions{
+		HostPort:      srv.addr,
+		StaticHeaders: map[string]string{"x-tenant": "tenant-a"},
+	})
+	require.NoError(t, err)
+	defer client.Close()
+	worker := NewAggregatedWorker(client.(*WorkflowClient), "task-queue", WorkerOptions{
+		HeadersProvider: authHeadersProvider{token: "worker-token"},
+	})
+
+	// Requests of the worker have the headers, requests of the client do not
+	_, err = worker.client.workflowService.SignalWorkflowExecution(context.Background(),
+		&workflowservice.SignalWorkflowExecutionRequest{Namespace: "default"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"worker-token"},
+		metadata.ValueFromIncomingContext(srv.lastSignalWorkflowExecutionContext, "authorization"))
+	require.Equal(t, []string{"tenant-a"}, metadata.ValueFromIncomingContext(srv.lastSignalWorkflowExecutionContext, "x-tenant"))
+	require.NoError(t, client.SignalWorkflow(context.Background(), "workflow", "run", "signal", nil))
+	require.Empty(t, metadata.ValueFromIncomingContext(srv.lastSignalWorkflowExecutionContext, "authorization"))
+	require.Same(t, client.(*WorkflowClient).eagerDispatcher, worker.client.eagerDispatcher)
+
+	// Requests fail when the headers cannot be provided
+	worker = NewAggregatedWorker(client.(*WorkflowClient), "task-queue", WorkerOptions{
+		HeadersProvider: authHeadersProvider{err: errors.New("token expired")},
+	})
+	_, err = worker.client.workflowService.SignalWorkflowExecution(context.Background(),
+		&workflowservice.SignalWorkflowExecutionRequest{Namespace: "default"})
+	require.EqualError(t, err, "token expired")
+}
+
+func TestHostMetadata_Worker(t *testing.T) {
+	srv, err := startTestGRPCServer()
+	require.NoError(t, err)
+	defer srv.Stop()
+
+	client, err := DialClient(context.Background(), ClientOptions{HostPort: srv.addr, Identity: "1234@host"})
+	require.NoError(t, err)
+	defer client.Close()
+	worker := NewAggregatedWorker(client.(*WorkflowClient), "task-queue", WorkerOptions{
+		HeadersProvider: authHeadersProvider{token: "worker-token"},
+		HostMetadata:    &WorkerHostMetadata{PodName: "pod-1", Region: "us-east-1", BinaryChecksum: "abc"},
+	})
+	require.Equal(t, "1234@host pod=pod-1 region=us-east-1 checksum=abc",
+		worker.workflowWorker.executionParameters.Identity)
+
+	_, err = worker.client.workflowService.SignalWorkflowExecution(context.Background(),
+		&workflowservice.SignalWorkflowExecutionRequest{Namespace: "default"})
+	require.NoError(t, err)
+	ctx := srv.lastSignalWorkflowExecutionContext
+	require.Equal(t, []string{"pod-1"}, metadata.ValueFromIncomingContext(ctx, workerPodNameHeaderName))
+	require.Empty(t, metadata.ValueFromIncomingContext(ctx, workerNodeNameHeaderName))
+	require.Equal(t, []string{"us-east-1"}, metadata.ValueFromIncomingContext(ctx, workerRegionHeaderName))
+	require.Equal(t, []string{"abc"}, metadata.ValueFromIncomingContext(ctx, workerBinaryChecksumHeaderName))
+	require.Equal(t, []string{"worker-token"}, metadata.ValueFromIncomingContext(ctx, "authorization"))
+}
+
 func TestMissingGetServerInfo(t *testing.T) {
 	// Make a gRPC server that has everything unimplemented
 	l, err := net.Listen("tcp", "127.0.0.1:0")
@@ -153,6 +277,83 @@ func TestMissingGetServerInfo(t *testing.T) {
 	require.True(t, proto.Equal(&workflowservice.GetSystemInfoResponse_Capabilities{}, workflowClient.capabilities))
 }
 
+func TestRetryJitter(t *testing.T) {
+	retryConfig := retry.NewGrpcRetryConfig(10 * time.Millisecond)
+	retryConfig.SetMaximumAttempts(3)
+	ctx := context.WithValue(context.Background(), retry.ConfigKey, retryConfig)
+
+	srv, err := startTestGRPCServer()
+	require.NoError(t, err)
+	defer srv.Stop()
+	srv.signalWorkflowExecutionResponseError = status.Error(codes.Unavailable, "unavailable")
+
+	jitter := &recordingRetryJitter{}
+	client, err := DialClient(context.Background(), ClientOptions{HostPort: srv.addr, RetryJitter: jitter})
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.WorkflowService().SignalWorkflowExecution(ctx, &workflowservice.SignalWorkflowExecutionRequest{})
+	require.Error(t, err)
+	require.Equal(t, 3, srv.signalWorkflowInvokeCount())
+	require.Len(t, jitter.inputs, 2)
+	require.Equal(t, 10*time.Millisecond, jitter.inputs[0].PreviousBackoff)
+	require.Equal(t, 20*time.Millisecond, jitter.inputs[0].Backoff)
+}
+
+func TestNamespaceFailoverRetry(t *testing.T) {
+	ctx := context.WithValue(context.Background(), retry.ConfigKey, retry.NewGrpcRetryConfig(10*time.Millisecond))
+	srv, err := startTestGRPCServer()
+	require.NoError(t, err)
+	defer srv.Stop()
+	srv.signalWorkflowExecutionResponseError = serviceerror.ToStatus(
+		serviceerror.NewNamespaceNotActive("ns", "cluster-a", "cluster-b")).Err()
+
+	// Failover errors are not retried by default
+	client, err := DialClient(context.Background(), ClientOptions{HostPort: srv.addr})
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.WorkflowService().SignalWorkflowExecution(ctx, &workflowservice.SignalWorkflowExecutionRequest{})
+	var notActive *serviceerror.NamespaceNotActive
+	require.ErrorAs(t, err, &notActive)
+	require.Equal(t, 1, srv.signalWorkflowInvokeCount())
+
+	// They are retried until the maximum duration when enabled, notifying each retry
+	srv.resetSignalWorkflowInvokeCount()
+	var infos []NamespaceFailoverInfo
+	client, err = DialClient(context.Background(), ClientOptions{
+		HostPort: srv.addr,
+		NamespaceFailover: &NamespaceFailoverOptions{
+			InitialInterval: 10 * time.Millisecond,
+			MaximumInterval: 10 * time.Millisecond,
+			MaximumDuration: 100 * time.Millisecond,
+			OnFailover:      func(info NamespaceFailoverInfo) { infos = append(infos, info) },
+		},
+		RetryJitter: &recordingRetryJitter{},
+	})
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.WorkflowService().SignalWorkflowExecution(ctx, &workflowservice.SignalWorkflowExecutionRequest{})
+	require.ErrorAs(t, err, &notActive)
+	require.Greater(t, srv.signalWorkflowInvokeCount(), 2)
+	require.Len(t, infos, srv.signalWorkflowInvokeCount()-1)
+	require.Equal(t, NamespaceFailoverInfo{
+		Namespace:      "ns",
+		CurrentCluster: "cluster-a",
+		ActiveCluster:  "cluster-b",
+		Method:         "/temporal.api.workflowservice.v1.WorkflowService/SignalWorkflowExecution",
+		Attempt:        2,
+		Error:          infos[1].Error,
+		// Halved by the jitter
+		Backoff: 5 * time.Millisecond,
+	}, infos[1])
+
+	// Calls succeeding after the failover are not retried
+	srv.resetSignalWorkflowInvokeCount()
+	srv.signalWorkflowExecutionResponseError = nil
+	_, err = client.WorkflowService().SignalWorkflowExecution(ctx, &workflowservice.SignalWorkflowExecutionRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 1, srv.signalWorkflowInvokeCount())
+}
+
 func TestInternalErrorRetry(t *testing.T) {
 	// Build a common retry policy that will retry 2 times (so 3 attempts total)
 	retryConfig := retry.NewGrpcRetryConfig(10 * time.Nanosecond)
@@ -506,6 +707,124 @@ func TestCredentialsAPIKey(t *testing.T) {
 	)
 }
 
+func TestStaticHeaders(t *testing.T) {
+	srv, err := startTestGRPCServer()
+	require.NoError(t, err)
+	defer srv.Stop()
+
+	client, err := DialClient(context.Background(), ClientOptions{
+		HostPort:      srv.addr,
+		StaticHeaders: map[string]string{"x-tenant": "tenant-a", "x-route": "us-east"},
+	})
+	require.NoError(t, err)
+	defer client.Close()
+	require.Equal(t, []string{"tenant-a"}, metadata.ValueFromIncomingContext(srv.getSystemInfoRequestContext, "x-tenant"))
+	require.Equal(t, []string{"us-east"}, metadata.ValueFromIncomingContext(srv.getSystemInfoRequestContext, "x-route"))
+
+	// Clients sharing the connection use their own headers
+	otherClient, err := NewClientFromExisting(context.Background(), client, ClientOptions{
+		Namespace:     "other",
+		StaticHeaders: map[string]string{"x-tenant": "tenant-b"},
+	})
+	require.NoError(t, err)
+	defer otherClient.Close()
+	_, err = otherClient.WorkflowService().GetSystemInfo(context.Background(), &workflowservice.GetSystemInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"tenant-b"}, metadata.ValueFromIncomingContext(srv.getSystemInfoRequestContext, "x-tenant"))
+	require.Empty(t, metadata.ValueFromIncomingContext(srv.getSystemInfoRequestContext, "x-route"))
+}
+
+func TestMultiClient(t *testing.T) {
+	srv, err := startTestGRPCServer()
+	require.NoError(t, err)
+	defer srv.Stop()
+
+	multi, err := DialMultiClient(context.Background(), MultiClientOptions{
+		ClientOptions: ClientOptions{HostPort: srv.addr},
+		NamespaceOptions: func(namespace string) (ClientOptions, error) {
+			if namespace == "invalid" {
+				return ClientOptions{}, errors.New("unknown namespace")
+			}
+			return ClientOptions{StaticHeaders: map[string]string{"x-tenant": namespace + "-tenant"}}, nil
+		},
+	})
+	require.NoError(t, err)
+	root := multi.(*multiClient).root
+
+	for _, namespace := range []string{"ns1", "ns2"} {
+		client, err := multi.Namespace(context.Background(), namespace)
+		require.NoError(t, err)
+		require.NoError(t, client.SignalWorkflow(context.Background(), "workflow", "run", "signal", nil))
+		require.Equal(t, []string{namespace},
+			metadata.ValueFromIncomingContext(srv.lastSignalWorkflowExecutionContext, temporalNamespaceHeaderKey))
+		require.Equal(t, []string{namespace + "-tenant"},
+			metadata.ValueFromIncomingContext(srv.lastSignalWorkflowExecutionContext, "x-tenant"))
+		// Clients share the connection and are reused
+		require.Same(t, root.conn, client.(*WorkflowClient).conn)
+		sameClient, err := multi.Namespace(context.Background(), namespace)
+		require.NoError(t, err)
+		require.Same(t, client, sameClient)
+	}
+	_, err = multi.Namespace(context.Background(), "invalid")
+	require.EqualError(t, err, "unknown namespace")
+	require.Equal(t, []string{"ns1", "ns2"}, multi.Namespaces())
+
+	// Removing a namespace closes its client but not the connection
+	multi.RemoveNamespace("ns1")
+	require.Equal(t, []string{"ns2"}, multi.Namespaces())
+	require.Less(t, root.conn.GetState(), connectivity.Shutdown)
+
+	multi.Close()
+	require.Equal(t, connectivity.Shutdown, root.conn.GetState())
+	require.Empty(t, multi.Namespaces())
+	_, err = multi.Namespace(context.Background(), "ns2")
+	require.ErrorIs(t, err, errMultiClientClosed)
+}
+
+func TestMultiClient_ConcurrentCreation(t *testing.T) {
+	srv, err := startTestGRPCServer()
+	require.NoError(t, err)
+	defer srv.Stop()
+
+	slowStarted, unblockSlow := make(chan struct{}), make(chan struct{})
+	multi, err := DialMultiClient(context.Background(), MultiClientOptions{
+		ClientOptions: ClientOptions{HostPort: srv.addr},
+		NamespaceOptions: func(namespace string) (ClientOptions, error) {
+			if namespace == "slow" {
+				close(slowStarted)
+				<-unblockSlow
+			}
+			return ClientOptions{}, nil
+		},
+	})
+	require.NoError(t, err)
+	defer multi.Close()
+
+	slowClients := make(chan Client, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			client, err := multi.Namespace(context.Background(), "slow")
+			assert.NoError(t, err)
+			slowClients <- client
+		}()
+	}
+	<-slowStarted
+
+	// Other namespaces are not blocked by the creation of a client
+	_, err = multi.Namespace(context.Background(), "fast")
+	require.NoError(t, err)
+	require.Equal(t, []string{"fast"}, multi.Namespaces())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = multi.Namespace(ctx, "slow")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Concurrent requests for a namespace get the same client
+	close(unblockSlow)
+	require.Same(t, <-slowClients, <-slowClients)
+	require.Equal(t, []string{"fast", "slow"}, multi.Namespaces())
+}
+
 func TestNamespaceInterceptor(t *testing.T) {
 	srv, err := startTestGRPCServer()
 	require.NoError(t, err)
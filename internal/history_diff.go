@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	historypb "go.temporal.io/api/history/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type (
+	// HistoryDiffOptions are options for DiffWorkflowHistories.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.HistoryDiffOptions]
+	HistoryDiffOptions struct {
+		// Optional: Paths of event fields not compared, in the form of HistoryEventDiff.Fields, for example
+		// "event_time" and "task_id", which differ between the histories of a workflow in different clusters.
+		IgnoredFields []string
+	}
+
+	// HistoryEventDiff is a divergence between two workflow histories, for the events with the same ID.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.HistoryEventDiff]
+	HistoryEventDiff struct {
+		// EventID of the divergent events.
+		EventID int64
+		// Left is the event of the first history, nil if it has no event with the ID.
+		Left *historypb.HistoryEvent
+		// Right is the event of the second history, nil if it has no event with the ID.
+		Right *historypb.HistoryEvent
+		// Fields are the paths of the fields that differ between the events, such as "event_type" or
+		// "activity_task_scheduled_event_attributes.activity_type.name", with list indexes and map keys in
+		// brackets. Empty if one of the events is missing.
+		Fields []string
+	}
+)
+
+// DiffWorkflowHistories compares two workflow histories event by event, matching events by ID, and returns the
+// divergent events ordered by ID. This helps debugging resets, replays and replication, for example by diffing the
+// history of a workflow with the history of its reset run, or with its history in another cluster.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.DiffWorkflowHistories]
+func DiffWorkflowHistories(left, right *historypb.History, options HistoryDiffOptions) []HistoryEventDiff {
+	leftEvents := historyEventsByID(left)
+	rightEvents := historyEventsByID(right)
+	var eventIDs []int64
+	for eventID := range leftEvents {
+		eventIDs = append(eventIDs, eventID)
+	}
+	for eventID := range rightEvents {
+		if _, ok := leftEvents[eventID]; !ok {
+			eventIDs = append(eventIDs, eventID)
+		}
+	}
+	slices.Sort(eventIDs)
+
+	var diffs []HistoryEventDiff
+	for _, eventID := range eventIDs {
+		diff := HistoryEventDiff{EventID: eventID, Left: leftEvents[eventID], Right: rightEvents[eventID]}
+		if diff.Left != nil && diff.Right != nil {
+			diff.Fields = diffMessageFields("", diff.Left.ProtoReflect(), diff.Right.ProtoReflect(), options.IgnoredFields)
+			if len(diff.Fields) == 0 {
+				continue
+			}
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+func historyEventsByID(history *historypb.History) map[int64]*historypb.HistoryEvent {
+	events := make(map[int64]*historypb.HistoryEvent, len(history.GetEvents()))
+	for _, event := range history.GetEvents() {
+		events[event.GetEventId()] = event
+	}
+	return events
+}
+
+// diffMessageFields returns the paths of the fields that differ between two messages of the same type, under the
+// path prefix.
+func diffMessageFields(prefix string, left, right protoreflect.Message, ignored []string) []string {
+	var paths []string
+	fields := left.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		path := prefix + string(field.Name())
+		if slices.Contains(ignored, path) || (!left.Has(field) && !right.Has(field)) {
+			continue
+		}
+		leftValue, rightValue := left.Get(field), right.Get(field)
+		switch {
+		case field.IsList():
+			paths = append(paths, diffListFields(path, field, leftValue.List(), rightValue.List(), ignored)...)
+		case field.IsMap():
+			paths = append(paths, diffMapFields(path, field, leftValue.Map(), rightValue.Map(), ignored)...)
+		default:
+			paths = append(paths, diffValueFields(path, field, leftValue, rightValue, left.Has(field) && right.Has(field), ignored)...)
+		}
+	}
+	return paths
+}
+
+func diffListFields(path string, field protoreflect.FieldDescriptor, left, right protoreflect.List, ignored []string) []string {
+	var paths []string
+	for i := 0; i < max(left.Len(), right.Len()); i++ {
+		elementPath := fmt.Sprintf("%s[%d]", path, i)
+		if i >= left.Len() || i >= right.Len() {
+			paths = append(paths, elementPath)
+			continue
+		}
+		paths = append(paths, diffValueFields(elementPath, field, left.Get(i), right.Get(i), true, ignored)...)
+	}
+	return paths
+}
+
+func diffMapFields(path string, field protoreflect.FieldDescriptor, left, right protoreflect.Map, ignored []string) []string {
+	var keys []protoreflect.MapKey
+	left.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, key)
+		return true
+	})
+	right.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		if !left.Has(key) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+	slices.SortFunc(keys, func(a, b protoreflect.MapKey) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	var paths []string
+	for _, key := range keys {
+		entryPath := fmt.Sprintf("%s[%s]", path, key.String())
+		if !left.Has(key) || !right.Has(key) {
+			paths = append(paths, entryPath)
+			continue
+		}
+		paths = append(paths, diffValueFields(entryPath, field.MapValue(), left.Get(key), right.Get(key), true, ignored)...)
+	}
+	return paths
+}
+
+// diffValueFields returns the paths of the fields that differ between two values of a field, or the path itself if
+// the values are scalars that differ, or messages of which only one is set.
+func diffValueFields(
+	path string,
+	field protoreflect.FieldDescriptor,
+	left, right protoreflect.Value,
+	bothSet bool,
+	ignored []string,
+) []string {
+	if field.Message() != nil && bothSet {
+		return diffMessageFields(path+".", left.Message(), right.Message(), ignored)
+	}
+	if bothSet && left.Equal(right) {
+		return nil
+	}
+	return []string{path}
+}
@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	historypb "go.temporal.io/api/history/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDiffWorkflowHistories(t *testing.T) {
+	newHistory := func(activityType string, eventTime time.Time, extraEvent bool) *historypb.History {
+		events := []*historypb.HistoryEvent{
+			createTestEventWorkflowExecutionStarted(1, &historypb.WorkflowExecutionStartedEventAttributes{
+				WorkflowType: &commonpb.WorkflowType{Name: "wf"},
+				TaskQueue:    &taskqueuepb.TaskQueue{Name: "tq"},
+			}),
+			createTestEventActivityTaskScheduled(2, &historypb.ActivityTaskScheduledEventAttributes{
+				ActivityId:   "2",
+				ActivityType: &commonpb.ActivityType{Name: activityType},
+				Header: &commonpb.Header{Fields: map[string]*commonpb.Payload{
+					"key": {Data: []byte(activityType)},
+				}},
+			}),
+		}
+		if extraEvent {
+			events = append(events, createTestEventWorkflowTaskScheduled(3, &historypb.WorkflowTaskScheduledEventAttributes{}))
+		}
+		for _, event := range events {
+			event.EventTime = timestamppb.New(eventTime)
+		}
+		return &historypb.History{Events: events}
+	}
+
+	left := newHistory("a", time.Unix(100, 0), true)
+	right := newHistory("b", time.Unix(200, 0), false)
+	diffs := DiffWorkflowHistories(left, right, HistoryDiffOptions{IgnoredFields: []string{"event_time"}})
+	require.Len(t, diffs, 2)
+	require.Equal(t, int64(2), diffs[0].EventID)
+	require.Equal(t, []string{
+		"activity_task_scheduled_event_attributes.activity_type.name",
+		"activity_task_scheduled_event_attributes.header.fields[key].data",
+	}, diffs[0].Fields)
+	require.Equal(t, int64(3), diffs[1].EventID)
+	require.Same(t, left.Events[2], diffs[1].Left)
+	require.Nil(t, diffs[1].Right)
+	require.Empty(t, diffs[1].Fields)
+
+	diffs = DiffWorkflowHistories(left, newHistory("a", time.Unix(200, 0), true), HistoryDiffOptions{})
+	require.Len(t, diffs, 3)
+	require.Equal(t, []string{"event_time.seconds"}, diffs[0].Fields)
+
+	require.Empty(t, DiffWorkflowHistories(left, left, HistoryDiffOptions{}))
+}
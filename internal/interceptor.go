@@ -82,6 +82,11 @@ type ActivityOutboundInterceptor interface {
 	// RecordHeartbeat intercepts activity.RecordHeartbeat.
 	RecordHeartbeat(ctx context.Context, details ...interface{})
 
+	// RecordHeartbeatImmediate intercepts activity.RecordHeartbeatImmediate.
+	//
+	// NOTE: Experimental
+	RecordHeartbeatImmediate(ctx context.Context, details ...interface{})
+
 	// HasHeartbeatDetails intercepts activity.HasHeartbeatDetails.
 	HasHeartbeatDetails(ctx context.Context) bool
 
@@ -91,6 +96,11 @@ type ActivityOutboundInterceptor interface {
 	// GetWorkerStopChannel intercepts activity.GetWorkerStopChannel.
 	GetWorkerStopChannel(ctx context.Context) <-chan struct{}
 
+	// GetWorkerStopInfo intercepts activity.GetWorkerStopInfo.
+	//
+	// NOTE: Experimental
+	GetWorkerStopInfo(ctx context.Context) (WorkerStopInfo, bool)
+
 	// GetClient intercepts activity.GetClient.
 	GetClient(ctx context.Context) Client
 
@@ -132,6 +142,17 @@ type WorkflowInboundInterceptor interface {
 	// perform workflow actions such as scheduling activities, timers, etc.
 	ExecuteUpdate(ctx Context, in *UpdateInput) (interface{}, error)
 
+	// HandleReplayedEvent is called after each history event is applied while
+	// replaying, that is for the events preceding the workflow task being
+	// processed on a worker and for all events in a replayer. Events that
+	// only schedule workflow tasks are skipped. It can be used to report
+	// replay progress or to find where replay diverges. It is called outside
+	// of any workflow coroutine, so it must not block or call workflow APIs
+	// other than reading from ctx.
+	//
+	// NOTE: Experimental
+	HandleReplayedEvent(ctx Context, in *HandleReplayedEventInput)
+
 	mustEmbedWorkflowInboundInterceptorBase()
 }
 
@@ -169,6 +190,18 @@ type HandleQueryInput struct {
 	Args      []interface{}
 }
 
+// HandleReplayedEventInput is the input to
+// WorkflowInboundInterceptor.HandleReplayedEvent.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/interceptor.HandleReplayedEventInput]
+type HandleReplayedEventInput struct {
+	EventID   int64
+	EventType enumspb.EventType
+	EventTime time.Time
+}
+
 // ExecuteNexusOperationInput is the input to WorkflowOutboundInterceptor.ExecuteNexusOperation.
 //
 // NOTE: Experimental
@@ -110,6 +110,12 @@ func (a *ActivityOutboundInterceptorBase) RecordHeartbeat(ctx context.Context, d
 	a.Next.RecordHeartbeat(ctx, details...)
 }
 
+// RecordHeartbeatImmediate implements
+// ActivityOutboundInterceptor.RecordHeartbeatImmediate.
+func (a *ActivityOutboundInterceptorBase) RecordHeartbeatImmediate(ctx context.Context, details ...interface{}) {
+	a.Next.RecordHeartbeatImmediate(ctx, details...)
+}
+
 // HasHeartbeatDetails implements
 // ActivityOutboundInterceptor.HasHeartbeatDetails.
 func (a *ActivityOutboundInterceptorBase) HasHeartbeatDetails(ctx context.Context) bool {
@@ -128,6 +134,14 @@ func (a *ActivityOutboundInterceptorBase) GetWorkerStopChannel(ctx context.Conte
 	return a.Next.GetWorkerStopChannel(ctx)
 }
 
+// GetWorkerStopInfo implements
+// ActivityOutboundInterceptor.GetWorkerStopInfo.
+//
+// NOTE: Experimental
+func (a *ActivityOutboundInterceptorBase) GetWorkerStopInfo(ctx context.Context) (WorkerStopInfo, bool) {
+	return a.Next.GetWorkerStopInfo(ctx)
+}
+
 // GetClient implements
 // ActivityOutboundInterceptor.GetClient
 func (a *ActivityOutboundInterceptorBase) GetClient(ctx context.Context) Client {
@@ -178,6 +192,11 @@ func (w *WorkflowInboundInterceptorBase) HandleQuery(ctx Context, in *HandleQuer
 	return w.Next.HandleQuery(ctx, in)
 }
 
+// HandleReplayedEvent implements WorkflowInboundInterceptor.HandleReplayedEvent.
+func (w *WorkflowInboundInterceptorBase) HandleReplayedEvent(ctx Context, in *HandleReplayedEventInput) {
+	w.Next.HandleReplayedEvent(ctx, in)
+}
+
 func (*WorkflowInboundInterceptorBase) mustEmbedWorkflowInboundInterceptorBase() {}
 
 // WorkflowOutboundInterceptorBase is a default implementation of
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	commonpb "go.temporal.io/api/common/v1"
+
+	"go.temporal.io/sdk/converter"
 )
 
 type headerKey struct{}
@@ -114,3 +116,27 @@ func workflowHeaderPropagated(ctx Context, ctxProps []ContextPropagator) (*commo
 	}
 	return header, nil
 }
+
+// withHeaderValue returns a copy of the header with the value set for the key, encoded with the default data converter.
+func withHeaderValue(header *commonpb.Header, key string, value interface{}) (*commonpb.Header, error) {
+	payload, err := converter.GetDefaultDataConverter().ToPayload(value)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]*commonpb.Payload, len(header.GetFields())+1)
+	for k, v := range header.GetFields() {
+		fields[k] = v
+	}
+	fields[key] = payload
+	return &commonpb.Header{Fields: fields}, nil
+}
+
+// getHeaderValue decodes the value set for the key in the header into valuePtr, returning false if it is not set or
+// cannot be decoded.
+func getHeaderValue(header *commonpb.Header, key string, valuePtr interface{}) bool {
+	payload, ok := header.GetFields()[key]
+	if !ok {
+		return false
+	}
+	return converter.GetDefaultDataConverter().FromPayload(payload, valuePtr) == nil
+}
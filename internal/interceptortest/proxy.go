@@ -212,6 +212,10 @@ func (p *proxyActivityOutbound) RecordHeartbeat(ctx context.Context, details ...
 	p.invoke(ctx, details)
 }
 
+func (p *proxyActivityOutbound) RecordHeartbeatImmediate(ctx context.Context, details ...interface{}) {
+	p.invoke(ctx, details)
+}
+
 func (p *proxyActivityOutbound) HasHeartbeatDetails(ctx context.Context) (ret bool) {
 	ret, _ = p.invoke(ctx)[0].Interface().(bool)
 	return
@@ -227,6 +231,13 @@ func (p *proxyActivityOutbound) GetWorkerStopChannel(ctx context.Context) (ret <
 	return
 }
 
+func (p *proxyActivityOutbound) GetWorkerStopInfo(ctx context.Context) (info activity.WorkerStopInfo, ok bool) {
+	vals := p.invoke(ctx)
+	info, _ = vals[0].Interface().(activity.WorkerStopInfo)
+	ok, _ = vals[1].Interface().(bool)
+	return
+}
+
 type proxyWorkflowInbound struct {
 	interceptor.WorkflowInboundInterceptorBase
 	*nextProxy
@@ -265,6 +276,10 @@ func (p *proxyWorkflowInbound) HandleQuery(
 	return
 }
 
+func (p *proxyWorkflowInbound) HandleReplayedEvent(ctx workflow.Context, in *interceptor.HandleReplayedEventInput) {
+	p.invoke(ctx, in)
+}
+
 type proxyWorkflowOutbound struct {
 	interceptor.WorkflowOutboundInterceptorBase
 	*nextProxy
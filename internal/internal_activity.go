@@ -51,6 +51,11 @@ type (
 		VersioningIntent       VersioningIntent
 		Summary                string
 		Priority               *commonpb.Priority
+		IdempotencyKey         string
+		RetryOverrides         []RetryOverride
+		Progress               *ActivityProgressOptions
+		// Overrides the data converter of the workflow context, see ActivityOptions.DataConverter
+		dataConverter converter.DataConverter
 	}
 
 	// ExecuteLocalActivityOptions options for executing a local activity
@@ -58,6 +63,11 @@ type (
 		ScheduleToCloseTimeout time.Duration
 		StartToCloseTimeout    time.Duration
 		RetryPolicy            *RetryPolicy
+		LocalRetryThreshold    time.Duration
+		RetryJitter            RetryJitter
+		RetryOverrides         []RetryOverride
+		// Overrides the data converter of the workflow context, see LocalActivityOptions.DataConverter
+		dataConverter converter.DataConverter
 	}
 
 	// ExecuteActivityParams parameters for executing an activity
@@ -114,6 +124,7 @@ type (
 		heartbeatTimeout   time.Duration
 		deadline           time.Time
 		scheduledTime      time.Time
+		attemptTime        time.Time
 		startedTime        time.Time
 		taskQueue          string
 		dataConverter      converter.DataConverter
@@ -122,9 +133,11 @@ type (
 		workflowType       *WorkflowType
 		workflowNamespace  string
 		workerStopChannel  <-chan struct{}
+		workerStopState    *workerStopState
 		contextPropagators []ContextPropagator
 		client             *WorkflowClient
 		priority           *commonpb.Priority
+		progressReporter   *activityProgressReporter
 	}
 
 	// context.WithValue need this type instead of basic type string to avoid lint error
@@ -137,6 +150,8 @@ const (
 	localActivityOptionsContextKey   contextKey = "localActivityOptions"
 	activityInterceptorContextKey    contextKey = "activityInterceptor"
 	activityEnvInterceptorContextKey contextKey = "activityEnvInterceptor"
+	// Set by the test environments to the function returning the time of their clock, see GetActivityNow
+	activityClockContextKey contextKey = "activityClock"
 )
 
 func (i ActivityID) String() string {
@@ -197,6 +212,9 @@ func getValidatedLocalActivityOptions(ctx Context) (*ExecuteLocalActivityOptions
 	if p.ScheduleToCloseTimeout == 0 && p.StartToCloseTimeout == 0 {
 		return nil, errors.New("at least one of ScheduleToCloseTimeout and StartToCloseTimeout is required")
 	}
+	if p.LocalRetryThreshold < 0 {
+		return nil, errors.New("negative LocalRetryThreshold")
+	}
 	if p.ScheduleToCloseTimeout == 0 {
 		p.ScheduleToCloseTimeout = p.StartToCloseTimeout
 	}
@@ -362,6 +380,8 @@ func (a *activityEnvironmentInterceptor) GetInfo(ctx context.Context) ActivityIn
 		WorkflowNamespace: a.env.workflowNamespace,
 		IsLocalActivity:   a.env.isLocalActivity,
 		Priority:          convertFromPBPriority(a.env.priority),
+
+		CurrentAttemptScheduledTime: a.env.attemptTime,
 	}
 }
 
@@ -374,6 +394,14 @@ func (a *activityEnvironmentInterceptor) GetMetricsHandler(ctx context.Context)
 }
 
 func (a *activityEnvironmentInterceptor) RecordHeartbeat(ctx context.Context, details ...interface{}) {
+	a.recordHeartbeat(ctx, details, false)
+}
+
+func (a *activityEnvironmentInterceptor) RecordHeartbeatImmediate(ctx context.Context, details ...interface{}) {
+	a.recordHeartbeat(ctx, details, true)
+}
+
+func (a *activityEnvironmentInterceptor) recordHeartbeat(ctx context.Context, details []interface{}, skipBatching bool) {
 	if a.env.isLocalActivity {
 		// no-op for local activity
 		return
@@ -389,7 +417,7 @@ func (a *activityEnvironmentInterceptor) RecordHeartbeat(ctx context.Context, de
 	}
 
 	// Heartbeat error is logged inside ServiceInvoker.internalHeartBeat
-	_ = a.env.serviceInvoker.Heartbeat(ctx, data, false)
+	_ = a.env.serviceInvoker.Heartbeat(ctx, data, skipBatching)
 }
 
 func (a *activityEnvironmentInterceptor) HasHeartbeatDetails(ctx context.Context) bool {
@@ -408,6 +436,10 @@ func (a *activityEnvironmentInterceptor) GetWorkerStopChannel(ctx context.Contex
 	return a.env.workerStopChannel
 }
 
+func (a *activityEnvironmentInterceptor) GetWorkerStopInfo(ctx context.Context) (WorkerStopInfo, bool) {
+	return a.env.workerStopState.get()
+}
+
 func (a *activityEnvironmentInterceptor) GetClient(ctx context.Context) Client {
 	return a.env.client
 }
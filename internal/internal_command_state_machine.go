@@ -1140,7 +1140,7 @@ func (h *commandsHelper) handleActivityTaskClosed(activityID string, scheduledEv
 	return command
 }
 
-func (h *commandsHelper) handleActivityTaskScheduled(activityID string, scheduledEventID int64) {
+func (h *commandsHelper) handleActivityTaskScheduled(activityID string, scheduledEventID int64) commandStateMachine {
 	if _, ok := h.scheduledEventIDToActivityID[scheduledEventID]; !ok {
 		panicMsg := fmt.Sprintf("[TMPRL1100] lookup failed for scheduledEventID to activityID: scheduleEventID: %v, activityID: %v",
 			scheduledEventID, activityID)
@@ -1149,6 +1149,16 @@ func (h *commandsHelper) handleActivityTaskScheduled(activityID string, schedule
 
 	command := h.getCommand(makeCommandID(commandTypeActivity, activityID))
 	command.handleInitiatedEvent()
+	return command
+}
+
+// getActivityCommand returns the command of the activity scheduled by the event, nil if the activity is closed.
+func (h *commandsHelper) getActivityCommand(scheduledEventID int64) commandStateMachine {
+	activityID, ok := h.scheduledEventIDToActivityID[scheduledEventID]
+	if !ok {
+		return nil
+	}
+	return h.getCommand(makeCommandID(commandTypeActivity, activityID))
 }
 
 func (h *commandsHelper) handleActivityTaskCancelRequested(scheduledEventID int64) {
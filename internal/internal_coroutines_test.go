@@ -1428,6 +1428,82 @@ func TestFutureFail(t *testing.T) {
 	require.EqualValues(t, expected, history)
 }
 
+func TestCancellableFuture(t *testing.T) {
+	var history []string
+	var failed Future
+	var failedSettable Settable
+	var cancel CancelFunc
+	d := createNewDispatcher(func(ctx Context) {
+		canceledCtx, cancelFunc := WithCancel(ctx)
+		cancel = cancelFunc
+		failed, failedSettable = NewCancellableFuture(canceledCtx, FutureCancellationFail)
+		ignored, ignoredSettable := NewCancellableFuture(canceledCtx, FutureCancellationIgnore)
+		set, setSettable := NewCancellableFuture(canceledCtx, FutureCancellationFail)
+		setSettable.SetValue("value1")
+
+		var v string
+		err := failed.Get(ctx, &v)
+		require.True(t, IsCanceledError(err), err)
+		history = append(history, "failed-canceled")
+		require.False(t, ignored.IsReady())
+
+		// Settings after the future is ready are ignored
+		failedSettable.SetValue("value2")
+		require.True(t, IsCanceledError(failed.Get(ctx, &v)))
+		setSettable.SetError(errors.New("error"))
+		require.NoError(t, set.Get(ctx, &v))
+		require.Equal(t, "value1", v)
+
+		ignoredSettable.SetValue("value3")
+		require.NoError(t, ignored.Get(ctx, &v))
+		require.Equal(t, "value3", v)
+		require.Panics(t, func() { ignoredSettable.SetValue("value4") })
+		history = append(history, "root-end")
+	})
+	defer d.Close()
+	requireNoExecuteErr(t, d.ExecuteUntilAllBlocked(defaultDeadlockDetectionTimeout))
+	require.False(t, d.IsDone())
+	require.False(t, failed.IsReady())
+
+	history = append(history, "cancel")
+	cancel()
+	requireNoExecuteErr(t, d.ExecuteUntilAllBlocked(defaultDeadlockDetectionTimeout))
+	require.True(t, d.IsDone())
+	require.Equal(t, []string{"cancel", "failed-canceled", "root-end"}, history)
+}
+
+// wrappedDoneContext is a context whose done channel is not implemented by the SDK.
+type wrappedDoneContext struct {
+	Context
+}
+
+type wrappedChannel struct {
+	Channel
+}
+
+func (c wrappedDoneContext) Done() Channel {
+	return wrappedChannel{c.Context.Done()}
+}
+
+func TestCancellableFuture_WrappedContext(t *testing.T) {
+	var failed Future
+	var cancel CancelFunc
+	d := createNewDispatcher(func(ctx Context) {
+		canceledCtx, cancelFunc := WithCancel(ctx)
+		cancel = cancelFunc
+		failed, _ = NewCancellableFuture(wrappedDoneContext{canceledCtx}, FutureCancellationFail)
+	})
+	defer d.Close()
+	requireNoExecuteErr(t, d.ExecuteUntilAllBlocked(defaultDeadlockDetectionTimeout))
+	require.False(t, failed.IsReady())
+
+	cancel()
+	requireNoExecuteErr(t, d.ExecuteUntilAllBlocked(defaultDeadlockDetectionTimeout))
+	require.True(t, failed.IsReady())
+	_, err := failed.(*cancellableFutureImpl).GetValueAndError()
+	require.True(t, IsCanceledError(err), err)
+}
+
 func TestFutureSet(t *testing.T) {
 	var history []string
 	var f1, f2 Future
@@ -3,6 +3,7 @@ package internal
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"go.temporal.io/api/workflowservice/v1"
 )
@@ -15,6 +16,9 @@ type eagerActivityExecutor struct {
 	activityWorker eagerWorker
 	heldSlotCount  int
 	countLock      sync.Mutex
+	// taskQueueRateLimited is set when a task queue rate limit is set on a running worker, since the server does not
+	// rate limit eager activities.
+	taskQueueRateLimited atomic.Bool
 }
 
 type eagerActivityExecutorOptions struct {
@@ -47,6 +51,7 @@ func (e *eagerActivityExecutor) applyToRequest(
 			// explicitly disabled
 			eagerDisallowed := e == nil ||
 				e.disabled ||
+				e.taskQueueRateLimited.Load() ||
 				!attrs.RequestEagerExecution ||
 				e.activityWorker == nil ||
 				e.taskQueue != attrs.TaskQueue.GetName() ||
@@ -6,6 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -58,6 +61,8 @@ type (
 		waitForCancelRequest bool
 		handled              bool
 		activityType         ActivityType
+		scheduledTime        time.Time
+		startedTime          time.Time
 	}
 
 	scheduledNexusOperation struct {
@@ -139,10 +144,13 @@ type (
 		signalHandler   func(name string, input *commonpb.Payloads, header *commonpb.Header) error // A signal handler to be invoked on a signal event
 		queryHandler    func(queryType string, queryArgs *commonpb.Payloads, header *commonpb.Header) (*commonpb.Payloads, error)
 		updateHandler   func(name string, id string, args *commonpb.Payloads, header *commonpb.Header, callbacks UpdateCallbacks)
+		// A handler to be invoked after an event is replayed, nil if not registered
+		replayedEventHandler func(event *historypb.HistoryEvent)
 
 		logger                log.Logger
-		isReplay              bool // flag to indicate if workflow is in replay mode
-		enableLoggingInReplay bool // flag to indicate if workflow should enable logging in replay mode
+		isReplay              bool             // flag to indicate if workflow is in replay mode
+		enableLoggingInReplay bool             // flag to indicate if workflow should enable logging in replay mode
+		logSampler            *ilog.LogSampler // nil if workflow logs are not sampled
 
 		metricsHandler           metrics.Handler
 		registry                 *registry
@@ -150,6 +158,7 @@ type (
 		failureConverter         converter.FailureConverter
 		contextPropagators       []ContextPropagator
 		deadlockDetectionTimeout time.Duration
+		payloadSizeLimits        payloadSizeLimits
 		sdkFlags                 *sdkFlags
 		sdkVersionUpdated        bool
 		sdkVersion               string
@@ -161,6 +170,13 @@ type (
 		bufferedUpdateRequests map[string][]func()
 
 		protocols *protocol.Registry
+
+		// Not replay aware, the versions returned by GetVersion are also reported during replay
+		versionMetricsHandler metrics.Handler
+		// Nil unless the workflow is replayed by a WorkflowReplayer
+		versionUsage *versionUsageRecorder
+		// Change IDs whose version was reported
+		reportedChangeIDs map[string]struct{}
 	}
 
 	localActivityTask struct {
@@ -178,6 +194,7 @@ type (
 		retryPolicy     *RetryPolicy
 		expireTime      time.Time
 		scheduledTime   time.Time // Time the activity was scheduled initially.
+		attemptTime     time.Time // Time the current attempt was scheduled.
 		header          *commonpb.Header
 	}
 
@@ -206,6 +223,8 @@ func newWorkflowExecutionEventHandler(
 	completeHandler completionHandler,
 	logger log.Logger,
 	enableLoggingInReplay bool,
+	logSampling WorkflowLogSamplingOptions,
+	errorLogStackTraces bool,
 	metricsHandler metrics.Handler,
 	registry *registry,
 	dataConverter converter.DataConverter,
@@ -213,6 +232,8 @@ func newWorkflowExecutionEventHandler(
 	contextPropagators []ContextPropagator,
 	deadlockDetectionTimeout time.Duration,
 	capabilities *workflowservice.GetSystemInfoResponse_Capabilities,
+	payloadSizeLimits payloadSizeLimits,
+	versionUsage *versionUsageRecorder,
 ) workflowExecutionEventHandler {
 	context := &workflowEnvironmentImpl{
 		workflowInfo:                 workflowInfo,
@@ -220,6 +241,8 @@ func newWorkflowExecutionEventHandler(
 		sideEffectResult:             make(map[int64]*commonpb.Payloads),
 		mutableSideEffect:            make(map[string]map[int]*commonpb.Payloads),
 		changeVersions:               make(map[string]Version),
+		reportedChangeIDs:            make(map[string]struct{}),
+		versionUsage:                 versionUsage,
 		pendingLaTasks:               make(map[string]*localActivityTask),
 		unstartedLaTasks:             make(map[string]struct{}),
 		openSessions:                 make(map[string]*SessionInfo),
@@ -230,25 +253,41 @@ func newWorkflowExecutionEventHandler(
 		failureConverter:             failureConverter,
 		contextPropagators:           contextPropagators,
 		deadlockDetectionTimeout:     deadlockDetectionTimeout,
+		payloadSizeLimits:            payloadSizeLimits,
 		protocols:                    protocol.NewRegistry(),
 		mutableSideEffectCallCounter: make(map[string]int),
 		sdkFlags:                     newSDKFlags(capabilities),
 		bufferedUpdateRequests:       make(map[string][]func()),
 	}
-	// Attempt to skip 1 log level to remove the ReplayLogger from the stack.
-	context.logger = log.Skip(ilog.NewReplayLogger(
-		log.With(logger,
-			tagWorkflowType, workflowInfo.WorkflowType.Name,
-			tagWorkflowID, workflowInfo.WorkflowExecution.ID,
-			tagRunID, workflowInfo.WorkflowExecution.RunID,
-			tagAttempt, workflowInfo.Attempt,
-		),
-		&context.isReplay,
-		&context.enableLoggingInReplay), 1)
+	logger = log.With(logger,
+		tagWorkflowType, workflowInfo.WorkflowType.Name,
+		tagWorkflowID, workflowInfo.WorkflowExecution.ID,
+		tagRunID, workflowInfo.WorkflowExecution.RunID,
+		tagAttempt, workflowInfo.Attempt,
+	)
+	// Attempt to skip 1 log level to remove the ReplayLogger from the stack, and 1 more for each of the
+	// StackTraceLogger and SamplingLogger.
+	skip := 1
+	if errorLogStackTraces {
+		logger = ilog.NewStackTraceLogger(logger, func() []interface{} {
+			return []interface{}{
+				tagTaskQueue, workflowInfo.TaskQueueName,
+				tagTaskStartedEventID, workflowInfo.currentHistoryLength,
+			}
+		})
+		skip++
+	}
+	if logSampling.MaxLogsPerWorkflowTask > 0 || logSampling.DebugSampleInterval > 1 {
+		context.logSampler = ilog.NewLogSampler(logSampling.MaxLogsPerWorkflowTask, logSampling.DebugSampleInterval)
+		logger = ilog.NewSamplingLogger(logger, context.logSampler)
+		skip++
+	}
+	context.logger = log.Skip(ilog.NewReplayLogger(logger, &context.isReplay, &context.enableLoggingInReplay), skip)
 
 	if metricsHandler != nil {
 		context.metricsHandler = metrics.NewReplayAwareHandler(&context.isReplay, metricsHandler).
 			WithTags(metrics.WorkflowTags(workflowInfo.WorkflowType.Name))
+		context.versionMetricsHandler = metricsHandler.WithTags(metrics.WorkflowTags(workflowInfo.WorkflowType.Name))
 	}
 
 	return &workflowExecutionEventHandlerImpl{context, nil}
@@ -537,6 +576,7 @@ func (wc *workflowEnvironmentImpl) RegisterCancelHandler(handler func()) {
 func (wc *workflowEnvironmentImpl) ExecuteChildWorkflow(
 	params ExecuteWorkflowParams, callback ResultHandler, startedHandler func(r WorkflowExecution, e error),
 ) {
+	wc.checkPayloadSize("child workflow", params.WorkflowType.Name, params.Input)
 	if params.WorkflowID == "" {
 		params.WorkflowID = wc.workflowInfo.currentRunID + "_" + wc.GenerateSequenceID()
 	}
@@ -681,6 +721,10 @@ func (wc *workflowEnvironmentImpl) RegisterUpdateHandler(
 	wc.updateHandler = handler
 }
 
+func (wc *workflowEnvironmentImpl) RegisterReplayedEventHandler(handler func(event *historypb.HistoryEvent)) {
+	wc.replayedEventHandler = handler
+}
+
 func (wc *workflowEnvironmentImpl) GetLogger() log.Logger {
 	return wc.logger
 }
@@ -720,6 +764,7 @@ func (wc *workflowEnvironmentImpl) CreateNewCommand(commandType enumspb.CommandT
 }
 
 func (wc *workflowEnvironmentImpl) ExecuteActivity(parameters ExecuteActivityParams, callback ResultHandler) ActivityID {
+	wc.checkPayloadSize("activity", parameters.ActivityType.Name, parameters.Input)
 	scheduleTaskAttr := &commandpb.ScheduleActivityTaskCommandAttributes{}
 	scheduleID := wc.GenerateSequence()
 	if parameters.ActivityID == "" {
@@ -788,6 +833,7 @@ func (wc *workflowEnvironmentImpl) ExecuteLocalActivity(params ExecuteLocalActiv
 }
 
 func newLocalActivityTask(params ExecuteLocalActivityParams, callback LocalActivityResultHandler, activityID string) *localActivityTask {
+	now := time.Now()
 	task := &localActivityTask{
 		activityID:    activityID,
 		params:        &params,
@@ -795,7 +841,8 @@ func newLocalActivityTask(params ExecuteLocalActivityParams, callback LocalActiv
 		retryPolicy:   params.RetryPolicy,
 		attempt:       params.Attempt,
 		header:        params.Header,
-		scheduledTime: time.Now(),
+		scheduledTime: now,
+		attemptTime:   now,
 	}
 
 	if params.ScheduleToCloseTimeout > 0 {
@@ -875,6 +922,7 @@ func validateVersion(changeID string, version, minSupported, maxSupported Versio
 func (wc *workflowEnvironmentImpl) GetVersion(changeID string, minSupported, maxSupported Version) Version {
 	if version, ok := wc.changeVersions[changeID]; ok {
 		validateVersion(changeID, version, minSupported, maxSupported)
+		wc.reportVersion(changeID, version, maxSupported)
 		return version
 	}
 
@@ -910,9 +958,24 @@ func (wc *workflowEnvironmentImpl) GetVersion(changeID string, minSupported, max
 
 	validateVersion(changeID, version, minSupported, maxSupported)
 	wc.changeVersions[changeID] = version
+	wc.reportVersion(changeID, version, maxSupported)
 	return version
 }
 
+// reportVersion reports the version returned for a change the first time GetVersion is called for it, so branches of
+// old versions still executed, including while replaying, can be found.
+func (wc *workflowEnvironmentImpl) reportVersion(changeID string, version, maxSupported Version) {
+	if _, ok := wc.reportedChangeIDs[changeID]; ok {
+		return
+	}
+	wc.reportedChangeIDs[changeID] = struct{}{}
+	if wc.versionMetricsHandler != nil {
+		wc.versionMetricsHandler.WithTags(metrics.GetVersionTags(changeID, strconv.Itoa(int(version)), wc.isReplay)).
+			Counter(metrics.WorkflowGetVersionCounter).Inc(1)
+	}
+	wc.versionUsage.record(changeID, version, maxSupported)
+}
+
 func createSearchAttributesForChangeVersion(changeID string, version Version, existingChangeVersions map[string]Version) map[string]interface{} {
 	return map[string]interface{}{
 		TemporalChangeVersion: getChangeVersions(changeID, version, existingChangeVersions),
@@ -958,6 +1021,7 @@ func (wc *workflowEnvironmentImpl) SideEffect(f func() (*commonpb.Payloads, erro
 			callback(result, err)
 			return
 		}
+		wc.checkPayloadSize("side effect", "", result)
 	}
 
 	wc.commandsHelper.recordSideEffectMarker(sideEffectID, result, wc.dataConverter)
@@ -1102,6 +1166,7 @@ func (wc *workflowEnvironmentImpl) recordMutableSideEffect(id string, callCountH
 	if err != nil {
 		panic(err)
 	}
+	wc.checkPayloadSize("mutable side effect", id, details)
 	wc.commandsHelper.recordMutableSideEffectMarker(id, callCountHint, details, wc.dataConverter)
 	if wc.mutableSideEffect[id] == nil {
 		wc.mutableSideEffect[id] = make(map[int]*commonpb.Payloads)
@@ -1110,6 +1175,14 @@ func (wc *workflowEnvironmentImpl) recordMutableSideEffect(id string, callCountH
 	return newEncodedValue(data, wc.GetDataConverter())
 }
 
+// checkPayloadSize checks the payloads of a new command against the payload size limits. Replayed commands are not
+// checked.
+func (wc *workflowEnvironmentImpl) checkPayloadSize(kind, name string, payloads *commonpb.Payloads) {
+	if !wc.isReplay {
+		wc.payloadSizeLimits.check(wc.logger, kind, name, payloads)
+	}
+}
+
 func (wc *workflowEnvironmentImpl) AddSession(sessionInfo *SessionInfo) {
 	wc.openSessions[sessionInfo.SessionID] = sessionInfo
 }
@@ -1201,6 +1274,9 @@ func (weh *workflowExecutionEventHandlerImpl) ProcessEvent(
 		weh.workflowInfo.currentHistorySize = int(event.GetWorkflowTaskStartedEventAttributes().GetHistorySizeBytes())
 		// Reset the counter on command helper used for generating ID for commands
 		weh.commandsHelper.setCurrentWorkflowTaskStartedEventID(event.GetEventId())
+		if weh.logSampler != nil {
+			weh.logSampler.Reset()
+		}
 		weh.workflowDefinition.OnWorkflowTaskStarted(weh.deadlockDetectionTimeout)
 
 	case enumspb.EVENT_TYPE_WORKFLOW_TASK_TIMED_OUT:
@@ -1214,11 +1290,14 @@ func (weh *workflowExecutionEventHandlerImpl) ProcessEvent(
 	case enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED:
 		// No Operation
 	case enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
-		weh.commandsHelper.handleActivityTaskScheduled(
+		command := weh.commandsHelper.handleActivityTaskScheduled(
 			event.GetActivityTaskScheduledEventAttributes().GetActivityId(), event.GetEventId())
+		if activity, ok := command.getData().(*scheduledActivity); ok {
+			activity.scheduledTime = event.GetEventTime().AsTime()
+		}
 
 	case enumspb.EVENT_TYPE_ACTIVITY_TASK_STARTED:
-		// No Operation
+		weh.handleActivityTaskStarted(event)
 
 	case enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
 		err = weh.handleActivityTaskCompleted(event)
@@ -1359,6 +1438,10 @@ func (weh *workflowExecutionEventHandlerImpl) ProcessEvent(
 		return err
 	}
 
+	if isReplay && weh.replayedEventHandler != nil {
+		weh.replayedEventHandler(event)
+	}
+
 	// When replaying histories to get stack trace or current state the last event might be not
 	// workflow task started. So always call OnWorkflowTaskStarted on the last event.
 	// Don't call for EventType_WorkflowTaskStarted as it was already called when handling it.
@@ -1401,8 +1484,8 @@ func (weh *workflowExecutionEventHandlerImpl) ProcessQuery(
 		return weh.encodeArg(weh.StackTrace())
 	case QueryTypeOpenSessions:
 		return weh.encodeArg(weh.getOpenSessions())
-	case QueryTypeWorkflowMetadata:
-		// We are intentionally not handling this here but rather in the
+	case QueryTypeWorkflowMetadata, QueryTypeHandlers:
+		// We are intentionally not handling these here but rather in the
 		// normal handler so it has access to the options/context as
 		// needed.
 		fallthrough
@@ -1455,6 +1538,16 @@ func (weh *workflowExecutionEventHandlerImpl) handleWorkflowExecutionStarted(
 	return nil
 }
 
+func (weh *workflowExecutionEventHandlerImpl) handleActivityTaskStarted(event *historypb.HistoryEvent) {
+	command := weh.commandsHelper.getActivityCommand(event.GetActivityTaskStartedEventAttributes().GetScheduledEventId())
+	if command == nil {
+		return
+	}
+	if activity, ok := command.getData().(*scheduledActivity); ok {
+		activity.startedTime = event.GetEventTime().AsTime()
+	}
+}
+
 func (weh *workflowExecutionEventHandlerImpl) handleActivityTaskCompleted(event *historypb.HistoryEvent) error {
 	activityID, scheduledEventID := weh.commandsHelper.getActivityAndScheduledEventIDs(event)
 	command := weh.commandsHelper.handleActivityTaskClosed(activityID, scheduledEventID)
@@ -1485,6 +1578,7 @@ func (weh *workflowExecutionEventHandlerImpl) handleActivityTaskFailed(event *hi
 		attributes.GetRetryState(),
 		weh.GetFailureConverter().FailureToError(attributes.GetFailure()),
 	)
+	activityTaskErr.setTimes(activity.scheduledTime, activity.startedTime, event.GetEventTime().AsTime())
 
 	activity.handle(nil, activityTaskErr)
 	return nil
@@ -1510,6 +1604,7 @@ func (weh *workflowExecutionEventHandlerImpl) handleActivityTaskTimedOut(event *
 		attributes.GetRetryState(),
 		timeoutError,
 	)
+	activityTaskErr.setTimes(activity.scheduledTime, activity.startedTime, event.GetEventTime().AsTime())
 
 	activity.handle(nil, activityTaskErr)
 	return nil
@@ -1538,6 +1633,7 @@ func (weh *workflowExecutionEventHandlerImpl) handleActivityTaskCanceled(event *
 			enumspb.RETRY_STATE_NON_RETRYABLE_FAILURE,
 			NewCanceledError(details),
 		)
+		activityTaskErr.setTimes(activity.scheduledTime, activity.startedTime, event.GetEventTime().AsTime())
 
 		activity.handle(nil, activityTaskErr)
 	}
@@ -2122,6 +2218,8 @@ func (weh *workflowExecutionEventHandlerImpl) handleSignalExternalWorkflowExecut
 		err = newUnknownExternalWorkflowExecutionError()
 	case enumspb.SIGNAL_EXTERNAL_WORKFLOW_EXECUTION_FAILED_CAUSE_NAMESPACE_NOT_FOUND:
 		err = &NamespaceNotFoundError{}
+	case enumspb.SIGNAL_EXTERNAL_WORKFLOW_EXECUTION_FAILED_CAUSE_SIGNAL_COUNT_LIMIT_EXCEEDED:
+		err = &SignalCountLimitExceededError{}
 	default:
 		err = fmt.Errorf("unable to signal external workflow for unknown cause: %v", attributes.GetCause())
 	}
@@ -2147,3 +2245,46 @@ func (weh *workflowExecutionEventHandlerImpl) protocolConstructorForMessage(
 	}
 	return nil, fmt.Errorf("unsupported protocol: %v", protoName)
 }
+
+// payloadSizeLimits are the sizes of the payloads of commands above which a warning is logged or the workflow task
+// fails, disabled if not positive.
+type payloadSizeLimits struct {
+	warnSize  int
+	errorSize int
+}
+
+// check logs a warning, or panics failing the workflow task, if the payloads of a command exceed the limits, with the
+// call site in the workflow.
+func (l payloadSizeLimits) check(logger log.Logger, kind, name string, payloads *commonpb.Payloads) {
+	if l.warnSize <= 0 && l.errorSize <= 0 {
+		return
+	}
+	size := proto.Size(payloads)
+	if l.errorSize > 0 && size > l.errorSize {
+		if name != "" {
+			kind += " " + name
+		}
+		panic(fmt.Errorf("%s payload size %d exceeds limit %d at %s", kind, size, l.errorSize, workflowCallSite()))
+	}
+	if l.warnSize > 0 && size > l.warnSize {
+		logger.Warn("Payload size exceeds warning threshold",
+			"Kind", kind, "Name", name, "Size", size, "Threshold", l.warnSize, "CallSite", workflowCallSite())
+	}
+}
+
+// workflowCallSite returns the file and line of the innermost caller outside the SDK, that is the workflow code
+// creating a command.
+func workflowCallSite() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		// Tests of the SDK are workflow code
+		if !strings.HasPrefix(frame.Function, "go.temporal.io/sdk/") || strings.HasSuffix(frame.File, "_test.go") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
@@ -14,7 +14,9 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/internal/common/metrics"
 	iconverter "go.temporal.io/sdk/internal/converter"
+	ilog "go.temporal.io/sdk/internal/log"
 	"go.temporal.io/sdk/internal/protocol"
 )
 
@@ -350,6 +352,37 @@ func Test_GetChangeVersion(t *testing.T) {
 	}
 }
 
+func Test_GetVersionReportsUsage(t *testing.T) {
+	t.Parallel()
+	handler := metrics.NewCapturingHandler()
+	env := &workflowEnvironmentImpl{
+		// Version of change-2 recorded by a marker
+		changeVersions:        map[string]Version{"change-2": 1},
+		reportedChangeIDs:     map[string]struct{}{},
+		isReplay:              true,
+		versionMetricsHandler: handler,
+		versionUsage:          newVersionUsageRecorder(),
+	}
+	require.Equal(t, DefaultVersion, env.GetVersion("change-1", DefaultVersion, 1))
+	require.Equal(t, DefaultVersion, env.GetVersion("change-1", DefaultVersion, 1))
+	require.Equal(t, Version(1), env.GetVersion("change-2", DefaultVersion, 2))
+
+	// Versions are reported once per change, even while replaying
+	counters := handler.Counters()
+	require.Len(t, counters, 2)
+	for _, counter := range counters {
+		require.Equal(t, metrics.WorkflowGetVersionCounter, counter.Name)
+		require.Equal(t, int64(1), counter.Value())
+		require.Equal(t, "true", counter.Tags[metrics.ReplayTagName])
+	}
+	report := env.versionUsage.report()
+	require.Equal(t, []ChangeVersionUsage{
+		{ChangeID: "change-1", Versions: map[Version]int{DefaultVersion: 1}, MaxSupported: 1},
+		{ChangeID: "change-2", Versions: map[Version]int{1: 1}, MaxSupported: 2},
+	}, report.Changes)
+	require.True(t, report.Changes[0].OldVersionsUsed())
+}
+
 func Test_GetChangeVersions(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -522,3 +555,36 @@ func TestUpdateEventsPanic(t *testing.T) {
 		}, false, false)
 	})
 }
+
+func TestCheckPayloadSize(t *testing.T) {
+	logger := ilog.NewMemoryLogger()
+	env := &workflowEnvironmentImpl{
+		logger:            logger,
+		payloadSizeLimits: payloadSizeLimits{warnSize: 100, errorSize: 1000},
+	}
+	payloads := func(size int) *commonpb.Payloads {
+		return &commonpb.Payloads{Payloads: []*commonpb.Payload{{Data: make([]byte, size)}}}
+	}
+
+	env.checkPayloadSize("activity", "SomeActivity", payloads(10))
+	require.Empty(t, logger.Lines())
+
+	env.checkPayloadSize("activity", "SomeActivity", payloads(200))
+	require.Len(t, logger.Lines(), 1)
+	require.Contains(t, logger.Lines()[0], "Payload size exceeds warning threshold")
+	require.Contains(t, logger.Lines()[0], "internal_event_handlers_test.go:")
+
+	func() {
+		defer func() {
+			err, _ := recover().(error)
+			require.ErrorContains(t, err, "activity SomeActivity payload size 2006 exceeds limit 1000 at ")
+			require.ErrorContains(t, err, "internal_event_handlers_test.go:")
+		}()
+		env.checkPayloadSize("activity", "SomeActivity", payloads(2000))
+	}()
+
+	// Replayed commands are not checked
+	env.isReplay = true
+	env.checkPayloadSize("activity", "SomeActivity", payloads(2000))
+	require.Len(t, logger.Lines(), 1)
+}
@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+
+	"go.temporal.io/sdk/log"
+)
+
+// logFieldEnricher derives additional logger fields for workflows and activities from the worker's
+// WorkerLogFieldsOptions.
+type logFieldEnricher struct {
+	provider      LogFieldProvider
+	scheduledTime bool
+}
+
+func newLogFieldEnricher(options WorkerLogFieldsOptions) *logFieldEnricher {
+	if options.FieldProvider == nil && !options.EnableScheduledTime {
+		return nil
+	}
+	return &logFieldEnricher{provider: options.FieldProvider, scheduledTime: options.EnableScheduledTime}
+}
+
+// omittedLogFields returns the keys of the identifiers that must not be attached to logs of the worker.
+func omittedLogFields(options WorkerLogFieldsOptions) []string {
+	var omit []string
+	if options.DisableAttempt {
+		omit = append(omit, tagAttempt)
+	}
+	if options.DisableTaskQueue {
+		omit = append(omit, tagTaskQueue)
+	}
+	if options.DisableBuildID {
+		omit = append(omit, tagBuildID)
+	}
+	return omit
+}
+
+func (e *logFieldEnricher) workflowFields(workflowType, taskQueue string, header *commonpb.Header) []interface{} {
+	if e == nil || e.provider == nil {
+		return nil
+	}
+	return e.provider(&LogFieldProviderInput{
+		WorkflowType: workflowType,
+		TaskQueue:    taskQueue,
+		Header:       NewHeaderReader(header),
+	})
+}
+
+func (e *logFieldEnricher) activityFields(
+	workflowType string,
+	activityType string,
+	taskQueue string,
+	scheduledTime time.Time,
+	header *commonpb.Header,
+) []interface{} {
+	if e == nil {
+		return nil
+	}
+	var fields []interface{}
+	if e.scheduledTime {
+		fields = append(fields, tagScheduledTime, scheduledTime)
+	}
+	if e.provider != nil {
+		fields = append(fields, e.provider(&LogFieldProviderInput{
+			WorkflowType: workflowType,
+			ActivityType: activityType,
+			TaskQueue:    taskQueue,
+			Header:       NewHeaderReader(header),
+		})...)
+	}
+	return fields
+}
+
+// withLogFields returns the logger with the given fields, or the logger itself if there are none.
+func withLogFields(logger log.Logger, fields []interface{}) log.Logger {
+	if len(fields) == 0 {
+		return logger
+	}
+	return log.With(logger, fields...)
+}
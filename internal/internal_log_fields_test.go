@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+
+	"go.temporal.io/sdk/converter"
+	ilog "go.temporal.io/sdk/internal/log"
+)
+
+func TestLogFieldEnricher(t *testing.T) {
+	require.Nil(t, newLogFieldEnricher(WorkerLogFieldsOptions{DisableAttempt: true}))
+
+	enricher := newLogFieldEnricher(WorkerLogFieldsOptions{
+		EnableScheduledTime: true,
+		FieldProvider: func(input *LogFieldProviderInput) []interface{} {
+			fields := []interface{}{"TaskQueueCopy", input.TaskQueue}
+			if payload, ok := input.Header.Get("request-id"); ok {
+				var requestID string
+				require.NoError(t, converter.GetDefaultDataConverter().FromPayload(payload, &requestID))
+				fields = append(fields, "RequestID", requestID)
+			}
+			return fields
+		},
+	})
+
+	requestIDPayload, err := converter.GetDefaultDataConverter().ToPayload("request-1")
+	require.NoError(t, err)
+	header := &commonpb.Header{Fields: map[string]*commonpb.Payload{"request-id": requestIDPayload}}
+	require.Equal(t,
+		[]interface{}{"TaskQueueCopy", "tq", "RequestID", "request-1"},
+		enricher.workflowFields("wf", "tq", header))
+
+	// The scheduled time is only attached to activities
+	scheduledTime := time.Unix(1000, 0)
+	require.Equal(t,
+		[]interface{}{tagScheduledTime, scheduledTime, "TaskQueueCopy", "tq"},
+		enricher.activityFields("wf", "act", "tq", scheduledTime, nil))
+}
+
+func TestWorkerLogFields(t *testing.T) {
+	logger := ilog.NewMemoryLogger()
+	aggWorker := NewAggregatedWorker(&WorkflowClient{namespace: "ns", logger: logger}, "worker-options-tq", WorkerOptions{
+		Identity:  "worker-identity",
+		BuildID:   "build-id",
+		LogFields: WorkerLogFieldsOptions{DisableAttempt: true, DisableBuildID: true},
+	})
+	aggWorker.executionParams.Logger.Info("test", tagAttempt, 1)
+	lines := logger.Lines()
+	require.Equal(t, "INFO  test Namespace ns TaskQueue worker-options-tq WorkerID worker-identity\n", lines[len(lines)-1])
+}
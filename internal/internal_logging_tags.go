@@ -22,6 +22,7 @@ const (
 	tagError                        = "Error"
 	tagStackTrace                   = "StackTrace"
 	tagAttempt                      = "Attempt"
+	tagScheduledTime                = "ScheduledTime"
 	tagTaskFirstEventID             = "TaskFirstEventID"
 	tagTaskStartedEventID           = "TaskStartedEventID"
 	tagPreviousStartedEventID       = "PreviousStartedEventID"
@@ -33,4 +34,5 @@ const (
 	tagPanicStack                   = "PanicStack"
 	tagUpdateID                     = "UpdateID"
 	tagUpdateName                   = "UpdateName"
+	tagRejectionCode                = "RejectionCode"
 )
@@ -0,0 +1,128 @@
+package internal
+
+import (
+	"sync"
+
+	commonpb "go.temporal.io/api/common/v1"
+
+	"go.temporal.io/sdk/internal/common/metrics"
+	"go.temporal.io/sdk/log"
+)
+
+const (
+	defaultMaxMetricsTagValues = 100
+	// Value used for tag values beyond the cardinality budget of their key
+	metricsTagValueOther = "_other_"
+)
+
+// metricsTagEnricher derives additional metric tags for workflows and activities from a user provided
+// MetricsTagProvider, keeping the number of distinct values per tag key within a budget. The tag keys are fixed by
+// the first non-empty result of the provider, so that all metrics have the same keys.
+type metricsTagEnricher struct {
+	provider  MetricsTagProvider
+	maxValues int
+	logger    log.Logger
+
+	lock   sync.Mutex
+	values map[string]map[string]struct{}
+	// Nil until the provider first returns tags
+	keys map[string]struct{}
+	// Whether a result with keys outside of keys was logged
+	droppedKeysLogged bool
+}
+
+func newMetricsTagEnricher(provider MetricsTagProvider, maxValues int, logger log.Logger) *metricsTagEnricher {
+	if provider == nil {
+		return nil
+	}
+	if maxValues <= 0 {
+		maxValues = defaultMaxMetricsTagValues
+	}
+	return &metricsTagEnricher{
+		provider:  provider,
+		maxValues: maxValues,
+		logger:    logger,
+		values:    map[string]map[string]struct{}{},
+	}
+}
+
+func (e *metricsTagEnricher) workflowTags(
+	workflowType string,
+	taskQueue string,
+	header *commonpb.Header,
+	searchAttributes *commonpb.SearchAttributes,
+) map[string]string {
+	if e == nil {
+		return nil
+	}
+	return e.tags(&MetricsTagProviderInput{
+		WorkflowType:     workflowType,
+		TaskQueue:        taskQueue,
+		Header:           NewHeaderReader(header),
+		SearchAttributes: convertToTypedSearchAttributes(e.logger, searchAttributes.GetIndexedFields()),
+	})
+}
+
+func (e *metricsTagEnricher) activityTags(workflowType, activityType, taskQueue string, header *commonpb.Header) map[string]string {
+	if e == nil {
+		return nil
+	}
+	return e.tags(&MetricsTagProviderInput{
+		WorkflowType: workflowType,
+		ActivityType: activityType,
+		TaskQueue:    taskQueue,
+		Header:       NewHeaderReader(header),
+	})
+}
+
+func (e *metricsTagEnricher) tags(input *MetricsTagProviderInput) map[string]string {
+	tags := e.provider(input)
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.keys == nil {
+		if len(tags) == 0 {
+			return nil
+		}
+		e.keys = make(map[string]struct{}, len(tags))
+		for key := range tags {
+			e.keys[key] = struct{}{}
+		}
+	}
+	for key := range tags {
+		if _, ok := e.keys[key]; !ok && !e.droppedKeysLogged {
+			e.logger.Warn("Dropping metrics tags with keys not returned by the first MetricsTagProvider result",
+				"TagKey", key)
+			e.droppedKeysLogged = true
+		}
+	}
+	budgeted := make(map[string]string, len(e.keys))
+	for key := range e.keys {
+		value, ok := tags[key]
+		if !ok {
+			budgeted[key] = metrics.NoneTagValue
+			continue
+		}
+		values := e.values[key]
+		if values == nil {
+			values = map[string]struct{}{}
+			e.values[key] = values
+		}
+		if _, ok := values[value]; !ok {
+			if len(values) >= e.maxValues {
+				value = metricsTagValueOther
+			} else {
+				values[value] = struct{}{}
+			}
+		}
+		budgeted[key] = value
+	}
+	return budgeted
+}
+
+// withMetricsTags returns the handler with the given tags, or the handler itself if there are none.
+func withMetricsTags(handler metrics.Handler, tags map[string]string) metrics.Handler {
+	if len(tags) == 0 {
+		return handler
+	}
+	return handler.WithTags(tags)
+}
@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/internal/common/metrics"
+	ilog "go.temporal.io/sdk/internal/log"
+)
+
+func TestMetricsTagEnricher(t *testing.T) {
+	require.Nil(t, newMetricsTagEnricher(nil, 0, ilog.NewNopLogger()))
+
+	tenantKey := NewSearchAttributeKeyKeyword("Tenant")
+	enricher := newMetricsTagEnricher(func(input *MetricsTagProviderInput) map[string]string {
+		tags := map[string]string{"task_queue_copy": input.TaskQueue}
+		if input.ActivityType != "" {
+			if payload, ok := input.Header.Get("tenant"); ok {
+				var tenant string
+				require.NoError(t, converter.GetDefaultDataConverter().FromPayload(payload, &tenant))
+				tags["tenant"] = tenant
+			}
+		} else if tenant, ok := input.SearchAttributes.GetKeyword(tenantKey); ok {
+			tags["tenant"] = tenant
+		}
+		if input.WorkflowType == "new-key" {
+			tags["new_key"] = "value"
+		}
+		return tags
+	}, 2, ilog.NewNopLogger())
+
+	searchAttributes, err := serializeTypedSearchAttributes(map[SearchAttributeKey]interface{}{tenantKey: "tenant-a"})
+	require.NoError(t, err)
+	require.Equal(t,
+		map[string]string{"task_queue_copy": "tq", "tenant": "tenant-a"},
+		enricher.workflowTags("wf", "tq", nil, searchAttributes))
+
+	tenantPayload, err := converter.GetDefaultDataConverter().ToPayload("tenant-b")
+	require.NoError(t, err)
+	header := &commonpb.Header{Fields: map[string]*commonpb.Payload{"tenant": tenantPayload}}
+	require.Equal(t,
+		map[string]string{"task_queue_copy": "tq", "tenant": "tenant-b"},
+		enricher.activityTags("wf", "act", "tq", header))
+
+	// Values beyond the budget of a key are replaced, known values are kept
+	tenantPayload, err = converter.GetDefaultDataConverter().ToPayload("tenant-c")
+	require.NoError(t, err)
+	header.Fields["tenant"] = tenantPayload
+	require.Equal(t,
+		map[string]string{"task_queue_copy": "tq", "tenant": metricsTagValueOther},
+		enricher.activityTags("wf", "act", "tq", header))
+	require.Equal(t,
+		map[string]string{"task_queue_copy": "tq", "tenant": "tenant-a"},
+		enricher.workflowTags("wf", "tq", nil, searchAttributes))
+
+	// The key set is fixed by the first result, missing keys are tagged with "none" and new keys are dropped
+	require.Equal(t,
+		map[string]string{"task_queue_copy": "tq", "tenant": metrics.NoneTagValue},
+		enricher.activityTags("wf", "act", "tq", nil))
+	require.Equal(t,
+		map[string]string{"task_queue_copy": "tq", "tenant": "tenant-a"},
+		enricher.workflowTags("new-key", "tq", nil, searchAttributes))
+}
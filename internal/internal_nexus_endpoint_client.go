@@ -0,0 +1,251 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	commonpb "go.temporal.io/api/common/v1"
+	nexuspb "go.temporal.io/api/nexus/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+var nexusEndpointNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+type (
+	// nexusEndpointClient is the client for managing Nexus endpoints.
+	nexusEndpointClient struct {
+		workflowClient  *WorkflowClient
+		operatorService operatorservice.OperatorServiceClient
+	}
+
+	// nexusEndpointListIteratorImpl is the implementation of [NexusEndpointListIterator].
+	// Adapted from [scheduleListIteratorImpl].
+	nexusEndpointListIteratorImpl struct {
+		// nextEndpointIndex - Local index to cached endpoints.
+		nextEndpointIndex int
+
+		// err - Error from getting the last page of endpoints.
+		err error
+
+		// response - Last page of endpoints from server.
+		response *operatorservice.ListNexusEndpointsResponse
+
+		// paginate - Function to get the next page of endpoints from server.
+		paginate func(nexttoken []byte) (*operatorservice.ListNexusEndpointsResponse, error)
+	}
+)
+
+func (iter *nexusEndpointListIteratorImpl) HasNext() bool {
+	if iter.err == nil &&
+		(iter.response == nil ||
+			(iter.nextEndpointIndex >= len(iter.response.Endpoints) && len(iter.response.NextPageToken) > 0)) {
+		iter.response, iter.err = iter.paginate(iter.response.GetNextPageToken())
+		iter.nextEndpointIndex = 0
+	}
+
+	return iter.nextEndpointIndex < len(iter.response.GetEndpoints()) || iter.err != nil
+}
+
+func (iter *nexusEndpointListIteratorImpl) Next() (*NexusEndpoint, error) {
+	if !iter.HasNext() {
+		panic("NexusEndpointListIterator Next() called without checking HasNext()")
+	} else if iter.err != nil {
+		return nil, iter.err
+	}
+	endpoint := iter.response.Endpoints[iter.nextEndpointIndex]
+	iter.nextEndpointIndex++
+	return nexusEndpointFromProto(endpoint)
+}
+
+func (nc *nexusEndpointClient) Create(ctx context.Context, options NexusEndpointCreateOptions) (*NexusEndpoint, error) {
+	if err := nc.workflowClient.ensureInitialized(ctx); err != nil {
+		return nil, err
+	}
+	spec, err := nc.specToProto(options.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	grpcCtx, cancel := newGRPCContext(ctx, defaultGrpcRetryParameters(ctx))
+	defer cancel()
+	resp, err := nc.operatorService.CreateNexusEndpoint(grpcCtx, &operatorservice.CreateNexusEndpointRequest{
+		Spec: spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nexusEndpointFromProto(resp.GetEndpoint())
+}
+
+func (nc *nexusEndpointClient) Get(ctx context.Context, id string) (*NexusEndpoint, error) {
+	if err := nc.workflowClient.ensureInitialized(ctx); err != nil {
+		return nil, err
+	}
+	if id == "" {
+		return nil, errors.New("missing Nexus endpoint ID argument")
+	}
+
+	grpcCtx, cancel := newGRPCContext(ctx, defaultGrpcRetryParameters(ctx))
+	defer cancel()
+	resp, err := nc.operatorService.GetNexusEndpoint(grpcCtx, &operatorservice.GetNexusEndpointRequest{
+		Id: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nexusEndpointFromProto(resp.GetEndpoint())
+}
+
+func (nc *nexusEndpointClient) Update(ctx context.Context, options NexusEndpointUpdateOptions) (*NexusEndpoint, error) {
+	if err := nc.workflowClient.ensureInitialized(ctx); err != nil {
+		return nil, err
+	}
+	if options.ID == "" {
+		return nil, errors.New("missing Nexus endpoint ID argument")
+	}
+	spec, err := nc.specToProto(options.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	grpcCtx, cancel := newGRPCContext(ctx, defaultGrpcRetryParameters(ctx))
+	defer cancel()
+	resp, err := nc.operatorService.UpdateNexusEndpoint(grpcCtx, &operatorservice.UpdateNexusEndpointRequest{
+		Id:      options.ID,
+		Version: options.Version,
+		Spec:    spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nexusEndpointFromProto(resp.GetEndpoint())
+}
+
+func (nc *nexusEndpointClient) Delete(ctx context.Context, options NexusEndpointDeleteOptions) error {
+	if err := nc.workflowClient.ensureInitialized(ctx); err != nil {
+		return err
+	}
+	if options.ID == "" {
+		return errors.New("missing Nexus endpoint ID argument")
+	}
+
+	grpcCtx, cancel := newGRPCContext(ctx, defaultGrpcRetryParameters(ctx))
+	defer cancel()
+	_, err := nc.operatorService.DeleteNexusEndpoint(grpcCtx, &operatorservice.DeleteNexusEndpointRequest{
+		Id:      options.ID,
+		Version: options.Version,
+	})
+	return err
+}
+
+func (nc *nexusEndpointClient) List(ctx context.Context, options NexusEndpointListOptions) (NexusEndpointListIterator, error) {
+	paginate := func(nextToken []byte) (*operatorservice.ListNexusEndpointsResponse, error) {
+		if err := nc.workflowClient.ensureInitialized(ctx); err != nil {
+			return nil, err
+		}
+		grpcCtx, cancel := newGRPCContext(ctx, defaultGrpcRetryParameters(ctx))
+		defer cancel()
+		request := &operatorservice.ListNexusEndpointsRequest{
+			PageSize:      int32(options.PageSize),
+			NextPageToken: nextToken,
+			Name:          options.Name,
+		}
+
+		return nc.operatorService.ListNexusEndpoints(grpcCtx, request)
+	}
+
+	return &nexusEndpointListIteratorImpl{
+		paginate: paginate,
+	}, nil
+}
+
+func (nc *nexusEndpointClient) specToProto(spec NexusEndpointSpec) (*nexuspb.EndpointSpec, error) {
+	if !nexusEndpointNameRegex.MatchString(spec.Name) {
+		return nil, fmt.Errorf("invalid Nexus endpoint name %q: must match %v", spec.Name, nexusEndpointNameRegex)
+	}
+	target := &nexuspb.EndpointTarget{}
+	switch {
+	case spec.TargetTaskQueue != "" && spec.ExternalURL != "":
+		return nil, errors.New("only one of TargetTaskQueue and ExternalURL can be set")
+	case spec.ExternalURL != "":
+		target.Variant = &nexuspb.EndpointTarget_External_{
+			External: &nexuspb.EndpointTarget_External{Url: spec.ExternalURL},
+		}
+	default:
+		if err := validateNexusTargetTaskQueue(spec.TargetTaskQueue); err != nil {
+			return nil, err
+		}
+		namespace := spec.TargetNamespace
+		if namespace == "" {
+			namespace = nc.workflowClient.namespace
+		}
+		target.Variant = &nexuspb.EndpointTarget_Worker_{
+			Worker: &nexuspb.EndpointTarget_Worker{Namespace: namespace, TaskQueue: spec.TargetTaskQueue},
+		}
+	}
+
+	// The description is always encoded as plain JSON, without the payload codecs of the client, so the server UI and
+	// CLI can display it
+	var description *commonpb.Payload
+	if spec.Description != "" {
+		var err error
+		if description, err = converter.GetDefaultDataConverter().ToPayload(spec.Description); err != nil {
+			return nil, fmt.Errorf("failed to encode Nexus endpoint description: %w", err)
+		}
+	}
+	return &nexuspb.EndpointSpec{
+		Name:        spec.Name,
+		Description: description,
+		Target:      target,
+	}, nil
+}
+
+// validateNexusTargetTaskQueue checks the task queue of a worker target with the same rules the server applies to
+// task queue names, so misconfigured endpoints fail before reaching the server.
+func validateNexusTargetTaskQueue(taskQueue string) error {
+	if taskQueue == "" {
+		return errors.New("missing Nexus endpoint target task queue")
+	}
+	if len(taskQueue) > maxIDLengthLimit {
+		return fmt.Errorf("Nexus endpoint target task queue exceeds length limit of %d", maxIDLengthLimit)
+	}
+	if !utf8.ValidString(taskQueue) {
+		return fmt.Errorf("Nexus endpoint target task queue %q is not valid UTF-8", taskQueue)
+	}
+	if strings.HasPrefix(taskQueue, reservedTaskQueuePrefix) {
+		return fmt.Errorf("Nexus endpoint target task queue %q cannot start with reserved prefix %q", taskQueue, reservedTaskQueuePrefix)
+	}
+	return nil
+}
+
+func nexusEndpointFromProto(endpoint *nexuspb.Endpoint) (*NexusEndpoint, error) {
+	spec := endpoint.GetSpec()
+	result := &NexusEndpoint{
+		ID:      endpoint.GetId(),
+		Version: endpoint.GetVersion(),
+		Spec: NexusEndpointSpec{
+			Name:            spec.GetName(),
+			TargetNamespace: spec.GetTarget().GetWorker().GetNamespace(),
+			TargetTaskQueue: spec.GetTarget().GetWorker().GetTaskQueue(),
+			ExternalURL:     spec.GetTarget().GetExternal().GetUrl(),
+		},
+		URLPrefix: endpoint.GetUrlPrefix(),
+	}
+	if endpoint.GetCreatedTime() != nil {
+		result.CreatedTime = endpoint.GetCreatedTime().AsTime()
+	}
+	if endpoint.GetLastModifiedTime() != nil {
+		result.LastModifiedTime = endpoint.GetLastModifiedTime().AsTime()
+	}
+	if spec.GetDescription() != nil {
+		if err := converter.GetDefaultDataConverter().FromPayload(spec.GetDescription(), &result.Spec.Description); err != nil {
+			return nil, fmt.Errorf("failed to decode Nexus endpoint description: %w", err)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	nexuspb "go.temporal.io/api/nexus/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/operatorservicemock/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/api/workflowservicemock/v1"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/grpc"
+)
+
+func newTestNexusEndpointClient(t *testing.T) (*nexusEndpointClient, *operatorservicemock.MockOperatorServiceClient) {
+	mockCtrl := gomock.NewController(t)
+	service := workflowservicemock.NewMockWorkflowServiceClient(mockCtrl)
+	service.EXPECT().GetSystemInfo(gomock.Any(), gomock.Any(), gomock.Any()).Return(&workflowservice.GetSystemInfoResponse{}, nil).AnyTimes()
+	operator := operatorservicemock.NewMockOperatorServiceClient(mockCtrl)
+	// Descriptions must not be encoded with the codecs of the client
+	dataConverter := converter.NewCodecDataConverter(converter.GetDefaultDataConverter(),
+		converter.NewZlibCodec(converter.ZlibCodecOptions{AlwaysEncode: true}))
+	client := NewServiceClient(service, nil, ClientOptions{DataConverter: dataConverter})
+	return &nexusEndpointClient{workflowClient: client, operatorService: operator}, operator
+}
+
+func TestNexusEndpointCreate(t *testing.T) {
+	client, operator := newTestNexusEndpointClient(t)
+	operator.EXPECT().CreateNexusEndpoint(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *operatorservice.CreateNexusEndpointRequest, _ ...grpc.CallOption) (*operatorservice.CreateNexusEndpointResponse, error) {
+			require.Equal(t, "my_endpoint", req.Spec.Name)
+			require.Equal(t, DefaultNamespace, req.Spec.Target.GetWorker().GetNamespace())
+			require.Equal(t, "my-task-queue", req.Spec.Target.GetWorker().GetTaskQueue())
+			require.Equal(t, `"Handles *my* operations"`, string(req.Spec.Description.GetData()))
+			return &operatorservice.CreateNexusEndpointResponse{
+				Endpoint: &nexuspb.Endpoint{Id: "endpoint-id", Version: 1, Spec: req.Spec, UrlPrefix: "/nexus/endpoints/endpoint-id"},
+			}, nil
+		})
+
+	endpoint, err := client.Create(context.Background(), NexusEndpointCreateOptions{
+		Spec: NexusEndpointSpec{
+			Name:            "my_endpoint",
+			Description:     "Handles *my* operations",
+			TargetTaskQueue: "my-task-queue",
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &NexusEndpoint{
+		ID:      "endpoint-id",
+		Version: 1,
+		Spec: NexusEndpointSpec{
+			Name:            "my_endpoint",
+			Description:     "Handles *my* operations",
+			TargetNamespace: DefaultNamespace,
+			TargetTaskQueue: "my-task-queue",
+		},
+		URLPrefix: "/nexus/endpoints/endpoint-id",
+	}, endpoint)
+}
+
+func TestNexusEndpointSpecValidation(t *testing.T) {
+	client, _ := newTestNexusEndpointClient(t)
+	for _, tc := range []struct {
+		name string
+		spec NexusEndpointSpec
+		err  string
+	}{
+		{"invalid name", NexusEndpointSpec{Name: "my-endpoint", TargetTaskQueue: "tq"}, "invalid Nexus endpoint name"},
+		{"no target", NexusEndpointSpec{Name: "e"}, "missing Nexus endpoint target task queue"},
+		{"both targets", NexusEndpointSpec{Name: "e", TargetTaskQueue: "tq", ExternalURL: "http://localhost"}, "only one of"},
+		{"reserved prefix", NexusEndpointSpec{Name: "e", TargetTaskQueue: reservedTaskQueuePrefix + "tq"}, "reserved prefix"},
+		{"too long", NexusEndpointSpec{Name: "e", TargetTaskQueue: strings.Repeat("a", maxIDLengthLimit+1)}, "length limit"},
+		{"invalid UTF-8", NexusEndpointSpec{Name: "e", TargetTaskQueue: "\xff"}, "not valid UTF-8"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.Create(context.Background(), NexusEndpointCreateOptions{Spec: tc.spec})
+			require.ErrorContains(t, err, tc.err)
+			_, err = client.Update(context.Background(), NexusEndpointUpdateOptions{ID: "endpoint-id", Spec: tc.spec})
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestNexusEndpointList(t *testing.T) {
+	client, operator := newTestNexusEndpointClient(t)
+	description, err := converter.GetDefaultDataConverter().ToPayload("external endpoint")
+	require.NoError(t, err)
+	operator.EXPECT().ListNexusEndpoints(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&operatorservice.ListNexusEndpointsResponse{
+			Endpoints:     []*nexuspb.Endpoint{{Id: "first", Spec: &nexuspb.EndpointSpec{Name: "first"}}},
+			NextPageToken: []byte("token"),
+		}, nil)
+	operator.EXPECT().ListNexusEndpoints(gomock.Any(), &operatorservice.ListNexusEndpointsRequest{PageSize: 1, NextPageToken: []byte("token")}, gomock.Any()).
+		Return(&operatorservice.ListNexusEndpointsResponse{
+			Endpoints: []*nexuspb.Endpoint{{Id: "second", Spec: &nexuspb.EndpointSpec{
+				Name:        "second",
+				Description: description,
+				Target: &nexuspb.EndpointTarget{Variant: &nexuspb.EndpointTarget_External_{
+					External: &nexuspb.EndpointTarget_External{Url: "http://localhost"},
+				}},
+			}}},
+		}, nil)
+
+	iter, err := client.List(context.Background(), NexusEndpointListOptions{PageSize: 1})
+	require.NoError(t, err)
+	var endpoints []*NexusEndpoint
+	for iter.HasNext() {
+		endpoint, err := iter.Next()
+		require.NoError(t, err)
+		endpoints = append(endpoints, endpoint)
+	}
+	require.Len(t, endpoints, 2)
+	require.Equal(t, "first", endpoints[0].ID)
+	require.Equal(t, "second", endpoints[1].ID)
+	require.Equal(t, "external endpoint", endpoints[1].Spec.Description)
+	require.Equal(t, "http://localhost", endpoints[1].Spec.ExternalURL)
+}
+
+func TestNexusEndpointDelete(t *testing.T) {
+	client, operator := newTestNexusEndpointClient(t)
+	operator.EXPECT().DeleteNexusEndpoint(gomock.Any(), &operatorservice.DeleteNexusEndpointRequest{Id: "endpoint-id", Version: 2}, gomock.Any()).
+		Return(&operatorservice.DeleteNexusEndpointResponse{}, nil)
+
+	require.NoError(t, client.Delete(context.Background(), NexusEndpointDeleteOptions{ID: "endpoint-id", Version: 2}))
+	require.ErrorContains(t, client.Delete(context.Background(), NexusEndpointDeleteOptions{}), "missing Nexus endpoint ID")
+}
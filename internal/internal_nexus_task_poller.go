@@ -43,6 +43,7 @@ func newNexusTaskPoller(
 			workerDeploymentVersion: params.WorkerDeploymentVersion,
 			deploymentSeriesName:    params.DeploymentSeriesName,
 			capabilities:            params.capabilities,
+			scheduleToStartSLO:      params.scheduleToStartSLO,
 		},
 		taskHandler:     taskHandler,
 		service:         service,
@@ -125,6 +126,11 @@ func (ntp *nexusTaskPoller) ProcessTask(task interface{}) error {
 	// the Nexus task queue.
 	scheduleToStartLatency := executionStartTime.Sub(response.GetRequest().GetScheduledTime().AsTime())
 	ntp.metricsHandler.WithTags(metrics.TaskQueueTags(ntp.taskQueueName)).Timer(metrics.NexusTaskScheduleToStartLatency).Record(scheduleToStartLatency)
+	ntp.scheduleToStartSLO.check(ScheduleToStartSLOViolation{
+		WorkerType: "NexusWorker",
+		TaskQueue:  ntp.taskQueueName,
+		Latency:    scheduleToStartLatency,
+	})
 
 	nctx, handlerErr := ntp.taskHandler.newNexusOperationContext(response)
 	if handlerErr != nil {
@@ -58,6 +58,9 @@ func newNexusWorker(opts nexusWorkerOptions) (*nexusWorker, error) {
 		slotReservationData: slotReservationData{
 			taskQueue: params.TaskQueue,
 		},
+		slotExhaustionThreshold: params.SlotExhaustionWarningThreshold,
+		pollGate:                params.pollGate,
+		pollerAutoscaling:       params.NexusTaskPollerAutoscaling,
 	},
 	)
 
@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	commandpb "go.temporal.io/api/command/v1"
+	historypb "go.temporal.io/api/history/v1"
+
+	"go.temporal.io/sdk/internal/common/util"
+)
+
+// Number of history events before and after the mismatching event included in diagnostics
+const historyMismatchSurroundingEvents = 2
+
+// historyMismatchDiff is a structured description of a mismatch between history events and replay commands.
+type historyMismatchDiff struct {
+	// History event that did not match, nil if replay produced an extra command
+	historyEvent *historypb.HistoryEvent
+	// Replay command that did not match, nil if replay did not produce a command for the history event
+	replayCommand *commandpb.Command
+	// IDs of the command events around the mismatch
+	surroundingEventIDs []int64
+	// Versions returned by GetVersion for the workflow so far, by change ID
+	versionMarkers map[string]Version
+}
+
+func newHistoryMismatchDiff(
+	historyEvents []*historypb.HistoryEvent,
+	historyIndex int,
+	replayCommand *commandpb.Command,
+) *historyMismatchDiff {
+	diff := &historyMismatchDiff{replayCommand: replayCommand}
+	if historyIndex < len(historyEvents) {
+		diff.historyEvent = historyEvents[historyIndex]
+	}
+	from := max(0, historyIndex-historyMismatchSurroundingEvents)
+	to := min(len(historyEvents), historyIndex+historyMismatchSurroundingEvents+1)
+	for _, e := range historyEvents[from:to] {
+		diff.surroundingEventIDs = append(diff.surroundingEventIDs, e.GetEventId())
+	}
+	return diff
+}
+
+func (d *historyMismatchDiff) String() string {
+	var b strings.Builder
+	if d.historyEvent != nil {
+		_, _ = fmt.Fprintf(&b, "\n\thistory event: %d %s", d.historyEvent.GetEventId(), util.HistoryEventToString(d.historyEvent))
+	} else {
+		b.WriteString("\n\thistory event: none")
+	}
+	if d.replayCommand != nil {
+		_, _ = fmt.Fprintf(&b, "\n\treplay command: %s", util.CommandToString(d.replayCommand))
+	} else {
+		b.WriteString("\n\treplay command: none")
+	}
+	_, _ = fmt.Fprintf(&b, "\n\tsurrounding command event IDs: %v", d.surroundingEventIDs)
+	if len(d.versionMarkers) > 0 {
+		changeIDs := make([]string, 0, len(d.versionMarkers))
+		for changeID := range d.versionMarkers {
+			changeIDs = append(changeIDs, changeID)
+		}
+		sort.Strings(changeIDs)
+		markers := make([]string, len(changeIDs))
+		for i, changeID := range changeIDs {
+			markers[i] = fmt.Sprintf("%s=%d", changeID, d.versionMarkers[changeID])
+		}
+		_, _ = fmt.Fprintf(&b, "\n\tversion markers in scope: %s", strings.Join(markers, ", "))
+	}
+	return b.String()
+}
+
+// writeNondeterminismReport writes the full comparison of the history events and replay commands of a workflow task
+// that failed the determinism check to a file in dir, and returns the path of the file. The file is named after the
+// workflow run and the mismatching event, so the retries of the workflow task overwrite the same file.
+func writeNondeterminismReport(
+	dir string,
+	info *WorkflowInfo,
+	mismatch historyMismatchError,
+	historyEvents []*historypb.HistoryEvent,
+	replayCommands []*commandpb.Command,
+) (string, error) {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "Workflow type: %s\nWorkflow ID: %s\nRun ID: %s\n\n",
+		info.WorkflowType.Name, info.WorkflowExecution.ID, info.WorkflowExecution.RunID)
+	_, _ = fmt.Fprintf(&b, "%s\n\nHistory events:\n", mismatch.Error())
+	for _, e := range historyEvents {
+		_, _ = fmt.Fprintf(&b, "\t%d %s\n", e.GetEventId(), util.HistoryEventToString(e))
+	}
+	b.WriteString("\nReplay commands:\n")
+	for _, c := range replayCommands {
+		_, _ = fmt.Fprintf(&b, "\t%s\n", util.CommandToString(c))
+	}
+
+	var eventID int64
+	if mismatch.diff != nil && mismatch.diff.historyEvent != nil {
+		eventID = mismatch.diff.historyEvent.GetEventId()
+	} else if len(historyEvents) > 0 {
+		eventID = historyEvents[len(historyEvents)-1].GetEventId()
+	}
+	name := fmt.Sprintf("nondeterminism_%s_%s_%d.txt",
+		sanitizeFileName(info.WorkflowExecution.ID), sanitizeFileName(info.WorkflowExecution.RunID), eventID)
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func sanitizeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,303 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	querypb "go.temporal.io/api/query/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/internal/common/cache"
+	"go.temporal.io/sdk/internal/common/metrics"
+)
+
+const defaultQueryReplicaCacheSize = 1000
+
+type (
+	// QueryReplicaOptions are options for NewQueryReplica.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.QueryReplicaOptions]
+	QueryReplicaOptions struct {
+		// MaxStaleness is how long the history of an open workflow is reused for queries after it was loaded from
+		// the Temporal service. Queries may not observe events added to the history since then. Zero loads the
+		// history for every query. The histories of closed workflow runs never change and are reused until
+		// evicted from the cache.
+		MaxStaleness time.Duration
+
+		// CacheSize is the maximum number of workflow histories kept in memory, the least recently used ones are
+		// evicted first. Default is 1000.
+		CacheSize int
+
+		// Interceptors to apply to the replayed workflows, in addition to the worker interceptors of the client.
+		// Earlier interceptors wrap later interceptors.
+		Interceptors []WorkerInterceptor
+
+		// Disable aliasing during registration. This should be set if it was set on
+		// worker.Options.DisableRegistrationAliasing of the workers running the workflows.
+		DisableRegistrationAliasing bool
+	}
+
+	// QueryReplica answers the queries of workflows by replaying their histories in the process, without
+	// polling a task queue or sending commands to the Temporal service. It only loads workflow histories, so
+	// any number of replicas can serve queries, for example of dashboards, without taking workflow tasks from
+	// or adding load to the workers running the workflows. Queries are answered from the history loaded by
+	// the replica, which is up to QueryReplicaOptions.MaxStaleness old for open workflows.
+	//
+	// The workflows must be registered on the replica as on the workers running them, and the replica must be
+	// able to replay them, like a WorkflowReplayer. Replicas must be closed with Close once no longer used.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.QueryReplica]
+	QueryReplica struct {
+		client   *WorkflowClient
+		registry *registry
+		// cache is private to the replica, so replays never evict the workflows cached by the workers of the
+		// process. Queries are not cached, it is only needed by the workflow task handler.
+		cache      *WorkerCache
+		cacheStore sharedWorkerCache
+		cacheLock  sync.Mutex
+		options    QueryReplicaOptions
+		// histories caches *queryReplicaHistory by run.
+		histories cache.Cache
+		// closeLock is held for reading while queries are answered, so the cache is not released under them.
+		closeLock sync.RWMutex
+		closed    bool
+	}
+
+	queryReplicaHistory struct {
+		history  *historypb.History
+		loadedAt time.Time
+		closed   bool
+	}
+)
+
+// NewQueryReplica creates a QueryReplica loading workflow histories with the client. The data converter,
+// failure converter, context propagators and worker interceptors of the client are used for the replayed
+// workflows.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/worker.NewQueryReplica]
+func NewQueryReplica(client Client, options QueryReplicaOptions) (*QueryReplica, error) {
+	workflowClient, ok := client.(*WorkflowClient)
+	if !ok {
+		return nil, errors.New("client must be created with client.Dial() or client.NewLazyClient()")
+	}
+	if options.MaxStaleness < 0 {
+		return nil, errors.New("negative MaxStaleness")
+	}
+	if options.CacheSize < 0 {
+		return nil, errors.New("negative CacheSize")
+	} else if options.CacheSize == 0 {
+		options.CacheSize = defaultQueryReplicaCacheSize
+	}
+	registry := newRegistryWithOptions(registryOptions{disableAliasing: options.DisableRegistrationAliasing})
+	registry.interceptors = make([]WorkerInterceptor, 0, len(workflowClient.workerInterceptors)+len(options.Interceptors))
+	registry.interceptors = append(append(registry.interceptors, workflowClient.workerInterceptors...), options.Interceptors...)
+	qr := &QueryReplica{
+		client:    workflowClient,
+		registry:  registry,
+		options:   options,
+		histories: cache.NewLRU(options.CacheSize),
+	}
+	qr.cache = newWorkerCache(&qr.cacheStore, &qr.cacheLock, 1)
+	return qr, nil
+}
+
+// RegisterWorkflow registers a workflow function whose queries are answered by the replica.
+func (qr *QueryReplica) RegisterWorkflow(w interface{}) {
+	qr.registry.RegisterWorkflow(w)
+}
+
+// RegisterWorkflowWithOptions registers a workflow function whose queries are answered by the replica with
+// user provided options.
+func (qr *QueryReplica) RegisterWorkflowWithOptions(w interface{}, options RegisterWorkflowOptions) {
+	qr.registry.RegisterWorkflowWithOptions(w, options)
+}
+
+// QueryWorkflow answers a query of a workflow like Client.QueryWorkflow, by replaying its history instead of
+// sending the query to a worker. The latest run of the workflow is queried if runID is empty. The built-in
+// QueryTypeStackTrace and QueryTypeStackTraceJSON queries are supported, the stack traces being those of the
+// workflow after the last completed workflow task in the history.
+func (qr *QueryReplica) QueryWorkflow(
+	ctx context.Context,
+	workflowID string,
+	runID string,
+	queryType string,
+	args ...interface{},
+) (converter.EncodedValue, error) {
+	if workflowID == "" {
+		return nil, errors.New("workflowID is required")
+	}
+	qr.closeLock.RLock()
+	defer qr.closeLock.RUnlock()
+	if qr.closed {
+		return nil, errors.New("query replica is closed")
+	}
+	history, err := qr.loadHistory(ctx, workflowID, runID)
+	if err != nil {
+		return nil, err
+	}
+	queryArgs, err := encodeArgs(qr.client.dataConverter, args)
+	if err != nil {
+		return nil, err
+	}
+	header, err := headerPropagated(contextWithNewHeader(ctx), qr.client.contextPropagators)
+	if err != nil {
+		return nil, err
+	}
+	result, err := qr.replayQuery(workflowID, runID, history, &querypb.WorkflowQuery{
+		QueryType: queryType,
+		QueryArgs: queryArgs,
+		Header:    header,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newEncodedValue(result, qr.client.dataConverter), nil
+}
+
+// Close releases the caches of the replica, waiting for ongoing queries to complete. Queries fail once the replica
+// is closed.
+func (qr *QueryReplica) Close() {
+	qr.closeLock.Lock()
+	defer qr.closeLock.Unlock()
+	if qr.closed {
+		return
+	}
+	qr.closed = true
+	// The finalizer would release the reference a second time
+	runtime.SetFinalizer(qr.cache, nil)
+	qr.cache.close(&qr.cacheLock)
+	qr.histories.Clear()
+}
+
+// loadHistory returns the history of a workflow run, from the cache if it is fresh enough.
+func (qr *QueryReplica) loadHistory(ctx context.Context, workflowID, runID string) (*historypb.History, error) {
+	key := workflowID + "/" + runID
+	if cached, ok := qr.histories.Get(key).(*queryReplicaHistory); ok {
+		// The closed latest run of a workflow may be followed by a new run, so it is only reused for as long
+		// as an open run would be.
+		if (cached.closed && runID != "") || time.Since(cached.loadedAt) < qr.options.MaxStaleness {
+			return cached.history, nil
+		}
+	}
+	loadedAt := time.Now()
+	history, err := getWorkflowExecutionHistory(ctx, qr.client.workflowService, qr.client.namespace,
+		WorkflowExecution{ID: workflowID, RunID: runID})
+	if err != nil {
+		return nil, err
+	}
+	events := history.GetEvents()
+	if len(events) == 0 {
+		return nil, fmt.Errorf("empty history for workflow %v", workflowID)
+	}
+	closed := isWorkflowCloseEvent(events[len(events)-1].GetEventType())
+	if closed || qr.options.MaxStaleness > 0 {
+		qr.histories.Put(key, &queryReplicaHistory{history: history, loadedAt: loadedAt, closed: closed})
+	}
+	return history, nil
+}
+
+// replayQuery replays the history of a workflow and answers the query on its resulting state. The history is
+// replayed as a legacy query task, so no commands are generated and the workflow state is not cached.
+func (qr *QueryReplica) replayQuery(
+	workflowID string,
+	runID string,
+	history *historypb.History,
+	query *querypb.WorkflowQuery,
+) (*commonpb.Payloads, error) {
+	first := history.Events[0]
+	attr := first.GetWorkflowExecutionStartedEventAttributes()
+	if attr == nil {
+		return nil, errors.New("first event is not WorkflowExecutionStarted")
+	}
+	if runID == "" {
+		runID = attr.GetOriginalExecutionRunId()
+	}
+	task := &workflowservice.PollWorkflowTaskQueueResponse{
+		Attempt:                1,
+		TaskToken:              []byte("QueryReplicaTaskToken"),
+		WorkflowType:           attr.WorkflowType,
+		WorkflowExecution:      &commonpb.WorkflowExecution{WorkflowId: workflowID, RunId: runID},
+		History:                history,
+		PreviousStartedEventId: math.MaxInt64,
+		Query:                  query,
+	}
+	iterator := &historyIteratorImpl{
+		execution: task.WorkflowExecution,
+		namespace: qr.client.namespace,
+		service:   qr.client.workflowService,
+		taskQueue: attr.GetTaskQueue().GetName(),
+	}
+	params := workerExecutionParameters{
+		Namespace:          qr.client.namespace,
+		TaskQueue:          attr.GetTaskQueue().GetName(),
+		Identity:           qr.client.identity,
+		Logger:             qr.client.logger,
+		cache:              qr.cache,
+		DataConverter:      qr.client.dataConverter,
+		FailureConverter:   qr.client.failureConverter,
+		ContextPropagators: qr.client.contextPropagators,
+		// Queries answered by replicas are not workflow tasks, so the worker metrics are not reported for them.
+		MetricsHandler: metrics.NopHandler,
+		capabilities: &workflowservice.GetSystemInfoResponse_Capabilities{
+			SignalAndQueryHeader:            true,
+			InternalErrorDifferentiation:    true,
+			ActivityFailureIncludeHeartbeat: true,
+			SupportsSchedules:               true,
+			EncodedFailureAttributes:        true,
+			UpsertMemo:                      true,
+			EagerWorkflowStart:              true,
+			SdkMetadata:                     true,
+		},
+	}
+	taskHandler := newWorkflowTaskHandler(params, nil, qr.registry)
+	wfctx, err := taskHandler.GetOrCreateWorkflowContext(task, iterator)
+	defer wfctx.Unlock(err)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := taskHandler.ProcessWorkflowTask(&workflowTask{task: task, historyIterator: iterator}, wfctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch resp := resp.(type) {
+	case *workflowservice.RespondQueryTaskCompletedRequest:
+		if resp.GetCompletedType() != enumspb.QUERY_RESULT_TYPE_ANSWERED {
+			return nil, serviceerror.NewQueryFailed(resp.GetErrorMessage())
+		}
+		return resp.GetQueryResult(), nil
+	case *workflowservice.RespondWorkflowTaskFailedRequest:
+		return nil, fmt.Errorf("replay of workflow %v failed: %w",
+			workflowID, qr.client.failureConverter.FailureToError(resp.GetFailure()))
+	default:
+		return nil, fmt.Errorf("unexpected response replaying workflow %v: %T", workflowID, resp)
+	}
+}
+
+func isWorkflowCloseEvent(eventType enumspb.EventType) bool {
+	switch eventType {
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED,
+		enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED,
+		enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED,
+		enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED,
+		enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW,
+		enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:
+		return true
+	}
+	return false
+}
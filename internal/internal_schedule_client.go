@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -50,6 +52,9 @@ type (
 	}
 )
 
+// Number of schedules patched at a time by PauseMany and UnpauseMany if not set
+const defaultScheduleBulkConcurrency = 10
+
 func (w *workflowClientInterceptor) CreateSchedule(ctx context.Context, in *ScheduleClientCreateInput) (ScheduleHandle, error) {
 	// This is always set before interceptor is invoked
 	ID := in.Options.ID
@@ -188,6 +193,81 @@ func (sc *scheduleClient) List(ctx context.Context, options ScheduleListOptions)
 	}, nil
 }
 
+func (sc *scheduleClient) PauseMany(ctx context.Context, options SchedulePauseManyOptions) (*ScheduleBulkResult, error) {
+	return sc.patchMany(ctx, options.Query, options.Concurrency, options.Progress, func(handle ScheduleHandle) error {
+		return handle.Pause(ctx, SchedulePauseOptions{Note: options.Note})
+	})
+}
+
+func (sc *scheduleClient) UnpauseMany(ctx context.Context, options ScheduleUnpauseManyOptions) (*ScheduleBulkResult, error) {
+	return sc.patchMany(ctx, options.Query, options.Concurrency, options.Progress, func(handle ScheduleHandle) error {
+		return handle.Unpause(ctx, ScheduleUnpauseOptions{Note: options.Note})
+	})
+}
+
+// patchMany calls patch concurrently for each schedule matching the query.
+func (sc *scheduleClient) patchMany(
+	ctx context.Context,
+	query string,
+	concurrency int,
+	progress func(ScheduleBulkProgress),
+	patch func(ScheduleHandle) error,
+) (*ScheduleBulkResult, error) {
+	// Require a query so a mistake does not affect every schedule in the namespace
+	if query == "" {
+		return nil, errors.New("query is required")
+	}
+	if concurrency <= 0 {
+		concurrency = defaultScheduleBulkConcurrency
+	}
+	iter, err := sc.List(ctx, ScheduleListOptions{Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ScheduleBulkResult{Failed: map[string]error{}}
+	var resultLock sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	defer func() {
+		wg.Wait()
+		sort.Strings(result.Succeeded)
+	}()
+	for iter.HasNext() {
+		entry, err := iter.Next()
+		if err != nil {
+			return result, err
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return result, ctx.Err()
+		}
+		wg.Add(1)
+		go func(scheduleID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			err := patch(sc.GetHandle(ctx, scheduleID))
+			resultLock.Lock()
+			defer resultLock.Unlock()
+			if err != nil {
+				result.Failed[scheduleID] = err
+			} else {
+				result.Succeeded = append(result.Succeeded, scheduleID)
+			}
+			if progress != nil {
+				progress(ScheduleBulkProgress{
+					ScheduleID: scheduleID,
+					Err:        err,
+					Succeeded:  len(result.Succeeded),
+					Failed:     len(result.Failed),
+				})
+			}
+		}(entry.ID)
+	}
+	return result, nil
+}
+
 func (iter *scheduleListIteratorImpl) HasNext() bool {
 	if iter.err == nil {
 		if iter.response == nil ||
@@ -511,6 +591,7 @@ func scheduleDescriptionFromPB(
 		Memo:                  describeResponse.Memo,
 		SearchAttributes:      searchAttributes,
 		TypedSearchAttributes: typedSearchAttributes,
+		dc:                    dc,
 	}, nil
 }
 
@@ -845,6 +926,7 @@ func convertFromPBScheduleActionResultList(aa []*schedulepb.ScheduleActionResult
 			ScheduleTime:        a.GetScheduleTime().AsTime(),
 			ActualTime:          a.GetActualTime().AsTime(),
 			StartWorkflowResult: workflowExecution,
+			StartWorkflowStatus: a.GetStartWorkflowStatus(),
 		}
 	}
 	return recentActions
@@ -6,11 +6,15 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
 	schedulepb "go.temporal.io/api/schedule/v1"
 	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/api/workflowservicemock/v1"
 	"go.temporal.io/sdk/converter"
+	"google.golang.org/grpc"
 )
 
 const (
@@ -219,3 +223,91 @@ func (s *scheduleClientTestSuite) TestIteratorError() {
 	s.Nil(event)
 	s.NotNil(err)
 }
+
+func (s *scheduleClientTestSuite) TestPauseMany() {
+	request := getListSchedulesRequest()
+	request.Query = "TemporalSchedulePaused = false"
+	s.service.EXPECT().ListSchedules(gomock.Any(), request, gomock.Any()).Return(&workflowservice.ListSchedulesResponse{
+		Schedules: []*schedulepb.ScheduleListEntry{{ScheduleId: "b"}, {ScheduleId: "a"}, {ScheduleId: "c"}},
+	}, nil).Times(1)
+	s.service.EXPECT().PatchSchedule(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *workflowservice.PatchScheduleRequest, _ ...grpc.CallOption) (*workflowservice.PatchScheduleResponse, error) {
+			s.Equal("incident", req.GetPatch().GetPause())
+			if req.GetScheduleId() == "c" {
+				return nil, serviceerror.NewNotFound("")
+			}
+			return &workflowservice.PatchScheduleResponse{}, nil
+		}).Times(3)
+
+	var progress []ScheduleBulkProgress
+	result, err := s.client.ScheduleClient().PauseMany(context.Background(), SchedulePauseManyOptions{
+		Query:       "TemporalSchedulePaused = false",
+		Note:        "incident",
+		Concurrency: 1,
+		Progress: func(p ScheduleBulkProgress) {
+			progress = append(progress, p)
+		},
+	})
+	s.NoError(err)
+	s.Equal([]string{"a", "b"}, result.Succeeded)
+	s.Len(result.Failed, 1)
+	s.IsType(&serviceerror.NotFound{}, result.Failed["c"])
+	s.Len(progress, 3)
+	s.Equal(ScheduleBulkProgress{ScheduleID: "a", Succeeded: 2}, progress[1])
+	s.Equal("c", progress[2].ScheduleID)
+	s.Error(progress[2].Err)
+	s.Equal(2, progress[2].Succeeded)
+	s.Equal(1, progress[2].Failed)
+}
+
+func (s *scheduleClientTestSuite) TestUnpauseManyRequiresQuery() {
+	_, err := s.client.ScheduleClient().UnpauseMany(context.Background(), ScheduleUnpauseManyOptions{})
+	s.Error(err)
+}
+
+func (s *scheduleClientTestSuite) TestDescribeDecodesPayloads() {
+	dc := converter.NewCodecDataConverter(s.dataConverter, converter.NewZlibCodec(converter.ZlibCodecOptions{AlwaysEncode: true}))
+	s.client = NewServiceClient(s.service, nil, ClientOptions{DataConverter: dc})
+	input, err := dc.ToPayloads("arg1", 2)
+	s.NoError(err)
+	workflowMemo, err := dc.ToPayload("workflow-memo")
+	s.NoError(err)
+	scheduleMemo, err := dc.ToPayload("schedule-memo")
+	s.NoError(err)
+	s.service.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeScheduleResponse{
+		Schedule: &schedulepb.Schedule{
+			Action: &schedulepb.ScheduleAction{
+				Action: &schedulepb.ScheduleAction_StartWorkflow{
+					StartWorkflow: &workflowpb.NewWorkflowExecutionInfo{
+						WorkflowId:   workflowID,
+						WorkflowType: &commonpb.WorkflowType{Name: "wf"},
+						Input:        input,
+						Memo:         &commonpb.Memo{Fields: map[string]*commonpb.Payload{"key": workflowMemo}},
+					},
+				},
+			},
+		},
+		Info: &schedulepb.ScheduleInfo{
+			RecentActions: []*schedulepb.ScheduleActionResult{{
+				StartWorkflowResult: &commonpb.WorkflowExecution{WorkflowId: workflowID, RunId: runID},
+				StartWorkflowStatus: enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+			}},
+		},
+		Memo: &commonpb.Memo{Fields: map[string]*commonpb.Payload{"key": scheduleMemo}},
+	}, nil).Times(1)
+
+	desc, err := s.client.ScheduleClient().GetHandle(context.Background(), scheduleID).Describe(context.Background())
+	s.NoError(err)
+	var arg1 string
+	var arg2 int
+	s.NoError(desc.GetWorkflowArgs(&arg1, &arg2))
+	s.Equal("arg1", arg1)
+	s.Equal(2, arg2)
+	var memo string
+	s.NoError(desc.GetWorkflowMemo("key", &memo))
+	s.Equal("workflow-memo", memo)
+	s.Error(desc.GetWorkflowMemo("missing", &memo))
+	s.NoError(desc.GetMemo("key", &memo))
+	s.Equal("schedule-memo", memo)
+	s.Equal(enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, desc.Info.RecentActions[0].StartWorkflowStatus)
+}
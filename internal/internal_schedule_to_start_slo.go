@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/internal/common/metrics"
+)
+
+type (
+	// ScheduleToStartSLOOptions are the schedule-to-start latency thresholds of the tasks of a worker, see
+	// WorkerOptions.ScheduleToStartSLO.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.ScheduleToStartSLOOptions]
+	ScheduleToStartSLOOptions struct {
+		// WorkflowTaskThreshold is the schedule-to-start latency of workflow tasks above which the SLO is violated,
+		// zero disables the SLO for workflow tasks.
+		WorkflowTaskThreshold time.Duration
+
+		// ActivityTaskThreshold is the schedule-to-start latency of activity tasks above which the SLO is violated,
+		// zero disables the SLO for activity tasks. The latency of an activity task is measured from the
+		// scheduling of its current attempt.
+		ActivityTaskThreshold time.Duration
+
+		// NexusTaskThreshold is the schedule-to-start latency of Nexus tasks above which the SLO is violated, zero
+		// disables the SLO for Nexus tasks.
+		NexusTaskThreshold time.Duration
+
+		// OnViolation is called with every task started later than its threshold, in addition to the
+		// temporal_schedule_to_start_slo_violation counter being incremented. It is called on the poller
+		// goroutine before the task is processed, so it must not block. Optional.
+		OnViolation func(ScheduleToStartSLOViolation)
+	}
+
+	// ScheduleToStartSLOViolation describes a task started later than its schedule-to-start latency threshold.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.ScheduleToStartSLOViolation]
+	ScheduleToStartSLOViolation struct {
+		// WorkerType is the kind of task, "WorkflowWorker", "ActivityWorker" or "NexusWorker", as in the
+		// worker_type tag of metrics.
+		WorkerType string
+		// TaskQueue is the task queue of the worker.
+		TaskQueue string
+		// WorkflowType is the type of the workflow of workflow and activity tasks.
+		WorkflowType string
+		// ActivityType is the type of the activity of activity tasks.
+		ActivityType string
+		// Latency is the schedule-to-start latency of the task.
+		Latency time.Duration
+		// Threshold is the threshold the latency exceeded.
+		Threshold time.Duration
+	}
+
+	// scheduleToStartSLO checks the schedule-to-start latencies of the tasks polled by a worker against its
+	// ScheduleToStartSLOOptions. A nil scheduleToStartSLO checks nothing.
+	scheduleToStartSLO struct {
+		options        ScheduleToStartSLOOptions
+		metricsHandler metrics.Handler
+	}
+)
+
+func newScheduleToStartSLO(options *ScheduleToStartSLOOptions, metricsHandler metrics.Handler) *scheduleToStartSLO {
+	if options == nil {
+		return nil
+	}
+	return &scheduleToStartSLO{options: *options, metricsHandler: metricsHandler}
+}
+
+// check reports the task as violating the SLO if its latency exceeds the threshold of its worker type.
+func (s *scheduleToStartSLO) check(violation ScheduleToStartSLOViolation) {
+	if s == nil {
+		return
+	}
+	switch violation.WorkerType {
+	case "WorkflowWorker":
+		violation.Threshold = s.options.WorkflowTaskThreshold
+	case "ActivityWorker":
+		violation.Threshold = s.options.ActivityTaskThreshold
+	case "NexusWorker":
+		violation.Threshold = s.options.NexusTaskThreshold
+	}
+	if violation.Threshold <= 0 || violation.Latency <= violation.Threshold {
+		return
+	}
+	tags := metrics.WorkerTags(violation.WorkerType)
+	if violation.WorkflowType != "" {
+		tags[metrics.WorkflowTypeNameTagName] = violation.WorkflowType
+	}
+	if violation.ActivityType != "" {
+		tags[metrics.ActivityTypeNameTagName] = violation.ActivityType
+	}
+	s.metricsHandler.WithTags(tags).Counter(metrics.ScheduleToStartSLOViolationCounter).Inc(1)
+	if s.options.OnViolation != nil {
+		s.options.OnViolation(violation)
+	}
+}
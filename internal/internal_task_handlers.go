@@ -110,6 +110,10 @@ type (
 		currentWorkflowTask *workflowservice.PollWorkflowTaskQueueResponse
 		laTunnel            *localActivityTunnel
 		cached              bool
+		// Tags from the worker's MetricsTagProvider added to metrics of the workflow
+		metricsTags map[string]string
+		// Fields from the worker's LogFieldsOptions added to the logger of the workflow
+		logFields []interface{}
 	}
 
 	// workflowTaskHandlerImpl is the implementation of WorkflowTaskHandler
@@ -125,6 +129,12 @@ type (
 		deploymentSeriesName      string
 		defaultVersioningBehavior VersioningBehavior
 		enableLoggingInReplay     bool
+		workflowLogSampling       WorkflowLogSamplingOptions
+		errorLogStackTraces       bool
+		nondeterminismReportDir   string
+		metricsTagEnricher        *metricsTagEnricher
+		logFieldEnricher          *logFieldEnricher
+		versionUsage              *versionUsageRecorder
 		registry                  *registry
 		laTunnel                  *localActivityTunnel
 		workflowPanicPolicy       WorkflowPanicPolicy
@@ -134,6 +144,7 @@ type (
 		cache                     *WorkerCache
 		deadlockDetectionTimeout  time.Duration
 		capabilities              *workflowservice.GetSystemInfoResponse_Capabilities
+		payloadSizeLimits         payloadSizeLimits
 	}
 
 	activityProvider func(name string) activity
@@ -151,6 +162,7 @@ type (
 		dataConverter                    converter.DataConverter
 		failureConverter                 converter.FailureConverter
 		workerStopCh                     <-chan struct{}
+		workerStopState                  *workerStopState
 		contextPropagators               []ContextPropagator
 		namespace                        string
 		defaultHeartbeatThrottleInterval time.Duration
@@ -158,6 +170,11 @@ type (
 		versionStamp                     *commonpb.WorkerVersionStamp
 		deployment                       *deploymentpb.Deployment
 		workerDeploymentOptions          *deploymentpb.WorkerDeploymentOptions
+		errorLogStackTraces              bool
+		metricsTagEnricher               *metricsTagEnricher
+		logFieldEnricher                 *logFieldEnricher
+		resultCache                      ActivityResultCache
+		executorPools                    *activityExecutorPools
 	}
 
 	// history wrapper method to help information about events.
@@ -183,6 +200,10 @@ type (
 
 	historyMismatchError struct {
 		message string
+		// Optional structured description of the mismatch
+		diff *historyMismatchDiff
+		// Commands produced by replay for the workflow task
+		replayCommands []*commandpb.Command
 	}
 
 	unknownSdkFlagError struct {
@@ -236,6 +257,9 @@ func historyMismatchErrorf(f string, v ...interface{}) historyMismatchError {
 }
 
 func (h historyMismatchError) Error() string {
+	if h.diff != nil {
+		return h.message + h.diff.String()
+	}
 	return h.message
 }
 
@@ -552,6 +576,12 @@ func newWorkflowTaskHandler(params workerExecutionParameters, ppMgr pressurePoin
 		deploymentSeriesName:      params.DeploymentSeriesName,
 		defaultVersioningBehavior: params.DefaultVersioningBehavior,
 		enableLoggingInReplay:     params.EnableLoggingInReplay,
+		workflowLogSampling:       params.WorkflowLogSampling,
+		errorLogStackTraces:       params.EnableStackTraceInErrorLogs,
+		nondeterminismReportDir:   params.NondeterminismReportDirectory,
+		metricsTagEnricher:        params.metricsTagEnricher,
+		logFieldEnricher:          params.logFieldEnricher,
+		versionUsage:              params.versionUsage,
 		registry:                  registry,
 		workflowPanicPolicy:       params.WorkflowPanicPolicy,
 		dataConverter:             params.DataConverter,
@@ -560,16 +590,24 @@ func newWorkflowTaskHandler(params workerExecutionParameters, ppMgr pressurePoin
 		cache:                     params.cache,
 		deadlockDetectionTimeout:  params.DeadlockDetectionTimeout,
 		capabilities:              params.capabilities,
+		payloadSizeLimits: payloadSizeLimits{
+			warnSize:  params.PayloadSizeWarningThreshold,
+			errorSize: params.PayloadSizeErrorThreshold,
+		},
 	}
 }
 
 func newWorkflowExecutionContext(
 	workflowInfo *WorkflowInfo,
 	taskHandler *workflowTaskHandlerImpl,
+	metricsTags map[string]string,
+	logFields []interface{},
 ) *workflowExecutionContextImpl {
 	workflowContext := &workflowExecutionContextImpl{
 		workflowInfo: workflowInfo,
 		wth:          taskHandler,
+		metricsTags:  metricsTags,
+		logFields:    logFields,
 	}
 	workflowContext.createEventHandler()
 	return workflowContext
@@ -603,9 +641,21 @@ func (w *workflowExecutionContextImpl) Unlock(err error) {
 		// Clear the state if we never cached the workflow so coroutines can be
 		// exited
 		w.clearState()
+	} else {
+		w.updateCachedSize()
 	}
 }
 
+// updateCachedSize updates the size of the cached workflow used for the sticky cache memory limit.
+func (w *workflowExecutionContextImpl) updateCachedSize() {
+	size := w.workflowInfo.GetCurrentHistorySize()
+	workflowCache := w.wth.cache.getWorkflowCache()
+	w.wth.cache.updateWorkflowContextSize(w.workflowInfo.WorkflowExecution.RunID, int64(size))
+	metrics.HistogramFromHandler(w.wth.metricsHandler.WithTags(metrics.WorkflowTags(w.workflowInfo.WorkflowType.Name)),
+		metrics.StickyCacheEntryHistorySize).RecordValue(float64(size))
+	w.wth.metricsHandler.Gauge(metrics.StickyCacheHistorySize).Update(float64(workflowCache.TotalSize()))
+}
+
 func (w *workflowExecutionContextImpl) getEventHandler() *workflowExecutionEventHandlerImpl {
 	if w.eventHandler == nil {
 		return nil
@@ -662,15 +712,19 @@ func (w *workflowExecutionContextImpl) createEventHandler() {
 	eventHandler := newWorkflowExecutionEventHandler(
 		w.workflowInfo,
 		w.completeWorkflow,
-		w.wth.logger,
+		withLogFields(w.wth.logger, w.logFields),
 		w.wth.enableLoggingInReplay,
-		w.wth.metricsHandler,
+		w.wth.workflowLogSampling,
+		w.wth.errorLogStackTraces,
+		withMetricsTags(w.wth.metricsHandler, w.metricsTags),
 		w.wth.registry,
 		w.wth.dataConverter,
 		w.wth.failureConverter,
 		w.wth.contextPropagators,
 		w.wth.deadlockDetectionTimeout,
 		w.wth.capabilities,
+		w.wth.payloadSizeLimits,
+		w.wth.versionUsage,
 	)
 
 	w.eventHandler = &eventHandler
@@ -750,7 +804,11 @@ func (wth *workflowTaskHandlerImpl) createWorkflowContext(task *workflowservice.
 		Priority:     convertFromPBPriority(attributes.Priority),
 	}
 
-	return newWorkflowExecutionContext(workflowInfo, wth), nil
+	metricsTags := wth.metricsTagEnricher.workflowTags(
+		workflowInfo.WorkflowType.Name, workflowInfo.TaskQueueName, attributes.Header, attributes.SearchAttributes)
+	logFields := wth.logFieldEnricher.workflowFields(
+		workflowInfo.WorkflowType.Name, workflowInfo.TaskQueueName, attributes.Header)
+	return newWorkflowExecutionContext(workflowInfo, wth, metricsTags, logFields), nil
 }
 
 func (wth *workflowTaskHandlerImpl) GetOrCreateWorkflowContext(
@@ -977,9 +1035,12 @@ processWorkflowLoop:
 						}
 
 						laRetry.attempt++
+						attemptTime := laRetry.attemptTime
+						laRetry.attemptTime = time.Now()
 
 						if !wth.laTunnel.sendTask(laRetry) {
 							laRetry.attempt--
+							laRetry.attemptTime = attemptTime
 						}
 
 					case lar := <-workflowTask.laResultCh:
@@ -1238,6 +1299,9 @@ ProcessEvents:
 	if !skipReplayCheck && (!w.isWorkflowCompleted || shouldForceReplayCheck()) {
 		// check if commands from reply matches to the history events
 		if err := matchReplayWithHistory(replayCommands, respondEvents, replayOutbox, w.getEventHandler().sdkFlags); err != nil {
+			if mismatch, ok := err.(historyMismatchError); ok {
+				err = w.addHistoryMismatchDiagnostics(mismatch, respondEvents, replayCommands)
+			}
 			workflowError = err
 			w.err = err
 		}
@@ -1246,6 +1310,39 @@ ProcessEvents:
 	return w.applyWorkflowPanicPolicy(workflowTask, workflowError)
 }
 
+// addHistoryMismatchDiagnostics adds the versions in scope to the mismatch and writes the full comparison to a report
+// file if configured.
+func (w *workflowExecutionContextImpl) addHistoryMismatchDiagnostics(
+	mismatch historyMismatchError,
+	historyEvents []*historypb.HistoryEvent,
+	replayCommands []*commandpb.Command,
+) historyMismatchError {
+	mismatch.replayCommands = replayCommands
+	if mismatch.diff == nil {
+		return mismatch
+	}
+	versions := w.getEventHandler().changeVersions
+	mismatch.diff.versionMarkers = make(map[string]Version, len(versions))
+	for changeID, version := range versions {
+		mismatch.diff.versionMarkers[changeID] = version
+	}
+	if w.wth.nondeterminismReportDir != "" {
+		path, err := writeNondeterminismReport(w.wth.nondeterminismReportDir, w.workflowInfo, mismatch, historyEvents, replayCommands)
+		if err != nil {
+			w.wth.logger.Warn("Failed to write nondeterminism report",
+				tagWorkflowID, w.workflowInfo.WorkflowExecution.ID,
+				tagRunID, w.workflowInfo.WorkflowExecution.RunID,
+				tagError, err)
+		} else {
+			w.wth.logger.Warn("Nondeterminism report written",
+				tagWorkflowID, w.workflowInfo.WorkflowExecution.ID,
+				tagRunID, w.workflowInfo.WorkflowExecution.RunID,
+				"ReportPath", path)
+		}
+	}
+	return mismatch
+}
+
 func (w *workflowExecutionContextImpl) ProcessLocalActivityResult(workflowTask *workflowTask, lar *localActivityResult) (interface{}, error) {
 	if lar.err != nil && w.retryLocalActivity(lar) {
 		return nil, nil // nothing to do here as we are retrying...
@@ -1281,7 +1378,11 @@ func (w *workflowExecutionContextImpl) applyWorkflowPanicPolicy(workflowTask *wo
 				tagError, workflowError)
 		}
 
-		switch w.wth.workflowPanicPolicy {
+		panicPolicy := w.wth.workflowPanicPolicy
+		if policy, ok := w.wth.registry.getWorkflowPanicPolicy(WorkflowType{Name: task.WorkflowType.GetName()}); ok {
+			panicPolicy = policy
+		}
+		switch panicPolicy {
 		case FailWorkflow:
 			// complete workflow with custom error will fail the workflow
 			w.getEventHandler().Complete(nil, NewApplicationError(
@@ -1305,8 +1406,12 @@ func (w *workflowExecutionContextImpl) retryLocalActivity(lar *localActivityResu
 		return false
 	}
 
+	localRetryThreshold := lar.task.params.LocalRetryThreshold
+	if localRetryThreshold == 0 {
+		localRetryThreshold = w.workflowInfo.WorkflowTaskTimeout
+	}
 	retryBackoff := getRetryBackoff(lar, time.Now())
-	if retryBackoff > 0 && retryBackoff <= w.workflowInfo.WorkflowTaskTimeout {
+	if retryBackoff > 0 && retryBackoff <= localRetryThreshold {
 		// we need a local retry
 		time.AfterFunc(retryBackoff, func() {
 			// Send retry signal
@@ -1331,10 +1436,18 @@ func (w *workflowExecutionContextImpl) retryLocalActivity(lar *localActivityResu
 }
 
 func getRetryBackoff(lar *localActivityResult, now time.Time) time.Duration {
-	return getRetryBackoffWithNowTime(lar.task.retryPolicy, lar.task.attempt, lar.err, now, lar.task.expireTime)
-}
-
-func getRetryBackoffWithNowTime(p *RetryPolicy, attempt int32, err error, now, expireTime time.Time) time.Duration {
+	return getRetryBackoffWithNowTime(lar.task.retryPolicy, lar.task.params.RetryOverrides, lar.task.params.RetryJitter,
+		lar.task.attempt, lar.err, now, lar.task.expireTime)
+}
+
+func getRetryBackoffWithNowTime(
+	p *RetryPolicy,
+	overrides []RetryOverride,
+	jitter RetryJitter,
+	attempt int32,
+	err error,
+	now, expireTime time.Time,
+) time.Duration {
 	if !IsRetryable(err, p.NonRetryableErrorTypes) {
 		return noRetryBackoff
 	}
@@ -1350,18 +1463,19 @@ func getRetryBackoffWithNowTime(p *RetryPolicy, attempt int32, err error, now, e
 		backoffInterval = applicationErr.nextRetryDelay
 	}
 	// Calculate next backoff interval if the error did not contain the next backoff interval.
-	// attempt starts from 1
 	if backoffInterval == 0 {
-		backoffInterval = time.Duration(float64(p.InitialInterval) * math.Pow(p.BackoffCoefficient, float64(attempt-1)))
-		if backoffInterval <= 0 {
-			// math.Pow() could overflow
-			if p.MaximumInterval > 0 {
-				backoffInterval = p.MaximumInterval
-			}
+		if override := findRetryOverride(overrides, getRetryErrType(err)); override != nil {
+			p = override.retryPolicy()
 		}
-		if p.MaximumInterval > 0 && backoffInterval > p.MaximumInterval {
-			// cap next interval to MaxInterval
-			backoffInterval = p.MaximumInterval
+		backoffInterval = getExponentialRetryBackoff(p, attempt)
+		if jitter != nil && backoffInterval > 0 {
+			backoffInterval = jitter.Apply(RetryJitterInput{
+				Attempt:         int(attempt),
+				InitialInterval: p.InitialInterval,
+				MaximumInterval: p.MaximumInterval,
+				PreviousBackoff: getExponentialRetryBackoff(p, attempt-1),
+				Backoff:         backoffInterval,
+			})
 		}
 	}
 	if backoffInterval <= 0 {
@@ -1376,6 +1490,26 @@ func getRetryBackoffWithNowTime(p *RetryPolicy, attempt int32, err error, now, e
 	return backoffInterval
 }
 
+// getExponentialRetryBackoff returns the backoff after the attempt, starting from 1, capped by the maximum interval.
+// The initial interval is returned for earlier attempts.
+func getExponentialRetryBackoff(p *RetryPolicy, attempt int32) time.Duration {
+	if attempt < 1 {
+		return p.InitialInterval
+	}
+	backoffInterval := time.Duration(float64(p.InitialInterval) * math.Pow(p.BackoffCoefficient, float64(attempt-1)))
+	if backoffInterval <= 0 {
+		// math.Pow() could overflow
+		if p.MaximumInterval > 0 {
+			backoffInterval = p.MaximumInterval
+		}
+	}
+	if p.MaximumInterval > 0 && backoffInterval > p.MaximumInterval {
+		// cap next interval to MaxInterval
+		backoffInterval = p.MaximumInterval
+	}
+	return backoffInterval
+}
+
 func (w *workflowExecutionContextImpl) CompleteWorkflowTask(workflowTask *workflowTask, waitLocalActivities bool) interface{} {
 	if w.currentWorkflowTask == nil {
 		return nil
@@ -1394,6 +1528,7 @@ func (w *workflowExecutionContextImpl) CompleteWorkflowTask(workflowTask *workfl
 				task.workflowTask = workflowTask
 
 				task.scheduledTime = time.Now()
+				task.attemptTime = task.scheduledTime
 
 				if !w.laTunnel.sendTask(task) {
 					unstartedLaTasks[activityID] = struct{}{}
@@ -1565,16 +1700,22 @@ matchLoop:
 		}
 
 		if d == nil {
-			return historyMismatchErrorf("[TMPRL1100] nondeterministic workflow: missing replay command for %s", util.HistoryEventToString(e))
+			err := historyMismatchErrorf("[TMPRL1100] nondeterministic workflow: missing replay command for %s", util.HistoryEventToString(e))
+			err.diff = newHistoryMismatchDiff(historyEvents, hi, nil)
+			return err
 		}
 
 		if e == nil {
-			return historyMismatchErrorf("[TMPRL1100] nondeterministic workflow: extra replay command for %s", util.CommandToString(d))
+			err := historyMismatchErrorf("[TMPRL1100] nondeterministic workflow: extra replay command for %s", util.CommandToString(d))
+			err.diff = newHistoryMismatchDiff(historyEvents, hi, d)
+			return err
 		}
 
 		if !isCommandMatchEvent(d, e, msgs) {
-			return historyMismatchErrorf("[TMPRL1100] nondeterministic workflow: history event is %s, replay command is %s",
+			err := historyMismatchErrorf("[TMPRL1100] nondeterministic workflow: history event is %s, replay command is %s",
 				util.HistoryEventToString(e), util.CommandToString(d))
+			err.diff = newHistoryMismatchDiff(historyEvents, hi, d)
+			return err
 		}
 
 		di++
@@ -1827,7 +1968,7 @@ func (wth *workflowTaskHandlerImpl) completeWorkflow(
 		return queryCompletedRequest
 	}
 
-	metricsHandler := wth.metricsHandler.WithTags(metrics.WorkflowTags(
+	metricsHandler := withMetricsTags(wth.metricsHandler, workflowContext.metricsTags).WithTags(metrics.WorkflowTags(
 		eventHandler.workflowEnvironmentImpl.workflowInfo.WorkflowType.Name))
 
 	// complete workflow task
@@ -2010,6 +2151,7 @@ func newActivityTaskHandlerWithCustomProvider(
 		dataConverter:                    params.DataConverter,
 		failureConverter:                 params.FailureConverter,
 		workerStopCh:                     params.WorkerStopChannel,
+		workerStopState:                  params.stopState,
 		contextPropagators:               params.ContextPropagators,
 		namespace:                        params.Namespace,
 		defaultHeartbeatThrottleInterval: params.DefaultHeartbeatThrottleInterval,
@@ -2026,6 +2168,11 @@ func newActivityTaskHandlerWithCustomProvider(
 			params.UseBuildIDForVersioning,
 			params.WorkerDeploymentVersion,
 		),
+		errorLogStackTraces: params.EnableStackTraceInErrorLogs,
+		metricsTagEnricher:  params.metricsTagEnricher,
+		logFieldEnricher:    params.logFieldEnricher,
+		resultCache:         params.ActivityResultCache,
+		executorPools:       params.activityExecutorPools,
 	}
 }
 
@@ -2058,6 +2205,16 @@ func (i *temporalInvoker) Heartbeat(ctx context.Context, details *commonpb.Paylo
 
 	isActivityCanceled, err := i.internalHeartBeat(ctx, details)
 
+	if skipBatching && i.hbBatchEndTimer != nil {
+		// Details buffered in the open batching window are older than these ones. Drop them if these were sent, or
+		// buffer these instead so they are reported when the window closes.
+		if err == nil || isActivityCanceled {
+			i.lastDetailsToReport = nil
+		} else {
+			i.lastDetailsToReport = &details
+		}
+	}
+
 	// If the activity is canceled, the activity can ignore the cancellation and do its work
 	// and complete. Our cancellation is co-operative, so we will try to heartbeat.
 	if (err == nil || isActivityCanceled) && !skipBatching {
@@ -2218,18 +2375,29 @@ func (ath *activityTaskHandlerImpl) Execute(taskQueue string, t *workflowservice
 
 	workflowType := t.WorkflowType.GetName()
 	activityType := t.ActivityType.GetName()
-	metricsHandler := ath.metricsHandler.WithTags(metrics.ActivityTags(workflowType, activityType, ath.taskQueueName))
-	ctx, err := WithActivityTask(canCtx, t, taskQueue, invoker, ath.logger, metricsHandler,
-		ath.dataConverter, ath.workerStopCh, ath.contextPropagators, ath.registry.interceptors, ath.client)
+	metricsHandler := withMetricsTags(ath.metricsHandler,
+		ath.metricsTagEnricher.activityTags(workflowType, activityType, ath.taskQueueName, t.Header)).
+		WithTags(metrics.ActivityTags(workflowType, activityType, ath.taskQueueName))
+	attemptScheduledTime := t.GetScheduledTime()
+	if t.GetCurrentAttemptScheduledTime().IsValid() {
+		attemptScheduledTime = t.GetCurrentAttemptScheduledTime()
+	}
+	logger := withLogFields(ath.logger, ath.logFieldEnricher.activityFields(
+		workflowType, activityType, ath.taskQueueName, attemptScheduledTime.AsTime(), t.Header))
+	if ath.errorLogStackTraces {
+		logger = newStackTraceActivityLogger(logger, taskQueue)
+	}
+	ctx, err := WithActivityTask(canCtx, t, taskQueue, invoker, logger, metricsHandler,
+		ath.dataConverter, ath.workerStopCh, ath.workerStopState, ath.contextPropagators, ath.registry.interceptors, ath.client)
 	if err != nil {
 		return nil, err
 	}
 
 	// We must capture the context here because it is changed later to one that is
-	// cancelled when the activity is done
+	// cancelled when the activity is done. Buffered heartbeat details are always flushed, so the last progress
+	// reported by the activity is not lost to the batching window whether it returned or panicked.
 	defer func(ctx context.Context) {
-		_, activityCompleted := result.(*workflowservice.RespondActivityTaskCompletedRequest)
-		invoker.Close(ctx, !activityCompleted) // flush buffered heartbeat if activity was not successfully completed.
+		invoker.Close(ctx, true)
 	}(ctx)
 
 	activityImplementation := ath.getActivity(activityType)
@@ -2256,6 +2424,11 @@ func (ath *activityTaskHandlerImpl) Execute(taskQueue string, t *workflowservice
 				tagPanicStack, st)
 			metricsHandler.Counter(metrics.ActivityTaskErrorCounter).Inc(1)
 			panicErr := newPanicError(p, st)
+			if ath.registry.getActivityPanicPolicy(activityType) == FailActivity {
+				panicErr = NewApplicationErrorWithOptions(
+					"Activity failed on panic due to FailActivity activity panic policy",
+					"", ApplicationErrorOptions{NonRetryable: true, Cause: panicErr})
+			}
 			result = convertActivityResultToRespondRequest(ath.identity, t.TaskToken, nil, panicErr,
 				ath.dataConverter, ath.failureConverter, ath.namespace, false, ath.versionStamp, ath.deployment, ath.workerDeploymentOptions)
 		}
@@ -2268,10 +2441,34 @@ func (ath *activityTaskHandlerImpl) Execute(taskQueue string, t *workflowservice
 	}
 
 	info := getActivityEnv(ctx)
+	info.progressReporter = newActivityProgressReporter(t.Header, info)
 	ctx, dlCancelFunc := context.WithDeadline(ctx, info.deadline)
 	defer dlCancelFunc()
 
-	output, err := activityImplementation.Execute(ctx, t.Input)
+	var idempotencyKey string
+	if ath.resultCache != nil {
+		idempotencyKey = getActivityIdempotencyKey(t.Header)
+	}
+	if idempotencyKey != "" {
+		cached, found, err := ath.resultCache.Get(ctx, idempotencyKey)
+		if err != nil {
+			// Executing the activity is always correct, the cache only avoids duplicate work
+			ath.logger.Warn("Failed to get activity result from cache.",
+				tagWorkflowID, t.WorkflowExecution.GetWorkflowId(),
+				tagRunID, t.WorkflowExecution.GetRunId(),
+				tagActivityType, activityType,
+				tagError, err)
+		} else if found {
+			ath.logger.Debug("Activity result found in cache, skipping execution.",
+				tagWorkflowID, t.WorkflowExecution.GetWorkflowId(),
+				tagRunID, t.WorkflowExecution.GetRunId(),
+				tagActivityType, activityType)
+			return convertActivityResultToRespondRequest(ath.identity, t.TaskToken, cached, nil,
+				ath.dataConverter, ath.failureConverter, ath.namespace, false, ath.versionStamp, ath.deployment, ath.workerDeploymentOptions), nil
+		}
+	}
+
+	output, err := ath.executeInPool(ctx, activityType, activityImplementation, t.Input)
 	// Check if context canceled at a higher level before we cancel it ourselves
 	// TODO : check if the cause of the context cancellation is from the server
 	isActivityCanceled := ctx.Err() == context.Canceled
@@ -2288,6 +2485,15 @@ func (ath *activityTaskHandlerImpl) Execute(taskQueue string, t *workflowservice
 		)
 		return nil, ctx.Err()
 	}
+	if err == nil && idempotencyKey != "" {
+		if err := ath.resultCache.Put(ctx, idempotencyKey, output); err != nil {
+			ath.logger.Warn("Failed to put activity result in cache.",
+				tagWorkflowID, t.WorkflowExecution.GetWorkflowId(),
+				tagRunID, t.WorkflowExecution.GetRunId(),
+				tagActivityType, activityType,
+				tagError, err)
+		}
+	}
 	if err != nil && err != ErrActivityResultPending {
 		logFunc := ath.logger.Error // Default to Error
 		if isBenignApplicationError(err) {
@@ -2301,8 +2507,40 @@ func (ath *activityTaskHandlerImpl) Execute(taskQueue string, t *workflowservice
 			tagError, err,
 		)
 	}
-	return convertActivityResultToRespondRequest(ath.identity, t.TaskToken, output, err,
-		ath.dataConverter, ath.failureConverter, ath.namespace, isActivityCanceled, ath.versionStamp, ath.deployment, ath.workerDeploymentOptions), nil
+	result = convertActivityResultToRespondRequest(ath.identity, t.TaskToken, output, err,
+		ath.dataConverter, ath.failureConverter, ath.namespace, isActivityCanceled, ath.versionStamp, ath.deployment, ath.workerDeploymentOptions)
+	if request, ok := result.(*workflowservice.RespondActivityTaskFailedRequest); ok {
+		if overrides := getActivityRetryOverrides(t.Header); len(overrides) > 0 {
+			applyActivityRetryOverride(request, overrides, t.Attempt)
+		}
+	}
+	return result, nil
+}
+
+// executeInPool executes the activity once a slot of its executor pool is available, holding the activity slot of
+// the worker while waiting. The wait is bounded by the deadline of the activity, which is not executed if the context
+// is done before then. Activities with a heartbeat timeout heartbeat while waiting, with the details of their previous
+// attempt so their progress is kept.
+func (ath *activityTaskHandlerImpl) executeInPool(
+	ctx context.Context,
+	activityType string,
+	activityImplementation activity,
+	input *commonpb.Payloads,
+) (*commonpb.Payloads, error) {
+	if ath.executorPools != nil {
+		env := getActivityEnv(ctx)
+		heartbeat := func() {
+			// Errors are reported by the cancellation of the context
+			_ = env.serviceInvoker.Heartbeat(ctx, env.heartbeatDetails, false)
+		}
+		release, err := ath.executorPools.acquire(ctx, ath.registry.getActivityExecutorPool(activityType),
+			ath.metricsHandler, env.heartbeatTimeout/2, heartbeat)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	return activityImplementation.Execute(ctx, input)
 }
 
 func (ath *activityTaskHandlerImpl) getActivity(name string) activity {
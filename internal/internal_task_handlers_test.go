@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 	"sync"
 	"testing"
@@ -20,6 +22,7 @@ import (
 	commandpb "go.temporal.io/api/command/v1"
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
+	failurepb "go.temporal.io/api/failure/v1"
 	historypb "go.temporal.io/api/history/v1"
 	protocolpb "go.temporal.io/api/protocol/v1"
 	querypb "go.temporal.io/api/query/v1"
@@ -1098,6 +1101,60 @@ func (t *TaskHandlersTestSuite) TestWorkflowTask_NondeterministicDetection() {
 	t.NotNil(request)
 }
 
+func (t *TaskHandlersTestSuite) TestWorkflowTask_NondeterminismDiagnostics() {
+	taskQueue := "taskQueue"
+	testEvents := []*historypb.HistoryEvent{
+		createTestEventWorkflowExecutionStarted(1, &historypb.WorkflowExecutionStartedEventAttributes{TaskQueue: &taskqueuepb.TaskQueue{Name: taskQueue}}),
+		createTestEventWorkflowTaskScheduled(2, &historypb.WorkflowTaskScheduledEventAttributes{TaskQueue: &taskqueuepb.TaskQueue{Name: taskQueue}}),
+		createTestEventWorkflowTaskStarted(3),
+		createTestEventWorkflowTaskCompleted(4, &historypb.WorkflowTaskCompletedEventAttributes{ScheduledEventId: 2}),
+		createTestEventActivityTaskScheduled(5, &historypb.ActivityTaskScheduledEventAttributes{
+			ActivityId:   "0",
+			ActivityType: &commonpb.ActivityType{Name: "some-other-activity"},
+			TaskQueue:    &taskqueuepb.TaskQueue{Name: taskQueue},
+		}),
+	}
+	reportDir := t.T().TempDir()
+	params := t.getTestWorkerExecutionParams()
+	params.WorkflowPanicPolicy = BlockWorkflow
+	params.NondeterminismReportDirectory = reportDir
+	taskHandler := newWorkflowTaskHandler(params, nil, t.registry)
+	newWorkflowTaskWorkerInternal(taskHandler, taskHandler, t.client, params, make(chan struct{}), nil)
+
+	task := createWorkflowTask(testEvents, 3, "HelloWorld_Workflow")
+	wftask := workflowTask{task: task}
+	wfctx := t.mustWorkflowContextImpl(&wftask, taskHandler)
+	_, err := taskHandler.ProcessWorkflowTask(&wftask, wfctx, nil)
+	wfctx.Unlock(err)
+	var mismatch historyMismatchError
+	t.ErrorAs(err, &mismatch)
+	t.Contains(err.Error(), "history event: 5 ActivityTaskScheduled")
+	t.Contains(err.Error(), "replay command: ScheduleActivityTask")
+	t.Contains(err.Error(), "surrounding command event IDs: [5]")
+	t.NotContains(err.Error(), reportDir)
+
+	// Retries of the workflow task overwrite the same report
+	runID := task.WorkflowExecution.GetRunId()
+	task = createWorkflowTask(testEvents, 3, "HelloWorld_Workflow")
+	task.WorkflowExecution.RunId = runID
+	task.Attempt = 2
+	wftask = workflowTask{task: task}
+	wfctx = t.mustWorkflowContextImpl(&wftask, taskHandler)
+	_, retryErr := taskHandler.ProcessWorkflowTask(&wftask, wfctx, nil)
+	wfctx.Unlock(retryErr)
+	t.Equal(err.Error(), retryErr.Error())
+
+	files, readErr := os.ReadDir(reportDir)
+	t.NoError(readErr)
+	t.Len(files, 1)
+	t.Equal("nondeterminism_fake-workflow-id_"+runID+"_5.txt", files[0].Name())
+	report, readErr := os.ReadFile(filepath.Join(reportDir, files[0].Name()))
+	t.NoError(readErr)
+	t.Contains(string(report), "Workflow type: HelloWorld_Workflow")
+	t.Contains(string(report), "History events:\n\t5 ActivityTaskScheduled")
+	t.Contains(string(report), "Replay commands:\n\tScheduleActivityTask")
+}
+
 func (t *TaskHandlersTestSuite) TestWorkflowTask_WorkflowReturnsPanicError() {
 	taskQueue := "taskQueue"
 	testEvents := []*historypb.HistoryEvent{
@@ -1144,6 +1201,42 @@ func (t *TaskHandlersTestSuite) TestWorkflowTask_WorkflowPanics() {
 	t.True(ok)
 }
 
+func (t *TaskHandlersTestSuite) TestWorkflowTask_WorkflowPanics_RegisteredPanicPolicy() {
+	failWorkflow := FailWorkflow
+	t.registry.RegisterWorkflowWithOptions(
+		panicWorkflowFunc,
+		RegisterWorkflowOptions{Name: "FailOnPanicWorkflow", PanicPolicy: &failWorkflow},
+	)
+	taskQueue := "taskQueue"
+	testEvents := []*historypb.HistoryEvent{
+		createTestEventWorkflowExecutionStarted(1, &historypb.WorkflowExecutionStartedEventAttributes{TaskQueue: &taskqueuepb.TaskQueue{Name: taskQueue}}),
+	}
+	params := t.getTestWorkerExecutionParams()
+	params.WorkflowPanicPolicy = BlockWorkflow
+	taskHandler := newWorkflowTaskHandler(params, nil, t.registry)
+
+	// The registered policy overrides the worker policy
+	task := createWorkflowTask(testEvents, 3, "FailOnPanicWorkflow")
+	wftask := workflowTask{task: task}
+	wfctx := t.mustWorkflowContextImpl(&wftask, taskHandler)
+	request, err := taskHandler.ProcessWorkflowTask(&wftask, wfctx, nil)
+	wfctx.Unlock(err)
+	t.NoError(err)
+	response := request.(*workflowservice.RespondWorkflowTaskCompletedRequest)
+	closeCommand := response.Commands[len(response.Commands)-1]
+	t.Equal(enumspb.COMMAND_TYPE_FAIL_WORKFLOW_EXECUTION, closeCommand.CommandType)
+	t.Contains(closeCommand.GetFailWorkflowExecutionCommandAttributes().GetFailure().GetMessage(), "FailWorkflow")
+
+	// Other workflow types still use the worker policy
+	task = createWorkflowTask(testEvents, 3, "PanicWorkflow")
+	wftask = workflowTask{task: task}
+	wfctx = t.mustWorkflowContextImpl(&wftask, taskHandler)
+	_, err = taskHandler.ProcessWorkflowTask(&wftask, wfctx, nil)
+	wfctx.Unlock(err)
+	var panicErr *workflowPanicError
+	t.ErrorAs(err, &panicErr)
+}
+
 func (t *TaskHandlersTestSuite) TestGetWorkflowInfo() {
 	parentID := "parentID"
 	parentRunID := "parentRun"
@@ -1855,6 +1948,77 @@ func (t *TaskHandlersTestSuite) TestLocalActivityRetry_Workflow() {
 	t.True(workflowComplete)
 }
 
+func (t *TaskHandlersTestSuite) TestLocalActivityRetry_LocalRetryThreshold() {
+	backoffInterval := 10 * time.Millisecond
+
+	retryLocalActivityWorkflowFunc := func(ctx Context, input []byte) error {
+		ao := LocalActivityOptions{
+			ScheduleToCloseTimeout: time.Minute,
+			RetryPolicy: &RetryPolicy{
+				InitialInterval:    backoffInterval,
+				BackoffCoefficient: 1.1,
+				MaximumInterval:    time.Minute,
+				MaximumAttempts:    5,
+			},
+			// Backoff is above the threshold so the retry must use a server timer
+			LocalRetryThreshold: time.Millisecond,
+		}
+		ctx = WithLocalActivityOptions(ctx, ao)
+
+		return ExecuteLocalActivity(ctx, func() error {
+			return errors.New("fail")
+		}).Get(ctx, nil)
+	}
+	t.registry.RegisterWorkflowWithOptions(
+		retryLocalActivityWorkflowFunc,
+		RegisterWorkflowOptions{Name: "RetryLocalActivityThresholdWorkflow"},
+	)
+
+	workflowTaskStartedEvent := createTestEventWorkflowTaskStarted(3)
+	workflowTaskStartedEvent.EventTime = timestamppb.New(time.Now())
+	testEvents := []*historypb.HistoryEvent{
+		createTestEventWorkflowExecutionStarted(1, &historypb.WorkflowExecutionStartedEventAttributes{
+			WorkflowTaskTimeout: durationpb.New(5 * time.Second),
+			TaskQueue:           &taskqueuepb.TaskQueue{Name: testWorkflowTaskTaskqueue},
+		},
+		),
+		createTestEventWorkflowTaskScheduled(2, &historypb.WorkflowTaskScheduledEventAttributes{}),
+		workflowTaskStartedEvent,
+	}
+
+	task := createWorkflowTask(testEvents, 0, "RetryLocalActivityThresholdWorkflow")
+	stopCh := make(chan struct{})
+	params := t.getTestWorkerExecutionParams()
+	params.WorkerStopChannel = stopCh
+	defer close(stopCh)
+
+	taskHandler := newWorkflowTaskHandler(params, nil, t.registry)
+	laStopCh := make(chan struct{})
+	defer close(laStopCh)
+	laTunnel := newLocalActivityTunnel(laStopCh)
+	taskHandlerImpl, ok := taskHandler.(*workflowTaskHandlerImpl)
+	t.True(ok)
+	taskHandlerImpl.laTunnel = laTunnel
+
+	laTaskPoller := newLocalActivityPoller(params, laTunnel, nil, nil)
+	go func() {
+		task, _ := laTaskPoller.PollTask()
+		_ = laTaskPoller.ProcessTask(task)
+	}()
+
+	laResultCh := make(chan *localActivityResult)
+	laRetryCh := make(chan *localActivityTask)
+	wftask := workflowTask{task: task, laResultCh: laResultCh, laRetryCh: laRetryCh}
+	wfctx := t.mustWorkflowContextImpl(&wftask, taskHandler)
+	response, err := taskHandler.ProcessWorkflowTask(&wftask, wfctx, nil)
+	t.NoError(err)
+	asWFTComplete, ok := response.(*workflowservice.RespondWorkflowTaskCompletedRequest)
+	t.True(ok)
+	t.Len(asWFTComplete.Commands, 2)
+	t.Equal(enumspb.COMMAND_TYPE_RECORD_MARKER, asWFTComplete.Commands[0].GetCommandType())
+	t.Equal(enumspb.COMMAND_TYPE_START_TIMER, asWFTComplete.Commands[1].GetCommandType())
+}
+
 func (t *TaskHandlersTestSuite) TestLocalActivityRetry_WorkflowTaskHeartbeatFail() {
 	backoffInterval := 50 * time.Millisecond
 	workflowComplete := false
@@ -2113,6 +2277,53 @@ func (t *TaskHandlersTestSuite) TestActivityExecutionDeadline() {
 	}
 }
 
+func (t *TaskHandlersTestSuite) TestActivityExecutionPanicPolicy() {
+	registry := t.registry
+	registry.RegisterActivityWithOptions(
+		func(context.Context) error { panic("retry me") },
+		RegisterActivityOptions{Name: "RetryOnPanicActivity"},
+	)
+	registry.RegisterActivityWithOptions(
+		func(context.Context) error { panic("fail me") },
+		RegisterActivityOptions{Name: "FailOnPanicActivity", PanicPolicy: FailActivity},
+	)
+
+	mockCtrl := gomock.NewController(t.T())
+	mockService := workflowservicemock.NewMockWorkflowServiceClient(mockCtrl)
+	client := WorkflowClient{workflowService: mockService}
+	activityHandler := newActivityTaskHandler(&client, t.getTestWorkerExecutionParams(), registry)
+	execute := func(activityType string) *failurepb.Failure {
+		now := time.Now()
+		r, err := activityHandler.Execute(taskqueue, &workflowservice.PollActivityTaskQueueResponse{
+			Attempt:   1,
+			TaskToken: []byte("token"),
+			WorkflowExecution: &commonpb.WorkflowExecution{
+				WorkflowId: "wID",
+				RunId:      "rID",
+			},
+			ActivityType:           &commonpb.ActivityType{Name: activityType},
+			ActivityId:             uuid.NewString(),
+			ScheduledTime:          timestamppb.New(now),
+			ScheduleToCloseTimeout: durationpb.New(time.Second),
+			StartedTime:            timestamppb.New(now),
+			StartToCloseTimeout:    durationpb.New(time.Second),
+			WorkflowType:           &commonpb.WorkflowType{Name: "wType"},
+			WorkflowNamespace:      "namespace",
+		})
+		t.NoError(err)
+		return r.(*workflowservice.RespondActivityTaskFailedRequest).GetFailure()
+	}
+
+	failure := execute("RetryOnPanicActivity")
+	t.Equal("PanicError", failure.GetApplicationFailureInfo().GetType())
+	t.False(failure.GetApplicationFailureInfo().GetNonRetryable())
+
+	failure = execute("FailOnPanicActivity")
+	t.Contains(failure.GetMessage(), "FailActivity")
+	t.True(failure.GetApplicationFailureInfo().GetNonRetryable())
+	t.Equal("PanicError", failure.GetCause().GetApplicationFailureInfo().GetType())
+}
+
 func activityWithWorkerStop(ctx context.Context) error {
 	fmt.Println("Executing Activity with worker stop")
 	workerStopCh := GetWorkerStopChannel(ctx)
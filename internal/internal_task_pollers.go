@@ -6,7 +6,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -37,6 +39,9 @@ const (
 	stickyWorkflowTaskScheduleToStartTimeoutSeconds = 5
 
 	ratioToForceCompleteWorkflowTaskComplete = 0.8
+
+	// nondeterminismHistoryTimeout bounds the fetch of the history passed to WorkerOptions.OnNondeterminism.
+	nondeterminismHistoryTimeout = time.Minute
 )
 
 type (
@@ -70,6 +75,8 @@ type (
 		deploymentSeriesName string
 		// Server's capabilities
 		capabilities *workflowservice.GetSystemInfoResponse_Capabilities
+		// Checks the schedule-to-start latencies of polled tasks, nil if the worker has no SLO
+		scheduleToStartSLO *scheduleToStartSLO
 	}
 
 	// numPollerMetric tracks the number of active pollers and publishes a metric on it.
@@ -101,6 +108,10 @@ type (
 		requestLock             sync.Mutex
 		stickyCacheSize         int
 		eagerActivityExecutor   *eagerActivityExecutor
+		// When set, only the sticky task queue is polled
+		stickyOnly atomic.Bool
+		// Called when a workflow task fails due to nondeterminism
+		onNondeterminism func(*NondeterminismDump)
 
 		numNormalPollerMetric *numPollerMetric
 		numStickyPollerMetric *numPollerMetric
@@ -109,13 +120,15 @@ type (
 	// activityTaskPoller implements polling/processing a workflow task
 	activityTaskPoller struct {
 		basePoller
-		namespace           string
-		taskQueueName       string
-		identity            string
-		service             workflowservice.WorkflowServiceClient
-		taskHandler         ActivityTaskHandler
-		logger              log.Logger
-		activitiesPerSecond float64
+		namespace     string
+		taskQueueName string
+		identity      string
+		service       workflowservice.WorkflowServiceClient
+		taskHandler   ActivityTaskHandler
+		logger        log.Logger
+		// activitiesPerSecond holds the math.Float64bits of the task queue rate limit, which can change while
+		// polling.
+		activitiesPerSecond atomic.Uint64
 		numPollerMetric     *numPollerMetric
 	}
 
@@ -147,6 +160,12 @@ type (
 		contextPropagators []ContextPropagator
 		interceptors       []WorkerInterceptor
 		client             *WorkflowClient
+		// Whether to attach stack traces to Error logs of local activities
+		errorLogStackTraces bool
+		logFieldEnricher    *logFieldEnricher
+		// How long local activities can run after their deadline before being reported, see
+		// WorkerOptions.LocalActivityWatchdogGracePeriod
+		watchdogGracePeriod time.Duration
 	}
 
 	localActivityResult struct {
@@ -277,6 +296,7 @@ func newWorkflowTaskPoller(
 			workerDeploymentVersion: params.WorkerDeploymentVersion,
 			deploymentSeriesName:    params.DeploymentSeriesName,
 			capabilities:            params.capabilities,
+			scheduleToStartSLO:      params.scheduleToStartSLO,
 		},
 		service:                      service,
 		namespace:                    params.Namespace,
@@ -293,6 +313,7 @@ func newWorkflowTaskPoller(
 		eagerActivityExecutor:        params.eagerActivityExecutor,
 		numNormalPollerMetric:        newNumPollerMetric(params.MetricsHandler, metrics.PollerTypeWorkflowTask),
 		numStickyPollerMetric:        newNumPollerMetric(params.MetricsHandler, metrics.PollerTypeWorkflowStickyTask),
+		onNondeterminism:             params.OnNondeterminism,
 	}
 }
 
@@ -460,6 +481,11 @@ func (wtp *workflowTaskPoller) RespondTaskCompletedWithMetrics(
 		failureReason := "WorkflowError"
 		if failWorkflowTask.Cause == enumspb.WORKFLOW_TASK_FAILED_CAUSE_NON_DETERMINISTIC_ERROR {
 			failureReason = "NonDeterminismError"
+			// Invoked once the failure has been reported so the hook does not delay it. Retries of the workflow task
+			// would fail the same way, so only the first attempt fetches the history.
+			if wtp.onNondeterminism != nil && task.GetAttempt() <= 1 {
+				defer func() { go wtp.dumpNondeterminism(task, taskErr) }()
+			}
 		}
 		incrementWorkflowTaskFailureCounter(metricsHandler, failureReason)
 		completedRequest = failWorkflowTask
@@ -569,6 +595,66 @@ func (wtp *workflowTaskPoller) errorToFailWorkflowTask(taskToken []byte, err err
 	return builtRequest
 }
 
+// dumpNondeterminism fetches the history of the workflow execution that failed the workflow task due to
+// nondeterminism and passes it to the user provided hook.
+func (wtp *workflowTaskPoller) dumpNondeterminism(task *workflowservice.PollWorkflowTaskQueueResponse, taskErr error) {
+	defer func() {
+		if p := recover(); p != nil {
+			wtp.logger.Error("Nondeterminism hook panicked.",
+				tagWorkflowID, task.WorkflowExecution.GetWorkflowId(),
+				tagRunID, task.WorkflowExecution.GetRunId(),
+				tagPanicError, fmt.Sprintf("%v", p))
+		}
+	}()
+	dump := &NondeterminismDump{
+		WorkflowType: task.WorkflowType.GetName(),
+		WorkflowExecution: WorkflowExecution{
+			ID:    task.WorkflowExecution.GetWorkflowId(),
+			RunID: task.WorkflowExecution.GetRunId(),
+		},
+		TaskQueue: wtp.taskQueueName,
+		Attempt:   task.GetAttempt(),
+		Error:     taskErr,
+	}
+	if mismatch, ok := taskErr.(historyMismatchError); ok {
+		dump.Commands = mismatch.replayCommands
+	}
+	dump.History, dump.HistoryError = wtp.getHistoryUntil(task.WorkflowExecution, task.GetStartedEventId())
+	if dump.HistoryError != nil {
+		wtp.logger.Warn("Failed to fetch history for nondeterminism hook.",
+			tagWorkflowID, dump.WorkflowExecution.ID,
+			tagRunID, dump.WorkflowExecution.RunID,
+			tagError, dump.HistoryError)
+	}
+	wtp.onNondeterminism(dump)
+}
+
+// getHistoryUntil fetches the history of the workflow execution up to and including the given event.
+func (wtp *workflowTaskPoller) getHistoryUntil(execution *commonpb.WorkflowExecution, lastEventID int64) (*historypb.History, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), nondeterminismHistoryTimeout)
+	defer cancel()
+	getHistoryPage := newGetHistoryPageFunc(ctx, wtp.service, wtp.namespace, execution, 0,
+		wtp.metricsHandler, wtp.taskQueueName)
+	history := &historypb.History{}
+	var nextPageToken []byte
+	for {
+		page, token, err := getHistoryPage(nextPageToken)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range page.GetEvents() {
+			if event.GetEventId() > lastEventID {
+				return history, nil
+			}
+			history.Events = append(history.Events, event)
+		}
+		if len(token) == 0 {
+			return history, nil
+		}
+		nextPageToken = token
+	}
+}
+
 func newLocalActivityPoller(
 	params workerExecutionParameters,
 	laTunnel *localActivityTunnel,
@@ -583,6 +669,10 @@ func newLocalActivityPoller(
 		contextPropagators: params.ContextPropagators,
 		interceptors:       interceptors,
 		client:             client,
+
+		errorLogStackTraces: params.EnableStackTraceInErrorLogs,
+		logFieldEnricher:    params.logFieldEnricher,
+		watchdogGracePeriod: params.LocalActivityWatchdogGracePeriod,
 	}
 	return &localActivityTaskPoller{
 		basePoller: basePoller{metricsHandler: params.MetricsHandler, stopC: params.WorkerStopChannel},
@@ -635,8 +725,17 @@ func (lath *localActivityTaskHandler) executeLocalActivityTask(task *localActivi
 			tagAttempt, task.attempt,
 		)
 	})
-	ctx, err := WithLocalActivityTask(lath.backgroundContext, task, lath.logger, lath.metricsHandler,
-		lath.dataConverter, lath.interceptors, lath.client)
+	logger := withLogFields(lath.logger, lath.logFieldEnricher.activityFields(
+		workflowType, activityType, task.params.WorkflowInfo.TaskQueueName, task.scheduledTime, task.header))
+	if lath.errorLogStackTraces {
+		logger = newStackTraceActivityLogger(logger, task.params.WorkflowInfo.TaskQueueName)
+	}
+	dataConverter := lath.dataConverter
+	if task.params.dataConverter != nil {
+		dataConverter = task.params.dataConverter
+	}
+	ctx, err := WithLocalActivityTask(lath.backgroundContext, task, logger, lath.metricsHandler,
+		dataConverter, lath.interceptors, lath.client)
 	if err != nil {
 		return &localActivityResult{task: task, err: fmt.Errorf("failed building context: %w", err)}
 	}
@@ -719,10 +818,19 @@ WaitResult:
 			metricsHandler.Counter(metrics.LocalActivityExecutionCanceledCounter).Inc(1)
 			return &localActivityResult{err: ErrCanceled, task: task}
 		} else if ctx.Err() == context.DeadlineExceeded {
+			var cause error
+			if lath.watchdogGracePeriod > 0 {
+				go lath.watchdog(doneCh, task, metricsHandler)
+				cause = NewApplicationError("local activity still running at its deadline", LocalActivityStillRunningErrorType,
+					false, nil)
+			}
 			if task.params.ScheduleToCloseTimeout != 0 && time.Now().After(info.scheduledTime.Add(task.params.ScheduleToCloseTimeout)) {
+				if cause != nil {
+					return &localActivityResult{err: NewTimeoutError("deadline exceeded", enumspb.TIMEOUT_TYPE_SCHEDULE_TO_CLOSE, cause), task: task}
+				}
 				return &localActivityResult{err: ErrDeadlineExceeded, task: task}
 			} else {
-				return &localActivityResult{err: NewTimeoutError("deadline exceeded", enumspb.TIMEOUT_TYPE_START_TO_CLOSE, nil), task: task}
+				return &localActivityResult{err: NewTimeoutError("deadline exceeded", enumspb.TIMEOUT_TYPE_START_TO_CLOSE, cause), task: task}
 			}
 		} else {
 			// should not happen
@@ -740,6 +848,31 @@ WaitResult:
 	return &localActivityResult{result: laResult, err: err, task: task}
 }
 
+// watchdog tracks a local activity whose deadline passed in the background, and reports it if it has not returned
+// after the grace period, typically because it ignores the cancellation of its context. The timeout of the local
+// activity is not delayed, it is retried as usual while the previous attempt keeps running.
+func (lath *localActivityTaskHandler) watchdog(
+	doneCh <-chan struct{},
+	task *localActivityTask,
+	metricsHandler metrics.Handler,
+) {
+	timer := time.NewTimer(lath.watchdogGracePeriod)
+	defer timer.Stop()
+	select {
+	case <-doneCh:
+		return
+	case <-timer.C:
+	}
+	metricsHandler.Counter(metrics.LocalActivityWatchdogTimeoutCounter).Inc(1)
+	lath.logger.Warn("LocalActivity still running after its deadline and grace period.",
+		tagWorkflowID, task.params.WorkflowInfo.WorkflowExecution.ID,
+		tagRunID, task.params.WorkflowInfo.WorkflowExecution.RunID,
+		tagActivityType, task.params.ActivityType,
+		tagAttempt, task.attempt,
+		"LocalActivityID", task.activityID,
+		"GracePeriod", lath.watchdogGracePeriod)
+}
+
 func (wtp *workflowTaskPoller) release(kind enumspb.TaskQueueKind) {
 	if wtp.stickyCacheSize <= 0 {
 		return
@@ -770,6 +903,7 @@ func (wtp *workflowTaskPoller) updateBacklog(taskQueueKind enumspb.TaskQueueKind
 //  2. otherwise:
 //     2.1) if sticky task queue has backlog, always prefer to process sticky task first
 //     2.2) poll from the task queue that has less pending requests (prefer sticky when they are the same).
+//     2.3) if sticky only polling is enabled, always poll for sticky task queue
 //
 // TODO: make this more smart to auto adjust based on poll latency
 func (wtp *workflowTaskPoller) getNextPollRequest() (request *workflowservice.PollWorkflowTaskQueueRequest) {
@@ -779,7 +913,7 @@ func (wtp *workflowTaskPoller) getNextPollRequest() (request *workflowservice.Po
 	}
 	if wtp.stickyCacheSize > 0 {
 		wtp.requestLock.Lock()
-		if wtp.stickyBacklog > 0 || wtp.pendingStickyPollCount <= wtp.pendingRegularPollCount {
+		if wtp.stickyOnly.Load() || wtp.stickyBacklog > 0 || wtp.pendingStickyPollCount <= wtp.pendingRegularPollCount {
 			wtp.pendingStickyPollCount++
 			taskQueue.Name = getWorkerTaskQueue(wtp.stickyUUID)
 			taskQueue.Kind = enumspb.TASK_QUEUE_KIND_STICKY
@@ -866,6 +1000,12 @@ func (wtp *workflowTaskPoller) poll(ctx context.Context) (taskForWorker, error)
 
 	scheduleToStartLatency := response.GetStartedTime().AsTime().Sub(response.GetScheduledTime().AsTime())
 	metricsHandler.Timer(metrics.WorkflowTaskScheduleToStartLatency).Record(scheduleToStartLatency)
+	wtp.scheduleToStartSLO.check(ScheduleToStartSLOViolation{
+		WorkerType:   "WorkflowWorker",
+		TaskQueue:    wtp.taskQueueName,
+		WorkflowType: response.WorkflowType.GetName(),
+		Latency:      scheduleToStartLatency,
+	})
 	return task, nil
 }
 
@@ -968,7 +1108,7 @@ func newGetHistoryPageFunc(
 }
 
 func newActivityTaskPoller(taskHandler ActivityTaskHandler, service workflowservice.WorkflowServiceClient, params workerExecutionParameters) *activityTaskPoller {
-	return &activityTaskPoller{
+	atp := &activityTaskPoller{
 		basePoller: basePoller{
 			metricsHandler:          params.MetricsHandler,
 			stopC:                   params.WorkerStopChannel,
@@ -977,16 +1117,26 @@ func newActivityTaskPoller(taskHandler ActivityTaskHandler, service workflowserv
 			workerDeploymentVersion: params.WorkerDeploymentVersion,
 			deploymentSeriesName:    params.DeploymentSeriesName,
 			capabilities:            params.capabilities,
+			scheduleToStartSLO:      params.scheduleToStartSLO,
 		},
-		taskHandler:         taskHandler,
-		service:             service,
-		namespace:           params.Namespace,
-		taskQueueName:       params.TaskQueue,
-		identity:            params.Identity,
-		logger:              params.Logger,
-		activitiesPerSecond: params.TaskQueueActivitiesPerSecond,
-		numPollerMetric:     newNumPollerMetric(params.MetricsHandler, metrics.PollerTypeActivityTask),
-	}
+		taskHandler:     taskHandler,
+		service:         service,
+		namespace:       params.Namespace,
+		taskQueueName:   params.TaskQueue,
+		identity:        params.Identity,
+		logger:          params.Logger,
+		numPollerMetric: newNumPollerMetric(params.MetricsHandler, metrics.PollerTypeActivityTask),
+	}
+	atp.setActivitiesPerSecond(params.TaskQueueActivitiesPerSecond)
+	return atp
+}
+
+func (atp *activityTaskPoller) setActivitiesPerSecond(activitiesPerSecond float64) {
+	atp.activitiesPerSecond.Store(math.Float64bits(activitiesPerSecond))
+}
+
+func (atp *activityTaskPoller) getActivitiesPerSecond() float64 {
+	return math.Float64frombits(atp.activitiesPerSecond.Load())
 }
 
 // Poll the activity task queue and update the num_poller metric
@@ -1006,7 +1156,7 @@ func (atp *activityTaskPoller) poll(ctx context.Context) (taskForWorker, error)
 		Namespace:         atp.namespace,
 		TaskQueue:         &taskqueuepb.TaskQueue{Name: atp.taskQueueName, Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
 		Identity:          atp.identity,
-		TaskQueueMetadata: &taskqueuepb.TaskQueueMetadata{MaxTasksPerSecond: wrapperspb.Double(atp.activitiesPerSecond)},
+		TaskQueueMetadata: &taskqueuepb.TaskQueueMetadata{MaxTasksPerSecond: wrapperspb.Double(atp.getActivitiesPerSecond())},
 		WorkerVersionCapabilities: &commonpb.WorkerVersionCapabilities{
 			BuildId:              atp.workerBuildID,
 			UseVersioning:        atp.useBuildIDVersioning,
@@ -1034,6 +1184,13 @@ func (atp *activityTaskPoller) poll(ctx context.Context) (taskForWorker, error)
 
 	scheduleToStartLatency := response.GetStartedTime().AsTime().Sub(response.GetCurrentAttemptScheduledTime().AsTime())
 	metricsHandler.Timer(metrics.ActivityScheduleToStartLatency).Record(scheduleToStartLatency)
+	atp.scheduleToStartSLO.check(ScheduleToStartSLOViolation{
+		WorkerType:   "ActivityWorker",
+		TaskQueue:    atp.taskQueueName,
+		WorkflowType: workflowType,
+		ActivityType: activityType,
+		Latency:      scheduleToStartLatency,
+	})
 
 	return &activityTask{task: response}, nil
 }
@@ -1339,3 +1496,15 @@ func (*eagerWorkflowTask) isEmpty() bool {
 func (nt *nexusTask) isEmpty() bool {
 	return nt.task == nil
 }
+
+func (wft *workflowTask) getPollerScalingDecision() *taskqueuepb.PollerScalingDecision {
+	return wft.task.GetPollerScalingDecision()
+}
+
+func (at *activityTask) getPollerScalingDecision() *taskqueuepb.PollerScalingDecision {
+	return at.task.GetPollerScalingDecision()
+}
+
+func (nt *nexusTask) getPollerScalingDecision() *taskqueuepb.PollerScalingDecision {
+	return nt.task.GetPollerScalingDecision()
+}
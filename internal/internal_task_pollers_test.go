@@ -10,7 +10,9 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	commandpb "go.temporal.io/api/command/v1"
 	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
 	historypb "go.temporal.io/api/history/v1"
 	protocolpb "go.temporal.io/api/protocol/v1"
 	taskqueuepb "go.temporal.io/api/taskqueue/v1"
@@ -19,6 +21,9 @@ import (
 	"go.temporal.io/api/workflowservicemock/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.temporal.io/sdk/internal/common/metrics"
 )
 
 type countingTaskHandler struct {
@@ -408,3 +413,176 @@ func TestWFTPanicInTaskHandler(t *testing.T) {
 	// Workflow should not be in cache
 	require.Nil(t, cache.getWorkflowContext(runID))
 }
+
+func TestWFTStickyOnlyPolling(t *testing.T) {
+	params := workerExecutionParameters{cache: NewWorkerCache(), TaskQueue: t.Name() + "-task-queue"}
+	ensureRequiredParams(&params)
+	ctrl := gomock.NewController(t)
+	client := workflowservicemock.NewMockWorkflowServiceClient(ctrl)
+	poller := newWorkflowTaskPoller(nil, nil, client, params)
+	require.Greater(t, poller.stickyCacheSize, 0)
+
+	// Normally alternates between sticky and normal
+	require.Equal(t, enumspb.TASK_QUEUE_KIND_STICKY, poller.getNextPollRequest().TaskQueue.Kind)
+	require.Equal(t, enumspb.TASK_QUEUE_KIND_NORMAL, poller.getNextPollRequest().TaskQueue.Kind)
+
+	// Only sticky while sticky only
+	poller.stickyOnly.Store(true)
+	for i := 0; i < 3; i++ {
+		request := poller.getNextPollRequest()
+		require.Equal(t, enumspb.TASK_QUEUE_KIND_STICKY, request.TaskQueue.Kind)
+		require.Equal(t, params.TaskQueue, request.TaskQueue.NormalName)
+	}
+
+	poller.stickyOnly.Store(false)
+	require.Equal(t, enumspb.TASK_QUEUE_KIND_NORMAL, poller.getNextPollRequest().TaskQueue.Kind)
+}
+
+func TestPollGate(t *testing.T) {
+	var gate pollGate
+	stopCh := make(chan struct{})
+	require.False(t, gate.isPaused())
+	require.True(t, gate.wait(stopCh))
+
+	gate.pause(pollPauseReasonUser)
+	gate.pause(pollPauseReasonUser)
+	gate.pause(pollPauseReasonStandby)
+	require.True(t, gate.isPaused())
+	waitResult := make(chan bool, 1)
+	go func() { waitResult <- gate.wait(stopCh) }()
+	select {
+	case <-waitResult:
+		t.Fatal("wait returned while paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+	gate.resume(pollPauseReasonUser)
+	require.True(t, gate.isPaused())
+	gate.resume(pollPauseReasonStandby)
+	require.True(t, <-waitResult)
+	require.False(t, gate.isPaused())
+
+	// Stopping unblocks waiters
+	gate.pause(pollPauseReasonUser)
+	go func() { waitResult <- gate.wait(stopCh) }()
+	close(stopCh)
+	require.False(t, <-waitResult)
+}
+
+func TestWFTNondeterminismHook(t *testing.T) {
+	dumpCh := make(chan *NondeterminismDump, 1)
+	params := workerExecutionParameters{
+		cache:            NewWorkerCache(),
+		TaskQueue:        t.Name() + "-task-queue",
+		OnNondeterminism: func(dump *NondeterminismDump) { dumpCh <- dump },
+	}
+	ensureRequiredParams(&params)
+	ctrl := gomock.NewController(t)
+	client := workflowservicemock.NewMockWorkflowServiceClient(ctrl)
+	poller := newWorkflowTaskPoller(nil, nil, client, params)
+
+	taskQueue := &taskqueuepb.TaskQueue{Name: params.TaskQueue}
+	history := &historypb.History{Events: []*historypb.HistoryEvent{
+		createTestEventWorkflowExecutionStarted(1, &historypb.WorkflowExecutionStartedEventAttributes{TaskQueue: taskQueue}),
+		createTestEventWorkflowTaskScheduled(2, &historypb.WorkflowTaskScheduledEventAttributes{TaskQueue: taskQueue}),
+		createTestEventWorkflowTaskStarted(3),
+		createTestEventWorkflowTaskFailed(4, &historypb.WorkflowTaskFailedEventAttributes{ScheduledEventId: 2}),
+	}}
+	task := &workflowservice.PollWorkflowTaskQueueResponse{
+		Attempt:           1,
+		TaskToken:         []byte("token"),
+		WorkflowExecution: &commonpb.WorkflowExecution{WorkflowId: "wid", RunId: "rid"},
+		WorkflowType:      &commonpb.WorkflowType{Name: "wtype"},
+		StartedEventId:    3,
+	}
+	commands := []*commandpb.Command{createNewCommand(enumspb.COMMAND_TYPE_START_TIMER)}
+	taskErr := historyMismatchError{message: "mismatch", replayCommands: commands}
+
+	client.EXPECT().RespondWorkflowTaskFailed(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, request *workflowservice.RespondWorkflowTaskFailedRequest, _ ...grpc.CallOption) (*workflowservice.RespondWorkflowTaskFailedResponse, error) {
+			require.Equal(t, enumspb.WORKFLOW_TASK_FAILED_CAUSE_NON_DETERMINISTIC_ERROR, request.Cause)
+			return &workflowservice.RespondWorkflowTaskFailedResponse{}, nil
+		})
+	client.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&workflowservice.GetWorkflowExecutionHistoryResponse{History: history}, nil)
+	_, err := poller.RespondTaskCompletedWithMetrics(nil, taskErr, task, time.Now())
+	require.NoError(t, err)
+
+	select {
+	case dump := <-dumpCh:
+		require.Equal(t, "wtype", dump.WorkflowType)
+		require.Equal(t, WorkflowExecution{ID: "wid", RunID: "rid"}, dump.WorkflowExecution)
+		require.Equal(t, params.TaskQueue, dump.TaskQueue)
+		require.Equal(t, taskErr, dump.Error)
+		require.NoError(t, dump.HistoryError)
+		// Events after the failed workflow task are not included
+		require.Equal(t, history.Events[:3], dump.History.Events)
+		require.Equal(t, commands, dump.Commands)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "nondeterminism hook was not called")
+	}
+
+	// Retries of the workflow task do not fetch the history again
+	task.Attempt = 2
+	_, err = poller.RespondTaskCompletedWithMetrics(nil, taskErr, task, time.Now())
+	require.NoError(t, err)
+	select {
+	case <-dumpCh:
+		require.Fail(t, "nondeterminism hook was called for a retry")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestActivityScheduleToStartSLO(t *testing.T) {
+	handler := metrics.NewCapturingHandler()
+	var violations []ScheduleToStartSLOViolation
+	params := workerExecutionParameters{
+		TaskQueue:      t.Name() + "-task-queue",
+		MetricsHandler: handler,
+		scheduleToStartSLO: newScheduleToStartSLO(&ScheduleToStartSLOOptions{
+			ActivityTaskThreshold: 5 * time.Second,
+			OnViolation:           func(violation ScheduleToStartSLOViolation) { violations = append(violations, violation) },
+		}, handler),
+	}
+	ensureRequiredParams(&params)
+	ctrl := gomock.NewController(t)
+	client := workflowservicemock.NewMockWorkflowServiceClient(ctrl)
+	poller := newActivityTaskPoller(nil, client, params)
+
+	startedTime := time.Now()
+	for _, latency := range []time.Duration{time.Second, 10 * time.Second} {
+		client.EXPECT().PollActivityTaskQueue(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&workflowservice.PollActivityTaskQueueResponse{
+				TaskToken:                   []byte("token"),
+				WorkflowType:                &commonpb.WorkflowType{Name: "wtype"},
+				ActivityType:                &commonpb.ActivityType{Name: "atype"},
+				CurrentAttemptScheduledTime: timestamppb.New(startedTime.Add(-latency)),
+				StartedTime:                 timestamppb.New(startedTime),
+			}, nil)
+		_, err := poller.poll(context.Background())
+		require.NoError(t, err)
+	}
+
+	// Only the task started after the threshold violates the SLO
+	require.Equal(t, []ScheduleToStartSLOViolation{{
+		WorkerType:   "ActivityWorker",
+		TaskQueue:    params.TaskQueue,
+		WorkflowType: "wtype",
+		ActivityType: "atype",
+		Latency:      10 * time.Second,
+		Threshold:    5 * time.Second,
+	}}, violations)
+	var violationCounters []*metrics.CapturedCounter
+	for _, counter := range handler.Counters() {
+		if counter.Name == metrics.ScheduleToStartSLOViolationCounter {
+			violationCounters = append(violationCounters, counter)
+		}
+	}
+	require.Len(t, violationCounters, 1)
+	require.Equal(t, int64(1), violationCounters[0].Value())
+	require.Equal(t, "ActivityWorker", violationCounters[0].Tags[metrics.WorkerTypeTagName])
+	require.Equal(t, "atype", violationCounters[0].Tags[metrics.ActivityTypeNameTagName])
+
+	// Workflow tasks have no threshold
+	params.scheduleToStartSLO.check(ScheduleToStartSLOViolation{WorkerType: "WorkflowWorker", Latency: time.Hour})
+	require.Len(t, violations, 1)
+}
@@ -11,9 +11,13 @@ import (
 	protocolpb "go.temporal.io/api/protocol/v1"
 	updatepb "go.temporal.io/api/update/v1"
 	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/internal/common/metrics"
 	"go.temporal.io/sdk/internal/protocol"
 )
 
+// updateRejectionFailureSource is the source of the failures of the updates rejected by Go workflows.
+const updateRejectionFailureSource = "GoSDK/UpdateRejection"
+
 type updateState string
 
 // WorkflowUpdateStage indicates the stage of an update request.
@@ -163,6 +167,9 @@ func (up *updateProtocol) Accept() {
 // Reject is called for an update if validation fails.
 func (up *updateProtocol) Reject(err error) {
 	up.requireState("reject", updateStateNew, updateStateRequestInitiated)
+	failure := up.env.GetFailureConverter().ErrorToFailure(err)
+	// Outcomes do not tell rejections apart from failures of accepted updates, so the client checks the source
+	failure.Source = updateRejectionFailureSource
 	up.env.Send(&protocolpb.Message{
 		Id:                 up.protoInstanceID + "/reject",
 		ProtocolInstanceId: up.protoInstanceID,
@@ -170,7 +177,7 @@ func (up *updateProtocol) Reject(err error) {
 			RejectedRequestMessageId:         up.requestMsgID,
 			RejectedRequestSequencingEventId: up.requestSeqID,
 			RejectedRequest:                  up.initialRequest,
-			Failure:                          up.env.GetFailureConverter().ErrorToFailure(err),
+			Failure:                          failure,
 		}),
 	})
 	up.state = updateStateCompleted
@@ -226,6 +233,29 @@ func (up *updateProtocol) checkAcceptedEvent(e *historypb.HistoryEvent) bool {
 		attrs.AcceptedRequestSequencingEventId == up.requestSeqID
 }
 
+// updateRejectionCode returns the rejection code of an update rejected with the
+// error, which is the type of the application error, if any.
+func updateRejectionCode(err error) string {
+	var applicationErr *ApplicationError
+	if errors.As(err, &applicationErr) {
+		return applicationErr.Type()
+	}
+	return ""
+}
+
+// reportUpdateRejection logs the rejection of an update and counts it in the
+// temporal_workflow_update_rejected metric.
+func reportUpdateRejection(env WorkflowEnvironment, name, id string, err error) {
+	code := updateRejectionCode(err)
+	env.GetLogger().Info("Workflow update rejected",
+		tagUpdateID, id,
+		tagUpdateName, name,
+		tagRejectionCode, code,
+		tagError, err)
+	env.GetMetricsHandler().WithTags(metrics.UpdateRejectionTags(name, code)).
+		Counter(metrics.WorkflowUpdateRejectedCounter).Inc(1)
+}
+
 // defaultHandler receives the initial invocation of an update during WFT
 // processing. The implementation will verify that an updateHandler exists for
 // the supplied name (rejecting the update otherwise) and use the provided spawn
@@ -243,9 +273,13 @@ func defaultUpdateHandler(
 	scheduler UpdateScheduler,
 ) {
 	env := getWorkflowEnvironment(rootCtx)
+	reject := func(err error) {
+		reportUpdateRejection(env, name, id, err)
+		callbacks.Reject(err)
+	}
 	ctx, err := workflowContextWithHeaderPropagated(rootCtx, header, env.GetContextPropagators())
 	if err != nil {
-		callbacks.Reject(err)
+		reject(err)
 		return
 	}
 	eo := getWorkflowEnvOptions(ctx)
@@ -268,7 +302,7 @@ func defaultUpdateHandler(
 			for k := range eo.updateHandlers {
 				keys = append(keys, k)
 			}
-			callbacks.Reject(fmt.Errorf("unknown update %v. KnownUpdates=%v", name, keys))
+			reject(fmt.Errorf("unknown update %v. KnownUpdates=%v", name, keys))
 			return
 		}
 
@@ -278,7 +312,7 @@ func defaultUpdateHandler(
 			serializedArgs,
 		)
 		if err != nil {
-			callbacks.Reject(fmt.Errorf("unable to decode the input for update %q: %w", name, err))
+			reject(fmt.Errorf("unable to decode the input for update %q: %w", name, err))
 			return
 		}
 		input := UpdateInput{Name: name, Args: args}
@@ -297,7 +331,7 @@ func defaultUpdateHandler(
 				return envInterceptor.inboundInterceptor.ValidateUpdate(ctx, &input)
 			}()
 			if err != nil {
-				callbacks.Reject(err)
+				reject(err)
 				return
 			}
 		}
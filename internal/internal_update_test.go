@@ -13,6 +13,8 @@ import (
 	updatepb "go.temporal.io/api/update/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/internal/common/metrics"
+	ilog "go.temporal.io/sdk/internal/log"
 	"go.temporal.io/sdk/internal/protocol"
 )
 
@@ -176,6 +178,8 @@ func TestDefaultUpdateHandler(t *testing.T) {
 				TaskQueueName: "taskqueue:" + t.Name(),
 			},
 			bufferedUpdateRequests: make(map[string][]func()),
+			logger:                 ilog.NewMemoryLogger(),
+			metricsHandler:         metrics.NewCapturingHandler(),
 		}
 	}
 
@@ -268,6 +272,47 @@ func TestDefaultUpdateHandler(t *testing.T) {
 		require.Equal(t, validatorFunc(ctx, argStr), rejectErr)
 	})
 
+	t.Run("reject from validator with code", func(t *testing.T) {
+		env := createTestWfEnv()
+		interceptor, ctx, err := newWorkflowContext(env, nil)
+		require.NoError(t, err)
+
+		updateFunc := func(Context, string) error { panic("should not get called") }
+		validatorFunc := func(Context, string) error { return NewApplicationError("too late", "OrderShipped", false, nil) }
+		dispatcher, ctx := newDispatcher(
+			ctx,
+			interceptor,
+			func(ctx Context) {
+				mustSetUpdateHandler(
+					t,
+					ctx,
+					t.Name(),
+					updateFunc,
+					UpdateHandlerOptions{Validator: validatorFunc},
+				)
+			},
+			env.DrainUnhandledUpdates)
+		var rejectErr error
+		defaultUpdateHandler(ctx, t.Name(), "testID", args, hdr, &testUpdateCallbacks{
+			RejectImpl: func(err error) { rejectErr = err },
+		}, runOnCallingThread)
+		require.NoError(t, dispatcher.ExecuteUntilAllBlocked(10*time.Second))
+		require.ErrorContains(t, rejectErr, "too late")
+
+		counters := env.metricsHandler.(*metrics.CapturingHandler).Counters()
+		require.Len(t, counters, 1)
+		require.Equal(t, metrics.WorkflowUpdateRejectedCounter, counters[0].Name)
+		require.Equal(t, map[string]string{
+			metrics.UpdateNameTagName:    t.Name(),
+			metrics.RejectionCodeTagName: "OrderShipped",
+		}, counters[0].Tags)
+		require.Equal(t, int64(1), counters[0].Value())
+		lines := env.logger.(*ilog.MemoryLogger).Lines()
+		require.Len(t, lines, 1)
+		require.Contains(t, lines[0], "Workflow update rejected")
+		require.Contains(t, lines[0], "RejectionCode OrderShipped")
+	})
+
 	t.Run("illegal state panic from validator", func(t *testing.T) {
 		env := createTestWfEnv()
 		interceptor, ctx, err := newWorkflowContext(env, nil)
@@ -486,6 +531,33 @@ func TestInvalidUpdateStateTransitions(t *testing.T) {
 	})
 }
 
+func TestRejectionFailureSource(t *testing.T) {
+	stubUpdateHandler := func(string, string, *commonpb.Payloads, *commonpb.Header, UpdateCallbacks) {}
+	requestMsg := protocolpb.Message{
+		Id:                 t.Name() + "-id",
+		ProtocolInstanceId: t.Name() + "-proto-id",
+		Body:               protocol.MustMarshalAny(&updatepb.Request{}),
+	}
+	env := &workflowEnvironmentImpl{
+		sdkFlags:         testSDKFlags,
+		commandsHelper:   newCommandsHelper(),
+		dataConverter:    converter.GetDefaultDataConverter(),
+		failureConverter: GetDefaultFailureConverter(),
+	}
+	up := newUpdateProtocol(t.Name(), stubUpdateHandler, env)
+	require.NoError(t, up.HandleMessage(&requestMsg))
+	up.Reject(NewApplicationError("too late", "OrderShipped", false, nil))
+	require.Len(t, env.outbox, 1)
+	var rejection updatepb.Rejection
+	require.NoError(t, env.outbox[0].msg.Body.UnmarshalTo(&rejection))
+	require.Equal(t, updateRejectionFailureSource, rejection.GetFailure().GetSource())
+
+	client := &WorkflowClient{failureConverter: GetDefaultFailureConverter()}
+	var rejectedErr *WorkflowUpdateRejectedError
+	require.ErrorAs(t, client.updateFailureToError(rejection.GetFailure()), &rejectedErr)
+	require.Equal(t, "OrderShipped", rejectedErr.Code())
+}
+
 func TestCompletedEventPredicate(t *testing.T) {
 	updateID := t.Name() + "-update-id"
 	stubUpdateHandler := func(string, string, *commonpb.Payloads, *commonpb.Header, UpdateCallbacks) {}
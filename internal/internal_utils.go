@@ -48,6 +48,10 @@ type grpcContextBuilder struct {
 	Headers metadata.MD
 
 	IsLongPoll bool
+
+	// Set when Timeout was explicitly set for the call, so it is not replaced by ConnectionOptions.CallTimeouts.
+	// The timeouts of long polls are always replaced.
+	explicitTimeout bool
 }
 
 func (cb *grpcContextBuilder) Build() (context.Context, context.CancelFunc) {
@@ -64,7 +68,24 @@ func (cb *grpcContextBuilder) Build() (context.Context, context.CancelFunc) {
 	ctx = context.WithValue(ctx, metrics.LongPollContextKey{}, cb.IsLongPoll)
 	var cancel context.CancelFunc
 	if cb.Timeout != time.Duration(0) {
-		ctx, cancel = context.WithTimeout(ctx, cb.Timeout)
+		if cb.IsLongPoll || !cb.explicitTimeout {
+			parent := cb.ParentContext
+			if parent == nil {
+				parent = context.Background()
+			}
+			// The deadline callTimeoutsInterceptor replaces the timeout with is derived from the parent, which
+			// must still be canceled along with the call, like when workers stop polling.
+			parent, cancelParent := context.WithCancel(parent)
+			ctx = context.WithValue(ctx, callTimeoutParentContextKey{}, parent)
+			ctx, cancel = context.WithTimeout(ctx, cb.Timeout)
+			cancelTimeout := cancel
+			cancel = func() {
+				cancelTimeout()
+				cancelParent()
+			}
+		} else {
+			ctx, cancel = context.WithTimeout(ctx, cb.Timeout)
+		}
 	}
 
 	return ctx, cancel
@@ -73,6 +94,7 @@ func (cb *grpcContextBuilder) Build() (context.Context, context.CancelFunc) {
 func grpcTimeout(timeout time.Duration) func(builder *grpcContextBuilder) {
 	return func(b *grpcContextBuilder) {
 		b.Timeout = timeout
+		b.explicitTimeout = true
 	}
 }
 
@@ -100,23 +122,9 @@ func defaultGrpcRetryParameters(ctx context.Context) func(builder *grpcContextBu
 
 // newGRPCContext - Get context for gRPC calls.
 func newGRPCContext(ctx context.Context, options ...func(builder *grpcContextBuilder)) (context.Context, context.CancelFunc) {
-	rpcTimeout := defaultRPCTimeout
-
-	// Set rpc timeout less than context timeout to allow for retries when call gets lost
-	now := time.Now()
-	if deadline, ok := ctx.Deadline(); ok && deadline.After(now) {
-		rpcTimeout = deadline.Sub(now) / 2
-		// Make sure to not set rpc timeout lower than minRPCTimeout
-		if rpcTimeout < minRPCTimeout {
-			rpcTimeout = minRPCTimeout
-		} else if rpcTimeout > maxRPCTimeout {
-			rpcTimeout = maxRPCTimeout
-		}
-	}
-
 	builder := &grpcContextBuilder{
 		ParentContext: ctx,
-		Timeout:       rpcTimeout,
+		Timeout:       rpcTimeoutWithin(ctx, defaultRPCTimeout, maxRPCTimeout),
 		Headers: metadata.New(map[string]string{
 			clientNameHeaderName:              clientNameHeaderValue,
 			clientVersionHeaderName:           SDKVersion,
@@ -131,6 +139,25 @@ func newGRPCContext(ctx context.Context, options ...func(builder *grpcContextBui
 	return builder.Build()
 }
 
+// rpcTimeoutWithin returns the timeout of a call made with the context: defaultTimeout if the context has no
+// deadline, otherwise half of its remaining time, to allow for retries when the call gets lost, between
+// minRPCTimeout and maxTimeout.
+func rpcTimeoutWithin(ctx context.Context, defaultTimeout, maxTimeout time.Duration) time.Duration {
+	now := time.Now()
+	deadline, ok := ctx.Deadline()
+	if !ok || !deadline.After(now) {
+		return defaultTimeout
+	}
+	rpcTimeout := deadline.Sub(now) / 2
+	// Make sure to not set rpc timeout lower than minRPCTimeout
+	if rpcTimeout < minRPCTimeout {
+		rpcTimeout = minRPCTimeout
+	} else if rpcTimeout > maxTimeout {
+		rpcTimeout = maxTimeout
+	}
+	return rpcTimeout
+}
+
 // GetWorkerIdentity gets a default identity for the worker.
 func getWorkerIdentity(taskqueueName string) string {
 	return fmt.Sprintf("%d@%s@%s", os.Getpid(), getHostName(), taskqueueName)
@@ -0,0 +1,102 @@
+package internal
+
+import (
+	"sort"
+	"sync"
+)
+
+type (
+	// VersionUsageReport describes the versions GetVersion returned in the workflows replayed by a
+	// WorkflowReplayer, to find the changes whose old versions are no longer used and can be removed.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.VersionUsageReport]
+	VersionUsageReport struct {
+		// Changes are the changes GetVersion was called for, sorted by change ID.
+		Changes []ChangeVersionUsage
+	}
+
+	// ChangeVersionUsage describes the versions GetVersion returned for a change.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.ChangeVersionUsage]
+	ChangeVersionUsage struct {
+		// ChangeID is the change ID passed to GetVersion.
+		ChangeID string
+		// Versions maps each version returned for the change to the number of workflow executions it was
+		// returned in.
+		Versions map[Version]int
+		// MaxSupported is the highest maximum supported version GetVersion was called with for the change.
+		MaxSupported Version
+	}
+
+	// versionUsageRecorder collects the versions returned by GetVersion, see WorkflowReplayer.GetVersionUsageReport.
+	versionUsageRecorder struct {
+		lock    sync.Mutex
+		changes map[string]*ChangeVersionUsage
+	}
+)
+
+// OldVersionsUsed returns whether a version lower than the maximum supported one was returned for the change. If
+// not, the branches for the older versions were not executed by any replayed workflow.
+func (u ChangeVersionUsage) OldVersionsUsed() bool {
+	for version := range u.Versions {
+		if version < u.MaxSupported {
+			return true
+		}
+	}
+	return false
+}
+
+// Change returns the usage of the change, and false if GetVersion was not called for it.
+func (r *VersionUsageReport) Change(changeID string) (ChangeVersionUsage, bool) {
+	for _, change := range r.Changes {
+		if change.ChangeID == changeID {
+			return change, true
+		}
+	}
+	return ChangeVersionUsage{}, false
+}
+
+func newVersionUsageRecorder() *versionUsageRecorder {
+	return &versionUsageRecorder{changes: map[string]*ChangeVersionUsage{}}
+}
+
+// record records the version returned for a change in a workflow execution. Does nothing if r is nil.
+func (r *versionUsageRecorder) record(changeID string, version, maxSupported Version) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	change, ok := r.changes[changeID]
+	if !ok {
+		change = &ChangeVersionUsage{ChangeID: changeID, Versions: map[Version]int{}, MaxSupported: maxSupported}
+		r.changes[changeID] = change
+	}
+	change.Versions[version]++
+	if maxSupported > change.MaxSupported {
+		change.MaxSupported = maxSupported
+	}
+}
+
+func (r *versionUsageRecorder) report() *VersionUsageReport {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	report := &VersionUsageReport{}
+	for _, change := range r.changes {
+		versions := make(map[Version]int, len(change.Versions))
+		for version, count := range change.Versions {
+			versions[version] = count
+		}
+		report.Changes = append(report.Changes, ChangeVersionUsage{
+			ChangeID:     change.ChangeID,
+			Versions:     versions,
+			MaxSupported: change.MaxSupported,
+		})
+	}
+	sort.Slice(report.Changes, func(i, j int) bool { return report.Changes[i].ChangeID < report.Changes[j].ChangeID })
+	return report
+}
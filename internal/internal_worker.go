@@ -26,10 +26,13 @@ import (
 	"go.temporal.io/api/temporalproto"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/api/workflowservicemock/v1"
+	"golang.org/x/time/rate"
 	"google.golang.org/protobuf/proto"
 
 	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/internal/common/backoff"
 	"go.temporal.io/sdk/internal/common/metrics"
+	"go.temporal.io/sdk/internal/common/retry"
 	"go.temporal.io/sdk/internal/common/serializer"
 	"go.temporal.io/sdk/internal/common/util"
 	ilog "go.temporal.io/sdk/internal/log"
@@ -61,6 +64,12 @@ const (
 	// as during debugging.
 	unlimitedDeadlockDetectionTimeout = math.MaxInt64
 
+	defaultPayloadSizeWarningThreshold = 512 * 1024 // The server warns about payloads above 512 KiB by default.
+
+	// Backoff between worker start gate attempts
+	startGateRetryInitialInterval = time.Second
+	startGateRetryMaxInterval     = 30 * time.Second
+
 	testTagsContextKey = "temporal-testTags"
 )
 
@@ -122,9 +131,17 @@ type (
 		// MaxConcurrentActivityTaskQueuePollers is the max number of pollers for activity task queue.
 		MaxConcurrentActivityTaskQueuePollers int
 
+		// ActivityTaskPollerAutoscaling scales the number of pollers for activity task queue instead of using
+		// MaxConcurrentActivityTaskQueuePollers, if set.
+		ActivityTaskPollerAutoscaling *PollerBehaviorAutoscaling
+
 		// MaxConcurrentWorkflowTaskQueuePollers is the max number of pollers for workflow task queue.
 		MaxConcurrentWorkflowTaskQueuePollers int
 
+		// WorkflowTaskPollerAutoscaling scales the number of pollers for workflow task queue instead of using
+		// MaxConcurrentWorkflowTaskQueuePollers, if set.
+		WorkflowTaskPollerAutoscaling *PollerBehaviorAutoscaling
+
 		// Defines rate limiting on number of local activities that can be executed per second per worker.
 		WorkerLocalActivitiesPerSecond float64
 
@@ -134,6 +151,10 @@ type (
 		// MaxConcurrentNexusTaskQueuePollers is the max number of pollers for the nexus task queue.
 		MaxConcurrentNexusTaskQueuePollers int
 
+		// NexusTaskPollerAutoscaling scales the number of pollers for the nexus task queue instead of using
+		// MaxConcurrentNexusTaskQueuePollers, if set.
+		NexusTaskPollerAutoscaling *PollerBehaviorAutoscaling
+
 		// User can provide an identity for the debuggability. If not provided the framework has
 		// a default option.
 		Identity string
@@ -163,6 +184,21 @@ type (
 		// Enable logging in replay mode
 		EnableLoggingInReplay bool
 
+		// Sampling of workflow logger output
+		WorkflowLogSampling WorkflowLogSamplingOptions
+
+		// Attach stack traces to Error logs from workflow and activity loggers
+		EnableStackTraceInErrorLogs bool
+
+		// Grace period of the local activity watchdog after the deadline of local activities
+		LocalActivityWatchdogGracePeriod time.Duration
+
+		// Directory to write nondeterminism reports to
+		NondeterminismReportDirectory string
+
+		// Called when a workflow task fails due to nondeterminism
+		OnNondeterminism func(*NondeterminismDump)
+
 		// Context to store user provided key/value pairs
 		BackgroundContext context.Context
 
@@ -199,16 +235,49 @@ type (
 		// DeadlockDetectionTimeout specifies workflow task timeout.
 		DeadlockDetectionTimeout time.Duration
 
+		// PayloadSizeWarningThreshold and PayloadSizeErrorThreshold are the sizes of the payloads of commands above
+		// which a warning is logged or the workflow task fails, disabled if not positive.
+		PayloadSizeWarningThreshold int
+		PayloadSizeErrorThreshold   int
+
 		DefaultHeartbeatThrottleInterval time.Duration
 
 		MaxHeartbeatThrottleInterval time.Duration
 
+		// SlotExhaustionWarningThreshold is how long a poller waits for a slot before slots are reported as
+		// exhausted, zero disables reporting.
+		SlotExhaustionWarningThreshold time.Duration
+
+		// ActivityResultCache stores results of activities with an idempotency key, nil if not set.
+		ActivityResultCache ActivityResultCache
+
+		// Limits the concurrent executions of the activities in executor pools, nil if there are none
+		activityExecutorPools *activityExecutorPools
+
+		// Checks the schedule-to-start latencies of polled tasks, nil if the worker has no SLO
+		scheduleToStartSLO *scheduleToStartSLO
+
 		// Pointer to the shared worker cache
 		cache *WorkerCache
 
 		eagerActivityExecutor *eagerActivityExecutor
 
 		capabilities *workflowservice.GetSystemInfoResponse_Capabilities
+
+		// Shared by the workers polling the server so they can be paused together
+		pollGate *pollGate
+
+		// Why and until when the worker is stopping, shared with its activities
+		stopState *workerStopState
+
+		// Nil if no MetricsTagProvider is set
+		metricsTagEnricher *metricsTagEnricher
+
+		// Nil if no log fields beyond the default ones are attached
+		logFieldEnricher *logFieldEnricher
+
+		// Nil unless replaying with a WorkflowReplayer
+		versionUsage *versionUsageRecorder
 	}
 
 	// HistoryJSONOptions are options for HistoryFromJSON.
@@ -327,6 +396,9 @@ func newWorkflowTaskWorkerInternal(
 		slotReservationData: slotReservationData{
 			taskQueue: params.TaskQueue,
 		},
+		slotExhaustionThreshold: params.SlotExhaustionWarningThreshold,
+		pollGate:                params.pollGate,
+		pollerAutoscaling:       params.WorkflowTaskPollerAutoscaling,
 	},
 	)
 
@@ -362,6 +434,7 @@ func newWorkflowTaskWorkerInternal(
 		slotReservationData: slotReservationData{
 			taskQueue: params.TaskQueue,
 		},
+		slotExhaustionThreshold: laParams.SlotExhaustionWarningThreshold,
 	},
 	)
 
@@ -417,6 +490,7 @@ func newSessionWorker(client *WorkflowClient, params workerExecutionParameters,
 		&workerOverrides{slotSupplier: params.Tuner.GetSessionActivitySlotSupplier()}, env, nil)
 
 	params.MaxConcurrentActivityTaskQueuePollers = 1
+	params.ActivityTaskPollerAutoscaling = nil
 	params.TaskQueue = creationTaskqueue
 	// Although we have session token bucket to limit session size across creation
 	// and recreation, we also limit it here for creation only
@@ -483,6 +557,7 @@ func newActivityWorker(
 	base := newBaseWorker(
 		baseWorkerOptions{
 			pollerCount:             params.MaxConcurrentActivityTaskQueuePollers,
+			pollerAutoscaling:       params.ActivityTaskPollerAutoscaling,
 			pollerRate:              defaultPollerRate,
 			slotSupplier:            slotSupplier,
 			maxTaskPerSecond:        params.WorkerActivitiesPerSecond,
@@ -499,6 +574,8 @@ func newActivityWorker(
 			slotReservationData: slotReservationData{
 				taskQueue: params.TaskQueue,
 			},
+			slotExhaustionThreshold: params.SlotExhaustionWarningThreshold,
+			pollGate:                params.pollGate,
 		},
 	)
 	return &activityWorker{
@@ -533,8 +610,12 @@ type registry struct {
 	workflowFuncMap               map[string]interface{}
 	workflowAliasMap              map[string]string
 	workflowVersioningBehaviorMap map[string]VersioningBehavior
+	workflowPanicPolicyMap        map[string]WorkflowPanicPolicy
+	workflowInputValidatorMap     map[string]interface{}
 	activityFuncMap               map[string]activity
 	activityAliasMap              map[string]string
+	activityPanicPolicyMap        map[string]ActivityPanicPolicy
+	activityExecutorPoolMap       map[string]string
 	interceptors                  []WorkerInterceptor
 }
 
@@ -559,10 +640,14 @@ func (r *registry) RegisterWorkflowWithOptions(
 		if strings.HasPrefix(options.Name, temporalPrefix) {
 			panic(temporalPrefixError)
 		}
+		if options.InputValidator != nil {
+			panic("WorkflowDefinitionFactory cannot be registered with an input validator")
+		}
 		r.Lock()
 		defer r.Unlock()
 		r.workflowFuncMap[options.Name] = factory
 		r.workflowVersioningBehaviorMap[options.Name] = options.VersioningBehavior
+		r.setWorkflowPanicPolicyNoLock(options.Name, options.PanicPolicy)
 		return
 	}
 	// Validate that it is a function
@@ -570,6 +655,14 @@ func (r *registry) RegisterWorkflowWithOptions(
 	if err := validateFnFormat(fnType, true); err != nil {
 		panic(err)
 	}
+	if options.InputValidator != nil {
+		if err := validateValidatorFn(options.InputValidator); err != nil {
+			panic(err)
+		}
+		if err := validateEquivalentParams(wf, options.InputValidator); err != nil {
+			panic(fmt.Errorf("input validator parameters must match the workflow: %w", err))
+		}
+	}
 	fnName, _ := getFunctionName(wf)
 	alias := options.Name
 	registerName := fnName
@@ -591,6 +684,12 @@ func (r *registry) RegisterWorkflowWithOptions(
 	}
 	r.workflowFuncMap[registerName] = wf
 	r.workflowVersioningBehaviorMap[registerName] = options.VersioningBehavior
+	r.setWorkflowPanicPolicyNoLock(registerName, options.PanicPolicy)
+	if options.InputValidator != nil {
+		r.workflowInputValidatorMap[registerName] = options.InputValidator
+	} else {
+		delete(r.workflowInputValidatorMap, registerName)
+	}
 
 	if len(alias) > 0 && r.workflowAliasMap != nil {
 		r.workflowAliasMap[fnName] = alias
@@ -615,6 +714,10 @@ func (r *registry) RegisterActivityWithOptions(
 			panic(temporalPrefixError)
 		}
 		r.addActivityWithLock(options.Name, a)
+		r.Lock()
+		r.activityPanicPolicyMap[options.Name] = options.PanicPolicy
+		r.activityExecutorPoolMap[options.Name] = options.ExecutorPool
+		r.Unlock()
 		return
 	}
 	// Validate that it is a function
@@ -649,6 +752,8 @@ func (r *registry) RegisterActivityWithOptions(
 		}
 	}
 	r.activityFuncMap[registerName] = &activityExecutor{name: registerName, fn: af}
+	r.activityPanicPolicyMap[registerName] = options.PanicPolicy
+	r.activityExecutorPoolMap[registerName] = options.ExecutorPool
 	if len(alias) > 0 && r.activityAliasMap != nil {
 		r.activityAliasMap[fnName] = alias
 	}
@@ -683,6 +788,8 @@ func (r *registry) registerActivityStructWithOptions(aStruct interface{}, option
 			}
 		}
 		r.activityFuncMap[registerName] = &activityExecutor{name: registerName, fn: methodValue.Interface()}
+		r.activityPanicPolicyMap[registerName] = options.PanicPolicy
+		r.activityExecutorPoolMap[registerName] = options.ExecutorPool
 		count++
 	}
 	if count == 0 {
@@ -788,7 +895,12 @@ func (r *registry) getWorkflowDefinition(wt WorkflowType) (WorkflowDefinition, e
 	if ok {
 		return wdf.NewWorkflowDefinition(), nil
 	}
-	executor := &workflowExecutor{workflowType: lookup, fn: wf, interceptors: r.interceptors}
+	executor := &workflowExecutor{
+		workflowType:   lookup,
+		fn:             wf,
+		inputValidator: r.getWorkflowInputValidator(wt),
+		interceptors:   r.interceptors,
+	}
 	return newSyncWorkflowDefinition(executor), nil
 }
 
@@ -803,6 +915,47 @@ func (r *registry) getWorkflowVersioningBehavior(wt WorkflowType) (VersioningBeh
 	return behavior, behavior != VersioningBehaviorUnspecified
 }
 
+func (r *registry) setWorkflowPanicPolicyNoLock(workflowType string, policy *WorkflowPanicPolicy) {
+	if policy != nil {
+		r.workflowPanicPolicyMap[workflowType] = *policy
+	} else {
+		delete(r.workflowPanicPolicyMap, workflowType)
+	}
+}
+
+func (r *registry) getWorkflowPanicPolicy(wt WorkflowType) (WorkflowPanicPolicy, bool) {
+	lookup := wt.Name
+	if alias, ok := r.getWorkflowAlias(lookup); ok {
+		lookup = alias
+	}
+	r.Lock()
+	defer r.Unlock()
+	policy, ok := r.workflowPanicPolicyMap[lookup]
+	return policy, ok
+}
+
+func (r *registry) getWorkflowInputValidator(wt WorkflowType) interface{} {
+	lookup := wt.Name
+	if alias, ok := r.getWorkflowAlias(lookup); ok {
+		lookup = alias
+	}
+	r.Lock()
+	defer r.Unlock()
+	return r.workflowInputValidatorMap[lookup]
+}
+
+func (r *registry) getActivityPanicPolicy(activityType string) ActivityPanicPolicy {
+	r.Lock()
+	defer r.Unlock()
+	return r.activityPanicPolicyMap[activityType]
+}
+
+func (r *registry) getActivityExecutorPool(activityType string) string {
+	r.Lock()
+	defer r.Unlock()
+	return r.activityExecutorPoolMap[activityType]
+}
+
 func (r *registry) getNexusService(service string) *nexus.Service {
 	r.Lock()
 	defer r.Unlock()
@@ -873,7 +1026,11 @@ func newRegistryWithOptions(options registryOptions) *registry {
 	r := &registry{
 		workflowFuncMap:               make(map[string]interface{}),
 		workflowVersioningBehaviorMap: make(map[string]VersioningBehavior),
+		workflowPanicPolicyMap:        make(map[string]WorkflowPanicPolicy),
+		workflowInputValidatorMap:     make(map[string]interface{}),
 		activityFuncMap:               make(map[string]activity),
+		activityPanicPolicyMap:        make(map[string]ActivityPanicPolicy),
+		activityExecutorPoolMap:       make(map[string]string),
 		nexusServices:                 make(map[string]*nexus.Service),
 	}
 	if !options.disableAliasing {
@@ -883,11 +1040,17 @@ func newRegistryWithOptions(options registryOptions) *registry {
 	return r
 }
 
+// workflowInputValidationErrorType is the type of the application error failing workflows rejected by their input
+// validator.
+const workflowInputValidationErrorType = "WorkflowInputValidationError"
+
 // Wrapper to execute workflow functions.
 type workflowExecutor struct {
 	workflowType string
 	fn           interface{}
-	interceptors []WorkerInterceptor
+	// Nil if the workflow has no input validator
+	inputValidator interface{}
+	interceptors   []WorkerInterceptor
 }
 
 func (we *workflowExecutor) Execute(ctx Context, input *commonpb.Payloads) (*commonpb.Payloads, error) {
@@ -900,6 +1063,16 @@ func (we *workflowExecutor) Execute(ctx Context, input *commonpb.Payloads) (*com
 			"unable to decode the workflow function input payload with error: %w, function name: %v",
 			err, we.workflowType)
 	}
+	// The validator also runs on replay, as whether the workflow func runs is part of the workflow logic
+	if we.inputValidator != nil {
+		if _, err := executeFunctionWithWorkflowContext(ctx, we.inputValidator, args); err != nil {
+			return nil, NewApplicationErrorWithOptions(
+				fmt.Sprintf("workflow input rejected by validator: %v", err),
+				workflowInputValidationErrorType,
+				ApplicationErrorOptions{NonRetryable: true, Cause: err},
+			)
+		}
+	}
 
 	envInterceptor := getWorkflowEnvironmentInterceptor(ctx)
 	envInterceptor.fn = we.fn
@@ -1015,6 +1188,10 @@ type AggregatedWorker struct {
 	fatalErr     error
 	fatalErrLock sync.Mutex
 	capabilities *workflowservice.GetSystemInfoResponse_Capabilities
+	// Set while the worker is in standby, waiting to be promoted
+	standby          atomic.Bool
+	waitForPromotion func(ctx context.Context) error
+	startGate        func(ctx context.Context) error
 }
 
 // RegisterWorkflow registers workflow implementation with the AggregatedWorker
@@ -1051,6 +1228,9 @@ func (aw *AggregatedWorker) RegisterActivity(a interface{}) {
 
 // RegisterActivityWithOptions registers activity implementation with the AggregatedWorker
 func (aw *AggregatedWorker) RegisterActivityWithOptions(a interface{}, options RegisterActivityOptions) {
+	if options.ExecutorPool != "" && !aw.executionParams.activityExecutorPools.has(options.ExecutorPool) {
+		panic(fmt.Sprintf("activity executor pool %q is not in the ActivityExecutorPools of the worker", options.ExecutorPool))
+	}
 	aw.registry.RegisterActivityWithOptions(a, options)
 }
 
@@ -1151,10 +1331,63 @@ func (aw *AggregatedWorker) start() error {
 			return fmt.Errorf("failed to start a nexus worker: %w", err)
 		}
 	}
+	if aw.startGate != nil {
+		go aw.runStartGate()
+	}
+	if aw.waitForPromotion != nil && aw.standby.Load() {
+		go aw.runPromotionHook()
+	}
 	aw.logger.Info("Started Worker")
 	return nil
 }
 
+// runStartGate retries the start gate until it succeeds, then allows polling to begin.
+func (aw *AggregatedWorker) runStartGate() {
+	ctx, cancel := aw.stopContext()
+	defer cancel()
+	policy := backoff.NewExponentialRetryPolicy(startGateRetryInitialInterval)
+	policy.SetMaximumInterval(startGateRetryMaxInterval)
+	policy.SetExpirationInterval(retry.UnlimitedInterval)
+	err := backoff.Retry(ctx, func() error {
+		err := aw.startGate(ctx)
+		if err != nil && ctx.Err() == nil {
+			aw.logger.Warn("Worker start gate failed, will retry", tagError, err)
+		}
+		return err
+	}, policy, nil)
+	if err != nil {
+		return
+	}
+	aw.executionParams.pollGate.resume(pollPauseReasonStartGate)
+	aw.logger.Info("Worker start gate passed")
+}
+
+// stopContext returns a context that is canceled when the worker is stopped.
+func (aw *AggregatedWorker) stopContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-aw.stopC:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// runPromotionHook promotes the worker once waitForPromotion returns successfully.
+func (aw *AggregatedWorker) runPromotionHook() {
+	ctx, cancel := aw.stopContext()
+	defer cancel()
+	if err := aw.waitForPromotion(ctx); err != nil {
+		if ctx.Err() == nil {
+			aw.logger.Error("Standby worker promotion hook failed, worker remains in standby", tagError, err)
+		}
+		return
+	}
+	aw.Promote()
+}
+
 func (aw *AggregatedWorker) assertNotStopped() {
 	stopped := true
 	select {
@@ -1229,8 +1462,138 @@ func (aw *AggregatedWorker) Run(interruptCh <-chan interface{}) error {
 	return nil
 }
 
+// PausePolling stops the worker from starting new polls for workflow, activity, and Nexus tasks until
+// ResumePolling is called. Tasks already received, including those from polls in flight when this is called, are
+// still processed. Local activities of running workflow tasks are not affected.
+func (aw *AggregatedWorker) PausePolling() {
+	aw.executionParams.pollGate.pause(pollPauseReasonUser)
+	aw.logger.Info("Paused worker polling")
+}
+
+// ResumePolling resumes polling paused by PausePolling.
+func (aw *AggregatedWorker) ResumePolling() {
+	aw.executionParams.pollGate.resume(pollPauseReasonUser)
+	aw.logger.Info("Resumed worker polling")
+}
+
+// SetStickyOnlyPolling sets whether the workflow worker only polls its sticky task queue. While enabled, the worker
+// keeps processing workflow tasks for executions in its cache but does not receive new workflow executions or
+// executions that are not cached. Has no effect on activity and Nexus polling, or if the sticky cache is disabled.
+func (aw *AggregatedWorker) SetStickyOnlyPolling(stickyOnly bool) {
+	if util.IsInterfaceNil(aw.workflowWorker) {
+		return
+	}
+	if poller, ok := aw.workflowWorker.poller.(*workflowTaskPoller); ok {
+		poller.stickyOnly.Store(stickyOnly)
+		aw.logger.Info("Set workflow sticky only polling", "StickyOnly", stickyOnly)
+	}
+}
+
+// UpdateOptions changes concurrency and rate limits of the running worker without restarting it, which keeps its
+// sticky cache. Options are validated before any of them is applied.
+func (aw *AggregatedWorker) UpdateOptions(options WorkerUpdateOptions) error {
+	if options.MaxConcurrentWorkflowTaskExecutionSize == 1 {
+		return errors.New("cannot set MaxConcurrentWorkflowTaskExecutionSize to 1")
+	}
+	if options.MaxConcurrentWorkflowTaskExecutionSize < 0 || options.MaxConcurrentActivityExecutionSize < 0 ||
+		options.MaxConcurrentLocalActivityExecutionSize < 0 || options.MaxConcurrentNexusTaskExecutionSize < 0 {
+		return errors.New("maximum concurrent execution sizes cannot be negative")
+	}
+	if options.WorkerActivitiesPerSecond < 0 || options.WorkerLocalActivitiesPerSecond < 0 ||
+		options.TaskQueueActivitiesPerSecond < 0 {
+		return errors.New("rates per second cannot be negative")
+	}
+
+	tuner := aw.executionParams.Tuner
+	type resize struct {
+		name     string
+		numSlots int
+		supplier SlotSupplier
+	}
+	var resizes []resize
+	for _, r := range []resize{
+		{"MaxConcurrentWorkflowTaskExecutionSize", options.MaxConcurrentWorkflowTaskExecutionSize, tuner.GetWorkflowTaskSlotSupplier()},
+		{"MaxConcurrentActivityExecutionSize", options.MaxConcurrentActivityExecutionSize, tuner.GetActivityTaskSlotSupplier()},
+		{"MaxConcurrentActivityExecutionSize", options.MaxConcurrentActivityExecutionSize, tuner.GetSessionActivitySlotSupplier()},
+		{"MaxConcurrentLocalActivityExecutionSize", options.MaxConcurrentLocalActivityExecutionSize, tuner.GetLocalActivitySlotSupplier()},
+		{"MaxConcurrentNexusTaskExecutionSize", options.MaxConcurrentNexusTaskExecutionSize, tuner.GetNexusSlotSupplier()},
+	} {
+		if r.numSlots == 0 || r.supplier == nil {
+			continue
+		}
+		if _, ok := r.supplier.(*FixedSizeSlotSupplier); !ok {
+			return fmt.Errorf("cannot update %v, the worker tuner does not use a fixed size slot supplier for it", r.name)
+		}
+		resizes = append(resizes, r)
+	}
+
+	for _, r := range resizes {
+		// Cannot fail since sizes were validated above
+		_ = r.supplier.(*FixedSizeSlotSupplier).SetMaxSlots(r.numSlots)
+	}
+	if options.WorkerActivitiesPerSecond > 0 {
+		for _, w := range aw.activityWorkers() {
+			w.worker.taskLimiter.SetLimit(rate.Limit(options.WorkerActivitiesPerSecond))
+		}
+	}
+	if options.WorkerLocalActivitiesPerSecond > 0 && aw.workflowWorker != nil {
+		aw.workflowWorker.localActivityWorker.taskLimiter.SetLimit(rate.Limit(options.WorkerLocalActivitiesPerSecond))
+	}
+	if options.TaskQueueActivitiesPerSecond > 0 {
+		if aw.executionParams.eagerActivityExecutor != nil {
+			aw.executionParams.eagerActivityExecutor.taskQueueRateLimited.Store(true)
+		}
+		if aw.activityWorker != nil {
+			if poller, ok := aw.activityWorker.poller.(*activityTaskPoller); ok {
+				poller.setActivitiesPerSecond(options.TaskQueueActivitiesPerSecond)
+			}
+		}
+	}
+	aw.logger.Info("Updated worker options", "Options", fmt.Sprintf("%+v", options))
+	return nil
+}
+
+func (aw *AggregatedWorker) activityWorkers() []*activityWorker {
+	var workers []*activityWorker
+	if aw.activityWorker != nil {
+		workers = append(workers, aw.activityWorker)
+	}
+	if aw.sessionWorker != nil {
+		workers = append(workers, aw.sessionWorker.activityWorker)
+	}
+	return workers
+}
+
+// Promote a worker started in standby so it begins polling. Does nothing if the worker is not in standby.
+func (aw *AggregatedWorker) Promote() {
+	if !aw.standby.CompareAndSwap(true, false) {
+		return
+	}
+	aw.executionParams.pollGate.resume(pollPauseReasonStandby)
+	aw.logger.Info("Promoted standby worker")
+}
+
+// IsStandby returns whether the worker is in standby, waiting to be promoted.
+func (aw *AggregatedWorker) IsStandby() bool {
+	return aw.standby.Load()
+}
+
+// BuildID returns the build ID of the worker, set in its options or derived from the binary checksum.
+func (aw *AggregatedWorker) BuildID() string {
+	return aw.executionParams.getBuildID()
+}
+
 // Stop the worker.
 func (aw *AggregatedWorker) Stop() {
+	aw.StopWithReason(WorkerStopReasonShutdown)
+}
+
+// StopWithReason stops the worker, exposing the reason to its activities with GetWorkerStopInfo.
+func (aw *AggregatedWorker) StopWithReason(reason WorkerStopReason) {
+	aw.stop(WorkerStopInfo{Reason: reason})
+}
+
+func (aw *AggregatedWorker) stop(info WorkerStopInfo) {
 	// Only attempt stop if we haven't attempted before
 	select {
 	case <-aw.stopC:
@@ -1238,6 +1601,10 @@ func (aw *AggregatedWorker) Stop() {
 	default:
 		close(aw.stopC)
 	}
+	if aw.executionParams.stopState != nil {
+		info.Deadline = time.Now().Add(aw.executionParams.WorkerStopTimeout)
+		aw.executionParams.stopState.stop(info)
+	}
 
 	if !util.IsInterfaceNil(aw.workflowWorker) {
 		if aw.client.eagerDispatcher != nil {
@@ -1263,6 +1630,8 @@ type WorkflowReplayer struct {
 	contextPropagators       []ContextPropagator
 	enableLoggingInReplay    bool
 	disableDeadlockDetection bool
+	nondeterminismReportDir  string
+	versionUsage             *versionUsageRecorder
 	mu                       sync.Mutex
 	workflowExecutionResults map[string]*commonpb.Payloads
 }
@@ -1300,6 +1669,13 @@ type WorkflowReplayerOptions struct {
 	// Optional: Disable the default 1 second deadlock detection timeout. This option can be used to step through
 	// workflow code with multiple breakpoints in a debugger.
 	DisableDeadlockDetection bool
+
+	// Optional: If set, whenever replay detects nondeterminism, the full comparison of the history events and
+	// replay commands of the workflow task is written to a file in this directory. See
+	// [WorkerOptions.NondeterminismReportDirectory].
+	//
+	// NOTE: Experimental
+	NondeterminismReportDirectory string
 }
 
 // ReplayWorkflowHistoryOptions are options for replaying a workflow.
@@ -1320,6 +1696,8 @@ func NewWorkflowReplayer(options WorkflowReplayerOptions) (*WorkflowReplayer, er
 		contextPropagators:       options.ContextPropagators,
 		enableLoggingInReplay:    options.EnableLoggingInReplay,
 		disableDeadlockDetection: options.DisableDeadlockDetection,
+		nondeterminismReportDir:  options.NondeterminismReportDirectory,
+		versionUsage:             newVersionUsageRecorder(),
 		workflowExecutionResults: make(map[string]*commonpb.Payloads),
 	}, nil
 }
@@ -1388,6 +1766,15 @@ func (aw *WorkflowReplayer) ReplayWorkflowExecution(ctx context.Context, service
 		logger = ilog.NewDefaultLogger()
 	}
 
+	history, err := getWorkflowExecutionHistory(ctx, service, namespace, execution)
+	if err != nil {
+		return err
+	}
+	return aw.replayWorkflowHistory(logger, service, namespace, execution, history)
+}
+
+// getWorkflowExecutionHistory loads the full history of a workflow execution from the Temporal service.
+func getWorkflowExecutionHistory(ctx context.Context, service workflowservice.WorkflowServiceClient, namespace string, execution WorkflowExecution) (*historypb.History, error) {
 	sharedExecution := &commonpb.WorkflowExecution{
 		RunId:      execution.RunID,
 		WorkflowId: execution.ID,
@@ -1400,13 +1787,13 @@ func (aw *WorkflowReplayer) ReplayWorkflowExecution(ctx context.Context, service
 	for {
 		resp, err := service.GetWorkflowExecutionHistory(ctx, request)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		currHistory := resp.History
 		if resp.RawHistory != nil {
 			currHistory, err = serializer.DeserializeBlobDataToHistoryEvents(resp.RawHistory, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		}
 		if currHistory == nil {
@@ -1418,7 +1805,7 @@ func (aw *WorkflowReplayer) ReplayWorkflowExecution(ctx context.Context, service
 		}
 		request.NextPageToken = resp.NextPageToken
 	}
-	return aw.replayWorkflowHistory(logger, service, namespace, execution, &history)
+	return &history, nil
 }
 
 // GetWorkflowResult get the result of a succesfully replayed workflow.
@@ -1520,6 +1907,8 @@ func (aw *WorkflowReplayer) replayWorkflowHistory(logger log.Logger, service wor
 	if aw.disableDeadlockDetection {
 		params.DeadlockDetectionTimeout = math.MaxInt64
 	}
+	params.NondeterminismReportDirectory = aw.nondeterminismReportDir
+	params.versionUsage = aw.versionUsage
 	taskHandler := newWorkflowTaskHandler(params, nil, aw.registry)
 	wfctx, err := taskHandler.GetOrCreateWorkflowContext(task, iterator)
 	defer wfctx.Unlock(err)
@@ -1562,6 +1951,15 @@ func (aw *WorkflowReplayer) replayWorkflowHistory(logger log.Logger, service wor
 	return fmt.Errorf("replay workflow doesn't return the same result as the last event, resp: %[1]T{%[1]v}, last: %[2]T{%[2]v}", resp, last)
 }
 
+// GetVersionUsageReport reports the versions GetVersion returned in all the workflows replayed so far. Changes
+// whose old versions were not returned by any replayed workflow no longer need the branches for those versions,
+// provided the replayed histories cover all the workflows that can still be replayed.
+//
+// NOTE: Experimental
+func (aw *WorkflowReplayer) GetVersionUsageReport() *VersionUsageReport {
+	return aw.versionUsage.report()
+}
+
 // HistoryFromJSON deserializes history from a reader of JSON bytes. This does
 // not close the reader if it is closeable.
 func HistoryFromJSON(r io.Reader, lastEventID int64) (*historypb.History, error) {
@@ -1641,6 +2039,20 @@ func NewAggregatedWorker(client *WorkflowClient, taskQueue string, options Worke
 		panic(temporalPrefixError)
 	}
 	setClientDefaults(client)
+	headersProvider := options.HeadersProvider
+	if options.HostMetadata != nil {
+		headersProvider = options.HostMetadata.headersProvider(headersProvider)
+	}
+	if headersProvider != nil {
+		client = client.withHeadersProvider(headersProvider)
+	}
+	// Poller behaviors replace the numbers of pollers, so they are resolved before defaults are set for those
+	workflowPollerAutoscaling := mustResolvePollerBehavior("WorkflowTaskPollerBehavior",
+		options.WorkflowTaskPollerBehavior, &options.MaxConcurrentWorkflowTaskPollers, 2)
+	activityPollerAutoscaling := mustResolvePollerBehavior("ActivityTaskPollerBehavior",
+		options.ActivityTaskPollerBehavior, &options.MaxConcurrentActivityTaskPollers, 1)
+	nexusPollerAutoscaling := mustResolvePollerBehavior("NexusTaskPollerBehavior",
+		options.NexusTaskPollerBehavior, &options.MaxConcurrentNexusTaskPollers, 1)
 	setWorkerOptionsDefaults(&options)
 	ctx := options.BackgroundActivityContext
 	if ctx == nil {
@@ -1669,6 +2081,17 @@ func NewAggregatedWorker(client *WorkflowClient, taskQueue string, options Worke
 		panic("cannot set both EnableSessionWorker and UseBuildIDForVersioning")
 	}
 
+	// The server has a resolution of seconds for the sticky queue schedule-to-start timeout, so a sub-second
+	// value would silently disable the timeout.
+	if timeout := options.StickyScheduleToStartTimeout; timeout%time.Second != 0 || timeout < time.Second {
+		options.StickyScheduleToStartTimeout = max(timeout.Round(time.Second), time.Second)
+		// The logger is only unset for clients created in tests
+		if client.logger != nil {
+			client.logger.Warn("StickyScheduleToStartTimeout is not a whole number of seconds, rounding it",
+				"StickyScheduleToStartTimeout", timeout, "RoundedTo", options.StickyScheduleToStartTimeout)
+		}
+	}
+
 	if options.DeploymentOptions.Version != "" &&
 		!strings.Contains(options.DeploymentOptions.Version, ".") {
 		panic("version in DeploymentOptions not in the form \"<deployment_name>.<build_id>\"")
@@ -1679,6 +2102,9 @@ func NewAggregatedWorker(client *WorkflowClient, taskQueue string, options Worke
 		options.DeploymentOptions.DeploymentSeriesName = splitVersion[0]
 		options.BuildID = splitVersion[1]
 	}
+	if options.BuildID == "" && (options.UseBuildIDForVersioning || options.DeploymentOptions.UseVersioning) {
+		options.BuildID = getBinaryChecksum()
+	}
 
 	// Need reference to result for fatal error handler
 	var aw *AggregatedWorker
@@ -1700,7 +2126,7 @@ func NewAggregatedWorker(client *WorkflowClient, taskQueue string, options Worke
 			select {
 			case <-aw.stopC:
 			default:
-				aw.Stop()
+				aw.stop(WorkerStopInfo{Reason: WorkerStopReasonFatalError, Err: err})
 			}
 		}
 	}
@@ -1719,15 +2145,23 @@ func NewAggregatedWorker(client *WorkflowClient, taskQueue string, options Worke
 		WorkerLocalActivitiesPerSecond:        options.WorkerLocalActivitiesPerSecond,
 		MaxConcurrentWorkflowTaskQueuePollers: options.MaxConcurrentWorkflowTaskPollers,
 		MaxConcurrentNexusTaskQueuePollers:    options.MaxConcurrentNexusTaskPollers,
+		WorkflowTaskPollerAutoscaling:         workflowPollerAutoscaling,
+		ActivityTaskPollerAutoscaling:         activityPollerAutoscaling,
+		NexusTaskPollerAutoscaling:            nexusPollerAutoscaling,
 		Identity:                              client.identity,
 		WorkerBuildID:                         options.BuildID,
 		UseBuildIDForVersioning:               options.UseBuildIDForVersioning || options.DeploymentOptions.UseVersioning,
 		DeploymentSeriesName:                  options.DeploymentOptions.DeploymentSeriesName,
 		WorkerDeploymentVersion:               options.DeploymentOptions.Version,
 		DefaultVersioningBehavior:             options.DeploymentOptions.DefaultVersioningBehavior,
-		MetricsHandler:                        client.metricsHandler.WithTags(metrics.TaskQueueTags(taskQueue)),
+		MetricsHandler:                        client.metricsHandler,
 		Logger:                                client.logger,
 		EnableLoggingInReplay:                 options.EnableLoggingInReplay,
+		WorkflowLogSampling:                   options.WorkflowLogSampling,
+		EnableStackTraceInErrorLogs:           options.EnableStackTraceInErrorLogs,
+		LocalActivityWatchdogGracePeriod:      options.LocalActivityWatchdogGracePeriod,
+		NondeterminismReportDirectory:         options.NondeterminismReportDirectory,
+		OnNondeterminism:                      options.OnNondeterminism,
 		BackgroundContext:                     backgroundActivityContext,
 		BackgroundContextCancel:               backgroundActivityContextCancel,
 		StickyScheduleToStartTimeout:          options.StickyScheduleToStartTimeout,
@@ -1739,8 +2173,12 @@ func NewAggregatedWorker(client *WorkflowClient, taskQueue string, options Worke
 		WorkerFatalErrorCallback:              fatalErrorCallback,
 		ContextPropagators:                    client.contextPropagators,
 		DeadlockDetectionTimeout:              options.DeadlockDetectionTimeout,
+		PayloadSizeWarningThreshold:           options.PayloadSizeWarningThreshold,
+		PayloadSizeErrorThreshold:             options.PayloadSizeErrorThreshold,
 		DefaultHeartbeatThrottleInterval:      options.DefaultHeartbeatThrottleInterval,
 		MaxHeartbeatThrottleInterval:          options.MaxHeartbeatThrottleInterval,
+		SlotExhaustionWarningThreshold:        options.SlotExhaustionWarningThreshold,
+		ActivityResultCache:                   options.ActivityResultCache,
 		cache:                                 cache,
 		eagerActivityExecutor: newEagerActivityExecutor(eagerActivityExecutorOptions{
 			disabled:      options.DisableEagerActivities,
@@ -1748,6 +2186,11 @@ func NewAggregatedWorker(client *WorkflowClient, taskQueue string, options Worke
 			maxConcurrent: options.MaxConcurrentEagerActivityExecutionSize,
 		}),
 		capabilities: &capabilities,
+		pollGate:     &pollGate{},
+		stopState:    &workerStopState{},
+		metricsTagEnricher: newMetricsTagEnricher(
+			options.MetricsTagProvider, options.MaxMetricsTagValues, client.logger),
+		logFieldEnricher: newLogFieldEnricher(options.LogFields),
 	}
 
 	if options.Identity != "" {
@@ -1755,18 +2198,33 @@ func NewAggregatedWorker(client *WorkflowClient, taskQueue string, options Worke
 	}
 
 	ensureRequiredParams(&workerParams)
+	if options.HostMetadata != nil {
+		workerParams.Identity = options.HostMetadata.withIdentity(workerParams.Identity)
+	}
+	workerParams.Logger = ilog.NewFieldFilterLogger(workerParams.Logger, omittedLogFields(options.LogFields)...)
 	workerParams.Logger = log.With(workerParams.Logger,
 		tagNamespace, client.namespace,
 		tagTaskQueue, taskQueue,
 		tagWorkerID, workerParams.Identity,
 	)
-	if workerParams.WorkerBuildID != "" {
-		// Add worker build ID to the logs if it's set by user
-		workerParams.Logger = log.With(workerParams.Logger,
-			tagBuildID, workerParams.WorkerBuildID,
-		)
+	workerParams.Logger = log.With(workerParams.Logger, tagBuildID, workerParams.getBuildID())
+	// The static tags are added first so they cannot override the tags identifying the worker
+	if len(options.MetricsTags) > 0 {
+		workerParams.MetricsHandler = workerParams.MetricsHandler.WithTags(options.MetricsTags)
+	}
+	workerParams.MetricsHandler = workerParams.MetricsHandler.WithTags(
+		metrics.WorkerIdentityTags(client.namespace, taskQueue, workerParams.Identity))
+	if options.EnableBuildIDMetricsTag {
+		workerParams.MetricsHandler = workerParams.MetricsHandler.WithTags(metrics.BuildIDTags(workerParams.getBuildID()))
 	}
 
+	workerParams.scheduleToStartSLO = newScheduleToStartSLO(options.ScheduleToStartSLO, workerParams.MetricsHandler)
+	activityExecutorPools, err := newActivityExecutorPools(options.ActivityExecutorPools)
+	if err != nil {
+		panic(err)
+	}
+	workerParams.activityExecutorPools = activityExecutorPools
+
 	processTestTags(&options, &workerParams)
 
 	// worker specific registry
@@ -1815,6 +2273,16 @@ func NewAggregatedWorker(client *WorkflowClient, taskQueue string, options Worke
 		stopC:           make(chan struct{}),
 		capabilities:    &capabilities,
 		executionParams: workerParams,
+
+		waitForPromotion: options.WaitForPromotion,
+		startGate:        options.StartGate,
+	}
+	if options.StartGate != nil {
+		workerParams.pollGate.pause(pollPauseReasonStartGate)
+	}
+	if options.Standby {
+		aw.standby.Store(true)
+		workerParams.pollGate.pause(pollPauseReasonStandby)
 	}
 	aw.memoizedStart = sync.OnceValue(aw.start)
 	return aw
@@ -1908,6 +2376,17 @@ func getReadOnlyChannel(c chan struct{}) <-chan struct{} {
 	return c
 }
 
+// mustResolvePollerBehavior resolves the poller behavior set in the named worker option, replacing the number of
+// pollers with the fixed number of the behavior, if any. It panics if the behavior is invalid.
+func mustResolvePollerBehavior(name string, behavior PollerBehavior, pollers *int, lowest int) *PollerBehaviorAutoscaling {
+	count, autoscaling, err := resolvePollerBehavior(behavior, *pollers, lowest)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s: %v", name, err))
+	}
+	*pollers = count
+	return autoscaling
+}
+
 func setWorkerOptionsDefaults(options *WorkerOptions) {
 	if options.Tuner != nil {
 		if options.MaxConcurrentWorkflowTaskExecutionSize != 0 ||
@@ -1968,6 +2447,9 @@ func setWorkerOptionsDefaults(options *WorkerOptions) {
 			options.DeadlockDetectionTimeout = defaultDeadlockDetectionTimeout
 		}
 	}
+	if options.PayloadSizeWarningThreshold == 0 {
+		options.PayloadSizeWarningThreshold = defaultPayloadSizeWarningThreshold
+	}
 	if options.DefaultHeartbeatThrottleInterval == 0 {
 		options.DefaultHeartbeatThrottleInterval = defaultDefaultHeartbeatThrottleInterval
 	}
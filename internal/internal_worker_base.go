@@ -10,6 +10,7 @@ import (
 	"time"
 
 	commonpb "go.temporal.io/api/common/v1"
+	historypb "go.temporal.io/api/history/v1"
 	"go.temporal.io/api/serviceerror"
 	"golang.org/x/time/rate"
 
@@ -103,6 +104,7 @@ type (
 		RegisterUpdateHandler(
 			handler func(string, string, *commonpb.Payloads, *commonpb.Header, UpdateCallbacks),
 		)
+		RegisterReplayedEventHandler(handler func(event *historypb.HistoryEvent))
 		IsReplaying() bool
 		MutableSideEffect(id string, f func() interface{}, equals func(a, b interface{}) bool) converter.EncodedValue
 		GetDataConverter() converter.DataConverter
@@ -167,6 +169,14 @@ type (
 		metricsHandler          metrics.Handler
 		sessionTokenBucket      *sessionTokenBucket
 		slotReservationData     slotReservationData
+		// slotExhaustionThreshold is how long a reservation may wait before slots are reported as exhausted, zero
+		// disables reporting.
+		slotExhaustionThreshold time.Duration
+		// pollGate may be shared across base workers so they can be paused together. If nil, the base worker
+		// gets its own.
+		pollGate *pollGate
+		// pollerAutoscaling scales the number of pollers instead of using pollerCount, if set.
+		pollerAutoscaling *PollerBehaviorAutoscaling
 	}
 
 	// baseWorker that wraps worker activities.
@@ -188,12 +198,25 @@ type (
 		eagerTaskQueueCh   chan eagerTask
 		fatalErrCb         func(error)
 		sessionTokenBucket *sessionTokenBucket
+		pollGate           *pollGate
+		// pollerScaler is set when the number of pollers scales.
+		pollerScaler *pollerScaler
 
 		lastPollTaskErrMessage string
 		lastPollTaskErrStarted time.Time
 		lastPollTaskErrLock    sync.Mutex
 	}
 
+	// pollGate blocks pollers from starting new polls while polling is paused for any reason.
+	pollGate struct {
+		lock    sync.Mutex
+		reasons map[pollPauseReason]struct{}
+		// Non-nil while paused, closed on resume
+		resumedCh chan struct{}
+	}
+
+	pollPauseReason int
+
 	eagerOrPolledTask interface {
 		getTask() taskForWorker
 		getPermit() *SlotPermit
@@ -231,6 +254,57 @@ func (t *eagerTask) getPermit() *SlotPermit {
 	return t.permit
 }
 
+const (
+	pollPauseReasonUser pollPauseReason = iota
+	pollPauseReasonStandby
+	pollPauseReasonStartGate
+)
+
+func (g *pollGate) pause(reason pollPauseReason) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.reasons == nil {
+		g.reasons = map[pollPauseReason]struct{}{}
+	}
+	g.reasons[reason] = struct{}{}
+	if g.resumedCh == nil {
+		g.resumedCh = make(chan struct{})
+	}
+}
+
+// resume removes a reason polling is paused. Polling resumes once no reasons remain.
+func (g *pollGate) resume(reason pollPauseReason) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	delete(g.reasons, reason)
+	if len(g.reasons) == 0 && g.resumedCh != nil {
+		close(g.resumedCh)
+		g.resumedCh = nil
+	}
+}
+
+func (g *pollGate) isPaused() bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.resumedCh != nil
+}
+
+// wait blocks while polling is paused. Returns false if stopCh was closed first.
+func (g *pollGate) wait(stopCh <-chan struct{}) bool {
+	g.lock.Lock()
+	resumedCh := g.resumedCh
+	g.lock.Unlock()
+	if resumedCh == nil {
+		return true
+	}
+	select {
+	case <-resumedCh:
+		return true
+	case <-stopCh:
+		return false
+	}
+}
+
 // SetRetryLongPollGracePeriod sets the amount of time a long poller retries on
 // fatal errors before it actually fails. For test use only,
 // not safe to call with a running worker.
@@ -272,6 +346,8 @@ func newBaseWorker(
 		metricsHandler: metricsHandler,
 		workerBuildId:  options.buildId,
 		workerIdentity: options.identity,
+
+		exhaustionThreshold: options.slotExhaustionThreshold,
 	})
 	bw := &baseWorker{
 		options:        options,
@@ -293,12 +369,20 @@ func newBaseWorker(
 		limiterContext:       ctx,
 		limiterContextCancel: cancel,
 		sessionTokenBucket:   options.sessionTokenBucket,
+		pollGate:             options.pollGate,
+	}
+	if bw.pollGate == nil {
+		bw.pollGate = &pollGate{}
 	}
 	// Set secondary retrier as resource exhausted
 	bw.retrier.SetSecondaryRetryPolicy(pollResourceExhaustedRetryPolicy)
 	if options.pollerRate > 0 {
 		bw.pollLimiter = rate.NewLimiter(rate.Limit(options.pollerRate), 1)
 	}
+	if options.pollerAutoscaling != nil {
+		bw.pollerScaler = newPollerScaler(*options.pollerAutoscaling)
+		bw.options.pollerCount = options.pollerAutoscaling.InitialNumberOfPollers
+	}
 
 	return bw
 }
@@ -349,6 +433,12 @@ func (bw *baseWorker) runPoller() {
 	reserveChan := make(chan *SlotPermit)
 
 	for {
+		if !bw.pollGate.wait(bw.stopCh) {
+			return
+		}
+		if bw.pollerScaler != nil && bw.pollerScaler.release() {
+			return
+		}
 		bw.stopWG.Add(1)
 		go func() {
 			defer bw.stopWG.Done()
@@ -382,6 +472,11 @@ func (bw *baseWorker) runPoller() {
 				}
 				continue
 			}
+			// Polling may have been paused while waiting for a slot
+			if bw.pollGate.isPaused() {
+				bw.releaseSlot(permit, SlotReleaseReasonUnused)
+				continue
+			}
 			if bw.sessionTokenBucket != nil {
 				bw.sessionTokenBucket.waitForAvailableToken()
 			}
@@ -391,7 +486,8 @@ func (bw *baseWorker) runPoller() {
 }
 
 func (bw *baseWorker) tryReserveSlot() *SlotPermit {
-	if bw.isStop() {
+	// Paused workers do not accept eager tasks either
+	if bw.isStop() || bw.pollGate.isPaused() {
 		return nil
 	}
 	return bw.slotSupplier.TryReserveSlot(&bw.options.slotReservationData)
@@ -511,6 +607,7 @@ func (bw *baseWorker) pollTask(slotPermit *SlotPermit) {
 		} else {
 			bw.retrier.Succeeded()
 		}
+		bw.scalePollers(task, err)
 	}
 
 	if task != nil {
@@ -522,6 +619,18 @@ func (bw *baseWorker) pollTask(slotPermit *SlotPermit) {
 	}
 }
 
+// scalePollers starts pollers if the outcome of a poll raises the number of pollers of an autoscaling worker. It is
+// called by a running poller, so the stop wait group cannot be waited on yet.
+func (bw *baseWorker) scalePollers(task taskForWorker, err error) {
+	if bw.pollerScaler == nil {
+		return
+	}
+	for i := bw.pollerScaler.pollCompleted(task, err); i > 0; i-- {
+		bw.stopWG.Add(1)
+		go bw.runPoller()
+	}
+}
+
 func (bw *baseWorker) logPollTaskError(err error) {
 	// We do not want to log any errors after we were explicitly stopped
 	select {
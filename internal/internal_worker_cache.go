@@ -3,8 +3,10 @@ package internal
 import (
 	"runtime"
 	"sync"
+	"time"
 
 	"go.temporal.io/sdk/internal/common/cache"
+	"go.temporal.io/sdk/internal/common/metrics"
 )
 
 // A WorkerCache instance is held by each worker to hold cached data. The contents of this struct should always be
@@ -33,6 +35,14 @@ var sharedWorkerCacheLock sync.Mutex
 
 // Must be set before spawning any workers
 var desiredWorkflowCacheSize = defaultStickyCacheSize
+var desiredWorkflowCacheTTL time.Duration
+var desiredWorkflowCacheMaxMemory int64
+
+type workerCacheOptions struct {
+	size      int
+	ttl       time.Duration
+	maxMemory int64
+}
 
 // SetStickyWorkflowCacheSize sets the cache size for sticky workflow cache. Sticky workflow execution is the affinity
 // between workflow tasks of a specific workflow execution to a specific worker. The benefit of sticky execution is that
@@ -45,6 +55,26 @@ func SetStickyWorkflowCacheSize(cacheSize int) {
 	desiredWorkflowCacheSize = cacheSize
 }
 
+// SetStickyWorkflowCacheTTL sets how long a workflow may go without processing a workflow task before it is evicted
+// from the sticky workflow cache, so idle workflows do not hold memory until they are pushed out by other workflows.
+// This must be called before any worker is started. If not called or zero, workflows are only evicted when the cache
+// is full.
+func SetStickyWorkflowCacheTTL(ttl time.Duration) {
+	sharedWorkerCacheLock.Lock()
+	defer sharedWorkerCacheLock.Unlock()
+	desiredWorkflowCacheTTL = ttl
+}
+
+// SetStickyWorkflowCacheMaxMemory sets the maximum memory in bytes used by the sticky workflow cache, in addition to
+// the maximum number of workflows set with SetStickyWorkflowCacheSize. The memory used by a workflow is estimated by
+// the size of its history. Least recently used workflows are evicted when the limit is exceeded. This must be called
+// before any worker is started. If not called or zero, the cache is only limited by the number of workflows.
+func SetStickyWorkflowCacheMaxMemory(maxBytes int64) {
+	sharedWorkerCacheLock.Lock()
+	defer sharedWorkerCacheLock.Unlock()
+	desiredWorkflowCacheMaxMemory = maxBytes
+}
+
 // PurgeStickyWorkflowCache resets the sticky workflow cache. This must be called only when all workers are stopped.
 func PurgeStickyWorkflowCache() {
 	sharedWorkerCacheLock.Lock()
@@ -60,14 +90,23 @@ func PurgeStickyWorkflowCache() {
 // WorkerCache, shared caches will be cleared
 func NewWorkerCache() *WorkerCache {
 	sharedWorkerCacheLock.Lock()
-	desiredWorkflowCacheSize := desiredWorkflowCacheSize
+	options := workerCacheOptions{
+		size:      desiredWorkflowCacheSize,
+		ttl:       desiredWorkflowCacheTTL,
+		maxMemory: desiredWorkflowCacheMaxMemory,
+	}
 	sharedWorkerCacheLock.Unlock()
 
-	return newWorkerCache(sharedWorkerCachePtr, &sharedWorkerCacheLock, desiredWorkflowCacheSize)
+	return newWorkerCacheWithOptions(sharedWorkerCachePtr, &sharedWorkerCacheLock, options)
 }
 
 // This private version allows us to test functionality without affecting the global shared cache
 func newWorkerCache(storeIn *sharedWorkerCache, lock *sync.Mutex, cacheSize int) *WorkerCache {
+	return newWorkerCacheWithOptions(storeIn, lock, workerCacheOptions{size: cacheSize})
+}
+
+func newWorkerCacheWithOptions(storeIn *sharedWorkerCache, lock *sync.Mutex, options workerCacheOptions) *WorkerCache {
+	cacheSize := options.size
 	lock.Lock()
 	defer lock.Unlock()
 
@@ -81,6 +120,13 @@ func newWorkerCache(storeIn *sharedWorkerCache, lock *sync.Mutex, cacheSize int)
 				wc := cachedEntity.(*workflowExecutionContextImpl)
 				wc.onEviction()
 			},
+			MaxIdleTime:  options.ttl,
+			MaxTotalSize: options.maxMemory,
+			EvictedFunc: func(cachedEntity interface{}, reason cache.EvictionReason) {
+				wc := cachedEntity.(*workflowExecutionContextImpl)
+				wc.wth.metricsHandler.WithTags(map[string]string{metrics.EvictionReasonTagName: string(reason)}).
+					Counter(metrics.StickyCacheEviction).Inc(1)
+			},
 		})
 		*storeIn = sharedWorkerCache{workflowCache: &newcache, workerRefcount: 0, maxWorkflowCacheSize: cacheSize}
 	}
@@ -130,6 +176,10 @@ func (wc *WorkerCache) removeWorkflowContext(runID string) {
 	(*wc.sharedCache.workflowCache).Delete(runID)
 }
 
+func (wc *WorkerCache) updateWorkflowContextSize(runID string, size int64) {
+	(*wc.sharedCache.workflowCache).UpdateSize(runID, size)
+}
+
 // MaxWorkflowCacheSize returns the maximum allowed size of the sticky cache
 func (wc *WorkerCache) MaxWorkflowCacheSize() int {
 	if wc == nil {
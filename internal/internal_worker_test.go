@@ -24,8 +24,10 @@ import (
 	namespacepb "go.temporal.io/api/namespace/v1"
 	"go.temporal.io/api/serviceerror"
 	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/api/workflowservicemock/v1"
+	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
 
 	"go.temporal.io/sdk/converter"
@@ -290,6 +292,333 @@ func (s *internalWorkerTestSuite) TestReplayWorkflowHistory() {
 	require.NoError(s.T(), err)
 }
 
+func (s *internalWorkerTestSuite) TestReplayWorkflowHistory_InputValidator() {
+	taskQueue := "taskQueue1"
+	history := &historypb.History{Events: []*historypb.HistoryEvent{
+		createTestEventWorkflowExecutionStarted(1, &historypb.WorkflowExecutionStartedEventAttributes{
+			WorkflowType: &commonpb.WorkflowType{Name: "testReplayWorkflow"},
+			TaskQueue:    &taskqueuepb.TaskQueue{Name: taskQueue},
+			Input:        testEncodeFunctionArgs(converter.GetDefaultDataConverter()),
+		}),
+		createTestEventWorkflowTaskScheduled(2, &historypb.WorkflowTaskScheduledEventAttributes{}),
+		createTestEventWorkflowTaskStarted(3),
+		createTestEventWorkflowTaskCompleted(4, &historypb.WorkflowTaskCompletedEventAttributes{}),
+		{
+			EventId:   5,
+			EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED,
+			Attributes: &historypb.HistoryEvent_WorkflowExecutionFailedEventAttributes{
+				WorkflowExecutionFailedEventAttributes: &historypb.WorkflowExecutionFailedEventAttributes{
+					WorkflowTaskCompletedEventId: 4,
+				},
+			},
+		},
+	}}
+
+	// The workflow was rejected by the validator, so the workflow func must not run on replay either
+	replayer, err := NewWorkflowReplayer(WorkflowReplayerOptions{})
+	s.NoError(err)
+	replayer.RegisterWorkflowWithOptions(testReplayWorkflow, RegisterWorkflowOptions{
+		Name:           "testReplayWorkflow",
+		InputValidator: func() error { return errors.New("rejected") },
+	})
+	s.NoError(replayer.ReplayWorkflowHistory(getLogger(), history))
+}
+
+// replayCompatibilityTestHistory returns the history of testReplayWorkflow, with workflow tasks completed by the
+// build, running the activity of the given type.
+func replayCompatibilityTestHistory(buildID, activityType string) *historypb.History {
+	taskQueue := "taskQueue1"
+	return &historypb.History{Events: []*historypb.HistoryEvent{
+		createTestEventWorkflowExecutionStarted(1, &historypb.WorkflowExecutionStartedEventAttributes{
+			WorkflowType: &commonpb.WorkflowType{Name: "testReplayWorkflow"},
+			TaskQueue:    &taskqueuepb.TaskQueue{Name: taskQueue},
+			Input:        testEncodeFunctionArgs(converter.GetDefaultDataConverter()),
+		}),
+		createTestEventWorkflowTaskScheduled(2, &historypb.WorkflowTaskScheduledEventAttributes{}),
+		createTestEventWorkflowTaskStarted(3),
+		createTestEventWorkflowTaskCompleted(4, &historypb.WorkflowTaskCompletedEventAttributes{
+			WorkerVersion: &commonpb.WorkerVersionStamp{BuildId: buildID},
+		}),
+		createTestEventActivityTaskScheduled(5, &historypb.ActivityTaskScheduledEventAttributes{
+			ActivityId:   "5",
+			ActivityType: &commonpb.ActivityType{Name: activityType},
+			TaskQueue:    &taskqueuepb.TaskQueue{Name: taskQueue},
+		}),
+	}}
+}
+
+func (s *internalWorkerTestSuite) TestCheckReplayCompatibility() {
+	replayer, err := NewWorkflowReplayer(WorkflowReplayerOptions{})
+	s.NoError(err)
+	replayer.RegisterWorkflow(testReplayWorkflow)
+
+	report := replayer.CheckReplayCompatibility(getLogger(),
+		replayCompatibilityTestHistory("build-1", "testActivity"),
+		replayCompatibilityTestHistory("build-2", "otherActivity"),
+	)
+	s.False(report.Compatible())
+	s.Len(report.Results, 2)
+	s.NoError(report.Results[0].Err)
+	s.Equal([]string{"build-1"}, report.Results[0].BuildIDs)
+	s.Error(report.Results[1].Err)
+	s.Equal([]string{"build-1"}, report.CompatibleBuildIDs)
+	s.Equal([]string{"build-2"}, report.IncompatibleBuildIDs)
+
+	report = replayer.CheckReplayCompatibility(getLogger(), replayCompatibilityTestHistory("build-1", "testActivity"))
+	s.True(report.Compatible())
+}
+
+func (s *internalWorkerTestSuite) TestCheckTaskQueueReplayCompatibility() {
+	replayer, err := NewWorkflowReplayer(WorkflowReplayerOptions{})
+	s.NoError(err)
+	replayer.RegisterWorkflow(testReplayWorkflow)
+
+	s.service.EXPECT().ListWorkflowExecutions(gomock.Any(), &workflowservice.ListWorkflowExecutionsRequest{
+		Namespace: "ns",
+		PageSize:  2,
+		Query:     `TaskQueue = "taskQueue1" AND (StartTime > "2024-01-01T00:00:00Z")`,
+	}, gomock.Any()).Return(&workflowservice.ListWorkflowExecutionsResponse{
+		Executions: []*workflowpb.WorkflowExecutionInfo{
+			{Execution: &commonpb.WorkflowExecution{WorkflowId: "wid1", RunId: "rid1"}},
+			{Execution: &commonpb.WorkflowExecution{WorkflowId: "wid2", RunId: "rid2"}},
+			{Execution: &commonpb.WorkflowExecution{WorkflowId: "wid3", RunId: "rid3"}},
+		},
+		NextPageToken: []byte("token"),
+	}, nil)
+	s.service.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		&workflowservice.GetWorkflowExecutionHistoryResponse{History: replayCompatibilityTestHistory("build-1", "testActivity")}, nil)
+	s.service.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		&workflowservice.GetWorkflowExecutionHistoryResponse{History: replayCompatibilityTestHistory("build-2", "testActivity")}, nil)
+
+	report, err := replayer.CheckTaskQueueReplayCompatibility(context.Background(), s.service, getLogger(), "ns",
+		ReplayCompatibilityOptions{
+			TaskQueue:    "taskQueue1",
+			Query:        `StartTime > "2024-01-01T00:00:00Z"`,
+			MaxWorkflows: 2,
+		})
+	s.NoError(err)
+	s.True(report.Compatible())
+	s.Equal(WorkflowExecution{ID: "wid2", RunID: "rid2"}, report.Results[1].Execution)
+	s.Equal([]string{"build-1", "build-2"}, report.CompatibleBuildIDs)
+}
+
+func testQueryReplicaWorkflow(ctx Context) error {
+	var value string
+	if err := SetQueryHandler(ctx, "value", func(prefix string) (string, error) {
+		return prefix + value, nil
+	}); err != nil {
+		return err
+	}
+	GetSignalChannel(ctx, "set").Receive(ctx, &value)
+	return Sleep(ctx, time.Hour)
+}
+
+func (s *internalWorkerTestSuite) TestQueryReplica() {
+	dc := converter.GetDefaultDataConverter()
+	signalInput, err := dc.ToPayloads("signaled")
+	s.NoError(err)
+	history := &historypb.History{Events: []*historypb.HistoryEvent{
+		createTestEventWorkflowExecutionStarted(1, &historypb.WorkflowExecutionStartedEventAttributes{
+			WorkflowType: &commonpb.WorkflowType{Name: "testQueryReplicaWorkflow"},
+			TaskQueue:    &taskqueuepb.TaskQueue{Name: "taskQueue1"},
+		}),
+		createTestEventWorkflowTaskScheduled(2, &historypb.WorkflowTaskScheduledEventAttributes{}),
+		createTestEventWorkflowTaskStarted(3),
+		createTestEventWorkflowTaskCompleted(4, &historypb.WorkflowTaskCompletedEventAttributes{}),
+		createTestEventWorkflowExecutionSignaledWithPayload(5, "set", signalInput),
+		createTestEventWorkflowTaskScheduled(6, &historypb.WorkflowTaskScheduledEventAttributes{}),
+		createTestEventWorkflowTaskStarted(7),
+		createTestEventWorkflowTaskCompleted(8, &historypb.WorkflowTaskCompletedEventAttributes{}),
+		createTestEventTimerStarted(9, 9),
+	}}
+	// The history is loaded once, the second query reusing it
+	s.service.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		&workflowservice.GetWorkflowExecutionHistoryResponse{History: history}, nil).Times(1)
+	s.service.EXPECT().RespondQueryTaskCompleted(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	s.service.EXPECT().RespondWorkflowTaskCompleted(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	client := NewServiceClient(s.service, nil, ClientOptions{Namespace: "ns"})
+	replica, err := NewQueryReplica(client, QueryReplicaOptions{MaxStaleness: time.Minute})
+	s.NoError(err)
+	replica.RegisterWorkflow(testQueryReplicaWorkflow)
+
+	value, err := replica.QueryWorkflow(context.Background(), "wid", "rid", "value", "value: ")
+	s.NoError(err)
+	var result string
+	s.NoError(value.Get(&result))
+	s.Equal("value: signaled", result)
+
+	value, err = replica.QueryWorkflow(context.Background(), "wid", "rid", QueryTypeStackTrace)
+	s.NoError(err)
+	s.NoError(value.Get(&result))
+	s.Contains(result, "testQueryReplicaWorkflow")
+
+	_, err = replica.QueryWorkflow(context.Background(), "wid", "rid", "unknown")
+	var queryFailed *serviceerror.QueryFailed
+	s.ErrorAs(err, &queryFailed)
+
+	replica.Close()
+	replica.Close()
+	_, err = replica.QueryWorkflow(context.Background(), "wid", "rid", "value", "value: ")
+	s.EqualError(err, "query replica is closed")
+}
+
+func testQueryReplicaPanicWorkflow(ctx Context) error {
+	panic("replay failure")
+}
+
+func (s *internalWorkerTestSuite) TestQueryReplicaKeepsWorkerCache() {
+	// A worker of the process has the run cached
+	workerCache := NewWorkerCache()
+	defer workerCache.close(&sharedWorkerCacheLock)
+	// The entry is completed so that its eviction once the test is done does not count a forced eviction
+	cached := &workflowExecutionContextImpl{isWorkflowCompleted: true}
+	_, err := workerCache.putWorkflowContext("cached-rid", cached)
+	s.NoError(err)
+	defer workerCache.removeWorkflowContext("cached-rid")
+
+	history := &historypb.History{Events: []*historypb.HistoryEvent{
+		createTestEventWorkflowExecutionStarted(1, &historypb.WorkflowExecutionStartedEventAttributes{
+			WorkflowType: &commonpb.WorkflowType{Name: "testQueryReplicaPanicWorkflow"},
+			TaskQueue:    &taskqueuepb.TaskQueue{Name: "taskQueue1"},
+		}),
+		createTestEventWorkflowTaskScheduled(2, &historypb.WorkflowTaskScheduledEventAttributes{}),
+		createTestEventWorkflowTaskStarted(3),
+	}}
+	s.service.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		&workflowservice.GetWorkflowExecutionHistoryResponse{History: history}, nil).AnyTimes()
+
+	client := NewServiceClient(s.service, nil, ClientOptions{Namespace: "ns"})
+	replica, err := NewQueryReplica(client, QueryReplicaOptions{})
+	s.NoError(err)
+	defer replica.Close()
+	replica.RegisterWorkflow(testQueryReplicaPanicWorkflow)
+
+	// The failed replay does not evict the run cached by the worker
+	_, err = replica.QueryWorkflow(context.Background(), "wid", "cached-rid", QueryTypeStackTrace)
+	s.Error(err)
+	s.Same(cached, workerCache.getWorkflowContext("cached-rid"))
+}
+
+func testVersionUsageWorkflow(ctx Context) error {
+	GetVersion(ctx, "change-1", DefaultVersion, 2)
+	return Sleep(ctx, time.Minute)
+}
+
+// versionUsageTestHistory returns the history of testVersionUsageWorkflow, with the version of the change recorded
+// if it is not the default version.
+func versionUsageTestHistory(version Version) *historypb.History {
+	events := []*historypb.HistoryEvent{
+		createTestEventWorkflowExecutionStarted(1, &historypb.WorkflowExecutionStartedEventAttributes{
+			WorkflowType: &commonpb.WorkflowType{Name: "testVersionUsageWorkflow"},
+			TaskQueue:    &taskqueuepb.TaskQueue{Name: "taskQueue1"},
+		}),
+		createTestEventWorkflowTaskScheduled(2, &historypb.WorkflowTaskScheduledEventAttributes{}),
+		createTestEventWorkflowTaskStarted(3),
+		createTestEventWorkflowTaskCompleted(4, &historypb.WorkflowTaskCompletedEventAttributes{}),
+	}
+	if version != DefaultVersion {
+		events = append(events,
+			createTestEventVersionMarker(5, 4, "change-1", version),
+			createTestUpsertWorkflowSearchAttributesForChangeVersion(6, 4, "change-1", version),
+		)
+	}
+	return &historypb.History{Events: append(events,
+		createTestEventTimerStarted(int64(len(events)+1), len(events)+1),
+	)}
+}
+
+func (s *internalWorkerTestSuite) TestGetVersionUsageReport() {
+	replayer, err := NewWorkflowReplayer(WorkflowReplayerOptions{})
+	s.NoError(err)
+	replayer.RegisterWorkflow(testVersionUsageWorkflow)
+	s.Empty(replayer.GetVersionUsageReport().Changes)
+
+	s.NoError(replayer.ReplayWorkflowHistory(getLogger(), versionUsageTestHistory(2)))
+	s.NoError(replayer.ReplayWorkflowHistory(getLogger(), versionUsageTestHistory(2)))
+	report := replayer.GetVersionUsageReport()
+	s.Equal([]ChangeVersionUsage{{ChangeID: "change-1", Versions: map[Version]int{2: 2}, MaxSupported: 2}}, report.Changes)
+	change, ok := report.Change("change-1")
+	s.True(ok)
+	s.False(change.OldVersionsUsed())
+	_, ok = report.Change("change-2")
+	s.False(ok)
+
+	// Workflows started before the change use the default version
+	s.NoError(replayer.ReplayWorkflowHistory(getLogger(), versionUsageTestHistory(DefaultVersion)))
+	change, _ = replayer.GetVersionUsageReport().Change("change-1")
+	s.Equal(map[Version]int{DefaultVersion: 1, 2: 2}, change.Versions)
+	s.True(change.OldVersionsUsed())
+}
+
+type replayedEventsInterceptor struct {
+	WorkerInterceptorBase
+	WorkflowInboundInterceptorBase
+	events []*HandleReplayedEventInput
+}
+
+func (r *replayedEventsInterceptor) InterceptWorkflow(ctx Context, next WorkflowInboundInterceptor) WorkflowInboundInterceptor {
+	r.Next = next
+	return r
+}
+
+func (r *replayedEventsInterceptor) HandleReplayedEvent(ctx Context, in *HandleReplayedEventInput) {
+	r.events = append(r.events, in)
+	r.WorkflowInboundInterceptorBase.HandleReplayedEvent(ctx, in)
+}
+
+func (s *internalWorkerTestSuite) TestReplayWorkflowHistory_HandleReplayedEvent() {
+	taskQueue := "taskQueue1"
+	testEvents := []*historypb.HistoryEvent{
+		createTestEventWorkflowExecutionStarted(1, &historypb.WorkflowExecutionStartedEventAttributes{
+			WorkflowType: &commonpb.WorkflowType{Name: "testReplayWorkflow"},
+			TaskQueue:    &taskqueuepb.TaskQueue{Name: taskQueue},
+			Input:        testEncodeFunctionArgs(converter.GetDefaultDataConverter()),
+		}),
+		createTestEventWorkflowTaskScheduled(2, &historypb.WorkflowTaskScheduledEventAttributes{}),
+		createTestEventWorkflowTaskStarted(3),
+		createTestEventWorkflowTaskCompleted(4, &historypb.WorkflowTaskCompletedEventAttributes{}),
+		createTestEventActivityTaskScheduled(5, &historypb.ActivityTaskScheduledEventAttributes{
+			ActivityId:   "5",
+			ActivityType: &commonpb.ActivityType{Name: "testActivity"},
+			TaskQueue:    &taskqueuepb.TaskQueue{Name: taskQueue},
+		}),
+		createTestEventActivityTaskStarted(6, &historypb.ActivityTaskStartedEventAttributes{
+			ScheduledEventId: 5,
+		}),
+		createTestEventActivityTaskCompleted(7, &historypb.ActivityTaskCompletedEventAttributes{
+			ScheduledEventId: 5,
+			StartedEventId:   6,
+		}),
+		createTestEventWorkflowTaskScheduled(8, &historypb.WorkflowTaskScheduledEventAttributes{}),
+		createTestEventWorkflowTaskStarted(9),
+		createTestEventWorkflowTaskCompleted(10, &historypb.WorkflowTaskCompletedEventAttributes{
+			ScheduledEventId: 8,
+			StartedEventId:   9,
+		}),
+		createTestEventWorkflowExecutionCompleted(11, &historypb.WorkflowExecutionCompletedEventAttributes{
+			WorkflowTaskCompletedEventId: 10,
+		}),
+	}
+
+	history := &historypb.History{Events: testEvents}
+	interceptor := &replayedEventsInterceptor{}
+	replayer, err := NewWorkflowReplayer(WorkflowReplayerOptions{Interceptors: []WorkerInterceptor{interceptor}})
+	require.NoError(s.T(), err)
+	replayer.RegisterWorkflow(testReplayWorkflow)
+	err = replayer.ReplayWorkflowHistory(getLogger(), history)
+	require.NoError(s.T(), err)
+
+	// Workflow task scheduled events are not processed by the SDK
+	var eventIDs []int64
+	for _, event := range interceptor.events {
+		eventIDs = append(eventIDs, event.EventID)
+	}
+	require.Equal(s.T(), []int64{1, 3, 4, 5, 6, 7, 9, 10, 11}, eventIDs)
+	require.Equal(s.T(), enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED, interceptor.events[0].EventType)
+	require.Equal(s.T(), enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED, interceptor.events[5].EventType)
+}
+
 func (s *internalWorkerTestSuite) TestReplayWorkflowHistory_IncompleteWorkflowExecution() {
 	taskQueue := "taskQueue1"
 	testEvents := []*historypb.HistoryEvent{
@@ -2637,6 +2966,45 @@ func TestWorkerOptionInvalid(t *testing.T) {
 	require.Panics(t, func() {
 		NewAggregatedWorker(&WorkflowClient{}, "worker-options-tq", WorkerOptions{MaxConcurrentWorkflowTaskPollers: 1})
 	})
+	require.Panics(t, func() {
+		NewAggregatedWorker(&WorkflowClient{}, "worker-options-tq", WorkerOptions{
+			MaxConcurrentActivityTaskPollers: 2,
+			ActivityTaskPollerBehavior:       PollerBehaviorSimpleMaximum{MaximumNumberOfPollers: 2},
+		})
+	})
+	require.Panics(t, func() {
+		NewAggregatedWorker(&WorkflowClient{}, "worker-options-tq", WorkerOptions{
+			WorkflowTaskPollerBehavior: PollerBehaviorSimpleMaximum{MaximumNumberOfPollers: 1},
+		})
+	})
+	require.Panics(t, func() {
+		NewAggregatedWorker(&WorkflowClient{}, "worker-options-tq", WorkerOptions{
+			WorkflowTaskPollerBehavior: PollerBehaviorAutoscaling{MinimumNumberOfPollers: 1},
+		})
+	})
+	require.Panics(t, func() {
+		NewAggregatedWorker(&WorkflowClient{}, "worker-options-tq", WorkerOptions{
+			ActivityExecutorPools: map[string]int{"cpu-heavy": 0},
+		})
+	})
+	require.Panics(t, func() {
+		w := NewAggregatedWorker(&WorkflowClient{}, "worker-options-tq", WorkerOptions{
+			ActivityExecutorPools: map[string]int{"cpu-heavy": 1},
+		})
+		w.RegisterActivityWithOptions(testActivity, RegisterActivityOptions{ExecutorPool: "io-heavy"})
+	})
+}
+
+func TestWorkerOptionStickyScheduleToStartTimeoutRounded(t *testing.T) {
+	for timeout, expected := range map[time.Duration]time.Duration{
+		3 * time.Second:         3 * time.Second,
+		1500 * time.Millisecond: 2 * time.Second,
+		500 * time.Millisecond:  time.Second,
+	} {
+		aggWorker := NewAggregatedWorker(&WorkflowClient{}, "worker-options-tq",
+			WorkerOptions{StickyScheduleToStartTimeout: timeout})
+		require.Equal(t, expected, aggWorker.workflowWorker.executionParameters.StickyScheduleToStartTimeout)
+	}
 }
 
 func TestWorkerOptionDefaults(t *testing.T) {
@@ -2682,6 +3050,90 @@ func TestWorkerOptionDefaults(t *testing.T) {
 	assertWorkerExecutionParamsEqual(t, expected, activityWorker.executionParameters)
 }
 
+func TestWorkerBuildID(t *testing.T) {
+	aggWorker := NewAggregatedWorker(&WorkflowClient{}, "worker-options-tq", WorkerOptions{BuildID: "build-1"})
+	require.Equal(t, "build-1", aggWorker.BuildID())
+
+	// The build ID is derived from the binary checksum when versioning is enabled without one
+	aggWorker = NewAggregatedWorker(&WorkflowClient{}, "worker-options-tq", WorkerOptions{UseBuildIDForVersioning: true})
+	require.Equal(t, getBinaryChecksum(), aggWorker.BuildID())
+	require.Equal(t, getBinaryChecksum(), aggWorker.executionParams.WorkerBuildID)
+
+	handler := metrics.NewCapturingHandler()
+	aggWorker = NewAggregatedWorker(&WorkflowClient{metricsHandler: handler}, "worker-options-tq", WorkerOptions{
+		DeploymentOptions:       WorkerDeploymentOptions{Version: "deployment.build-2"},
+		EnableBuildIDMetricsTag: true,
+	})
+	require.Equal(t, "build-2", aggWorker.BuildID())
+	aggWorker.executionParams.MetricsHandler.Counter("test").Inc(1)
+	require.Equal(t, "build-2", handler.Counters()[0].Tags[metrics.BuildIDTagName])
+}
+
+func TestWorkerStopWithReason(t *testing.T) {
+	aggWorker := NewAggregatedWorker(&WorkflowClient{}, "worker-options-tq", WorkerOptions{WorkerStopTimeout: time.Minute})
+	_, stopping := aggWorker.executionParams.stopState.get()
+	require.False(t, stopping)
+
+	aggWorker.StopWithReason(WorkerStopReasonDeploymentDrain)
+	info, stopping := aggWorker.executionParams.stopState.get()
+	require.True(t, stopping)
+	require.Equal(t, WorkerStopReasonDeploymentDrain, info.Reason)
+	require.WithinDuration(t, time.Now().Add(time.Minute), info.Deadline, 10*time.Second)
+}
+
+func TestWorkerMetricsTags(t *testing.T) {
+	handler := metrics.NewCapturingHandler()
+	aggWorker := NewAggregatedWorker(&WorkflowClient{namespace: "ns", metricsHandler: handler}, "worker-options-tq", WorkerOptions{
+		Identity:    "worker-identity",
+		MetricsTags: map[string]string{"tenant": "tenant-1", metrics.TaskQueueTagName: "ignored"},
+	})
+	aggWorker.executionParams.MetricsHandler.Counter("test").Inc(1)
+	counters := handler.Counters()
+	require.Equal(t, "test", counters[len(counters)-1].Name)
+	require.Equal(t, map[string]string{
+		metrics.NamespaceTagName:      "ns",
+		metrics.TaskQueueTagName:      "worker-options-tq",
+		metrics.WorkerIdentityTagName: "worker-identity",
+		"tenant":                      "tenant-1",
+	}, counters[len(counters)-1].Tags)
+}
+
+func TestWorkerMetricsTagKeys(t *testing.T) {
+	// Reporters like Prometheus require the series of a metric to have the same tag keys
+	handler := metrics.NewCapturingHandler()
+	clientHandler := handler.WithTags(metrics.RootTags("ns"))
+	aggWorker := NewAggregatedWorker(&WorkflowClient{namespace: "ns", metricsHandler: clientHandler}, "worker-options-tq",
+		WorkerOptions{Identity: "worker-identity"})
+	clientHandler.Counter(metrics.TemporalRequest).Inc(1)
+	aggWorker.executionParams.MetricsHandler.Counter(metrics.TemporalRequest).Inc(1)
+
+	tagKeys := func(tags map[string]string) []string {
+		var keys []string
+		for k := range tags {
+			keys = append(keys, k)
+		}
+		return keys
+	}
+	var requests []*metrics.CapturedCounter
+	for _, counter := range handler.Counters() {
+		if counter.Name == metrics.TemporalRequest {
+			requests = append(requests, counter)
+		}
+	}
+	require.Len(t, requests, 2)
+	require.ElementsMatch(t, tagKeys(requests[0].Tags), tagKeys(requests[1].Tags))
+	require.Equal(t, metrics.NoneTagValue, requests[0].Tags[metrics.WorkerIdentityTagName])
+	require.Equal(t, "worker-identity", requests[1].Tags[metrics.WorkerIdentityTagName])
+
+	aggWorker = NewAggregatedWorker(&WorkflowClient{namespace: "ns", metricsHandler: clientHandler}, "worker-options-tq",
+		WorkerOptions{Identity: "worker-identity", BuildID: "build-1", EnableBuildIDMetricsTag: true})
+	aggWorker.executionParams.MetricsHandler.Counter(metrics.TemporalRequest).Inc(1)
+	counters := handler.Counters()
+	require.ElementsMatch(t, tagKeys(requests[0].Tags), tagKeys(counters[len(counters)-1].Tags))
+	require.Equal(t, metrics.NoneTagValue, requests[0].Tags[metrics.BuildIDTagName])
+	require.Equal(t, "build-1", counters[len(counters)-1].Tags[metrics.BuildIDTagName])
+}
+
 func TestWorkerOptionNonDefaults(t *testing.T) {
 	taskQueue := "worker-options-tq"
 
@@ -2983,3 +3435,64 @@ func (s *internalWorkerTestSuite) TestReservedTemporalName() {
 	require.Error(s.T(), err)
 	require.Contains(s.T(), err.Error(), temporalPrefixError)
 }
+
+func TestWorkerUpdateOptions(t *testing.T) {
+	service := workflowservicemock.NewMockWorkflowServiceClient(gomock.NewController(t))
+	client := NewServiceClient(service, nil, ClientOptions{})
+	worker := NewAggregatedWorker(client, "update-options-task-queue", WorkerOptions{EnableSessionWorker: true})
+	tuner := worker.executionParams.Tuner
+
+	require.NoError(t, worker.UpdateOptions(WorkerUpdateOptions{
+		MaxConcurrentWorkflowTaskExecutionSize:  10,
+		MaxConcurrentActivityExecutionSize:      20,
+		MaxConcurrentLocalActivityExecutionSize: 30,
+		MaxConcurrentNexusTaskExecutionSize:     40,
+		WorkerActivitiesPerSecond:               50,
+		WorkerLocalActivitiesPerSecond:          60,
+		TaskQueueActivitiesPerSecond:            70,
+	}))
+	require.Equal(t, 10, tuner.GetWorkflowTaskSlotSupplier().MaxSlots())
+	require.Equal(t, 20, tuner.GetActivityTaskSlotSupplier().MaxSlots())
+	require.Equal(t, 20, tuner.GetSessionActivitySlotSupplier().MaxSlots())
+	require.Equal(t, 30, tuner.GetLocalActivitySlotSupplier().MaxSlots())
+	require.Equal(t, 40, tuner.GetNexusSlotSupplier().MaxSlots())
+	require.Equal(t, rate.Limit(50), worker.activityWorker.worker.taskLimiter.Limit())
+	require.Equal(t, rate.Limit(50), worker.sessionWorker.activityWorker.worker.taskLimiter.Limit())
+	require.Equal(t, rate.Limit(60), worker.workflowWorker.localActivityWorker.taskLimiter.Limit())
+	require.Equal(t, 70.0, worker.activityWorker.poller.(*activityTaskPoller).getActivitiesPerSecond())
+	require.True(t, worker.executionParams.eagerActivityExecutor.taskQueueRateLimited.Load())
+
+	// Zero values are left unchanged
+	require.NoError(t, worker.UpdateOptions(WorkerUpdateOptions{MaxConcurrentActivityExecutionSize: 5}))
+	require.Equal(t, 10, tuner.GetWorkflowTaskSlotSupplier().MaxSlots())
+	require.Equal(t, 5, tuner.GetActivityTaskSlotSupplier().MaxSlots())
+	require.Equal(t, rate.Limit(50), worker.activityWorker.worker.taskLimiter.Limit())
+
+	require.ErrorContains(t, worker.UpdateOptions(WorkerUpdateOptions{MaxConcurrentWorkflowTaskExecutionSize: 1}), "to 1")
+	require.ErrorContains(t, worker.UpdateOptions(WorkerUpdateOptions{WorkerActivitiesPerSecond: -1}), "negative")
+}
+
+func TestWorkerUpdateOptionsCustomTuner(t *testing.T) {
+	service := workflowservicemock.NewMockWorkflowServiceClient(gomock.NewController(t))
+	client := NewServiceClient(service, nil, ClientOptions{})
+	fixedSupplier, err := NewFixedSizeSlotSupplier(10)
+	require.NoError(t, err)
+	tuner, err := NewCompositeTuner(CompositeTunerOptions{
+		WorkflowSlotSupplier:      fixedSupplier,
+		ActivitySlotSupplier:      &throwsOneErrSlotSupplier{},
+		LocalActivitySlotSupplier: fixedSupplier,
+		NexusSlotSupplier:         fixedSupplier,
+	})
+	require.NoError(t, err)
+	worker := NewAggregatedWorker(client, "update-options-task-queue", WorkerOptions{Tuner: tuner})
+
+	require.ErrorContains(t, worker.UpdateOptions(WorkerUpdateOptions{
+		MaxConcurrentWorkflowTaskExecutionSize: 20,
+		MaxConcurrentActivityExecutionSize:     20,
+	}), "MaxConcurrentActivityExecutionSize")
+	// Nothing is applied when an option cannot be
+	require.Equal(t, 10, fixedSupplier.MaxSlots())
+
+	require.NoError(t, worker.UpdateOptions(WorkerUpdateOptions{MaxConcurrentWorkflowTaskExecutionSize: 20}))
+	require.Equal(t, 20, fixedSupplier.MaxSlots())
+}
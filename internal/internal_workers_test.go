@@ -726,6 +726,95 @@ func (s *WorkersTestSuite) TestWorkerMultipleStop() {
 	worker.Stop()
 }
 
+func (s *WorkersTestSuite) TestWorkerStandby() {
+	var polls atomic.Int32
+	s.service.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	s.service.EXPECT().PollWorkflowTaskQueue(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *workflowservice.PollWorkflowTaskQueueRequest, ...grpc.CallOption) (*workflowservice.PollWorkflowTaskQueueResponse, error) {
+			polls.Add(1)
+			return &workflowservice.PollWorkflowTaskQueueResponse{}, nil
+		}).AnyTimes()
+	s.service.EXPECT().PollActivityTaskQueue(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *workflowservice.PollActivityTaskQueueRequest, ...grpc.CallOption) (*workflowservice.PollActivityTaskQueueResponse, error) {
+			polls.Add(1)
+			return &workflowservice.PollActivityTaskQueueResponse{}, nil
+		}).AnyTimes()
+	s.service.EXPECT().ShutdownWorker(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&workflowservice.ShutdownWorkerResponse{}, nil).AnyTimes()
+
+	promoteCh := make(chan struct{})
+	client := NewServiceClient(s.service, nil, ClientOptions{Identity: "standby-identity"})
+	worker := NewAggregatedWorker(client, "standby-tq", WorkerOptions{
+		Standby: true,
+		WaitForPromotion: func(ctx context.Context) error {
+			select {
+			case <-promoteCh:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+	s.NoError(worker.Start())
+	defer worker.Stop()
+
+	// No polling or eager tasks while in standby
+	time.Sleep(100 * time.Millisecond)
+	s.True(worker.IsStandby())
+	s.Zero(polls.Load())
+	s.Nil(worker.workflowWorker.worker.tryReserveSlot())
+
+	// Resuming a pause does not take the worker out of standby
+	worker.PausePolling()
+	worker.ResumePolling()
+	s.True(worker.IsStandby())
+	s.Nil(worker.workflowWorker.worker.tryReserveSlot())
+
+	close(promoteCh)
+	s.Eventually(func() bool { return polls.Load() > 0 }, 2*time.Second, 10*time.Millisecond)
+	s.False(worker.IsStandby())
+}
+
+func (s *WorkersTestSuite) TestWorkerStartGate() {
+	var polls atomic.Int32
+	s.service.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	s.service.EXPECT().PollWorkflowTaskQueue(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *workflowservice.PollWorkflowTaskQueueRequest, ...grpc.CallOption) (*workflowservice.PollWorkflowTaskQueueResponse, error) {
+			polls.Add(1)
+			return &workflowservice.PollWorkflowTaskQueueResponse{}, nil
+		}).AnyTimes()
+	s.service.EXPECT().PollActivityTaskQueue(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&workflowservice.PollActivityTaskQueueResponse{}, nil).AnyTimes()
+	s.service.EXPECT().ShutdownWorker(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&workflowservice.ShutdownWorkerResponse{}, nil).AnyTimes()
+
+	// Fail the first attempt, then block until the dependency is ready
+	var attempts atomic.Int32
+	readyCh := make(chan struct{})
+	client := NewServiceClient(s.service, nil, ClientOptions{Identity: "start-gate-identity"})
+	worker := NewAggregatedWorker(client, "start-gate-tq", WorkerOptions{
+		StartGate: func(ctx context.Context) error {
+			if attempts.Add(1) == 1 {
+				return errors.New("database not ready")
+			}
+			select {
+			case <-readyCh:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+	s.NoError(worker.Start())
+	defer worker.Stop()
+
+	s.Eventually(func() bool { return attempts.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+	s.Zero(polls.Load())
+
+	close(readyCh)
+	s.Eventually(func() bool { return polls.Load() > 0 }, 2*time.Second, 10*time.Millisecond)
+}
+
 func (s *WorkersTestSuite) TestWorkerTaskQueueLimitDisableEager() {
 	client := NewServiceClient(s.service, nil, ClientOptions{Identity: "task-queue-limit-disable-eager"})
 	worker := NewAggregatedWorker(client, "task-queue-limit-disable-eager", WorkerOptions{
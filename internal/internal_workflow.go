@@ -4,6 +4,7 @@ package internal
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -18,7 +19,10 @@ import (
 
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
 	"go.temporal.io/api/sdk/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
 
 	"go.temporal.io/sdk/converter"
 	"go.temporal.io/sdk/internal/common/metrics"
@@ -59,6 +63,16 @@ type (
 		chained []asyncFuture // Futures that are chained to this one
 	}
 
+	// cancellableFutureImpl is a future created with NewCancellableFuture. With FutureCancellationFail, it is failed
+	// with a CanceledError when its context is canceled before it is set, and settings after it is ready are ignored.
+	cancellableFutureImpl struct {
+		*futureImpl
+		mode FutureCancellationMode
+		// Done channel of the context when the cancellation callback is registered on it
+		ctxDone              *channelImpl
+		cancellationCallback *receiveCallback
+	}
+
 	// Implements WaitGroup interface
 	waitGroupImpl struct {
 		n        int      // the number of coroutines to wait on
@@ -89,6 +103,8 @@ type (
 		IsExecuting() bool
 		Close()             // Destroys all coroutines without waiting for their completion
 		StackTrace() string // Stack trace of all coroutines owned by the Dispatcher instance
+		// Structured stack trace of all coroutines owned by the Dispatcher instance
+		StructuredStackTrace() *WorkflowStackTrace
 
 		// Create coroutine. To be called from within other coroutine.
 		// Used by the interceptors
@@ -205,16 +221,20 @@ type (
 		ParentClosePolicy        enumspb.ParentClosePolicy
 		StaticSummary            string
 		StaticDetails            string
-		signalChannels           map[string]Channel
-		requestedSignalChannels  map[string]*requestedSignalChannel
-		queryHandlers            map[string]*queryHandler
-		updateHandlers           map[string]*updateHandler
+		// Overrides DataConverter for child workflows, see ChildWorkflowOptions.DataConverter
+		childDataConverter      converter.DataConverter
+		signalChannels          map[string]Channel
+		requestedSignalChannels map[string]*requestedSignalChannel
+		queryHandlers           map[string]*queryHandler
+		updateHandlers          map[string]*updateHandler
 		// runningUpdatesHandles is a map of update handlers that are currently running.
 		runningUpdatesHandles map[string]UpdateInfo
 		VersioningIntent      VersioningIntent
 		// currentDetails is the user-set string returned on metadata query as
 		// WorkflowMetadata.current_details
 		currentDetails string
+		// activityProgress holds the progress options of the activities scheduled with ActivityOptions.Progress
+		activityProgress *activityProgressState
 	}
 
 	// ExecuteWorkflowParams parameters of the workflow invocation
@@ -232,6 +252,8 @@ type (
 	decodeFutureImpl struct {
 		*futureImpl
 		fn interface{}
+		// Data converter the value is decoded with instead of the one of the context passed to Get, if set
+		dataConverter converter.DataConverter
 	}
 
 	childWorkflowFutureImpl struct {
@@ -461,6 +483,40 @@ func (f *futureImpl) Chain(future Future) {
 	f.ready = true
 }
 
+func (f *cancellableFutureImpl) Set(value interface{}, err error) {
+	if f.mode == FutureCancellationFail && f.IsReady() {
+		return
+	}
+	f.futureImpl.Set(value, err)
+	if f.ctxDone != nil {
+		// future is done, we don't need cancellation anymore
+		f.ctxDone.removeReceiveCallback(f.cancellationCallback)
+	}
+}
+
+func (f *cancellableFutureImpl) SetValue(value interface{}) {
+	f.Set(value, nil)
+}
+
+func (f *cancellableFutureImpl) SetError(err error) {
+	f.Set(nil, err)
+}
+
+func (f *cancellableFutureImpl) Chain(future Future) {
+	if f.mode == FutureCancellationFail && f.IsReady() {
+		return
+	}
+	ch, ok := future.(asyncFuture)
+	if !ok {
+		panic("cannot chain Future that wasn't created with workflow.NewFuture")
+	}
+	if !ch.IsReady() {
+		ch.ChainFuture(f)
+		return
+	}
+	f.Set(ch.GetValueAndError())
+}
+
 func (f *futureImpl) ChainFuture(future Future) {
 	f.chained = append(f.chained, future.(asyncFuture))
 }
@@ -486,6 +542,113 @@ func (f *childWorkflowFutureImpl) SignalChildWorkflow(ctx Context, signalName st
 	return i.SignalChildWorkflow(ctx, childExec.ID, signalName, data)
 }
 
+type childWorkflowHandleImpl[T any] struct {
+	future ChildWorkflowFuture
+}
+
+func (h *childWorkflowHandleImpl[T]) WaitForStart(ctx Context) (WorkflowExecution, error) {
+	var childExec WorkflowExecution
+	err := h.future.GetChildWorkflowExecution().Get(ctx, &childExec)
+	return childExec, err
+}
+
+func (h *childWorkflowHandleImpl[T]) GetResult(ctx Context) (T, error) {
+	var result T
+	err := h.future.Get(ctx, &result)
+	return result, err
+}
+
+func (h *childWorkflowHandleImpl[T]) Future() ChildWorkflowFuture {
+	return h.future
+}
+
+type externalWorkflowHandleImpl struct {
+	workflowID string
+	runID      string
+	options    ExternalWorkflowHandleOptions
+}
+
+func (h *externalWorkflowHandleImpl) GetID() string {
+	return h.workflowID
+}
+
+func (h *externalWorkflowHandleImpl) GetRunID() string {
+	return h.runID
+}
+
+func (h *externalWorkflowHandleImpl) Cancel(ctx Context) Future {
+	return describeUnknownExternalWorkflow(ctx, h, RequestCancelExternalWorkflow(ctx, h.workflowID, h.runID))
+}
+
+// describeUnknownExternalWorkflow returns a future set like the given one, except that an
+// UnknownExternalWorkflowExecutionError is replaced with an ExternalWorkflowNotFoundError or an
+// ExternalWorkflowCompletedError depending on the status of the target execution, which is described by a local
+// activity so replays get the same error. The future is returned as is unless the handle was created with
+// DescribeUnknownTarget, as the local activity adds a marker to the history.
+func describeUnknownExternalWorkflow(ctx Context, handle ExternalWorkflowHandle, future Future) Future {
+	if impl, ok := handle.(*externalWorkflowHandleImpl); !ok || !impl.options.DescribeUnknownTarget {
+		return future
+	}
+	result, settable := NewFuture(ctx)
+	namespace := getWorkflowEnvOptions(setWorkflowEnvOptionsIfNotExist(ctx)).Namespace
+	Go(ctx, func(ctx Context) {
+		err := future.Get(ctx, nil)
+		if _, ok := err.(*UnknownExternalWorkflowExecutionError); ok {
+			ctx = WithLocalActivityOptions(ctx, LocalActivityOptions{
+				ScheduleToCloseTimeout: 10 * time.Second,
+				RetryPolicy:            &RetryPolicy{MaximumAttempts: 3},
+			})
+			var status enumspb.WorkflowExecutionStatus
+			if ExecuteLocalActivity(ctx, describeExternalWorkflowStatus, namespace, handle.GetID(), handle.GetRunID()).
+				Get(ctx, &status) == nil {
+				switch status {
+				case enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED:
+					err = &ExternalWorkflowNotFoundError{}
+				case enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING:
+					// A new run started since, keep the error of the server
+				default:
+					err = &ExternalWorkflowCompletedError{status: status}
+				}
+			}
+		}
+		settable.Set(nil, err)
+	})
+	return result
+}
+
+// describeExternalWorkflowStatus is the local activity returning the status of an external workflow execution, or
+// WORKFLOW_EXECUTION_STATUS_UNSPECIFIED if it does not exist.
+func describeExternalWorkflowStatus(ctx context.Context, namespace, workflowID, runID string) (enumspb.WorkflowExecutionStatus, error) {
+	client := GetClient(ctx)
+	if workflowClient, ok := client.(*WorkflowClient); client == nil || ok && workflowClient == nil {
+		// Like in the test environment
+		return enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED,
+			NewApplicationError("no client to describe the external workflow", "", true, nil)
+	}
+	return externalWorkflowStatus(ctx, client.WorkflowService(), namespace, workflowID, runID)
+}
+
+func externalWorkflowStatus(
+	ctx context.Context,
+	service workflowservice.WorkflowServiceClient,
+	namespace, workflowID, runID string,
+) (enumspb.WorkflowExecutionStatus, error) {
+	grpcCtx, cancel := newGRPCContext(ctx, defaultGrpcRetryParameters(ctx))
+	defer cancel()
+	resp, err := service.DescribeWorkflowExecution(grpcCtx, &workflowservice.DescribeWorkflowExecutionRequest{
+		Namespace: namespace,
+		Execution: &commonpb.WorkflowExecution{WorkflowId: workflowID, RunId: runID},
+	})
+	var notFound *serviceerror.NotFound
+	if errors.As(err, &notFound) {
+		return enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED, nil
+	}
+	if err != nil {
+		return enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED, err
+	}
+	return resp.GetWorkflowExecutionInfo().GetStatus(), nil
+}
+
 func (f *nexusOperationFutureImpl) GetNexusOperationExecution() Future {
 	return f.executionFuture
 }
@@ -585,6 +748,14 @@ func (d *syncWorkflowDefinition) Execute(env WorkflowEnvironment, header *common
 			defaultUpdateHandler(d.rootCtx, name, id, serializedArgs, header, callbacks, updateSchedulerImpl{d.dispatcher})
 		})
 
+	getWorkflowEnvironment(d.rootCtx).RegisterReplayedEventHandler(func(event *historypb.HistoryEvent) {
+		envInterceptor.inboundInterceptor.HandleReplayedEvent(d.rootCtx, &HandleReplayedEventInput{
+			EventID:   event.GetEventId(),
+			EventType: event.GetEventType(),
+			EventTime: event.GetEventTime().AsTime(),
+		})
+	})
+
 	getWorkflowEnvironment(d.rootCtx).RegisterQueryHandler(
 		func(queryType string, queryArgs *commonpb.Payloads, header *commonpb.Header) (*commonpb.Payloads, error) {
 			// Put the header on context if server supports it
@@ -593,21 +764,31 @@ func (d *syncWorkflowDefinition) Execute(env WorkflowEnvironment, header *common
 				return nil, err
 			}
 
-			// As a special case, we handle __temporal_workflow_metadata query
-			// here instead of in workflowExecutionEventHandlerImpl.ProcessQuery
-			// because we need the context environment to do so.
-			if queryType == QueryTypeWorkflowMetadata {
-				if result, err := getWorkflowMetadata(rootCtx); err != nil {
-					return nil, err
+			// As a special case, we handle __temporal_workflow_metadata,
+			// __handlers, and __stack_trace_json queries here instead of in
+			// workflowExecutionEventHandlerImpl.ProcessQuery because we need the
+			// context environment to do so.
+			if queryType == QueryTypeWorkflowMetadata || queryType == QueryTypeHandlers || queryType == QueryTypeStackTraceJSON {
+				var result interface{}
+				if queryType == QueryTypeStackTraceJSON {
+					result = d.dispatcher.StructuredStackTrace()
 				} else {
-					// Use raw value built from default converter because we don't want to use
-					// user-conversion
-					resultPayload, err := converter.GetDefaultDataConverter().ToPayload(result)
+					metadata, err := getWorkflowMetadata(rootCtx)
 					if err != nil {
 						return nil, err
 					}
-					return encodeArg(getDataConverterFromWorkflowContext(rootCtx), converter.NewRawValue(resultPayload))
+					result = metadata
+					if queryType == QueryTypeHandlers {
+						result = getWorkflowHandlers(metadata)
+					}
 				}
+				// Use raw value built from default converter because we don't want to use
+				// user-conversion
+				resultPayload, err := converter.GetDefaultDataConverter().ToPayload(result)
+				if err != nil {
+					return nil, err
+				}
+				return encodeArg(getDataConverterFromWorkflowContext(rootCtx), converter.NewRawValue(resultPayload))
 			}
 
 			eo := getWorkflowEnvOptions(rootCtx)
@@ -615,7 +796,7 @@ func (d *syncWorkflowDefinition) Execute(env WorkflowEnvironment, header *common
 			// even if the interceptor intercepts query handling
 			handler, ok := eo.queryHandlers[queryType]
 			if !ok {
-				keys := []string{QueryTypeStackTrace, QueryTypeOpenSessions, QueryTypeWorkflowMetadata}
+				keys := []string{QueryTypeStackTrace, QueryTypeStackTraceJSON, QueryTypeOpenSessions, QueryTypeWorkflowMetadata, QueryTypeHandlers}
 				for k := range eo.queryHandlers {
 					keys = append(keys, k)
 				}
@@ -695,6 +876,9 @@ func executeDispatcher(ctx Context, dispatcher dispatcher, timeout time.Duration
 	us := weo.getUnhandledSignalNames()
 	if len(us) > 0 {
 		env.GetLogger().Warn("Workflow has unhandled signals", "SignalNames", us)
+		for _, name := range us {
+			env.GetMetricsHandler().WithTags(metrics.SignalTags(name)).Counter(metrics.UnhandledSignalsCounter).Inc(1)
+		}
 	}
 	// Warn if there are any update handlers still running
 	type warnUpdate struct {
@@ -1103,6 +1287,37 @@ func getCoroStackTrace(crt *coroutineState, status string, stackDepth int) (stri
 	return fmt.Sprintf("coroutine %s [%s]:\n%s", crt.name, status, outStack), nil
 }
 
+// getStructuredStackTrace returns the stack of the calling coroutine, omitting the top stackDepth frames and the
+// frames wrapping the coroutine in a goroutine.
+func getStructuredStackTrace(coroutineName, status string, stackDepth int) CoroutineStackTrace {
+	pcs := make([]uintptr, 64)
+	// Skip runtime.Callers and this function
+	for {
+		n := runtime.Callers(stackDepth+2, pcs)
+		if n < len(pcs) {
+			pcs = pcs[:n]
+			break
+		}
+		pcs = make([]uintptr, 2*len(pcs))
+	}
+	result := CoroutineStackTrace{
+		Name:      coroutineName,
+		BlockedOn: strings.TrimPrefix(status, "blocked on "),
+		Frames:    []StackFrame{},
+	}
+	frames := runtime.CallersFrames(pcs)
+	for more := len(pcs) > 0; more; {
+		var frame runtime.Frame
+		frame, more = frames.Next()
+		result.Frames = append(result.Frames, StackFrame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		// Frames below the coroutine are the goroutine running it
+		if !disableCleanStackTraces && strings.HasSuffix(frame.Function, ".(*coroutineState).run") {
+			break
+		}
+	}
+	return result
+}
+
 // unblocked is called by coroutine to indicate that since the last time yield was unblocked channel or select
 // where unblocked versus calling yield again after checking their condition
 func (s *coroutineState) unblocked() {
@@ -1187,6 +1402,18 @@ func (s *coroutineState) stackTrace() string {
 	return <-stackCh
 }
 
+func (s *coroutineState) structuredStackTrace() (CoroutineStackTrace, bool) {
+	if s.closed.Load() {
+		return CoroutineStackTrace{}, false
+	}
+	stackCh := make(chan CoroutineStackTrace, 1)
+	s.unblock <- func(status string, stackDepth int) bool {
+		stackCh <- getStructuredStackTrace(s.name, status, stackDepth)
+		return true
+	}
+	return <-stackCh, true
+}
+
 func (s *coroutineState) run(ctx Context, f func(ctx Context)) {
 	defer runtime.KeepAlive(&s) // keep receiver argument alive for getCoroStackTrace
 	defer s.close()
@@ -1350,6 +1577,16 @@ func (d *dispatcherImpl) StackTrace() string {
 	return result
 }
 
+func (d *dispatcherImpl) StructuredStackTrace() *WorkflowStackTrace {
+	result := &WorkflowStackTrace{Coroutines: []CoroutineStackTrace{}}
+	for i := 0; i < len(d.coroutines); i++ {
+		if stack, ok := d.coroutines[i].structuredStackTrace(); ok {
+			result.Coroutines = append(result.Coroutines, stack)
+		}
+	}
+	return result
+}
+
 func (s *selectorImpl) AddReceive(c ReceiveChannel, f func(c ReceiveChannel, more bool)) Selector {
 	s.cases = append(s.cases, &selectCase{channel: c.(*channelImpl), receiveFunc: &f})
 	return s
@@ -1571,6 +1808,7 @@ func setWorkflowEnvOptionsIfNotExist(ctx Context) Context {
 		newOptions.queryHandlers = make(map[string]*queryHandler)
 		newOptions.updateHandlers = make(map[string]*updateHandler)
 		newOptions.runningUpdatesHandles = make(map[string]UpdateInfo)
+		newOptions.activityProgress = &activityProgressState{options: make(map[string]*ActivityProgressOptions)}
 	}
 	if newOptions.DataConverter == nil {
 		newOptions.DataConverter = converter.GetDefaultDataConverter()
@@ -1607,7 +1845,12 @@ func (w *WorkflowOptions) getSignalChannel(ctx Context, signalName string) Recei
 	return ch
 }
 
-// GetUnhandledSignalNames returns signal names that have unconsumed signals.
+// GetUnhandledSignalNames returns the sorted names of the signals that were received but not consumed yet, because
+// nothing has received them from their signal channel. When a workflow completes with unhandled signals, a warning is
+// logged and the "temporal_unhandled_signals" counter is incremented for each of their names, in the "signal_name" tag,
+// which helps catching misspelled signal names and channels that are not drained.
+//
+// Exposed as: [go.temporal.io/sdk/workflow.GetUnhandledSignalNames]
 func GetUnhandledSignalNames(ctx Context) []string {
 	return getWorkflowEnvOptions(ctx).getUnhandledSignalNames()
 }
@@ -1637,6 +1880,10 @@ func getWorkflowMetadata(ctx Context) (*sdk.WorkflowMetadata, error) {
 					Name:        QueryTypeStackTrace,
 					Description: "Current stack trace",
 				},
+				{
+					Name:        QueryTypeStackTraceJSON,
+					Description: "Current stack trace in a structured form",
+				},
 				{
 					Name:        QueryTypeOpenSessions,
 					Description: "Open sessions on the workflow",
@@ -1645,6 +1892,10 @@ func getWorkflowMetadata(ctx Context) (*sdk.WorkflowMetadata, error) {
 					Name:        QueryTypeWorkflowMetadata,
 					Description: "Metadata about the workflow",
 				},
+				{
+					Name:        QueryTypeHandlers,
+					Description: "Signal, query, and update handlers of the workflow",
+				},
 			},
 		},
 		CurrentDetails: eo.currentDetails,
@@ -1677,6 +1928,22 @@ func getWorkflowMetadata(ctx Context) (*sdk.WorkflowMetadata, error) {
 	return ret, nil
 }
 
+// getWorkflowHandlers returns the handlers in the workflow metadata as the result of the __handlers query.
+func getWorkflowHandlers(metadata *sdk.WorkflowMetadata) *WorkflowHandlers {
+	toHandlerInfos := func(defns []*sdk.WorkflowInteractionDefinition) []WorkflowHandlerInfo {
+		infos := make([]WorkflowHandlerInfo, len(defns))
+		for i, defn := range defns {
+			infos[i] = WorkflowHandlerInfo{Name: defn.GetName(), Description: defn.GetDescription()}
+		}
+		return infos
+	}
+	return &WorkflowHandlers{
+		Signals: toHandlerInfos(metadata.GetDefinition().GetSignalDefinitions()),
+		Queries: toHandlerInfos(metadata.GetDefinition().GetQueryDefinitions()),
+		Updates: toHandlerInfos(metadata.GetDefinition().GetUpdateDefinitions()),
+	}
+}
+
 func sortWorkflowInteractionDefinitions(defns []*sdk.WorkflowInteractionDefinition) {
 	sort.Slice(defns, func(i, j int) bool { return defns[i].Name < defns[j].Name })
 }
@@ -1692,6 +1959,7 @@ func (w *WorkflowOptions) getUnhandledSignalNames() []string {
 			ch.recValue = &v
 		}
 	}
+	sort.Strings(unhandledSignals)
 	return unhandledSignals
 }
 
@@ -1714,7 +1982,10 @@ func (d *decodeFutureImpl) Get(ctx Context, valuePtr interface{}) error {
 	if rf.Type().Kind() != reflect.Ptr {
 		return errors.New("valuePtr parameter is not a pointer")
 	}
-	dataConverter := getDataConverterFromWorkflowContext(ctx)
+	dataConverter := d.dataConverter
+	if dataConverter == nil {
+		dataConverter = getDataConverterFromWorkflowContext(ctx)
+	}
 	err := dataConverter.FromPayloads(d.futureImpl.value.(*commonpb.Payloads), valuePtr)
 	if err != nil {
 		return err
@@ -1726,7 +1997,7 @@ func (d *decodeFutureImpl) Get(ctx Context, valuePtr interface{}) error {
 // fn - the decoded value needs to be validated against a function.
 func newDecodeFuture(ctx Context, fn interface{}) (Future, Settable) {
 	impl := &decodeFutureImpl{
-		&futureImpl{channel: NewChannel(ctx).(*channelImpl)}, fn}
+		futureImpl: &futureImpl{channel: NewChannel(ctx).(*channelImpl)}, fn: fn}
 	return impl, impl
 }
 
@@ -20,6 +20,7 @@ import (
 
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
+	failurepb "go.temporal.io/api/failure/v1"
 	historypb "go.temporal.io/api/history/v1"
 	"go.temporal.io/api/operatorservice/v1"
 	querypb "go.temporal.io/api/query/v1"
@@ -27,6 +28,7 @@ import (
 	"go.temporal.io/api/serviceerror"
 	taskqueuepb "go.temporal.io/api/taskqueue/v1"
 	updatepb "go.temporal.io/api/update/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
 	"go.temporal.io/api/workflowservice/v1"
 
 	"go.temporal.io/sdk/converter"
@@ -76,6 +78,8 @@ type (
 		capabilitiesLock         sync.RWMutex
 		eagerDispatcher          *eagerWorkflowDispatcher
 		getSystemInfoTimeout     time.Duration
+		// gRPC headers added to requests of this client, see ClientOptions.StaticHeaders
+		staticHeaders map[string]string
 
 		// The pointer value is shared across multiple clients. If non-nil, only
 		// access/mutate atomically.
@@ -130,6 +134,19 @@ type (
 		// top of existing values may result in unexpected behavior similar to
 		// json.Unmarshal.
 		GetWithOptions(ctx context.Context, valuePtr interface{}, options WorkflowRunGetOptions) error
+
+		// GetRequestID returns the request ID the workflow was started with, which is StartWorkflowOptions.RequestID
+		// if provided. It is empty if the run was not started by this call, such as a run returned by GetWorkflow.
+		//
+		// NOTE: Experimental
+		GetRequestID() string
+
+		// GetStarted returns whether the call returning this run started a new workflow execution. It is false if the
+		// call attached to an already running workflow, such as with WorkflowIDConflictPolicy UseExisting, and for a
+		// run returned by GetWorkflow.
+		//
+		// NOTE: Experimental
+		GetStarted() bool
 	}
 
 	// WorkflowRunGetOptions are options for WorkflowRun.GetWithOptions.
@@ -148,6 +165,8 @@ type (
 		workflowID       string
 		firstRunID       string
 		currentRunID     *util.OnceCell
+		requestID        string
+		started          bool
 		iterFn           func(ctx context.Context, runID string) HistoryEventIterator
 		dataConverter    converter.DataConverter
 		failureConverter converter.FailureConverter
@@ -924,12 +943,111 @@ type WorkflowExecutionMetadata struct {
 	HistoryLength int
 }
 
+// PendingActivityInfo describes an activity of a workflow execution that has not completed yet.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.PendingActivityInfo]
+type PendingActivityInfo struct {
+	ActivityID   string
+	ActivityType ActivityType
+	State        enumspb.PendingActivityState
+	// Attempt is the current attempt, starting from 1.
+	Attempt int32
+	// MaximumAttempts is the maximum number of attempts of the retry policy, 0 if unlimited.
+	MaximumAttempts int32
+	// LastFailure is the error that failed the last attempt, nil if no attempt failed.
+	LastFailure error
+	// LastAttemptCompleteTime is the time the last attempt failed, nil if no attempt failed.
+	LastAttemptCompleteTime *time.Time
+	// CurrentRetryInterval is the delay the server waits after the last failed attempt before the next one, computed
+	// from the retry policy or taken from the ApplicationErrorOptions.NextRetryDelay of the last failure.
+	CurrentRetryInterval time.Duration
+	// NextAttemptScheduleTime is the time the server schedules the next attempt, nil if no retry is pending.
+	NextAttemptScheduleTime *time.Time
+	// HeartbeatDetails are the details of the last heartbeat of the activity, decoded with the client's
+	// DataConverter. HasValues returns false if the activity has not heartbeated with details.
+	HeartbeatDetails converter.EncodedValues
+	// LastHeartbeatTime is the time of the last heartbeat of the activity, nil if it has not heartbeated.
+	LastHeartbeatTime *time.Time
+	// LastStartedTime is the time the current or last attempt started, nil if no attempt started.
+	LastStartedTime *time.Time
+	// LastWorkerIdentity is the identity of the worker that started the current or last attempt.
+	LastWorkerIdentity string
+}
+
+// PendingChildWorkflowInfo describes a child workflow of a workflow execution that has not completed yet.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.PendingChildWorkflowInfo]
+type PendingChildWorkflowInfo struct {
+	// WorkflowExecution of the child. The run ID is empty if the child has not started yet.
+	WorkflowExecution WorkflowExecution
+	WorkflowType      WorkflowType
+	// InitiatedEventID is the ID of the event in the history of the parent that initiated the child.
+	InitiatedEventID  int64
+	ParentClosePolicy enumspb.ParentClosePolicy
+}
+
+// PendingNexusOperationInfo describes a Nexus operation of a workflow execution that has not completed yet.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.PendingNexusOperationInfo]
+type PendingNexusOperationInfo struct {
+	Endpoint  string
+	Service   string
+	Operation string
+	// OperationToken identifies the operation once it has started asynchronously, empty before.
+	OperationToken string
+	State          enumspb.PendingNexusOperationState
+	// ScheduledEventID is the ID of the event in the history of the workflow that scheduled the operation.
+	ScheduledEventID       int64
+	ScheduledTime          time.Time
+	ScheduleToCloseTimeout time.Duration
+	// Attempt is the current attempt to start the operation, starting from 1.
+	Attempt int32
+	// LastFailure is the error that failed the last attempt, nil if no attempt failed.
+	LastFailure error
+	// LastAttemptCompleteTime is the time the last attempt failed, nil if no attempt failed.
+	LastAttemptCompleteTime *time.Time
+	// NextAttemptScheduleTime is the time the server schedules the next attempt, nil if no retry is pending.
+	NextAttemptScheduleTime *time.Time
+	// BlockedReason is why the operation is blocked, empty if it is not.
+	BlockedReason string
+}
+
 // WorkflowExecutionDescription defines the response to DescribeWorkflow.
 type WorkflowExecutionDescription struct {
 	WorkflowExecutionMetadata
-	dc                   converter.DataConverter
-	staticSummaryPayload *commonpb.Payload
-	staticDetailsPayload *commonpb.Payload
+	// PendingActivities are the activities of the workflow execution that have not completed yet.
+	//
+	// NOTE: Experimental
+	PendingActivities []PendingActivityInfo
+	// PendingChildren are the child workflows of the workflow execution that have not completed yet.
+	//
+	// NOTE: Experimental
+	PendingChildren []PendingChildWorkflowInfo
+	// PendingNexusOperations are the Nexus operations of the workflow execution that have not completed yet.
+	//
+	// NOTE: Experimental
+	PendingNexusOperations []PendingNexusOperationInfo
+	dc                     converter.DataConverter
+	staticSummaryPayload   *commonpb.Payload
+	staticDetailsPayload   *commonpb.Payload
+}
+
+// GetMemo decodes the workflow memo with the given key into valuePtr using the client's DataConverter. Returns an
+// error if there is no memo with the key.
+//
+// NOTE: Experimental
+func (w *WorkflowExecutionDescription) GetMemo(key string, valuePtr interface{}) error {
+	payload := w.Memo.GetFields()[key]
+	if payload == nil {
+		return fmt.Errorf("workflow memo %q not found", key)
+	}
+	return w.dc.FromPayload(payload, valuePtr)
 }
 
 // GetStaticSummary returns the summary set on workflow start.
@@ -1049,6 +1167,30 @@ func (wc *WorkflowClient) ResetWorkflowExecution(ctx context.Context, request *w
 	return resp, nil
 }
 
+// ResetWorkflow resets an existing workflow execution to a workflow task, reapplying the events after it selected by
+// the options, or only returns the events that would be reapplied for dry runs.
+//
+// NOTE: Experimental
+func (wc *WorkflowClient) ResetWorkflow(ctx context.Context, options ResetWorkflowOptions) (ResetWorkflowResult, error) {
+	if err := options.validate(); err != nil {
+		return ResetWorkflowResult{}, err
+	}
+	if err := wc.ensureInitialized(ctx); err != nil {
+		return ResetWorkflowResult{}, err
+	}
+	if options.DryRun {
+		return wc.resetWorkflowDryRun(ctx, &options)
+	}
+
+	grpcCtx, cancel := newGRPCContext(ctx, defaultGrpcRetryParameters(ctx))
+	defer cancel()
+	resp, err := wc.workflowService.ResetWorkflowExecution(grpcCtx, options.toProto(wc.namespace))
+	if err != nil {
+		return ResetWorkflowResult{}, err
+	}
+	return ResetWorkflowResult{RunID: resp.GetRunId()}, nil
+}
+
 // UpdateWorkerBuildIdCompatibility allows you to update the worker-build-id based version sets for a particular
 // task queue. This is used in conjunction with workers who specify their build id and thus opt into the
 // feature.
@@ -1327,7 +1469,7 @@ func (wc *WorkflowClient) WorkflowService() workflowservice.WorkflowServiceClien
 
 // OperatorService implements Client.OperatorService.
 func (wc *WorkflowClient) OperatorService() operatorservice.OperatorServiceClient {
-	return operatorservice.NewOperatorServiceClient(wc.conn)
+	return operatorservice.NewOperatorServiceClient(withStaticHeaders(wc.conn, wc.staticHeaders))
 }
 
 // Get capabilities, lazily fetching from server if not already obtained.
@@ -1394,6 +1536,14 @@ func (wc *WorkflowClient) WorkerDeploymentClient() WorkerDeploymentClient {
 	}
 }
 
+// NexusEndpointClient implements [Client.NexusEndpointClient].
+func (wc *WorkflowClient) NexusEndpointClient() NexusEndpointClient {
+	return &nexusEndpointClient{
+		workflowClient:  wc,
+		operatorService: wc.OperatorService(),
+	}
+}
+
 // Close client and clean up underlying resources.
 func (wc *WorkflowClient) Close() {
 	// If there's a set of unclosed clients, we have to decrement it and then
@@ -1418,6 +1568,43 @@ func (wc *WorkflowClient) Close() {
 	}
 }
 
+// withHeadersProvider returns a copy of the client whose calls to the service add the headers of the provider, for
+// the requests made by a worker. The copy shares the connection, the eager dispatcher, and the interceptors of the
+// client, so calls made through its Client methods do not add the headers. The client itself is returned if it has
+// no connection to wrap.
+func (wc *WorkflowClient) withHeadersProvider(headersProvider HeadersProvider) *WorkflowClient {
+	if wc.conn == nil {
+		wc.logger.Warn("Worker headers provider ignored, the client has no connection")
+		return wc
+	}
+	wc.capabilitiesLock.RLock()
+	capabilities := wc.capabilities
+	wc.capabilitiesLock.RUnlock()
+	return &WorkflowClient{
+		workflowService: workflowservice.NewWorkflowServiceClient(&headersProviderConn{
+			ClientConnInterface: withStaticHeaders(wc.conn, wc.staticHeaders),
+			headersProvider:     headersProvider,
+		}),
+		conn:                     wc.conn,
+		namespace:                wc.namespace,
+		registry:                 wc.registry,
+		logger:                   wc.logger,
+		metricsHandler:           wc.metricsHandler,
+		identity:                 wc.identity,
+		dataConverter:            wc.dataConverter,
+		failureConverter:         wc.failureConverter,
+		contextPropagators:       wc.contextPropagators,
+		workerInterceptors:       wc.workerInterceptors,
+		interceptor:              wc.interceptor,
+		excludeInternalFromRetry: wc.excludeInternalFromRetry,
+		capabilities:             capabilities,
+		eagerDispatcher:          wc.eagerDispatcher,
+		getSystemInfoTimeout:     wc.getSystemInfoTimeout,
+		staticHeaders:            wc.staticHeaders,
+		unclosedClients:          wc.unclosedClients,
+	}
+}
+
 // Register a namespace with temporal server
 // The errors it can throw:
 //   - NamespaceAlreadyExistsError
@@ -1514,9 +1701,12 @@ func (iter *historyEventIteratorImpl) Next() (*historypb.HistoryEvent, error) {
 
 	// we have cached events
 	if iter.nextEventIndex < len(iter.events) {
-		index := iter.nextEventIndex
+		event := iter.events[iter.nextEventIndex]
+		// Release the event so that memory is only held by callers keeping it, and very large histories are read
+		// with a bounded amount of memory
+		iter.events[iter.nextEventIndex] = nil
 		iter.nextEventIndex++
-		return iter.events[index], nil
+		return event, nil
 	} else if iter.err != nil {
 		// we have err, clear that iter.err and return err
 		err := iter.err
@@ -1535,6 +1725,14 @@ func (workflowRun *workflowRunImpl) GetID() string {
 	return workflowRun.workflowID
 }
 
+func (workflowRun *workflowRunImpl) GetRequestID() string {
+	return workflowRun.requestID
+}
+
+func (workflowRun *workflowRunImpl) GetStarted() bool {
+	return workflowRun.started
+}
+
 func (workflowRun *workflowRunImpl) Get(ctx context.Context, valuePtr interface{}) error {
 	return workflowRun.GetWithOptions(ctx, valuePtr, WorkflowRunGetOptions{})
 }
@@ -1578,7 +1776,12 @@ func (workflowRun *workflowRunImpl) GetWithOptions(
 		details := newEncodedValues(attributes.Details, workflowRun.dataConverter)
 		err = NewCanceledError(details)
 	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED:
-		err = newTerminatedError()
+		attributes := closeEvent.GetWorkflowExecutionTerminatedEventAttributes()
+		err = &TerminatedError{
+			reason:   attributes.GetReason(),
+			identity: attributes.GetIdentity(),
+			details:  newEncodedValues(attributes.GetDetails(), workflowRun.dataConverter),
+		}
 	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:
 		attributes := closeEvent.GetWorkflowExecutionTimedOutEventAttributes()
 		if !options.DisableFollowingRuns && attributes.NewExecutionRunId != "" {
@@ -1746,8 +1949,8 @@ func (w *workflowClientInterceptor) createStartWorkflowRequest(
 		return nil, err
 	}
 
-	if in.Options.requestID != "" {
-		startRequest.RequestId = in.Options.requestID
+	if in.Options.RequestID != "" {
+		startRequest.RequestId = in.Options.RequestID
 	} else {
 		startRequest.RequestId = uuid.NewString()
 	}
@@ -1780,6 +1983,7 @@ func (w *workflowClientInterceptor) ExecuteWorkflow(
 	defer cancel()
 
 	var runID string
+	var started bool
 	response, err := w.client.workflowService.StartWorkflowExecution(grpcCtx, startRequest)
 
 	eagerWorkflowTask := response.GetEagerWorkflowTask()
@@ -1796,6 +2000,7 @@ func (w *workflowClientInterceptor) ExecuteWorkflow(
 		return nil, err
 	} else {
 		runID = response.RunId
+		started = response.GetStarted()
 	}
 
 	if responseInfo := in.Options.responseInfo; responseInfo != nil {
@@ -1815,6 +2020,8 @@ func (w *workflowClientInterceptor) ExecuteWorkflow(
 		workflowID:       workflowID,
 		firstRunID:       runID,
 		currentRunID:     &curRunIDCell,
+		requestID:        startRequest.RequestId,
+		started:          started,
 		iterFn:           iterFn,
 		dataConverter:    w.client.dataConverter,
 		failureConverter: w.client.failureConverter,
@@ -1870,6 +2077,8 @@ func (w *workflowClientInterceptor) UpdateWithStartWorkflow(
 			workflowID:       startOp.input.Options.ID,
 			firstRunID:       startResp.RunId,
 			currentRunID:     &runIDCell,
+			requestID:        startReq.RequestId,
+			started:          startResp.GetStarted(),
 			iterFn:           iterFn,
 			dataConverter:    w.client.dataConverter,
 			failureConverter: w.client.failureConverter,
@@ -2099,7 +2308,7 @@ func (w *workflowClientInterceptor) SignalWithStartWorkflow(
 
 	signalWithStartRequest := &workflowservice.SignalWithStartWorkflowExecutionRequest{
 		Namespace:                w.client.namespace,
-		RequestId:                uuid.NewString(),
+		RequestId:                in.Options.RequestID,
 		WorkflowId:               in.Options.ID,
 		WorkflowType:             &commonpb.WorkflowType{Name: in.WorkflowType},
 		TaskQueue:                &taskqueuepb.TaskQueue{Name: in.Options.TaskQueue, Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
@@ -2130,6 +2339,10 @@ func (w *workflowClientInterceptor) SignalWithStartWorkflow(
 		return nil, err
 	}
 
+	if signalWithStartRequest.RequestId == "" {
+		signalWithStartRequest.RequestId = uuid.NewString()
+	}
+
 	var response *workflowservice.SignalWithStartWorkflowExecutionResponse
 
 	// Start creating workflow request.
@@ -2154,6 +2367,8 @@ func (w *workflowClientInterceptor) SignalWithStartWorkflow(
 		workflowID:       in.Options.ID,
 		firstRunID:       response.GetRunId(),
 		currentRunID:     &curRunIDCell,
+		requestID:        signalWithStartRequest.RequestId,
+		started:          response.GetStarted(),
 		iterFn:           iterFn,
 		dataConverter:    w.client.dataConverter,
 		failureConverter: w.client.failureConverter,
@@ -2269,9 +2484,14 @@ func (w *workflowClientInterceptor) DescribeWorkflow(
 	}
 	o := &WorkflowExecutionDescription{
 		WorkflowExecutionMetadata: m,
-		dc:                        w.client.dataConverter,
-		staticSummaryPayload:      resp.GetExecutionConfig().GetUserMetadata().GetSummary(),
-		staticDetailsPayload:      resp.GetExecutionConfig().GetUserMetadata().GetDetails(),
+		PendingActivities: convertFromPBPendingActivities(
+			resp.GetPendingActivities(), w.client.dataConverter, w.client.failureConverter),
+		PendingChildren: convertFromPBPendingChildren(resp.GetPendingChildren()),
+		PendingNexusOperations: convertFromPBPendingNexusOperations(
+			resp.GetPendingNexusOperations(), w.client.failureConverter),
+		dc:                   w.client.dataConverter,
+		staticSummaryPayload: resp.GetExecutionConfig().GetUserMetadata().GetSummary(),
+		staticDetailsPayload: resp.GetExecutionConfig().GetUserMetadata().GetDetails(),
 	}
 
 	return &ClientDescribeWorkflowOutput{
@@ -2279,6 +2499,101 @@ func (w *workflowClientInterceptor) DescribeWorkflow(
 	}, nil
 }
 
+func convertFromPBPendingActivities(
+	activities []*workflowpb.PendingActivityInfo,
+	dataConverter converter.DataConverter,
+	failureConverter converter.FailureConverter,
+) []PendingActivityInfo {
+	if len(activities) == 0 {
+		return nil
+	}
+	result := make([]PendingActivityInfo, len(activities))
+	for i, activity := range activities {
+		result[i] = PendingActivityInfo{
+			ActivityID:           activity.GetActivityId(),
+			ActivityType:         ActivityType{Name: activity.GetActivityType().GetName()},
+			State:                activity.GetState(),
+			Attempt:              activity.GetAttempt(),
+			MaximumAttempts:      activity.GetMaximumAttempts(),
+			CurrentRetryInterval: activity.GetCurrentRetryInterval().AsDuration(),
+			HeartbeatDetails:     newEncodedValues(activity.GetHeartbeatDetails(), dataConverter),
+			LastWorkerIdentity:   activity.GetLastWorkerIdentity(),
+		}
+		if activity.GetLastFailure() != nil {
+			result[i].LastFailure = failureConverter.FailureToError(activity.GetLastFailure())
+		}
+		if activity.GetLastAttemptCompleteTime().IsValid() {
+			t := activity.GetLastAttemptCompleteTime().AsTime()
+			result[i].LastAttemptCompleteTime = &t
+		}
+		if activity.GetNextAttemptScheduleTime().IsValid() {
+			t := activity.GetNextAttemptScheduleTime().AsTime()
+			result[i].NextAttemptScheduleTime = &t
+		}
+		if activity.GetLastHeartbeatTime().IsValid() {
+			t := activity.GetLastHeartbeatTime().AsTime()
+			result[i].LastHeartbeatTime = &t
+		}
+		if activity.GetLastStartedTime().IsValid() {
+			t := activity.GetLastStartedTime().AsTime()
+			result[i].LastStartedTime = &t
+		}
+	}
+	return result
+}
+
+func convertFromPBPendingChildren(children []*workflowpb.PendingChildExecutionInfo) []PendingChildWorkflowInfo {
+	if len(children) == 0 {
+		return nil
+	}
+	result := make([]PendingChildWorkflowInfo, len(children))
+	for i, child := range children {
+		result[i] = PendingChildWorkflowInfo{
+			WorkflowExecution: WorkflowExecution{ID: child.GetWorkflowId(), RunID: child.GetRunId()},
+			WorkflowType:      WorkflowType{Name: child.GetWorkflowTypeName()},
+			InitiatedEventID:  child.GetInitiatedId(),
+			ParentClosePolicy: child.GetParentClosePolicy(),
+		}
+	}
+	return result
+}
+
+func convertFromPBPendingNexusOperations(
+	operations []*workflowpb.PendingNexusOperationInfo,
+	failureConverter converter.FailureConverter,
+) []PendingNexusOperationInfo {
+	if len(operations) == 0 {
+		return nil
+	}
+	result := make([]PendingNexusOperationInfo, len(operations))
+	for i, operation := range operations {
+		result[i] = PendingNexusOperationInfo{
+			Endpoint:               operation.GetEndpoint(),
+			Service:                operation.GetService(),
+			Operation:              operation.GetOperation(),
+			OperationToken:         operation.GetOperationToken(),
+			State:                  operation.GetState(),
+			ScheduledEventID:       operation.GetScheduledEventId(),
+			ScheduledTime:          operation.GetScheduledTime().AsTime(),
+			ScheduleToCloseTimeout: operation.GetScheduleToCloseTimeout().AsDuration(),
+			Attempt:                operation.GetAttempt(),
+			BlockedReason:          operation.GetBlockedReason(),
+		}
+		if operation.GetLastAttemptFailure() != nil {
+			result[i].LastFailure = failureConverter.FailureToError(operation.GetLastAttemptFailure())
+		}
+		if operation.GetLastAttemptCompleteTime().IsValid() {
+			t := operation.GetLastAttemptCompleteTime().AsTime()
+			result[i].LastAttemptCompleteTime = &t
+		}
+		if operation.GetNextAttemptScheduleTime().IsValid() {
+			t := operation.GetNextAttemptScheduleTime().AsTime()
+			result[i].NextAttemptScheduleTime = &t
+		}
+	}
+	return result
+}
+
 func (w *workflowClientInterceptor) QueryWorkflow(
 	ctx context.Context,
 	in *ClientQueryWorkflowInput,
@@ -2474,7 +2789,7 @@ func (w *workflowClientInterceptor) PollWorkflowUpdate(
 		switch v := resp.GetOutcome().GetValue().(type) {
 		case *updatepb.Outcome_Failure:
 			return &ClientPollWorkflowUpdateOutput{
-				Error: w.client.failureConverter.FailureToError(v.Failure),
+				Error: w.client.updateFailureToError(v.Failure),
 			}, nil
 		case *updatepb.Outcome_Success:
 			return &ClientPollWorkflowUpdateOutput{
@@ -2522,7 +2837,7 @@ func (w *workflowClientInterceptor) updateHandleFromResponse(
 		}, nil
 	case *updatepb.Outcome_Failure:
 		return &completedUpdateHandle{
-			err:              w.client.failureConverter.FailureToError(v.Failure),
+			err:              w.client.updateFailureToError(v.Failure),
 			baseUpdateHandle: baseUpdateHandle{ref: resp.GetUpdateRef()},
 		}, nil
 	case *updatepb.Outcome_Success:
@@ -2534,6 +2849,19 @@ func (w *workflowClientInterceptor) updateHandleFromResponse(
 	return nil, fmt.Errorf("unsupported outcome type %T", resp.GetOutcome().GetValue())
 }
 
+// updateFailureToError converts the failure of an update outcome to an error, which is a WorkflowUpdateRejectedError
+// if the update was rejected by a Go workflow.
+func (wc *WorkflowClient) updateFailureToError(failure *failurepb.Failure) error {
+	err := wc.failureConverter.FailureToError(failure)
+	if failure.GetSource() != updateRejectionFailureSource {
+		return err
+	}
+	return &WorkflowUpdateRejectedError{
+		code:  failure.GetApplicationFailureInfo().GetType(),
+		cause: err,
+	}
+}
+
 func (uh *baseUpdateHandle) WorkflowID() string {
 	return uh.ref.GetWorkflowExecution().GetWorkflowId()
 }
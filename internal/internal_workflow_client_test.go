@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -14,6 +15,8 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	ilog "go.temporal.io/sdk/internal/log"
 
@@ -210,6 +213,8 @@ func (s *historyEventIteratorSuite) TestIterator_NoError() {
 		events = append(events, event)
 	}
 	s.Equal(3, len(events))
+	// Consumed events are released by the iterator
+	s.Nil(response1.History.Events[0])
 }
 
 func (s *historyEventIteratorSuite) TestIterator_NoError_EmptyPage() {
@@ -493,6 +498,54 @@ func (s *workflowRunSuite) TestExecuteWorkflow_StartWorkflowResponseInfo() {
 	s.Equal(link, responseInfo.Link)
 }
 
+func (s *workflowRunSuite) TestExecuteWorkflow_RequestID() {
+	var requestIDs []string
+	s.workflowServiceClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.StartWorkflowExecutionRequest, _ ...grpc.CallOption) (*workflowservice.StartWorkflowExecutionResponse, error) {
+			requestIDs = append(requestIDs, req.RequestId)
+			return &workflowservice.StartWorkflowExecutionResponse{RunId: runID}, nil
+		}).Times(2)
+
+	options := StartWorkflowOptions{
+		ID:        workflowID,
+		TaskQueue: taskqueue,
+		RequestID: "external-request-id",
+	}
+	run, err := s.workflowClient.ExecuteWorkflow(context.Background(), options, workflowType)
+	s.NoError(err)
+	s.Equal("external-request-id", run.GetRequestID())
+
+	options.RequestID = ""
+	run, err = s.workflowClient.ExecuteWorkflow(context.Background(), options, workflowType)
+	s.NoError(err)
+	s.NotEmpty(run.GetRequestID())
+	s.Equal([]string{"external-request-id", run.GetRequestID()}, requestIDs)
+}
+
+func (s *workflowRunSuite) TestExecuteWorkflow_Started() {
+	options := StartWorkflowOptions{
+		ID:                       workflowID,
+		TaskQueue:                taskqueue,
+		WorkflowIDConflictPolicy: enumspb.WORKFLOW_ID_CONFLICT_POLICY_USE_EXISTING,
+	}
+	for _, started := range []bool{true, false} {
+		s.workflowServiceClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&workflowservice.StartWorkflowExecutionResponse{RunId: runID, Started: started}, nil).Times(1)
+		run, err := s.workflowClient.ExecuteWorkflow(context.Background(), options, workflowType)
+		s.NoError(err)
+		s.Equal(runID, run.GetRunID())
+		s.Equal(started, run.GetStarted())
+	}
+
+	s.workflowServiceClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, serviceerror.NewWorkflowExecutionAlreadyStarted("Already Started", "", runID)).Times(1)
+	options.WorkflowIDConflictPolicy = enumspb.WORKFLOW_ID_CONFLICT_POLICY_FAIL
+	run, err := s.workflowClient.ExecuteWorkflow(context.Background(), options, workflowType)
+	s.NoError(err)
+	s.Equal(runID, run.GetRunID())
+	s.False(run.GetStarted())
+}
+
 func (s *workflowRunSuite) TestExecuteWorkflowWorkflowExecutionAlreadyStartedError() {
 	mockerr := serviceerror.NewWorkflowExecutionAlreadyStarted("Already Started", "", runID)
 	s.workflowServiceClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -842,6 +895,56 @@ func (s *workflowRunSuite) TestExecuteWorkflow_NoDup_Terminated() {
 	s.Equal(time.Minute, decodedResult)
 }
 
+func (s *workflowRunSuite) TestExecuteWorkflow_NoDup_TerminatedWithDetails() {
+	createResponse := &workflowservice.StartWorkflowExecutionResponse{
+		RunId: runID,
+	}
+	s.workflowServiceClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any()).Return(createResponse, nil).Times(1)
+
+	type terminationContext struct {
+		Ticket string
+		Forced bool
+	}
+	details, err := s.dataConverter.ToPayloads(terminationContext{Ticket: "OPS-1", Forced: true}, "operator")
+	s.NoError(err)
+	getRequest := getGetWorkflowExecutionHistoryRequest(enumspb.HISTORY_EVENT_FILTER_TYPE_CLOSE_EVENT)
+	getResponse := &workflowservice.GetWorkflowExecutionHistoryResponse{
+		History: &historypb.History{
+			Events: []*historypb.HistoryEvent{
+				{
+					EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED,
+					Attributes: &historypb.HistoryEvent_WorkflowExecutionTerminatedEventAttributes{WorkflowExecutionTerminatedEventAttributes: &historypb.WorkflowExecutionTerminatedEventAttributes{
+						Reason:   "stuck",
+						Identity: "ops-tool",
+						Details:  details,
+					}}},
+			},
+		},
+	}
+	s.workflowServiceClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), getRequest, gomock.Any()).Return(getResponse, nil).Times(1)
+
+	workflowRun, err := s.workflowClient.ExecuteWorkflow(
+		context.Background(),
+		StartWorkflowOptions{
+			ID:        workflowID,
+			TaskQueue: taskqueue,
+		}, workflowType,
+	)
+	s.NoError(err)
+
+	err = workflowRun.Get(context.Background(), nil)
+	var terminatedErr *TerminatedError
+	s.True(errors.As(err, &terminatedErr))
+	s.Equal("stuck", terminatedErr.Reason())
+	s.Equal("ops-tool", terminatedErr.Identity())
+	s.True(terminatedErr.HasDetails())
+	var decodedContext terminationContext
+	var operator string
+	s.NoError(terminatedErr.Details(&decodedContext, &operator))
+	s.Equal(terminationContext{Ticket: "OPS-1", Forced: true}, decodedContext)
+	s.Equal("operator", operator)
+}
+
 func (s *workflowRunSuite) TestExecuteWorkflow_NoDup_TimedOut() {
 	createResponse := &workflowservice.StartWorkflowExecutionResponse{
 		RunId: runID,
@@ -1006,6 +1109,146 @@ func (s *workflowRunSuite) TestGetWorkflowNoRunId() {
 	s.Equal(runID, workflowRunNoRunID.GetRunID())
 }
 
+func (s *workflowRunSuite) TestDescribeWorkflow_PendingActivities() {
+	completeTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	nextAttemptTime := completeTime.Add(30 * time.Second)
+	failure := GetDefaultFailureConverter().ErrorToFailure(NewApplicationErrorWithOptions("rate limited", "RateLimited",
+		ApplicationErrorOptions{NextRetryDelay: 30 * time.Second}))
+	describeResp := &workflowservice.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &workflowpb.WorkflowExecutionInfo{
+			Execution:        &commonpb.WorkflowExecution{WorkflowId: workflowID, RunId: runID},
+			SearchAttributes: &commonpb.SearchAttributes{},
+		},
+		PendingActivities: []*workflowpb.PendingActivityInfo{
+			{
+				ActivityId:   "1",
+				ActivityType: &commonpb.ActivityType{Name: "Fetch"},
+				State:        enumspb.PENDING_ACTIVITY_STATE_SCHEDULED,
+				Attempt:      2,
+				LastFailure:  failure,
+
+				LastAttemptCompleteTime: timestamppb.New(completeTime),
+				CurrentRetryInterval:    durationpb.New(30 * time.Second),
+				NextAttemptScheduleTime: timestamppb.New(nextAttemptTime),
+			},
+			{
+				ActivityId:   "2",
+				ActivityType: &commonpb.ActivityType{Name: "Store"},
+				State:        enumspb.PENDING_ACTIVITY_STATE_STARTED,
+				Attempt:      1,
+			},
+		},
+	}
+	s.workflowServiceClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any()).Return(describeResp, nil).Times(1)
+
+	description, err := s.workflowClient.DescribeWorkflow(context.Background(), workflowID, runID)
+	s.NoError(err)
+	s.Len(description.PendingActivities, 2)
+
+	retrying := description.PendingActivities[0]
+	s.Equal("1", retrying.ActivityID)
+	s.Equal("Fetch", retrying.ActivityType.Name)
+	s.Equal(enumspb.PENDING_ACTIVITY_STATE_SCHEDULED, retrying.State)
+	s.Equal(int32(2), retrying.Attempt)
+	var applicationErr *ApplicationError
+	s.True(errors.As(retrying.LastFailure, &applicationErr))
+	s.Equal("RateLimited", applicationErr.Type())
+	s.Equal(30*time.Second, applicationErr.NextRetryDelay())
+	s.Equal(completeTime, *retrying.LastAttemptCompleteTime)
+	s.Equal(30*time.Second, retrying.CurrentRetryInterval)
+	s.Equal(nextAttemptTime, *retrying.NextAttemptScheduleTime)
+
+	running := description.PendingActivities[1]
+	s.Equal("2", running.ActivityID)
+	s.NoError(running.LastFailure)
+	s.Nil(running.LastAttemptCompleteTime)
+	s.Nil(running.NextAttemptScheduleTime)
+}
+
+func (s *workflowRunSuite) TestDescribeWorkflow_PendingChildrenAndNexusOperations() {
+	scheduledTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	dc := converter.GetDefaultDataConverter()
+	memo, err := dc.ToPayload("memo-value")
+	s.NoError(err)
+	heartbeatDetails, err := dc.ToPayloads(42)
+	s.NoError(err)
+	describeResp := &workflowservice.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &workflowpb.WorkflowExecutionInfo{
+			Execution:        &commonpb.WorkflowExecution{WorkflowId: workflowID, RunId: runID},
+			Memo:             &commonpb.Memo{Fields: map[string]*commonpb.Payload{"key": memo}},
+			SearchAttributes: &commonpb.SearchAttributes{},
+		},
+		PendingActivities: []*workflowpb.PendingActivityInfo{
+			{
+				ActivityId:         "1",
+				State:              enumspb.PENDING_ACTIVITY_STATE_STARTED,
+				HeartbeatDetails:   heartbeatDetails,
+				LastHeartbeatTime:  timestamppb.New(scheduledTime),
+				LastWorkerIdentity: "worker",
+			},
+		},
+		PendingChildren: []*workflowpb.PendingChildExecutionInfo{
+			{
+				WorkflowId:        "child",
+				RunId:             "child-run",
+				WorkflowTypeName:  "ChildWorkflow",
+				InitiatedId:       5,
+				ParentClosePolicy: enumspb.PARENT_CLOSE_POLICY_ABANDON,
+			},
+		},
+		PendingNexusOperations: []*workflowpb.PendingNexusOperationInfo{
+			{
+				Endpoint:           "endpoint",
+				Service:            "service",
+				Operation:          "operation",
+				State:              enumspb.PENDING_NEXUS_OPERATION_STATE_BACKING_OFF,
+				ScheduledEventId:   7,
+				ScheduledTime:      timestamppb.New(scheduledTime),
+				Attempt:            2,
+				LastAttemptFailure: GetDefaultFailureConverter().ErrorToFailure(errors.New("unavailable")),
+			},
+		},
+	}
+	s.workflowServiceClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any()).Return(describeResp, nil).Times(1)
+
+	description, err := s.workflowClient.DescribeWorkflow(context.Background(), workflowID, runID)
+	s.NoError(err)
+
+	var memoValue string
+	s.NoError(description.GetMemo("key", &memoValue))
+	s.Equal("memo-value", memoValue)
+	s.ErrorContains(description.GetMemo("unknown", &memoValue), `workflow memo "unknown" not found`)
+
+	s.Len(description.PendingActivities, 1)
+	activity := description.PendingActivities[0]
+	s.True(activity.HeartbeatDetails.HasValues())
+	var progress int
+	s.NoError(activity.HeartbeatDetails.Get(&progress))
+	s.Equal(42, progress)
+	s.Equal(scheduledTime, *activity.LastHeartbeatTime)
+	s.Nil(activity.LastStartedTime)
+	s.Equal("worker", activity.LastWorkerIdentity)
+
+	s.Equal([]PendingChildWorkflowInfo{{
+		WorkflowExecution: WorkflowExecution{ID: "child", RunID: "child-run"},
+		WorkflowType:      WorkflowType{Name: "ChildWorkflow"},
+		InitiatedEventID:  5,
+		ParentClosePolicy: enumspb.PARENT_CLOSE_POLICY_ABANDON,
+	}}, description.PendingChildren)
+
+	s.Len(description.PendingNexusOperations, 1)
+	operation := description.PendingNexusOperations[0]
+	s.Equal("endpoint", operation.Endpoint)
+	s.Equal("service", operation.Service)
+	s.Equal("operation", operation.Operation)
+	s.Equal(enumspb.PENDING_NEXUS_OPERATION_STATE_BACKING_OFF, operation.State)
+	s.Equal(int64(7), operation.ScheduledEventID)
+	s.Equal(scheduledTime, operation.ScheduledTime)
+	s.Equal(int32(2), operation.Attempt)
+	s.ErrorContains(operation.LastFailure, "unavailable")
+	s.Nil(operation.NextAttemptScheduleTime)
+}
+
 func (s *workflowRunSuite) TestGetWorkflowNoExtantWorkflowAndNoRunId() {
 	describeResp := &workflowservice.DescribeWorkflowExecutionResponse{
 		WorkflowExecutionInfo: nil}
@@ -1073,6 +1316,48 @@ func (s *workflowRunSuite) TestExecuteWorkflowWithUpdate_Retry() {
 	s.NoError(err)
 }
 
+func (s *workflowRunSuite) TestExecuteUpdateWithStartWorkflow() {
+	updateResult, err := converter.GetDefaultDataConverter().ToPayloads("update result")
+	s.NoError(err)
+	s.workflowServiceClient.EXPECT().
+		ExecuteMultiOperation(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.ExecuteMultiOperationRequest, _ ...grpc.CallOption) (*workflowservice.ExecuteMultiOperationResponse, error) {
+			startReq := req.Operations[0].GetStartWorkflow()
+			s.Equal(enumspb.WORKFLOW_ID_CONFLICT_POLICY_USE_EXISTING, startReq.WorkflowIdConflictPolicy)
+			updateReq := req.Operations[1].GetUpdateWorkflow()
+			s.Equal(workflowID, updateReq.WorkflowExecution.WorkflowId)
+			s.Equal("update", updateReq.Request.Input.Name)
+			return &workflowservice.ExecuteMultiOperationResponse{
+				Responses: []*workflowservice.ExecuteMultiOperationResponse_Response{
+					{
+						Response: &workflowservice.ExecuteMultiOperationResponse_Response_StartWorkflow{
+							StartWorkflow: &workflowservice.StartWorkflowExecutionResponse{RunId: runID},
+						},
+					},
+					{
+						Response: &workflowservice.ExecuteMultiOperationResponse_Response_UpdateWorkflow{
+							UpdateWorkflow: &workflowservice.UpdateWorkflowExecutionResponse{
+								Stage:   enumspb.UPDATE_WORKFLOW_EXECUTION_LIFECYCLE_STAGE_COMPLETED,
+								Outcome: &updatepb.Outcome{Value: &updatepb.Outcome_Success{Success: updateResult}},
+							},
+						},
+					},
+				},
+			}, nil
+		})
+
+	builder := NewUpdateWithStartWorkflowBuilder(StartWorkflowOptions{
+		ID:        workflowID,
+		TaskQueue: taskqueue,
+	}, workflowType).UseExisting().Update("update")
+	run, update, err := ExecuteUpdateWithStartWorkflow[string, string](context.Background(), s.workflowClient, builder)
+	s.NoError(err)
+	s.Equal(runID, run.GetRunID())
+	result, err := update.GetResult(context.Background())
+	s.NoError(err)
+	s.Equal("update result", result)
+}
+
 func (s *workflowRunSuite) TestExecuteWorkflowWithUpdate_DefaultTimeout() {
 	var actualDeadline time.Time
 	expectedDeadline := time.Now().Add(pollUpdateTimeout)
@@ -1417,7 +1702,8 @@ func (s *workflowClientTestSuite) TestSignalWithStartWorkflow() {
 	}
 
 	startResponse := &workflowservice.SignalWithStartWorkflowExecutionResponse{
-		RunId: runID,
+		RunId:   runID,
+		Started: true,
 	}
 	s.service.EXPECT().SignalWithStartWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any()).Return(startResponse, nil).Times(2)
 
@@ -1425,6 +1711,7 @@ func (s *workflowClientTestSuite) TestSignalWithStartWorkflow() {
 		options, workflowType)
 	s.Nil(err)
 	s.Equal(startResponse.GetRunId(), resp.GetRunID())
+	s.True(resp.GetStarted())
 
 	options.ID = ""
 	resp, err = s.client.SignalWithStartWorkflow(context.Background(), "", signalName, signalInput,
@@ -2031,6 +2318,67 @@ func (s *workflowClientTestSuite) TestGetSearchAttributes() {
 	s.IsType(&serviceerror.InvalidArgument{}, err)
 }
 
+func (s *workflowClientTestSuite) TestResetWorkflow() {
+	s.service.EXPECT().ResetWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&workflowservice.ResetWorkflowExecutionResponse{RunId: "new-run"}, nil).
+		Do(func(_ interface{}, req *workflowservice.ResetWorkflowExecutionRequest, _ ...interface{}) {
+			s.Equal(DefaultNamespace, req.GetNamespace())
+			s.Equal(workflowID, req.GetWorkflowExecution().GetWorkflowId())
+			s.Equal(int64(4), req.GetWorkflowTaskFinishEventId())
+			s.NotEmpty(req.GetRequestId())
+			s.Equal([]enumspb.ResetReapplyExcludeType{enumspb.RESET_REAPPLY_EXCLUDE_TYPE_UPDATE}, req.GetResetReapplyExcludeTypes())
+		})
+	result, err := s.client.ResetWorkflow(context.Background(), ResetWorkflowOptions{
+		WorkflowID:                workflowID,
+		WorkflowTaskFinishEventID: 4,
+		Reapply:                   ResetReapplyOptions{ExcludeUpdates: true},
+	})
+	s.NoError(err)
+	s.Equal(ResetWorkflowResult{RunID: "new-run"}, result)
+
+	_, err = s.client.ResetWorkflow(context.Background(), ResetWorkflowOptions{WorkflowID: workflowID})
+	s.ErrorContains(err, "workflow task finish event ID must be positive")
+}
+
+func (s *workflowClientTestSuite) TestResetWorkflowDryRun() {
+	events := []*historypb.HistoryEvent{
+		createTestEventWorkflowExecutionStarted(1, &historypb.WorkflowExecutionStartedEventAttributes{}),
+		createTestEventWorkflowTaskScheduled(2, &historypb.WorkflowTaskScheduledEventAttributes{}),
+		createTestEventWorkflowExecutionSignaled(3, "before"),
+		createTestEventWorkflowTaskStarted(4),
+		createTestEventWorkflowTaskCompleted(5, &historypb.WorkflowTaskCompletedEventAttributes{}),
+		createTestEventWorkflowExecutionSignaled(6, "after"),
+		{EventId: 7, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_ACCEPTED},
+		createTestEventWorkflowTaskScheduled(8, &historypb.WorkflowTaskScheduledEventAttributes{}),
+	}
+	s.service.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *workflowservice.GetWorkflowExecutionHistoryRequest, ...grpc.CallOption) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+			return &workflowservice.GetWorkflowExecutionHistoryResponse{
+				History: &historypb.History{Events: slices.Clone(events)},
+			}, nil
+		}).Times(4)
+	s.service.EXPECT().ResetWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	options := ResetWorkflowOptions{WorkflowID: workflowID, WorkflowTaskFinishEventID: 5, DryRun: true}
+	result, err := s.client.ResetWorkflow(context.Background(), options)
+	s.NoError(err)
+	s.Empty(result.RunID)
+	s.Equal([]*historypb.HistoryEvent{events[5], events[6]}, result.ReappliedEvents)
+
+	options.Reapply.ExcludeSignals = true
+	result, err = s.client.ResetWorkflow(context.Background(), options)
+	s.NoError(err)
+	s.Equal([]*historypb.HistoryEvent{events[6]}, result.ReappliedEvents)
+
+	options.WorkflowTaskFinishEventID = 3
+	_, err = s.client.ResetWorkflow(context.Background(), options)
+	s.ErrorContains(err, "cannot reset to event 3 of type WorkflowExecutionSignaled")
+
+	options.WorkflowTaskFinishEventID = 9
+	_, err = s.client.ResetWorkflow(context.Background(), options)
+	s.ErrorContains(err, "event 9 not found in the history")
+}
+
 func serializeEvents(events []*historypb.HistoryEvent) *commonpb.DataBlob {
 	blob, _ := serializer.SerializeBatchEvents(events, enumspb.ENCODING_TYPE_PROTO3)
 
@@ -2217,6 +2565,36 @@ func TestUpdate(t *testing.T) {
 		err = handle.Get(context.TODO(), &got)
 		require.Error(t, err)
 		require.ErrorContains(t, err, want.Error())
+		var rejectedErr *WorkflowUpdateRejectedError
+		require.False(t, errors.As(err, &rejectedErr))
+	})
+	t.Run("sync rejected", func(t *testing.T) {
+		svc, client := init(t)
+		req := newRequest(t, sync)
+		// Go workflows mark the failures of rejected updates with their source
+		outcome := mustOutcome(t, NewApplicationError("too late", "OrderShipped", false, nil, "order-1"))
+		outcome.GetFailure().Source = updateRejectionFailureSource
+		svc.EXPECT().
+			UpdateWorkflowExecution(gomock.Any(), gomock.Any()).Return(
+			&workflowservice.UpdateWorkflowExecutionResponse{
+				UpdateRef: refFromRequest(req),
+				Outcome:   outcome,
+				Stage:     enumspb.UPDATE_WORKFLOW_EXECUTION_LIFECYCLE_STAGE_COMPLETED,
+			},
+			nil,
+		)
+		handle, err := client.UpdateWorkflow(context.TODO(), req)
+		require.NoError(t, err)
+		err = handle.Get(context.TODO(), nil)
+		var rejectedErr *WorkflowUpdateRejectedError
+		require.ErrorAs(t, err, &rejectedErr)
+		require.Equal(t, "OrderShipped", rejectedErr.Code())
+		var applicationErr *ApplicationError
+		require.ErrorAs(t, err, &applicationErr)
+		require.Equal(t, "OrderShipped", applicationErr.Type())
+		var details string
+		require.NoError(t, applicationErr.Details(&details))
+		require.Equal(t, "order-1", details)
 	})
 	t.Run("async success", func(t *testing.T) {
 		svc, client := init(t)
@@ -11,9 +11,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/api/workflowservicemock/v1"
 
 	"go.temporal.io/sdk/converter"
 	"go.temporal.io/sdk/internal/common/metrics"
@@ -666,6 +672,40 @@ func (s *WorkflowUnitTest) Test_SignalWorkflow() {
 	s.EqualValues(strings.Join(expected, ""), string(result))
 }
 
+func unhandledSignalWorkflowTest(ctx Context) ([]string, error) {
+	if err := Sleep(ctx, time.Hour); err != nil {
+		return nil, err
+	}
+	return GetUnhandledSignalNames(ctx), nil
+}
+
+func (s *WorkflowUnitTest) Test_UnhandledSignalWorkflow_ShouldLogMetrics() {
+	metricsHandler := metrics.NewCapturingHandler()
+	s.SetMetricsHandler(metricsHandler)
+	env := s.NewTestWorkflowEnvironment()
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow("typoSignal", "value")
+		env.SignalWorkflow("otherSignal", "value")
+		env.SignalWorkflow("otherSignal", "value")
+	}, time.Minute)
+
+	env.ExecuteWorkflow(unhandledSignalWorkflowTest)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+	var result []string
+	s.NoError(env.GetWorkflowResult(&result))
+	s.Equal([]string{"otherSignal", "typoSignal"}, result)
+
+	counters := metricsHandler.Counters()
+	s.Len(counters, 2)
+	for i, name := range result {
+		s.Equal(metrics.UnhandledSignalsCounter, counters[i].Name)
+		s.Equal(name, counters[i].Tags[metrics.SignalNameTagName])
+		s.EqualValues(1, counters[i].Value())
+	}
+}
+
 type message struct {
 	Value string
 }
@@ -1378,6 +1418,158 @@ func (s *WorkflowUnitTest) Test_MutatingFunctionsInQueries() {
 	s.NoError(env.GetWorkflowError())
 }
 
+func (s *WorkflowUnitTest) Test_WaitForSignal() {
+	wf := func(ctx Context) ([]string, error) {
+		value, ok, err := WaitForSignal[string](ctx, "approve", time.Hour)
+		if err != nil {
+			return nil, err
+		}
+		result := []string{fmt.Sprintf("%v:%v", value, ok)}
+		value, ok, err = WaitForSignal[string](ctx, "approve", time.Hour)
+		if err != nil {
+			return nil, err
+		}
+		return append(result, fmt.Sprintf("%v:%v", value, ok)), nil
+	}
+
+	env := s.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(wf)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow("approve", "yes")
+	}, time.Minute)
+	env.ExecuteWorkflow(wf)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+	var result []string
+	s.NoError(env.GetWorkflowResult(&result))
+	s.Equal([]string{"yes:true", ":false"}, result)
+
+	env = s.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(wf)
+	env.RegisterDelayedCallback(func() {
+		env.CancelWorkflow()
+	}, time.Minute)
+	env.ExecuteWorkflow(wf)
+	s.True(env.IsWorkflowCompleted())
+	var canceledErr *CanceledError
+	s.ErrorAs(env.GetWorkflowError(), &canceledErr)
+}
+
+func (s *WorkflowUnitTest) Test_HandlersQuery() {
+	env := s.NewTestWorkflowEnvironment()
+
+	wf := func(ctx Context) error {
+		_ = GetSignalChannelWithOptions(ctx, "my-signal", SignalChannelOptions{Description: "My signal"})
+		_ = SetQueryHandlerWithOptions(ctx, "my-query", func() (string, error) {
+			return "", nil
+		}, QueryHandlerOptions{Description: "My query"})
+		_ = SetUpdateHandler(ctx, "my-update", func(ctx Context) error {
+			return nil
+		}, UpdateHandlerOptions{})
+		_ = Sleep(ctx, time.Minute)
+		return nil
+	}
+	env.RegisterWorkflow(wf)
+	env.RegisterDelayedCallback(func() {
+		val, err := env.QueryWorkflow(QueryTypeHandlers)
+		s.NoError(err)
+		var handlers WorkflowHandlers
+		s.NoError(val.Get(&handlers))
+		s.Equal([]WorkflowHandlerInfo{{Name: "my-signal", Description: "My signal"}}, handlers.Signals)
+		s.Equal([]WorkflowHandlerInfo{{Name: "my-update"}}, handlers.Updates)
+		s.Equal([]WorkflowHandlerInfo{
+			{Name: QueryTypeHandlers, Description: "Signal, query, and update handlers of the workflow"},
+			{Name: QueryTypeOpenSessions, Description: "Open sessions on the workflow"},
+			{Name: QueryTypeStackTrace, Description: "Current stack trace"},
+			{Name: QueryTypeStackTraceJSON, Description: "Current stack trace in a structured form"},
+			{Name: QueryTypeWorkflowMetadata, Description: "Metadata about the workflow"},
+			{Name: "my-query", Description: "My query"},
+		}, handlers.Queries)
+	}, time.Second)
+	env.ExecuteWorkflow(wf)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+}
+
+func (s *WorkflowUnitTest) Test_StackTraceJSONQuery() {
+	env := s.NewTestWorkflowEnvironment()
+
+	wf := func(ctx Context) error {
+		GoNamed(ctx, "waiter", func(ctx Context) {
+			GetSignalChannel(ctx, "my-signal").Receive(ctx, nil)
+		})
+		_ = Sleep(ctx, time.Minute)
+		return nil
+	}
+	env.RegisterWorkflow(wf)
+	env.RegisterDelayedCallback(func() {
+		val, err := env.QueryWorkflow(QueryTypeStackTraceJSON)
+		s.NoError(err)
+		var trace WorkflowStackTrace
+		s.NoError(val.Get(&trace))
+		s.Len(trace.Coroutines, 2)
+
+		root := trace.Coroutines[0]
+		s.Equal("root", root.Name)
+		// Sleep blocks on the channel of the timer future
+		s.True(strings.HasSuffix(root.BlockedOn, ".Receive"), root.BlockedOn)
+		s.NotEmpty(root.Frames)
+
+		waiter := trace.Coroutines[1]
+		s.Equal("waiter", waiter.Name)
+		s.Equal("my-signal.Receive", waiter.BlockedOn)
+		s.NotEmpty(waiter.Frames)
+		// Internal frames are omitted, so the top frame is the workflow code
+		s.Contains(waiter.Frames[0].Function, "Test_StackTraceJSONQuery")
+		s.True(strings.HasSuffix(waiter.Frames[0].File, "internal_workflow_test.go"), waiter.Frames[0].File)
+		s.Positive(waiter.Frames[0].Line)
+		// Frames of the goroutine running the coroutine are omitted too
+		s.True(strings.HasSuffix(waiter.Frames[len(waiter.Frames)-1].Function, ".(*coroutineState).run"))
+	}, time.Second)
+	env.ExecuteWorkflow(wf)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+}
+
+func (s *WorkflowUnitTest) Test_StateSnapshotQuery() {
+	env := s.NewTestWorkflowEnvironment()
+
+	type progress struct {
+		Processed int
+		Total     int
+	}
+	wf := func(ctx Context) error {
+		state := progress{Total: 3}
+		if err := RegisterStateSnapshot(ctx, func() progress { return state }); err != nil {
+			return err
+		}
+		for state.Processed < state.Total {
+			if err := Sleep(ctx, time.Minute); err != nil {
+				return err
+			}
+			state.Processed++
+		}
+		return nil
+	}
+	env.RegisterWorkflow(wf)
+	env.RegisterDelayedCallback(func() {
+		val, err := env.QueryWorkflow(QueryTypeStateSnapshot)
+		s.NoError(err)
+		var state progress
+		s.NoError(val.Get(&state))
+		s.Equal(progress{Processed: 1, Total: 3}, state)
+
+		val, err = env.QueryWorkflow(QueryTypeHandlers)
+		s.NoError(err)
+		var handlers WorkflowHandlers
+		s.NoError(val.Get(&handlers))
+		s.Contains(handlers.Queries, WorkflowHandlerInfo{Name: QueryTypeStateSnapshot, Description: "Snapshot of the workflow state"})
+	}, 90*time.Second)
+	env.ExecuteWorkflow(wf)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+}
+
 func (s *WorkflowUnitTest) Test_MutatingFunctionsInUpdateValidator() {
 	env := s.NewTestWorkflowEnvironment()
 
@@ -1590,3 +1782,26 @@ func TestStackTraceInvalidDepthBounded(t *testing.T) {
 	lines = strings.Split(getStackTrace("mycoroutine", "success", 100), "\n")
 	require.True(t, len(lines) > 3 && len(lines) < 100)
 }
+
+func TestExternalWorkflowStatus(t *testing.T) {
+	service := workflowservicemock.NewMockWorkflowServiceClient(gomock.NewController(t))
+	service.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&workflowservice.DescribeWorkflowExecutionResponse{WorkflowExecutionInfo: &workflowpb.WorkflowExecutionInfo{
+			Status: enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+		}}, nil)
+	status, err := externalWorkflowStatus(context.Background(), service, "ns", "wid", "rid")
+	require.NoError(t, err)
+	require.Equal(t, enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, status)
+
+	// Executions that do not exist have no status
+	service.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, serviceerror.NewNotFound("workflow not found"))
+	status, err = externalWorkflowStatus(context.Background(), service, "ns", "wid", "rid")
+	require.NoError(t, err)
+	require.Equal(t, enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED, status)
+
+	service.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, serviceerror.NewUnavailable("unavailable"))
+	_, err = externalWorkflowStatus(context.Background(), service, "ns", "wid", "rid")
+	require.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -24,6 +25,7 @@ import (
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
 	failurepb "go.temporal.io/api/failure/v1"
+	historypb "go.temporal.io/api/history/v1"
 	nexuspb "go.temporal.io/api/nexus/v1"
 	"go.temporal.io/api/serviceerror"
 	taskqueuepb "go.temporal.io/api/taskqueue/v1"
@@ -62,6 +64,9 @@ type (
 		mockTimeToFire time.Time
 		wallTimeToFire time.Time
 		timerID        int64
+		// Summary set in TimerOptions, and whether the timer was started by the workflow
+		summary       string
+		workflowTimer bool
 	}
 
 	testActivityHandle struct {
@@ -84,11 +89,15 @@ type (
 		params          executeNexusOperationParams
 		operationToken  string
 		cancelRequested bool
-		started         bool
-		done            bool
-		onCompleted     func(*commonpb.Payload, error)
-		onStarted       func(opID string, e error)
-		isMocked        bool
+		// Whether the cancel handler of the operation is running
+		canceling bool
+		// Completion received while the cancel handler was running, reported once it returns
+		pendingCompletion func()
+		started           bool
+		done              bool
+		onCompleted       func(*commonpb.Payload, error)
+		onStarted         func(opID string, e error)
+		isMocked          bool
 	}
 
 	testNexusAsyncOperationHandle struct {
@@ -196,6 +205,9 @@ type (
 		onNexusOperationStartedListener   func(service string, operation string, args converter.EncodedValue)
 		onNexusOperationCompletedListener func(service string, operation string, result converter.EncodedValue, err error)
 		onNexusOperationCanceledListener  func(service string, operation string)
+
+		// Shared by the activity task handlers, which are created per task
+		activityExecutorPools *activityExecutorPools
 	}
 
 	// testWorkflowEnvironmentImpl is the environment that runs the workflow/activity unit tests.
@@ -373,6 +385,23 @@ func newTestWorkflowEnvironmentImpl(s *WorkflowTestSuite, parentRegistry *regist
 		return &workflowservice.RecordActivityTaskHeartbeatResponse{CancelRequested: false}, nil
 	}).AnyTimes()
 
+	// Activities signal their workflow to report their progress, see RecordActivityProgress
+	mockService.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(
+		ctx context.Context,
+		r *workflowservice.SignalWorkflowExecutionRequest,
+		opts ...grpc.CallOption,
+	) (*workflowservice.SignalWorkflowExecutionResponse, error) {
+		if env.activityEnvOnly {
+			return &workflowservice.SignalWorkflowExecutionResponse{}, nil
+		}
+		env.postCallback(func() {
+			if handle, ok := env.runningWorkflows[r.GetWorkflowExecution().GetWorkflowId()]; ok && !handle.handled {
+				_ = handle.env.signalHandler(r.GetSignalName(), r.GetInput(), r.GetHeader())
+			}
+		}, true)
+		return &workflowservice.SignalWorkflowExecutionResponse{}, nil
+	}).AnyTimes()
+
 	env.service = mockService
 
 	return env
@@ -491,6 +520,11 @@ func (env *testWorkflowEnvironmentImpl) newTestWorkflowEnvironmentForChild(
 func (env *testWorkflowEnvironmentImpl) setWorkerOptions(options WorkerOptions) {
 	env.workerOptions = options
 	env.registry.interceptors = options.Interceptors
+	pools, err := newActivityExecutorPools(options.ActivityExecutorPools)
+	if err != nil {
+		panic(err)
+	}
+	env.activityExecutorPools = pools
 	if env.workerOptions.EnableSessionWorker && env.sessionEnvironment == nil {
 		env.registry.RegisterActivityWithOptions(sessionCreationActivity, RegisterActivityOptions{
 			Name:                          sessionCreationActivityName,
@@ -614,8 +648,13 @@ func (env *testWorkflowEnvironmentImpl) getWorkflowDefinition(wt WorkflowType) (
 		return nil, fmt.Errorf("unable to find workflow type: %v. Supported types: [%v]", wt.Name, supported)
 	}
 	wd := &workflowExecutorWrapper{
-		workflowExecutor: &workflowExecutor{workflowType: wt.Name, fn: wf, interceptors: env.registry.interceptors},
-		env:              env,
+		workflowExecutor: &workflowExecutor{
+			workflowType:   wt.Name,
+			fn:             wf,
+			inputValidator: env.registry.getWorkflowInputValidator(wt),
+			interceptors:   env.registry.interceptors,
+		},
+		env: env,
 	}
 	return newSyncWorkflowDefinition(wd), nil
 }
@@ -774,11 +813,13 @@ func (env *testWorkflowEnvironmentImpl) executeLocalActivity(
 		header:        params.Header,
 	}
 	taskHandler := localActivityTaskHandler{
-		backgroundContext:  env.workerOptions.BackgroundActivityContext,
+		backgroundContext:  env.withActivityClock(env.workerOptions.BackgroundActivityContext),
 		metricsHandler:     env.metricsHandler,
 		logger:             env.logger,
 		interceptors:       env.registry.interceptors,
 		contextPropagators: env.contextPropagators,
+
+		watchdogGracePeriod: env.workerOptions.LocalActivityWatchdogGracePeriod,
 	}
 
 	result := taskHandler.executeLocalActivityTask(task)
@@ -1207,6 +1248,18 @@ func (env *testWorkflowEnvironmentImpl) GetContextPropagators() []ContextPropaga
 	return env.contextPropagators
 }
 
+// payloadSizeLimits returns the payload size limits of the worker options, with the defaults of a worker.
+func (env *testWorkflowEnvironmentImpl) payloadSizeLimits() payloadSizeLimits {
+	limits := payloadSizeLimits{
+		warnSize:  env.workerOptions.PayloadSizeWarningThreshold,
+		errorSize: env.workerOptions.PayloadSizeErrorThreshold,
+	}
+	if limits.warnSize == 0 {
+		limits.warnSize = defaultPayloadSizeWarningThreshold
+	}
+	return limits
+}
+
 func (env *testWorkflowEnvironmentImpl) ExecuteActivity(parameters ExecuteActivityParams, callback ResultHandler) ActivityID {
 	ensureDefaultRetryPolicy(&parameters)
 	scheduleTaskAttr := &commandpb.ScheduleActivityTaskCommandAttributes{}
@@ -1217,6 +1270,7 @@ func (env *testWorkflowEnvironmentImpl) ExecuteActivity(parameters ExecuteActivi
 		scheduleTaskAttr.ActivityId = parameters.ActivityID
 	}
 	activityID := ActivityID{id: scheduleTaskAttr.GetActivityId()}
+	env.payloadSizeLimits().check(env.logger, "activity", parameters.ActivityType.Name, parameters.Input)
 	scheduleTaskAttr.ActivityType = &commonpb.ActivityType{Name: parameters.ActivityType.Name}
 	scheduleTaskAttr.TaskQueue = &taskqueuepb.TaskQueue{Name: parameters.TaskQueueName, Kind: enumspb.TASK_QUEUE_KIND_NORMAL}
 	scheduleTaskAttr.Input = parameters.Input
@@ -1474,7 +1528,7 @@ func (env *testWorkflowEnvironmentImpl) executeActivityWithRetryForTest(
 			}
 
 			p := fromProtoRetryPolicy(parameters.RetryPolicy)
-			backoff := getRetryBackoffWithNowTime(p, task.GetAttempt(), env.failureConverter.FailureToError(failure), env.Now(), expireTime)
+			backoff := getRetryBackoffWithNowTime(p, nil, nil, task.GetAttempt(), env.failureConverter.FailureToError(failure), env.Now(), expireTime)
 			if backoff > 0 {
 				// need a retry
 				waitCh := make(chan struct{})
@@ -1484,6 +1538,7 @@ func (env *testWorkflowEnvironmentImpl) executeActivityWithRetryForTest(
 				env.registerDelayedCallback(func() {
 					env.runningCount++
 					task.Attempt = task.GetAttempt() + 1
+					task.CurrentAttemptScheduledTime = timestamppb.New(env.Now())
 					activityID := ActivityID{id: string(task.TaskToken)}
 					if ah, ok := env.getActivityHandle(activityID.id, task.WorkflowExecution.RunId); ok {
 						task.HeartbeatDetails = ah.heartbeatDetails
@@ -1520,7 +1575,7 @@ func getRetryBackoffFromProtoRetryPolicy(prp *commonpb.RetryPolicy, attempt int3
 	}
 
 	p := fromProtoRetryPolicy(prp)
-	return getRetryBackoffWithNowTime(p, attempt, err, now, expireTime)
+	return getRetryBackoffWithNowTime(p, nil, nil, attempt, err, now, expireTime)
 }
 
 func ensureDefaultRetryPolicy(parameters *ExecuteActivityParams) {
@@ -1567,12 +1622,14 @@ func (env *testWorkflowEnvironmentImpl) ExecuteLocalActivity(params ExecuteLocal
 
 	task := newLocalActivityTask(params, callback, activityID)
 	taskHandler := localActivityTaskHandler{
-		backgroundContext:  env.workerOptions.BackgroundActivityContext,
+		backgroundContext:  env.withActivityClock(env.workerOptions.BackgroundActivityContext),
 		metricsHandler:     env.metricsHandler,
 		logger:             env.logger,
 		dataConverter:      env.dataConverter,
 		contextPropagators: env.contextPropagators,
 		interceptors:       env.registry.interceptors,
+
+		watchdogGracePeriod: env.workerOptions.LocalActivityWatchdogGracePeriod,
 	}
 
 	env.localActivities[activityID] = task
@@ -2069,19 +2126,29 @@ func (m *mockWrapper) executeMock(ctx interface{}, input *commonpb.Payloads, moc
 	return m.getMockValue(mockRet)
 }
 
+// withActivityClock returns the context for activities, with the environment's clock returned by GetActivityNow.
+func (env *testWorkflowEnvironmentImpl) withActivityClock(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, activityClockContextKey, env.mockClock.Now)
+}
+
 func (env *testWorkflowEnvironmentImpl) newTestActivityTaskHandler(taskQueue string, dataConverter converter.DataConverter) ActivityTaskHandler {
 	setWorkerOptionsDefaults(&env.workerOptions)
 	params := workerExecutionParameters{
-		TaskQueue:          taskQueue,
-		Identity:           env.identity,
-		MetricsHandler:     env.metricsHandler,
-		Logger:             env.logger,
-		BackgroundContext:  env.workerOptions.BackgroundActivityContext,
-		FailureConverter:   env.failureConverter,
-		DataConverter:      dataConverter,
-		WorkerStopChannel:  env.workerStopChannel,
-		ContextPropagators: env.contextPropagators,
-	}
+		TaskQueue:           taskQueue,
+		Identity:            env.identity,
+		MetricsHandler:      env.metricsHandler,
+		Logger:              env.logger,
+		BackgroundContext:   env.workerOptions.BackgroundActivityContext,
+		FailureConverter:    env.failureConverter,
+		DataConverter:       dataConverter,
+		WorkerStopChannel:   env.workerStopChannel,
+		ContextPropagators:  env.contextPropagators,
+		ActivityResultCache: env.workerOptions.ActivityResultCache,
+	}
+	params.activityExecutorPools = env.activityExecutorPools
 	ensureRequiredParams(&params)
 	if params.BackgroundContext == nil {
 		params.BackgroundContext = context.Background()
@@ -2090,6 +2157,7 @@ func (env *testWorkflowEnvironmentImpl) newTestActivityTaskHandler(taskQueue str
 		env.sessionEnvironment = newTestSessionEnvironment(env, &params, env.workerOptions.MaxConcurrentSessionExecutionSize)
 	}
 	params.BackgroundContext = context.WithValue(params.BackgroundContext, sessionEnvironmentContextKey, env.sessionEnvironment)
+	params.BackgroundContext = env.withActivityClock(params.BackgroundContext)
 	registry := env.registry
 	if len(registry.getRegisteredActivities()) == 0 {
 		panic(fmt.Sprintf("no activity is registered for taskqueue '%v'", taskQueue))
@@ -2140,15 +2208,16 @@ func newTestActivityTask(workflowID, runID, workflowTypeName, namespace string,
 			WorkflowId: workflowID,
 			RunId:      runID,
 		},
-		ActivityId:             activityID,
-		TaskToken:              []byte(activityID), // use activityID as TaskToken so we can map TaskToken in heartbeat calls.
-		ActivityType:           &commonpb.ActivityType{Name: attr.GetActivityType().GetName()},
-		Input:                  attr.GetInput(),
-		ScheduledTime:          timestamppb.New(now),
-		ScheduleToCloseTimeout: attr.GetScheduleToCloseTimeout(),
-		StartedTime:            timestamppb.New(now),
-		StartToCloseTimeout:    attr.GetStartToCloseTimeout(),
-		HeartbeatTimeout:       attr.GetHeartbeatTimeout(),
+		ActivityId:                  activityID,
+		TaskToken:                   []byte(activityID), // use activityID as TaskToken so we can map TaskToken in heartbeat calls.
+		ActivityType:                &commonpb.ActivityType{Name: attr.GetActivityType().GetName()},
+		Input:                       attr.GetInput(),
+		ScheduledTime:               timestamppb.New(now),
+		CurrentAttemptScheduledTime: timestamppb.New(now),
+		ScheduleToCloseTimeout:      attr.GetScheduleToCloseTimeout(),
+		StartedTime:                 timestamppb.New(now),
+		StartToCloseTimeout:         attr.GetStartToCloseTimeout(),
+		HeartbeatTimeout:            attr.GetHeartbeatTimeout(),
 		WorkflowType: &commonpb.WorkflowType{
 			Name: workflowTypeName,
 		},
@@ -2184,6 +2253,8 @@ func (env *testWorkflowEnvironmentImpl) newTimer(
 		wallTimeToFire: env.wallClock.Now().Add(d),
 		duration:       d,
 		timerID:        nextID,
+		summary:        options.Summary,
+		workflowTimer:  notifyListener,
 	}
 	if notifyListener && env.onTimerScheduledListener != nil {
 		env.onTimerScheduledListener(timerInfo.id, d)
@@ -2191,6 +2262,26 @@ func (env *testWorkflowEnvironmentImpl) newTimer(
 	return timerInfo
 }
 
+func (env *testWorkflowEnvironmentImpl) getPendingTimers() []TestPendingTimerInfo {
+	var timers []*testTimerHandle
+	for _, t := range env.timers {
+		if t.workflowTimer {
+			timers = append(timers, t)
+		}
+	}
+	sort.Slice(timers, func(i, j int) bool { return timers[i].timerID < timers[j].timerID })
+	infos := make([]TestPendingTimerInfo, len(timers))
+	for i, t := range timers {
+		infos[i] = TestPendingTimerInfo{
+			TimerID:  getStringID(t.timerID),
+			Summary:  t.summary,
+			Duration: t.duration,
+			FireTime: t.mockTimeToFire,
+		}
+	}
+	return infos
+}
+
 func (env *testWorkflowEnvironmentImpl) NewTimer(
 	d time.Duration,
 	options TimerOptions,
@@ -2254,6 +2345,10 @@ func (env *testWorkflowEnvironmentImpl) RegisterQueryHandler(
 	env.queryHandler = handler
 }
 
+func (env *testWorkflowEnvironmentImpl) RegisterReplayedEventHandler(func(event *historypb.HistoryEvent)) {
+	// Workflows are never replayed in the test environment
+}
+
 func (env *testWorkflowEnvironmentImpl) RequestCancelChildWorkflow(_, workflowID string) {
 	if childHandle, ok := env.runningWorkflows[workflowID]; ok && !childHandle.handled {
 		// current workflow is a parent workflow, and we are canceling a child workflow
@@ -2681,6 +2776,58 @@ func (env *testWorkflowEnvironmentImpl) scheduleNexusAsyncOperationCompletion(
 	}, completionHandle.delay)
 }
 
+func (env *testWorkflowEnvironmentImpl) completeNexusOperation(
+	service string,
+	operation string,
+	token string,
+	result any,
+	err error,
+) error {
+	var handle *testNexusOperationHandle
+	for _, h := range env.runningNexusOperations {
+		if h.started && !h.done && h.params.client.Service() == service && h.params.operation == operation &&
+			h.operationToken == token {
+			handle = h
+			break
+		}
+	}
+	if handle == nil {
+		return fmt.Errorf("no started nexus service %q operation %q with token %q", service, operation, token)
+	}
+
+	var data *commonpb.Payload
+	var nexusErr error
+	if err != nil {
+		nexusErr = env.failureConverter.FailureToError(nexusOperationFailure(
+			handle.params,
+			token,
+			env.failureConverter.ErrorToFailure(err),
+		))
+	} else {
+		opRef := env.nexusOperationRefs[service][operation]
+		if opRef != nil && reflect.TypeOf(result) != opRef.OutputType() {
+			return fmt.Errorf(
+				"nexus service %q operation %q expected result type %s, got %T",
+				service,
+				operation,
+				opRef.OutputType(),
+				result,
+			)
+		}
+		if result != nil {
+			var encodeErr error
+			data, encodeErr = env.GetDataConverter().ToPayload(result)
+			if encodeErr != nil {
+				return encodeErr
+			}
+		}
+	}
+	env.postCallback(func() {
+		handle.completedCallback(data, nexusErr)
+	}, true)
+	return nil
+}
+
 func (env *testWorkflowEnvironmentImpl) resolveNexusOperation(seq int64, token string, result *commonpb.Payload, err error) {
 	env.postCallback(func() {
 		handle, ok := env.getNexusOperationHandle(seq)
@@ -3238,6 +3385,12 @@ func (h *testNexusOperationHandle) completedCallback(result *commonpb.Payload, e
 		// Ignore duplicate completions.
 		return
 	}
+	if h.canceling {
+		// The cancel handler may complete the operation, such as by canceling its workflow, before returning. Wait
+		// for it to return so that it is done by the time the caller sees the operation completed.
+		h.pendingCompletion = func() { h.completedCallback(result, err) }
+		return
+	}
 	h.done = true
 	h.env.deleteNexusOperationHandle(h.seq)
 	h.onCompleted(result, err)
@@ -3276,12 +3429,17 @@ func (h *testNexusOperationHandle) cancel() {
 			h.params.client.Endpoint(), h.params.client.Service(), h.params.operation))
 	}
 	h.env.runningCount++
+	h.canceling = true
 	task := h.newCancelTask()
 	taskHandler := h.env.newTestNexusTaskHandler(h)
 
 	go func() {
 		_, failure, err := taskHandler.Execute(task)
 		h.env.postCallback(func() {
+			h.canceling = false
+			if h.pendingCompletion != nil {
+				h.env.postCallback(h.pendingCompletion, true)
+			}
 			if err != nil {
 				// No retries in the test env, fail the operation immediately.
 				h.completedCallback(nil, fmt.Errorf("operation cancelation handler failed: %w", err))
@@ -5,11 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/nexus-rpc/sdk-go/nexus"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	commonpb "go.temporal.io/api/common/v1"
@@ -18,6 +21,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/internal/common/metrics"
 	iconverter "go.temporal.io/sdk/internal/converter"
 	ilog "go.temporal.io/sdk/internal/log"
 )
@@ -238,6 +242,35 @@ func (s *WorkflowTestSuiteUnitTest) Test_TimerWorkflow_ClockAutoFastForward() {
 	s.Equal([]string{"t2", "t3", "t1", "t4"}, firedTimerRecord)
 }
 
+func (s *WorkflowTestSuiteUnitTest) Test_TimerWorkflow_PendingTimers() {
+	workflowFn := func(ctx Context) error {
+		backoff := NewTimerWithOptions(ctx, time.Minute, TimerOptions{Summary: "retry backoff"})
+		sla := NewTimerWithOptions(ctx, time.Hour, TimerOptions{Summary: "SLA deadline"})
+		if err := backoff.Get(ctx, nil); err != nil {
+			return err
+		}
+		return sla.Get(ctx, nil)
+	}
+
+	env := s.NewTestWorkflowEnvironment()
+	var pendingTimers [][]TestPendingTimerInfo
+	env.SetOnTimerFiredListener(func(string) {
+		pendingTimers = append(pendingTimers, env.GetPendingTimers())
+	})
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	env.SetStartTime(startTime)
+	env.ExecuteWorkflow(workflowFn)
+
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+	s.Len(pendingTimers, 2)
+	s.Len(pendingTimers[0], 1)
+	s.Equal("SLA deadline", pendingTimers[0][0].Summary)
+	s.Equal(time.Hour, pendingTimers[0][0].Duration)
+	s.True(startTime.Add(time.Hour).Equal(pendingTimers[0][0].FireTime))
+	s.Empty(pendingTimers[1])
+}
+
 func (s *WorkflowTestSuiteUnitTest) Test_WorkflowAutoForwardClock() {
 	workflowFn := func(ctx Context) (string, error) {
 		// Schedule a timer with long duration. In this test, we won't actually wait for that long, because the test suite
@@ -390,6 +423,372 @@ func (s *WorkflowTestSuiteUnitTest) Test_ActivityWithUserContext() {
 	s.Equal(testValue, value)
 }
 
+func (s *WorkflowTestSuiteUnitTest) Test_ActivityNow() {
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	activityNow := func(ctx context.Context) (time.Time, error) {
+		return GetActivityNow(ctx), nil
+	}
+
+	env := s.NewTestActivityEnvironment().SetStartTime(startTime)
+	env.RegisterActivity(activityNow)
+	for _, execute := range []func(interface{}, ...interface{}) (converter.EncodedValue, error){
+		env.ExecuteActivity, env.ExecuteLocalActivity,
+	} {
+		blob, err := execute(activityNow)
+		s.NoError(err)
+		var now time.Time
+		s.NoError(blob.Get(&now))
+		s.True(startTime.Equal(now))
+	}
+
+	// Activities see the time skipped by workflows
+	workflowFn := func(ctx Context) ([]time.Time, error) {
+		if err := Sleep(ctx, time.Hour); err != nil {
+			return nil, err
+		}
+		var now, localNow time.Time
+		ctx = WithActivityOptions(ctx, ActivityOptions{StartToCloseTimeout: time.Minute})
+		if err := ExecuteActivity(ctx, activityNow).Get(ctx, &now); err != nil {
+			return nil, err
+		}
+		ctx = WithLocalActivityOptions(ctx, LocalActivityOptions{StartToCloseTimeout: time.Minute})
+		if err := ExecuteLocalActivity(ctx, activityNow).Get(ctx, &localNow); err != nil {
+			return nil, err
+		}
+		return []time.Time{now, localNow}, nil
+	}
+	wfEnv := s.NewTestWorkflowEnvironment()
+	wfEnv.SetStartTime(startTime)
+	wfEnv.RegisterWorkflow(workflowFn)
+	wfEnv.RegisterActivity(activityNow)
+	wfEnv.ExecuteWorkflow(workflowFn)
+	s.NoError(wfEnv.GetWorkflowError())
+	var times []time.Time
+	s.NoError(wfEnv.GetWorkflowResult(&times))
+	s.Len(times, 2)
+	for _, now := range times {
+		s.True(startTime.Add(time.Hour).Equal(now))
+	}
+}
+
+func (s *WorkflowTestSuiteUnitTest) Test_WorkflowInputValidator() {
+	var executed bool
+	workflowFn := func(ctx Context, name string, count int) (string, error) {
+		executed = true
+		return strings.Repeat(name, count), nil
+	}
+	validator := func(name string, count int) error {
+		if count <= 0 {
+			return errors.New("count must be positive")
+		}
+		return nil
+	}
+
+	env := s.NewTestWorkflowEnvironment()
+	env.RegisterWorkflowWithOptions(workflowFn, RegisterWorkflowOptions{Name: "repeat", InputValidator: validator})
+	env.ExecuteWorkflow("repeat", "a", 0)
+	s.False(executed)
+	err := env.GetWorkflowError()
+	var applicationErr *ApplicationError
+	s.True(errors.As(err, &applicationErr))
+	s.True(applicationErr.NonRetryable())
+	s.Equal("WorkflowInputValidationError", applicationErr.Type())
+	s.EqualError(errors.Unwrap(applicationErr), "count must be positive")
+
+	env = s.NewTestWorkflowEnvironment()
+	env.RegisterWorkflowWithOptions(workflowFn, RegisterWorkflowOptions{Name: "repeat", InputValidator: validator})
+	env.ExecuteWorkflow("repeat", "a", 3)
+	s.True(executed)
+	s.NoError(env.GetWorkflowError())
+	var result string
+	s.NoError(env.GetWorkflowResult(&result))
+	s.Equal("aaa", result)
+
+	// Validator parameters must match the workflow
+	s.Panics(func() {
+		s.NewTestWorkflowEnvironment().RegisterWorkflowWithOptions(workflowFn, RegisterWorkflowOptions{
+			Name:           "mismatch",
+			InputValidator: func(name string) error { return nil },
+		})
+	})
+}
+
+// secretTestCodec marks payloads as encoded with it, so they cannot be decoded
+// without it, and counts the payloads it encodes and decodes.
+type secretTestCodec struct {
+	encoded, decoded int32
+}
+
+func (c *secretTestCodec) Encode(payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
+	result := make([]*commonpb.Payload, len(payloads))
+	for i, p := range payloads {
+		atomic.AddInt32(&c.encoded, 1)
+		result[i] = &commonpb.Payload{
+			Metadata: map[string][]byte{"encoding": []byte("binary/secret"), "secret-encoding": p.Metadata["encoding"]},
+			Data:     p.Data,
+		}
+	}
+	return result, nil
+}
+
+func (c *secretTestCodec) Decode(payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
+	result := make([]*commonpb.Payload, len(payloads))
+	for i, p := range payloads {
+		if string(p.Metadata["encoding"]) != "binary/secret" {
+			return nil, errors.New("payload is not encoded with the secret codec")
+		}
+		atomic.AddInt32(&c.decoded, 1)
+		result[i] = &commonpb.Payload{Metadata: map[string][]byte{"encoding": p.Metadata["secret-encoding"]}, Data: p.Data}
+	}
+	return result, nil
+}
+
+func (s *WorkflowTestSuiteUnitTest) Test_PerCallDataConverter() {
+	codec := &secretTestCodec{}
+	secretConverter := converter.NewCodecDataConverter(converter.GetDefaultDataConverter(), codec)
+	activityFn := func(ctx context.Context, name string) (string, error) {
+		return "activity " + name, nil
+	}
+	childWorkflowFn := func(ctx Context, name string) (string, error) {
+		return "child " + name, nil
+	}
+	workflowFn := func(ctx Context) ([]string, error) {
+		activityCtx := WithActivityOptions(ctx, ActivityOptions{
+			StartToCloseTimeout: time.Minute,
+			DataConverter:       secretConverter,
+		})
+		localActivityCtx := WithLocalActivityOptions(ctx, LocalActivityOptions{
+			StartToCloseTimeout: time.Minute,
+			DataConverter:       secretConverter,
+		})
+		childCtx := WithChildWorkflowOptions(ctx, ChildWorkflowOptions{DataConverter: secretConverter})
+		// Results are decoded with the converter of the call, not the one of
+		// the context passed to Get
+		results := make([]string, 3)
+		if err := ExecuteActivity(activityCtx, activityFn, "a").Get(ctx, &results[0]); err != nil {
+			return nil, err
+		}
+		if err := ExecuteLocalActivity(localActivityCtx, activityFn, "b").Get(ctx, &results[1]); err != nil {
+			return nil, err
+		}
+		if err := ExecuteChildWorkflow(childCtx, childWorkflowFn, "c").Get(ctx, &results[2]); err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+
+	env := s.NewTestWorkflowEnvironment()
+	env.RegisterActivity(activityFn)
+	env.RegisterWorkflow(childWorkflowFn)
+	env.RegisterWorkflow(workflowFn)
+	env.ExecuteWorkflow(workflowFn)
+	s.NoError(env.GetWorkflowError())
+	var results []string
+	s.NoError(env.GetWorkflowResult(&results))
+	s.Equal([]string{"activity a", "activity b", "child c"}, results)
+	// Activity and child workflow inputs and results, and the local activity
+	// result, go through the codec
+	s.Equal(int32(5), atomic.LoadInt32(&codec.encoded))
+	s.Equal(int32(5), atomic.LoadInt32(&codec.decoded))
+}
+
+func (s *WorkflowTestSuiteUnitTest) Test_NewUUIDv7() {
+	workflowFn := func(ctx Context) ([]string, error) {
+		ids := make([]string, 3)
+		for i := range ids {
+			var err error
+			if ids[i], err = NewUUIDv7(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return ids, nil
+	}
+
+	env := s.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(workflowFn)
+	env.ExecuteWorkflow(workflowFn)
+	s.NoError(env.GetWorkflowError())
+	var ids []string
+	s.NoError(env.GetWorkflowResult(&ids))
+	s.Len(ids, 3)
+	for i, id := range ids {
+		parsed, err := uuid.Parse(id)
+		s.NoError(err)
+		s.Equal(uuid.Version(7), parsed.Version())
+		if i > 0 {
+			s.Less(ids[i-1], id)
+		}
+	}
+}
+
+func (s *WorkflowTestSuiteUnitTest) Test_ActivityProgress() {
+	progressKey := NewSearchAttributeKeyKeyword("CustomKeywordField")
+	activityFn := func(ctx context.Context) error {
+		RecordActivityProgress(ctx, "halfway")
+		return nil
+	}
+	pending := -1
+	workflowFn := func(ctx Context) (string, error) {
+		ctx = WithActivityOptions(ctx, ActivityOptions{
+			StartToCloseTimeout: time.Minute,
+			Progress:            &ActivityProgressOptions{SearchAttribute: progressKey.GetName(), MemoKey: "progress"},
+		})
+		if err := ExecuteActivity(ctx, activityFn).Get(ctx, nil); err != nil {
+			return "", err
+		}
+		pending = len(getWorkflowEnvOptions(ctx).activityProgress.options)
+		progress, _ := GetTypedSearchAttributes(ctx).GetKeyword(progressKey)
+		return progress, nil
+	}
+
+	env := s.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(workflowFn)
+	env.RegisterActivity(activityFn)
+	var memo map[string]interface{}
+	env.OnUpsertMemo(mock.Anything).Run(func(args mock.Arguments) {
+		memo = args.Get(0).(map[string]interface{})
+	}).Return(nil).Once()
+	env.ExecuteWorkflow(workflowFn)
+	s.NoError(env.GetWorkflowError())
+	var result string
+	s.NoError(env.GetWorkflowResult(&result))
+	s.Equal("halfway", result)
+	s.Equal(map[string]interface{}{"progress": "halfway"}, memo)
+	s.Zero(pending)
+}
+
+func (s *WorkflowTestSuiteUnitTest) Test_LocalActivityWatchdog() {
+	release := make(chan struct{})
+	defer close(release)
+	var attempts atomic.Int32
+	runawayFn := func(ctx context.Context) error {
+		attempts.Add(1)
+		// Ignores the cancellation of its context
+		<-release
+		return nil
+	}
+	workflowFn := func(ctx Context) error {
+		ctx = WithLocalActivityOptions(ctx, LocalActivityOptions{
+			ScheduleToCloseTimeout: time.Minute,
+			StartToCloseTimeout:    10 * time.Millisecond,
+			RetryPolicy:            &RetryPolicy{MaximumAttempts: 2, InitialInterval: time.Millisecond},
+		})
+		return ExecuteLocalActivity(ctx, runawayFn).Get(ctx, nil)
+	}
+
+	var testSuite WorkflowTestSuite
+	metricsHandler := metrics.NewCapturingHandler()
+	testSuite.SetMetricsHandler(metricsHandler)
+	env := testSuite.NewTestWorkflowEnvironment()
+	env.SetWorkerOptions(WorkerOptions{LocalActivityWatchdogGracePeriod: 10 * time.Millisecond})
+	env.RegisterWorkflow(workflowFn)
+	env.ExecuteWorkflow(workflowFn)
+
+	// The local activity times out and is retried as usual while the attempts keep running
+	var timeoutErr *TimeoutError
+	s.ErrorAs(env.GetWorkflowError(), &timeoutErr)
+	s.Equal(enumspb.TIMEOUT_TYPE_START_TO_CLOSE, timeoutErr.TimeoutType())
+	var appErr *ApplicationError
+	s.ErrorAs(timeoutErr, &appErr)
+	s.Equal(LocalActivityStillRunningErrorType, appErr.Type())
+	s.False(appErr.NonRetryable())
+	s.Equal(int32(2), attempts.Load())
+	s.Eventually(func() bool {
+		for _, counter := range metricsHandler.Counters() {
+			if counter.Name == metrics.LocalActivityWatchdogTimeoutCounter {
+				return counter.Value() == 2
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (s *WorkflowTestSuiteUnitTest) Test_ActivityExecutorPools() {
+	var running, maxRunning atomic.Int32
+	cpuHeavyFn := func(ctx context.Context) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}
+	ioHeavyFn := func(ctx context.Context) error {
+		return nil
+	}
+	workflowFn := func(ctx Context) error {
+		ctx = WithActivityOptions(ctx, ActivityOptions{StartToCloseTimeout: time.Minute})
+		var futures []Future
+		for i := 0; i < 4; i++ {
+			futures = append(futures, ExecuteActivity(ctx, "cpuHeavy"), ExecuteActivity(ctx, "ioHeavy"))
+		}
+		for _, f := range futures {
+			if err := f.Get(ctx, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	env := s.NewTestWorkflowEnvironment()
+	env.SetWorkerOptions(WorkerOptions{ActivityExecutorPools: map[string]int{"cpu-heavy": 2, "io-heavy": 10}})
+	env.RegisterWorkflow(workflowFn)
+	env.RegisterActivityWithOptions(cpuHeavyFn, RegisterActivityOptions{Name: "cpuHeavy", ExecutorPool: "cpu-heavy"})
+	env.RegisterActivityWithOptions(ioHeavyFn, RegisterActivityOptions{Name: "ioHeavy", ExecutorPool: "io-heavy"})
+	env.ExecuteWorkflow(workflowFn)
+	s.NoError(env.GetWorkflowError())
+	s.Equal(int32(2), maxRunning.Load())
+}
+
+func (s *WorkflowTestSuiteUnitTest) Test_ActivityPayloadSize() {
+	activityFn := func(ctx context.Context, input []byte) error {
+		return nil
+	}
+	workflowFn := func(ctx Context, size int) error {
+		ctx = WithActivityOptions(ctx, ActivityOptions{StartToCloseTimeout: time.Minute})
+		return ExecuteActivity(ctx, activityFn, make([]byte, size)).Get(ctx, nil)
+	}
+	oldLogger := s.GetLogger()
+	defer s.SetLogger(oldLogger)
+	logger := ilog.NewMemoryLogger()
+	s.SetLogger(logger)
+
+	for _, size := range []int{10, 200, 2000} {
+		env := s.NewTestWorkflowEnvironment()
+		env.SetWorkerOptions(WorkerOptions{PayloadSizeWarningThreshold: 100, PayloadSizeErrorThreshold: 1000})
+		env.RegisterWorkflow(workflowFn)
+		env.RegisterActivityWithOptions(activityFn, RegisterActivityOptions{Name: "SomeActivity"})
+		env.ExecuteWorkflow(workflowFn, size)
+		var warnings []string
+		for _, line := range logger.Lines() {
+			if strings.Contains(line, "Payload size exceeds warning threshold") {
+				warnings = append(warnings, line)
+			}
+		}
+
+		switch size {
+		case 10:
+			s.NoError(env.GetWorkflowError())
+			s.Empty(warnings)
+		case 200:
+			s.NoError(env.GetWorkflowError())
+			s.Len(warnings, 1)
+			s.Contains(warnings[0], "internal_workflow_testsuite_test.go:")
+		case 2000:
+			var panicErr *PanicError
+			s.ErrorAs(env.GetWorkflowError(), &panicErr)
+			s.Contains(panicErr.Error(), "activity SomeActivity payload size")
+			s.Contains(panicErr.Error(), "exceeds limit 1000 at ")
+			s.Contains(panicErr.Error(), "internal_workflow_testsuite_test.go:")
+			s.Len(warnings, 1)
+		}
+	}
+}
+
 func (s *WorkflowTestSuiteUnitTest) Test_ActivityWithHeaderContext() {
 	// inline activity using value passing through user context.
 	activityWithUserContext := func(ctx context.Context) (string, error) {
@@ -472,13 +871,13 @@ func (s *WorkflowTestSuiteUnitTest) Test_ActivityHeartbeatMock() {
 		}
 		calls = append(calls, detail)
 	}
-	// Test that without activity erroring, only the first heartbeat recorded
+	// Test that without activity erroring, first and last heartbeat recorded
 	env := s.NewTestActivityEnvironment()
 	env.RegisterActivity(activityFn)
 	env.SetOnActivityHeartbeatListener(heartbeatFn)
 	_, err := env.ExecuteActivity(activityFn)
 	s.NoError(err)
-	s.Equal(calls, []string{"some detail1"})
+	s.Equal(calls, []string{"some detail1", "some detail3"})
 	// Test that with activity erroring, first and last heartbeat recorded
 	calls, shouldErrorActivity = nil, true
 	env = s.NewTestActivityEnvironment()
@@ -516,14 +915,14 @@ func (s *WorkflowTestSuiteUnitTest) Test_WorkflowHeartbeatMock() {
 		f := ExecuteActivity(ctx, activityFn)
 		return f.Get(ctx, nil)
 	}
-	// Test that without activity erroring, only the first heartbeat recorded
+	// Test that without activity erroring, first and last heartbeat recorded
 	env := s.NewTestWorkflowEnvironment()
 	env.RegisterActivity(activityFn)
 	env.SetOnActivityHeartbeatListener(heartbeatFn)
 	env.ExecuteWorkflow(workflowFn)
 	s.True(env.IsWorkflowCompleted())
 	s.NoError(env.GetWorkflowError())
-	s.Equal(calls, []string{"some detail1"})
+	s.Equal(calls, []string{"some detail1", "some detail3"})
 	// Test that with activity erroring, first and last heartbeat recorded
 	calls, shouldErrorActivity = nil, true
 	env = s.NewTestWorkflowEnvironment()
@@ -2588,6 +2987,40 @@ func (s *WorkflowTestSuiteUnitTest) Test_SignalChildWorkflow() {
 	s.NoError(env.GetWorkflowError())
 }
 
+func (s *WorkflowTestSuiteUnitTest) Test_ChildWorkflowHandle() {
+	signalName := "test-signal-name"
+	childWorkflowFn := func(ctx Context) (string, error) {
+		var data string
+		GetSignalChannel(ctx, signalName).Receive(ctx, &data)
+		return data + "-processed", nil
+	}
+
+	workflowFn := func(ctx Context) (string, error) {
+		ctx = WithChildWorkflowOptions(ctx, ChildWorkflowOptions{WorkflowID: "child-id"})
+		handle := ExecuteChildWorkflowTyped[string](ctx, childWorkflowFn)
+
+		childExec, err := handle.WaitForStart(ctx)
+		if err != nil {
+			return "", err
+		}
+		s.Equal("child-id", childExec.ID)
+
+		if err := SignalChildWorkflowTyped[string](ctx, handle, signalName, "test-data").Get(ctx, nil); err != nil {
+			return "", err
+		}
+		return handle.GetResult(ctx)
+	}
+
+	env := s.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(childWorkflowFn)
+	env.ExecuteWorkflow(workflowFn)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+	var result string
+	s.NoError(env.GetWorkflowResult(&result))
+	s.Equal("test-data-processed", result)
+}
+
 func (s *WorkflowTestSuiteUnitTest) Test_SignalExternalWorkflow() {
 	signalName := "test-signal-name"
 	signalData := "test-signal-data"
@@ -2652,6 +3085,56 @@ func (s *WorkflowTestSuiteUnitTest) Test_SignalExternalWorkflow() {
 	s.NoError(env.GetWorkflowError())
 }
 
+func (s *WorkflowTestSuiteUnitTest) Test_ExternalWorkflowHandle() {
+	signalName := "test-signal-name"
+	workflowFn := func(ctx Context) error {
+		ctx = WithWorkflowNamespace(ctx, "test-namespace")
+		handle := GetExternalWorkflowHandle("test-workflow-id", "test-runid")
+		s.Equal("test-workflow-id", handle.GetID())
+		s.Equal("test-runid", handle.GetRunID())
+		if err := SignalExternalWorkflowTyped[string](ctx, handle, signalName, "test-data").Get(ctx, nil); err != nil {
+			return err
+		}
+
+		// By default, the target is not described
+		err := handle.Cancel(ctx).Get(ctx, nil)
+		s.IsType(&UnknownExternalWorkflowExecutionError{}, err)
+
+		// With DescribeUnknownTarget, the target is described to tell why it is unknown
+		handle = GetExternalWorkflowHandleWithOptions("test-workflow-id", "test-runid",
+			ExternalWorkflowHandleOptions{DescribeUnknownTarget: true})
+		err = SignalExternalWorkflowTyped[string](ctx, handle, signalName, "test-data").Get(ctx, nil)
+		var notFoundErr *ExternalWorkflowNotFoundError
+		s.ErrorAs(err, &notFoundErr)
+		err = handle.Cancel(ctx).Get(ctx, nil)
+		var completedErr *ExternalWorkflowCompletedError
+		s.ErrorAs(err, &completedErr)
+		s.Equal(enumspb.WORKFLOW_EXECUTION_STATUS_FAILED, completedErr.Status())
+		var unknownErr *UnknownExternalWorkflowExecutionError
+		s.ErrorAs(err, &unknownErr)
+
+		// The test environment has no client to describe it
+		err = handle.Cancel(ctx).Get(ctx, nil)
+		s.IsType(&UnknownExternalWorkflowExecutionError{}, err)
+		return nil
+	}
+
+	env := s.NewTestWorkflowEnvironment()
+	env.OnSignalExternalWorkflow("test-namespace", "test-workflow-id", "test-runid", signalName, "test-data").Return(nil).Once()
+	env.OnSignalExternalWorkflow("test-namespace", "test-workflow-id", "test-runid", signalName, "test-data").Return(
+		newUnknownExternalWorkflowExecutionError()).Once()
+	env.OnRequestCancelExternalWorkflow("test-namespace", "test-workflow-id", "test-runid").Return(
+		newUnknownExternalWorkflowExecutionError()).Times(3)
+	env.OnActivity(describeExternalWorkflowStatus, mock.Anything, "test-namespace", "test-workflow-id", "test-runid").
+		Return(enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED, nil).Once()
+	env.OnActivity(describeExternalWorkflowStatus, mock.Anything, "test-namespace", "test-workflow-id", "test-runid").
+		Return(enumspb.WORKFLOW_EXECUTION_STATUS_FAILED, nil).Once()
+	env.ExecuteWorkflow(workflowFn)
+	env.AssertExpectations(s.T())
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+}
+
 func (s *WorkflowTestSuiteUnitTest) Test_CancelChildWorkflow() {
 	childWorkflowFn := func(ctx Context) error {
 		var err error
@@ -3020,6 +3503,42 @@ func (s *WorkflowTestSuiteUnitTest) Test_ActivityRetry() {
 	s.Equal(4, attempt2Count)
 }
 
+func (s *WorkflowTestSuiteUnitTest) Test_ActivityRetry_NextRetryDelay() {
+	var infos []ActivityInfo
+	activityFn := func(ctx context.Context) (string, error) {
+		info := GetActivityInfo(ctx)
+		infos = append(infos, info)
+		if info.Attempt < 2 {
+			return "", NewApplicationErrorWithOptions("rate limited", "RateLimited",
+				ApplicationErrorOptions{NextRetryDelay: time.Minute})
+		}
+		return "retry-done", nil
+	}
+
+	workflowFn := func(ctx Context) (string, error) {
+		ctx = WithActivityOptions(ctx, ActivityOptions{
+			StartToCloseTimeout: time.Hour,
+			RetryPolicy: &RetryPolicy{
+				InitialInterval: time.Second,
+			},
+		})
+		var result string
+		err := ExecuteActivity(ctx, activityFn).Get(ctx, &result)
+		return result, err
+	}
+
+	env := s.NewTestWorkflowEnvironment()
+	env.RegisterActivity(activityFn)
+	env.ExecuteWorkflow(workflowFn)
+
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+	s.Len(infos, 2)
+	s.Equal(infos[0].ScheduledTime, infos[0].CurrentAttemptScheduledTime)
+	s.Equal(infos[0].ScheduledTime, infos[1].ScheduledTime)
+	s.WithinDuration(infos[0].ScheduledTime.Add(time.Minute), infos[1].CurrentAttemptScheduledTime, time.Second)
+}
+
 func (s *WorkflowTestSuiteUnitTest) Test_ActivityRetry_DefaultRetry() {
 	attemptCount1 := 0
 	activityFn := func(ctx context.Context) (string, error) {
@@ -4250,3 +4769,64 @@ func (s *WorkflowTestSuiteUnitTest) Test_SignalLoss() {
 	s.True(errors.As(err, &workflowErr))
 	s.Equal("deadline exceeded (type: ScheduleToClose)", workflowErr.cause.Error())
 }
+
+func (s *WorkflowTestSuiteUnitTest) Test_CompleteNexusOperation() {
+	op := nexus.NewOperationReference[string, string]("op")
+	workflowFn := func(ctx Context) ([]string, error) {
+		client := NewNexusClient("endpoint", "service")
+		selector := NewSelector(ctx)
+		var completions []string
+		for _, input := range []string{"a", "b", "c"} {
+			selector.AddFuture(client.ExecuteOperation(ctx, op, input, NexusOperationOptions{}), func(f Future) {
+				var result string
+				var canceledErr *CanceledError
+				err := f.Get(ctx, &result)
+				switch {
+				case errors.As(err, &canceledErr):
+					result = input + " canceled"
+				case err != nil:
+					result = input + " failed: " + errors.Unwrap(err).Error()
+				}
+				completions = append(completions, result)
+			})
+		}
+		for range 3 {
+			selector.Select(ctx)
+		}
+		return completions, nil
+	}
+
+	for _, delays := range [][3]time.Duration{{1, 2, 3}, {3, 2, 1}, {2, 3, 1}} {
+		env := s.NewTestWorkflowEnvironment()
+		for _, input := range []string{"a", "b", "c"} {
+			env.OnNexusOperation("service", op, input, mock.Anything).Return(
+				&nexus.HandlerStartOperationResultAsync{OperationToken: "token-" + input},
+				nil,
+			)
+		}
+		env.RegisterDelayedCallback(func() {
+			s.ErrorContains(env.CompleteNexusOperation("service", "op", "token-x", "x done", nil), `with token "token-x"`)
+			s.ErrorContains(env.CompleteNexusOperation("service", "op", "token-a", 1, nil), "expected result type string, got int")
+		}, 500*time.Millisecond)
+		env.RegisterDelayedCallback(func() {
+			s.NoError(env.CompleteNexusOperation("service", "op", "token-a", "a done", nil))
+		}, delays[0]*time.Second)
+		env.RegisterDelayedCallback(func() {
+			s.NoError(env.CompleteNexusOperation("service", "op", "token-b", nil, errors.New("boom")))
+		}, delays[1]*time.Second)
+		env.RegisterDelayedCallback(func() {
+			s.NoError(env.CompleteNexusOperation("service", "op", "token-c", nil, NewCanceledError()))
+		}, delays[2]*time.Second)
+		env.RegisterDelayedCallback(func() {
+			s.Error(env.CompleteNexusOperation("service", "op", "token-a", "a done", nil))
+		}, 4*time.Second)
+
+		env.ExecuteWorkflow(workflowFn)
+		s.True(env.IsWorkflowCompleted())
+		s.NoError(env.GetWorkflowError())
+		var completions []string
+		s.NoError(env.GetWorkflowResult(&completions))
+		expected := map[time.Duration]string{delays[0]: "a done", delays[1]: "b failed: boom", delays[2]: "c canceled"}
+		s.Equal([]string{expected[1], expected[2], expected[3]}, completions)
+	}
+}
@@ -0,0 +1,82 @@
+package log
+
+import (
+	"go.temporal.io/sdk/log"
+)
+
+var _ log.Logger = (*FieldFilterLogger)(nil)
+var _ log.WithLogger = (*FieldFilterLogger)(nil)
+var _ log.WithSkipCallers = (*FieldFilterLogger)(nil)
+
+// FieldFilterLogger is Logger implementation that drops the fields with the given keys from every entry, both the
+// fields attached with With and the ones passed when the entry is written.
+type FieldFilterLogger struct {
+	logger log.Logger
+	omit   map[string]struct{}
+}
+
+// NewFieldFilterLogger creates new instance of FieldFilterLogger. The logger itself is returned if no keys are
+// omitted.
+func NewFieldFilterLogger(logger log.Logger, omit ...string) log.Logger {
+	if len(omit) == 0 {
+		return logger
+	}
+	l := &FieldFilterLogger{logger: log.Skip(logger, 1), omit: make(map[string]struct{}, len(omit))}
+	for _, key := range omit {
+		l.omit[key] = struct{}{}
+	}
+	return l
+}
+
+// Debug writes message to the log.
+func (l *FieldFilterLogger) Debug(msg string, keyvals ...interface{}) {
+	l.logger.Debug(msg, l.filter(keyvals)...)
+}
+
+// Info writes message to the log.
+func (l *FieldFilterLogger) Info(msg string, keyvals ...interface{}) {
+	l.logger.Info(msg, l.filter(keyvals)...)
+}
+
+// Warn writes message to the log.
+func (l *FieldFilterLogger) Warn(msg string, keyvals ...interface{}) {
+	l.logger.Warn(msg, l.filter(keyvals)...)
+}
+
+// Error writes message to the log.
+func (l *FieldFilterLogger) Error(msg string, keyvals ...interface{}) {
+	l.logger.Error(msg, l.filter(keyvals)...)
+}
+
+// With returns new logger that prepend every log entry with keyvals, without the omitted fields.
+func (l *FieldFilterLogger) With(keyvals ...interface{}) log.Logger {
+	return &FieldFilterLogger{logger: log.With(l.logger, l.filter(keyvals)...), omit: l.omit}
+}
+
+func (l *FieldFilterLogger) WithCallerSkip(depth int) log.Logger {
+	if sl, ok := l.logger.(log.WithSkipCallers); ok {
+		return &FieldFilterLogger{logger: sl.WithCallerSkip(depth), omit: l.omit}
+	}
+	return l
+}
+
+// filter returns keyvals without the omitted keys and their values.
+func (l *FieldFilterLogger) filter(keyvals []interface{}) []interface{} {
+	var filtered []interface{}
+	for i := 0; i < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if _, omitted := l.omit[key]; !ok || !omitted {
+			if filtered != nil {
+				filtered = append(filtered, keyvals[i:min(i+2, len(keyvals))]...)
+			}
+			continue
+		}
+		if filtered == nil {
+			filtered = append(make([]interface{}, 0, len(keyvals)), keyvals[:i]...)
+		}
+	}
+	if filtered == nil {
+		return keyvals
+	}
+	return filtered
+}
@@ -1,9 +1,14 @@
 package log
 
 import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.temporal.io/sdk/log"
 )
@@ -97,3 +102,103 @@ func TestReplayLogger_Skip(t *testing.T) {
 	assert.Equal(t, "INFO  message p1 1 p2 v2 p3 3 p4 true p5 5 p6 6 p7 7\n", loggerWithoutWith.Lines()[0])
 
 }
+
+func TestSamplingLogger(t *testing.T) {
+	logger := NewMemoryLogger()
+	sampler := NewLogSampler(3, 2)
+	samplingLogger := NewSamplingLogger(logger, sampler)
+	withSamplingLogger := log.With(samplingLogger, "p1", 1)
+
+	samplingLogger.Debug("debug1")
+	samplingLogger.Debug("debug2") // dropped by debug sampling
+	samplingLogger.Debug("debug3")
+	withSamplingLogger.Info("info1") // shares the limit
+	withSamplingLogger.Info("info2") // dropped by the limit
+	samplingLogger.Warn("warn1")     // dropped by the limit
+	samplingLogger.Error("error1")   // errors are never dropped
+
+	sampler.Reset()
+	samplingLogger.Info("info3")
+
+	assert.Equal(t, []string{
+		"DEBUG debug1\n",
+		"DEBUG debug3\n",
+		"INFO  info1 p1 1\n",
+		"WARN  Log limit reached, dropping further logs until the next period p1 1 Limit 3\n",
+		"ERROR error1\n",
+		"INFO  info3\n",
+	}, logger.Lines())
+}
+
+func TestSamplingLogger_Caller(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{AddSource: true})
+	// Skip the SamplingLogger frame, as done for the workflow logger
+	samplingLogger := log.Skip(NewSamplingLogger(log.NewStructuredLogger(slog.New(handler)), NewLogSampler(1, 0)), 1)
+
+	samplingLogger.Info("info1")
+	samplingLogger.Info("info2") // dropped by the limit
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		var entry struct {
+			Msg    string
+			Source struct{ Function string }
+		}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		assert.Equal(t, "go.temporal.io/sdk/internal/log.TestSamplingLogger_Caller", entry.Source.Function, entry.Msg)
+	}
+}
+
+func TestStackTraceLogger(t *testing.T) {
+	logger := NewMemoryLogger()
+	taskStartedEventID := 3
+	stackTraceLogger := log.With(NewStackTraceLogger(logger, func() []interface{} {
+		return []interface{}{"TaskStartedEventID", taskStartedEventID}
+	}), "p1", 1)
+
+	stackTraceLogger.Info("info")
+	taskStartedEventID = 7
+	stackTraceLogger.Error("error", "p2", 2)
+
+	lines := logger.Lines()
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "INFO  info p1 1\n", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "ERROR error p1 1 p2 2 TaskStartedEventID 7 StackTrace "), lines[1])
+	// SDK internal and runtime frames are removed, including this test's
+	assert.Contains(t, lines[1], "testing.tRunner")
+	assert.NotContains(t, lines[1], "go.temporal.io/sdk/internal")
+	assert.NotContains(t, lines[1], "runtime.")
+}
+
+func TestStackTraceLogger_KeyvalsNotModified(t *testing.T) {
+	logger := NewMemoryLogger()
+	stackTraceLogger := NewStackTraceLogger(logger, func() []interface{} {
+		return []interface{}{"TaskStartedEventID", 3}
+	})
+
+	keyvals := make([]interface{}, 2, 10)
+	keyvals[0], keyvals[1] = "p1", 1
+	stackTraceLogger.Error("error", keyvals...)
+
+	assert.Equal(t, []interface{}{"p1", 1, nil, nil}, keyvals[:4])
+	assert.True(t, strings.HasPrefix(logger.Lines()[0], "ERROR error p1 1 TaskStartedEventID 3 StackTrace "))
+}
+
+func TestFieldFilterLogger(t *testing.T) {
+	logger := NewMemoryLogger()
+	filterLogger := log.With(NewFieldFilterLogger(logger, "Attempt", "TaskQueue"), "p1", 1, "Attempt", 2)
+	filterLogger = log.With(filterLogger, "TaskQueue", "tq", "p2", 2)
+
+	filterLogger.Info("info", "Attempt", 3, "p3", 3)
+	filterLogger.Error("error")
+
+	assert.Equal(t, []string{
+		"INFO  info p1 1 p2 2 p3 3\n",
+		"ERROR error p1 1 p2 2\n",
+	}, logger.Lines())
+
+	// Nothing is filtered if no keys are omitted
+	assert.Same(t, logger, NewFieldFilterLogger(logger))
+}
@@ -0,0 +1,115 @@
+package log
+
+import (
+	"sync"
+
+	"go.temporal.io/sdk/log"
+)
+
+var _ log.Logger = (*SamplingLogger)(nil)
+var _ log.WithLogger = (*SamplingLogger)(nil)
+var _ log.WithSkipCallers = (*SamplingLogger)(nil)
+
+// LogSampler holds the sampling state shared by a SamplingLogger and the loggers derived from it.
+type LogSampler struct {
+	maxPerPeriod        int
+	debugSampleInterval int
+
+	lock       sync.Mutex
+	count      int
+	debugCount int
+	dropped    bool
+}
+
+// NewLogSampler creates a LogSampler. If maxPerPeriod is positive, at most that many Debug, Info, and Warn entries are
+// written between calls to Reset. If debugSampleInterval is greater than 1, only every debugSampleInterval-th Debug
+// entry is written. Error entries are always written.
+func NewLogSampler(maxPerPeriod, debugSampleInterval int) *LogSampler {
+	return &LogSampler{maxPerPeriod: maxPerPeriod, debugSampleInterval: debugSampleInterval}
+}
+
+// Reset starts a new period.
+func (s *LogSampler) Reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.count = 0
+	s.dropped = false
+}
+
+// allow returns whether an entry should be written and whether the limit was reached by this entry for the first
+// time in the period.
+func (s *LogSampler) allow(debug bool) (allowed bool, limitReached bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if debug && s.debugSampleInterval > 1 {
+		s.debugCount++
+		if s.debugCount%s.debugSampleInterval != 1 {
+			return false, false
+		}
+	}
+	if s.maxPerPeriod > 0 && s.count >= s.maxPerPeriod {
+		limitReached = !s.dropped
+		s.dropped = true
+		return false, limitReached
+	}
+	s.count++
+	return true, false
+}
+
+// SamplingLogger is Logger implementation that drops entries according to a LogSampler.
+type SamplingLogger struct {
+	logger  log.Logger
+	sampler *LogSampler
+}
+
+// NewSamplingLogger creates new instance of SamplingLogger.
+func NewSamplingLogger(logger log.Logger, sampler *LogSampler) log.Logger {
+	return &SamplingLogger{logger: logger, sampler: sampler}
+}
+
+func (l *SamplingLogger) check(debug bool) bool {
+	allowed, limitReached := l.sampler.allow(debug)
+	if limitReached {
+		// Skip check so the entry reports the same caller as the entry being dropped.
+		log.Skip(l.logger, 1).Warn("Log limit reached, dropping further logs until the next period", "Limit", l.sampler.maxPerPeriod)
+	}
+	return allowed
+}
+
+// Debug writes message to the log if allowed by the sampler.
+func (l *SamplingLogger) Debug(msg string, keyvals ...interface{}) {
+	if l.check(true) {
+		l.logger.Debug(msg, keyvals...)
+	}
+}
+
+// Info writes message to the log if allowed by the sampler.
+func (l *SamplingLogger) Info(msg string, keyvals ...interface{}) {
+	if l.check(false) {
+		l.logger.Info(msg, keyvals...)
+	}
+}
+
+// Warn writes message to the log if allowed by the sampler.
+func (l *SamplingLogger) Warn(msg string, keyvals ...interface{}) {
+	if l.check(false) {
+		l.logger.Warn(msg, keyvals...)
+	}
+}
+
+// Error writes message to the log.
+func (l *SamplingLogger) Error(msg string, keyvals ...interface{}) {
+	l.logger.Error(msg, keyvals...)
+}
+
+// With returns new logger that prepend every log entry with keyvals.
+func (l *SamplingLogger) With(keyvals ...interface{}) log.Logger {
+	return NewSamplingLogger(log.With(l.logger, keyvals...), l.sampler)
+}
+
+func (l *SamplingLogger) WithCallerSkip(depth int) log.Logger {
+	if sl, ok := l.logger.(log.WithSkipCallers); ok {
+		return NewSamplingLogger(sl.WithCallerSkip(depth), l.sampler)
+	}
+	return l
+}
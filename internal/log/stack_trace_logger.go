@@ -0,0 +1,92 @@
+package log
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"go.temporal.io/sdk/log"
+)
+
+var _ log.Logger = (*StackTraceLogger)(nil)
+var _ log.WithLogger = (*StackTraceLogger)(nil)
+var _ log.WithSkipCallers = (*StackTraceLogger)(nil)
+
+const (
+	stackTraceKey = "StackTrace"
+	// Maximum number of frames captured for a stack trace
+	maxStackTraceFrames = 64
+	// Frames from these packages are SDK plumbing and are removed from captured stack traces
+	sdkInternalPackagePrefix = "go.temporal.io/sdk/internal"
+	runtimePackagePrefix     = "runtime."
+)
+
+// StackTraceLogger is Logger implementation that attaches the caller's stack trace, with SDK internal frames
+// removed, to every Error entry.
+type StackTraceLogger struct {
+	logger log.Logger
+	// Optional fields also attached to every Error entry, evaluated when the entry is written
+	fields func() []interface{}
+}
+
+// NewStackTraceLogger creates new instance of StackTraceLogger. fields may be nil.
+func NewStackTraceLogger(logger log.Logger, fields func() []interface{}) log.Logger {
+	return &StackTraceLogger{logger: logger, fields: fields}
+}
+
+// Debug writes message to the log.
+func (l *StackTraceLogger) Debug(msg string, keyvals ...interface{}) {
+	l.logger.Debug(msg, keyvals...)
+}
+
+// Info writes message to the log.
+func (l *StackTraceLogger) Info(msg string, keyvals ...interface{}) {
+	l.logger.Info(msg, keyvals...)
+}
+
+// Warn writes message to the log.
+func (l *StackTraceLogger) Warn(msg string, keyvals ...interface{}) {
+	l.logger.Warn(msg, keyvals...)
+}
+
+// Error writes message to the log with the caller's stack trace.
+func (l *StackTraceLogger) Error(msg string, keyvals ...interface{}) {
+	// Limit the capacity so the appends below copy keyvals instead of writing to the caller's array.
+	keyvals = keyvals[:len(keyvals):len(keyvals)]
+	if l.fields != nil {
+		keyvals = append(keyvals, l.fields()...)
+	}
+	keyvals = append(keyvals, stackTraceKey, captureStackTrace(3))
+	l.logger.Error(msg, keyvals...)
+}
+
+// With returns new logger that prepend every log entry with keyvals.
+func (l *StackTraceLogger) With(keyvals ...interface{}) log.Logger {
+	return NewStackTraceLogger(log.With(l.logger, keyvals...), l.fields)
+}
+
+func (l *StackTraceLogger) WithCallerSkip(depth int) log.Logger {
+	if sl, ok := l.logger.(log.WithSkipCallers); ok {
+		return NewStackTraceLogger(sl.WithCallerSkip(depth), l.fields)
+	}
+	return l
+}
+
+// captureStackTrace returns the current stack trace in the format of a panic stack trace, skipping the given number
+// of frames and removing runtime and SDK internal frames.
+func captureStackTrace(skip int) string {
+	pcs := make([]uintptr, maxStackTraceFrames)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(skip, pcs)])
+	var b strings.Builder
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, sdkInternalPackagePrefix) &&
+			!strings.HasPrefix(frame.Function, runtimePackagePrefix) {
+			_, _ = fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,165 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/backoffutils"
+	"go.temporal.io/api/serviceerror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"go.temporal.io/sdk/internal/common/retry"
+)
+
+type (
+	// NamespaceFailoverOptions configures how a client retries calls failing because its namespace is failing over
+	// to another cluster, see ClientOptions.NamespaceFailover.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.NamespaceFailoverOptions]
+	NamespaceFailoverOptions struct {
+		// InitialInterval is the delay before the first retry.
+		//
+		// Optional: defaults to 1 second.
+		InitialInterval time.Duration
+
+		// MaximumInterval caps the delay between retries, which doubles with every retry.
+		//
+		// Optional: defaults to 10 seconds.
+		MaximumInterval time.Duration
+
+		// MaximumDuration is how long a call is retried while its namespace fails over. Calls are never retried past
+		// the deadline of their context.
+		//
+		// Optional: defaults to 1 minute.
+		MaximumDuration time.Duration
+
+		// OnFailover is called before a call is retried because its namespace is failing over. It must not block.
+		//
+		// Optional: defaults to no callback.
+		OnFailover func(NamespaceFailoverInfo)
+	}
+
+	// NamespaceFailoverInfo describes a call retried because its namespace is failing over, see
+	// NamespaceFailoverOptions.OnFailover.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.NamespaceFailoverInfo]
+	NamespaceFailoverInfo struct {
+		// Namespace that is failing over.
+		Namespace string
+		// CurrentCluster is the cluster that rejected the call. Empty if the server did not report it.
+		CurrentCluster string
+		// ActiveCluster is the cluster the namespace is active in according to the cluster that rejected the call.
+		// Empty if the server did not report it.
+		ActiveCluster string
+		// Method is the full gRPC method name of the call.
+		Method string
+		// Attempt is the attempt that failed, starting from 1.
+		Attempt int
+		// Error is the error the attempt failed with.
+		Error error
+		// Backoff is the delay before the call is retried.
+		Backoff time.Duration
+	}
+)
+
+const (
+	defaultNamespaceFailoverInitialInterval = time.Second
+	defaultNamespaceFailoverMaximumInterval = 10 * time.Second
+	defaultNamespaceFailoverMaximumDuration = time.Minute
+)
+
+// namespaceFailoverInterceptor retries calls failing with namespace failover errors. Only calls the SDK retries,
+// which have a retry config in their context, are retried.
+func namespaceFailoverInterceptor(options NamespaceFailoverOptions, jitter RetryJitter) grpc.UnaryClientInterceptor {
+	if options.InitialInterval <= 0 {
+		options.InitialInterval = defaultNamespaceFailoverInitialInterval
+	}
+	if options.MaximumInterval <= 0 {
+		options.MaximumInterval = defaultNamespaceFailoverMaximumInterval
+	}
+	if options.MaximumDuration <= 0 {
+		options.MaximumDuration = defaultNamespaceFailoverMaximumDuration
+	}
+	backoff := func(attempt int) time.Duration {
+		next := float64(options.InitialInterval) * math.Pow(retry.DefaultBackoffCoefficient, float64(attempt-1))
+		return time.Duration(math.Min(next, float64(options.MaximumInterval)))
+	}
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Value(retry.ConfigKey).(*retry.GrpcRetryConfig); !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		expiration := time.Now().Add(options.MaximumDuration)
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			info, ok := namespaceFailoverInfoFromError(err)
+			if !ok {
+				return err
+			}
+			delay := backoff(attempt)
+			if jitter == nil {
+				delay = backoffutils.JitterUp(delay, retry.DefaultJitter)
+			} else {
+				previous := options.InitialInterval
+				if attempt > 1 {
+					previous = backoff(attempt - 1)
+				}
+				delay = jitter.Apply(RetryJitterInput{
+					Attempt:         attempt,
+					InitialInterval: options.InitialInterval,
+					MaximumInterval: options.MaximumInterval,
+					PreviousBackoff: previous,
+					Backoff:         delay,
+				})
+			}
+			if deadline, ok := ctx.Deadline(); time.Now().Add(delay).After(expiration) ||
+				(ok && time.Now().Add(delay).After(deadline)) {
+				return err
+			}
+			if nsReq, ok := req.(interface{ GetNamespace() string }); ok && info.Namespace == "" {
+				info.Namespace = nsReq.GetNamespace()
+			}
+			info.Method, info.Attempt, info.Error, info.Backoff = method, attempt, err, delay
+			if options.OnFailover != nil {
+				options.OnFailover(info)
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// namespaceFailoverInfoFromError returns the failover info of a namespace failover error, and false if the error is
+// not one.
+func namespaceFailoverInfoFromError(err error) (NamespaceFailoverInfo, bool) {
+	if err == nil {
+		return NamespaceFailoverInfo{}, false
+	}
+	// Errors are not converted to service errors yet at this point of the interceptor chain
+	err = serviceerror.FromStatus(status.Convert(err))
+	var notActive *serviceerror.NamespaceNotActive
+	if errors.As(err, &notActive) {
+		return NamespaceFailoverInfo{
+			Namespace:      notActive.Namespace,
+			CurrentCluster: notActive.CurrentCluster,
+			ActiveCluster:  notActive.ActiveCluster,
+		}, true
+	}
+	var unavailable *serviceerror.NamespaceUnavailable
+	if errors.As(err, &unavailable) {
+		return NamespaceFailoverInfo{Namespace: unavailable.Namespace}, true
+	}
+	return NamespaceFailoverInfo{}, false
+}
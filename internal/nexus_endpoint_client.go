@@ -0,0 +1,164 @@
+package internal
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// NexusEndpointSpec describes a Nexus endpoint. Exactly one of TargetTaskQueue and ExternalURL must be set.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.NexusEndpointSpec]
+	NexusEndpointSpec struct {
+		// Name - Endpoint name, unique for the cluster. Must match `[a-zA-Z_][a-zA-Z0-9_]*`.
+		// Renaming an endpoint breaks all workflow callers that reference it.
+		Name string
+
+		// Description - Markdown description of the endpoint. Encoded with the default data converter, not the one of
+		// the client, so that it can be displayed by the server UI and CLI.
+		//
+		// Optional: defaulted to no description.
+		Description string
+
+		// TargetNamespace - Namespace to route requests to when targeting a worker.
+		//
+		// Optional: defaulted to the namespace of the client.
+		TargetNamespace string
+
+		// TargetTaskQueue - Task queue of the workers handling requests to the endpoint.
+		TargetTaskQueue string
+
+		// ExternalURL - URL to route requests to instead of a worker task queue.
+		ExternalURL string
+	}
+
+	// NexusEndpoint is a Nexus endpoint registered on the server.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.NexusEndpoint]
+	NexusEndpoint struct {
+		// ID - Server-generated ID of the endpoint.
+		ID string
+
+		// Version - Data version of the endpoint, incremented on every update. Must be provided to update or delete
+		// the endpoint.
+		Version int64
+
+		// Spec - Spec of the endpoint.
+		Spec NexusEndpointSpec
+
+		// CreatedTime - Time the endpoint was created.
+		CreatedTime time.Time
+
+		// LastModifiedTime - Time the endpoint was last modified. Zero if it was never modified.
+		LastModifiedTime time.Time
+
+		// URLPrefix - Server exposed URL prefix for invocation of operations on this endpoint.
+		URLPrefix string
+	}
+
+	// NexusEndpointCreateOptions provides options for [NexusEndpointClient.Create].
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.NexusEndpointCreateOptions]
+	NexusEndpointCreateOptions struct {
+		// Spec - Spec of the endpoint to create.
+		Spec NexusEndpointSpec
+	}
+
+	// NexusEndpointUpdateOptions provides options for [NexusEndpointClient.Update].
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.NexusEndpointUpdateOptions]
+	NexusEndpointUpdateOptions struct {
+		// ID - ID of the endpoint to update.
+		ID string
+
+		// Version - Current version of the endpoint, used for optimistic concurrency control.
+		Version int64
+
+		// Spec - New spec of the endpoint, replacing the existing one.
+		Spec NexusEndpointSpec
+	}
+
+	// NexusEndpointDeleteOptions provides options for [NexusEndpointClient.Delete].
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.NexusEndpointDeleteOptions]
+	NexusEndpointDeleteOptions struct {
+		// ID - ID of the endpoint to delete.
+		ID string
+
+		// Version - Current version of the endpoint, used for optimistic concurrency control.
+		Version int64
+	}
+
+	// NexusEndpointListOptions provides options for [NexusEndpointClient.List].
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.NexusEndpointListOptions]
+	NexusEndpointListOptions struct {
+		// PageSize - How many results to fetch from the Server at a time.
+		//
+		// Optional: defaulted to the server default.
+		PageSize int
+
+		// Name - Only list the endpoint with this name.
+		//
+		// Optional: defaulted to listing all endpoints.
+		Name string
+	}
+
+	// NexusEndpointListIterator is an iterator for Nexus endpoints.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.NexusEndpointListIterator]
+	NexusEndpointListIterator interface {
+		// HasNext - Return whether this iterator has next value.
+		HasNext() bool
+
+		// Next - Returns the next endpoint and error
+		Next() (*NexusEndpoint, error)
+	}
+
+	// NexusEndpointClient is the client that manages Nexus endpoints. Endpoints are cluster-wide resources managed
+	// through the operator service.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.NexusEndpointClient]
+	NexusEndpointClient interface {
+		// Create a Nexus endpoint. The spec is validated before it is sent to the server.
+		//
+		// NOTE: Experimental
+		Create(ctx context.Context, options NexusEndpointCreateOptions) (*NexusEndpoint, error)
+
+		// Get a Nexus endpoint by ID.
+		//
+		// NOTE: Experimental
+		Get(ctx context.Context, id string) (*NexusEndpoint, error)
+
+		// Update a Nexus endpoint, replacing its spec. The spec is validated before it is sent to the server.
+		//
+		// NOTE: Experimental
+		Update(ctx context.Context, options NexusEndpointUpdateOptions) (*NexusEndpoint, error)
+
+		// Delete a Nexus endpoint.
+		//
+		// NOTE: Experimental
+		Delete(ctx context.Context, options NexusEndpointDeleteOptions) error
+
+		// List returns an iterator to enumerate Nexus endpoints.
+		//
+		// NOTE: Experimental
+		List(ctx context.Context, options NexusEndpointListOptions) (NexusEndpointListIterator, error)
+	}
+)
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"strconv"
 
+	"github.com/google/uuid"
 	"github.com/nexus-rpc/sdk-go/nexus"
 	commonpb "go.temporal.io/api/common/v1"
 	"go.temporal.io/api/enums/v1"
@@ -285,6 +287,18 @@ func nexusFailureMetadataToPayloads(failure nexus.Failure) (*commonpb.Payloads,
 	}, err
 }
 
+func protoLinksToNexusLinks(links []*nexuspb.Link) ([]nexus.Link, error) {
+	nexusLinks := make([]nexus.Link, 0, len(links))
+	for _, link := range links {
+		linkURL, err := url.Parse(link.GetUrl())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse link url: %w", err)
+		}
+		nexusLinks = append(nexusLinks, nexus.Link{URL: linkURL, Type: link.GetType()})
+	}
+	return nexusLinks, nil
+}
+
 func apiOperationErrorToNexusOperationError(opErr *nexuspb.UnsuccessfulOperationError) *nexus.OperationError {
 	return &nexus.OperationError{
 		State: nexus.OperationState(opErr.GetOperationState()),
@@ -435,7 +449,10 @@ func (t *testSuiteClientForNexusOperations) ExecuteWorkflow(ctx context.Context,
 		return nil, fmt.Errorf("cannot validate workflow function: %w", err)
 	}
 
-	run := &testEnvWorkflowRunForNexusOperations{}
+	run := &testEnvWorkflowRunForNexusOperations{requestID: options.RequestID}
+	if run.requestID == "" {
+		run.requestID = uuid.NewString()
+	}
 	startedErrCh := make(chan error, 1)
 	doneCh := make(chan error)
 
@@ -446,6 +463,11 @@ func (t *testSuiteClientForNexusOperations) ExecuteWorkflow(ctx context.Context,
 	}
 
 	t.env.postCallback(func() {
+		// With WorkflowIDConflictPolicy UseExisting the run of an already running workflow is returned
+		var existingRunID string
+		if handle, ok := t.env.runningWorkflows[options.ID]; ok && !handle.handled {
+			existingRunID = handle.env.workflowInfo.WorkflowExecution.RunID
+		}
 		t.env.executeChildWorkflowWithDelay(options.StartDelay, ExecuteWorkflowParams{
 			// Not propagating Header as this client does not support context propagation.
 			WorkflowType: wfType,
@@ -514,6 +536,7 @@ func (t *testSuiteClientForNexusOperations) ExecuteWorkflow(ctx context.Context,
 			}
 		}, func(r WorkflowExecution, err error) {
 			run.WorkflowExecution = r
+			run.started = err == nil && r.RunID != existingRunID
 			startedErrCh <- err
 			close(startedErrCh)
 			doneCh <- err
@@ -615,6 +638,11 @@ func (t *testSuiteClientForNexusOperations) ResetWorkflowExecution(ctx context.C
 	panic("not implemented in the test environment")
 }
 
+// ResetWorkflow implements Client.
+func (t *testSuiteClientForNexusOperations) ResetWorkflow(ctx context.Context, options ResetWorkflowOptions) (ResetWorkflowResult, error) {
+	panic("not implemented in the test environment")
+}
+
 // ScanWorkflow implements Client.
 //
 //lint:ignore SA1019 the server API was deprecated.
@@ -677,6 +705,11 @@ func (t *testSuiteClientForNexusOperations) WorkerDeploymentClient() WorkerDeplo
 	panic("not implemented in the test environment")
 }
 
+// NexusEndpointClient implements Client.
+func (t *testSuiteClientForNexusOperations) NexusEndpointClient() NexusEndpointClient {
+	panic("not implemented in the test environment")
+}
+
 // UpdateWorkflowExecutionOptions implements Client.
 func (t *testSuiteClientForNexusOperations) UpdateWorkflowExecutionOptions(ctx context.Context, options UpdateWorkflowExecutionOptionsRequest) (WorkflowExecutionOptions, error) {
 	panic("not implemented in the test environment")
@@ -688,6 +721,8 @@ var _ Client = &testSuiteClientForNexusOperations{}
 // to support basic Nexus functionality.
 type testEnvWorkflowRunForNexusOperations struct {
 	WorkflowExecution
+	requestID string
+	started   bool
 }
 
 // Get implements WorkflowRun.
@@ -710,5 +745,15 @@ func (t *testEnvWorkflowRunForNexusOperations) GetWithOptions(ctx context.Contex
 	panic("not implemented in the test environment")
 }
 
+// GetRequestID implements WorkflowRun.
+func (t *testEnvWorkflowRunForNexusOperations) GetRequestID() string {
+	return t.requestID
+}
+
+// GetStarted implements WorkflowRun.
+func (t *testEnvWorkflowRunForNexusOperations) GetStarted() bool {
+	return t.started
+}
+
 // Exposed as: [go.temporal.io/sdk/client.WorkflowRun]
 var _ WorkflowRun = &testEnvWorkflowRunForNexusOperations{}
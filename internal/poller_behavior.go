@@ -0,0 +1,186 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.temporal.io/api/serviceerror"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+)
+
+const (
+	defaultAutoscalingInitialNumberOfPollers = 5
+	defaultAutoscalingMinimumNumberOfPollers = 1
+	defaultAutoscalingMaximumNumberOfPollers = 100
+)
+
+type (
+	// PollerBehavior configures how a worker polls a task queue for one kind of task, see
+	// PollerBehaviorSimpleMaximum and PollerBehaviorAutoscaling.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.PollerBehavior]
+	PollerBehavior interface {
+		isPollerBehavior()
+	}
+
+	// PollerBehaviorSimpleMaximum polls with a fixed number of pollers, the behavior of the
+	// MaxConcurrent*TaskPollers worker options.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.PollerBehaviorSimpleMaximum]
+	PollerBehaviorSimpleMaximum struct {
+		// MaximumNumberOfPollers is the number of goroutines concurrently polling the task queue.
+		//
+		// default: 2
+		MaximumNumberOfPollers int
+	}
+
+	// PollerBehaviorAutoscaling scales the number of pollers between a minimum and a maximum. Pollers are added
+	// following the scaling decisions of the server, or when polls return tasks if the server does not send any, and
+	// removed when polls return no task or are throttled by the server.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.PollerBehaviorAutoscaling]
+	PollerBehaviorAutoscaling struct {
+		// InitialNumberOfPollers is the number of pollers started with the worker.
+		//
+		// default: 5
+		InitialNumberOfPollers int
+		// MinimumNumberOfPollers is the minimum number of pollers. Workflow task pollers alternate between sticky and
+		// non-sticky queues, so at least 2 of them are required.
+		//
+		// default: 1, 2 for workflow tasks
+		MinimumNumberOfPollers int
+		// MaximumNumberOfPollers is the maximum number of pollers.
+		//
+		// default: 100
+		MaximumNumberOfPollers int
+	}
+
+	// pollerScaler tracks the number of pollers of a base worker using PollerBehaviorAutoscaling.
+	pollerScaler struct {
+		lock    sync.Mutex
+		minimum int
+		maximum int
+		// target is the number of pollers the worker should have.
+		target int
+		// active is the number of running pollers.
+		active int
+	}
+
+	// pollerScalingTask is a polled task that may carry a scaling decision of the server.
+	pollerScalingTask interface {
+		getPollerScalingDecision() *taskqueuepb.PollerScalingDecision
+	}
+)
+
+func (PollerBehaviorSimpleMaximum) isPollerBehavior() {}
+
+func (PollerBehaviorAutoscaling) isPollerBehavior() {}
+
+// withDefaults returns the behavior with defaults applied, using the given lowest number of pollers as default minimum.
+func (b PollerBehaviorAutoscaling) withDefaults(lowest int) PollerBehaviorAutoscaling {
+	if b.MinimumNumberOfPollers <= 0 {
+		b.MinimumNumberOfPollers = max(defaultAutoscalingMinimumNumberOfPollers, lowest)
+	}
+	if b.MaximumNumberOfPollers <= 0 {
+		b.MaximumNumberOfPollers = max(defaultAutoscalingMaximumNumberOfPollers, b.MinimumNumberOfPollers)
+	}
+	if b.InitialNumberOfPollers <= 0 {
+		b.InitialNumberOfPollers = min(max(defaultAutoscalingInitialNumberOfPollers, b.MinimumNumberOfPollers),
+			b.MaximumNumberOfPollers)
+	}
+	return b
+}
+
+// validate returns an error if the behavior is inconsistent, after defaults are applied.
+func (b PollerBehaviorAutoscaling) validate(lowest int) error {
+	if b.MinimumNumberOfPollers < lowest {
+		return fmt.Errorf("minimum number of pollers must be at least %d", lowest)
+	}
+	if b.MaximumNumberOfPollers < b.MinimumNumberOfPollers {
+		return errors.New("maximum number of pollers must not be lower than the minimum")
+	}
+	if b.InitialNumberOfPollers < b.MinimumNumberOfPollers || b.InitialNumberOfPollers > b.MaximumNumberOfPollers {
+		return errors.New("initial number of pollers must be between the minimum and the maximum")
+	}
+	return nil
+}
+
+// resolvePollerBehavior returns the fixed number of pollers for the behavior, or the autoscaling behavior with
+// defaults applied. The deprecated number of pollers option, if set, is used when no behavior is. Lowest is the lowest
+// number of pollers allowed.
+func resolvePollerBehavior(
+	behavior PollerBehavior,
+	pollers int,
+	lowest int,
+) (int, *PollerBehaviorAutoscaling, error) {
+	if behavior != nil && pollers > 0 {
+		return 0, nil, errors.New("cannot set both a poller behavior and a maximum number of pollers")
+	}
+	switch b := behavior.(type) {
+	case nil:
+		return pollers, nil, nil
+	case PollerBehaviorSimpleMaximum:
+		return b.MaximumNumberOfPollers, nil, nil
+	case PollerBehaviorAutoscaling:
+		b = b.withDefaults(lowest)
+		if err := b.validate(lowest); err != nil {
+			return 0, nil, err
+		}
+		return 0, &b, nil
+	default:
+		return 0, nil, fmt.Errorf("unknown poller behavior %T", behavior)
+	}
+}
+
+func newPollerScaler(behavior PollerBehaviorAutoscaling) *pollerScaler {
+	return &pollerScaler{
+		minimum: behavior.MinimumNumberOfPollers,
+		maximum: behavior.MaximumNumberOfPollers,
+		target:  behavior.InitialNumberOfPollers,
+		active:  behavior.InitialNumberOfPollers,
+	}
+}
+
+// pollCompleted updates the target number of pollers from the outcome of a poll, and returns the number of pollers to
+// start to reach it. The started pollers are counted as active.
+func (s *pollerScaler) pollCompleted(task taskForWorker, err error) int {
+	delta := 0
+	if err != nil {
+		var resourceExhausted *serviceerror.ResourceExhausted
+		if errors.As(err, &resourceExhausted) {
+			delta = -1
+		}
+	} else if task == nil || task.isEmpty() {
+		delta = -1
+	} else if t, ok := task.(pollerScalingTask); ok && t.getPollerScalingDecision() != nil {
+		delta = int(t.getPollerScalingDecision().GetPollRequestDeltaSuggestion())
+	} else {
+		delta = 1
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.target = min(max(s.target+delta, s.minimum), s.maximum)
+	start := max(s.target-s.active, 0)
+	s.active += start
+	return start
+}
+
+// release returns whether a poller should stop because there are more pollers than the target, in which case it is no
+// longer counted as active.
+func (s *pollerScaler) release() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.active <= s.target {
+		return false
+	}
+	s.active--
+	return true
+}
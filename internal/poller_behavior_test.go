@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+func TestResolvePollerBehavior(t *testing.T) {
+	count, autoscaling, err := resolvePollerBehavior(nil, 3, 1)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+	require.Nil(t, autoscaling)
+
+	count, autoscaling, err = resolvePollerBehavior(PollerBehaviorSimpleMaximum{MaximumNumberOfPollers: 4}, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, 4, count)
+	require.Nil(t, autoscaling)
+
+	_, autoscaling, err = resolvePollerBehavior(PollerBehaviorAutoscaling{}, 0, 2)
+	require.NoError(t, err)
+	require.Equal(t, &PollerBehaviorAutoscaling{
+		InitialNumberOfPollers: 5,
+		MinimumNumberOfPollers: 2,
+		MaximumNumberOfPollers: 100,
+	}, autoscaling)
+
+	_, autoscaling, err = resolvePollerBehavior(PollerBehaviorAutoscaling{MaximumNumberOfPollers: 3}, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, 3, autoscaling.InitialNumberOfPollers)
+
+	_, _, err = resolvePollerBehavior(PollerBehaviorSimpleMaximum{MaximumNumberOfPollers: 4}, 2, 1)
+	require.Error(t, err)
+	_, _, err = resolvePollerBehavior(PollerBehaviorAutoscaling{MinimumNumberOfPollers: 1}, 0, 2)
+	require.Error(t, err)
+	_, _, err = resolvePollerBehavior(PollerBehaviorAutoscaling{MinimumNumberOfPollers: 5, MaximumNumberOfPollers: 3}, 0, 1)
+	require.Error(t, err)
+	_, _, err = resolvePollerBehavior(PollerBehaviorAutoscaling{InitialNumberOfPollers: 10, MaximumNumberOfPollers: 3}, 0, 1)
+	require.Error(t, err)
+}
+
+func TestPollerScaler(t *testing.T) {
+	scaler := newPollerScaler(PollerBehaviorAutoscaling{
+		InitialNumberOfPollers: 2,
+		MinimumNumberOfPollers: 1,
+		MaximumNumberOfPollers: 4,
+	})
+	withDecision := func(delta int32) taskForWorker {
+		return &activityTask{task: &workflowservice.PollActivityTaskQueueResponse{
+			TaskToken:             []byte("token"),
+			PollerScalingDecision: &taskqueuepb.PollerScalingDecision{PollRequestDeltaSuggestion: delta},
+		}}
+	}
+
+	// Tasks without a decision and decisions of the server add pollers, up to the maximum
+	require.Equal(t, 1, scaler.pollCompleted(&activityTask{task: &workflowservice.PollActivityTaskQueueResponse{}}, nil))
+	require.Equal(t, 1, scaler.pollCompleted(withDecision(5), nil))
+	require.False(t, scaler.release())
+
+	// Empty polls, throttling and decisions of the server remove pollers, down to the minimum
+	require.Equal(t, 0, scaler.pollCompleted(&activityTask{}, nil))
+	require.Equal(t, 0, scaler.pollCompleted(nil, serviceerror.NewResourceExhausted(0, "throttled")))
+	require.Equal(t, 0, scaler.pollCompleted(withDecision(-5), nil))
+	require.True(t, scaler.release())
+	require.True(t, scaler.release())
+	require.True(t, scaler.release())
+	require.False(t, scaler.release())
+
+	// Other errors do not scale
+	require.Equal(t, 0, scaler.pollCompleted(nil, errors.New("unavailable")))
+	require.False(t, scaler.release())
+	require.Equal(t, 1, scaler.pollCompleted(withDecision(1), nil))
+}
@@ -0,0 +1,195 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/workflowservice/v1"
+
+	ilog "go.temporal.io/sdk/internal/log"
+	"go.temporal.io/sdk/log"
+)
+
+const defaultReplayCompatibilityMaxWorkflows = 100
+
+type (
+	// ReplayCompatibilityOptions are options for WorkflowReplayer.CheckTaskQueueReplayCompatibility.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.ReplayCompatibilityOptions]
+	ReplayCompatibilityOptions struct {
+		// TaskQueue of the workflows to replay. Required.
+		TaskQueue string
+
+		// Optional: Query narrowing the workflows to replay, in the syntax of Client.ListWorkflow, for example
+		// to only replay workflows started recently.
+		Query string
+
+		// Optional: Maximum number of workflows to replay, the most recently started first.
+		//
+		// default: 100
+		MaxWorkflows int
+	}
+
+	// ReplayCompatibilityReport is the outcome of replaying a set of histories with the workflows registered on a
+	// WorkflowReplayer, for example to verify that a new build is compatible with the workflows of a task queue
+	// before deploying it.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.ReplayCompatibilityReport]
+	ReplayCompatibilityReport struct {
+		// Results of the replay of each history, in the order they were replayed.
+		Results []ReplayCompatibilityResult
+		// CompatibleBuildIDs are the build IDs of the workers that completed workflow tasks only in histories
+		// that replayed cleanly. Sorted.
+		CompatibleBuildIDs []string
+		// IncompatibleBuildIDs are the build IDs of the workers that completed workflow tasks of at least one
+		// history that failed to replay. Sorted.
+		IncompatibleBuildIDs []string
+	}
+
+	// ReplayCompatibilityResult is the outcome of replaying a single history.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.ReplayCompatibilityResult]
+	ReplayCompatibilityResult struct {
+		// Execution of the replayed workflow. Empty for histories given to WorkflowReplayer.CheckReplayCompatibility.
+		Execution WorkflowExecution
+		// BuildIDs of the workers that completed workflow tasks of the history, in the order they first did.
+		BuildIDs []string
+		// Err is the replay error, nil if the history replayed cleanly.
+		Err error
+	}
+)
+
+// Compatible returns whether all the histories replayed cleanly.
+func (r *ReplayCompatibilityReport) Compatible() bool {
+	for _, result := range r.Results {
+		if result.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckReplayCompatibility replays the histories, and reports which ones replay cleanly and which build IDs the
+// registered workflows are compatible with. The logger is an optional parameter. Defaults to the noop logger.
+//
+// NOTE: Experimental
+func (aw *WorkflowReplayer) CheckReplayCompatibility(logger log.Logger, histories ...*historypb.History) *ReplayCompatibilityReport {
+	results := make([]ReplayCompatibilityResult, 0, len(histories))
+	for _, history := range histories {
+		results = append(results, ReplayCompatibilityResult{
+			BuildIDs: historyBuildIDs(history),
+			Err:      aw.ReplayWorkflowHistory(logger, history),
+		})
+	}
+	return newReplayCompatibilityReport(results)
+}
+
+// CheckTaskQueueReplayCompatibility loads the histories of the most recent workflows of a task queue from the
+// Temporal service and replays them like CheckReplayCompatibility. It returns an error, and no report, if the
+// workflows or their histories cannot be loaded.
+//
+// NOTE: Experimental
+func (aw *WorkflowReplayer) CheckTaskQueueReplayCompatibility(
+	ctx context.Context,
+	service workflowservice.WorkflowServiceClient,
+	logger log.Logger,
+	namespace string,
+	options ReplayCompatibilityOptions,
+) (*ReplayCompatibilityReport, error) {
+	if options.TaskQueue == "" {
+		return nil, errors.New("task queue is required")
+	}
+	if options.MaxWorkflows <= 0 {
+		options.MaxWorkflows = defaultReplayCompatibilityMaxWorkflows
+	}
+	if logger == nil {
+		logger = ilog.NewDefaultLogger()
+	}
+	query := fmt.Sprintf("TaskQueue = %q", options.TaskQueue)
+	if options.Query != "" {
+		query = fmt.Sprintf("%s AND (%s)", query, options.Query)
+	}
+
+	var results []ReplayCompatibilityResult
+	request := &workflowservice.ListWorkflowExecutionsRequest{
+		Namespace: namespace,
+		PageSize:  int32(options.MaxWorkflows),
+		Query:     query,
+	}
+	for len(results) < options.MaxWorkflows {
+		response, err := service.ListWorkflowExecutions(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range response.GetExecutions() {
+			if len(results) == options.MaxWorkflows {
+				break
+			}
+			execution := WorkflowExecution{ID: info.GetExecution().GetWorkflowId(), RunID: info.GetExecution().GetRunId()}
+			history, err := getWorkflowExecutionHistory(ctx, service, namespace, execution)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load history of workflow %s: %w", execution.ID, err)
+			}
+			results = append(results, ReplayCompatibilityResult{
+				Execution: execution,
+				BuildIDs:  historyBuildIDs(history),
+				Err:       aw.replayWorkflowHistory(logger, service, namespace, execution, history),
+			})
+		}
+		if len(response.GetNextPageToken()) == 0 {
+			break
+		}
+		request.NextPageToken = response.GetNextPageToken()
+	}
+	return newReplayCompatibilityReport(results), nil
+}
+
+func newReplayCompatibilityReport(results []ReplayCompatibilityResult) *ReplayCompatibilityReport {
+	report := &ReplayCompatibilityReport{Results: results}
+	incompatible := map[string]bool{}
+	for _, result := range results {
+		for _, buildID := range result.BuildIDs {
+			incompatible[buildID] = incompatible[buildID] || result.Err != nil
+		}
+	}
+	for buildID, isIncompatible := range incompatible {
+		if isIncompatible {
+			report.IncompatibleBuildIDs = append(report.IncompatibleBuildIDs, buildID)
+		} else {
+			report.CompatibleBuildIDs = append(report.CompatibleBuildIDs, buildID)
+		}
+	}
+	slices.Sort(report.CompatibleBuildIDs)
+	slices.Sort(report.IncompatibleBuildIDs)
+	return report
+}
+
+// historyBuildIDs returns the build IDs of the workers that completed workflow tasks of the history, in the order they
+// first did.
+func historyBuildIDs(history *historypb.History) []string {
+	var buildIDs []string
+	for _, event := range history.GetEvents() {
+		if event.GetEventType() != enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED {
+			continue
+		}
+		attributes := event.GetWorkflowTaskCompletedEventAttributes()
+		buildID := attributes.GetWorkerVersion().GetBuildId()
+		if buildID == "" {
+			buildID = attributes.GetBinaryChecksum()
+		}
+		if buildID != "" && !slices.Contains(buildIDs, buildID) {
+			buildIDs = append(buildIDs, buildID)
+		}
+	}
+	return buildIDs
+}
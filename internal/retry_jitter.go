@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"go.temporal.io/sdk/internal/common/retry"
+)
+
+type (
+	// RetryJitterInput is the input of RetryJitter.Apply.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/temporal.RetryJitterInput]
+	RetryJitterInput = retry.JitterInput
+
+	// RetryJitter randomizes the delay before a retry performed by the SDK, so that callers failing at the same time
+	// do not retry at the same time. See ClientOptions.RetryJitter and LocalActivityOptions.RetryJitter.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/temporal.RetryJitter]
+	RetryJitter = retry.Jitter
+)
+
+var (
+	// FullRetryJitter picks a delay between zero and the backoff.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/temporal.FullRetryJitter]
+	FullRetryJitter = retry.FullJitter
+
+	// EqualRetryJitter picks a delay between half the backoff and the backoff.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/temporal.EqualRetryJitter]
+	EqualRetryJitter = retry.EqualJitter
+
+	// DecorrelatedRetryJitter picks a delay between the initial interval and three times the backoff of the previous
+	// attempt, capped by the maximum interval.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/temporal.DecorrelatedRetryJitter]
+	DecorrelatedRetryJitter = retry.DecorrelatedJitter
+)
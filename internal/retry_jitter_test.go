@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type constantRetryJitter time.Duration
+
+func (c constantRetryJitter) Apply(RetryJitterInput) time.Duration {
+	return time.Duration(c)
+}
+
+type recordingRetryJitter struct {
+	inputs []RetryJitterInput
+}
+
+func (r *recordingRetryJitter) Apply(input RetryJitterInput) time.Duration {
+	r.inputs = append(r.inputs, input)
+	return input.Backoff / 2
+}
+
+func TestGetRetryBackoffWithJitter(t *testing.T) {
+	policy := &RetryPolicy{InitialInterval: time.Second, BackoffCoefficient: 2, MaximumInterval: 3 * time.Second}
+	now := time.Now()
+	jitter := &recordingRetryJitter{}
+	var backoffs []time.Duration
+	for attempt := int32(1); attempt <= 3; attempt++ {
+		backoffs = append(backoffs, getRetryBackoffWithNowTime(policy, nil, jitter, attempt, errors.New("failed"), now, time.Time{}))
+	}
+	require.Equal(t, []time.Duration{500 * time.Millisecond, time.Second, 1500 * time.Millisecond}, backoffs)
+	require.Equal(t, []RetryJitterInput{
+		{Attempt: 1, InitialInterval: time.Second, MaximumInterval: 3 * time.Second, PreviousBackoff: time.Second, Backoff: time.Second},
+		{Attempt: 2, InitialInterval: time.Second, MaximumInterval: 3 * time.Second, PreviousBackoff: time.Second, Backoff: 2 * time.Second},
+		{Attempt: 3, InitialInterval: time.Second, MaximumInterval: 3 * time.Second, PreviousBackoff: 2 * time.Second, Backoff: 3 * time.Second},
+	}, jitter.inputs)
+
+	// Delays requested by the error are not randomized
+	err := NewApplicationErrorWithOptions("failed", "", ApplicationErrorOptions{NextRetryDelay: 5 * time.Second})
+	require.Equal(t, 5*time.Second, getRetryBackoffWithNowTime(policy, nil, jitter, 1, err, now, time.Time{}))
+	require.Len(t, jitter.inputs, 3)
+}
@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"errors"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// activityRetryOverridesHeader is the header carrying ActivityOptions.RetryOverrides to the activity worker.
+const activityRetryOverridesHeader = "__temporal_activity_retry_overrides"
+
+// RetryOverride replaces the backoff parameters of the retry policy of an activity when an attempt fails with an error
+// of a given type, for instance to wait longer after errors reporting a rate limit. The maximum number of attempts and
+// non-retryable error types of the retry policy still apply.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/temporal.RetryOverride]
+type RetryOverride struct {
+	// ErrorType is the type of the errors the override applies to, matched like RetryPolicy.NonRetryableErrorTypes.
+	ErrorType string
+
+	// Backoff interval for the first retry. If not set or set to 0, a default interval of 1s will be used.
+	InitialInterval time.Duration
+
+	// Coefficient used to calculate the next retry backoff interval. Default is 2.0.
+	BackoffCoefficient float64
+
+	// Maximum backoff interval between retries. Default is 100x of initial interval.
+	MaximumInterval time.Duration
+}
+
+// retryPolicy returns the retry policy with the backoff parameters of the override, with defaults applied.
+func (o *RetryOverride) retryPolicy() *RetryPolicy {
+	return applyRetryPolicyDefaultsForLocalActivity(&RetryPolicy{
+		InitialInterval:    o.InitialInterval,
+		BackoffCoefficient: o.BackoffCoefficient,
+		MaximumInterval:    o.MaximumInterval,
+	})
+}
+
+// findRetryOverride returns the override for the error type, nil if there is none.
+func findRetryOverride(overrides []RetryOverride, errType string) *RetryOverride {
+	for i := range overrides {
+		if overrides[i].ErrorType == errType {
+			return &overrides[i]
+		}
+	}
+	return nil
+}
+
+// getRetryErrType returns the type of the error matched against retry policy error types.
+func getRetryErrType(err error) string {
+	var applicationErr *ApplicationError
+	if errors.As(err, &applicationErr) {
+		return applicationErr.errType
+	}
+	return getErrType(err)
+}
+
+// withActivityRetryOverrides returns a copy of the header with the retry overrides set.
+func withActivityRetryOverrides(header *commonpb.Header, overrides []RetryOverride) (*commonpb.Header, error) {
+	return withHeaderValue(header, activityRetryOverridesHeader, overrides)
+}
+
+// getActivityRetryOverrides returns the retry overrides set in the header of an activity task, if any.
+func getActivityRetryOverrides(header *commonpb.Header) []RetryOverride {
+	var overrides []RetryOverride
+	if !getHeaderValue(header, activityRetryOverridesHeader, &overrides) {
+		return nil
+	}
+	return overrides
+}
+
+// applyActivityRetryOverride sets the next retry delay of a failed activity task from the retry override matching the
+// type of the failure, so that the server retries with the backoff of the override. Failures that are not retryable
+// or already have a next retry delay are left unchanged.
+func applyActivityRetryOverride(request *workflowservice.RespondActivityTaskFailedRequest, overrides []RetryOverride, attempt int32) {
+	info := request.GetFailure().GetApplicationFailureInfo()
+	if info == nil || info.GetNonRetryable() || info.GetNextRetryDelay() != nil {
+		return
+	}
+	override := findRetryOverride(overrides, info.GetType())
+	if override == nil {
+		return
+	}
+	info.NextRetryDelay = durationpb.New(getExponentialRetryBackoff(override.retryPolicy(), attempt))
+}
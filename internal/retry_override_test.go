@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	failurepb "go.temporal.io/api/failure/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type rateLimitedError struct{}
+
+func (rateLimitedError) Error() string { return "rate limited" }
+
+func TestGetRetryBackoffWithOverrides(t *testing.T) {
+	policy := &RetryPolicy{InitialInterval: time.Second, BackoffCoefficient: 2, MaximumInterval: time.Minute}
+	overrides := []RetryOverride{
+		{ErrorType: "RateLimited", InitialInterval: 10 * time.Second, BackoffCoefficient: 3},
+		{ErrorType: "rateLimitedError", InitialInterval: 5 * time.Second},
+	}
+	now := time.Now()
+	backoff := func(err error, attempt int32) time.Duration {
+		return getRetryBackoffWithNowTime(policy, overrides, nil, attempt, err, now, time.Time{})
+	}
+
+	require.Equal(t, 2*time.Second, backoff(errors.New("failed"), 2))
+	require.Equal(t, 30*time.Second, backoff(NewApplicationError("failed", "RateLimited", false, nil), 2))
+	require.Equal(t, 10*time.Second, backoff(rateLimitedError{}, 2))
+	// The maximum interval of the override applies, 100x the initial interval by default
+	require.Equal(t, 500*time.Second, backoff(rateLimitedError{}, 10))
+	// Delays requested by the error take precedence
+	err := NewApplicationErrorWithOptions("failed", "RateLimited", ApplicationErrorOptions{NextRetryDelay: time.Second})
+	require.Equal(t, time.Second, backoff(err, 2))
+}
+
+func TestApplyActivityRetryOverride(t *testing.T) {
+	overrides := []RetryOverride{{ErrorType: "RateLimited", InitialInterval: 10 * time.Second}}
+	newRequest := func(info *failurepb.ApplicationFailureInfo) *workflowservice.RespondActivityTaskFailedRequest {
+		return &workflowservice.RespondActivityTaskFailedRequest{
+			Failure: &failurepb.Failure{
+				FailureInfo: &failurepb.Failure_ApplicationFailureInfo{ApplicationFailureInfo: info},
+			},
+		}
+	}
+
+	request := newRequest(&failurepb.ApplicationFailureInfo{Type: "RateLimited"})
+	applyActivityRetryOverride(request, overrides, 2)
+	require.Equal(t, 20*time.Second, request.Failure.GetApplicationFailureInfo().GetNextRetryDelay().AsDuration())
+
+	for _, info := range []*failurepb.ApplicationFailureInfo{
+		{Type: "Other"},
+		{Type: "RateLimited", NonRetryable: true},
+		{Type: "RateLimited", NextRetryDelay: durationpb.New(time.Second)},
+	} {
+		request := newRequest(info)
+		expected := info.GetNextRetryDelay()
+		applyActivityRetryOverride(request, overrides, 2)
+		require.Equal(t, expected, request.Failure.GetApplicationFailureInfo().GetNextRetryDelay())
+	}
+}
+
+func TestActivityRetryOverridesHeader(t *testing.T) {
+	require.Nil(t, getActivityRetryOverrides(nil))
+	overrides := []RetryOverride{{ErrorType: "RateLimited", InitialInterval: time.Minute, BackoffCoefficient: 1.5}}
+	header, err := withActivityRetryOverrides(nil, overrides)
+	require.NoError(t, err)
+	require.Equal(t, overrides, getActivityRetryOverrides(header))
+}
+
+func TestActivityRetryOverrides(t *testing.T) {
+	var attempts int
+	activityFn := func(ctx context.Context) error {
+		attempts++
+		if attempts == 1 {
+			return NewApplicationError("rate limited", "RateLimited", false, nil)
+		}
+		return nil
+	}
+	wf := func(ctx Context) (time.Duration, error) {
+		ctx = WithActivityOptions(ctx, ActivityOptions{
+			StartToCloseTimeout: time.Minute,
+			RetryPolicy:         &RetryPolicy{InitialInterval: time.Second},
+			RetryOverrides:      []RetryOverride{{ErrorType: "RateLimited", InitialInterval: time.Hour}},
+		})
+		start := Now(ctx)
+		err := ExecuteActivity(ctx, activityFn).Get(ctx, nil)
+		return Now(ctx).Sub(start), err
+	}
+
+	var suite WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(wf)
+	env.RegisterActivity(activityFn)
+	env.ExecuteWorkflow(wf)
+	require.NoError(t, env.GetWorkflowError())
+	var elapsed time.Duration
+	require.NoError(t, env.GetWorkflowResult(&elapsed))
+	require.Equal(t, 2, attempts)
+	require.GreaterOrEqual(t, elapsed, time.Hour)
+}
@@ -2,10 +2,14 @@ package internal
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
+
+	"go.temporal.io/sdk/converter"
 )
 
 type (
@@ -242,7 +246,8 @@ type (
 		Workflow interface{}
 
 		// Args - Arguments to pass to the workflow.
-		// On ScheduleHandle.Describe() or ScheduleHandle.Update() Args will be returned as *commonpb.Payload.
+		// On ScheduleHandle.Describe() or ScheduleHandle.Update() Args will be returned as *commonpb.Payload, which can be
+		// decoded with ScheduleDescription.GetWorkflowArgs.
 		Args []interface{}
 
 		// TaskQueue - The workflow tasks of the workflow are scheduled on the queue with this name.
@@ -264,7 +269,8 @@ type (
 		RetryPolicy *RetryPolicy
 
 		// Memo - Optional non-indexed info that will be shown in list workflow.
-		// On ScheduleHandle.Describe() or ScheduleHandle.Update() Memo will be returned as *commonpb.Payload.
+		// On ScheduleHandle.Describe() or ScheduleHandle.Update() Memo will be returned as *commonpb.Payload, which can be
+		// decoded with ScheduleDescription.GetWorkflowMemo.
 		Memo map[string]interface{}
 
 		// TypedSearchAttributes - Optional indexed info that can be used in query of List/Scan/Count workflow APIs. The key
@@ -469,6 +475,8 @@ type (
 		//
 		// [Visibility]: https://docs.temporal.io/visibility
 		TypedSearchAttributes SearchAttributes
+
+		dc converter.DataConverter
 	}
 
 	// SchedulePolicies describes the current polcies of a schedule.
@@ -588,6 +596,88 @@ type (
 		Note string
 	}
 
+	// SchedulePauseManyOptions configure the parameters for pausing all schedules matching a query.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.SchedulePauseManyOptions]
+	SchedulePauseManyOptions struct {
+		// Query - Filter the schedules to pause using a SQL-like query, as in ScheduleListOptions.
+		// Required, use "TemporalSchedulePaused = false" to select all schedules that are not paused.
+		Query string
+
+		// Note - Informative human-readable message with contextual notes.
+		//
+		// Optional: defaulted to 'Paused via Go SDK'
+		Note string
+
+		// Concurrency - How many schedules are paused at a time.
+		//
+		// Optional: defaulted to 10
+		Concurrency int
+
+		// Progress - Called after each schedule is processed. Calls are not concurrent.
+		// Optional
+		Progress func(ScheduleBulkProgress)
+	}
+
+	// ScheduleUnpauseManyOptions configure the parameters for unpausing all schedules matching a query.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ScheduleUnpauseManyOptions]
+	ScheduleUnpauseManyOptions struct {
+		// Query - Filter the schedules to unpause using a SQL-like query, as in ScheduleListOptions.
+		// Required, use "TemporalSchedulePaused = true" to select all schedules that are paused.
+		Query string
+
+		// Note - Informative human-readable message with contextual notes.
+		//
+		// Optional: defaulted to 'Unpaused via Go SDK'
+		Note string
+
+		// Concurrency - How many schedules are unpaused at a time.
+		//
+		// Optional: defaulted to 10
+		Concurrency int
+
+		// Progress - Called after each schedule is processed. Calls are not concurrent.
+		// Optional
+		Progress func(ScheduleBulkProgress)
+	}
+
+	// ScheduleBulkProgress reports the progress of a ScheduleClient.PauseMany or ScheduleClient.UnpauseMany call.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ScheduleBulkProgress]
+	ScheduleBulkProgress struct {
+		// ScheduleID - The ID of the schedule that was just processed.
+		ScheduleID string
+
+		// Err - The error processing the schedule, if any.
+		Err error
+
+		// Succeeded - The number of schedules processed successfully so far.
+		Succeeded int
+
+		// Failed - The number of schedules that failed to be processed so far.
+		Failed int
+	}
+
+	// ScheduleBulkResult is the result of a ScheduleClient.PauseMany or ScheduleClient.UnpauseMany call.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ScheduleBulkResult]
+	ScheduleBulkResult struct {
+		// Succeeded - The IDs of the schedules processed successfully, sorted.
+		Succeeded []string
+
+		// Failed - The errors of the schedules that failed to be processed, by schedule ID.
+		Failed map[string]error
+	}
+
 	// ScheduleBackfillOptions configure the parameters for backfilling a schedule.
 	//
 	// Exposed as: [go.temporal.io/sdk/client.ScheduleBackfillOptions]
@@ -641,6 +731,10 @@ type (
 		// StartWorkflowResult - If action was ScheduleWorkflowAction, returns the
 		// ID of the workflow.
 		StartWorkflowResult *ScheduleWorkflowExecution
+
+		// StartWorkflowStatus - If action was ScheduleWorkflowAction, an eventually consistent
+		// view of the status of the workflow.
+		StartWorkflowStatus enumspb.WorkflowExecutionStatus
 	}
 
 	// ScheduleListEntry
@@ -723,8 +817,86 @@ type (
 		// This method does not validate scheduleID. If there is no Schedule with the given scheduleID, handle
 		// methods like ScheduleHandle.Describe() will return an error.
 		GetHandle(ctx context.Context, scheduleID string) ScheduleHandle
+
+		// PauseMany pauses all schedules matching the query, for example to halt schedules quickly during an incident.
+		// An error is only returned if listing the schedules fails or ctx is done, in which case the result has the
+		// schedules processed so far. Errors pausing individual schedules are in the result.
+		//
+		// Note: When using advanced visibility the query is eventually consistent, so recently created or updated
+		// schedules may be missed.
+		//
+		// NOTE: Experimental
+		PauseMany(ctx context.Context, options SchedulePauseManyOptions) (*ScheduleBulkResult, error)
+
+		// UnpauseMany unpauses all schedules matching the query. Errors are reported as for PauseMany.
+		//
+		// NOTE: Experimental
+		UnpauseMany(ctx context.Context, options ScheduleUnpauseManyOptions) (*ScheduleBulkResult, error)
 	}
 )
 
 func (*ScheduleWorkflowAction) isScheduleAction() {
 }
+
+// GetMemo decodes the schedule memo with the given key into valuePtr using the client's DataConverter. Returns an
+// error if there is no memo with the key.
+//
+// NOTE: Experimental
+func (d *ScheduleDescription) GetMemo(key string, valuePtr interface{}) error {
+	payload := d.Memo.GetFields()[key]
+	if payload == nil {
+		return fmt.Errorf("schedule memo %q not found", key)
+	}
+	return d.dataConverter().FromPayload(payload, valuePtr)
+}
+
+// GetWorkflowArgs decodes the arguments of the ScheduleWorkflowAction of the schedule into valuePtrs using the
+// client's DataConverter, in the same way as the result of a workflow is decoded.
+//
+// NOTE: Experimental
+func (d *ScheduleDescription) GetWorkflowArgs(valuePtrs ...interface{}) error {
+	action, err := d.workflowAction()
+	if err != nil {
+		return err
+	}
+	payloads := &commonpb.Payloads{}
+	for i, arg := range action.Args {
+		payload, ok := arg.(*commonpb.Payload)
+		if !ok {
+			return fmt.Errorf("workflow argument %d is not a payload", i)
+		}
+		payloads.Payloads = append(payloads.Payloads, payload)
+	}
+	return d.dataConverter().FromPayloads(payloads, valuePtrs...)
+}
+
+// GetWorkflowMemo decodes the memo with the given key of the ScheduleWorkflowAction of the schedule into valuePtr
+// using the client's DataConverter. Returns an error if there is no memo with the key.
+//
+// NOTE: Experimental
+func (d *ScheduleDescription) GetWorkflowMemo(key string, valuePtr interface{}) error {
+	action, err := d.workflowAction()
+	if err != nil {
+		return err
+	}
+	payload, ok := action.Memo[key].(*commonpb.Payload)
+	if !ok {
+		return fmt.Errorf("workflow memo %q not found", key)
+	}
+	return d.dataConverter().FromPayload(payload, valuePtr)
+}
+
+func (d *ScheduleDescription) workflowAction() (*ScheduleWorkflowAction, error) {
+	action, ok := d.Schedule.Action.(*ScheduleWorkflowAction)
+	if !ok {
+		return nil, errors.New("schedule action is not a workflow action")
+	}
+	return action, nil
+}
+
+func (d *ScheduleDescription) dataConverter() converter.DataConverter {
+	if d.dc == nil {
+		return converter.GetDefaultDataConverter()
+	}
+	return d.dc
+}
@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+type (
+	// TaskTokenCodecOptions are options for NewTaskTokenCodec.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.TaskTokenCodecOptions]
+	TaskTokenCodecOptions struct {
+		// HMACKey is the key wrapped tokens are signed with, at least 32 bytes. Required.
+		HMACKey []byte
+
+		// EncryptionKey is an AES-128, AES-192 or AES-256 key of 16, 24 or 32 bytes. If set, wrapped tokens are
+		// also encrypted with AES-GCM so third parties cannot read the task token.
+		//
+		// Optional: defaults to tokens only being signed.
+		EncryptionKey []byte
+	}
+
+	// TaskTokenCodec wraps activity task tokens before they are handed to third parties for asynchronous
+	// completion, and unwraps the tokens they hand back, verifying they were wrapped by a codec with the same
+	// keys and not modified. Wrapped tokens are binary, encode them, for example with base64, where text is needed.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.TaskTokenCodec]
+	TaskTokenCodec interface {
+		// Wrap returns the opaque token for the given task token.
+		Wrap(taskToken []byte) ([]byte, error)
+
+		// Unwrap returns the task token of an opaque token returned by Wrap, or an error if the token is invalid.
+		Unwrap(token []byte) ([]byte, error)
+	}
+
+	taskTokenCodec struct {
+		hmacKey []byte
+		// Nil if tokens are not encrypted
+		aead cipher.AEAD
+	}
+)
+
+const (
+	// First byte of wrapped task tokens, identifying their format
+	taskTokenVersionSigned    byte = 1
+	taskTokenVersionEncrypted byte = 2
+
+	minTaskTokenHMACKeySize = 32
+)
+
+var errInvalidTaskToken = errors.New("invalid task token")
+
+// NewTaskTokenCodec creates a TaskTokenCodec signing tokens with HMAC-SHA256 and, if an encryption key is given,
+// encrypting them with AES-GCM.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.NewTaskTokenCodec]
+func NewTaskTokenCodec(options TaskTokenCodecOptions) (TaskTokenCodec, error) {
+	if len(options.HMACKey) < minTaskTokenHMACKeySize {
+		return nil, fmt.Errorf("HMAC key must be at least %d bytes", minTaskTokenHMACKeySize)
+	}
+	codec := &taskTokenCodec{hmacKey: options.HMACKey}
+	if len(options.EncryptionKey) > 0 {
+		block, err := aes.NewCipher(options.EncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key: %w", err)
+		}
+		if codec.aead, err = cipher.NewGCM(block); err != nil {
+			return nil, err
+		}
+	}
+	return codec, nil
+}
+
+func (c *taskTokenCodec) Wrap(taskToken []byte) ([]byte, error) {
+	if len(taskToken) == 0 {
+		return nil, errors.New("task token is empty")
+	}
+	var token []byte
+	if c.aead == nil {
+		token = append([]byte{taskTokenVersionSigned}, taskToken...)
+	} else {
+		token = make([]byte, 1+c.aead.NonceSize(), 1+c.aead.NonceSize()+len(taskToken)+c.aead.Overhead()+sha256.Size)
+		token[0] = taskTokenVersionEncrypted
+		nonce := token[1:]
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, err
+		}
+		// The nonce is prepended to the ciphertext
+		token = c.aead.Seal(token, nonce, taskToken, nil)
+	}
+	return append(token, c.mac(token)...), nil
+}
+
+func (c *taskTokenCodec) Unwrap(token []byte) ([]byte, error) {
+	if len(token) <= 1+sha256.Size {
+		return nil, errInvalidTaskToken
+	}
+	body, mac := token[:len(token)-sha256.Size], token[len(token)-sha256.Size:]
+	if !hmac.Equal(mac, c.mac(body)) {
+		return nil, errInvalidTaskToken
+	}
+	switch {
+	case body[0] == taskTokenVersionSigned && c.aead == nil:
+		return append([]byte(nil), body[1:]...), nil
+	case body[0] == taskTokenVersionEncrypted && c.aead != nil:
+		if len(body) < 1+c.aead.NonceSize() {
+			return nil, errInvalidTaskToken
+		}
+		nonce := body[1 : 1+c.aead.NonceSize()]
+		taskToken, err := c.aead.Open(nil, nonce, body[1+c.aead.NonceSize():], nil)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidTaskToken, err)
+		}
+		return taskToken, nil
+	default:
+		return nil, errInvalidTaskToken
+	}
+}
+
+// mac returns the HMAC-SHA256 of b.
+func (c *taskTokenCodec) mac(b []byte) []byte {
+	h := hmac.New(sha256.New, c.hmacKey)
+	_, _ = h.Write(b)
+	return h.Sum(nil)
+}
@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskTokenCodec(t *testing.T) {
+	hmacKey := bytes.Repeat([]byte{1}, 32)
+	_, err := NewTaskTokenCodec(TaskTokenCodecOptions{HMACKey: hmacKey[:16]})
+	require.ErrorContains(t, err, "HMAC key must be at least 32 bytes")
+	_, err = NewTaskTokenCodec(TaskTokenCodecOptions{HMACKey: hmacKey, EncryptionKey: []byte("short")})
+	require.ErrorContains(t, err, "invalid encryption key")
+
+	signed, err := NewTaskTokenCodec(TaskTokenCodecOptions{HMACKey: hmacKey})
+	require.NoError(t, err)
+	encrypted, err := NewTaskTokenCodec(TaskTokenCodecOptions{HMACKey: hmacKey, EncryptionKey: bytes.Repeat([]byte{2}, 32)})
+	require.NoError(t, err)
+	otherKey, err := NewTaskTokenCodec(TaskTokenCodecOptions{HMACKey: bytes.Repeat([]byte{3}, 32)})
+	require.NoError(t, err)
+
+	taskToken := []byte("task-token")
+	for _, codec := range []TaskTokenCodec{signed, encrypted} {
+		token, err := codec.Wrap(taskToken)
+		require.NoError(t, err)
+		unwrapped, err := codec.Unwrap(token)
+		require.NoError(t, err)
+		require.Equal(t, taskToken, unwrapped)
+
+		// Modified tokens and tokens of other codecs are rejected
+		modified := append([]byte(nil), token...)
+		modified[1] ^= 1
+		_, err = codec.Unwrap(modified)
+		require.ErrorIs(t, err, errInvalidTaskToken)
+		_, err = otherKey.Unwrap(token)
+		require.ErrorIs(t, err, errInvalidTaskToken)
+		_, err = codec.Unwrap(token[:10])
+		require.ErrorIs(t, err, errInvalidTaskToken)
+	}
+
+	// The task token can only be read from tokens that are not encrypted
+	token, err := signed.Wrap(taskToken)
+	require.NoError(t, err)
+	require.True(t, bytes.Contains(token, taskToken))
+	token, err = encrypted.Wrap(taskToken)
+	require.NoError(t, err)
+	require.False(t, bytes.Contains(token, taskToken))
+	// Signed tokens are not accepted by a codec encrypting them, even with the same HMAC key
+	token, err = signed.Wrap(taskToken)
+	require.NoError(t, err)
+	_, err = encrypted.Unwrap(token)
+	require.ErrorIs(t, err, errInvalidTaskToken)
+
+	_, err = signed.Wrap(nil)
+	require.ErrorContains(t, err, "task token is empty")
+}
@@ -5,8 +5,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
-
-	"golang.org/x/sync/semaphore"
+	"time"
 
 	"go.temporal.io/sdk/internal/common/metrics"
 	"go.temporal.io/sdk/log"
@@ -257,10 +256,13 @@ func NewFixedSizeTuner(options FixedSizeTunerOptions) (WorkerTuner, error) {
 }
 
 // FixedSizeSlotSupplier is a slot supplier that will only ever issue at most a fixed number of
-// slots.
+// slots. The number of slots can be changed with SetMaxSlots while the supplier is in use.
 type FixedSizeSlotSupplier struct {
+	lock     sync.Mutex
 	numSlots int
-	sem      *semaphore.Weighted
+	issued   int
+	// released is closed when a slot may have become available, created when a reservation starts waiting.
+	released chan struct{}
 }
 
 // NewFixedSizeSlotSupplier creates a new FixedSizeSlotSupplier with the given number of slots.
@@ -270,35 +272,262 @@ func NewFixedSizeSlotSupplier(numSlots int) (*FixedSizeSlotSupplier, error) {
 	if numSlots <= 0 {
 		return nil, fmt.Errorf("NumSlots must be positive")
 	}
-	return &FixedSizeSlotSupplier{
-		numSlots: numSlots,
-		sem:      semaphore.NewWeighted(int64(numSlots)),
-	}, nil
+	return &FixedSizeSlotSupplier{numSlots: numSlots}, nil
 }
 
 func (f *FixedSizeSlotSupplier) ReserveSlot(ctx context.Context, _ SlotReservationInfo) (
 	*SlotPermit, error) {
-	err := f.sem.Acquire(ctx, 1)
-	if err != nil {
-		return nil, fmt.Errorf("failed to acquire slot: %w", err)
+	for {
+		f.lock.Lock()
+		if f.issued < f.numSlots {
+			f.issued++
+			f.lock.Unlock()
+			return &SlotPermit{}, nil
+		}
+		if f.released == nil {
+			f.released = make(chan struct{})
+		}
+		released := f.released
+		f.lock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to acquire slot: %w", ctx.Err())
+		case <-released:
+		}
 	}
-
-	return &SlotPermit{}, nil
 }
 func (f *FixedSizeSlotSupplier) TryReserveSlot(SlotReservationInfo) *SlotPermit {
-	if f.sem.TryAcquire(1) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.issued < f.numSlots {
+		f.issued++
 		return &SlotPermit{}
 	}
 	return nil
 }
 func (f *FixedSizeSlotSupplier) MarkSlotUsed(SlotMarkUsedInfo) {}
 func (f *FixedSizeSlotSupplier) ReleaseSlot(SlotReleaseInfo) {
-	f.sem.Release(1)
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.issued--
+	f.notifyReleasedLocked()
 }
 func (f *FixedSizeSlotSupplier) MaxSlots() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
 	return f.numSlots
 }
 
+// SetMaxSlots changes the number of slots. When lowered below the number of slots currently issued, no new slots are
+// issued until enough of them are released.
+//
+// NOTE: Experimental
+func (f *FixedSizeSlotSupplier) SetMaxSlots(numSlots int) error {
+	if numSlots <= 0 {
+		return fmt.Errorf("NumSlots must be positive")
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.numSlots = numSlots
+	f.notifyReleasedLocked()
+	return nil
+}
+
+func (f *FixedSizeSlotSupplier) notifyReleasedLocked() {
+	if f.released != nil {
+		close(f.released)
+		f.released = nil
+	}
+}
+
+// SharedPoolTunerOptions are the options used by NewSharedPoolTuner.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/worker.SharedPoolTunerOptions]
+type SharedPoolTunerOptions struct {
+	// NumSlots is the number of slots shared by all task types, at least one per task type. Required.
+	NumSlots int
+	// WorkflowWeight is the weight of workflow tasks.
+	WorkflowWeight int
+	// ActivityWeight is the weight of activity tasks, activities within sessions share their slots.
+	ActivityWeight int
+	// LocalActivityWeight is the weight of local activities.
+	LocalActivityWeight int
+	// NexusWeight is the weight of nexus tasks.
+	NexusWeight int
+}
+
+// Task types sharing the slots of a sharedSlotPool
+const (
+	sharedSlotKindWorkflow = iota
+	sharedSlotKindActivity
+	sharedSlotKindLocalActivity
+	sharedSlotKindNexus
+	numSharedSlotKinds
+)
+
+// NewSharedPoolTuner creates a WorkerTuner whose task types share one pool of slots. Every task type is guaranteed
+// its share of the slots, proportional to its weight, and may use more while the other task types do not need them.
+// When slots become free, task types waiting below their share get them first, so a burst of one task type cannot
+// starve the others for longer than the slots it borrowed are in use. A slot is kept free for every task type holding
+// none, including the ones with a zero weight, so that workflow tasks waiting for their local activities cannot take
+// all the slots. Weights must not be negative, all task types get the same weight when they are all zero.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/worker.NewSharedPoolTuner]
+func NewSharedPoolTuner(options SharedPoolTunerOptions) (WorkerTuner, error) {
+	if options.NumSlots < numSharedSlotKinds {
+		return nil, fmt.Errorf("NumSlots must be at least %d, one per task type", numSharedSlotKinds)
+	}
+	weights := [numSharedSlotKinds]int{
+		sharedSlotKindWorkflow:      options.WorkflowWeight,
+		sharedSlotKindActivity:      options.ActivityWeight,
+		sharedSlotKindLocalActivity: options.LocalActivityWeight,
+		sharedSlotKindNexus:         options.NexusWeight,
+	}
+	totalWeight := 0
+	for _, weight := range weights {
+		if weight < 0 {
+			return nil, fmt.Errorf("slot weights cannot be negative")
+		}
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		for kind := range weights {
+			weights[kind] = 1
+		}
+		totalWeight = numSharedSlotKinds
+	}
+	pool := &sharedSlotPool{numSlots: options.NumSlots}
+	for kind, weight := range weights {
+		pool.shares[kind] = options.NumSlots * weight / totalWeight
+		// Task types with a weight are always guaranteed a slot
+		if weight > 0 && pool.shares[kind] == 0 {
+			pool.shares[kind] = 1
+		}
+	}
+	activitySS := &sharedSlotSupplier{pool: pool, kind: sharedSlotKindActivity}
+	return &CompositeTuner{
+		workflowSlotSupplier:        &sharedSlotSupplier{pool: pool, kind: sharedSlotKindWorkflow},
+		activitySlotSupplier:        activitySS,
+		localActivitySlotSupplier:   &sharedSlotSupplier{pool: pool, kind: sharedSlotKindLocalActivity},
+		nexusSlotSupplier:           &sharedSlotSupplier{pool: pool, kind: sharedSlotKindNexus},
+		sessionActivitySlotSupplier: activitySS,
+	}, nil
+}
+
+// sharedSlotPool is the pool of slots shared by the slot suppliers of a tuner created by NewSharedPoolTuner.
+type sharedSlotPool struct {
+	lock     sync.Mutex
+	numSlots int
+	// Guaranteed number of slots per task type
+	shares  [numSharedSlotKinds]int
+	issued  [numSharedSlotKinds]int
+	waiting [numSharedSlotKinds]int
+	// released is closed when a slot may have become available, created when a reservation starts waiting.
+	released chan struct{}
+}
+
+// tryIssueLocked issues a slot to the task type if one is free and is not kept for other task types holding none or,
+// when borrowing, needed by other task types waiting below their share.
+func (p *sharedSlotPool) tryIssueLocked(kind int) bool {
+	free := p.numSlots
+	for _, issued := range p.issued {
+		free -= issued
+	}
+	borrowing := p.issued[kind] >= p.shares[kind]
+	needed := 0
+	for other := range p.issued {
+		if other == kind {
+			continue
+		}
+		otherNeeded := 0
+		if p.issued[other] == 0 {
+			otherNeeded = 1
+		}
+		if borrowing && p.waiting[other] > 0 && p.issued[other] < p.shares[other] {
+			otherNeeded = max(otherNeeded, min(p.waiting[other], p.shares[other]-p.issued[other]))
+		}
+		needed += otherNeeded
+	}
+	if free <= needed {
+		return false
+	}
+	p.issued[kind]++
+	return true
+}
+
+func (p *sharedSlotPool) notifyReleasedLocked() {
+	if p.released != nil {
+		close(p.released)
+		p.released = nil
+	}
+}
+
+// sharedSlotSupplier is the SlotSupplier of one task type of a sharedSlotPool.
+type sharedSlotSupplier struct {
+	pool *sharedSlotPool
+	kind int
+}
+
+func (s *sharedSlotSupplier) ReserveSlot(ctx context.Context, _ SlotReservationInfo) (*SlotPermit, error) {
+	p := s.pool
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.tryIssueLocked(s.kind) {
+		return &SlotPermit{}, nil
+	}
+	p.waiting[s.kind]++
+	defer func() {
+		p.waiting[s.kind]--
+		// Waiting reservations of other task types may have been held back for this one
+		p.notifyReleasedLocked()
+	}()
+	for {
+		if p.released == nil {
+			p.released = make(chan struct{})
+		}
+		released := p.released
+		p.lock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			p.lock.Lock()
+			return nil, fmt.Errorf("failed to acquire slot: %w", ctx.Err())
+		case <-released:
+		}
+		p.lock.Lock()
+		if p.tryIssueLocked(s.kind) {
+			return &SlotPermit{}, nil
+		}
+	}
+}
+
+func (s *sharedSlotSupplier) TryReserveSlot(SlotReservationInfo) *SlotPermit {
+	s.pool.lock.Lock()
+	defer s.pool.lock.Unlock()
+	if s.pool.tryIssueLocked(s.kind) {
+		return &SlotPermit{}
+	}
+	return nil
+}
+
+func (s *sharedSlotSupplier) MarkSlotUsed(SlotMarkUsedInfo) {}
+
+func (s *sharedSlotSupplier) ReleaseSlot(SlotReleaseInfo) {
+	s.pool.lock.Lock()
+	defer s.pool.lock.Unlock()
+	s.pool.issued[s.kind]--
+	s.pool.notifyReleasedLocked()
+}
+
+func (s *sharedSlotSupplier) MaxSlots() int {
+	return s.pool.numSlots
+}
+
 type slotReservationData struct {
 	taskQueue string
 }
@@ -390,6 +619,10 @@ type trackingSlotSupplier struct {
 	usedSlots               map[*SlotPermit]struct{}
 	taskSlotsAvailableGauge metrics.Gauge
 	taskSlotsUsedGauge      metrics.Gauge
+
+	exhaustionThreshold       time.Duration
+	taskSlotsExhaustedCounter metrics.Counter
+	lastExhaustionWarning     time.Time // Guarded by slotsMutex
 }
 
 type trackingSlotSupplierOptions struct {
@@ -397,8 +630,15 @@ type trackingSlotSupplierOptions struct {
 	metricsHandler metrics.Handler
 	workerBuildId  string
 	workerIdentity string
+
+	// exhaustionThreshold is how long ReserveSlot may wait while slots are in use before the slots are reported as
+	// exhausted, zero disables reporting.
+	exhaustionThreshold time.Duration
 }
 
+// slotExhaustionWarningInterval is the minimum time between two warnings about exhausted slots of a slot supplier.
+const slotExhaustionWarningInterval = time.Minute
+
 func newTrackingSlotSupplier(inner SlotSupplier, options trackingSlotSupplierOptions) *trackingSlotSupplier {
 	tss := &trackingSlotSupplier{
 		inner:                   inner,
@@ -409,6 +649,9 @@ func newTrackingSlotSupplier(inner SlotSupplier, options trackingSlotSupplierOpt
 		usedSlots:               make(map[*SlotPermit]struct{}),
 		taskSlotsAvailableGauge: options.metricsHandler.Gauge(metrics.WorkerTaskSlotsAvailable),
 		taskSlotsUsedGauge:      options.metricsHandler.Gauge(metrics.WorkerTaskSlotsUsed),
+
+		exhaustionThreshold:       options.exhaustionThreshold,
+		taskSlotsExhaustedCounter: options.metricsHandler.Counter(metrics.WorkerTaskSlotsExhausted),
 	}
 	return tss
 }
@@ -417,6 +660,10 @@ func (t *trackingSlotSupplier) ReserveSlot(
 	ctx context.Context,
 	data *slotReservationData,
 ) (*SlotPermit, error) {
+	if t.exhaustionThreshold > 0 {
+		timer := time.AfterFunc(t.exhaustionThreshold, t.reportExhausted)
+		defer timer.Stop()
+	}
 	permit, err := t.inner.ReserveSlot(ctx, slotReserveInfoImpl{
 		taskQueue:      data.taskQueue,
 		workerBuildId:  t.workerBuildId,
@@ -501,3 +748,27 @@ func (t *trackingSlotSupplier) publishMetrics(usedSlots int) {
 	}
 	t.taskSlotsUsedGauge.Update(float64(usedSlots))
 }
+
+// reportExhausted is called when a reservation has waited longer than the exhaustion threshold. Waiting is only
+// reported when tasks are using slots, as pollers outnumbering the slots also wait while the worker is idle.
+func (t *trackingSlotSupplier) reportExhausted() {
+	t.slotsMutex.Lock()
+	usedSlots := len(t.usedSlots)
+	if usedSlots == 0 {
+		t.slotsMutex.Unlock()
+		return
+	}
+	warn := time.Since(t.lastExhaustionWarning) >= slotExhaustionWarningInterval
+	if warn {
+		t.lastExhaustionWarning = time.Now()
+	}
+	t.slotsMutex.Unlock()
+
+	t.taskSlotsExhaustedCounter.Inc(1)
+	if warn {
+		t.logger.Warn("Task slots exhausted, new tasks are not polled until a slot is released",
+			"WaitedFor", t.exhaustionThreshold,
+			"UsedSlots", usedSlots,
+			"MaxSlots", t.inner.MaxSlots())
+	}
+}
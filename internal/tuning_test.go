@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/internal/common/metrics"
+	ilog "go.temporal.io/sdk/internal/log"
+)
+
+func TestFixedSizeSlotSupplierSetMaxSlots(t *testing.T) {
+	supplier, err := NewFixedSizeSlotSupplier(1)
+	require.NoError(t, err)
+	permit := supplier.TryReserveSlot(nil)
+	require.NotNil(t, permit)
+	require.Nil(t, supplier.TryReserveSlot(nil))
+
+	// Raising the size unblocks waiting reservations
+	reserved := make(chan error, 1)
+	go func() {
+		_, err := supplier.ReserveSlot(context.Background(), nil)
+		reserved <- err
+	}()
+	select {
+	case <-reserved:
+		t.Fatal("slot reserved while none available")
+	case <-time.After(50 * time.Millisecond):
+	}
+	require.NoError(t, supplier.SetMaxSlots(2))
+	require.NoError(t, <-reserved)
+	require.Equal(t, 2, supplier.MaxSlots())
+
+	// Lowering the size keeps issued slots, no new one is issued until enough are released
+	require.NoError(t, supplier.SetMaxSlots(1))
+	supplier.ReleaseSlot(nil)
+	require.Nil(t, supplier.TryReserveSlot(nil))
+	supplier.ReleaseSlot(nil)
+	require.NotNil(t, supplier.TryReserveSlot(nil))
+
+	require.Error(t, supplier.SetMaxSlots(0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = supplier.ReserveSlot(ctx, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTrackingSlotSupplierReportsExhaustion(t *testing.T) {
+	inner, err := NewFixedSizeSlotSupplier(1)
+	require.NoError(t, err)
+	metricsHandler := metrics.NewCapturingHandler()
+	logger := ilog.NewMemoryLogger()
+	supplier := newTrackingSlotSupplier(inner, trackingSlotSupplierOptions{
+		logger:              logger,
+		metricsHandler:      metricsHandler,
+		exhaustionThreshold: 10 * time.Millisecond,
+	})
+	exhaustedCount := func() int64 {
+		for _, counter := range metricsHandler.Counters() {
+			if counter.Name == metrics.WorkerTaskSlotsExhausted {
+				return counter.Value()
+			}
+		}
+		return 0
+	}
+	reserveWithTimeout := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := supplier.ReserveSlot(ctx, &slotReservationData{})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+
+	// Waiting for a slot held by a poller is not exhaustion
+	permit, err := supplier.ReserveSlot(context.Background(), &slotReservationData{})
+	require.NoError(t, err)
+	reserveWithTimeout()
+	require.Equal(t, int64(0), exhaustedCount())
+
+	// Waiting for a slot held by a task is, and the warning is throttled
+	supplier.MarkSlotUsed(permit)
+	reserveWithTimeout()
+	reserveWithTimeout()
+	require.Equal(t, int64(2), exhaustedCount())
+	require.Len(t, logger.Lines(), 1)
+	require.Contains(t, logger.Lines()[0], "Task slots exhausted")
+
+	// Reservations that do not wait past the threshold are not reported
+	supplier.ReleaseSlot(permit, SlotReleaseReasonTaskProcessed)
+	_, err = supplier.ReserveSlot(context.Background(), &slotReservationData{})
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(t, int64(2), exhaustedCount())
+}
+
+func TestSharedPoolTuner(t *testing.T) {
+	_, err := NewSharedPoolTuner(SharedPoolTunerOptions{})
+	require.Error(t, err)
+	_, err = NewSharedPoolTuner(SharedPoolTunerOptions{NumSlots: 3})
+	require.Error(t, err)
+	_, err = NewSharedPoolTuner(SharedPoolTunerOptions{NumSlots: 4, WorkflowWeight: -1})
+	require.Error(t, err)
+
+	tuner, err := NewSharedPoolTuner(SharedPoolTunerOptions{NumSlots: 8, WorkflowWeight: 1, ActivityWeight: 1})
+	require.NoError(t, err)
+	workflowSS, activitySS := tuner.GetWorkflowTaskSlotSupplier(), tuner.GetActivityTaskSlotSupplier()
+	require.Equal(t, 8, workflowSS.MaxSlots())
+	require.Same(t, activitySS, tuner.GetSessionActivitySlotSupplier())
+
+	// Idle slots are borrowed by workflow tasks, except the ones kept for the other task types
+	var permits []*SlotPermit
+	for i := 0; i < 5; i++ {
+		permit := workflowSS.TryReserveSlot(nil)
+		require.NotNil(t, permit)
+		permits = append(permits, permit)
+	}
+	require.Nil(t, workflowSS.TryReserveSlot(nil))
+	// Waiting activities below their share get the released slots first
+	reserved := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := activitySS.ReserveSlot(context.Background(), nil)
+			reserved <- err
+		}()
+	}
+	require.NoError(t, <-reserved)
+	require.Eventually(t, func() bool {
+		activitySS.(*sharedSlotSupplier).pool.lock.Lock()
+		defer activitySS.(*sharedSlotSupplier).pool.lock.Unlock()
+		return activitySS.(*sharedSlotSupplier).pool.waiting[sharedSlotKindActivity] == 1
+	}, time.Second, time.Millisecond)
+	workflowSS.ReleaseSlot(nil)
+	require.Nil(t, workflowSS.TryReserveSlot(nil))
+	require.NoError(t, <-reserved)
+
+	// Workflow tasks keep their share, no more slots are available
+	require.Nil(t, activitySS.TryReserveSlot(nil))
+	workflowSS.ReleaseSlot(nil)
+	require.NotNil(t, workflowSS.TryReserveSlot(nil))
+
+	// Reservations can be canceled
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = activitySS.ReserveSlot(ctx, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Task types without a weight still get the slot kept for them
+	require.NotNil(t, tuner.GetLocalActivitySlotSupplier().TryReserveSlot(nil))
+	require.NotNil(t, tuner.GetNexusSlotSupplier().TryReserveSlot(nil))
+	require.Nil(t, workflowSS.TryReserveSlot(nil))
+}
@@ -0,0 +1,185 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+type (
+	// UpdateWithStartWorkflowBuilder builds the options of an update-with-start request, checking on Build that the
+	// start and update options can be combined. Create it with NewUpdateWithStartWorkflowBuilder and execute it with
+	// ExecuteUpdateWithStartWorkflow. Setters return the builder so that calls can be chained.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.UpdateWithStartWorkflowBuilder]
+	UpdateWithStartWorkflowBuilder struct {
+		startOptions  StartWorkflowOptions
+		workflow      interface{}
+		workflowArgs  []interface{}
+		updateOptions UpdateWorkflowOptions
+	}
+
+	// NOTE to maintainers, the interface definitions below are duplicated in the client package to provide a better UX.
+
+	// TypedWorkflowRun is a WorkflowRun whose result is of type T.
+	//
+	// NOTE: Experimental
+	TypedWorkflowRun[T any] interface {
+		WorkflowRun
+
+		// GetResult blocks until the workflow completes and returns its result decoded into T.
+		GetResult(ctx context.Context) (T, error)
+	}
+
+	// TypedWorkflowUpdateHandle is a WorkflowUpdateHandle whose result is of type T.
+	//
+	// NOTE: Experimental
+	TypedWorkflowUpdateHandle[T any] interface {
+		WorkflowUpdateHandle
+
+		// GetResult blocks on the outcome of the update and returns its result decoded into T.
+		GetResult(ctx context.Context) (T, error)
+	}
+
+	typedWorkflowRun[T any] struct {
+		WorkflowRun
+	}
+
+	typedWorkflowUpdateHandle[T any] struct {
+		WorkflowUpdateHandle
+	}
+)
+
+// NewUpdateWithStartWorkflowBuilder creates an UpdateWithStartWorkflowBuilder starting the workflow with the options
+// and arguments if it is not running. The update is sent to the workflow with the ID of the options, and waits for
+// WorkflowUpdateStageCompleted unless set otherwise.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.NewUpdateWithStartWorkflowBuilder]
+func NewUpdateWithStartWorkflowBuilder(options StartWorkflowOptions, workflow interface{}, args ...interface{}) *UpdateWithStartWorkflowBuilder {
+	return &UpdateWithStartWorkflowBuilder{
+		startOptions: options,
+		workflow:     workflow,
+		workflowArgs: args,
+		updateOptions: UpdateWorkflowOptions{
+			WaitForStage: WorkflowUpdateStageCompleted,
+		},
+	}
+}
+
+// UseExisting sends the update to the workflow if it is already running instead of failing, by setting the
+// WorkflowIDConflictPolicy of the start options to UseExisting.
+func (b *UpdateWithStartWorkflowBuilder) UseExisting() *UpdateWithStartWorkflowBuilder {
+	b.startOptions.WorkflowIDConflictPolicy = enumspb.WORKFLOW_ID_CONFLICT_POLICY_USE_EXISTING
+	return b
+}
+
+// Update sets the name and arguments of the update.
+func (b *UpdateWithStartWorkflowBuilder) Update(updateName string, args ...interface{}) *UpdateWithStartWorkflowBuilder {
+	b.updateOptions.UpdateName = updateName
+	b.updateOptions.Args = args
+	return b
+}
+
+// UpdateID sets the ID of the update, see UpdateWorkflowOptions.UpdateID.
+func (b *UpdateWithStartWorkflowBuilder) UpdateID(updateID string) *UpdateWithStartWorkflowBuilder {
+	b.updateOptions.UpdateID = updateID
+	return b
+}
+
+// WaitForStage sets the stage of the update to wait for before returning, which must be
+// WorkflowUpdateStageAccepted or WorkflowUpdateStageCompleted.
+func (b *UpdateWithStartWorkflowBuilder) WaitForStage(stage WorkflowUpdateStage) *UpdateWithStartWorkflowBuilder {
+	b.updateOptions.WaitForStage = stage
+	return b
+}
+
+// Build checks that the start and update options can be combined and returns the options of an update-with-start
+// request made with the client.
+func (b *UpdateWithStartWorkflowBuilder) Build(c Client) (UpdateWithStartWorkflowOptions, error) {
+	if err := b.validate(); err != nil {
+		return UpdateWithStartWorkflowOptions{}, err
+	}
+	updateOptions := b.updateOptions
+	updateOptions.WorkflowID = b.startOptions.ID
+	return UpdateWithStartWorkflowOptions{
+		StartWorkflowOperation: c.NewWithStartWorkflowOperation(b.startOptions, b.workflow, b.workflowArgs...),
+		UpdateOptions:          updateOptions,
+	}, nil
+}
+
+func (b *UpdateWithStartWorkflowBuilder) validate() error {
+	var errs []error
+	if b.startOptions.ID == "" {
+		errs = append(errs, errors.New("workflow ID must be set"))
+	}
+	if b.startOptions.TaskQueue == "" {
+		errs = append(errs, errors.New("task queue must be set"))
+	}
+	if b.startOptions.WorkflowIDConflictPolicy == enumspb.WORKFLOW_ID_CONFLICT_POLICY_UNSPECIFIED {
+		errs = append(errs, errors.New("WorkflowIDConflictPolicy must be set"))
+	}
+	if b.startOptions.WorkflowIDReusePolicy == enumspb.WORKFLOW_ID_REUSE_POLICY_TERMINATE_IF_RUNNING {
+		errs = append(errs, errors.New("WorkflowIDReusePolicy TerminateIfRunning cannot be used, set WorkflowIDConflictPolicy instead"))
+	}
+	if b.startOptions.CronSchedule != "" {
+		errs = append(errs, errors.New("CronSchedule cannot be used"))
+	}
+	if b.startOptions.StartDelay != 0 {
+		errs = append(errs, errors.New("StartDelay cannot be used"))
+	}
+	if b.startOptions.EnableEagerStart {
+		errs = append(errs, errors.New("EnableEagerStart cannot be used"))
+	}
+	if b.updateOptions.UpdateName == "" {
+		errs = append(errs, errors.New("update name must be set"))
+	}
+	if stage := b.updateOptions.WaitForStage; stage != WorkflowUpdateStageAccepted && stage != WorkflowUpdateStageCompleted {
+		errs = append(errs, fmt.Errorf("WaitForStage must be WorkflowUpdateStageAccepted or WorkflowUpdateStageCompleted, got %v", stage))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid update-with-start options: %w", err)
+	}
+	return nil
+}
+
+// ExecuteUpdateWithStartWorkflow issues the update-with-start request built by the builder, and returns the run of the
+// workflow, started or already running, along with the handle of the update.
+//
+// NOTE: Experimental
+func ExecuteUpdateWithStartWorkflow[W, U any](
+	ctx context.Context,
+	c Client,
+	builder *UpdateWithStartWorkflowBuilder,
+) (TypedWorkflowRun[W], TypedWorkflowUpdateHandle[U], error) {
+	options, err := builder.Build(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	updateHandle, err := c.UpdateWithStartWorkflow(ctx, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	run, err := options.StartWorkflowOperation.Get(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return typedWorkflowRun[W]{run}, typedWorkflowUpdateHandle[U]{updateHandle}, nil
+}
+
+func (r typedWorkflowRun[T]) GetResult(ctx context.Context) (T, error) {
+	var result T
+	err := r.Get(ctx, &result)
+	return result, err
+}
+
+func (h typedWorkflowUpdateHandle[T]) GetResult(ctx context.Context) (T, error) {
+	var result T
+	err := h.Get(ctx, &result)
+	return result, err
+}
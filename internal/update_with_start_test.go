@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+func TestUpdateWithStartWorkflowBuilderValidation(t *testing.T) {
+	validOptions := StartWorkflowOptions{ID: "workflow-id", TaskQueue: "task-queue"}
+	for _, tc := range []struct {
+		name    string
+		builder func() *UpdateWithStartWorkflowBuilder
+		err     string
+	}{
+		{
+			name: "valid",
+			builder: func() *UpdateWithStartWorkflowBuilder {
+				return NewUpdateWithStartWorkflowBuilder(validOptions, "workflow").UseExisting().Update("update")
+			},
+		},
+		{
+			name: "missing conflict policy",
+			builder: func() *UpdateWithStartWorkflowBuilder {
+				return NewUpdateWithStartWorkflowBuilder(validOptions, "workflow").Update("update")
+			},
+			err: "WorkflowIDConflictPolicy must be set",
+		},
+		{
+			name: "missing workflow ID and update name",
+			builder: func() *UpdateWithStartWorkflowBuilder {
+				return NewUpdateWithStartWorkflowBuilder(StartWorkflowOptions{TaskQueue: "task-queue"}, "workflow").UseExisting()
+			},
+			err: "workflow ID must be set\nupdate name must be set",
+		},
+		{
+			name: "terminate if running",
+			builder: func() *UpdateWithStartWorkflowBuilder {
+				options := validOptions
+				options.WorkflowIDReusePolicy = enumspb.WORKFLOW_ID_REUSE_POLICY_TERMINATE_IF_RUNNING
+				return NewUpdateWithStartWorkflowBuilder(options, "workflow").UseExisting().Update("update")
+			},
+			err: "WorkflowIDReusePolicy TerminateIfRunning cannot be used",
+		},
+		{
+			name: "start delay",
+			builder: func() *UpdateWithStartWorkflowBuilder {
+				options := validOptions
+				options.StartDelay = time.Minute
+				return NewUpdateWithStartWorkflowBuilder(options, "workflow").UseExisting().Update("update")
+			},
+			err: "StartDelay cannot be used",
+		},
+		{
+			name: "admitted stage",
+			builder: func() *UpdateWithStartWorkflowBuilder {
+				return NewUpdateWithStartWorkflowBuilder(validOptions, "workflow").UseExisting().Update("update").
+					WaitForStage(WorkflowUpdateStageAdmitted)
+			},
+			err: "WaitForStage must be WorkflowUpdateStageAccepted or WorkflowUpdateStageCompleted",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.builder().validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -5,11 +5,136 @@ import (
 	"strings"
 	"time"
 
+	commandpb "go.temporal.io/api/command/v1"
 	deploymentpb "go.temporal.io/api/deployment/v1"
 	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
 )
 
 type (
+	// MetricsTagProvider returns additional metric tags for a workflow or activity. See
+	// [WorkerOptions.MetricsTagProvider].
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.MetricsTagProvider]
+	MetricsTagProvider func(input *MetricsTagProviderInput) map[string]string
+
+	// MetricsTagProviderInput is the information available to a MetricsTagProvider.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.MetricsTagProviderInput]
+	MetricsTagProviderInput struct {
+		WorkflowType string
+		// ActivityType is empty for workflows.
+		ActivityType string
+		TaskQueue    string
+		// Header of the workflow or activity.
+		Header HeaderReader
+		// SearchAttributes of the workflow when it was started. Empty for activities.
+		SearchAttributes SearchAttributes
+	}
+
+	// LogFieldProvider returns additional fields for the logger of a workflow or activity, as alternating keys and
+	// values. See [WorkerLogFieldsOptions.FieldProvider].
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.LogFieldProvider]
+	LogFieldProvider func(input *LogFieldProviderInput) []interface{}
+
+	// LogFieldProviderInput is the information available to a LogFieldProvider.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.LogFieldProviderInput]
+	LogFieldProviderInput struct {
+		WorkflowType string
+		// ActivityType is empty for workflows.
+		ActivityType string
+		TaskQueue    string
+		// Header of the workflow or activity.
+		Header HeaderReader
+	}
+
+	// WorkerLogFieldsOptions configures the identifiers the worker attaches to its loggers and to the loggers of
+	// its workflows and activities, so logs can be correlated the same way across workers.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.LogFieldsOptions]
+	WorkerLogFieldsOptions struct {
+		// Optional: If true, the Attempt field is not attached to logs of workflows and activities.
+		//
+		// default: false
+		DisableAttempt bool
+
+		// Optional: If true, the TaskQueue field is not attached to logs of the worker, its workflows and
+		// activities.
+		//
+		// default: false
+		DisableTaskQueue bool
+
+		// Optional: If true, the BuildID field is not attached to logs of the worker, its workflows and
+		// activities.
+		//
+		// default: false
+		DisableBuildID bool
+
+		// Optional: If true, the ScheduledTime of the current attempt is attached to logs of activities.
+		//
+		// default: false
+		EnableScheduledTime bool
+
+		// Optional: Callback providing additional fields for the loggers of workflows and activities, for example
+		// a request ID propagated in a header. It is called once when a workflow execution is loaded into the
+		// worker and once per activity task. It must be fast and safe for concurrent use.
+		FieldProvider LogFieldProvider
+	}
+
+	// NondeterminismDump is the information passed to [WorkerOptions.OnNondeterminism].
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.NondeterminismDump]
+	NondeterminismDump struct {
+		WorkflowType      string
+		WorkflowExecution WorkflowExecution
+		TaskQueue         string
+		// Attempt of the failed workflow task.
+		Attempt int32
+		// Error the workflow task failed with.
+		Error error
+		// History of the workflow execution up to the start of the failed workflow task, which can be replayed
+		// with a WorkflowReplayer. Nil if it could not be fetched, see HistoryError.
+		History *historypb.History
+		// HistoryError is the error fetching the history, if any.
+		HistoryError error
+		// Commands produced by replaying the workflow code for the failed workflow task. Empty if the failure was
+		// not a mismatch between history events and replay commands.
+		Commands []*commandpb.Command
+	}
+
+	// WorkflowLogSamplingOptions configures sampling of logs written with the workflow logger outside of replay.
+	// Limits apply to each workflow execution separately. Error logs are never dropped.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.WorkflowLogSamplingOptions]
+	WorkflowLogSamplingOptions struct {
+		// Optional: Maximum number of Debug, Info, and Warn logs written per workflow task. Once reached, a single
+		// warning is logged and further logs are dropped until the next workflow task.
+		//
+		// default: 0, no limit
+		MaxLogsPerWorkflowTask int
+
+		// Optional: If greater than 1, only one in every DebugSampleInterval Debug logs is written.
+		//
+		// default: 0, all Debug logs are written
+		DebugSampleInterval int
+	}
+
 	// WorkerDeploymentOptions provides configuration for Worker Deployment Versioning.
 	//
 	// NOTE: Both [WorkerDeploymentOptions.Version] and [WorkerDeploymentOptions.UseVersioning]
@@ -108,8 +233,18 @@ type (
 		// rate at which the worker is able to consume tasks from a task queue.
 		//
 		// default: 2
+		//
+		// Deprecated: use ActivityTaskPollerBehavior with PollerBehaviorSimpleMaximum instead.
 		MaxConcurrentActivityTaskPollers int
 
+		// Optional: Sets how the worker polls the task queue for activity tasks, with a fixed number of pollers or a
+		// number of pollers scaling with the load. Cannot be set with MaxConcurrentActivityTaskPollers.
+		//
+		// default: PollerBehaviorSimpleMaximum with 2 pollers
+		//
+		// NOTE: Experimental
+		ActivityTaskPollerBehavior PollerBehavior
+
 		// Optional: To set the maximum concurrent workflow task executions this worker can have.
 		// The zero value of this uses the default value. Due to internal logic where pollers
 		// alternate between stick and non-sticky queues, this
@@ -125,8 +260,20 @@ type (
 		// value cannot be 1 and will panic if set to that value.
 		//
 		// default: 2
+		//
+		// Deprecated: use WorkflowTaskPollerBehavior with PollerBehaviorSimpleMaximum instead.
 		MaxConcurrentWorkflowTaskPollers int
 
+		// Optional: Sets how the worker polls the task queue for workflow tasks, with a fixed number of pollers or a
+		// number of pollers scaling with the load. Cannot be set with MaxConcurrentWorkflowTaskPollers. Pollers
+		// alternate between sticky and non-sticky queues, so the number of pollers cannot be 1, and the minimum
+		// number of autoscaling pollers must be at least 2.
+		//
+		// default: PollerBehaviorSimpleMaximum with 2 pollers
+		//
+		// NOTE: Experimental
+		WorkflowTaskPollerBehavior PollerBehavior
+
 		// Optional: Sets the maximum concurrent nexus task executions this worker can have.
 		// The zero value of this uses the default value.
 		//
@@ -138,8 +285,18 @@ type (
 		// rate at which the worker is able to consume tasks from a task queue.
 		//
 		// default: 2
+		//
+		// Deprecated: use NexusTaskPollerBehavior with PollerBehaviorSimpleMaximum instead.
 		MaxConcurrentNexusTaskPollers int
 
+		// Optional: Sets how the worker polls the task queue for nexus tasks, with a fixed number of pollers or a
+		// number of pollers scaling with the load. Cannot be set with MaxConcurrentNexusTaskPollers.
+		//
+		// default: PollerBehaviorSimpleMaximum with 2 pollers
+		//
+		// NOTE: Experimental
+		NexusTaskPollerBehavior PollerBehavior
+
 		// Optional: Enable logging in replay.
 		// In the workflow code you can use workflow.GetLogger(ctx) to write logs. By default, the logger will skip log
 		// entry during replay mode so you won't see duplicate logs. This option will enable the logging in replay mode.
@@ -148,8 +305,47 @@ type (
 		// default: false
 		EnableLoggingInReplay bool
 
+		// Optional: Sampling and rate limiting of workflow logger output, to keep high frequency logging in workflow
+		// loops from flooding logging backends. See [WorkflowLogSamplingOptions].
+		//
+		// NOTE: Experimental
+		WorkflowLogSampling WorkflowLogSamplingOptions
+
+		// Optional: If true, Error logs written with the workflow and activity loggers include the stack trace of
+		// the caller, with SDK internal frames removed, under the StackTrace key, and the task queue. Workflow Error
+		// logs also include the TaskStartedEventID of the current workflow task.
+		//
+		// NOTE: Experimental
+		//
+		// default: false
+		EnableStackTraceInErrorLogs bool
+
+		// Optional: Controls which identifiers are attached to the loggers of the worker, its workflows and
+		// activities, and allows attaching custom fields. See [WorkerLogFieldsOptions].
+		//
+		// NOTE: Experimental
+		LogFields WorkerLogFieldsOptions
+
+		// Optional: If set, whenever replay detects nondeterminism, the full comparison of the history events and
+		// replay commands of the workflow task is written to a file in this directory, and the path of the file is
+		// logged. Files are named after the workflow run and the mismatching event, so retries of the workflow task
+		// overwrite the same file. The directory must exist.
+		//
+		// NOTE: Experimental
+		NondeterminismReportDirectory string
+
+		// Optional: If set, called whenever a workflow task fails due to nondeterminism, with the history of the
+		// workflow execution and the commands produced by replay, so they can be persisted for offline replay and
+		// debugging. It is called on a separate goroutine after the failure has been reported, for the first failed
+		// attempt of the workflow task only, as its retries fail the same way. The history is fetched with a timeout
+		// of one minute, see NondeterminismDump.HistoryError. Not called for workflows with the FailWorkflow panic
+		// policy, which fail the workflow instead of the workflow task.
+		//
+		// NOTE: Experimental
+		OnNondeterminism func(*NondeterminismDump)
+
 		// Optional: Sticky schedule to start timeout.
-		// The resolution is seconds.
+		// The resolution is seconds. Other values are rounded to the nearest second, and at least 1s, with a warning.
 		//
 		// Sticky Execution is to run the workflow tasks for one workflow execution on same worker host. This is an
 		// optimization for workflow execution. When sticky execution is enabled, worker keeps the workflow state in
@@ -211,7 +407,9 @@ type (
 		// default: false
 		LocalActivityWorkerOnly bool
 
-		// Optional: If set overwrites the client level Identity value.
+		// Optional: If set overwrites the client level Identity value. The identity is the "worker_identity" tag of
+		// the metrics of the worker, so the default one, which contains the process ID, creates new metric series
+		// every time the process restarts. Set a stable identity to avoid this.
 		//
 		// default: client identity
 		Identity string
@@ -219,6 +417,23 @@ type (
 		// Optional: If set defines maximum amount of time that workflow task will be allowed to run. Defaults to 1 sec.
 		DeadlockDetectionTimeout time.Duration
 
+		// Optional: Size in bytes of the input of an activity or child workflow, or of the value of a side effect
+		// marker, above which a warning is logged with the call site in the workflow when the workflow schedules it,
+		// before the server rejects the workflow task for a payload that is too large. Negative values disable the
+		// warning.
+		//
+		// default: 512 KiB
+		//
+		// NOTE: Experimental
+		PayloadSizeWarningThreshold int
+
+		// Optional: Size in bytes of the input of an activity or child workflow, or of the value of a side effect
+		// marker, above which the workflow task fails with an error pointing at the call site in the workflow,
+		// instead of sending the command to the server. Zero disables the error.
+		//
+		// NOTE: Experimental
+		PayloadSizeErrorThreshold int
+
 		// Optional: The maximum amount of time between sending each pending heartbeat to the server. Regardless of
 		// heartbeat timeout, no pending heartbeat will wait longer than this amount of time to send. To effectively disable
 		// heartbeat throttling, this can be set to something like 1 nanosecond, but it is not recommended.
@@ -284,7 +499,9 @@ type (
 
 		// Assign a BuildID to this worker. This replaces the deprecated binary checksum concept,
 		// and is used to provide a unique identifier for a set of worker code, and is necessary
-		// to opt in to the Worker Versioning feature. See [UseBuildIDForVersioning].
+		// to opt in to the Worker Versioning feature. See [UseBuildIDForVersioning]. If not set while
+		// versioning is enabled, it is derived from the checksum of the worker binary, which can be
+		// overridden with SetBinaryChecksum.
 		//
 		// Deprecated: Use [WorkerDeploymentOptions.Version]
 		BuildID string
@@ -304,12 +521,190 @@ type (
 		// NOTE: Experimental
 		DeploymentOptions WorkerDeploymentOptions
 
+		// Optional: Adds the build ID of the worker as a "build_id" tag to the metrics it emits, to compare
+		// builds during a rollout. The metrics of the client and of workers without this option have a "build_id"
+		// tag of "none", so that all the series of a metric have the same tags. It does not change the logs of the
+		// worker, which have the build ID unless [WorkerLogFieldsOptions.DisableBuildID] is set in LogFields.
+		//
+		// default: false
+		//
+		// NOTE: Experimental
+		EnableBuildIDMetricsTag bool
+
+		// Optional: Static tags added to all the metrics emitted by this worker, to distinguish the workers of a
+		// process in dashboards. They cannot override the "namespace", "task_queue" and "worker_identity" tags the
+		// metrics of a worker always have. The client and its other workers emit some of the same metrics, such as
+		// temporal_request, without these tags. Reporters requiring all the series of a metric to have the same tag
+		// keys, such as Prometheus, fail on this, in which case the tags should be set on the metrics handler of the
+		// client instead, with a default value the workers override.
+		//
+		// NOTE: Experimental
+		MetricsTags map[string]string
+
 		// Optional: If set, use a custom tuner for this worker. See WorkerTuner for more.
 		// Mutually exclusive with MaxConcurrentWorkflowTaskExecutionSize,
 		// MaxConcurrentActivityExecutionSize, and MaxConcurrentLocalActivityExecutionSize.
 		//
 		// NOTE: Experimental
 		Tuner WorkerTuner
+
+		// Optional: Callback providing additional tags for metrics emitted for workflows and activities, both by
+		// the SDK and by user code through the workflow and activity metrics handlers. For example, a tenant tag
+		// can be extracted from a search attribute or header. It is called once when a workflow execution is
+		// loaded into the worker and once per activity task. It must be fast and safe for concurrent use.
+		//
+		// The tag keys are fixed by the first call that returns tags, as some metrics backends such as Prometheus
+		// require all series of a metric to have the same keys. Keys missing from later results are tagged with
+		// "none", and keys not returned by the first result are dropped. Metrics emitted before the first call that
+		// returns tags do not have them.
+		//
+		// NOTE: Experimental
+		MetricsTagProvider MetricsTagProvider
+
+		// Optional: Maximum number of distinct values per tag key returned by MetricsTagProvider. Once a key
+		// reaches this many values, further new values are replaced with "_other_" to bound metric cardinality.
+		// Together with the fixed key set, this bounds the tags MetricsTagProvider can add.
+		//
+		// NOTE: Experimental
+		//
+		// default: 100
+		MaxMetricsTagValues int
+
+		// Optional: If true, the worker starts in standby. A standby worker connects to the server and registers
+		// its workflows, activities, and Nexus services on Start, but does not poll for or accept any tasks until
+		// it is promoted with Controller.Promote or by WaitForPromotion. This allows a warm replacement to be kept
+		// ready for a singleton worker and promoted on failover without both processing tasks at once.
+		//
+		// NOTE: Experimental
+		Standby bool
+
+		// Optional: Hook called when a standby worker is started, such as a leader election campaign. The worker
+		// is promoted when it returns nil. If it returns an error, the error is logged and the worker remains in
+		// standby until promoted with Controller.Promote. The context is canceled when the worker is stopped. Ignored
+		// if Standby is false.
+		//
+		// NOTE: Experimental
+		WaitForPromotion func(ctx context.Context) error
+
+		// Optional: If set, the worker calls this on Start and does not poll for or accept any tasks until it returns
+		// nil. Errors are logged and the call is retried with exponential backoff. This can be used to delay
+		// processing until dependencies such as databases and downstream services are ready. Start does not wait
+		// for the gate. The context is canceled when the worker is stopped.
+		//
+		// NOTE: Experimental
+		StartGate func(ctx context.Context) error
+
+		// Optional: If set, the worker reports task slots as exhausted when a poller has waited this long for a slot of
+		// a slot type (workflow, activity, local activity, or Nexus) while tasks are using slots of that type. Each
+		// such wait increments the temporal_worker_task_slots_exhausted counter, tagged with the worker type, and a
+		// warning is logged at most once a minute per slot type. Sustained exhaustion means tasks are waiting on the
+		// server to be picked up, which otherwise only shows as a rising schedule-to-start latency.
+		//
+		// NOTE: Experimental
+		//
+		// default: 0, which disables exhaustion reporting
+		SlotExhaustionWarningThreshold time.Duration
+
+		// Optional: If set, results of activities scheduled with ActivityOptions.IdempotencyKey are stored in this
+		// cache when they succeed, and activity tasks whose key is already in the cache complete with the stored
+		// result without running the activity. See NewMemoryActivityResultCache for an in-memory implementation.
+		//
+		// NOTE: Experimental
+		ActivityResultCache ActivityResultCache
+
+		// Optional: HeadersProvider is invoked on every request the worker makes to the server, like polls, task
+		// responses and activity heartbeats, and gives the ability to set request headers for the worker, for example
+		// auth tokens refreshed centrally. The headers are added to the ones set by the HeadersProvider of the
+		// client. Requests the worker makes through the client, like workflows started by Nexus operations, are not
+		// affected. Requires a client connected by this package.
+		//
+		// NOTE: Experimental
+		HeadersProvider HeadersProvider
+
+		// Optional: Schedule-to-start latency thresholds of the tasks of the worker's task queue. Tasks started
+		// later than their threshold increment the temporal_schedule_to_start_slo_violation counter, tagged with
+		// the worker type and the workflow and activity types, and are passed to the OnViolation callback, for
+		// example to scale workers or page before the backlog grows.
+		//
+		// NOTE: Experimental
+		ScheduleToStartSLO *ScheduleToStartSLOOptions
+
+		// Optional: Metadata of the host of the worker, like its pod and node, so operators can trace a stuck task
+		// to the exact pod. The non-empty fields are appended to the identity of the worker, as in
+		// "1234@host@queue pod=p node=n", which is shown with the pollers of DescribeTaskQueue, and sent in the
+		// temporal-worker-* headers of the requests the worker makes to the server. Use DetectWorkerHostMetadata
+		// to fill it from the environment.
+		//
+		// NOTE: Experimental
+		HostMetadata *WorkerHostMetadata
+
+		// Optional: Enables the local activity watchdog. Local activities still running when their context is done
+		// at the end of their StartToCloseTimeout, or ScheduleToCloseTimeout, time out right away and are retried as
+		// usual, the cause of their timeout error being a retryable application error of type
+		// LocalActivityStillRunningErrorType. The grace period does not delay this error. The watchdog tracks the
+		// attempt still running in the background. Attempts that have not returned after this grace period, typically
+		// because they ignore the cancellation of their context, are reported: the
+		// temporal_local_activity_watchdog_timeout counter is incremented and a warning is logged. They are not
+		// stopped, as Go cannot stop goroutines, and do not hold a local activity slot.
+		//
+		// NOTE: Experimental
+		//
+		// default: 0, which disables the watchdog
+		LocalActivityWatchdogGracePeriod time.Duration
+
+		// Optional: Named executor pools with their maximum number of concurrent activity executions, such as
+		// {"io-heavy": 100, "cpu-heavy": 4}, which limit how many activities of each class of a task queue run at
+		// once. Activities are assigned to a pool with RegisterActivityOptions.ExecutorPool. As the activity
+		// type of a task is only known once it is polled, activity tasks of a pool that is full wait after being
+		// polled for one of its executions to finish before running:
+		//   - The time spent waiting counts toward their StartToCloseTimeout and ScheduleToCloseTimeout. Tasks
+		//     whose timeout expires while waiting are not run, and time out and are retried like other activities.
+		//   - Tasks with a HeartbeatTimeout heartbeat while waiting, with the details of their previous attempt, so
+		//     waiting does not count toward it.
+		//   - Waiting tasks hold an activity slot of the worker. Pools do not isolate their activities from each
+		//     other: tasks of a full pool can take all the slots of the worker and starve the activities of other
+		//     pools. MaxConcurrentActivityExecutionSize should be well above the sum of the pool sizes, or the pools
+		//     run on separate workers when isolation is required.
+		// The number of running activities of each pool is reported by the temporal_activity_executor_pool_slots_used
+		// gauge, tagged with the pool name.
+		//
+		// NOTE: Experimental
+		ActivityExecutorPools map[string]int
+	}
+
+	// WorkerUpdateOptions are the options that can be changed on a running worker with Controller.UpdateOptions. Fields
+	// left at their zero value keep their current setting. Concurrency limits can only be changed if the worker does
+	// not use a Tuner other than one with fixed size slot suppliers, and slots already in use are kept when they are
+	// lowered.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.UpdateOptions]
+	WorkerUpdateOptions struct {
+		// Optional: Changes the maximum concurrent activity executions.
+		MaxConcurrentActivityExecutionSize int
+
+		// Optional: Changes the rate limit on the number of activities executed per second by the worker.
+		WorkerActivitiesPerSecond float64
+
+		// Optional: Changes the maximum concurrent local activity executions.
+		MaxConcurrentLocalActivityExecutionSize int
+
+		// Optional: Changes the rate limit on the number of local activities executed per second by the worker.
+		WorkerLocalActivitiesPerSecond float64
+
+		// Optional: Changes the rate limit on the number of activities executed per second for the entire task queue,
+		// sent to the server on the next activity task poll.
+		//
+		// NOTE: Setting this will also disable eager activities.
+		TaskQueueActivitiesPerSecond float64
+
+		// Optional: Changes the maximum concurrent workflow task executions. Cannot be 1, see
+		// WorkerOptions.MaxConcurrentWorkflowTaskExecutionSize.
+		MaxConcurrentWorkflowTaskExecutionSize int
+
+		// Optional: Changes the maximum concurrent Nexus task executions.
+		MaxConcurrentNexusTaskExecutionSize int
 	}
 )
 
@@ -337,6 +732,27 @@ const (
 	FailWorkflow
 )
 
+// ActivityPanicPolicy is used for configuring how worker deals with activity
+// code panicking. The default behavior is to retry the activity according to
+// its retry policy.
+//
+// Exposed as: [go.temporal.io/sdk/worker.ActivityPanicPolicy]
+type ActivityPanicPolicy int
+
+const (
+	// RetryActivity is the default policy for handling activity panics. The
+	// activity attempt fails with a PanicError and the activity is retried
+	// according to its retry policy.
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.RetryActivity]
+	RetryActivity ActivityPanicPolicy = iota
+	// FailActivity fails the activity without retrying it if activity code
+	// panics.
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.FailActivity]
+	FailActivity
+)
+
 // ReplayNamespace is namespace for replay because startEvent doesn't contain it
 const ReplayNamespace = "ReplayNamespace"
 
@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+const (
+	workerPodNameHeaderName        = "temporal-worker-pod-name"
+	workerNodeNameHeaderName       = "temporal-worker-node-name"
+	workerRegionHeaderName         = "temporal-worker-region"
+	workerBinaryChecksumHeaderName = "temporal-worker-binary-checksum"
+)
+
+type (
+	// WorkerHostMetadata describes the host a worker runs on, see WorkerOptions.HostMetadata. Empty fields are
+	// omitted.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.HostMetadata]
+	WorkerHostMetadata struct {
+		// PodName is the name of the pod, or the container or host, running the worker.
+		PodName string
+		// NodeName is the name of the node the pod runs on.
+		NodeName string
+		// Region is the region of the node.
+		Region string
+		// BinaryChecksum identifies the binary of the worker.
+		BinaryChecksum string
+	}
+
+	// hostMetadataHeadersProvider adds the headers of the host metadata of a worker to the ones of another provider.
+	hostMetadataHeadersProvider struct {
+		headers map[string]string
+		next    HeadersProvider
+	}
+)
+
+// DetectWorkerHostMetadata returns the host metadata of the current process: the pod name from the POD_NAME
+// environment variable, defaulting to the host name, the node name from NODE_NAME, the region from REGION or
+// AWS_REGION, and the checksum of the running binary. POD_NAME and NODE_NAME are typically set from the Kubernetes
+// downward API.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/worker.DetectHostMetadata]
+func DetectWorkerHostMetadata() WorkerHostMetadata {
+	metadata := WorkerHostMetadata{
+		PodName:        os.Getenv("POD_NAME"),
+		NodeName:       os.Getenv("NODE_NAME"),
+		Region:         os.Getenv("REGION"),
+		BinaryChecksum: getBinaryChecksum(),
+	}
+	if metadata.PodName == "" {
+		metadata.PodName = getHostName()
+	}
+	if metadata.Region == "" {
+		metadata.Region = os.Getenv("AWS_REGION")
+	}
+	return metadata
+}
+
+// headers returns the gRPC headers carrying the metadata.
+func (m *WorkerHostMetadata) headers() map[string]string {
+	headers := make(map[string]string, 4)
+	for name, value := range map[string]string{
+		workerPodNameHeaderName:        m.PodName,
+		workerNodeNameHeaderName:       m.NodeName,
+		workerRegionHeaderName:         m.Region,
+		workerBinaryChecksumHeaderName: m.BinaryChecksum,
+	} {
+		if value != "" {
+			headers[name] = value
+		}
+	}
+	return headers
+}
+
+// withIdentity returns the identity followed by the metadata, as in "1234@host@queue pod=p node=n", so it is
+// shown with the pollers of the task queue.
+func (m *WorkerHostMetadata) withIdentity(identity string) string {
+	var b strings.Builder
+	b.WriteString(identity)
+	for _, field := range [][2]string{
+		{"pod", m.PodName},
+		{"node", m.NodeName},
+		{"region", m.Region},
+		{"checksum", m.BinaryChecksum},
+	} {
+		if field[1] != "" {
+			b.WriteString(" " + field[0] + "=" + field[1])
+		}
+	}
+	return b.String()
+}
+
+// headersProvider returns a provider adding the headers of the metadata to the ones of the next provider, which
+// may be nil. Headers of the next provider take precedence.
+func (m *WorkerHostMetadata) headersProvider(next HeadersProvider) HeadersProvider {
+	return &hostMetadataHeadersProvider{headers: m.headers(), next: next}
+}
+
+func (p *hostMetadataHeadersProvider) GetHeaders(ctx context.Context) (map[string]string, error) {
+	headers := make(map[string]string, len(p.headers))
+	for name, value := range p.headers {
+		headers[name] = value
+	}
+	if p.next != nil {
+		next, err := p.next.GetHeaders(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range next {
+			headers[name] = value
+		}
+	}
+	return headers, nil
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/nexus-rpc/sdk-go/nexus"
 
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -301,6 +302,51 @@ type (
 		SignalChildWorkflow(ctx Context, signalName string, data interface{}) Future
 	}
 
+	// ChildWorkflowHandle is a typed handle to a child workflow execution whose result is of type T.
+	//
+	// NOTE to maintainers, this interface definition is duplicated in the workflow package to provide a better UX.
+	//
+	// NOTE: Experimental
+	ChildWorkflowHandle[T any] interface {
+		// WaitForStart blocks until the child workflow execution has started and returns its execution. The error is
+		// non-nil if the child workflow could not be started.
+		WaitForStart(ctx Context) (WorkflowExecution, error)
+
+		// GetResult blocks until the child workflow completes and returns its result decoded into T.
+		GetResult(ctx Context) (T, error)
+
+		// Future returns the underlying untyped ChildWorkflowFuture, for use with selectors.
+		Future() ChildWorkflowFuture
+	}
+
+	// ExternalWorkflowHandle is a handle to a workflow execution that is not a child of the current workflow.
+	// See [GetExternalWorkflowHandle]. It is signaled with [SignalExternalWorkflowTyped].
+	//
+	// The futures returned by Cancel and SignalExternalWorkflowTyped fail with:
+	//   - *UnknownExternalWorkflowExecutionError if the target execution does not exist or has already completed.
+	//     The server reports both with the same failure cause. With
+	//     [ExternalWorkflowHandleOptions.DescribeUnknownTarget] the target is described to tell them apart, and the
+	//     futures fail with *ExternalWorkflowNotFoundError or *ExternalWorkflowCompletedError instead, which unwrap
+	//     to this error.
+	//   - *NamespaceNotFoundError if the target namespace does not exist.
+	//   - *SignalCountLimitExceededError (signals only) if the target has received the maximum number of signals.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/workflow.ExternalWorkflowHandle]
+	ExternalWorkflowHandle interface {
+		// GetID returns the workflow ID of the target execution.
+		GetID() string
+
+		// GetRunID returns the run ID of the target execution. Empty means the currently running execution of the
+		// workflow ID.
+		GetRunID() string
+
+		// Cancel requests cancellation of the target execution. The returned future is ready once the cancellation
+		// request has been delivered or failed.
+		Cancel(ctx Context) Future
+	}
+
 	// WorkflowType identifies a workflow type.
 	//
 	// Exposed as: [go.temporal.io/sdk/workflow.Type]
@@ -449,6 +495,17 @@ type (
 		//
 		// WARNING: Task queue priority is currently experimental.
 		Priority Priority
+
+		// DataConverter - Data converter encoding the input and decoding the result of the child workflow instead of
+		// the data converter of the workflow, for example to encrypt them with a payload codec only some workers have.
+		// The workers running the child workflow must use a compatible data converter, typically by running it on its
+		// own task queue. Failures, memos and other payloads are still converted with the data converter of the
+		// workflow.
+		//
+		// Optional: defaults to the data converter of the workflow context.
+		//
+		// NOTE: Experimental
+		DataConverter converter.DataConverter
 	}
 
 	// RegisterWorkflowOptions consists of options for registering a workflow
@@ -470,6 +527,21 @@ type (
 		//
 		// NOTE: Experimental
 		VersioningBehavior VersioningBehavior
+		// Optional: Overrides WorkerOptions.WorkflowPanicPolicy for workflows of
+		// this type.
+		//
+		// NOTE: Experimental
+		PanicPolicy *WorkflowPanicPolicy
+		// Optional: InputValidator is a func with the same parameters as the workflow func, the workflow.Context
+		// being optional, returning only an error. It is called with the workflow input before the workflow func
+		// when the workflow is executed, and the workflow fails with a non-retryable error wrapping the error it
+		// returns, without running the workflow func. It is also called when the workflow is replayed, so like the
+		// workflow func it must be deterministic, and changing which inputs it rejects breaks the replay of
+		// workflows started with them. It must not alter workflow state. Not supported for workflows registered as
+		// WorkflowDefinitionFactory.
+		//
+		// NOTE: Experimental
+		InputValidator interface{}
 	}
 
 	localActivityContext struct {
@@ -515,6 +587,14 @@ type (
 		// as well as workflow actions such as scheduling activities and
 		// performing side-effects. A panic from this function will be treated
 		// as equivalent to returning an error.
+		//
+		// The type of an application error returned by the validator is the
+		// rejection code of the update: it tags the
+		// temporal_workflow_update_rejected metric and the rejection log entry,
+		// and is returned to clients by the Code method of the
+		// WorkflowUpdateRejectedError the update fails with. Use
+		// temporal.NewApplicationError with the code as its type to reject
+		// updates with a code.
 		Validator interface{}
 		// UnfinishedPolicy is the policy to apply when a workflow exits while
 		// the update handler is still running.
@@ -539,6 +619,23 @@ type (
 		Summary string
 	}
 
+	// ExternalWorkflowHandleOptions are options for [GetExternalWorkflowHandleWithOptions].
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/workflow.ExternalWorkflowHandleOptions]
+	ExternalWorkflowHandleOptions struct {
+		// DescribeUnknownTarget, if true, describes the target execution when a signal or cancellation fails with
+		// UnknownExternalWorkflowExecutionError, so the failure is replaced with ExternalWorkflowNotFoundError or
+		// ExternalWorkflowCompletedError. The target is described by a local activity, which records a marker in
+		// the workflow history. Setting this for a handle whose calls can already have failed in running workflows
+		// is therefore a non-deterministic change. The worker must have a client to describe the target, otherwise
+		// the original error is kept.
+		//
+		// NOTE: Experimental
+		DescribeUnknownTarget bool
+	}
+
 	// AwaitOptions are options set when creating an await.
 	//
 	// NOTE: Experimental
@@ -554,6 +651,71 @@ type (
 		// NOTE: Experimental
 		TimerOptions TimerOptions
 	}
+
+	// WorkflowHandlers is the result of the QueryTypeHandlers query, listing the handlers registered on a workflow.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.WorkflowHandlers]
+	WorkflowHandlers struct {
+		// Signals are the signal channels requested by the workflow, sorted by name.
+		Signals []WorkflowHandlerInfo `json:"signals"`
+		// Queries are the query handlers registered by the workflow, including the built-in ones, sorted by name.
+		Queries []WorkflowHandlerInfo `json:"queries"`
+		// Updates are the update handlers registered by the workflow, sorted by name.
+		Updates []WorkflowHandlerInfo `json:"updates"`
+	}
+
+	// WorkflowHandlerInfo describes a signal, query, or update handler of a workflow.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.WorkflowHandlerInfo]
+	WorkflowHandlerInfo struct {
+		// Name is the name of the signal, query, or update.
+		Name string `json:"name"`
+		// Description is the description set when registering the handler, if any.
+		Description string `json:"description,omitempty"`
+	}
+
+	// WorkflowStackTrace is the result of the QueryTypeStackTraceJSON query, listing the call stacks of the
+	// coroutines of a workflow.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.WorkflowStackTrace]
+	WorkflowStackTrace struct {
+		// Coroutines are the coroutines of the workflow that have not completed, in creation order.
+		Coroutines []CoroutineStackTrace `json:"coroutines"`
+	}
+
+	// CoroutineStackTrace is the call stack of a workflow coroutine.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.CoroutineStackTrace]
+	CoroutineStackTrace struct {
+		// Name is the name of the coroutine, "root" for the workflow function or the name given to workflow.GoNamed.
+		Name string `json:"name"`
+		// BlockedOn is what the coroutine is blocked on, such as "chan-0.Receive", "selector-1.Select", or "Await".
+		BlockedOn string `json:"blocked_on"`
+		// Frames are the frames of the call stack, innermost first.
+		Frames []StackFrame `json:"frames"`
+	}
+
+	// StackFrame is a frame of the call stack of a workflow coroutine.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.StackFrame]
+	StackFrame struct {
+		// Function is the fully qualified name of the function.
+		Function string `json:"function"`
+		// File is the path of the source file.
+		File string `json:"file"`
+		// Line is the line in the source file.
+		Line int `json:"line"`
+	}
 )
 
 // Await blocks the calling thread until condition() returns true
@@ -731,6 +893,9 @@ func GoNamed(ctx Context, name string, f func(ctx Context)) {
 }
 
 // NewFuture creates a new future as well as associated Settable that is used to set its value.
+// The future is not affected by the cancellation of ctx: it only becomes ready when it is set
+// through the Settable, and Get keeps blocking after ctx is canceled. Setting it more than once
+// panics. Use [NewCancellableFuture] for a future that is failed when ctx is canceled.
 //
 // Exposed as: [go.temporal.io/sdk/workflow.NewFuture]
 func NewFuture(ctx Context) (Future, Settable) {
@@ -739,6 +904,74 @@ func NewFuture(ctx Context) (Future, Settable) {
 	return impl, impl
 }
 
+// FutureCancellationMode is how a future created with [NewCancellableFuture] behaves when its
+// context is canceled.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/workflow.FutureCancellationMode]
+type FutureCancellationMode int
+
+const (
+	// FutureCancellationFail fails the future with a CanceledError when its context is canceled
+	// before it is set. Settings after the future is ready, including after it was failed by the
+	// cancellation, are ignored instead of panicking, so the code completing the future, for
+	// instance a signal handler, does not have to check whether it was canceled.
+	//
+	// Exposed as: [go.temporal.io/sdk/workflow.FutureCancellationFail]
+	FutureCancellationFail FutureCancellationMode = iota
+
+	// FutureCancellationIgnore leaves the future unaffected by the cancellation of its context,
+	// like a future created with [NewFuture]: it only becomes ready when it is set, and setting
+	// it more than once panics.
+	//
+	// Exposed as: [go.temporal.io/sdk/workflow.FutureCancellationIgnore]
+	FutureCancellationIgnore
+)
+
+// NewCancellableFuture creates a new future as well as associated Settable that is used to set
+// its value, typically from a signal or update handler completing work started elsewhere. The
+// mode decides what happens to the future when ctx is canceled.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/workflow.NewCancellableFuture]
+func NewCancellableFuture(ctx Context, mode FutureCancellationMode) (Future, Settable) {
+	assertNotInReadOnlyState(ctx)
+	impl := &cancellableFutureImpl{
+		futureImpl: &futureImpl{channel: NewChannel(ctx).(*channelImpl)},
+		mode:       mode,
+	}
+	if mode == FutureCancellationIgnore || ctx.Done() == nil {
+		return impl, impl
+	}
+	cancel := func() {
+		if !impl.IsReady() {
+			impl.futureImpl.Set(nil, ctx.Err())
+		}
+	}
+	ctxDone, ok := ctx.Done().(*channelImpl)
+	if !ok {
+		// Contexts implemented outside of the SDK are only canceled through their done channel
+		Go(ctx, func(coroutineCtx Context) {
+			ctx.Done().Receive(coroutineCtx, nil)
+			cancel()
+		})
+		return impl, impl
+	}
+	impl.ctxDone = ctxDone
+	impl.cancellationCallback = &receiveCallback{fn: func(v interface{}, more bool) bool {
+		assertNotInReadOnlyStateCancellation(ctx)
+		cancel()
+		return false
+	}}
+	_, ok, more := ctxDone.receiveAsyncImpl(impl.cancellationCallback)
+	if ok || !more {
+		impl.cancellationCallback.fn(nil, more)
+	}
+	return impl, impl
+}
+
 func (wc *workflowEnvironmentInterceptor) HandleSignal(ctx Context, in *HandleSignalInput) error {
 	// Remove header from the context
 	ctx = workflowContextWithoutHeader(ctx)
@@ -780,12 +1013,14 @@ func (wc *workflowEnvironmentInterceptor) ExecuteUpdate(ctx Context, in *UpdateI
 	return handler.execute(ctx, in.Args)
 }
 
+func (wc *workflowEnvironmentInterceptor) HandleReplayedEvent(Context, *HandleReplayedEventInput) {}
+
 func (wc *workflowEnvironmentInterceptor) HandleQuery(ctx Context, in *HandleQueryInput) (interface{}, error) {
 	eo := getWorkflowEnvOptions(ctx)
 	handler, ok := eo.queryHandlers[in.QueryType]
 	// Should never happen because its presence is checked before this call too
 	if !ok {
-		keys := []string{QueryTypeStackTrace, QueryTypeOpenSessions, QueryTypeWorkflowMetadata}
+		keys := []string{QueryTypeStackTrace, QueryTypeStackTraceJSON, QueryTypeOpenSessions, QueryTypeWorkflowMetadata, QueryTypeHandlers}
 		for k := range eo.queryHandlers {
 			keys = append(keys, k)
 		}
@@ -862,6 +1097,10 @@ func (wc *workflowEnvironmentInterceptor) ExecuteActivity(ctx Context, typeName
 	}
 	// Validate context options.
 	options := getActivityOptions(ctx)
+	if options.dataConverter != nil {
+		dataConverter = WithWorkflowContext(ctx, options.dataConverter)
+		future.(*decodeFutureImpl).dataConverter = dataConverter
+	}
 
 	// Validate session state.
 	if sessionInfo := getSessionInfo(ctx); sessionInfo != nil {
@@ -887,6 +1126,24 @@ func (wc *workflowEnvironmentInterceptor) ExecuteActivity(ctx Context, typeName
 		settable.Set(nil, err)
 		return future
 	}
+	if options.IdempotencyKey != "" {
+		if header, err = withActivityIdempotencyKey(header, options.IdempotencyKey); err != nil {
+			settable.Set(nil, err)
+			return future
+		}
+	}
+	if len(options.RetryOverrides) > 0 {
+		if header, err = withActivityRetryOverrides(header, options.RetryOverrides); err != nil {
+			settable.Set(nil, err)
+			return future
+		}
+	}
+	if options.Progress != nil {
+		if header, err = withActivityProgress(header, options.Progress); err != nil {
+			settable.Set(nil, err)
+			return future
+		}
+	}
 
 	input, err := encodeArgs(dataConverter, args)
 	if err != nil {
@@ -903,13 +1160,21 @@ func (wc *workflowEnvironmentInterceptor) ExecuteActivity(ctx Context, typeName
 
 	ctxDone, cancellable := ctx.Done().(*channelImpl)
 	cancellationCallback := &receiveCallback{}
+	var progressActivityID string
 	a := getWorkflowEnvironment(ctx).ExecuteActivity(params, func(r *commonpb.Payloads, e error) {
 		settable.Set(r, e)
 		if cancellable {
 			// future is done, we don't need the cancellation callback anymore.
 			ctxDone.removeReceiveCallback(cancellationCallback)
 		}
+		if progressActivityID != "" {
+			unregisterActivityProgress(ctx, progressActivityID)
+		}
 	})
+	if options.Progress != nil && !future.IsReady() {
+		progressActivityID = a.id
+		registerActivityProgress(ctx, a.id, options.Progress)
+	}
 
 	if cancellable {
 		cancellationCallback.fn = func(v interface{}, more bool) bool {
@@ -1052,13 +1317,19 @@ func (wc *workflowEnvironmentInterceptor) ExecuteLocalActivity(ctx Context, type
 		return future
 	}
 
+	dataConverter := getDataConverterFromWorkflowContext(ctx)
+	if options.dataConverter != nil {
+		dataConverter = WithWorkflowContext(ctx, options.dataConverter)
+		future.(*decodeFutureImpl).dataConverter = dataConverter
+	}
+
 	params := &ExecuteLocalActivityParams{
 		ExecuteLocalActivityOptions: *options,
 		ActivityFn:                  activityFn,
 		ActivityType:                typeName,
 		InputArgs:                   args,
 		WorkflowInfo:                GetWorkflowInfo(ctx),
-		DataConverter:               getDataConverterFromWorkflowContext(ctx),
+		DataConverter:               dataConverter,
 		ScheduledTime:               Now(ctx), // initial scheduled time
 		Header:                      header,
 		Attempt:                     1, // Attempts always start at one
@@ -1169,6 +1440,29 @@ func ExecuteChildWorkflow(ctx Context, childWorkflow interface{}, args ...interf
 	return i.ExecuteChildWorkflow(ctx, workflowType, args...)
 }
 
+// ExecuteChildWorkflowTyped requests child workflow execution like [ExecuteChildWorkflow], but returns a
+// [ChildWorkflowHandle] whose result is decoded into T.
+//
+//	handle := workflow.ExecuteChildWorkflowTyped[string](ctx, ChildWorkflow, "input")
+//	if err := workflow.SignalChildWorkflowTyped[Approval](ctx, handle, "approve", Approval{By: "me"}).Get(ctx, nil); err != nil {
+//		return err
+//	}
+//	result, err := handle.GetResult(ctx)
+//
+// NOTE: Experimental
+func ExecuteChildWorkflowTyped[T any](ctx Context, childWorkflow interface{}, args ...interface{}) ChildWorkflowHandle[T] {
+	return &childWorkflowHandleImpl[T]{future: ExecuteChildWorkflow(ctx, childWorkflow, args...)}
+}
+
+// SignalChildWorkflowTyped sends a signal whose argument is of type A to the child workflow of the handle. This call
+// will block until the child workflow is started. The returned future is ready once the signal has been delivered or
+// failed.
+//
+// NOTE: Experimental
+func SignalChildWorkflowTyped[A, T any](ctx Context, handle ChildWorkflowHandle[T], signalName string, arg A) Future {
+	return handle.Future().SignalChildWorkflow(ctx, signalName, arg)
+}
+
 func (wc *workflowEnvironmentInterceptor) ExecuteChildWorkflow(ctx Context, childWorkflowType string, args ...interface{}) ChildWorkflowFuture {
 	mainFuture, mainSettable := newDecodeFuture(ctx, childWorkflowType)
 	executionFuture, executionSettable := NewFuture(ctx)
@@ -1186,6 +1480,10 @@ func (wc *workflowEnvironmentInterceptor) ExecuteChildWorkflow(ctx Context, chil
 
 	workflowOptionsFromCtx := getWorkflowEnvOptions(ctx)
 	dc := WithWorkflowContext(ctx, workflowOptionsFromCtx.DataConverter)
+	if workflowOptionsFromCtx.childDataConverter != nil {
+		dc = WithWorkflowContext(ctx, workflowOptionsFromCtx.childDataConverter)
+		result.decodeFutureImpl.dataConverter = dc
+	}
 	env := getWorkflowEnvironment(ctx)
 	wfType, input, err := getValidatedWorkflowFunction(childWorkflowType, args, dc, env.GetRegistry())
 	if err != nil {
@@ -1195,7 +1493,6 @@ func (wc *workflowEnvironmentInterceptor) ExecuteChildWorkflow(ctx Context, chil
 	}
 
 	options := getWorkflowEnvOptions(ctx)
-	options.DataConverter = dc
 	options.ContextPropagators = workflowOptionsFromCtx.ContextPropagators
 	options.Memo = workflowOptionsFromCtx.Memo
 	options.SearchAttributes = workflowOptionsFromCtx.SearchAttributes
@@ -1218,6 +1515,7 @@ func (wc *workflowEnvironmentInterceptor) ExecuteChildWorkflow(ctx Context, chil
 		scheduledTime:   Now(ctx), /* this is needed for test framework, and is not send to server */
 		attempt:         1,
 	}
+	params.DataConverter = dc
 
 	ctxDone, cancellable := ctx.Done().(*channelImpl)
 	cancellationCallback := &receiveCallback{}
@@ -1354,6 +1652,25 @@ func (wInfo *WorkflowInfo) GetContinueAsNewSuggested() bool {
 	return wInfo.continueAsNewSuggested
 }
 
+// IsRetry returns whether this run is a retry of a failed run of the workflow, in which case
+// [GetLastError] returns the error the previous attempt failed with.
+//
+// NOTE: Experimental
+func (wInfo *WorkflowInfo) IsRetry() bool {
+	return wInfo.Attempt > 1
+}
+
+// IsLastAttempt returns whether this run is the last attempt allowed by the MaximumAttempts of
+// the retry policy of the workflow, so a failure of this run fails the workflow. It is always
+// false if the workflow has no retry policy or no maximum number of attempts, in which case
+// retries stop only on non-retryable errors or the workflow execution timeout.
+//
+// NOTE: Experimental
+func (wInfo *WorkflowInfo) IsLastAttempt() bool {
+	return wInfo.RetryPolicy != nil && wInfo.RetryPolicy.MaximumAttempts > 0 &&
+		wInfo.Attempt >= wInfo.RetryPolicy.MaximumAttempts
+}
+
 // GetWorkflowInfo extracts info of a current workflow from a context.
 //
 // Exposed as: [go.temporal.io/sdk/workflow.GetInfo]
@@ -1639,6 +1956,37 @@ func signalExternalWorkflow(ctx Context, workflowID, runID, signalName string, a
 	return future
 }
 
+// GetExternalWorkflowHandle returns a handle to an external workflow execution that can be used to signal or cancel
+// it. Input runID is optional, when empty the currently running execution of workflowID will be targeted. By default,
+// the current workflow's namespace is used as target namespace, a different one can be specified on the context passed
+// to the handle methods using WithWorkflowNamespace.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/workflow.GetExternalWorkflowHandle]
+func GetExternalWorkflowHandle(workflowID, runID string) ExternalWorkflowHandle {
+	return GetExternalWorkflowHandleWithOptions(workflowID, runID, ExternalWorkflowHandleOptions{})
+}
+
+// GetExternalWorkflowHandleWithOptions returns a handle to an external workflow execution like
+// [GetExternalWorkflowHandle], with the given options.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/workflow.GetExternalWorkflowHandleWithOptions]
+func GetExternalWorkflowHandleWithOptions(workflowID, runID string, options ExternalWorkflowHandleOptions) ExternalWorkflowHandle {
+	return &externalWorkflowHandleImpl{workflowID: workflowID, runID: runID, options: options}
+}
+
+// SignalExternalWorkflowTyped sends a signal whose argument is of type A to the target execution of the handle. The
+// returned future is ready once the signal has been delivered or failed, see [ExternalWorkflowHandle] for its errors.
+//
+// NOTE: Experimental
+func SignalExternalWorkflowTyped[A any](ctx Context, handle ExternalWorkflowHandle, signalName string, arg A) Future {
+	future := SignalExternalWorkflow(ctx, handle.GetID(), handle.GetRunID(), signalName, arg)
+	return describeUnknownExternalWorkflow(ctx, handle, future)
+}
+
 // UpsertSearchAttributes is used to add or update workflow search attributes.
 // The search attributes can be used in query of List/Scan/Count workflow APIs.
 // The key and value type must be registered on temporal server side;
@@ -1772,6 +2120,7 @@ func WithChildWorkflowOptions(ctx Context, cwo ChildWorkflowOptions) Context {
 	wfOptions.StaticSummary = cwo.StaticSummary
 	wfOptions.StaticDetails = cwo.StaticDetails
 	wfOptions.Priority = convertToPBPriority(cwo.Priority)
+	wfOptions.childDataConverter = cwo.DataConverter
 
 	return ctx1
 }
@@ -1803,6 +2152,7 @@ func GetChildWorkflowOptions(ctx Context) ChildWorkflowOptions {
 		VersioningIntent:         opts.VersioningIntent,
 		StaticSummary:            opts.StaticSummary,
 		StaticDetails:            opts.StaticDetails,
+		DataConverter:            opts.childDataConverter,
 	}
 }
 
@@ -1913,6 +2263,27 @@ func GetSignalChannel(ctx Context, signalName string) ReceiveChannel {
 	return i.GetSignalChannel(ctx, signalName)
 }
 
+// WaitForSignal blocks until a signal with the given name is received or the timeout expires, and returns the
+// received value. Returns ok equals to false if timed out and err equals to CanceledError if the ctx is canceled.
+// Signals whose value cannot be decoded into T are dropped.
+func WaitForSignal[T any](ctx Context, signalName string, timeout time.Duration) (value T, ok bool, err error) {
+	ch := GetSignalChannel(ctx, signalName)
+	// Cancel the timer once the signal is received
+	timerCtx, cancel := WithCancel(ctx)
+	defer cancel()
+	deadline := Now(ctx).Add(timeout)
+	for {
+		options := AwaitOptions{Timeout: deadline.Sub(Now(ctx)), TimerOptions: TimerOptions{Summary: "WaitForSignal"}}
+		ok, err = AwaitWithOptions(timerCtx, options, func() bool { return ch.Len() > 0 })
+		if !ok || err != nil {
+			return value, false, err
+		}
+		if ch.ReceiveAsync(&value) {
+			return value, true, nil
+		}
+	}
+}
+
 // GetSignalChannelWithOptions returns channel corresponding to the signal name.
 //
 // NOTE: Experimental
@@ -2067,6 +2438,25 @@ func (wc *workflowEnvironmentInterceptor) MutableSideEffect(ctx Context, id stri
 	return wc.env.MutableSideEffect(id, wrapperFunc, equals)
 }
 
+// NewUUIDv7 returns a new time-ordered UUID (version 7) in its string form. The UUID is generated with SideEffect, so
+// it is recorded in the workflow history and the same UUID is returned during replay. UUIDs returned by successive
+// calls in a workflow sort in the order they were generated.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/workflow.NewUUIDv7]
+func NewUUIDv7(ctx Context) (string, error) {
+	encoded := SideEffect(ctx, func(Context) interface{} {
+		// The only way to fail SideEffect is to panic, which retries the workflow task
+		return uuid.Must(uuid.NewV7()).String()
+	})
+	var id string
+	if err := encoded.Get(&id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
 // DefaultVersion is a version returned by GetVersion for code that wasn't versioned before
 //
 // Exposed as: [go.temporal.io/sdk/workflow.DefaultVersion], [go.temporal.io/sdk/workflow.Version]
@@ -2213,6 +2603,22 @@ func SetQueryHandlerWithOptions(ctx Context, queryType string, handler interface
 	return i.SetQueryHandlerWithOptions(ctx, queryType, handler, options)
 }
 
+// RegisterStateSnapshot registers a function describing the state of the workflow, returned by the built-in
+// QueryTypeStateSnapshot query. Registering again replaces the previous function.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/workflow.RegisterStateSnapshot]
+func RegisterStateSnapshot[T any](ctx Context, snapshot func() T) error {
+	assertNotInReadOnlyState(ctx)
+	if snapshot == nil {
+		return errors.New("snapshot function must not be nil")
+	}
+	return setQueryHandler(ctx, QueryTypeStateSnapshot, func() (T, error) {
+		return snapshot(), nil
+	}, QueryHandlerOptions{Description: "Snapshot of the workflow state"})
+}
+
 func (wc *workflowEnvironmentInterceptor) SetQueryHandler(ctx Context, queryType string, handler interface{}) error {
 	return wc.SetQueryHandlerWithOptions(ctx, queryType, handler, QueryHandlerOptions{})
 }
@@ -2379,6 +2785,10 @@ func WithActivityOptions(ctx Context, options ActivityOptions) Context {
 	eap.VersioningIntent = options.VersioningIntent
 	eap.Priority = convertToPBPriority(options.Priority)
 	eap.Summary = options.Summary
+	eap.IdempotencyKey = options.IdempotencyKey
+	eap.RetryOverrides = options.RetryOverrides
+	eap.Progress = options.Progress
+	eap.dataConverter = options.DataConverter
 	return ctx1
 }
 
@@ -2394,6 +2804,10 @@ func WithLocalActivityOptions(ctx Context, options LocalActivityOptions) Context
 	opts.ScheduleToCloseTimeout = options.ScheduleToCloseTimeout
 	opts.StartToCloseTimeout = options.StartToCloseTimeout
 	opts.RetryPolicy = applyRetryPolicyDefaultsForLocalActivity(options.RetryPolicy)
+	opts.LocalRetryThreshold = options.LocalRetryThreshold
+	opts.RetryJitter = options.RetryJitter
+	opts.RetryOverrides = options.RetryOverrides
+	opts.dataConverter = options.DataConverter
 	return ctx1
 }
 
@@ -2443,6 +2857,10 @@ func GetActivityOptions(ctx Context) ActivityOptions {
 		VersioningIntent:       opts.VersioningIntent,
 		Priority:               convertFromPBPriority(opts.Priority),
 		Summary:                opts.Summary,
+		IdempotencyKey:         opts.IdempotencyKey,
+		RetryOverrides:         opts.RetryOverrides,
+		DataConverter:          opts.dataConverter,
+		Progress:               opts.Progress,
 	}
 }
 
@@ -2458,6 +2876,10 @@ func GetLocalActivityOptions(ctx Context) LocalActivityOptions {
 		ScheduleToCloseTimeout: opts.ScheduleToCloseTimeout,
 		StartToCloseTimeout:    opts.StartToCloseTimeout,
 		RetryPolicy:            opts.RetryPolicy,
+		LocalRetryThreshold:    opts.LocalRetryThreshold,
+		RetryJitter:            opts.RetryJitter,
+		RetryOverrides:         opts.RetryOverrides,
+		DataConverter:          opts.dataConverter,
 	}
 }
 
@@ -2532,6 +2954,31 @@ func WithPriority(ctx Context, priority Priority) Context {
 	return ctx1
 }
 
+// WithNonRetryableErrors returns a copy of the retry policy that also stops retrying on errors of the same types as
+// the given errors, as matched by the server: the type of an [ApplicationError], or the name of the Go type of any
+// other error. It classifies errors returned by workflows for workflow level retries, when set as the RetryPolicy
+// of StartWorkflowOptions or ChildWorkflowOptions, and errors returned by activities, without having to spell out
+// the type names in NonRetryableErrorTypes. Errors without a type, such as the ones created with errors.New, are
+// ignored.
+//
+// NOTE: Experimental
+func (p *RetryPolicy) WithNonRetryableErrors(errs ...error) *RetryPolicy {
+	result := RetryPolicy{}
+	if p != nil {
+		result = *p
+	}
+	result.NonRetryableErrorTypes = append([]string(nil), result.NonRetryableErrorTypes...)
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if errType := getRetryErrType(err); errType != "" && !slices.Contains(result.NonRetryableErrorTypes, errType) {
+			result.NonRetryableErrorTypes = append(result.NonRetryableErrorTypes, errType)
+		}
+	}
+	return &result
+}
+
 func convertToPBRetryPolicy(retryPolicy *RetryPolicy) *commonpb.RetryPolicy {
 	if retryPolicy == nil {
 		return nil
@@ -0,0 +1,357 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// JSONDocument is a loosely structured JSON document, such as the payload of a DSL workflow, that can be read and
+// patched deterministically in workflows. Values are addressed with JSON Pointers (RFC 6901), such as "/steps/0/name",
+// the empty pointer addressing the whole document. Objects are read as map[string]interface{}, arrays as
+// []interface{} and numbers as json.Number, so that integers do not lose precision. Keys returns the keys of objects
+// in sorted order, to be used instead of ranging over maps, and the document is always encoded with sorted keys.
+//
+// A JSONDocument is encoded as its JSON value, so it can be used as the input or result of workflows and activities
+// with the default data converter. The zero value is the null document.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/workflow.JSONDocument]
+type JSONDocument struct {
+	value interface{}
+}
+
+// ParseJSONDocument parses a JSON document.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/workflow.ParseJSONDocument]
+func ParseJSONDocument(data []byte) (*JSONDocument, error) {
+	value, err := decodeJSONValue(data)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONDocument{value: value}, nil
+}
+
+// NewJSONDocument returns the document of the JSON encoding of a value.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/workflow.NewJSONDocument]
+func NewJSONDocument(value interface{}) (*JSONDocument, error) {
+	normalized, err := normalizeJSONValue(value)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONDocument{value: normalized}, nil
+}
+
+// Has returns whether there is a value at the pointer.
+func (d *JSONDocument) Has(pointer string) bool {
+	_, err := d.lookup(pointer)
+	return err == nil
+}
+
+// Get returns the value at the pointer. The value is shared with the document, it must be modified with Set and
+// Remove.
+func (d *JSONDocument) Get(pointer string) (interface{}, error) {
+	return d.lookup(pointer)
+}
+
+// Decode decodes the value at the pointer into valuePtr, like json.Unmarshal.
+func (d *JSONDocument) Decode(pointer string, valuePtr interface{}) error {
+	value, err := d.lookup(pointer)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, valuePtr)
+}
+
+// Keys returns the keys of the object at the pointer in sorted order.
+func (d *JSONDocument) Keys(pointer string) ([]string, error) {
+	value, err := d.lookup(pointer)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("value at %q is not an object", pointer)
+	}
+	return DeterministicKeys(object), nil
+}
+
+// Len returns the number of elements of the array, or of members of the object, at the pointer.
+func (d *JSONDocument) Len(pointer string) (int, error) {
+	value, err := d.lookup(pointer)
+	if err != nil {
+		return 0, err
+	}
+	switch value := value.(type) {
+	case []interface{}:
+		return len(value), nil
+	case map[string]interface{}:
+		return len(value), nil
+	default:
+		return 0, fmt.Errorf("value at %q is neither an array nor an object", pointer)
+	}
+}
+
+// Set sets the value at the pointer to the JSON encoding of a value, like the add operation of JSON Patch (RFC
+// 6902): members are added to or replaced in objects, elements are inserted in arrays at their index, or appended
+// when the last token of the pointer is "-". The parent of the value must exist.
+func (d *JSONDocument) Set(pointer string, value interface{}) error {
+	normalized, err := normalizeJSONValue(value)
+	if err != nil {
+		return err
+	}
+	return d.update(pointer, func(parent interface{}, token string) (interface{}, error) {
+		switch parent := parent.(type) {
+		case map[string]interface{}:
+			parent[token] = normalized
+			return parent, nil
+		case []interface{}:
+			if token == "-" {
+				return append(parent, normalized), nil
+			}
+			index, err := jsonArrayIndex(token, len(parent)+1)
+			if err != nil {
+				return nil, err
+			}
+			return slices.Insert(parent, index, normalized), nil
+		default:
+			return nil, errors.New("parent is neither an array nor an object")
+		}
+	}, normalized)
+}
+
+// Remove removes the value at the pointer, like the remove operation of JSON Patch (RFC 6902). Removing the whole
+// document sets it to null.
+func (d *JSONDocument) Remove(pointer string) error {
+	return d.update(pointer, func(parent interface{}, token string) (interface{}, error) {
+		switch parent := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := parent[token]; !ok {
+				return nil, fmt.Errorf("member %q not found", token)
+			}
+			delete(parent, token)
+			return parent, nil
+		case []interface{}:
+			index, err := jsonArrayIndex(token, len(parent))
+			if err != nil {
+				return nil, err
+			}
+			return slices.Delete(parent, index, index+1), nil
+		default:
+			return nil, errors.New("parent is neither an array nor an object")
+		}
+	}, nil)
+}
+
+// Clone returns a deep copy of the document.
+func (d *JSONDocument) Clone() *JSONDocument {
+	return &JSONDocument{value: cloneJSONValue(d.value)}
+}
+
+// MarshalJSON encodes the document with the keys of objects in sorted order. It has a value receiver so that documents
+// held by value, like in struct fields, are encoded as their JSON value too.
+func (d JSONDocument) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.value)
+}
+
+// UnmarshalJSON replaces the document with the decoded JSON value.
+func (d *JSONDocument) UnmarshalJSON(data []byte) error {
+	value, err := decodeJSONValue(data)
+	if err != nil {
+		return err
+	}
+	d.value = value
+	return nil
+}
+
+// String returns the JSON encoding of the document.
+func (d *JSONDocument) String() string {
+	data, err := d.MarshalJSON()
+	if err != nil {
+		return fmt.Sprintf("<invalid JSON document: %v>", err)
+	}
+	return string(data)
+}
+
+func (d *JSONDocument) lookup(pointer string) (interface{}, error) {
+	tokens, err := parseJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	value := d.value
+	for i, token := range tokens {
+		value, err = jsonChild(value, token)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", formatJSONPointer(tokens[:i+1]), err)
+		}
+	}
+	return value, nil
+}
+
+// update replaces the parent of the value at the pointer with the result of fn, or the whole document with root if
+// the pointer is empty.
+func (d *JSONDocument) update(
+	pointer string,
+	fn func(parent interface{}, token string) (interface{}, error),
+	root interface{},
+) error {
+	tokens, err := parseJSONPointer(pointer)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		d.value = root
+		return nil
+	}
+	value, err := d.updateChild(d.value, tokens, fn)
+	if err != nil {
+		return fmt.Errorf("%q: %w", pointer, err)
+	}
+	d.value = value
+	return nil
+}
+
+// updateChild returns the value with its descendant at the tokens updated by fn. Arrays are replaced in their parent
+// as appending to or removing from them returns a new slice.
+func (d *JSONDocument) updateChild(
+	value interface{},
+	tokens []string,
+	fn func(parent interface{}, token string) (interface{}, error),
+) (interface{}, error) {
+	if len(tokens) == 1 {
+		return fn(value, tokens[0])
+	}
+	child, err := jsonChild(value, tokens[0])
+	if err != nil {
+		return nil, err
+	}
+	child, err = d.updateChild(child, tokens[1:], fn)
+	if err != nil {
+		return nil, err
+	}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		value[tokens[0]] = child
+	case []interface{}:
+		// The index was validated by jsonChild
+		index, _ := strconv.Atoi(tokens[0])
+		value[index] = child
+	}
+	return value, nil
+}
+
+func jsonChild(value interface{}, token string) (interface{}, error) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		child, ok := value[token]
+		if !ok {
+			return nil, fmt.Errorf("member %q not found", token)
+		}
+		return child, nil
+	case []interface{}:
+		index, err := jsonArrayIndex(token, len(value))
+		if err != nil {
+			return nil, err
+		}
+		return value[index], nil
+	default:
+		return nil, errors.New("parent is neither an array nor an object")
+	}
+}
+
+// jsonArrayIndex parses the token as an array index lower than limit.
+func jsonArrayIndex(token string, limit int) (int, error) {
+	// Leading zeros are not allowed by RFC 6901
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.Trim(token, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index >= limit {
+		return 0, fmt.Errorf("array index %q out of range", token)
+	}
+	return index, nil
+}
+
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("invalid JSON pointer %q: must be empty or start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func formatJSONPointer(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/" + strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+func decodeJSONValue(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("invalid JSON document: data after the top-level value")
+	}
+	return value, nil
+}
+
+// normalizeJSONValue returns the value as decoded from its JSON encoding, so that documents only hold the types
+// returned by Get.
+func normalizeJSONValue(value interface{}) (interface{}, error) {
+	switch document := value.(type) {
+	case *JSONDocument:
+		return cloneJSONValue(document.value), nil
+	case JSONDocument:
+		return cloneJSONValue(document.value), nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSONValue(data)
+}
+
+func cloneJSONValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		clone := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			clone[k] = cloneJSONValue(v)
+		}
+		return clone
+	case []interface{}:
+		clone := make([]interface{}, len(value))
+		for i, v := range value {
+			clone[i] = cloneJSONValue(v)
+		}
+		return clone
+	default:
+		return value
+	}
+}
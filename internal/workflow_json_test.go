@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONDocument(t *testing.T) {
+	doc, err := ParseJSONDocument([]byte(`{"steps":[{"name":"a"},{"name":"b"}],"z":1,"a/b":{"~c":9007199254740993}}`))
+	require.NoError(t, err)
+
+	value, err := doc.Get("/steps/1/name")
+	require.NoError(t, err)
+	require.Equal(t, "b", value)
+	value, err = doc.Get("/a~1b/~0c")
+	require.NoError(t, err)
+	require.Equal(t, json.Number("9007199254740993"), value)
+	require.False(t, doc.Has("/steps/2"))
+	require.False(t, doc.Has("/steps/01"))
+	_, err = doc.Get("/steps/0/missing/x")
+	require.ErrorContains(t, err, `"/steps/0/missing": member "missing" not found`)
+	_, err = doc.Get("steps")
+	require.ErrorContains(t, err, "must be empty or start with /")
+
+	keys, err := doc.Keys("")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a/b", "steps", "z"}, keys)
+	_, err = doc.Keys("/steps")
+	require.ErrorContains(t, err, "not an object")
+
+	var step struct{ Name string }
+	require.NoError(t, doc.Decode("/steps/0", &step))
+	require.Equal(t, "a", step.Name)
+
+	clone := doc.Clone()
+	require.NoError(t, doc.Set("/steps/-", map[string]string{"name": "d"}))
+	require.NoError(t, doc.Set("/steps/2", struct {
+		Name string `json:"name"`
+	}{"c"}))
+	require.NoError(t, doc.Set("/steps/0/name", "a2"))
+	require.NoError(t, doc.Remove("/z"))
+	require.NoError(t, doc.Remove("/steps/1"))
+	require.Error(t, doc.Set("/steps/4", "e"))
+	require.Error(t, doc.Set("/missing/x", "e"))
+	require.Error(t, doc.Remove("/z"))
+	n, err := doc.Len("/steps")
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, `{"a/b":{"~c":9007199254740993},"steps":[{"name":"a2"},{"name":"c"},{"name":"d"}]}`, doc.String())
+	require.Equal(t, `{"a/b":{"~c":9007199254740993},"steps":[{"name":"a"},{"name":"b"}],"z":1}`, clone.String())
+
+	require.NoError(t, doc.Remove(""))
+	require.Equal(t, "null", doc.String())
+}
+
+func TestJSONDocumentField(t *testing.T) {
+	doc, err := ParseJSONDocument([]byte(`{"b":1,"a":[true]}`))
+	require.NoError(t, err)
+	type workflowInput struct {
+		Doc    JSONDocument  `json:"doc"`
+		DocPtr *JSONDocument `json:"docPtr"`
+	}
+	data, err := json.Marshal(workflowInput{Doc: *doc, DocPtr: doc})
+	require.NoError(t, err)
+	require.Equal(t, `{"doc":{"a":[true],"b":1},"docPtr":{"a":[true],"b":1}}`, string(data))
+
+	var input workflowInput
+	require.NoError(t, json.Unmarshal(data, &input))
+	require.Equal(t, `{"a":[true],"b":1}`, input.Doc.String())
+	require.Equal(t, `{"a":[true],"b":1}`, input.DocPtr.String())
+
+	// Documents held by value are set as their JSON value too
+	require.NoError(t, doc.Set("/c", *doc))
+	require.Equal(t, `{"a":[true],"b":1,"c":{"a":[true],"b":1}}`, doc.String())
+}
+
+func TestJSONDocumentInWorkflow(t *testing.T) {
+	wf := func(ctx Context, doc *JSONDocument) (*JSONDocument, error) {
+		keys, err := doc.Keys("/vars")
+		if err != nil {
+			return nil, err
+		}
+		for i, key := range keys {
+			if err := doc.Set("/order/-", key); err != nil {
+				return nil, err
+			}
+			if err := doc.Set("/vars/"+key, i); err != nil {
+				return nil, err
+			}
+		}
+		return doc, nil
+	}
+	input, err := NewJSONDocument(map[string]interface{}{
+		"vars":  map[string]string{"c": "", "a": "", "b": ""},
+		"order": []string{},
+	})
+	require.NoError(t, err)
+
+	var suite WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(wf)
+	env.ExecuteWorkflow(wf, input)
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var result JSONDocument
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, `{"order":["a","b","c"],"vars":{"a":0,"b":1,"c":2}}`, result.String())
+}
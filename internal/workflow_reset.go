@@ -0,0 +1,156 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+type (
+	// ResetWorkflowOptions is a request for [Client.ResetWorkflow].
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ResetWorkflowOptions]
+	ResetWorkflowOptions struct {
+		// WorkflowID of the workflow to reset. Required.
+		WorkflowID string
+		// RunID of the run to reset. If empty, the current run of the workflow is reset.
+		RunID string
+		// WorkflowTaskFinishEventID is the ID of the WorkflowTaskCompleted, WorkflowTaskTimedOut,
+		// WorkflowTaskFailed or WorkflowTaskStarted event to reset to. Events after it are discarded, except the
+		// reapplied ones. Required.
+		WorkflowTaskFinishEventID int64
+		// Reason of the reset, recorded in the history of both runs.
+		Reason string
+		// Reapply selects the events after the reset point that are reapplied to the new run. By default, all of
+		// them are reapplied.
+		Reapply ResetReapplyOptions
+		// DryRun only returns the events that would be reapplied, without resetting the workflow.
+		DryRun bool
+		// RequestID is used to deduplicate resets. It is generated if not set.
+		RequestID string
+	}
+
+	// ResetReapplyOptions selects the events after the reset point of [Client.ResetWorkflow] that are reapplied to
+	// the new run.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ResetReapplyOptions]
+	ResetReapplyOptions struct {
+		// ExcludeSignals drops the signals received after the reset point.
+		ExcludeSignals bool
+		// ExcludeUpdates drops the updates admitted or accepted after the reset point.
+		ExcludeUpdates bool
+	}
+
+	// ResetWorkflowResult is the result of [Client.ResetWorkflow].
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ResetWorkflowResult]
+	ResetWorkflowResult struct {
+		// RunID of the run started by the reset. Empty for dry runs.
+		RunID string
+		// ReappliedEvents are the signal and update events after the reset point that would be reapplied to the
+		// new run, in history order. Only set for dry runs.
+		ReappliedEvents []*historypb.HistoryEvent
+	}
+)
+
+func (o *ResetWorkflowOptions) validate() error {
+	if o.WorkflowID == "" {
+		return errors.New("workflow ID is required")
+	}
+	if o.WorkflowTaskFinishEventID <= 0 {
+		return errors.New("workflow task finish event ID must be positive")
+	}
+	return nil
+}
+
+func (o *ResetWorkflowOptions) toProto(namespace string) *workflowservice.ResetWorkflowExecutionRequest {
+	requestID := o.RequestID
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	return &workflowservice.ResetWorkflowExecutionRequest{
+		Namespace: namespace,
+		WorkflowExecution: &commonpb.WorkflowExecution{
+			WorkflowId: o.WorkflowID,
+			RunId:      o.RunID,
+		},
+		Reason:                    o.Reason,
+		WorkflowTaskFinishEventId: o.WorkflowTaskFinishEventID,
+		RequestId:                 requestID,
+		ResetReapplyExcludeTypes:  o.Reapply.excludeTypes(),
+	}
+}
+
+func (o *ResetReapplyOptions) excludeTypes() []enumspb.ResetReapplyExcludeType {
+	var types []enumspb.ResetReapplyExcludeType
+	if o.ExcludeSignals {
+		types = append(types, enumspb.RESET_REAPPLY_EXCLUDE_TYPE_SIGNAL)
+	}
+	if o.ExcludeUpdates {
+		types = append(types, enumspb.RESET_REAPPLY_EXCLUDE_TYPE_UPDATE)
+	}
+	return types
+}
+
+func (o *ResetReapplyOptions) reapplies(eventType enumspb.EventType) bool {
+	switch eventType {
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
+		return !o.ExcludeSignals
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_ADMITTED, enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_ACCEPTED:
+		return !o.ExcludeUpdates
+	default:
+		return false
+	}
+}
+
+// isResetPointEventType returns whether the server accepts resetting to events of the type.
+func isResetPointEventType(eventType enumspb.EventType) bool {
+	switch eventType {
+	case enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED,
+		enumspb.EVENT_TYPE_WORKFLOW_TASK_TIMED_OUT,
+		enumspb.EVENT_TYPE_WORKFLOW_TASK_FAILED,
+		enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED:
+		return true
+	default:
+		return false
+	}
+}
+
+// resetWorkflowDryRun returns the events of the history that a reset would reapply, checking the reset point.
+func (wc *WorkflowClient) resetWorkflowDryRun(ctx context.Context, options *ResetWorkflowOptions) (ResetWorkflowResult, error) {
+	iter := wc.GetWorkflowHistory(ctx, options.WorkflowID, options.RunID, false, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	var result ResetWorkflowResult
+	var resetPoint *historypb.HistoryEvent
+	for iter.HasNext() {
+		event, err := iter.Next()
+		if err != nil {
+			return ResetWorkflowResult{}, err
+		}
+		switch {
+		case event.GetEventId() == options.WorkflowTaskFinishEventID:
+			resetPoint = event
+		case event.GetEventId() > options.WorkflowTaskFinishEventID && options.Reapply.reapplies(event.GetEventType()):
+			result.ReappliedEvents = append(result.ReappliedEvents, event)
+		}
+	}
+	if resetPoint == nil {
+		return ResetWorkflowResult{}, fmt.Errorf("event %d not found in the history", options.WorkflowTaskFinishEventID)
+	}
+	if !isResetPointEventType(resetPoint.GetEventType()) {
+		return ResetWorkflowResult{}, fmt.Errorf("cannot reset to event %d of type %v, it must be a workflow task "+
+			"completed, timed out, failed or started event", resetPoint.GetEventId(), resetPoint.GetEventType())
+	}
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// WorkflowRunCompletion is the completion of a run awaited with AwaitAnyWorkflow or AwaitAllWorkflows.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.WorkflowRunCompletion]
+type WorkflowRunCompletion struct {
+	// Index is the position of the run in the runs given to AwaitAnyWorkflow or AwaitAllWorkflows.
+	Index int
+	// Run is the run that completed. Its result can be decoded with Get, which returns without waiting now that the
+	// workflow is closed.
+	Run WorkflowRun
+	// Err is the error returned by Get for the run, nil if the workflow completed successfully.
+	Err error
+}
+
+// AwaitAnyWorkflow waits for the first of the runs to complete and returns its completion. Runs are awaited
+// concurrently, and the waits on the other runs are canceled once one completes. It returns the error of the context
+// if it is done before any run completes.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.AwaitAnyWorkflow]
+func AwaitAnyWorkflow(ctx context.Context, runs ...WorkflowRun) (WorkflowRunCompletion, error) {
+	if len(runs) == 0 {
+		return WorkflowRunCompletion{}, errors.New("no workflow run to await")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// The channel is closed without any completion if the context is done first
+	completion, ok := <-AwaitAllWorkflows(ctx, runs...)
+	if !ok {
+		return WorkflowRunCompletion{}, ctx.Err()
+	}
+	return completion, nil
+}
+
+// AwaitAllWorkflows waits for the runs to complete concurrently, and sends the completion of each run to the returned
+// channel as soon as it completes. The channel is closed once all runs have completed, or once the context is done,
+// in which case the runs still running are not sent.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.AwaitAllWorkflows]
+func AwaitAllWorkflows(ctx context.Context, runs ...WorkflowRun) <-chan WorkflowRunCompletion {
+	completions := make(chan WorkflowRunCompletion, len(runs))
+	var wg sync.WaitGroup
+	wg.Add(len(runs))
+	for i, run := range runs {
+		go func() {
+			defer wg.Done()
+			err := run.Get(ctx, nil)
+			if ctx.Err() != nil {
+				// The run may not have completed, the wait was interrupted.
+				return
+			}
+			completions <- WorkflowRunCompletion{Index: i, Run: run, Err: err}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(completions)
+	}()
+	return completions
+}
@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// awaitTestWorkflowRun is a WorkflowRun completing with err once done is closed.
+type awaitTestWorkflowRun struct {
+	WorkflowRun
+	done chan struct{}
+	err  error
+}
+
+func newAwaitTestWorkflowRun(err error) *awaitTestWorkflowRun {
+	return &awaitTestWorkflowRun{done: make(chan struct{}), err: err}
+}
+
+func (r *awaitTestWorkflowRun) Get(ctx context.Context, _ interface{}) error {
+	select {
+	case <-r.done:
+		return r.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestAwaitAnyWorkflow(t *testing.T) {
+	failure := errors.New("failed")
+	runs := []*awaitTestWorkflowRun{newAwaitTestWorkflowRun(nil), newAwaitTestWorkflowRun(failure)}
+	close(runs[1].done)
+
+	completion, err := AwaitAnyWorkflow(context.Background(), runs[0], runs[1])
+	require.NoError(t, err)
+	require.Equal(t, 1, completion.Index)
+	require.Same(t, runs[1], completion.Run)
+	require.Equal(t, failure, completion.Err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = AwaitAnyWorkflow(ctx, runs[0])
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = AwaitAnyWorkflow(context.Background())
+	require.Error(t, err)
+}
+
+func TestAwaitAnyWorkflow_Canceled(t *testing.T) {
+	runs := []*awaitTestWorkflowRun{newAwaitTestWorkflowRun(nil), newAwaitTestWorkflowRun(nil)}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var completion WorkflowRunCompletion
+	errCh := make(chan error, 1)
+	go func() {
+		var err error
+		completion, err = AwaitAnyWorkflow(ctx, runs[0], runs[1])
+		errCh <- err
+	}()
+	cancel()
+	require.ErrorIs(t, <-errCh, context.Canceled)
+	require.Nil(t, completion.Run)
+}
+
+func TestAwaitAllWorkflows(t *testing.T) {
+	failure := errors.New("failed")
+	runs := []*awaitTestWorkflowRun{
+		newAwaitTestWorkflowRun(nil),
+		newAwaitTestWorkflowRun(failure),
+		newAwaitTestWorkflowRun(nil),
+	}
+
+	completions := AwaitAllWorkflows(context.Background(), runs[0], runs[1], runs[2])
+	for _, i := range []int{2, 0, 1} {
+		close(runs[i].done)
+		completion := <-completions
+		require.Equal(t, i, completion.Index)
+		require.Same(t, runs[i], completion.Run)
+		require.Equal(t, runs[i].err, completion.Err)
+	}
+	_, ok := <-completions
+	require.False(t, ok)
+}
+
+func TestAwaitAllWorkflows_ContextDone(t *testing.T) {
+	runs := []*awaitTestWorkflowRun{newAwaitTestWorkflowRun(nil), newAwaitTestWorkflowRun(nil)}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	completions := AwaitAllWorkflows(ctx, runs[0], runs[1])
+	close(runs[0].done)
+	require.Equal(t, 0, (<-completions).Index)
+	cancel()
+	_, ok := <-completions
+	require.False(t, ok)
+}
@@ -40,6 +40,7 @@ func TestGetChildWorkflowOptions(t *testing.T) {
 		StaticSummary:     "child workflow summary",
 		StaticDetails:     "child workflow details",
 		Priority:          newPriority(),
+		DataConverter:     converter.GetDefaultDataConverter(),
 	}
 
 	// Require test options to have non-zero value for each field. This ensures that we update tests (and the
@@ -63,6 +64,14 @@ func TestGetActivityOptions(t *testing.T) {
 		VersioningIntent:       VersioningIntentDefault,
 		Summary:                "activity summary",
 		Priority:               newPriority(),
+		IdempotencyKey:         "idempotency key",
+		RetryOverrides:         newTestRetryOverrides(),
+		DataConverter:          converter.GetDefaultDataConverter(),
+		Progress: &ActivityProgressOptions{
+			SearchAttribute: "progress",
+			MemoKey:         "progress",
+			Interval:        time.Minute,
+		},
 	}
 
 	assertNonZero(t, opts)
@@ -74,6 +83,10 @@ func TestGetLocalActivityOptions(t *testing.T) {
 		ScheduleToCloseTimeout: time.Minute,
 		StartToCloseTimeout:    time.Hour,
 		RetryPolicy:            newTestRetryPolicy(),
+		LocalRetryThreshold:    time.Second,
+		RetryJitter:            constantRetryJitter(time.Millisecond),
+		RetryOverrides:         newTestRetryOverrides(),
+		DataConverter:          converter.GetDefaultDataConverter(),
 	}
 
 	assertNonZero(t, opts)
@@ -95,6 +108,45 @@ func TestConvertRetryPolicy(t *testing.T) {
 	assert.Equal(t, &pbRetryPolicy, convertToPBRetryPolicy(convertFromPBRetryPolicy(&pbRetryPolicy)))
 }
 
+type retryTestError struct{}
+
+func (retryTestError) Error() string { return "retry test error" }
+
+func TestRetryPolicyWithNonRetryableErrors(t *testing.T) {
+	policy := &RetryPolicy{MaximumAttempts: 3, NonRetryableErrorTypes: []string{"Existing"}}
+	withErrors := policy.WithNonRetryableErrors(
+		&retryTestError{},
+		NewApplicationError("invalid input", "InvalidInput", false, nil),
+		fmt.Errorf("no type"),
+		nil,
+		retryTestError{},
+	)
+	assert.Equal(t, &RetryPolicy{
+		MaximumAttempts:        3,
+		NonRetryableErrorTypes: []string{"Existing", "retryTestError", "InvalidInput"},
+	}, withErrors)
+	// The original policy is not modified
+	assert.Equal(t, []string{"Existing"}, policy.NonRetryableErrorTypes)
+	assert.False(t, IsRetryable(&retryTestError{}, withErrors.NonRetryableErrorTypes))
+
+	var nilPolicy *RetryPolicy
+	assert.Equal(t, &RetryPolicy{NonRetryableErrorTypes: []string{"InvalidInput"}},
+		nilPolicy.WithNonRetryableErrors(NewApplicationError("invalid input", "InvalidInput", false, nil)))
+}
+
+func TestWorkflowInfoAttempts(t *testing.T) {
+	info := &WorkflowInfo{Attempt: 1}
+	assert.False(t, info.IsRetry())
+	assert.False(t, info.IsLastAttempt())
+
+	info = &WorkflowInfo{Attempt: 2, RetryPolicy: &RetryPolicy{}}
+	assert.True(t, info.IsRetry())
+	assert.False(t, info.IsLastAttempt())
+
+	info.RetryPolicy.MaximumAttempts = 2
+	assert.True(t, info.IsLastAttempt())
+}
+
 func newTestWorkflowContext() Context {
 	_, ctx, err := newWorkflowContext(&workflowEnvironmentImpl{
 		dataConverter: converter.GetDefaultDataConverter(),
@@ -119,6 +171,15 @@ func newTestRetryPolicy() *RetryPolicy {
 	}
 }
 
+func newTestRetryOverrides() []RetryOverride {
+	return []RetryOverride{{
+		ErrorType:          "RateLimited",
+		InitialInterval:    1,
+		BackoffCoefficient: 2,
+		MaximumInterval:    3,
+	}}
+}
+
 func newPriority() Priority {
 	return Priority{
 		PriorityKey: 1,
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"reflect"
@@ -8,11 +9,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/nexus-rpc/sdk-go/nexus"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
+	nexuspb "go.temporal.io/api/nexus/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/sdk/converter"
@@ -58,6 +63,34 @@ type (
 		impl *testWorkflowEnvironmentImpl
 	}
 
+	// TestNexusEnvironment is the environment that you use to test Nexus operation handlers. Operations are executed
+	// directly, without a caller workflow, with their input and output going through the data converter as they would
+	// on a worker.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/testsuite.TestNexusEnvironment]
+	TestNexusEnvironment struct {
+		impl           *testWorkflowEnvironmentImpl
+		client         Client
+		header         nexus.Header
+		requestTimeout time.Duration
+	}
+
+	// TestNexusOperationResult is the result of an operation started with [TestNexusEnvironment.ExecuteOperation].
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/testsuite.TestNexusOperationResult]
+	TestNexusOperationResult struct {
+		// OperationToken is the token of the operation if it was started asynchronously, empty otherwise.
+		OperationToken string
+		// Links are the links attached to the response by the handler.
+		Links []nexus.Link
+
+		value converter.EncodedValue
+	}
+
 	// MockCallWrapper is a wrapper to mock.Call. It offers the ability to wait on workflow's clock instead of wall clock.
 	//
 	// Exposed as: [go.temporal.io/sdk/testsuite.MockCallWrapper]
@@ -69,6 +102,21 @@ type (
 		waitDuration func() time.Duration
 	}
 
+	// TestPendingTimerInfo describes a timer started by the workflow under test that has not yet fired or been
+	// canceled.
+	//
+	// Exposed as: [go.temporal.io/sdk/testsuite.TestPendingTimerInfo]
+	TestPendingTimerInfo struct {
+		// TimerID is the ID of the timer, as given to timer listeners.
+		TimerID string
+		// Summary is the summary set in the TimerOptions when the timer was started.
+		Summary string
+		// Duration is the duration the timer was started with.
+		Duration time.Duration
+		// FireTime is the workflow time at which the timer will fire.
+		FireTime time.Time
+	}
+
 	// TestUpdateCallback is a basic implementation of the UpdateCallbacks interface for testing purposes.
 	// Tests are welcome to implement their own version of this interface if they need to test more complex
 	// update logic. This is a simple implementation to make testing basic Workflow Updates easier.
@@ -136,6 +184,14 @@ func (s *WorkflowTestSuite) NewTestActivityEnvironment() *TestActivityEnvironmen
 	return t
 }
 
+// NewTestNexusEnvironment creates a new instance of TestNexusEnvironment. Use the returned TestNexusEnvironment to run
+// your Nexus operation handlers in the test environment.
+//
+// NOTE: Experimental
+func (s *WorkflowTestSuite) NewTestNexusEnvironment() *TestNexusEnvironment {
+	return &TestNexusEnvironment{impl: newTestWorkflowEnvironmentImpl(s, nil)}
+}
+
 // SetLogger sets the logger for this WorkflowTestSuite. If you don't set logger, test suite will create a default logger
 // with Debug level logging enabled.
 func (s *WorkflowTestSuite) SetLogger(logger log.Logger) {
@@ -199,6 +255,16 @@ func (t *TestActivityEnvironment) ExecuteLocalActivity(activityFn interface{}, a
 	return t.impl.executeLocalActivity(activityFn, args...)
 }
 
+// SetStartTime sets the time of the environment's clock, which is the time returned by activity.Now(ctx) in the
+// activities it executes. This is optional, the default is the wall clock time when the environment was created. The
+// clock does not advance on its own.
+//
+// NOTE: Experimental
+func (t *TestActivityEnvironment) SetStartTime(startTime time.Time) *TestActivityEnvironment {
+	t.impl.setStartTime(startTime)
+	return t
+}
+
 // SetWorkerOptions sets the WorkerOptions that will be use by TestActivityEnvironment. TestActivityEnvironment will
 // use options of BackgroundActivityContext, MaxConcurrentSessionExecutionSize, and WorkflowInterceptorChainFactories on the WorkerOptions.
 // Other options are ignored.
@@ -273,6 +339,205 @@ func (t *TestActivityEnvironment) SetOnActivityHeartbeatListener(
 	return t
 }
 
+// RegisterNexusService registers a Nexus Service with the TestNexusEnvironment.
+func (t *TestNexusEnvironment) RegisterNexusService(s *nexus.Service) {
+	t.impl.RegisterNexusService(s)
+}
+
+// RegisterWorkflow registers a workflow with the TestNexusEnvironment, so workflow run operations resolve its name as a
+// worker would. Workflows are not executed by the TestNexusEnvironment.
+func (t *TestNexusEnvironment) RegisterWorkflow(w interface{}) {
+	t.impl.RegisterWorkflow(w)
+}
+
+// RegisterWorkflowWithOptions registers a workflow with the TestNexusEnvironment. See RegisterWorkflow.
+func (t *TestNexusEnvironment) RegisterWorkflowWithOptions(w interface{}, options RegisterWorkflowOptions) {
+	t.impl.RegisterWorkflowWithOptions(w, options)
+}
+
+// SetClient sets the client returned by temporalnexus.GetClient to handlers, typically a mock such as the one from
+// go.temporal.io/sdk/mocks. Without a client, temporalnexus.GetClient returns nil.
+func (t *TestNexusEnvironment) SetClient(client Client) *TestNexusEnvironment {
+	t.client = client
+	return t
+}
+
+// SetWorkerOptions sets the WorkerOptions that will be use by TestNexusEnvironment. TestNexusEnvironment will
+// use Identity and Interceptors from the WorkerOptions.
+func (t *TestNexusEnvironment) SetWorkerOptions(options WorkerOptions) *TestNexusEnvironment {
+	t.impl.setWorkerOptions(options)
+	return t
+}
+
+// SetDataConverter sets the DataConverter used to encode operation inputs and decode operation results.
+func (t *TestNexusEnvironment) SetDataConverter(dataConverter converter.DataConverter) *TestNexusEnvironment {
+	t.impl.setDataConverter(dataConverter)
+	return t
+}
+
+// SetFailureConverter sets the FailureConverter used to convert errors returned by handlers.
+func (t *TestNexusEnvironment) SetFailureConverter(failureConverter converter.FailureConverter) *TestNexusEnvironment {
+	t.impl.setFailureConverter(failureConverter)
+	return t
+}
+
+// SetIdentity sets the identity of the worker executing the handlers.
+func (t *TestNexusEnvironment) SetIdentity(identity string) *TestNexusEnvironment {
+	t.impl.setIdentity(identity)
+	return t
+}
+
+// SetHeader sets the Nexus header sent with every request to the handlers, as a caller or the server would.
+func (t *TestNexusEnvironment) SetHeader(header nexus.Header) *TestNexusEnvironment {
+	t.header = header
+	return t
+}
+
+// SetRequestTimeout sets the request timeout of handler calls. The context given to handlers is canceled once the
+// timeout elapses, which can be used to simulate callers giving up on a request. Default is no timeout.
+func (t *TestNexusEnvironment) SetRequestTimeout(timeout time.Duration) *TestNexusEnvironment {
+	t.requestTimeout = timeout
+	return t
+}
+
+// ExecuteOperation starts a Nexus operation of a registered service with the given input. Errors returned by the
+// handler are returned as *nexus.HandlerError or *nexus.OperationError, as a caller would see them.
+func (t *TestNexusEnvironment) ExecuteOperation(
+	service string,
+	operation string,
+	input interface{},
+	options nexus.StartOperationOptions,
+) (*TestNexusOperationResult, error) {
+	payload, err := t.impl.dataConverter.ToPayload(input)
+	if err != nil {
+		return nil, err
+	}
+	links := make([]*nexuspb.Link, len(options.Links))
+	for i, link := range options.Links {
+		links[i] = &nexuspb.Link{Url: link.URL.String(), Type: link.Type}
+	}
+	requestID := options.RequestID
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	resp, err := t.executeNexusTask(options.Header, &nexuspb.Request{
+		Variant: &nexuspb.Request_StartOperation{
+			StartOperation: &nexuspb.StartOperationRequest{
+				Service:        service,
+				Operation:      operation,
+				RequestId:      requestID,
+				Callback:       options.CallbackURL,
+				CallbackHeader: options.CallbackHeader,
+				Payload:        payload,
+				Links:          links,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := resp.GetStartOperation().GetVariant().(type) {
+	case *nexuspb.StartOperationResponse_SyncSuccess:
+		result := &TestNexusOperationResult{
+			value: newEncodedValue(&commonpb.Payloads{Payloads: []*commonpb.Payload{v.SyncSuccess.GetPayload()}}, t.impl.dataConverter),
+		}
+		result.Links, err = protoLinksToNexusLinks(v.SyncSuccess.GetLinks())
+		return result, err
+	case *nexuspb.StartOperationResponse_AsyncSuccess:
+		result := &TestNexusOperationResult{OperationToken: v.AsyncSuccess.GetOperationToken()}
+		result.Links, err = protoLinksToNexusLinks(v.AsyncSuccess.GetLinks())
+		return result, err
+	case *nexuspb.StartOperationResponse_OperationError:
+		return nil, apiOperationErrorToNexusOperationError(v.OperationError)
+	default:
+		return nil, fmt.Errorf("unexpected Nexus start operation response %T", v)
+	}
+}
+
+// CancelOperation requests cancellation of an operation started asynchronously, as a caller would when the workflow
+// that started the operation is canceled.
+func (t *TestNexusEnvironment) CancelOperation(service string, operation string, token string, options nexus.CancelOperationOptions) error {
+	_, err := t.executeNexusTask(options.Header, &nexuspb.Request{
+		Variant: &nexuspb.Request_CancelOperation{
+			CancelOperation: &nexuspb.CancelOperationRequest{
+				Service:        service,
+				Operation:      operation,
+				OperationToken: token,
+			},
+		},
+	})
+	return err
+}
+
+func (t *TestNexusEnvironment) executeNexusTask(header nexus.Header, request *nexuspb.Request) (*nexuspb.Response, error) {
+	reg := nexus.NewServiceRegistry()
+	for _, service := range t.impl.registry.getRegisteredNexusServices() {
+		if err := reg.Register(service); err != nil {
+			return nil, fmt.Errorf("failed to register nexus service '%v': %w", service, err)
+		}
+	}
+	reg.Use(nexusMiddleware(t.impl.registry.interceptors))
+	handler, err := reg.NewHandler()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create nexus handler: %w", err)
+	}
+
+	// Header keys are lower cased by the server.
+	request.Header = make(map[string]string, len(t.header)+len(header)+1)
+	for k, v := range t.header {
+		request.Header[strings.ToLower(k)] = v
+	}
+	for k, v := range header {
+		request.Header[strings.ToLower(k)] = v
+	}
+	if t.requestTimeout > 0 {
+		request.Header[strings.ToLower(nexus.HeaderRequestTimeout)] = t.requestTimeout.String()
+	}
+	request.ScheduledTime = timestamppb.Now()
+
+	taskHandler := newNexusTaskHandler(
+		handler,
+		t.impl.identity,
+		t.impl.workflowInfo.Namespace,
+		t.impl.workflowInfo.TaskQueueName,
+		t.client,
+		t.impl.dataConverter,
+		t.impl.failureConverter,
+		t.impl.logger,
+		t.impl.metricsHandler,
+		t.impl.registry,
+	)
+	completed, failed, err := taskHandler.Execute(&workflowservice.PollNexusTaskQueueResponse{
+		TaskToken: []byte{},
+		Request:   request,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if failed != nil {
+		handlerErr, err := apiHandlerErrorToNexusHandlerError(failed.GetError(), t.impl.failureConverter)
+		if err != nil {
+			return nil, err
+		}
+		return nil, handlerErr
+	}
+	return completed.GetResponse(), nil
+}
+
+// IsAsync returns whether the operation was started asynchronously.
+func (r *TestNexusOperationResult) IsAsync() bool {
+	return r.value == nil
+}
+
+// Get extracts the result of an operation that completed synchronously.
+func (r *TestNexusOperationResult) Get(valuePtr interface{}) error {
+	if r.value == nil {
+		return fmt.Errorf("operation was started asynchronously with token %q", r.OperationToken)
+	}
+	return r.value.Get(valuePtr)
+}
+
 // RegisterWorkflow registers workflow implementation with the TestWorkflowEnvironment
 func (e *TestWorkflowEnvironment) RegisterWorkflow(w interface{}) {
 	e.impl.RegisterWorkflow(w)
@@ -567,7 +832,8 @@ func (e *TestWorkflowEnvironment) OnUpsertMemo(attributes interface{}) *MockCall
 // it must be *nexus.HandlerStartOperationResultSync[T] or *nexus.HandlerStartOperationResultAsync.
 // The second parameter of Return() is an error.
 // If your mock returns *nexus.HandlerStartOperationResultAsync, then you need to register the
-// completion of the async operation by calling RegisterNexusAsyncOperationCompletion.
+// completion of the async operation by calling RegisterNexusAsyncOperationCompletion, or deliver it
+// with CompleteNexusOperation.
 // Example: assume the Nexus operation input/output types are as follows:
 //
 //	type (
@@ -691,6 +957,23 @@ func (e *TestWorkflowEnvironment) RegisterNexusAsyncOperationCompletion(
 	)
 }
 
+// CompleteNexusOperation delivers the completion callback of a started async Nexus operation, identified by its
+// service, operation and token, as the server would when the handler completes it. The operation succeeds with the
+// result if err is nil, is canceled if err is a [CanceledError], and fails with err otherwise. Call it from a function
+// given to RegisterDelayedCallback to complete operations at arbitrary test times, e.g. to test all the completion
+// orderings of concurrent operations. It returns an error if there is no such started operation that is not completed.
+//
+// NOTE: Experimental
+func (e *TestWorkflowEnvironment) CompleteNexusOperation(
+	service string,
+	operation string,
+	token string,
+	result any,
+	err error,
+) error {
+	return e.impl.completeNexusOperation(service, operation, token, result, err)
+}
+
 func (e *TestWorkflowEnvironment) wrapWorkflowCall(call *mock.Call) *MockCallWrapper {
 	callWrapper := &MockCallWrapper{call: call, env: e}
 	call.Run(e.impl.getWorkflowMockRunFn(callWrapper))
@@ -1034,6 +1317,15 @@ func (e *TestWorkflowEnvironment) SetOnNexusOperationCanceledListener(
 	return e
 }
 
+// GetPendingTimers returns the timers started by the workflow that have not yet fired or been canceled, in the order
+// they were started, with the summary set in their TimerOptions. This is meant to be called from delayed callbacks or
+// listeners to check why the workflow is waiting.
+//
+// NOTE: Experimental
+func (e *TestWorkflowEnvironment) GetPendingTimers() []TestPendingTimerInfo {
+	return e.impl.getPendingTimers()
+}
+
 // IsWorkflowCompleted check if test is completed or not
 func (e *TestWorkflowEnvironment) IsWorkflowCompleted() bool {
 	return e.impl.isWorkflowCompleted
@@ -1306,3 +1598,73 @@ func (e *TestWorkflowEnvironment) AssertNexusOperationNotCalled(t mock.TestingT,
 func (e *TestWorkflowEnvironment) AssertNexusOperationNumberOfCalls(t mock.TestingT, service string, expectedCalls int) bool {
 	return e.nexusMock.AssertNumberOfCalls(t, service, expectedCalls)
 }
+
+// ReplayAssertionOptions are options for AssertReplayCompatibleWithOptions.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/testsuite.ReplayAssertionOptions]
+type ReplayAssertionOptions struct {
+	// RegisterOptions are the options the workflow is registered with, for example its name if it was not
+	// registered under its function name when the history was recorded.
+	RegisterOptions RegisterWorkflowOptions
+
+	// PayloadCodecs decode the payloads of the history, for example to replay histories exported from workflows
+	// whose payloads are encrypted. They are applied to the data converter of ReplayerOptions, or to the default
+	// data converter if it is not set, as with converter.NewCodecDataConverter.
+	PayloadCodecs []converter.PayloadCodec
+
+	// ReplayerOptions are the options of the WorkflowReplayer replaying the history.
+	ReplayerOptions WorkflowReplayerOptions
+}
+
+// AssertReplayCompatible asserts that the workflow replays the JSON history without nondeterminism errors, as
+// exported by the Temporal CLI or UI, so that known-good histories can be pinned as regression tests next to the
+// workflow code.
+//
+// NOTE: Experimental
+func AssertReplayCompatible(t mock.TestingT, workflow interface{}, historyJSON []byte) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	return AssertReplayCompatibleWithOptions(t, workflow, historyJSON, ReplayAssertionOptions{})
+}
+
+// AssertReplayCompatibleWithOptions asserts that the workflow replays the JSON history without nondeterminism
+// errors, like AssertReplayCompatible, with the options.
+//
+// NOTE: Experimental
+func AssertReplayCompatibleWithOptions(
+	t mock.TestingT,
+	workflow interface{},
+	historyJSON []byte,
+	options ReplayAssertionOptions,
+) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	history, err := HistoryFromJSON(bytes.NewReader(historyJSON), 0)
+	if err != nil {
+		t.Errorf("Failed to parse workflow history: %v", err)
+		return false
+	}
+	replayerOptions := options.ReplayerOptions
+	if len(options.PayloadCodecs) > 0 {
+		dataConverter := replayerOptions.DataConverter
+		if dataConverter == nil {
+			dataConverter = converter.GetDefaultDataConverter()
+		}
+		replayerOptions.DataConverter = converter.NewCodecDataConverter(dataConverter, options.PayloadCodecs...)
+	}
+	replayer, err := NewWorkflowReplayer(replayerOptions)
+	if err != nil {
+		t.Errorf("Failed to create workflow replayer: %v", err)
+		return false
+	}
+	replayer.RegisterWorkflowWithOptions(workflow, options.RegisterOptions)
+	if err := replayer.ReplayWorkflowHistory(nil, history); err != nil {
+		t.Errorf("Workflow is not replay compatible with the history: %v", err)
+		return false
+	}
+	return true
+}
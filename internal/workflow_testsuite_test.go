@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -41,6 +42,38 @@ func TestSetMemoOnStart(t *testing.T) {
 	require.NotNil(t, env.impl.workflowInfo.Memo)
 }
 
+// replayAssertionT records the failures of replay assertions.
+type replayAssertionT struct {
+	mock.TestingT
+	errors []string
+}
+
+func (t *replayAssertionT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertReplayCompatible(t *testing.T) {
+	history, err := os.ReadFile("testdata/sampleHistory.json")
+	require.NoError(t, err)
+
+	require.True(t, AssertReplayCompatible(t, testReplayWorkflowFromFile, history))
+
+	changedWorkflow := func(ctx Context) error {
+		return Sleep(ctx, time.Minute)
+	}
+	var recorder replayAssertionT
+	require.False(t, AssertReplayCompatibleWithOptions(&recorder, changedWorkflow, history, ReplayAssertionOptions{
+		RegisterOptions: RegisterWorkflowOptions{Name: "testReplayWorkflowFromFile"},
+	}))
+	require.Len(t, recorder.errors, 1)
+	require.Contains(t, recorder.errors[0], "TMPRL1100")
+
+	recorder = replayAssertionT{}
+	require.False(t, AssertReplayCompatible(&recorder, testReplayWorkflowFromFile, []byte("{")))
+	require.Len(t, recorder.errors, 1)
+	require.Contains(t, recorder.errors[0], "Failed to parse workflow history")
+}
+
 func TestSetSearchAttributesOnStart(t *testing.T) {
 	t.Parallel()
 	testSuite := &WorkflowTestSuite{}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+
+	commonpb "go.temporal.io/api/common/v1"
+
+	"go.temporal.io/sdk/converter"
+)
+
+type (
+	// VersionedState is a workflow state tagged with the version of its schema, to be passed as input to
+	// continue-as-new. Create it with NewVersionedState and read it with DecodeVersionedState, which migrates states
+	// of older versions. The zero value means there is no state, such as on the first run of a workflow.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/workflow.VersionedState]
+	VersionedState struct {
+		// Version of the schema of the state.
+		Version int `json:"version"`
+		// Payload is the state encoded with the data converter of the workflow.
+		Payload *commonpb.Payload `json:"payload,omitempty"`
+	}
+
+	// StateMigrations are functions migrating a VersionedState to the next version, keyed by the version they
+	// migrate from. A migration receives the encoded state of its version and returns the state of the next version.
+	// Migrations run as workflow code, so they must be deterministic.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/workflow.StateMigrations]
+	StateMigrations map[int]func(ctx Context, state converter.EncodedValue) (interface{}, error)
+)
+
+// NewVersionedState encodes the state with the data converter of the workflow and tags it with the version.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/workflow.NewVersionedState]
+func NewVersionedState(ctx Context, version int, state interface{}) (VersionedState, error) {
+	payload, err := getDataConverterFromWorkflowContext(ctx).ToPayload(state)
+	if err != nil {
+		return VersionedState{}, fmt.Errorf("unable to encode state of version %d: %w", version, err)
+	}
+	return VersionedState{Version: version, Payload: payload}, nil
+}
+
+// DecodeVersionedState migrates the state to currentVersion by applying the migrations from its version, then decodes
+// it into valuePtr. A zero VersionedState leaves valuePtr unchanged. An error is returned if the state is newer than
+// currentVersion or a migration is missing.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/workflow.DecodeVersionedState]
+func DecodeVersionedState(
+	ctx Context,
+	state VersionedState,
+	currentVersion int,
+	migrations StateMigrations,
+	valuePtr interface{},
+) error {
+	if valuePtr == nil {
+		return errors.New("valuePtr must not be nil")
+	}
+	if state.Payload == nil {
+		return nil
+	}
+	if state.Version > currentVersion {
+		return fmt.Errorf("state version %d is newer than the current version %d", state.Version, currentVersion)
+	}
+	dc := getDataConverterFromWorkflowContext(ctx)
+	payload := state.Payload
+	for version := state.Version; version < currentVersion; version++ {
+		migrate := migrations[version]
+		if migrate == nil {
+			return fmt.Errorf("no migration from state version %d", version)
+		}
+		migrated, err := migrate(ctx, newEncodedValue(&commonpb.Payloads{Payloads: []*commonpb.Payload{payload}}, dc))
+		if err != nil {
+			return fmt.Errorf("unable to migrate state from version %d: %w", version, err)
+		}
+		if payload, err = dc.ToPayload(migrated); err != nil {
+			return fmt.Errorf("unable to encode state of version %d: %w", version+1, err)
+		}
+	}
+	return dc.FromPayload(payload, valuePtr)
+}
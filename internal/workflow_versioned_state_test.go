@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/sdk/converter"
+)
+
+type versionedStateV1 struct{ Count int }
+
+type versionedStateV2 struct{ Processed int }
+
+func TestDecodeVersionedState(t *testing.T) {
+	migrations := StateMigrations{
+		1: func(ctx Context, encoded converter.EncodedValue) (interface{}, error) {
+			var old versionedStateV1
+			err := encoded.Get(&old)
+			return versionedStateV2{Processed: old.Count}, err
+		},
+	}
+	wf := func(ctx Context, input VersionedState) (versionedStateV2, error) {
+		state := versionedStateV2{Processed: -1}
+		err := DecodeVersionedState(ctx, input, 2, migrations, &state)
+		return state, err
+	}
+	versionedState := func(version int, state interface{}) VersionedState {
+		payload, err := converter.GetDefaultDataConverter().ToPayload(state)
+		require.NoError(t, err)
+		return VersionedState{Version: version, Payload: payload}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		input    VersionedState
+		expected versionedStateV2
+		err      string
+	}{
+		{"no state", VersionedState{}, versionedStateV2{Processed: -1}, ""},
+		{"current version", versionedState(2, versionedStateV2{Processed: 3}), versionedStateV2{Processed: 3}, ""},
+		{"migrated", versionedState(1, versionedStateV1{Count: 5}), versionedStateV2{Processed: 5}, ""},
+		{"newer version", versionedState(3, versionedStateV2{}), versionedStateV2{}, "state version 3 is newer"},
+		{"missing migration", versionedState(0, versionedStateV1{}), versionedStateV2{}, "no migration from state version 0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var suite WorkflowTestSuite
+			env := suite.NewTestWorkflowEnvironment()
+			env.RegisterWorkflow(wf)
+			env.ExecuteWorkflow(wf, tc.input)
+			require.True(t, env.IsWorkflowCompleted())
+			if tc.err != "" {
+				require.ErrorContains(t, env.GetWorkflowError(), tc.err)
+				return
+			}
+			require.NoError(t, env.GetWorkflowError())
+			var state versionedStateV2
+			require.NoError(t, env.GetWorkflowResult(&state))
+			require.Equal(t, tc.expected, state)
+		})
+	}
+}
+
+func TestVersionedStateContinueAsNew(t *testing.T) {
+	wf := func(ctx Context, input VersionedState) error {
+		var state versionedStateV2
+		if err := DecodeVersionedState(ctx, input, 2, nil, &state); err != nil {
+			return err
+		}
+		state.Processed++
+		next, err := NewVersionedState(ctx, 2, state)
+		if err != nil {
+			return err
+		}
+		return NewContinueAsNewError(ctx, "wf", next)
+	}
+	var suite WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterWorkflowWithOptions(wf, RegisterWorkflowOptions{Name: "wf"})
+	env.ExecuteWorkflow("wf", VersionedState{})
+
+	var canErr *ContinueAsNewError
+	require.True(t, errors.As(env.GetWorkflowError(), &canErr))
+	var next VersionedState
+	require.NoError(t, converter.GetDefaultDataConverter().FromPayloads(canErr.Input, &next))
+	require.Equal(t, 2, next.Version)
+	var state versionedStateV2
+	require.NoError(t, converter.GetDefaultDataConverter().FromPayload(next.Payload, &state))
+	require.Equal(t, versionedStateV2{Processed: 1}, state)
+}
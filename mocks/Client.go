@@ -625,6 +625,26 @@ func (_m *Client) NewWithStartWorkflowOperation(options client.StartWorkflowOpti
 	return r0
 }
 
+// NexusEndpointClient provides a mock function with given fields:
+func (_m *Client) NexusEndpointClient() client.NexusEndpointClient {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NexusEndpointClient")
+	}
+
+	var r0 client.NexusEndpointClient
+	if rf, ok := ret.Get(0).(func() client.NexusEndpointClient); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(client.NexusEndpointClient)
+		}
+	}
+
+	return r0
+}
+
 // OperatorService provides a mock function with given fields:
 func (_m *Client) OperatorService() operatorservice.OperatorServiceClient {
 	ret := _m.Called()
@@ -750,6 +770,34 @@ func (_m *Client) RecordActivityHeartbeatByID(ctx context.Context, namespace str
 	return r0
 }
 
+// ResetWorkflow provides a mock function with given fields: ctx, options
+func (_m *Client) ResetWorkflow(ctx context.Context, options client.ResetWorkflowOptions) (client.ResetWorkflowResult, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetWorkflow")
+	}
+
+	var r0 client.ResetWorkflowResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.ResetWorkflowOptions) (client.ResetWorkflowResult, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.ResetWorkflowOptions) client.ResetWorkflowResult); ok {
+		r0 = rf(ctx, options)
+	} else {
+		r0 = ret.Get(0).(client.ResetWorkflowResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.ResetWorkflowOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResetWorkflowExecution provides a mock function with given fields: ctx, request
 func (_m *Client) ResetWorkflowExecution(ctx context.Context, request *workflowservice.ResetWorkflowExecutionRequest) (*workflowservice.ResetWorkflowExecutionResponse, error) {
 	ret := _m.Called(ctx, request)
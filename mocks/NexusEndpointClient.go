@@ -0,0 +1,172 @@
+// Code generated by mockery v1.0.0.
+// Modified manually for type alias to work correctly.
+// https://github.com/vektra/mockery/issues/236
+
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	"go.temporal.io/sdk/client"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// NexusEndpointClient is an autogenerated mock type for the NexusEndpointClient type
+type NexusEndpointClient struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, options
+func (_m *NexusEndpointClient) Create(ctx context.Context, options client.NexusEndpointCreateOptions) (*client.NexusEndpoint, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *client.NexusEndpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.NexusEndpointCreateOptions) (*client.NexusEndpoint, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.NexusEndpointCreateOptions) *client.NexusEndpoint); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.NexusEndpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.NexusEndpointCreateOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, options
+func (_m *NexusEndpointClient) Delete(ctx context.Context, options client.NexusEndpointDeleteOptions) error {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.NexusEndpointDeleteOptions) error); ok {
+		r0 = rf(ctx, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *NexusEndpointClient) Get(ctx context.Context, id string) (*client.NexusEndpoint, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *client.NexusEndpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*client.NexusEndpoint, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *client.NexusEndpoint); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.NexusEndpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, options
+func (_m *NexusEndpointClient) List(ctx context.Context, options client.NexusEndpointListOptions) (client.NexusEndpointListIterator, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 client.NexusEndpointListIterator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.NexusEndpointListOptions) (client.NexusEndpointListIterator, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.NexusEndpointListOptions) client.NexusEndpointListIterator); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(client.NexusEndpointListIterator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.NexusEndpointListOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, options
+func (_m *NexusEndpointClient) Update(ctx context.Context, options client.NexusEndpointUpdateOptions) (*client.NexusEndpoint, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *client.NexusEndpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.NexusEndpointUpdateOptions) (*client.NexusEndpoint, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.NexusEndpointUpdateOptions) *client.NexusEndpoint); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.NexusEndpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.NexusEndpointUpdateOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewNexusEndpointClient creates a new instance of NexusEndpointClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNexusEndpointClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NexusEndpointClient {
+	mock := &NexusEndpointClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
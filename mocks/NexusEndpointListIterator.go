@@ -0,0 +1,80 @@
+// Code generated by mockery v1.0.0.
+// Modified manually for type alias to work correctly.
+// https://github.com/vektra/mockery/issues/236
+
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	"go.temporal.io/sdk/client"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// NexusEndpointListIterator is an autogenerated mock type for the NexusEndpointListIterator type
+type NexusEndpointListIterator struct {
+	mock.Mock
+}
+
+// HasNext provides a mock function with given fields:
+func (_m *NexusEndpointListIterator) HasNext() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasNext")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Next provides a mock function with given fields:
+func (_m *NexusEndpointListIterator) Next() (*client.NexusEndpoint, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Next")
+	}
+
+	var r0 *client.NexusEndpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*client.NexusEndpoint, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *client.NexusEndpoint); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.NexusEndpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewNexusEndpointListIterator creates a new instance of NexusEndpointListIterator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNexusEndpointListIterator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NexusEndpointListIterator {
+	mock := &NexusEndpointListIterator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
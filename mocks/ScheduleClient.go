@@ -97,6 +97,66 @@ func (_m *ScheduleClient) List(ctx context.Context, options client.ScheduleListO
 	return r0, r1
 }
 
+// PauseMany provides a mock function with given fields: ctx, options
+func (_m *ScheduleClient) PauseMany(ctx context.Context, options client.SchedulePauseManyOptions) (*client.ScheduleBulkResult, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseMany")
+	}
+
+	var r0 *client.ScheduleBulkResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.SchedulePauseManyOptions) (*client.ScheduleBulkResult, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.SchedulePauseManyOptions) *client.ScheduleBulkResult); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.ScheduleBulkResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.SchedulePauseManyOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnpauseMany provides a mock function with given fields: ctx, options
+func (_m *ScheduleClient) UnpauseMany(ctx context.Context, options client.ScheduleUnpauseManyOptions) (*client.ScheduleBulkResult, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnpauseMany")
+	}
+
+	var r0 *client.ScheduleBulkResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.ScheduleUnpauseManyOptions) (*client.ScheduleBulkResult, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.ScheduleUnpauseManyOptions) *client.ScheduleBulkResult); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.ScheduleBulkResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.ScheduleUnpauseManyOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewScheduleClient creates a new instance of ScheduleClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewScheduleClient(t interface {
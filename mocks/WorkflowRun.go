@@ -71,6 +71,42 @@ func (_m *WorkflowRun) GetRunID() string {
 	return r0
 }
 
+// GetRequestID provides a mock function with given fields:
+func (_m *WorkflowRun) GetRequestID() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRequestID")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetStarted provides a mock function with given fields:
+func (_m *WorkflowRun) GetStarted() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStarted")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // GetWithOptions provides a mock function with given fields: ctx, valuePtr, options
 func (_m *WorkflowRun) GetWithOptions(ctx context.Context, valuePtr interface{}, options client.WorkflowRunGetOptions) error {
 	ret := _m.Called(ctx, valuePtr, options)
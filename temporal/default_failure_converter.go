@@ -10,6 +10,7 @@ type (
 	DefaultFailureConverterOptions = internal.DefaultFailureConverterOptions
 
 	// DefaultFailureConverter seralizes errors with the option to encode common parameters under Failure.EncodedAttributes.
+	// Errors created with errors.Join keep all the wrapped errors.
 	DefaultFailureConverter = internal.DefaultFailureConverter
 )
 
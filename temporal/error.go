@@ -135,6 +135,25 @@ type (
 	// UnknownExternalWorkflowExecutionError can be returned when external workflow doesn't exist
 	UnknownExternalWorkflowExecutionError = internal.UnknownExternalWorkflowExecutionError
 
+	// SignalCountLimitExceededError is returned when signaling an external workflow fails because the target
+	// workflow has already received the maximum number of signals allowed by the server.
+	SignalCountLimitExceededError = internal.SignalCountLimitExceededError
+
+	// ExternalWorkflowNotFoundError is returned by the futures of a workflow.ExternalWorkflowHandle when the target
+	// execution does not exist, if the handle was created with workflow.ExternalWorkflowHandleOptions.DescribeUnknownTarget.
+	// It unwraps to UnknownExternalWorkflowExecutionError.
+	//
+	// NOTE: Experimental
+	ExternalWorkflowNotFoundError = internal.ExternalWorkflowNotFoundError
+
+	// ExternalWorkflowCompletedError is returned by the futures of a workflow.ExternalWorkflowHandle when the target
+	// execution has already completed, if the handle was created with
+	// workflow.ExternalWorkflowHandleOptions.DescribeUnknownTarget. It unwraps to
+	// UnknownExternalWorkflowExecutionError.
+	//
+	// NOTE: Experimental
+	ExternalWorkflowCompletedError = internal.ExternalWorkflowCompletedError
+
 	// QueryRejectedError is a possible error that can be returned by
 	// ClientOutboundInterceptor.QueryWorkflow to indicate that the query was rejected by the server.
 	QueryRejectedError = internal.QueryRejectedError
@@ -157,11 +176,32 @@ type ApplicationErrorOptions = internal.ApplicationErrorOptions
 
 // NewApplicationErrorWithOptions creates new instance of *ApplicationError type, all the options of the
 // newly created error could be controlled through instance of ApplicationErrorOptions.
-// The options structure also receives some extra requests. See activity.ApplicationErrorOptions for details.
+// The options structure also receives some extra requests. See ApplicationErrorOptions for details.
+//
+// Activities can set ApplicationErrorOptions.NextRetryDelay to choose when the server retries them:
+//
+//	return temporal.NewApplicationErrorWithOptions("rate limited", "RateLimited", temporal.ApplicationErrorOptions{
+//		NextRetryDelay: retryAfter,
+//	})
 func NewApplicationErrorWithOptions(msg, errType string, options ApplicationErrorOptions) error {
 	return internal.NewApplicationErrorWithOptions(msg, errType, options)
 }
 
+// DetailsInto decodes the detail value at the index, starting from 0, of the error into T, leaving the other detail
+// values encoded. Use ApplicationError.NumDetails to find how many detail values the error has:
+//
+//	var applicationErr *temporal.ApplicationError
+//	if errors.As(err, &applicationErr) && applicationErr.NumDetails() > 0 {
+//		reason, err := temporal.DetailsInto[string](applicationErr, 0)
+//	}
+//
+// If the error has no detail value at the index, it returns ErrNoData.
+//
+// NOTE: Experimental
+func DetailsInto[T any](err *ApplicationError, index int) (T, error) {
+	return internal.DetailsInto[T](err, index)
+}
+
 // NewApplicationError creates new instance of retryable *ApplicationError with message, type, and optional details.
 // Use ApplicationError for any use case specific errors that cross activity and child workflow boundaries.
 // errType can be used to control if error is retryable or not. Add the same type in to RetryPolicy.NonRetryableErrorTypes
@@ -260,3 +300,10 @@ const (
 	// ApplicationErrorCategoryBenign indicates an error that is expected under normal operation and should not trigger alerts.
 	ApplicationErrorCategoryBenign = internal.ApplicationErrorCategoryBenign
 )
+
+// LocalActivityStillRunningErrorType is the type of the retryable application errors set as the cause of the timeout
+// errors of local activities that had not returned when their deadline passed, see
+// [go.temporal.io/sdk/worker.Options.LocalActivityWatchdogGracePeriod].
+//
+// NOTE: Experimental
+const LocalActivityStillRunningErrorType = internal.LocalActivityStillRunningErrorType
@@ -4,3 +4,42 @@ import "go.temporal.io/sdk/internal"
 
 // RetryPolicy defines the retry policy for activity/workflow.
 type RetryPolicy = internal.RetryPolicy
+
+// RetryOverride replaces the backoff parameters of the retry policy of an activity when an attempt fails with an error
+// of a given type, for instance to wait longer after errors reporting a rate limit. The maximum number of attempts and
+// non-retryable error types of the retry policy still apply. See
+// [go.temporal.io/sdk/workflow.ActivityOptions].RetryOverrides.
+//
+// NOTE: Experimental
+type RetryOverride = internal.RetryOverride
+
+// RetryJitterInput is the input of [RetryJitter].Apply.
+//
+// NOTE: Experimental
+type RetryJitterInput = internal.RetryJitterInput
+
+// RetryJitter randomizes the delay before a retry performed by the SDK, so that callers failing at the same time do
+// not retry at the same time. It can be set on [go.temporal.io/sdk/workflow.LocalActivityOptions].RetryJitter for
+// local activities, and on [go.temporal.io/sdk/client.Options].RetryJitter for calls to the server. Implementations
+// must be safe for concurrent use.
+//
+// NOTE: Experimental
+type RetryJitter = internal.RetryJitter
+
+var (
+	// FullRetryJitter picks a delay between zero and the backoff.
+	//
+	// NOTE: Experimental
+	FullRetryJitter = internal.FullRetryJitter
+
+	// EqualRetryJitter picks a delay between half the backoff and the backoff.
+	//
+	// NOTE: Experimental
+	EqualRetryJitter = internal.EqualRetryJitter
+
+	// DecorrelatedRetryJitter picks a delay between the initial interval and three times the backoff of the previous
+	// attempt, capped by the maximum interval.
+	//
+	// NOTE: Experimental
+	DecorrelatedRetryJitter = internal.DecorrelatedRetryJitter
+)
@@ -19,6 +19,7 @@ package temporalnexus
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/nexus-rpc/sdk-go/nexus"
@@ -47,6 +48,32 @@ func GetClient(ctx context.Context) client.Client {
 	return internal.GetNexusOperationClient(ctx)
 }
 
+// WorkflowRunOperationCancellationType specifies what a workflow run operation does with its backing workflow when
+// the operation is canceled.
+//
+// NOTE: Experimental
+type WorkflowRunOperationCancellationType int
+
+const (
+	// WorkflowRunOperationCancellationTypeUnspecified - Cancellation type is not set, defaults to
+	// WorkflowRunOperationCancellationTypeTryCancel.
+	WorkflowRunOperationCancellationTypeUnspecified WorkflowRunOperationCancellationType = iota
+
+	// WorkflowRunOperationCancellationTypeAbandon - Do not cancel the backing workflow, leaving it running. The
+	// cancel request is acknowledged immediately.
+	WorkflowRunOperationCancellationTypeAbandon
+
+	// WorkflowRunOperationCancellationTypeTryCancel - Request cancellation of the backing workflow and acknowledge the
+	// cancel request once the server accepted it. The workflow may ignore the request or take time to complete.
+	WorkflowRunOperationCancellationTypeTryCancel
+
+	// WorkflowRunOperationCancellationTypeWaitCancellationCompleted - Request cancellation of the backing workflow
+	// and only acknowledge the cancel request once the workflow closed. If the workflow takes longer to close than the
+	// cancel request is allowed to take, the request fails and is retried by the caller.
+	// Not supported in the test environment.
+	WorkflowRunOperationCancellationTypeWaitCancellationCompleted
+)
+
 // WorkflowRunOperationOptions are options for [NewWorkflowRunOperationWithOptions].
 type WorkflowRunOperationOptions[I, O any] struct {
 	// Operation name.
@@ -68,6 +95,11 @@ type WorkflowRunOperationOptions[I, O any] struct {
 	// Handler for starting a workflow with a different input than the operation. Mutually exclusive with Workflow
 	// and GetOptions.
 	Handler func(context.Context, I, nexus.StartOperationOptions) (WorkflowHandle[O], error)
+	// CancellationType determines what happens to the backing workflow when the operation is canceled.
+	// Optional: defaults to WorkflowRunOperationCancellationTypeTryCancel.
+	//
+	// NOTE: Experimental
+	CancellationType WorkflowRunOperationCancellationType
 }
 
 // NOTE: not implementing GetInfo and GetResult just yet, they're not part of the supported methods in Temporal.
@@ -113,6 +145,10 @@ func NewWorkflowRunOperationWithOptions[I, O any](options WorkflowRunOperationOp
 	if options.Handler != nil && options.Workflow != nil || options.Handler == nil && options.Workflow == nil {
 		return nil, errors.New("invalid options: Workflow is mutually exclusive with Handler")
 	}
+	if options.CancellationType < WorkflowRunOperationCancellationTypeUnspecified ||
+		options.CancellationType > WorkflowRunOperationCancellationTypeWaitCancellationCompleted {
+		return nil, fmt.Errorf("invalid options: unknown CancellationType %d", options.CancellationType)
+	}
 	return &workflowRunOperation[I, O]{
 		options: options,
 	}, nil
@@ -128,7 +164,7 @@ func MustNewWorkflowRunOperationWithOptions[I, O any](options WorkflowRunOperati
 	return op
 }
 
-func (*workflowRunOperation[I, O]) Cancel(ctx context.Context, token string, options nexus.CancelOperationOptions) error {
+func (o *workflowRunOperation[I, O]) Cancel(ctx context.Context, token string, options nexus.CancelOperationOptions) error {
 	// Prevent the test env client from panicking when we try to use it from a workflow run operation.
 	ctx = context.WithValue(ctx, internal.IsWorkflowRunOpContextKey, true)
 
@@ -143,7 +179,25 @@ func (*workflowRunOperation[I, O]) Cancel(ctx context.Context, token string, opt
 		workflowID = workflowRunToken.WorkflowID
 	}
 
-	return GetClient(ctx).CancelWorkflow(ctx, workflowID, "")
+	switch o.options.CancellationType {
+	case WorkflowRunOperationCancellationTypeAbandon:
+		return nil
+	case WorkflowRunOperationCancellationTypeWaitCancellationCompleted:
+		c := GetClient(ctx)
+		if err := c.CancelWorkflow(ctx, workflowID, ""); err != nil {
+			return err
+		}
+		// Long poll for the close event, the workflow result is irrelevant to the cancel request.
+		iter := c.GetWorkflowHistory(ctx, workflowID, "", true, enums.HISTORY_EVENT_FILTER_TYPE_CLOSE_EVENT)
+		for iter.HasNext() {
+			if _, err := iter.Next(); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return GetClient(ctx).CancelWorkflow(ctx, workflowID, "")
+	}
 }
 
 func (o *workflowRunOperation[I, O]) Name() string {
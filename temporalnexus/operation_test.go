@@ -3,11 +3,15 @@ package temporalnexus_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/nexus-rpc/sdk-go/nexus"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
 	"go.temporal.io/sdk/temporalnexus"
+	"go.temporal.io/sdk/testsuite"
 	"go.temporal.io/sdk/workflow"
 )
 
@@ -74,3 +78,82 @@ func TestNewWorkflowRunOperationWithOptions(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestNewWorkflowRunOperationWithOptions_CancellationType(t *testing.T) {
+	options := temporalnexus.WorkflowRunOperationOptions[string, string]{
+		Name: "test",
+		Handler: func(ctx context.Context, s string, soo nexus.StartOperationOptions) (temporalnexus.WorkflowHandle[string], error) {
+			return nil, nil
+		},
+		CancellationType: temporalnexus.WorkflowRunOperationCancellationTypeWaitCancellationCompleted,
+	}
+	_, err := temporalnexus.NewWorkflowRunOperationWithOptions(options)
+	require.NoError(t, err)
+
+	options.CancellationType = temporalnexus.WorkflowRunOperationCancellationTypeWaitCancellationCompleted + 1
+	_, err = temporalnexus.NewWorkflowRunOperationWithOptions(options)
+	require.ErrorContains(t, err, "unknown CancellationType")
+}
+
+func TestNexusEnvironment(t *testing.T) {
+	syncOp := nexus.NewSyncOperation("sync", func(ctx context.Context, input string, options nexus.StartOperationOptions) (string, error) {
+		if input == "fail" {
+			return "", nexus.HandlerErrorf(nexus.HandlerErrorTypeBadRequest, "bad input")
+		}
+		return input + " " + options.Header.Get("suffix"), nil
+	})
+	blockingOp := nexus.NewSyncOperation("blocking", func(ctx context.Context, _ string, _ nexus.StartOperationOptions) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	handlerWorkflow := func(workflow.Context, string) (string, error) { return "", nil }
+	workflowOp := temporalnexus.MustNewWorkflowRunOperationWithOptions(temporalnexus.WorkflowRunOperationOptions[string, string]{
+		Name:     "workflow",
+		Workflow: handlerWorkflow,
+		GetOptions: func(ctx context.Context, input string, _ nexus.StartOperationOptions) (client.StartWorkflowOptions, error) {
+			return client.StartWorkflowOptions{ID: input}, nil
+		},
+	})
+	service := nexus.NewService("test")
+	require.NoError(t, service.Register(syncOp, blockingOp, workflowOp))
+
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestNexusEnvironment()
+	env.RegisterNexusService(service)
+	env.SetHeader(nexus.Header{"suffix": "world"})
+
+	result, err := env.ExecuteOperation("test", "sync", "hello", nexus.StartOperationOptions{})
+	require.NoError(t, err)
+	require.False(t, result.IsAsync())
+	var output string
+	require.NoError(t, result.Get(&output))
+	require.Equal(t, "hello world", output)
+
+	_, err = env.ExecuteOperation("test", "sync", "fail", nexus.StartOperationOptions{})
+	var handlerErr *nexus.HandlerError
+	require.ErrorAs(t, err, &handlerErr)
+	require.Equal(t, nexus.HandlerErrorTypeBadRequest, handlerErr.Type)
+
+	mockClient := &mocks.Client{}
+	mockRun := &mocks.WorkflowRun{}
+	mockRun.On("GetID").Return("workflow-id")
+	mockRun.On("GetRunID").Return("run-id")
+	mockClient.On("ExecuteWorkflow", mock.Anything, mock.Anything, "handlerWorkflow", "workflow-id").Return(mockRun, nil).Once()
+	mockClient.On("CancelWorkflow", mock.Anything, "workflow-id", "").Return(nil).Once()
+	env.SetClient(mockClient)
+	env.RegisterWorkflowWithOptions(handlerWorkflow, workflow.RegisterOptions{Name: "handlerWorkflow"})
+
+	result, err = env.ExecuteOperation("test", "workflow", "workflow-id", nexus.StartOperationOptions{
+		CallbackURL: "http://localhost/callback",
+	})
+	require.NoError(t, err)
+	require.True(t, result.IsAsync())
+	require.NotEmpty(t, result.OperationToken)
+	require.Len(t, result.Links, 1)
+	require.NoError(t, env.CancelOperation("test", "workflow", result.OperationToken, nexus.CancelOperationOptions{}))
+	mockClient.AssertExpectations(t)
+
+	env.SetRequestTimeout(10 * time.Millisecond)
+	_, err = env.ExecuteOperation("test", "blocking", "", nexus.StartOperationOptions{})
+	require.Error(t, err)
+}
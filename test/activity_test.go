@@ -365,6 +365,7 @@ func (a *Activities) InterceptorCalls(ctx context.Context, someVal string) (stri
 	activity.HasHeartbeatDetails(ctx)
 	_ = activity.GetHeartbeatDetails(ctx)
 	activity.GetWorkerStopChannel(ctx)
+	activity.GetWorkerStopInfo(ctx)
 	return someVal, nil
 }
 
@@ -2777,6 +2777,7 @@ func (ts *IntegrationTestSuite) TestInterceptorCalls() {
 		"ActivityOutboundInterceptor.HasHeartbeatDetails":  {},
 		"ActivityOutboundInterceptor.GetHeartbeatDetails":  {},
 		"ActivityOutboundInterceptor.GetWorkerStopChannel": {},
+		"ActivityOutboundInterceptor.GetWorkerStopInfo":    {},
 	}
 
 	// Do call checks
@@ -7510,7 +7511,15 @@ func (ts *IntegrationTestSuite) TestRawValueQueryMetadata() {
 	err = converter.GetDefaultDataConverter().FromPayload(rawValue.Payload(), &metadata)
 	ts.NoError(err)
 	ts.Equal("Basic", metadata.Definition.Type)
-	ts.Equal(3, len(metadata.Definition.QueryDefinitions))
+	var queryNames []string
+	for _, query := range metadata.Definition.QueryDefinitions {
+		queryNames = append(queryNames, query.Name)
+	}
+	ts.Contains(queryNames, client.QueryTypeStackTrace)
+	ts.Contains(queryNames, client.QueryTypeStackTraceJSON)
+	ts.Contains(queryNames, client.QueryTypeOpenSessions)
+	ts.Contains(queryNames, "__temporal_workflow_metadata")
+	ts.Contains(queryNames, client.QueryTypeHandlers)
 }
 
 func (ts *IntegrationTestSuite) TestWorkflowTaskFailureMetric_BenignHandling() {
@@ -1265,6 +1265,104 @@ func TestAsyncOperationFromWorkflow_CancellationTypes(t *testing.T) {
 	})
 }
 
+func TestWorkflowRunOperation_HandlerCancellationTypes(t *testing.T) {
+	if os.Getenv("DISABLE_SERVER_1_27_TESTS") == "1" {
+		t.Skip()
+	}
+
+	run := func(ctx context.Context, tc *testContext, t *testing.T, cancellationType temporalnexus.WorkflowRunOperationCancellationType) (client.WorkflowRun, string) {
+		handlerWf := func(ctx workflow.Context, _ string) (string, error) {
+			err := workflow.Await(ctx, func() bool { return false })
+			// Delay completion after receiving cancellation so the caller has to wait for it.
+			disconCtx, _ := workflow.NewDisconnectedContext(ctx)
+			_ = workflow.Sleep(disconCtx, time.Second)
+			return "", err
+		}
+		handlerID := atomic.Value{}
+		op := temporalnexus.MustNewWorkflowRunOperationWithOptions(temporalnexus.WorkflowRunOperationOptions[string, string]{
+			Name:     "workflow-op",
+			Workflow: handlerWf,
+			GetOptions: func(ctx context.Context, _ string, soo nexus.StartOperationOptions) (client.StartWorkflowOptions, error) {
+				handlerID.Store(soo.RequestID)
+				return client.StartWorkflowOptions{ID: soo.RequestID}, nil
+			},
+			CancellationType: cancellationType,
+		})
+		callerWf := func(ctx workflow.Context) error {
+			c := workflow.NewNexusClient(tc.endpoint, "test")
+			fut := c.ExecuteOperation(ctx, op, "", workflow.NexusOperationOptions{
+				CancellationType: workflow.NexusOperationCancellationTypeWaitRequested,
+			})
+			if err := fut.GetNexusOperationExecution().Get(ctx, nil); err != nil {
+				return err
+			}
+			return fut.Get(ctx, nil)
+		}
+
+		w := worker.New(tc.client, tc.taskQueue, worker.Options{})
+		service := nexus.NewService("test")
+		require.NoError(t, service.Register(op))
+		w.RegisterNexusService(service)
+		w.RegisterWorkflow(handlerWf)
+		w.RegisterWorkflow(callerWf)
+		require.NoError(t, w.Start())
+		t.Cleanup(w.Stop)
+
+		callerRun, err := tc.client.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+			TaskQueue: tc.taskQueue,
+		}, callerWf)
+		require.NoError(t, err)
+		require.Eventuallyf(t, func() bool {
+			id := handlerID.Load()
+			if id == nil {
+				return false
+			}
+			_, descErr := tc.client.DescribeWorkflow(ctx, id.(string), "")
+			return descErr == nil
+		}, 2*time.Second, 20*time.Millisecond, "timed out waiting for handler wf to start")
+		require.NoError(t, tc.client.CancelWorkflow(ctx, callerRun.GetID(), callerRun.GetRunID()))
+		var canceledErr *temporal.CanceledError
+		require.ErrorAs(t, callerRun.Get(ctx, nil), &canceledErr)
+		return callerRun, handlerID.Load().(string)
+	}
+
+	t.Run("Abandon", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultNexusTestTimeout)
+		defer cancel()
+		tc := newTestContext(t, ctx)
+		_, handlerID := run(ctx, tc, t, temporalnexus.WorkflowRunOperationCancellationTypeAbandon)
+
+		handlerDesc, err := tc.client.DescribeWorkflowExecution(ctx, handlerID, "")
+		require.NoError(t, err)
+		require.Equal(t, enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING, handlerDesc.WorkflowExecutionInfo.Status)
+		require.NoError(t, tc.client.TerminateWorkflow(ctx, handlerID, "", "test"))
+	})
+
+	t.Run("WaitCancellationCompleted", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultNexusTestTimeout)
+		defer cancel()
+		tc := newTestContext(t, ctx)
+		callerRun, handlerID := run(ctx, tc, t, temporalnexus.WorkflowRunOperationCancellationTypeWaitCancellationCompleted)
+
+		// Verify the cancel request only completed after the handler workflow closed.
+		handlerHist := tc.client.GetWorkflowHistory(ctx, handlerID, "", false, enumspb.HISTORY_EVENT_FILTER_TYPE_CLOSE_EVENT)
+		handlerCloseEvent, err := handlerHist.Next()
+		require.NoError(t, err)
+		require.Equal(t, enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED, handlerCloseEvent.EventType)
+		callerHist := tc.client.GetWorkflowHistory(ctx, callerRun.GetID(), callerRun.GetRunID(), false, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+		foundRequestCompleted := false
+		for callerHist.HasNext() {
+			event, err := callerHist.Next()
+			require.NoError(t, err)
+			if event.EventType == enumspb.EVENT_TYPE_NEXUS_OPERATION_CANCEL_REQUEST_COMPLETED {
+				foundRequestCompleted = true
+				require.Greater(t, event.EventTime.AsTime(), handlerCloseEvent.EventTime.AsTime())
+			}
+		}
+		require.True(t, foundRequestCompleted)
+	})
+}
+
 func TestAsyncOperationFromWorkflow_MultipleCallers(t *testing.T) {
 	if os.Getenv("DISABLE_SERVER_1_27_TESTS") == "1" {
 		t.Skip()
@@ -0,0 +1,210 @@
+package testsuite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+)
+
+// Configuration for the dev server environment.
+//
+// NOTE: Experimental
+type DevServerEnvironmentOptions struct {
+	// Options of the dev server started for the environment.
+	DevServerOptions DevServerOptions
+	// Task queue of the worker, a random one is used if unset.
+	TaskQueue string
+	// Options of the worker polling the task queue.
+	WorkerOptions worker.Options
+}
+
+// DevServerEnvironment runs a worker against an ephemeral dev server, so workflows can be tested end to end against a
+// real server. Workflows and activities are registered on the worker before the first workflow is executed, which
+// starts the worker.
+//
+// NOTE: Experimental
+type DevServerEnvironment struct {
+	server    *DevServer
+	worker    worker.Worker
+	taskQueue string
+
+	startOnce sync.Once
+	startErr  error
+}
+
+// StartDevServerEnvironment starts a dev server and creates a worker for it. This may download the server if not
+// already downloaded. Stop must be called to stop the worker and the server.
+//
+// NOTE: Experimental
+func StartDevServerEnvironment(ctx context.Context, options DevServerEnvironmentOptions) (*DevServerEnvironment, error) {
+	server, err := StartDevServer(ctx, options.DevServerOptions)
+	if err != nil {
+		return nil, err
+	}
+	taskQueue := options.TaskQueue
+	if taskQueue == "" {
+		taskQueue = "dev-server-env-" + uuid.NewString()
+	}
+	return &DevServerEnvironment{
+		server:    server,
+		worker:    worker.New(server.Client(), taskQueue, options.WorkerOptions),
+		taskQueue: taskQueue,
+	}, nil
+}
+
+// Client returns a client connected to the dev server.
+func (e *DevServerEnvironment) Client() client.Client {
+	return e.server.Client()
+}
+
+// Worker returns the worker of the environment, to register workflows, activities, and Nexus services on.
+func (e *DevServerEnvironment) Worker() worker.Worker {
+	return e.worker
+}
+
+// TaskQueue returns the task queue the worker is polling.
+func (e *DevServerEnvironment) TaskQueue() string {
+	return e.taskQueue
+}
+
+// ExecuteWorkflow starts the worker if not already started, then starts a workflow on the task queue of the worker. ID
+// is a random one and TaskQueue is the one of the worker if unset in the options.
+func (e *DevServerEnvironment) ExecuteWorkflow(
+	ctx context.Context,
+	options client.StartWorkflowOptions,
+	workflow interface{},
+	args ...interface{},
+) (client.WorkflowRun, error) {
+	e.startOnce.Do(func() { e.startErr = e.worker.Start() })
+	if e.startErr != nil {
+		return nil, fmt.Errorf("failed starting worker: %w", e.startErr)
+	}
+	if options.ID == "" {
+		options.ID = uuid.NewString()
+	}
+	if options.TaskQueue == "" {
+		options.TaskQueue = e.taskQueue
+	}
+	return e.server.Client().ExecuteWorkflow(ctx, options, workflow, args...)
+}
+
+// GetWorkflowHistory returns all events of a workflow run. The runID may be empty to get the history of the latest run.
+func (e *DevServerEnvironment) GetWorkflowHistory(ctx context.Context, workflowID, runID string) ([]*historypb.HistoryEvent, error) {
+	iter := e.server.Client().GetWorkflowHistory(ctx, workflowID, runID, false, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	var events []*historypb.HistoryEvent
+	for iter.HasNext() {
+		event, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// Stop the worker, if started, and the dev server.
+func (e *DevServerEnvironment) Stop() error {
+	e.startOnce.Do(func() { e.startErr = errors.New("environment stopped") })
+	if e.startErr == nil {
+		e.worker.Stop()
+	}
+	return e.server.Stop()
+}
+
+// HistoryEventMatcher matches a history event, used with MatchHistoryEventSequence.
+//
+// NOTE: Experimental
+type HistoryEventMatcher struct {
+	// Description of the matched event, used in errors.
+	Description string
+	// Match returns whether the event matches.
+	Match func(event *historypb.HistoryEvent) bool
+}
+
+// EventOfType matches events of the given type.
+//
+// NOTE: Experimental
+func EventOfType(eventType enumspb.EventType) HistoryEventMatcher {
+	return HistoryEventMatcher{
+		Description: eventType.String(),
+		Match: func(event *historypb.HistoryEvent) bool {
+			return event.GetEventType() == eventType
+		},
+	}
+}
+
+// ActivityScheduledEvent matches the scheduling of an activity of the given type.
+//
+// NOTE: Experimental
+func ActivityScheduledEvent(activityType string) HistoryEventMatcher {
+	return HistoryEventMatcher{
+		Description: fmt.Sprintf("%v for activity %q", enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED, activityType),
+		Match: func(event *historypb.HistoryEvent) bool {
+			return event.GetActivityTaskScheduledEventAttributes().GetActivityType().GetName() == activityType
+		},
+	}
+}
+
+// ChildWorkflowInitiatedEvent matches the start of a child workflow of the given type.
+//
+// NOTE: Experimental
+func ChildWorkflowInitiatedEvent(workflowType string) HistoryEventMatcher {
+	return HistoryEventMatcher{
+		Description: fmt.Sprintf("%v for workflow %q", enumspb.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_INITIATED, workflowType),
+		Match: func(event *historypb.HistoryEvent) bool {
+			return event.GetStartChildWorkflowExecutionInitiatedEventAttributes().GetWorkflowType().GetName() == workflowType
+		},
+	}
+}
+
+// SignaledEvent matches the receipt of a signal with the given name.
+//
+// NOTE: Experimental
+func SignaledEvent(signalName string) HistoryEventMatcher {
+	return HistoryEventMatcher{
+		Description: fmt.Sprintf("%v for signal %q", enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED, signalName),
+		Match: func(event *historypb.HistoryEvent) bool {
+			return event.GetWorkflowExecutionSignaledEventAttributes().GetSignalName() == signalName
+		},
+	}
+}
+
+// MatchHistoryEventSequence checks that the events contain events matching the matchers in order. Other events may
+// appear between the matched ones. The returned error describes the first matcher without a matching event.
+//
+// NOTE: Experimental
+func MatchHistoryEventSequence(events []*historypb.HistoryEvent, matchers ...HistoryEventMatcher) error {
+	next := 0
+	for i, matcher := range matchers {
+		found := false
+		for ; next < len(events); next++ {
+			if matcher.Match(events[next]) {
+				found = true
+				next++
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("no event matching %v (matcher %d) in sequence, events were: %v",
+				matcher.Description, i, describeEventTypes(events))
+		}
+	}
+	return nil
+}
+
+func describeEventTypes(events []*historypb.HistoryEvent) string {
+	eventTypes := make([]string, len(events))
+	for i, event := range events {
+		eventTypes[i] = fmt.Sprintf("%d:%v", event.GetEventId(), event.GetEventType())
+	}
+	return "[" + strings.Join(eventTypes, ", ") + "]"
+}
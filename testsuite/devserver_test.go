@@ -3,11 +3,16 @@ package testsuite_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
 	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/activity"
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
 )
 
 func TestStartDevServer_Defaults(t *testing.T) {
@@ -48,3 +53,58 @@ func TestStartDevServer_FrontendHostPort(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, info.Capabilities)
 }
+
+func greetActivity(_ context.Context, name string) (string, error) {
+	return "Hello " + name, nil
+}
+
+func greetWorkflow(ctx workflow.Context, name string) (string, error) {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+	var greeting string
+	err := workflow.ExecuteActivity(ctx, greetActivity, name).Get(ctx, &greeting)
+	return greeting, err
+}
+
+func TestStartDevServerEnvironment(t *testing.T) {
+	ctx := context.Background()
+	env, err := testsuite.StartDevServerEnvironment(ctx, testsuite.DevServerEnvironmentOptions{})
+	require.NoError(t, err)
+	defer func() { _ = env.Stop() }()
+	env.Worker().RegisterWorkflow(greetWorkflow)
+	env.Worker().RegisterActivityWithOptions(greetActivity, activity.RegisterOptions{Name: "greet"})
+
+	run, err := env.ExecuteWorkflow(ctx, client.StartWorkflowOptions{}, greetWorkflow, "Temporal")
+	require.NoError(t, err)
+	var greeting string
+	require.NoError(t, run.Get(ctx, &greeting))
+	require.Equal(t, "Hello Temporal", greeting)
+
+	events, err := env.GetWorkflowHistory(ctx, run.GetID(), run.GetRunID())
+	require.NoError(t, err)
+	require.NoError(t, testsuite.MatchHistoryEventSequence(events,
+		testsuite.EventOfType(enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED),
+		testsuite.ActivityScheduledEvent("greet"),
+		testsuite.EventOfType(enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED),
+	))
+}
+
+func TestMatchHistoryEventSequence(t *testing.T) {
+	events := []*historypb.HistoryEvent{
+		{EventId: 1, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED},
+		{EventId: 2, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED, Attributes: &historypb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{
+			WorkflowExecutionSignaledEventAttributes: &historypb.WorkflowExecutionSignaledEventAttributes{SignalName: "go"},
+		}},
+		{EventId: 3, EventType: enumspb.EVENT_TYPE_TIMER_STARTED},
+		{EventId: 4, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED},
+	}
+	require.NoError(t, testsuite.MatchHistoryEventSequence(events,
+		testsuite.EventOfType(enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED),
+		testsuite.SignaledEvent("go"),
+		testsuite.EventOfType(enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED),
+	))
+	require.ErrorContains(t, testsuite.MatchHistoryEventSequence(events,
+		testsuite.EventOfType(enumspb.EVENT_TYPE_TIMER_STARTED),
+		testsuite.SignaledEvent("go"),
+	), `signal "go" (matcher 1)`)
+	require.Error(t, testsuite.MatchHistoryEventSequence(events, testsuite.SignaledEvent("stop")))
+}
@@ -3,6 +3,8 @@
 package testsuite
 
 import (
+	"github.com/stretchr/testify/mock"
+
 	"go.temporal.io/sdk/internal"
 )
 
@@ -16,12 +18,69 @@ type (
 	// TestActivityEnvironment is the environment that you use to test activity
 	TestActivityEnvironment = internal.TestActivityEnvironment
 
+	// TestNexusEnvironment is the environment that you use to test Nexus operation handlers
+	//
+	// NOTE: Experimental
+	TestNexusEnvironment = internal.TestNexusEnvironment
+
+	// TestNexusOperationResult is the result of an operation started with TestNexusEnvironment.ExecuteOperation
+	//
+	// NOTE: Experimental
+	TestNexusOperationResult = internal.TestNexusOperationResult
+
 	// MockCallWrapper is a wrapper to mock.Call. It offers the ability to wait on workflow's clock instead of wall clock.
 	MockCallWrapper = internal.MockCallWrapper
 
+	// TestPendingTimerInfo describes a timer started by the workflow under test that has not yet fired or been
+	// canceled.
+	//
+	// NOTE: Experimental
+	TestPendingTimerInfo = internal.TestPendingTimerInfo
+
 	// TestUpdateCallback is a basic implementation of the UpdateCallbacks interface for testing purposes.
 	TestUpdateCallback = internal.TestUpdateCallback
 )
 
 // ErrMockStartChildWorkflowFailed is special error used to indicate the mocked child workflow should fail to start.
 var ErrMockStartChildWorkflowFailed = internal.ErrMockStartChildWorkflowFailed
+
+// ReplayAssertionOptions are options for [AssertReplayCompatibleWithOptions].
+//
+// NOTE: Experimental
+type ReplayAssertionOptions = internal.ReplayAssertionOptions
+
+// AssertReplayCompatible asserts that the workflow replays the JSON history without nondeterminism errors, as
+// exported by the Temporal CLI or UI, so that known-good histories can be pinned as regression tests next to the
+// workflow code:
+//
+//	//go:embed testdata/order_workflow_history.json
+//	var orderWorkflowHistory []byte
+//
+//	func TestOrderWorkflowReplay(t *testing.T) {
+//		testsuite.AssertReplayCompatible(t, OrderWorkflow, orderWorkflowHistory)
+//	}
+//
+// NOTE: Experimental
+func AssertReplayCompatible(t mock.TestingT, workflow interface{}, historyJSON []byte) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	return internal.AssertReplayCompatible(t, workflow, historyJSON)
+}
+
+// AssertReplayCompatibleWithOptions asserts that the workflow replays the JSON history without nondeterminism
+// errors, like [AssertReplayCompatible], with the options, for example the payload codecs of histories with encrypted
+// payloads.
+//
+// NOTE: Experimental
+func AssertReplayCompatibleWithOptions(
+	t mock.TestingT,
+	workflow interface{},
+	historyJSON []byte,
+	options ReplayAssertionOptions,
+) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	return internal.AssertReplayCompatibleWithOptions(t, workflow, historyJSON, options)
+}
@@ -0,0 +1,234 @@
+// Package vis builds visibility queries for Client.ListWorkflow, Client.CountWorkflow and the other visibility APIs.
+//
+// Queries are composed from conditions on search attributes, and values are always rendered as literals, so they
+// cannot change the structure of the query:
+//
+//	query := vis.Eq("WorkflowType", workflowType).
+//		And(vis.Between("StartTime", from, to)).
+//		And(vis.In("ExecutionStatus", "Running", "ContinuedAsNew"))
+//	iter, err := c.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{Query: query.String()})
+//
+// NOTE: Experimental
+package vis
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query is a visibility query, or part of one. The zero Query matches all workflows and is ignored when combined
+// with other queries.
+//
+// NOTE: Experimental
+type Query struct {
+	expr string
+	// Operator combining the parts of expr, empty for a single condition
+	op string
+}
+
+const (
+	opAnd = "AND"
+	opOr  = "OR"
+)
+
+// Names of search attributes that can be written without quoting
+var plainName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Eq matches workflows whose search attribute equals the value.
+//
+// NOTE: Experimental
+func Eq(name string, value interface{}) Query { return compare(name, "=", value) }
+
+// NotEq matches workflows whose search attribute does not equal the value.
+//
+// NOTE: Experimental
+func NotEq(name string, value interface{}) Query { return compare(name, "!=", value) }
+
+// Gt matches workflows whose search attribute is greater than the value.
+//
+// NOTE: Experimental
+func Gt(name string, value interface{}) Query { return compare(name, ">", value) }
+
+// Gte matches workflows whose search attribute is greater than or equal to the value.
+//
+// NOTE: Experimental
+func Gte(name string, value interface{}) Query { return compare(name, ">=", value) }
+
+// Lt matches workflows whose search attribute is less than the value.
+//
+// NOTE: Experimental
+func Lt(name string, value interface{}) Query { return compare(name, "<", value) }
+
+// Lte matches workflows whose search attribute is less than or equal to the value.
+//
+// NOTE: Experimental
+func Lte(name string, value interface{}) Query { return compare(name, "<=", value) }
+
+// Between matches workflows whose search attribute is between from and to, both included.
+//
+// NOTE: Experimental
+func Between(name string, from, to interface{}) Query {
+	return Query{expr: fmt.Sprintf("%s BETWEEN %s AND %s", quoteName(name), literal(from), literal(to))}
+}
+
+// NotBetween matches workflows whose search attribute is not between from and to.
+//
+// NOTE: Experimental
+func NotBetween(name string, from, to interface{}) Query {
+	return Query{expr: fmt.Sprintf("%s NOT BETWEEN %s AND %s", quoteName(name), literal(from), literal(to))}
+}
+
+// In matches workflows whose search attribute equals one of the values. Without values, it matches no workflow.
+//
+// NOTE: Experimental
+func In(name string, values ...interface{}) Query {
+	if len(values) == 0 {
+		// "IN ()" is not valid, the condition cannot hold for any workflow instead
+		return IsNull(name).And(IsNotNull(name))
+	}
+	return in(name, "IN", values)
+}
+
+// NotIn matches workflows whose search attribute equals none of the values. Without values, it matches all
+// workflows.
+//
+// NOTE: Experimental
+func NotIn(name string, values ...interface{}) Query {
+	if len(values) == 0 {
+		// "NOT IN ()" is not valid, the condition holds for all workflows instead. It is not the zero Query, which
+		// would be ignored when combined with Or.
+		return IsNull(name).Or(IsNotNull(name))
+	}
+	return in(name, "NOT IN", values)
+}
+
+// StartsWith matches workflows whose Keyword search attribute starts with the prefix.
+//
+// NOTE: Experimental
+func StartsWith(name string, prefix string) Query {
+	return Query{expr: fmt.Sprintf("%s STARTS_WITH %s", quoteName(name), literal(prefix))}
+}
+
+// IsNull matches workflows that do not have the search attribute set.
+//
+// NOTE: Experimental
+func IsNull(name string) Query { return Query{expr: quoteName(name) + " IS NULL"} }
+
+// IsNotNull matches workflows that have the search attribute set.
+//
+// NOTE: Experimental
+func IsNotNull(name string) Query { return Query{expr: quoteName(name) + " IS NOT NULL"} }
+
+// And matches workflows matched by all the queries.
+//
+// NOTE: Experimental
+func And(queries ...Query) Query { return combine(opAnd, queries) }
+
+// Or matches workflows matched by any of the queries.
+//
+// NOTE: Experimental
+func Or(queries ...Query) Query { return combine(opOr, queries) }
+
+// And matches workflows matched by q and all the other queries.
+func (q Query) And(queries ...Query) Query { return combine(opAnd, append([]Query{q}, queries...)) }
+
+// Or matches workflows matched by q or any of the other queries.
+func (q Query) Or(queries ...Query) Query { return combine(opOr, append([]Query{q}, queries...)) }
+
+// IsEmpty returns whether the query has no conditions, matching all workflows.
+func (q Query) IsEmpty() bool { return q.expr == "" }
+
+// String returns the query string, to use as the query of visibility requests.
+func (q Query) String() string { return q.expr }
+
+func compare(name, operator string, value interface{}) Query {
+	return Query{expr: fmt.Sprintf("%s %s %s", quoteName(name), operator, literal(value))}
+}
+
+func in(name, operator string, values []interface{}) Query {
+	literals := make([]string, len(values))
+	for i, value := range values {
+		literals[i] = literal(value)
+	}
+	return Query{expr: fmt.Sprintf("%s %s (%s)", quoteName(name), operator, strings.Join(literals, ", "))}
+}
+
+func combine(op string, queries []Query) Query {
+	var nonEmpty []Query
+	for _, q := range queries {
+		if !q.IsEmpty() {
+			nonEmpty = append(nonEmpty, q)
+		}
+	}
+	switch len(nonEmpty) {
+	case 0:
+		return Query{}
+	case 1:
+		return nonEmpty[0]
+	}
+	parts := make([]string, len(nonEmpty))
+	for i, q := range nonEmpty {
+		// Queries combined with the other operator are parenthesized, the ones combined with the same are flattened
+		if q.op != "" && q.op != op {
+			parts[i] = "(" + q.expr + ")"
+		} else {
+			parts[i] = q.expr
+		}
+	}
+	return Query{expr: strings.Join(parts, " "+op+" "), op: op}
+}
+
+// quoteName returns the search attribute name, quoted with backticks if it is not a plain identifier.
+func quoteName(name string) string {
+	if plainName.MatchString(name) {
+		return name
+	}
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// literal returns the value as a query literal. Times are formatted as RFC 3339 strings, and values of types without
+// a literal form are formatted with fmt and quoted as strings.
+func literal(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return quoteString(v)
+	case time.Time:
+		return quoteString(v.Format(time.RFC3339Nano))
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.FormatInt(int64(v), 10)
+	case int8:
+		return strconv.FormatInt(int64(v), 10)
+	case int16:
+		return strconv.FormatInt(int64(v), 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return quoteString(fmt.Sprint(v))
+	}
+}
+
+// quoteString returns s as a single quoted string literal, escaping quotes and backslashes.
+func quoteString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,51 @@
+package vis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/sdk/vis"
+)
+
+func TestQuery(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(36 * time.Hour)
+	query := vis.Eq("WorkflowType", "Order").
+		And(vis.Between("StartTime", from, to)).
+		And(vis.In("ExecutionStatus", "Running", "ContinuedAsNew"))
+	require.Equal(t, "WorkflowType = 'Order' AND StartTime BETWEEN '2024-01-01T00:00:00Z' AND '2024-01-02T12:00:00Z' "+
+		"AND ExecutionStatus IN ('Running', 'ContinuedAsNew')", query.String())
+
+	require.Equal(t, "Amount > 10 AND Ratio <= 0.5 AND Active = true AND Id != -3",
+		vis.And(vis.Gt("Amount", 10), vis.Lte("Ratio", 0.5), vis.Eq("Active", true), vis.NotEq("Id", int64(-3))).String())
+	require.Equal(t, "CustomKeyword STARTS_WITH 'prefix' AND TemporalChangeVersion IS NULL AND BuildIds IS NOT NULL",
+		vis.And(vis.StartsWith("CustomKeyword", "prefix"), vis.IsNull("TemporalChangeVersion"), vis.IsNotNull("BuildIds")).String())
+	require.Equal(t, "Count NOT BETWEEN 1 AND 5 OR Status NOT IN ('a')",
+		vis.NotBetween("Count", 1, 5).Or(vis.NotIn("Status", "a")).String())
+
+	// Without values, In matches no workflow and NotIn all workflows
+	require.Equal(t, "A = 1 OR (Status IS NULL AND Status IS NOT NULL)", vis.Eq("A", 1).Or(vis.In("Status")).String())
+	require.Equal(t, "A = 1 AND (Status IS NULL OR Status IS NOT NULL)", vis.Eq("A", 1).And(vis.NotIn("Status")).String())
+}
+
+func TestQuery_Precedence(t *testing.T) {
+	a, b, c, d := vis.Eq("A", 1), vis.Eq("B", 2), vis.Eq("C", 3), vis.Eq("D", 4)
+	require.Equal(t, "(A = 1 OR B = 2) AND (C = 3 OR D = 4)", vis.And(a.Or(b), c.Or(d)).String())
+	require.Equal(t, "(A = 1 AND B = 2) OR C = 3", a.And(b).Or(c).String())
+	require.Equal(t, "A = 1 AND B = 2 AND C = 3", a.And(b).And(c).String())
+
+	// Empty queries are ignored
+	require.True(t, vis.And().IsEmpty())
+	require.Equal(t, "(A = 1 OR B = 2) AND C = 3", vis.And(vis.Query{}, a.Or(b), vis.Or(vis.Query{}), c).String())
+	require.Equal(t, "A = 1 OR B = 2", vis.Query{}.And(a.Or(b)).String())
+}
+
+func TestQuery_Escaping(t *testing.T) {
+	require.Equal(t, `WorkflowId = 'x\' OR WorkflowType = \'y'`,
+		vis.Eq("WorkflowId", "x' OR WorkflowType = 'y").String())
+	require.Equal(t, `WorkflowId = 'a\\\'b'`, vis.Eq("WorkflowId", `a\'b`).String())
+	require.Equal(t, "`Custom Attr` = 1 AND `a``b` = 2", vis.Eq("Custom Attr", 1).And(vis.Eq("a`b", 2)).String())
+	require.Equal(t, "Duration = '1m0s'", vis.Eq("Duration", time.Minute).String())
+}
@@ -46,11 +46,25 @@ type FixedSizeTunerOptions = internal.FixedSizeTunerOptions
 // WARNING: Custom implementations of SlotSupplier are currently experimental.
 type CompositeTunerOptions = internal.CompositeTunerOptions
 
+// SharedPoolTunerOptions are the options used by NewSharedPoolTuner.
+//
+// NOTE: Experimental
+type SharedPoolTunerOptions = internal.SharedPoolTunerOptions
+
 // NewFixedSizeTuner creates a WorkerTuner that uses fixed size slot suppliers.
 func NewFixedSizeTuner(options FixedSizeTunerOptions) (WorkerTuner, error) {
 	return internal.NewFixedSizeTuner(options)
 }
 
+// NewSharedPoolTuner creates a WorkerTuner whose task types share one pool of slots. Every task type is guaranteed
+// its share of the slots, proportional to its weight, and may use more while the other task types do not need them.
+// A slot is kept free for every task type holding none, including the ones with a zero weight.
+//
+// NOTE: Experimental
+func NewSharedPoolTuner(options SharedPoolTunerOptions) (WorkerTuner, error) {
+	return internal.NewSharedPoolTuner(options)
+}
+
 // NewCompositeTuner creates a WorkerTuner that uses a combination of slot suppliers.
 //
 // WARNING: Custom implementations of SlotSupplier are currently experimental.
@@ -3,6 +3,7 @@ package worker
 
 import (
 	"context"
+	"time"
 
 	"github.com/nexus-rpc/sdk-go/nexus"
 	historypb "go.temporal.io/api/history/v1"
@@ -51,6 +52,70 @@ type (
 		Stop()
 	}
 
+	// Controller controls a running worker. Workers created with New implement it in addition to Worker, and it is
+	// obtained with a type assertion:
+	//
+	//	w := worker.New(c, "my-task-queue", worker.Options{})
+	//	w.(worker.Controller).PausePolling()
+	//
+	// It is kept separate from Worker so that implementations and mocks of Worker do not need to implement it.
+	//
+	// NOTE: Experimental
+	Controller interface {
+		// PausePolling stops the worker from starting new polls for workflow, activity, and Nexus tasks until
+		// ResumePolling is called, for example to drain a worker before maintenance without stopping it. Tasks
+		// already received are still processed, and polls already in flight when this is called may still
+		// deliver tasks.
+		//
+		// NOTE: Experimental
+		PausePolling()
+
+		// ResumePolling resumes polling paused by PausePolling.
+		//
+		// NOTE: Experimental
+		ResumePolling()
+
+		// SetStickyOnlyPolling sets whether the worker only polls its sticky workflow task queue. While enabled, the
+		// worker keeps processing workflow tasks for executions in its cache but receives no new workflow
+		// executions, which lets cached executions finish on this worker during a drain. Activity and Nexus polling
+		// are not affected. Has no effect if sticky execution is disabled.
+		//
+		// NOTE: Experimental
+		SetStickyOnlyPolling(stickyOnly bool)
+
+		// UpdateOptions changes concurrency and rate limits of the worker while it is running, without restarting
+		// it and losing its sticky cache. Fields left at their zero value keep their current setting. Returns an
+		// error, and changes nothing, if the options are invalid or a concurrency limit is set while the worker uses
+		// a Tuner other than one with fixed size slot suppliers.
+		//
+		// NOTE: Experimental
+		UpdateOptions(options UpdateOptions) error
+
+		// Promote a worker created with Options.Standby so it begins polling for tasks. Does nothing if the
+		// worker is not in standby. May be called before or after Start.
+		//
+		// NOTE: Experimental
+		Promote()
+
+		// IsStandby returns whether the worker is in standby, waiting to be promoted.
+		//
+		// NOTE: Experimental
+		IsStandby() bool
+
+		// BuildID returns the build ID of the worker, set with Options.BuildID or Options.DeploymentOptions, or
+		// derived from the checksum of the worker binary otherwise.
+		//
+		// NOTE: Experimental
+		BuildID() string
+
+		// StopWithReason stops the worker like Stop, with the reason exposed to its running activities by
+		// activity.GetWorkerStopInfo, along with the deadline of the worker stop timeout. For example, a worker of
+		// a deployment version that is drained can be stopped with WorkerStopReasonDeploymentDrain.
+		//
+		// NOTE: Experimental
+		StopWithReason(reason WorkerStopReason)
+	}
+
 	// Registry exposes registration functions to consumers.
 	Registry interface {
 		WorkflowRegistry
@@ -192,6 +257,40 @@ type (
 		ReplayWorkflowExecution(ctx context.Context, service workflowservice.WorkflowServiceClient, logger log.Logger, namespace string, execution workflow.Execution) error
 	}
 
+	// ReplayReporter reports on the workflows replayed by a WorkflowReplayer. Replayers created with
+	// NewWorkflowReplayer implement it in addition to WorkflowReplayer, and it is obtained with a type assertion:
+	//
+	//	replayer := worker.NewWorkflowReplayer()
+	//	report := replayer.(worker.ReplayReporter).CheckReplayCompatibility(nil, histories...)
+	//
+	// It is kept separate from WorkflowReplayer so that implementations and mocks of WorkflowReplayer do not need
+	// to implement it.
+	//
+	// NOTE: Experimental
+	ReplayReporter interface {
+		// CheckReplayCompatibility replays the histories, and reports which ones replay cleanly and which build IDs
+		// the registered workflows are compatible with. Use as a deploy gate with histories of recent workflows.
+		// The logger is an optional parameter. Defaults to the noop logger.
+		//
+		// NOTE: Experimental
+		CheckReplayCompatibility(logger log.Logger, histories ...*historypb.History) *ReplayCompatibilityReport
+
+		// CheckTaskQueueReplayCompatibility loads the histories of the most recent workflows of a task queue from
+		// the Temporal service and replays them like CheckReplayCompatibility. The service of a client is available
+		// with client.Client.WorkflowService. It returns an error, and no report, if the workflows or their
+		// histories cannot be loaded.
+		//
+		// NOTE: Experimental
+		CheckTaskQueueReplayCompatibility(ctx context.Context, service workflowservice.WorkflowServiceClient, logger log.Logger, namespace string, options ReplayCompatibilityOptions) (*ReplayCompatibilityReport, error)
+
+		// GetVersionUsageReport reports the versions workflow.GetVersion returned in all the workflows replayed so
+		// far. Changes whose old versions were not returned by any replayed workflow no longer need the branches for
+		// those versions, provided the replayed histories cover all the workflows that can still be replayed.
+		//
+		// NOTE: Experimental
+		GetVersionUsageReport() *VersionUsageReport
+	}
+
 	// DeploymentOptions provides configuration to enable Worker Versioning.
 	//
 	// NOTE: Experimental
@@ -200,18 +299,149 @@ type (
 	// Options is used to configure a worker instance.
 	Options = internal.WorkerOptions
 
+	// ActivityResultCache stores the results of activities scheduled with an idempotency key. See
+	// [Options.ActivityResultCache].
+	//
+	// NOTE: Experimental
+	ActivityResultCache = internal.ActivityResultCache
+
+	// PollerBehavior configures how a worker polls a task queue for one kind of task. See
+	// [Options.WorkflowTaskPollerBehavior].
+	//
+	// NOTE: Experimental
+	PollerBehavior = internal.PollerBehavior
+
+	// PollerBehaviorSimpleMaximum polls with a fixed number of pollers.
+	//
+	// NOTE: Experimental
+	PollerBehaviorSimpleMaximum = internal.PollerBehaviorSimpleMaximum
+
+	// PollerBehaviorAutoscaling scales the number of pollers between a minimum and a maximum with the load.
+	//
+	// NOTE: Experimental
+	PollerBehaviorAutoscaling = internal.PollerBehaviorAutoscaling
+
+	// UpdateOptions are the options that can be changed on a running worker with Controller.UpdateOptions.
+	//
+	// NOTE: Experimental
+	UpdateOptions = internal.WorkerUpdateOptions
+
+	// WorkflowLogSamplingOptions configures sampling of logs written with the workflow logger outside of replay.
+	//
+	// NOTE: Experimental
+	WorkflowLogSamplingOptions = internal.WorkflowLogSamplingOptions
+
+	// MetricsTagProvider returns additional metric tags for a workflow or activity. See
+	// [Options.MetricsTagProvider].
+	//
+	// NOTE: Experimental
+	MetricsTagProvider = internal.MetricsTagProvider
+
+	// MetricsTagProviderInput is the information available to a MetricsTagProvider.
+	//
+	// NOTE: Experimental
+	MetricsTagProviderInput = internal.MetricsTagProviderInput
+
+	// LogFieldsOptions configures the identifiers attached to the loggers of a worker, its workflows and activities.
+	// See [Options.LogFields].
+	//
+	// NOTE: Experimental
+	LogFieldsOptions = internal.WorkerLogFieldsOptions
+
+	// LogFieldProvider returns additional fields for the logger of a workflow or activity. See
+	// [LogFieldsOptions.FieldProvider].
+	//
+	// NOTE: Experimental
+	LogFieldProvider = internal.LogFieldProvider
+
+	// LogFieldProviderInput is the information available to a LogFieldProvider.
+	//
+	// NOTE: Experimental
+	LogFieldProviderInput = internal.LogFieldProviderInput
+
+	// NondeterminismDump is the information passed to Options.OnNondeterminism.
+	//
+	// NOTE: Experimental
+	NondeterminismDump = internal.NondeterminismDump
+
 	// WorkflowPanicPolicy is used for configuring how worker deals with workflow
 	// code panicking which includes non backwards compatible changes to the workflow code without appropriate
 	// versioning (see [workflow.GetVersion]).
 	// The default behavior is to block workflow execution until the problem is fixed.
 	WorkflowPanicPolicy = internal.WorkflowPanicPolicy
 
+	// ActivityPanicPolicy is used for configuring how worker deals with activity
+	// code panicking. It is set per activity type with [activity.RegisterOptions].
+	//
+	// NOTE: Experimental
+	ActivityPanicPolicy = internal.ActivityPanicPolicy
+
 	// WorkflowReplayerOptions are options used for
 	// NewWorkflowReplayerWithOptions.
 	WorkflowReplayerOptions = internal.WorkflowReplayerOptions
 
 	// ReplayWorkflowHistoryOptions are options for replaying a workflow.
 	ReplayWorkflowHistoryOptions = internal.ReplayWorkflowHistoryOptions
+
+	// ReplayCompatibilityOptions are options for ReplayReporter.CheckTaskQueueReplayCompatibility.
+	//
+	// NOTE: Experimental
+	ReplayCompatibilityOptions = internal.ReplayCompatibilityOptions
+
+	// ReplayCompatibilityReport is the outcome of replaying a set of histories with
+	// ReplayReporter.CheckReplayCompatibility or ReplayReporter.CheckTaskQueueReplayCompatibility.
+	//
+	// NOTE: Experimental
+	ReplayCompatibilityReport = internal.ReplayCompatibilityReport
+
+	// ReplayCompatibilityResult is the outcome of replaying a single history.
+	//
+	// NOTE: Experimental
+	ReplayCompatibilityResult = internal.ReplayCompatibilityResult
+
+	// VersionUsageReport describes the versions workflow.GetVersion returned in the workflows replayed by a
+	// WorkflowReplayer, see ReplayReporter.GetVersionUsageReport.
+	//
+	// NOTE: Experimental
+	VersionUsageReport = internal.VersionUsageReport
+
+	// ChangeVersionUsage describes the versions workflow.GetVersion returned for a change.
+	//
+	// NOTE: Experimental
+	ChangeVersionUsage = internal.ChangeVersionUsage
+
+	// WorkerStopReason is why a worker is stopping, exposed to its activities by activity.GetWorkerStopInfo.
+	//
+	// NOTE: Experimental
+	WorkerStopReason = internal.WorkerStopReason
+
+	// ScheduleToStartSLOOptions are the schedule-to-start latency thresholds of the tasks of a worker, see
+	// Options.ScheduleToStartSLO.
+	//
+	// NOTE: Experimental
+	ScheduleToStartSLOOptions = internal.ScheduleToStartSLOOptions
+
+	// ScheduleToStartSLOViolation describes a task started later than its schedule-to-start latency threshold.
+	//
+	// NOTE: Experimental
+	ScheduleToStartSLOViolation = internal.ScheduleToStartSLOViolation
+
+	// QueryReplicaOptions are options for NewQueryReplica.
+	//
+	// NOTE: Experimental
+	QueryReplicaOptions = internal.QueryReplicaOptions
+
+	// QueryReplica answers the queries of workflows by replaying their histories in the process, without polling a
+	// task queue, so queries can be served at scale without impacting the workers running the workflows. Replicas
+	// must be closed with Close once no longer used.
+	//
+	// NOTE: Experimental
+	QueryReplica = internal.QueryReplica
+
+	// HostMetadata describes the host a worker runs on, see Options.HostMetadata.
+	//
+	// NOTE: Experimental
+	HostMetadata = internal.WorkerHostMetadata
 )
 
 const (
@@ -224,8 +454,40 @@ const (
 	// detects non-determinism. This feature is convenient during development.
 	// WARNING: enabling this in production can cause all open workflows to fail on a single bug or bad deployment.
 	FailWorkflow = internal.FailWorkflow
+
+	// RetryActivity is the default ActivityPanicPolicy. A panicking activity
+	// attempt fails with a [temporal.PanicError] and is retried according to
+	// the activity's retry policy.
+	//
+	// NOTE: Experimental
+	RetryActivity = internal.RetryActivity
+	// FailActivity ActivityPanicPolicy fails the activity without retrying it
+	// if activity code panics.
+	//
+	// NOTE: Experimental
+	FailActivity = internal.FailActivity
+
+	// WorkerStopReasonShutdown indicates the worker was stopped with Stop, or by the interrupt channel of Run.
+	//
+	// NOTE: Experimental
+	WorkerStopReasonShutdown = internal.WorkerStopReasonShutdown
+	// WorkerStopReasonFatalError indicates the worker is stopping because of a fatal error, such as a poll error
+	// that cannot be retried.
+	//
+	// NOTE: Experimental
+	WorkerStopReasonFatalError = internal.WorkerStopReasonFatalError
+	// WorkerStopReasonDeploymentDrain indicates the worker is stopping because its deployment version is drained.
+	//
+	// NOTE: Experimental
+	WorkerStopReasonDeploymentDrain = internal.WorkerStopReasonDeploymentDrain
 )
 
+// make sure workers created with New implement Controller.
+var _ Controller = (*internal.AggregatedWorker)(nil)
+
+// make sure replayers created with NewWorkflowReplayer implement ReplayReporter.
+var _ ReplayReporter = (*internal.WorkflowReplayer)(nil)
+
 // New creates an instance of worker for managing workflow and activity executions.
 //
 //	client    - the client for use by the worker
@@ -256,6 +518,32 @@ func NewWorkflowReplayerWithOptions(options WorkflowReplayerOptions) (WorkflowRe
 	return internal.NewWorkflowReplayer(options)
 }
 
+// NewQueryReplica creates a QueryReplica loading workflow histories with the client. The workflows whose queries
+// are answered must be registered on it.
+//
+// NOTE: Experimental
+func NewQueryReplica(client client.Client, options QueryReplicaOptions) (*QueryReplica, error) {
+	return internal.NewQueryReplica(client, options)
+}
+
+// DetectHostMetadata returns the host metadata of the current process, for Options.HostMetadata: the pod name from
+// the POD_NAME environment variable, defaulting to the host name, the node name from NODE_NAME, the region from
+// REGION or AWS_REGION, and the checksum of the running binary.
+//
+// NOTE: Experimental
+func DetectHostMetadata() HostMetadata {
+	return internal.DetectWorkerHostMetadata()
+}
+
+// NewMemoryActivityResultCache creates an ActivityResultCache keeping up to maxSize results in memory, evicting the
+// least recently used ones first. Results older than ttl are not returned, a ttl of zero keeps them until evicted.
+// Results are lost when the worker process exits and are not shared between workers.
+//
+// NOTE: Experimental
+func NewMemoryActivityResultCache(maxSize int, ttl time.Duration) ActivityResultCache {
+	return internal.NewMemoryActivityResultCache(maxSize, ttl)
+}
+
 // EnableVerboseLogging enable or disable verbose logging of internal Temporal library components.
 // Most customers don't need this feature, unless advised by the Temporal team member.
 // Also there is no guarantee that this API is not going to change.
@@ -272,12 +560,33 @@ func SetStickyWorkflowCacheSize(cacheSize int) {
 	internal.SetStickyWorkflowCacheSize(cacheSize)
 }
 
+// SetStickyWorkflowCacheTTL sets how long a workflow may go without processing a workflow task before it is evicted
+// from the sticky workflow cache, so idle workflows do not hold memory until they are pushed out by other workflows.
+// This must be called before any worker is started. If not called or zero, workflows are only evicted when the cache
+// is full.
+//
+// NOTE: Experimental
+func SetStickyWorkflowCacheTTL(ttl time.Duration) {
+	internal.SetStickyWorkflowCacheTTL(ttl)
+}
+
+// SetStickyWorkflowCacheMaxMemory sets the maximum memory in bytes used by the sticky workflow cache, in addition to
+// the maximum number of workflows set with SetStickyWorkflowCacheSize. The memory used by a workflow is estimated by
+// the size of its history. Least recently used workflows are evicted when the limit is exceeded. This must be called
+// before any worker is started. If not called or zero, the cache is only limited by the number of workflows.
+//
+// NOTE: Experimental
+func SetStickyWorkflowCacheMaxMemory(maxBytes int64) {
+	internal.SetStickyWorkflowCacheMaxMemory(maxBytes)
+}
+
 // PurgeStickyWorkflowCache resets the sticky workflow cache. This must be called only when all workers are stopped.
 func PurgeStickyWorkflowCache() {
 	internal.PurgeStickyWorkflowCache()
 }
 
-// SetBinaryChecksum sets the identifier of the binary(aka BinaryChecksum).
+// SetBinaryChecksum sets the identifier of the binary(aka BinaryChecksum). It is also the build ID of workers that
+// do not set one in their options.
 // The identifier is mainly used in recording reset points when respondWorkflowTaskCompleted. For each workflow, the very first
 // workflow task completed by a binary will be associated as a auto-reset point for the binary. So that when a customer wants to
 // mark the binary as bad, the workflow will be reset to that point -- which means workflow will forget all progress generated
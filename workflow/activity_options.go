@@ -10,6 +10,11 @@ import (
 // ActivityOptions stores all activity-specific invocation parameters that will be stored inside of a context.
 type ActivityOptions = internal.ActivityOptions
 
+// ActivityProgressOptions configure the publication of the progress of an activity, see ActivityOptions.Progress.
+//
+// NOTE: Experimental
+type ActivityProgressOptions = internal.ActivityProgressOptions
+
 // LocalActivityOptions doc
 type LocalActivityOptions = internal.LocalActivityOptions
 
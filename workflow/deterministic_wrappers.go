@@ -53,6 +53,26 @@ type (
 	//
 	// NOTE: Experimental
 	AwaitOptions = internal.AwaitOptions
+
+	// FutureCancellationMode is how a future created with [NewCancellableFuture] behaves when its
+	// context is canceled.
+	//
+	// NOTE: Experimental
+	FutureCancellationMode = internal.FutureCancellationMode
+)
+
+const (
+	// FutureCancellationFail fails the future with a CanceledError when its context is canceled
+	// before it is set. Settings after the future is ready are ignored instead of panicking.
+	//
+	// NOTE: Experimental
+	FutureCancellationFail = internal.FutureCancellationFail
+
+	// FutureCancellationIgnore leaves the future unaffected by the cancellation of its context,
+	// like a future created with [NewFuture].
+	//
+	// NOTE: Experimental
+	FutureCancellationIgnore = internal.FutureCancellationIgnore
 )
 
 // Await blocks the calling thread until condition() returns true.
@@ -173,10 +193,23 @@ func GoNamed(ctx Context, name string, f func(ctx Context)) {
 }
 
 // NewFuture creates a new future as well as an associated Settable that is used to set its value.
+// The future is not affected by the cancellation of ctx: it only becomes ready when it is set
+// through the Settable, and Get keeps blocking after ctx is canceled. Setting it more than once
+// panics. Use [NewCancellableFuture] for a future that is failed when ctx is canceled.
 func NewFuture(ctx Context) (Future, Settable) {
 	return internal.NewFuture(ctx)
 }
 
+// NewCancellableFuture creates a new future as well as an associated Settable that is used to
+// set its value, typically from a signal or update handler completing work started elsewhere.
+// The mode decides what happens to the future when ctx is canceled, see
+// [FutureCancellationFail] and [FutureCancellationIgnore].
+//
+// NOTE: Experimental
+func NewCancellableFuture(ctx Context, mode FutureCancellationMode) (Future, Settable) {
+	return internal.NewCancellableFuture(ctx, mode)
+}
+
 // Now returns the time when the workflow task was first started, even during replay.
 // Workflows must use this Now() to get the wall clock time, instead of Go's time.Now().
 func Now(ctx Context) time.Time {
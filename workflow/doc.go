@@ -402,7 +402,9 @@ the current call stack of a workflow execution. You can use tctl to do the query
 	tctl --namespace samples-namespace workflow query -w my_workflow_id -r my_run_id -qt __stack_trace
 
 The above cli command uses __stack_trace as the query type. The __stack_trace is a built-in query type that is
-supported by temporal client library. You can also add your own custom query types to support thing like query current
+supported by temporal client library. The built-in __stack_trace_json query type returns the same call stacks as a
+[go.temporal.io/sdk/client.WorkflowStackTrace], with the name of each coroutine, what it is blocked on, and its
+frames, for tools that render the state of a workflow. You can also add your own custom query types to support thing like query current
 state of the workflow, or query how many activities the workflow has completed. To do so, you need to setup your own
 query handler using [workflow.SetQueryHandler] in your workflow code:
 
@@ -0,0 +1,32 @@
+package workflow
+
+import (
+	"go.temporal.io/sdk/internal"
+)
+
+// JSONDocument is a loosely structured JSON document, such as the payload of a DSL workflow, that can be read and
+// patched deterministically in workflows. Values are addressed with JSON Pointers (RFC 6901), such as "/steps/0/name",
+// the empty pointer addressing the whole document. Objects are read as map[string]interface{}, arrays as
+// []interface{} and numbers as json.Number, so that integers do not lose precision. Use [JSONDocument.Keys], which
+// returns the keys of objects in sorted order, instead of ranging over maps. The document is always encoded with
+// sorted keys.
+//
+// A JSONDocument is encoded as its JSON value, so it can be used as the input or result of workflows and activities
+// with the default data converter. The zero value is the null document.
+//
+// NOTE: Experimental
+type JSONDocument = internal.JSONDocument
+
+// ParseJSONDocument parses a JSON document.
+//
+// NOTE: Experimental
+func ParseJSONDocument(data []byte) (*JSONDocument, error) {
+	return internal.ParseJSONDocument(data)
+}
+
+// NewJSONDocument returns the document of the JSON encoding of a value.
+//
+// NOTE: Experimental
+func NewJSONDocument(value interface{}) (*JSONDocument, error) {
+	return internal.NewJSONDocument(value)
+}
@@ -0,0 +1,68 @@
+package workflow
+
+import (
+	"go.temporal.io/sdk/internal"
+)
+
+type (
+	// VersionedState is a workflow state tagged with the version of its schema, to be passed as input to
+	// continue-as-new so that the state struct can evolve over a long chain of runs. Create it with
+	// [NewVersionedState] and read it with [DecodeVersionedState], which migrates states of older versions. The zero
+	// value means there is no state, such as on the first run of a workflow.
+	//
+	// NOTE: Experimental
+	VersionedState = internal.VersionedState
+
+	// StateMigrations are functions migrating a [VersionedState] to the next version, keyed by the version they
+	// migrate from. A migration receives the encoded state of its version and returns the state of the next version.
+	// Migrations run as workflow code, so they must be deterministic.
+	//
+	// NOTE: Experimental
+	StateMigrations = internal.StateMigrations
+)
+
+// NewVersionedState encodes the state with the data converter of the workflow and tags it with the version.
+//
+// NOTE: Experimental
+func NewVersionedState(ctx Context, version int, state interface{}) (VersionedState, error) {
+	return internal.NewVersionedState(ctx, version, state)
+}
+
+// DecodeVersionedState migrates the state to currentVersion by applying the migrations from its version, then decodes
+// it into valuePtr. A zero VersionedState leaves valuePtr unchanged. An error is returned if the state is newer than
+// currentVersion or a migration is missing. The following workflow renamed a field of its state in version 2:
+//
+//	type StateV1 struct{ Count int }
+//	type State struct{ Processed int }
+//
+//	var migrations = workflow.StateMigrations{
+//		1: func(ctx workflow.Context, encoded converter.EncodedValue) (interface{}, error) {
+//			var old StateV1
+//			err := encoded.Get(&old)
+//			return State{Processed: old.Count}, err
+//		},
+//	}
+//
+//	func MyWorkflow(ctx workflow.Context, input workflow.VersionedState) error {
+//		var state State
+//		if err := workflow.DecodeVersionedState(ctx, input, 2, migrations, &state); err != nil {
+//			return err
+//		}
+//		// ... process, then continue as new with the current version
+//		next, err := workflow.NewVersionedState(ctx, 2, state)
+//		if err != nil {
+//			return err
+//		}
+//		return workflow.NewContinueAsNewError(ctx, MyWorkflow, next)
+//	}
+//
+// NOTE: Experimental
+func DecodeVersionedState(
+	ctx Context,
+	state VersionedState,
+	currentVersion int,
+	migrations StateMigrations,
+	valuePtr interface{},
+) error {
+	return internal.DecodeVersionedState(ctx, state, currentVersion, migrations, valuePtr)
+}
@@ -3,6 +3,7 @@ package workflow
 import (
 	"cmp"
 	"errors"
+	"time"
 
 	"go.temporal.io/sdk/converter"
 	"go.temporal.io/sdk/internal"
@@ -70,6 +71,45 @@ type (
 	// ChildWorkflowFuture represents the result of a child workflow execution
 	ChildWorkflowFuture = internal.ChildWorkflowFuture
 
+	// NOTE to maintainers, this interface definition is duplicated in the internal package to provide a better UX.
+
+	// ChildWorkflowHandle is a typed handle to a child workflow execution whose result is of type T. See
+	// [ExecuteChildWorkflowTyped].
+	//
+	// NOTE: Experimental
+	ChildWorkflowHandle[T any] interface {
+		// WaitForStart blocks until the child workflow execution has started and returns its execution. The error is
+		// non-nil if the child workflow could not be started.
+		WaitForStart(ctx Context) (Execution, error)
+
+		// GetResult blocks until the child workflow completes and returns its result decoded into T.
+		GetResult(ctx Context) (T, error)
+
+		// Future returns the underlying untyped ChildWorkflowFuture, for use with selectors.
+		Future() ChildWorkflowFuture
+	}
+
+	// ExternalWorkflowHandle is a handle to a workflow execution that is not a child of the current workflow.
+	// See [GetExternalWorkflowHandle]. It is signaled with [SignalExternalWorkflowTyped].
+	//
+	// The futures returned by Cancel and SignalExternalWorkflowTyped fail with:
+	//   - *temporal.UnknownExternalWorkflowExecutionError if the target execution does not exist or has already
+	//     completed. The server reports both with the same failure cause. With
+	//     [ExternalWorkflowHandleOptions.DescribeUnknownTarget] the target is described to tell them apart, and the
+	//     futures fail with *temporal.ExternalWorkflowNotFoundError or *temporal.ExternalWorkflowCompletedError
+	//     instead, which unwrap to this error.
+	//   - *temporal.NamespaceNotFoundError if the target namespace does not exist.
+	//   - *temporal.SignalCountLimitExceededError (signals only) if the target has received the maximum number of
+	//     signals.
+	//
+	// NOTE: Experimental
+	ExternalWorkflowHandle = internal.ExternalWorkflowHandle
+
+	// ExternalWorkflowHandleOptions are options for [GetExternalWorkflowHandleWithOptions].
+	//
+	// NOTE: Experimental
+	ExternalWorkflowHandleOptions = internal.ExternalWorkflowHandleOptions
+
 	// Type identifies a workflow type.
 	Type = internal.WorkflowType
 
@@ -264,6 +304,32 @@ func ExecuteChildWorkflow(ctx Context, childWorkflow interface{}, args ...interf
 	return internal.ExecuteChildWorkflow(ctx, childWorkflow, args...)
 }
 
+// ExecuteChildWorkflowTyped requests child workflow execution like [ExecuteChildWorkflow], but returns a
+// [ChildWorkflowHandle] whose result is decoded into T. The handle combines waiting for the child to start and
+// getting its result, and can be signaled with [SignalChildWorkflowTyped]:
+//
+//	handle := workflow.ExecuteChildWorkflowTyped[string](ctx, ChildWorkflow, "input")
+//	if err := workflow.SignalChildWorkflowTyped[Approval](ctx, handle, "approve", Approval{By: "me"}).Get(ctx, nil); err != nil {
+//		return err
+//	}
+//	result, err := handle.GetResult(ctx)
+//
+// NOTE: Experimental
+func ExecuteChildWorkflowTyped[T any](ctx Context, childWorkflow interface{}, args ...interface{}) ChildWorkflowHandle[T] {
+	return internal.ExecuteChildWorkflowTyped[T](ctx, childWorkflow, args...)
+}
+
+// SignalChildWorkflowTyped sends a signal whose argument is of type A to the child workflow of the handle. This call
+// will block until the child workflow is started. The returned future is ready once the signal has been delivered or
+// failed. Give A explicitly so the compiler checks the argument against the type the child workflow receives:
+//
+//	err := workflow.SignalChildWorkflowTyped[Approval](ctx, handle, "approve", Approval{By: "me"}).Get(ctx, nil)
+//
+// NOTE: Experimental
+func SignalChildWorkflowTyped[A, T any](ctx Context, handle ChildWorkflowHandle[T], signalName string, arg A) Future {
+	return internal.SignalChildWorkflowTyped[A, T](ctx, handle, signalName, arg)
+}
+
 // GetInfo extracts info of a current workflow from a context.
 func GetInfo(ctx Context) *Info {
 	return internal.GetWorkflowInfo(ctx)
@@ -295,7 +361,10 @@ func GetMetricsHandler(ctx Context) metrics.Handler {
 	return internal.GetMetricsHandler(ctx)
 }
 
-// GetUnhandledSignalNames returns signal names that have unconsumed signals.
+// GetUnhandledSignalNames returns the sorted names of the signals that were received but not consumed yet, because
+// nothing has received them from their signal channel. When a workflow completes with unhandled signals, a warning is
+// logged and the "temporal_unhandled_signals" counter is incremented for each of their names, in the "signal_name" tag,
+// which helps catching misspelled signal names and channels that are not drained.
 func GetUnhandledSignalNames(ctx Context) []string {
 	return internal.GetUnhandledSignalNames(ctx)
 }
@@ -328,11 +397,70 @@ func SignalExternalWorkflow(ctx Context, workflowID, runID, signalName string, a
 	return internal.SignalExternalWorkflow(ctx, workflowID, runID, signalName, arg)
 }
 
+// GetExternalWorkflowHandle returns a handle to an external workflow execution that can be used to signal or cancel
+// it. Input runID is optional, when empty the currently running execution of workflowID will be targeted. By default,
+// the current workflow's namespace is used as target namespace, a different one can be specified on the context passed
+// to the handle methods:
+//
+//	handle := workflow.GetExternalWorkflowHandle("order-123", "")
+//	ctx = workflow.WithWorkflowNamespace(ctx, "orders")
+//	err := workflow.SignalExternalWorkflowTyped[Approval](ctx, handle, "approve", approval).Get(ctx, nil)
+//	var unknownErr *temporal.UnknownExternalWorkflowExecutionError
+//	if errors.As(err, &unknownErr) {
+//		// target does not exist or has already completed
+//	}
+//
+// NOTE: Experimental
+func GetExternalWorkflowHandle(workflowID, runID string) ExternalWorkflowHandle {
+	return internal.GetExternalWorkflowHandle(workflowID, runID)
+}
+
+// GetExternalWorkflowHandleWithOptions returns a handle to an external workflow execution like
+// [GetExternalWorkflowHandle], with the given options. For example, to tell a missing target from a completed one:
+//
+//	handle := workflow.GetExternalWorkflowHandleWithOptions("order-123", "",
+//		workflow.ExternalWorkflowHandleOptions{DescribeUnknownTarget: true})
+//	err := workflow.SignalExternalWorkflowTyped[Approval](ctx, handle, "approve", approval).Get(ctx, nil)
+//	var completedErr *temporal.ExternalWorkflowCompletedError
+//	if errors.As(err, &completedErr) {
+//		// target has already completed
+//	}
+//
+// NOTE: Experimental
+func GetExternalWorkflowHandleWithOptions(workflowID, runID string, options ExternalWorkflowHandleOptions) ExternalWorkflowHandle {
+	return internal.GetExternalWorkflowHandleWithOptions(workflowID, runID, options)
+}
+
+// SignalExternalWorkflowTyped sends a signal whose argument is of type A to the target execution of the handle. The
+// returned future is ready once the signal has been delivered or failed, see [ExternalWorkflowHandle] for its errors.
+// Give A explicitly so the compiler checks the argument against the type the target workflow receives:
+//
+//	err := workflow.SignalExternalWorkflowTyped[Approval](ctx, handle, "approve", Approval{By: "me"}).Get(ctx, nil)
+//
+// NOTE: Experimental
+func SignalExternalWorkflowTyped[A any](ctx Context, handle ExternalWorkflowHandle, signalName string, arg A) Future {
+	return internal.SignalExternalWorkflowTyped[A](ctx, handle, signalName, arg)
+}
+
 // GetSignalChannel returns channel corresponding to the signal name.
 func GetSignalChannel(ctx Context, signalName string) ReceiveChannel {
 	return internal.GetSignalChannel(ctx, signalName)
 }
 
+// WaitForSignal blocks until a signal with the given name is received or the timeout expires, and returns the
+// received value. Returns ok=false if timed out, and err CanceledError if the ctx is canceled. Signals whose value
+// cannot be decoded into T are dropped. The following code waits up to a day for an approval:
+//
+//	approval, ok, err := workflow.WaitForSignal[Approval](ctx, "approve", 24*time.Hour)
+//	if err != nil {
+//		return err
+//	} else if !ok {
+//		return errors.New("approval timed out")
+//	}
+func WaitForSignal[T any](ctx Context, signalName string, timeout time.Duration) (T, bool, error) {
+	return internal.WaitForSignal[T](ctx, signalName, timeout)
+}
+
 // GetSignalChannelWithOptions returns channel corresponding to the signal name.
 // Options will only apply to the first signal channel.
 //
@@ -403,6 +531,16 @@ func MutableSideEffect(ctx Context, id string, f func(ctx Context) interface{},
 	return internal.MutableSideEffect(ctx, id, f, equals)
 }
 
+// NewUUIDv7 returns a new time-ordered UUID (version 7) in its string form. Workflows must use this function instead
+// of generating UUIDs directly, which is non-deterministic. The UUID is generated with SideEffect, so it is recorded in
+// the workflow history and the same UUID is returned during replay. UUIDs returned by successive calls in a workflow
+// sort in the order they were generated.
+//
+// NOTE: Experimental
+func NewUUIDv7(ctx Context) (string, error) {
+	return internal.NewUUIDv7(ctx)
+}
+
 // DefaultVersion is a version returned by GetVersion for code that wasn't versioned before
 const DefaultVersion Version = internal.DefaultVersion
 
@@ -528,6 +666,26 @@ func SetQueryHandlerWithOptions(ctx Context, queryType string, handler interface
 	return internal.SetQueryHandlerWithOptions(ctx, queryType, handler, options)
 }
 
+// RegisterStateSnapshot registers a function describing the state of the workflow, such as its progress through a
+// long-running process. The function is called on every client.QueryTypeStateSnapshot query and its result returned
+// to the caller, so it must not block or mutate workflow state. Registering again replaces the previous function.
+// The following code exposes the progress of a batch workflow:
+//
+//	type BatchState struct {
+//		Processed int
+//		Total     int
+//	}
+//
+//	state := BatchState{Total: len(items)}
+//	if err := workflow.RegisterStateSnapshot(ctx, func() BatchState { return state }); err != nil {
+//		return err
+//	}
+//
+// NOTE: Experimental
+func RegisterStateSnapshot[T any](ctx Context, snapshot func() T) error {
+	return internal.RegisterStateSnapshot(ctx, snapshot)
+}
+
 // SetUpdateHandler forwards to SetUpdateHandlerWithOptions with an
 // zero-initialized UpdateHandlerOptions struct. See SetUpdateHandlerWithOptions
 // for more details.